@@ -18,6 +18,7 @@ package commands
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -33,6 +34,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clustercomplexity"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterflavor"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterstate"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/featuretarget"
 	"github.com/CS-SI/SafeScale/lib/utils"
 	clitools "github.com/CS-SI/SafeScale/lib/utils/cli"
 	"github.com/CS-SI/SafeScale/lib/utils/cli/enums/exitcode"
@@ -63,16 +65,23 @@ var ClusterCommand = &cli.Command{
 		clusterDeleteCommand,
 		clusterInspectCommand,
 		clusterStateCommand,
+		clusterKubeconfigCommand,
+		clusterLogCommand,
+		clusterDiagnosticsCommand,
 		clusterRunCommand,
 		// clusterSshCommand,
 		clusterStartCommand,
 		clusterStopCommand,
 		clusterExpandCommand,
 		clusterShrinkCommand,
+		clusterResizeGatewaysCommand,
+		clusterSetDomainCommand,
+		clusterRetagCommand,
 		clusterKubectlCommand,
 		clusterHelmCommand,
 		clusterListFeaturesCommand,
 		clusterCheckFeatureCommand,
+		clusterVerifyFeaturesCommand,
 		clusterAddFeatureCommand,
 		clusterRemoveFeatureCommand,
 		clusterFeatureCommands,
@@ -84,6 +93,20 @@ var clusterListCommand = &cli.Command{
 	Name:    "list",
 	Aliases: []string{"ls"},
 	Usage:   "ErrorList available clusters",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "flavor",
+			Usage: "Lists only the clusters of this flavor (BOH or K8S)",
+		},
+		&cli.StringFlag{
+			Name:  "complexity",
+			Usage: "Lists only the clusters of this complexity (Small, Normal or Large)",
+		},
+		&cli.StringFlag{
+			Name:  "state",
+			Usage: "Lists only the clusters in this state, as last known (Nominal, Degraded, Stopped, ...)",
+		},
+	},
 
 	Action: func(c *cli.Context) error {
 		logrus.Tracef("SafeScale command: %s %s with args '%s'", clusterCmdLabel, c.Command.Name, c.Args())
@@ -93,7 +116,7 @@ var clusterListCommand = &cli.Command{
 			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
 		}
 
-		list, err := clientSession.Cluster.List(temporal.DefaultExecutionTimeout)
+		list, err := clientSession.Cluster.List(c.String("flavor"), c.String("complexity"), c.String("state"), temporal.DefaultExecutionTimeout)
 		if err != nil {
 			err := fail.FromGRPCStatus(err)
 			return clitools.FailureResponse(clitools.ExitOnRPC(strprocess.Capitalize(client.DecorateTimeoutError(err, "failed to get cluster list", false).Error())))
@@ -178,6 +201,13 @@ var clusterInspectCommand = &cli.Command{
 	Usage:     "inspect CLUSTERNAME",
 	ArgsUsage: "CLUSTERNAME",
 
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "health",
+			Usage: "probes also the hosts of the cluster to report their reachability (and the one of the API server of a K8S cluster)",
+		},
+	},
+
 	Action: func(c *cli.Context) error {
 		logrus.Tracef("SafeScale command: %s %s with args '%s'", clusterCmdLabel, c.Command.Name, c.Args())
 
@@ -190,7 +220,15 @@ var clusterInspectCommand = &cli.Command{
 			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
 		}
 
-		cluster, err := clientSession.Cluster.Inspect(clusterName, temporal.GetExecutionTimeout())
+		var (
+			cluster *protocol.ClusterResponse
+			err     error
+		)
+		if c.Bool("health") {
+			cluster, err = clientSession.Cluster.InspectWithHealth(clusterName, temporal.GetExecutionTimeout())
+		} else {
+			cluster, err = clientSession.Cluster.Inspect(clusterName, temporal.GetExecutionTimeout())
+		}
 		if err != nil {
 			err = fail.FromGRPCStatus(err)
 			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.RPC, err.Error()))
@@ -297,6 +335,10 @@ func convertToMap(c *protocol.ClusterResponse) (map[string]interface{}, fail.Err
 	result["last_state"] = c.State
 	result["admin_login"] = "cladm"
 
+	if c.Health != nil {
+		result["health"] = c.Health
+	}
+
 	// Add information not directly in cluster GetConfig()
 	// TODO: replace use of !Disabled["remotedesktop"] with use of Installed["remotedesktop"] (not yet implemented)
 	found := false
@@ -378,10 +420,26 @@ var clusterCreateCommand = &cli.Command{
 			Value: "cluster.local",
 			Usage: "domain name of the hosts in the cluster (default: cluster.local)",
 		},
+		&cli.StringFlag{
+			Name:  "existing-gateway",
+			Usage: "ID or name of an existing gateway to use instead of creating gateways; the cluster is then created in the Subnet of this gateway",
+		},
+		&cli.StringFlag{
+			Name:  "metadata-bucket",
+			Usage: "Name of the bucket storing the metadata of the cluster and its resources, instead of the metadata bucket of the tenant; created if needed",
+		},
+		&cli.StringSliceFlag{
+			Name:  "tag",
+			Usage: "Tag KEY=VALUE to set on the resources of the cluster in the provider, besides the tag identifying the cluster (can be used several times)",
+		},
 		&cli.StringSliceFlag{
 			Name:  "disable",
 			Usage: "Allows to disable addition of default features (can be used several times to disable several features)",
 		},
+		&cli.StringSliceFlag{
+			Name:  "best-effort",
+			Usage: "Allows the addition of a default feature to fail without failing the cluster (can be used several times; supports remotedesktop and reverseproxy)",
+		},
 		&cli.StringFlag{
 			Name:  "os",
 			Usage: "Defines the operating system to use",
@@ -456,6 +514,10 @@ var clusterCreateCommand = &cli.Command{
 		cidr := c.String("cidr")
 		disable := c.StringSlice("disable")
 		los := c.String("os")
+		tags, err := parseLabels(c.StringSlice("tag"))
+		if err != nil {
+			return clitools.FailureResponse(clitools.ExitOnInvalidOption(err.Error()))
+		}
 
 		var (
 			globalDef   string
@@ -494,18 +556,23 @@ var clusterCreateCommand = &cli.Command{
 		}
 
 		req := protocol.ClusterCreateRequest{
-			Name:          clusterName,
-			Complexity:    protocol.ClusterComplexity(comp),
-			Flavor:        protocol.ClusterFlavor(fla),
-			KeepOnFailure: keep,
-			Cidr:          cidr,
-			Disabled:      disable,
-			Os:            los,
-			GlobalSizing:  globalDef,
-			GatewaySizing: gatewaysDef,
-			MasterSizing:  mastersDef,
-			NodeSizing:    nodesDef,
-			Force:         force,
+			Name:            clusterName,
+			Complexity:      protocol.ClusterComplexity(comp),
+			Flavor:          protocol.ClusterFlavor(fla),
+			KeepOnFailure:   keep,
+			Cidr:            cidr,
+			Disabled:        disable,
+			BestEffort:      c.StringSlice("best-effort"),
+			Os:              los,
+			GlobalSizing:    globalDef,
+			GatewaySizing:   gatewaysDef,
+			MasterSizing:    mastersDef,
+			NodeSizing:      nodesDef,
+			Domain:          c.String("domain"),
+			ExistingGateway: c.String("existing-gateway"),
+			MetadataBucket:  c.String("metadata-bucket"),
+			Tags:            tags,
+			Force:           force,
 			// NodeCount:     uint32(c.Int("initial-node-count")),
 		}
 		res, err := clientSession.Cluster.Create(&req, temporal.GetLongOperationTimeout())
@@ -668,6 +735,161 @@ var clusterStateCommand = &cli.Command{
 	},
 }
 
+// clusterKubeconfigCommand handles 'safescale cluster kubeconfig CLUSTERNAME'
+var clusterKubeconfigCommand = &cli.Command{
+	Name:      "kubeconfig",
+	Usage:     "kubeconfig CLUSTERNAME",
+	ArgsUsage: "CLUSTERNAME",
+
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "output",
+			Aliases: []string{"o"},
+			Usage:   "writes the kubeconfig in the file given instead of displaying it",
+		},
+	},
+
+	Action: func(c *cli.Context) error {
+		logrus.Tracef("SafeScale command: %s %s with args '%s'", clusterCmdLabel, c.Command.Name, c.Args())
+		err := extractClusterName(c)
+		if err != nil {
+			return clitools.FailureResponse(err)
+		}
+
+		clientSession, xerr := client.New(c.String("server"))
+		if xerr != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+		}
+
+		kubeconfig, err := clientSession.Cluster.GetKubeconfig(clusterName, temporal.GetExecutionTimeout())
+		if err != nil {
+			err = fail.FromGRPCStatus(err)
+			msg := fmt.Sprintf("failed to get cluster kubeconfig: %s", err.Error())
+			return clitools.FailureResponse(clitools.ExitOnRPC(msg))
+		}
+
+		if output := c.String("output"); output != "" {
+			if err := ioutil.WriteFile(output, []byte(kubeconfig), 0600); err != nil {
+				return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, fmt.Sprintf("failed to write kubeconfig in '%s': %s", output, err.Error())))
+			}
+			return clitools.SuccessResponse(nil)
+		}
+		return clitools.SuccessResponse(kubeconfig)
+	},
+}
+
+// clusterLogCommand handles 'safescale cluster log CLUSTERNAME PATH'
+var clusterLogCommand = &cli.Command{
+	Name:      "log",
+	Usage:     "follow a file on all the masters and nodes of the cluster, like 'tail -F' (until interrupted)",
+	ArgsUsage: "CLUSTERNAME PATH",
+
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:    "lines",
+			Aliases: []string{"n"},
+			Value:   10,
+			Usage:   "Number of existing lines displayed before following",
+		},
+	},
+
+	Action: func(c *cli.Context) error {
+		logrus.Tracef("SafeScale command: %s %s with args '%s'", clusterCmdLabel, c.Command.Name, c.Args())
+		err := extractClusterName(c)
+		if err != nil {
+			return clitools.FailureResponse(err)
+		}
+		path := c.Args().Get(1)
+		if path == "" {
+			_ = cli.ShowSubcommandHelp(c)
+			return clitools.FailureResponse(clitools.ExitOnInvalidArgument("Missing mandatory argument PATH."))
+		}
+		lines := c.Int("lines")
+		if lines < 0 {
+			return clitools.FailureResponse(clitools.ExitOnInvalidOption("Invalid value of option --lines: cannot be negative"))
+		}
+
+		clientSession, xerr := client.New(c.String("server"))
+		if xerr != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+		}
+
+		err = clientSession.Cluster.StreamLog(clusterName, path, uint(lines), func(host, line string) {
+			fmt.Printf("[%s] %s\n", host, line)
+		})
+		if err != nil {
+			err = fail.FromGRPCStatus(err)
+			msg := fmt.Sprintf("failed to follow '%s' on cluster: %s", path, err.Error())
+			return clitools.FailureResponse(clitools.ExitOnRPC(msg))
+		}
+		return nil
+	},
+}
+
+// clusterDiagnosticsCommand handles 'safescale cluster diagnostics CLUSTERNAME'
+var clusterDiagnosticsCommand = &cli.Command{
+	Name:      "diagnostics",
+	Usage:     "collect files and command outputs on all the masters and nodes of the cluster, in a tar bundle",
+	ArgsUsage: "CLUSTERNAME",
+
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  "path",
+			Usage: "Absolute path of the files to collect, shell patterns accepted (can be used several times; system and SafeScale logs if neither --path nor --command is used)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "command",
+			Usage: "Command run as root whose output is collected (can be used several times)",
+		},
+		&cli.UintFlag{
+			Name:  "max-size",
+			Value: 64,
+			Usage: "Maximum size in MiB of the collected data",
+		},
+		&cli.StringFlag{
+			Name:    "output",
+			Aliases: []string{"o"},
+			Usage:   "File where the bundle is written (default: <cluster_name>-diagnostics-<date>.tar)",
+		},
+	},
+
+	Action: func(c *cli.Context) error {
+		logrus.Tracef("SafeScale command: %s %s with args '%s'", clusterCmdLabel, c.Command.Name, c.Args())
+		err := extractClusterName(c)
+		if err != nil {
+			return clitools.FailureResponse(err)
+		}
+		if c.Uint("max-size") == 0 {
+			return clitools.FailureResponse(clitools.ExitOnInvalidOption("Invalid value of option --max-size: cannot be 0"))
+		}
+		output := c.String("output")
+		if output == "" {
+			output = fmt.Sprintf("%s-diagnostics-%s.tar", clusterName, time.Now().Format("20060102-150405"))
+		}
+
+		clientSession, xerr := client.New(c.String("server"))
+		if xerr != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+		}
+
+		file, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, fmt.Sprintf("failed to create '%s': %s", output, err.Error())))
+		}
+		err = clientSession.Cluster.CollectDiagnostics(clusterName, c.StringSlice("path"), c.StringSlice("command"), uint64(c.Uint("max-size"))*1024*1024, file)
+		if cerr := file.Close(); err == nil && cerr != nil {
+			err = cerr
+		}
+		if err != nil {
+			_ = os.Remove(output)
+			err = fail.FromGRPCStatus(err)
+			msg := fmt.Sprintf("failed to collect diagnostics of cluster: %s", err.Error())
+			return clitools.FailureResponse(clitools.ExitOnRPC(msg))
+		}
+		return clitools.SuccessResponse(map[string]string{"file": output})
+	},
+}
+
 // clusterExpandCmd handles 'deploy cluster <clustername> expand'
 var clusterExpandCommand = &cli.Command{
 	Name:      "expand",
@@ -692,6 +914,14 @@ var clusterExpandCommand = &cli.Command{
 	<operator> can be =,<,> (except for disk where valid operators are only = or >)
 	<value> can be an integer (for cpu and disk) or a float (for ram) or an including interval "[<lower value>-<upper value>]"`,
 		},
+		&cli.StringFlag{
+			Name:  "node-pool",
+			Usage: "Define the node pool where to add the nodes; nodes of a pool are created in a Subnet dedicated to the pool",
+		},
+		&cli.StringFlag{
+			Name:  "node-pool-cidr",
+			Usage: "Define the CIDR of the Subnet to create for the node pool, if it does not exist yet",
+		},
 	},
 	Action: func(c *cli.Context) error {
 		logrus.Tracef("SafeScale command: %s %s with args '%s'", clusterCmdLabel, c.Command.Name, c.Args())
@@ -719,10 +949,12 @@ var clusterExpandCommand = &cli.Command{
 		}
 
 		req := protocol.ClusterResizeRequest{
-			Name:       clusterName,
-			Count:      int32(count),
-			NodeSizing: nodesDef,
-			ImageId:    los,
+			Name:         clusterName,
+			Count:        int32(count),
+			NodeSizing:   nodesDef,
+			ImageId:      los,
+			NodePool:     c.String("node-pool"),
+			NodePoolCidr: c.String("node-pool-cidr"),
 		}
 
 		clientSession, xerr := client.New(c.String("server"))
@@ -739,6 +971,120 @@ var clusterExpandCommand = &cli.Command{
 	},
 }
 
+// clusterResizeGatewaysCommand handles 'safescale cluster resize-gateways CLUSTERNAME'
+var clusterResizeGatewaysCommand = &cli.Command{
+	Name:      "resize-gateways",
+	Usage:     "resize-gateways CLUSTERNAME",
+	ArgsUsage: "CLUSTERNAME",
+
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name: "gateway-sizing",
+			Usage: `Describe gateway sizing in format "<component><operator><value>[,...]" (same syntax as --node-sizing of create);
+	must meet the minimum sizing of gateways of the flavor of the Cluster`,
+		},
+	},
+
+	Action: func(c *cli.Context) error {
+		logrus.Tracef("SafeScale command: %s %s with args '%s'", clusterCmdLabel, c.Command.Name, c.Args())
+		err := extractClusterName(c)
+		if err != nil {
+			return clitools.FailureResponse(err)
+		}
+
+		sizing := c.String("gateway-sizing")
+		if sizing == "" {
+			return clitools.FailureResponse(clitools.ExitOnInvalidOption("Missing mandatory option --gateway-sizing"))
+		}
+
+		clientSession, xerr := client.New(c.String("server"))
+		if xerr != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+		}
+
+		req := protocol.ClusterGatewayResizeRequest{
+			Name:          clusterName,
+			GatewaySizing: sizing,
+		}
+		if err = clientSession.Cluster.ResizeGateways(&req, temporal.GetLongOperationTimeout()); err != nil {
+			err = fail.FromGRPCStatus(err)
+			return clitools.FailureResponse(clitools.ExitOnRPC(err.Error()))
+		}
+		return clitools.SuccessResponse(nil)
+	},
+}
+
+// clusterSetDomainCommand handles 'safescale cluster set-domain CLUSTERNAME DOMAIN'
+var clusterSetDomainCommand = &cli.Command{
+	Name:      "set-domain",
+	Usage:     "set-domain CLUSTERNAME DOMAIN",
+	ArgsUsage: "CLUSTERNAME DOMAIN",
+
+	Action: func(c *cli.Context) error {
+		logrus.Tracef("SafeScale command: %s %s with args '%s'", clusterCmdLabel, c.Command.Name, c.Args())
+		err := extractClusterName(c)
+		if err != nil {
+			return clitools.FailureResponse(err)
+		}
+
+		domain := c.Args().Get(1)
+		if domain == "" {
+			_ = cli.ShowSubcommandHelp(c)
+			return clitools.FailureResponse(clitools.ExitOnInvalidArgument("Missing mandatory argument DOMAIN."))
+		}
+
+		clientSession, xerr := client.New(c.String("server"))
+		if xerr != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+		}
+
+		req := protocol.ClusterDomainRequest{
+			Name:   clusterName,
+			Domain: domain,
+		}
+		if err = clientSession.Cluster.SetDomain(&req, temporal.GetLongOperationTimeout()); err != nil {
+			err = fail.FromGRPCStatus(err)
+			return clitools.FailureResponse(clitools.ExitOnRPC(err.Error()))
+		}
+		return clitools.SuccessResponse(nil)
+	},
+}
+
+// clusterRetagCommand handles 'safescale cluster retag CLUSTERNAME [KEY=VALUE...]'
+var clusterRetagCommand = &cli.Command{
+	Name:      "retag",
+	Usage:     "retag CLUSTERNAME [KEY=VALUE...]",
+	ArgsUsage: "CLUSTERNAME [KEY=VALUE...]",
+
+	Action: func(c *cli.Context) error {
+		logrus.Tracef("SafeScale command: %s %s with args '%s'", clusterCmdLabel, c.Command.Name, c.Args())
+		err := extractClusterName(c)
+		if err != nil {
+			return clitools.FailureResponse(err)
+		}
+
+		tags, err := parseLabels(c.Args().Slice()[1:])
+		if err != nil {
+			return clitools.FailureResponse(clitools.ExitOnInvalidArgument(err.Error()))
+		}
+
+		clientSession, xerr := client.New(c.String("server"))
+		if xerr != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+		}
+
+		req := protocol.ClusterTagsRequest{
+			Name: clusterName,
+			Tags: tags,
+		}
+		if err = clientSession.Cluster.RetagResources(&req, temporal.GetLongOperationTimeout()); err != nil {
+			err = fail.FromGRPCStatus(err)
+			return clitools.FailureResponse(clitools.ExitOnRPC(err.Error()))
+		}
+		return clitools.SuccessResponse(nil)
+	},
+}
+
 // clusterShrinkCommand handles 'deploy cluster <clustername> shrink'
 var clusterShrinkCommand = &cli.Command{
 	Name:      "shrink",
@@ -757,6 +1103,18 @@ var clusterShrinkCommand = &cli.Command{
 			Aliases: []string{"yes", "y"},
 			Usage:   "Don't ask deletion confirmation",
 		},
+		&cli.BoolFlag{
+			Name:  "allow-below-minimum",
+			Usage: "Allows to leave less nodes than the minimum required by the flavor of the Cluster",
+		},
+		&cli.BoolFlag{
+			Name:  "skip-drain",
+			Usage: "Removes the nodes without draining their workloads first (K8S flavor only)",
+		},
+		&cli.UintFlag{
+			Name:  "drain-timeout",
+			Usage: "Maximum number of seconds to drain each node; a node not drained in time is kept (default: the long operation timeout)",
+		},
 	},
 
 	Action: func(c *cli.Context) error {
@@ -782,8 +1140,11 @@ var clusterShrinkCommand = &cli.Command{
 		}
 
 		req := protocol.ClusterResizeRequest{
-			Name:  clusterName,
-			Count: int32(count),
+			Name:              clusterName,
+			Count:             int32(count),
+			AllowBelowMinimum: c.Bool("allow-below-minimum"),
+			SkipDrain:         c.Bool("skip-drain"),
+			DrainTimeout:      uint32(c.Uint("drain-timeout")),
 		}
 
 		clientSession, xerr := client.New(c.String("server"))
@@ -1079,6 +1440,14 @@ var clusterAddFeatureCommand = &cli.Command{
 			Name:  "skip-proxy",
 			Usage: "Disables reverse proxy rules",
 		},
+		&cli.StringFlag{
+			Name:  "target",
+			Usage: "Selects the hosts of the cluster on which the feature is installed, instead of the ones defined by the feature (can be gateways, masters, nodes, allhosts or singlemaster)",
+		},
+		&cli.UintFlag{
+			Name:  "timeout",
+			Usage: "Limits the duration of the installation, in minutes (default: the timeout defined by the feature)",
+		},
 	},
 
 	Action: clusterFeatureAddAction,
@@ -1100,6 +1469,50 @@ var clusterCheckFeatureCommand = &cli.Command{
 	Action: clusterFeatureCheckAction,
 }
 
+// clusterVerifyFeaturesCommand handles 'safescale cluster verify-features CLUSTERNAME'
+var clusterVerifyFeaturesCommand = &cli.Command{
+	Name:      "verify-features",
+	Aliases:   []string{"check-features"},
+	Usage:     "Checks the features installed on the cluster and reports their health on each host; fails if one is not healthy",
+	ArgsUsage: "CLUSTERNAME",
+	Action: func(c *cli.Context) error {
+		logrus.Tracef("SafeScale command: %s %s with args '%s'", clusterCmdLabel, c.Command.Name, c.Args())
+		if err := extractClusterName(c); err != nil {
+			return clitools.FailureResponse(err)
+		}
+
+		clientSession, xerr := client.New(c.String("server"))
+		if xerr != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+		}
+
+		report, err := clientSession.Cluster.VerifyFeatures(clusterName, temporal.GetExecutionTimeout())
+		if err != nil {
+			err = fail.FromGRPCStatus(err)
+			msg := fmt.Sprintf("error verifying features of Cluster '%s': %s", clusterName, err.Error())
+			return clitools.FailureResponse(clitools.ExitOnRPC(msg))
+		}
+		if !report.GetSuccessful() {
+			var unhealthy []string
+			for _, f := range report.GetFeatures() {
+				if !f.GetSuccessful() && f.GetError() != "" {
+					unhealthy = append(unhealthy, fmt.Sprintf("%s: %s", f.GetName(), f.GetError()))
+				}
+			}
+			for _, h := range report.GetHosts() {
+				for _, f := range h.GetFeatures() {
+					if !f.GetSuccessful() {
+						unhealthy = append(unhealthy, fmt.Sprintf("%s on %s: %s", f.GetName(), h.GetHost(), f.GetError()))
+					}
+				}
+			}
+			msg := fmt.Sprintf("features of Cluster '%s' not healthy: %s", clusterName, strings.Join(unhealthy, "; "))
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, msg))
+		}
+		return clitools.SuccessResponse(report)
+	},
+}
+
 // clusterRemoveFeatureCommand handles 'deploy host <host name or id> package <pkgname> delete'
 var clusterRemoveFeatureCommand = &cli.Command{
 	Name:      "remove-feature",
@@ -1126,6 +1539,9 @@ var clusterNodeCommands = &cli.Command{
 
 	Subcommands: []*cli.Command{
 		clusterNodeListCommand,
+		clusterNodeLabelCommand,
+		clusterNodesStartCommand,
+		clusterNodesStopCommand,
 		// clusterNodeInspectCommand,
 		// clusterNodeStartCommand,
 		// clusterNodeStopCommand,
@@ -1170,6 +1586,125 @@ var clusterNodeListCommand = &cli.Command{
 	},
 }
 
+// clusterNodeLabelCommand handles 'safescale cluster node label CLUSTERNAME NODENAME KEY=VALUE...'
+var clusterNodeLabelCommand = &cli.Command{
+	Name:      "label",
+	Usage:     "Sets labels on a node of a cluster (an empty value removes the label)",
+	ArgsUsage: "CLUSTERNAME NODENAME KEY=VALUE [KEY=VALUE...]",
+
+	Action: func(c *cli.Context) error {
+		logrus.Tracef("SafeScale command: %s %s %s with args '%s'", clusterCmdLabel, clusterNodeCmdLabel, c.Command.Name, c.Args())
+		err := extractClusterName(c)
+		if err != nil {
+			return clitools.FailureResponse(err)
+		}
+		if c.NArg() < 3 {
+			_ = cli.ShowSubcommandHelp(c)
+			return clitools.FailureResponse(clitools.ExitOnInvalidArgument("Missing mandatory arguments NODENAME and KEY=VALUE."))
+		}
+
+		labels, err := parseLabels(c.Args().Slice()[2:])
+		if err != nil {
+			return clitools.FailureResponse(clitools.ExitOnInvalidArgument(err.Error()))
+		}
+
+		clientSession, xerr := client.New(c.String("server"))
+		if xerr != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+		}
+
+		err = clientSession.Cluster.LabelNode(clusterName, c.Args().Get(1), labels, temporal.GetExecutionTimeout())
+		if err != nil {
+			err = fail.FromGRPCStatus(err)
+			return clitools.FailureResponse(clitools.ExitOnRPC(err.Error()))
+		}
+		return clitools.SuccessResponse(nil)
+	},
+}
+
+// clusterNodesStartCommand handles 'safescale cluster node start --selector KEY=VALUE CLUSTERNAME'
+var clusterNodesStartCommand = &cli.Command{
+	Name:      "start",
+	Usage:     "Starts the nodes of a cluster matching a label selector",
+	ArgsUsage: "CLUSTERNAME",
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:     "selector",
+			Aliases:  []string{"l"},
+			Usage:    "KEY=VALUE label a node must have to be started (may be used several times, all must match)",
+			Required: true,
+		},
+	},
+
+	Action: func(c *cli.Context) error {
+		return clusterNodesChangeStateAction(c, "start")
+	},
+}
+
+// clusterNodesStopCommand handles 'safescale cluster node stop --selector KEY=VALUE CLUSTERNAME'
+var clusterNodesStopCommand = &cli.Command{
+	Name:      "stop",
+	Usage:     "Stops the nodes of a cluster matching a label selector (masters and gateways are never stopped)",
+	ArgsUsage: "CLUSTERNAME",
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:     "selector",
+			Aliases:  []string{"l"},
+			Usage:    "KEY=VALUE label a node must have to be stopped (may be used several times, all must match)",
+			Required: true,
+		},
+	},
+
+	Action: func(c *cli.Context) error {
+		return clusterNodesChangeStateAction(c, "stop")
+	},
+}
+
+// clusterNodesChangeStateAction is the action of 'safescale cluster node start' and 'safescale cluster node stop'
+func clusterNodesChangeStateAction(c *cli.Context, action string) error {
+	logrus.Tracef("SafeScale command: %s %s %s with args '%s'", clusterCmdLabel, clusterNodeCmdLabel, c.Command.Name, c.Args())
+	err := extractClusterName(c)
+	if err != nil {
+		return clitools.FailureResponse(err)
+	}
+
+	selector, err := parseLabels(c.StringSlice("selector"))
+	if err != nil {
+		return clitools.FailureResponse(clitools.ExitOnInvalidOption(err.Error()))
+	}
+
+	clientSession, xerr := client.New(c.String("server"))
+	if xerr != nil {
+		return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+	}
+
+	var names []string
+	if action == "stop" {
+		names, err = clientSession.Cluster.StopNodes(clusterName, selector, temporal.GetExecutionTimeout())
+	} else {
+		names, err = clientSession.Cluster.StartNodes(clusterName, selector, temporal.GetExecutionTimeout())
+	}
+	if err != nil {
+		err = fail.FromGRPCStatus(err)
+		return clitools.FailureResponse(clitools.ExitOnRPC(strprocess.Capitalize(client.DecorateTimeoutError(err, action+" of cluster nodes", false).Error())))
+	}
+	return clitools.SuccessResponse(names)
+}
+
+// parseLabels converts a list of KEY=VALUE strings to a map
+func parseLabels(items []string) (map[string]string, error) {
+	labels := make(map[string]string, len(items))
+	for _, v := range items {
+		parts := strings.SplitN(v, "=", 2)
+		key := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || key == "" {
+			return nil, fmt.Errorf("invalid label '%s', expected KEY=VALUE", v)
+		}
+		labels[key] = strings.TrimSpace(parts[1])
+	}
+	return labels, nil
+}
+
 // // clusterNodeInspectCmd handles 'deploy cluster <clustername> inspect'
 // var clusterNodeInspectCommand = &cli.Command{
 // 	Name:      "inspect",
@@ -1440,6 +1975,14 @@ var clusterFeatureAddCommand = &cli.Command{
 			Name:  "skip-proxy",
 			Usage: "Disables reverse proxy rules",
 		},
+		&cli.StringFlag{
+			Name:  "target",
+			Usage: "Selects the hosts of the cluster on which the feature is installed, instead of the ones defined by the feature (can be gateways, masters, nodes, allhosts or singlemaster)",
+		},
+		&cli.UintFlag{
+			Name:  "timeout",
+			Usage: "Limits the duration of the installation, in minutes (default: the timeout defined by the feature)",
+		},
 	},
 
 	Action: clusterFeatureAddAction,
@@ -1466,6 +2009,13 @@ func clusterFeatureAddAction(c *cli.Context) error {
 
 	settings := protocol.FeatureSettings{}
 	settings.SkipProxy = c.Bool("skip-proxy")
+	settings.Timeout = uint32(c.Uint("timeout"))
+	if target := c.String("target"); target != "" {
+		if _, err := featuretarget.Parse(target); err != nil {
+			return clitools.FailureResponse(clitools.ExitOnInvalidOption(err.Error()))
+		}
+		settings.Target = target
+	}
 
 	clientSession, xerr := client.New(c.String("server"))
 	if xerr != nil {
@@ -19,7 +19,9 @@ package commands
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
@@ -44,7 +46,13 @@ var HostCommand = &cli.Command{
 	Subcommands: []*cli.Command{
 		hostList,
 		hostCreate,
+		hostImport,
 		//		hostResize,
+		hostAttachSubnet,
+		hostDetachSubnet,
+		hostRunPhase,
+		hostRotateKeypair,
+		hostCreateImage,
 		hostDelete,
 		hostInspect,
 		hostStatus,
@@ -52,6 +60,8 @@ var HostCommand = &cli.Command{
 		hostReboot,
 		hostStart,
 		hostStop,
+		hostWait,
+		hostLog,
 		hostCheckFeatureCommand,  // Legacy, will be deprecated
 		hostAddFeatureCommand,    // Legacy, will be deprecated
 		hostRemoveFeatureCommand, // Legacy, will be deprecated
@@ -91,6 +101,13 @@ var hostStop = &cli.Command{
 	Name:      "stop",
 	Usage:     "stop Host",
 	ArgsUsage: "<Host_name|Host_ID>",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:  "grace-period",
+			Value: 0,
+			Usage: "Tries first to shut down the Host from its operating system, waiting at most the number of seconds given before stopping it with the provider",
+		},
+	},
 	Action: func(c *cli.Context) error {
 		logrus.Tracef("SafeScale command: %s %s with args '%s'", hostCmdLabel, c.Command.Name, c.Args())
 		if c.NArg() != 1 {
@@ -103,13 +120,112 @@ var hostStop = &cli.Command{
 			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
 		}
 
+		gracePeriod := c.Int("grace-period")
+		if gracePeriod < 0 {
+			return clitools.FailureResponse(clitools.ExitOnInvalidOption("Invalid value of option --grace-period: cannot be negative"))
+		}
+
 		hostRef := c.Args().First()
-		err := clientSession.Host.Stop(hostRef, temporal.GetExecutionTimeout())
+		if gracePeriod == 0 {
+			err := clientSession.Host.Stop(hostRef, temporal.GetExecutionTimeout())
+			if err != nil {
+				err = fail.FromGRPCStatus(err)
+				return clitools.FailureResponse(clitools.ExitOnRPC(strprocess.Capitalize(client.DecorateTimeoutError(err, "stop of host", false).Error())))
+			}
+			return clitools.SuccessResponse(nil)
+		}
+
+		method, err := clientSession.Host.StopGracefully(hostRef, time.Duration(gracePeriod)*time.Second, temporal.GetExecutionTimeout())
 		if err != nil {
 			err = fail.FromGRPCStatus(err)
 			return clitools.FailureResponse(clitools.ExitOnRPC(strprocess.Capitalize(client.DecorateTimeoutError(err, "stop of host", false).Error())))
 		}
-		return clitools.SuccessResponse(nil)
+		return clitools.SuccessResponse(map[string]string{"method": method})
+	},
+}
+
+var hostWait = &cli.Command{
+	Name:      "wait",
+	Usage:     "wait for Host to reach a state",
+	ArgsUsage: "<Host_name|Host_ID>",
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  "state",
+			Usage: "State to wait for (can be repeated, the wait ends on the first state reached)",
+		},
+		&cli.IntFlag{
+			Name:  "timeout",
+			Value: 0,
+			Usage: "Maximum number of seconds to wait; 0 uses the default timeout of state change",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		logrus.Tracef("SafeScale command: %s %s with args '%s'", hostCmdLabel, c.Command.Name, c.Args())
+		if c.NArg() != 1 {
+			_ = cli.ShowSubcommandHelp(c)
+			return clitools.FailureResponse(clitools.ExitOnInvalidArgument("Missing mandatory argument <Host_name>."))
+		}
+
+		states := c.StringSlice("state")
+		if len(states) == 0 {
+			return clitools.FailureResponse(clitools.ExitOnInvalidOption("Missing mandatory option --state"))
+		}
+		waitTimeout := c.Int("timeout")
+		if waitTimeout < 0 {
+			return clitools.FailureResponse(clitools.ExitOnInvalidOption("Invalid value of option --timeout: cannot be negative"))
+		}
+
+		clientSession, xerr := client.New(c.String("server"))
+		if xerr != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+		}
+
+		hostRef := c.Args().First()
+		resp, err := clientSession.Host.WaitState(hostRef, states, time.Duration(waitTimeout)*time.Second, temporal.GetExecutionTimeout())
+		if err != nil {
+			err = fail.FromGRPCStatus(err)
+			return clitools.FailureResponse(clitools.ExitOnRPC(strprocess.Capitalize(client.DecorateTimeoutError(err, "wait of host state", false).Error())))
+		}
+		return clitools.SuccessResponse(resp)
+	},
+}
+
+var hostLog = &cli.Command{
+	Name:      "log",
+	Usage:     "follow a file on Host, like 'tail -F' (until interrupted)",
+	ArgsUsage: "<Host_name|Host_ID> <path>",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:    "lines",
+			Aliases: []string{"n"},
+			Value:   10,
+			Usage:   "Number of existing lines displayed before following",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		logrus.Tracef("SafeScale command: %s %s with args '%s'", hostCmdLabel, c.Command.Name, c.Args())
+		if c.NArg() != 2 {
+			_ = cli.ShowSubcommandHelp(c)
+			return clitools.FailureResponse(clitools.ExitOnInvalidArgument("Missing mandatory argument <Host_name> or <path>."))
+		}
+		lines := c.Int("lines")
+		if lines < 0 {
+			return clitools.FailureResponse(clitools.ExitOnInvalidOption("Invalid value of option --lines: cannot be negative"))
+		}
+
+		clientSession, xerr := client.New(c.String("server"))
+		if xerr != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+		}
+
+		err := clientSession.Host.StreamLog(c.Args().Get(0), c.Args().Get(1), uint(lines), func(_, line string) {
+			fmt.Println(line)
+		})
+		if err != nil {
+			err = fail.FromGRPCStatus(err)
+			return clitools.FailureResponse(clitools.ExitOnRPC(strprocess.Capitalize(client.DecorateTimeoutError(err, "log of host", false).Error())))
+		}
+		return nil
 	},
 }
 
@@ -295,6 +411,57 @@ May be used multiple times, the first occurrence becoming the default subnet by
 				--sizing "cpu ~ 4, ram = [14-32]" (is identical to --sizing "cpu=[4-8], ram=[14-32]")
 				--sizing "cpu <= 8, ram ~ 16"`,
 		},
+		&cli.StringFlag{
+			Name:  "tenancy",
+			Value: "default",
+			Usage: "kind of physical host to place the host on: default, dedicated or host (the latter allowing to choose the dedicated host with --dedicated-host)",
+		},
+		&cli.StringFlag{
+			Name:  "dedicated-host",
+			Usage: "ID of the dedicated host to place the host on (requires --tenancy host)",
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "only resolves the template, image, subnets and security groups the host would use, and reports the problems preventing its creation",
+		},
+		&cli.BoolFlag{
+			Name:  "sync-clock",
+			Usage: "forces a NTP synchronization of the clock of the host if it is skewed compared to the daemon (threshold set by SAFESCALE_CLOCK_SKEW_THRESHOLD)",
+		},
+		&cli.StringSliceFlag{
+			Name: "interface",
+			Usage: `adds a network interface to the host, in format "<subnet>[=<ip address>]" where:
+			<subnet> is the name or id of the subnet the interface is connected to
+			<ip address> is the fixed private IP address of the interface (allocated by the provider if not set)
+			May be used multiple times`,
+		},
+		&cli.StringFlag{
+			Name:  "access-preference",
+			Value: "default",
+			Usage: "IP address used to access the host: default (preference of the tenant, set by option PreferPrivateAccessIP), public or private",
+		},
+		&cli.StringSliceFlag{
+			Name:  "security-group",
+			Usage: "name or id of a security group to bind to the host; may be used multiple times",
+		},
+		&cli.BoolFlag{
+			Name: "skip-default-security-groups",
+			Usage: `binds only the security groups given with --security-group, without the ones of the subnets (gateway, public IP and internal security groups)
+			Warning: the firewalling of the host is then entirely up to these security groups; they must at least allow SSH from the gateway
+			(or from the daemon for a host with public IP) for the host to be provisioned`,
+		},
+		&cli.BoolFlag{
+			Name:  "preserve-public-ip",
+			Usage: "Keeps the public IP of the host across stop and start, making it static if the provider releases it when the host is stopped",
+		},
+		&cli.StringSliceFlag{
+			Name:  "guest-label",
+			Usage: "KEY=VALUE label written in /etc/safescale/labels on the host and exported as environment variable SAFESCALE_LABEL_KEY; may be used several times",
+		},
+		&cli.StringSliceFlag{
+			Name:  "install-method",
+			Usage: "method to install features on the host (apt, yum, dnf, bash); may be used several times, by order of preference (default: preference of the tenant, set by option InstallMethods, or detected from the OS)",
+		},
 	},
 	Action: func(c *cli.Context) error {
 		logrus.Tracef("SafeScale command: %s %s with args '%v", hostCmdLabel, c.Command.Name, c.Args())
@@ -313,16 +480,58 @@ May be used multiple times, the first occurrence becoming the default subnet by
 			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
 		}
 
+		var interfaces []*protocol.HostInterfaceDefinition
+		for _, v := range c.StringSlice("interface") {
+			parts := strings.SplitN(v, "=", 2)
+			if strings.TrimSpace(parts[0]) == "" {
+				return clitools.FailureResponse(clitools.ExitOnInvalidOption(fmt.Sprintf("Invalid value '%s' of option --interface: missing subnet", v)))
+			}
+			item := &protocol.HostInterfaceDefinition{Subnet: strings.TrimSpace(parts[0])}
+			if len(parts) == 2 {
+				item.IpAddress = strings.TrimSpace(parts[1])
+			}
+			interfaces = append(interfaces, item)
+		}
+
+		guestLabels, err := parseLabels(c.StringSlice("guest-label"))
+		if err != nil {
+			return clitools.FailureResponse(clitools.ExitOnInvalidOption(err.Error()))
+		}
+
 		req := protocol.HostDefinition{
-			Name:           c.Args().First(),
-			ImageId:        c.String("os"),
-			Network:        c.String("network"),
-			Subnets:        c.StringSlice("subnet"),
-			Single:         c.Bool("single"),
-			Force:          c.Bool("force"),
-			SizingAsString: sizing,
-			KeepOnFailure:  c.Bool("keep-on-failure"),
+			Name:            c.Args().First(),
+			ImageId:         c.String("os"),
+			Network:         c.String("network"),
+			Subnets:         c.StringSlice("subnet"),
+			Single:          c.Bool("single"),
+			Force:           c.Bool("force"),
+			SizingAsString:  sizing,
+			KeepOnFailure:   c.Bool("keep-on-failure"),
+			Tenancy:         c.String("tenancy"),
+			DedicatedHostId: c.String("dedicated-host"),
+			SyncClock:       c.Bool("sync-clock"),
+
+			AdditionalInterfaces:      interfaces,
+			AccessPreference:          c.String("access-preference"),
+			SecurityGroups:            c.StringSlice("security-group"),
+			InstallMethods:            c.StringSlice("install-method"),
+			GuestLabels:               guestLabels,
+			PreservePublicIp:          c.Bool("preserve-public-ip"),
+			SkipDefaultSecurityGroups: c.Bool("skip-default-security-groups"),
+		}
+		if c.Bool("dry-run") {
+			check, err := clientSession.Host.CheckCreate(&req, temporal.GetExecutionTimeout())
+			if err != nil {
+				err = fail.FromGRPCStatus(err)
+				return clitools.FailureResponse(clitools.ExitOnRPC(strprocess.Capitalize(client.DecorateTimeoutError(err, "check of host creation", true).Error())))
+			}
+			if len(check.GetProblems()) > 0 {
+				msg := fmt.Sprintf("host '%s' cannot be created: %s", req.Name, strings.Join(check.GetProblems(), "; "))
+				return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.InvalidArgument, msg))
+			}
+			return clitools.SuccessResponse(check)
 		}
+
 		resp, err := clientSession.Host.Create(&req, temporal.GetExecutionTimeout())
 		if err != nil {
 			err = fail.FromGRPCStatus(err)
@@ -332,6 +541,65 @@ May be used multiple times, the first occurrence becoming the default subnet by
 	},
 }
 
+var hostImport = &cli.Command{
+	Name:      "import",
+	Usage:     "bring under management a host created outside SafeScale",
+	ArgsUsage: "<provider_host_ID>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "network",
+			Aliases: []string{"net"},
+			Value:   "",
+			Usage:   "network name or network id of the subnet",
+		},
+		&cli.StringFlag{
+			Name:  "subnet",
+			Value: "",
+			Usage: "subnet name or id the host is attached to (becomes its default subnet)",
+		},
+		&cli.StringFlag{
+			Name:  "private-key",
+			Value: "",
+			Usage: "file containing the private key to access the host with the operator user",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		logrus.Tracef("SafeScale command: %s %s with args '%s'", hostCmdLabel, c.Command.Name, c.Args())
+		if c.NArg() != 1 {
+			_ = cli.ShowSubcommandHelp(c)
+			return clitools.FailureResponse(clitools.ExitOnInvalidArgument("Missing mandatory argument <provider_host_ID>."))
+		}
+		if c.String("subnet") == "" {
+			return clitools.FailureResponse(clitools.ExitOnInvalidOption("Missing mandatory option --subnet"))
+		}
+
+		req := protocol.HostImportRequest{
+			ProviderHostId: c.Args().First(),
+			Network:        c.String("network"),
+			Subnet:         c.String("subnet"),
+		}
+		if keyFile := c.String("private-key"); keyFile != "" {
+			content, err := ioutil.ReadFile(keyFile)
+			if err != nil {
+				return clitools.FailureResponse(clitools.ExitOnInvalidOption(fmt.Sprintf("Invalid value of option --private-key: %s", err.Error())))
+			}
+			req.PrivateKey = string(content)
+		}
+
+		clientSession, xerr := client.New(c.String("server"))
+		if xerr != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+		}
+
+		resp, err := clientSession.Host.Import(&req, temporal.GetExecutionTimeout())
+		if err != nil {
+			err = fail.FromGRPCStatus(err)
+			return clitools.FailureResponse(clitools.ExitOnRPC(strprocess.Capitalize(client.DecorateTimeoutError(err, "import of host", true).Error())))
+		}
+		return clitools.SuccessResponse(resp)
+	},
+}
+
 var hostResize = &cli.Command{
 	Name:      "resize",
 	Aliases:   []string{"upgrade"},
@@ -398,11 +666,177 @@ var hostResize = &cli.Command{
 	},
 }
 
+var hostAttachSubnet = &cli.Command{
+	Name:      "attach-subnet",
+	Usage:     "connects a host to an additional subnet",
+	ArgsUsage: "<Host_name_or_id> <Subnet_name_or_id>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "network",
+			Aliases: []string{"net"},
+			Value:   "",
+			Usage:   "network name or network id of the subnet",
+		},
+		&cli.BoolFlag{
+			Name:  "default",
+			Usage: "makes the subnet the default subnet of the host",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		logrus.Tracef("SafeScale command: %s %s with args '%s'", hostCmdLabel, c.Command.Name, c.Args())
+		if c.NArg() != 2 {
+			_ = cli.ShowSubcommandHelp(c)
+			return clitools.FailureResponse(clitools.ExitOnInvalidArgument("Missing mandatory arguments <Host_name_or_id> and <Subnet_name_or_id>."))
+		}
+
+		clientSession, xerr := client.New(c.String("server"))
+		if xerr != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+		}
+
+		req := protocol.HostSubnetRequest{
+			Host:    &protocol.Reference{Name: c.Args().First()},
+			Network: c.String("network"),
+			Subnet:  c.Args().Get(1),
+			Default: c.Bool("default"),
+		}
+		if err := clientSession.Host.AttachToSubnet(&req, temporal.GetExecutionTimeout()); err != nil {
+			err = fail.FromGRPCStatus(err)
+			return clitools.FailureResponse(clitools.ExitOnRPC(strprocess.Capitalize(client.DecorateTimeoutError(err, "attachment of host to subnet", false).Error())))
+		}
+		return clitools.SuccessResponse(nil)
+	},
+}
+
+var hostDetachSubnet = &cli.Command{
+	Name:      "detach-subnet",
+	Usage:     "disconnects a host from a subnet that is not its default one",
+	ArgsUsage: "<Host_name_or_id> <Subnet_name_or_id>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "network",
+			Aliases: []string{"net"},
+			Value:   "",
+			Usage:   "network name or network id of the subnet",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		logrus.Tracef("SafeScale command: %s %s with args '%s'", hostCmdLabel, c.Command.Name, c.Args())
+		if c.NArg() != 2 {
+			_ = cli.ShowSubcommandHelp(c)
+			return clitools.FailureResponse(clitools.ExitOnInvalidArgument("Missing mandatory arguments <Host_name_or_id> and <Subnet_name_or_id>."))
+		}
+
+		clientSession, xerr := client.New(c.String("server"))
+		if xerr != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+		}
+
+		req := protocol.HostSubnetRequest{
+			Host:    &protocol.Reference{Name: c.Args().First()},
+			Network: c.String("network"),
+			Subnet:  c.Args().Get(1),
+		}
+		if err := clientSession.Host.DetachFromSubnet(&req, temporal.GetExecutionTimeout()); err != nil {
+			err = fail.FromGRPCStatus(err)
+			return clitools.FailureResponse(clitools.ExitOnRPC(strprocess.Capitalize(client.DecorateTimeoutError(err, "detachment of host from subnet", false).Error())))
+		}
+		return clitools.SuccessResponse(nil)
+	},
+}
+
+var hostRunPhase = &cli.Command{
+	Name:      "run-phase",
+	Usage:     "runs again an install phase on a host (typically after a failure of provisioning of a host created with --keep-on-failure)",
+	ArgsUsage: "<Host_name_or_id> <netsec|gwha|sysfix|final>",
+	Action: func(c *cli.Context) error {
+		logrus.Tracef("SafeScale command: %s %s with args '%s'", hostCmdLabel, c.Command.Name, c.Args())
+		if c.NArg() != 2 {
+			_ = cli.ShowSubcommandHelp(c)
+			return clitools.FailureResponse(clitools.ExitOnInvalidArgument("Missing mandatory arguments <Host_name_or_id> and <phase>."))
+		}
+
+		clientSession, xerr := client.New(c.String("server"))
+		if xerr != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+		}
+
+		req := protocol.HostPhaseRequest{
+			Host:  &protocol.Reference{Name: c.Args().First()},
+			Phase: c.Args().Get(1),
+		}
+		if err := clientSession.Host.RunPhase(&req, temporal.GetHostCreationTimeout()); err != nil {
+			err = fail.FromGRPCStatus(err)
+			return clitools.FailureResponse(clitools.ExitOnRPC(strprocess.Capitalize(client.DecorateTimeoutError(err, "run of install phase", false).Error())))
+		}
+		return clitools.SuccessResponse(nil)
+	},
+}
+
+var hostCreateImage = &cli.Command{
+	Name:      "create-image",
+	Usage:     "creates an image from the disk of a Host; a started Host is stopped during the capture, then restarted",
+	ArgsUsage: "<Host_name|Host_ID> <image_name>",
+	Action: func(c *cli.Context) error {
+		logrus.Tracef("SafeScale command: %s %s with args '%s'", hostCmdLabel, c.Command.Name, c.Args())
+		if c.NArg() != 2 {
+			_ = cli.ShowSubcommandHelp(c)
+			return clitools.FailureResponse(clitools.ExitOnInvalidArgument("Missing mandatory argument <Host_name> or <image_name>."))
+		}
+
+		clientSession, xerr := client.New(c.String("server"))
+		if xerr != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+		}
+
+		imageID, err := clientSession.Host.CreateImage(c.Args().First(), c.Args().Get(1), temporal.GetExecutionTimeout())
+		if err != nil {
+			err = fail.FromGRPCStatus(err)
+			return clitools.FailureResponse(clitools.ExitOnRPC(strprocess.Capitalize(client.DecorateTimeoutError(err, "creation of image", false).Error())))
+		}
+		return clitools.SuccessResponse(map[string]string{"image_id": imageID})
+	},
+}
+
+var hostRotateKeypair = &cli.Command{
+	Name:      "rotate-keypair",
+	Usage:     "replaces the SSH keypair used to connect to a host",
+	ArgsUsage: "<Host_name|Host_ID>",
+	Action: func(c *cli.Context) error {
+		logrus.Tracef("SafeScale command: %s %s with args '%s'", hostCmdLabel, c.Command.Name, c.Args())
+		if c.NArg() != 1 {
+			_ = cli.ShowSubcommandHelp(c)
+			return clitools.FailureResponse(clitools.ExitOnInvalidArgument("Missing mandatory argument <Host_name>."))
+		}
+
+		clientSession, xerr := client.New(c.String("server"))
+		if xerr != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+		}
+
+		if err := clientSession.Host.RotateKeypair(c.Args().First(), temporal.GetExecutionTimeout()); err != nil {
+			err = fail.FromGRPCStatus(err)
+			return clitools.FailureResponse(clitools.ExitOnRPC(strprocess.Capitalize(client.DecorateTimeoutError(err, "rotation of keypair", false).Error())))
+		}
+		return clitools.SuccessResponse(nil)
+	},
+}
+
 var hostDelete = &cli.Command{
 	Name:      "delete",
 	Aliases:   []string{"rm", "remove"},
 	Usage:     "Remove host",
 	ArgsUsage: "<Host_name|Host_ID> [<Host_name|Host_ID>...]",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "cleanup-features",
+			Usage: "removes the features installed on the host before deleting it (failures are reported but do not prevent the deletion)",
+		},
+		&cli.BoolFlag{
+			Name:  "strict-feature-cleanup",
+			Usage: "with --cleanup-features, does not delete the host if a feature cannot be removed",
+		},
+	},
 	Action: func(c *cli.Context) error {
 		logrus.Tracef("SafeScale command: %s %s with args '%s'", hostCmdLabel, c.Command.Name, c.Args())
 		if c.NArg() < 1 {
@@ -418,7 +852,8 @@ var hostDelete = &cli.Command{
 			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
 		}
 
-		if err := clientSession.Host.Delete(hostList, temporal.GetExecutionTimeout()); err != nil {
+		cleanupFeatures := c.Bool("cleanup-features") || c.Bool("strict-feature-cleanup")
+		if err := clientSession.Host.Delete(hostList, cleanupFeatures, c.Bool("strict-feature-cleanup"), temporal.GetExecutionTimeout()); err != nil {
 			err = fail.FromGRPCStatus(err)
 			return clitools.FailureResponse(clitools.ExitOnRPC(strprocess.Capitalize(client.DecorateTimeoutError(err, "deletion of host", false).Error())))
 		}
@@ -485,6 +920,10 @@ var hostAddFeatureCommand = &cli.Command{
 			Name:  "skip-proxy",
 			Usage: "Disable reverse proxy rules",
 		},
+		&cli.UintFlag{
+			Name:  "timeout",
+			Usage: "Limits the duration of the installation, in minutes (default: the timeout defined by the feature)",
+		},
 	},
 
 	Action: hostFeatureAddAction,
@@ -767,6 +1206,10 @@ var hostFeatureAddCommand = &cli.Command{
 			Name:  "skip-proxy",
 			Usage: "Disable reverse proxy rules",
 		},
+		&cli.UintFlag{
+			Name:  "timeout",
+			Usage: "Limits the duration of the installation, in minutes (default: the timeout defined by the feature)",
+		},
 	},
 
 	Action: hostFeatureAddAction,
@@ -795,6 +1238,7 @@ func hostFeatureAddAction(c *cli.Context) error {
 
 	settings := protocol.FeatureSettings{}
 	settings.SkipProxy = c.Bool("skip-proxy")
+	settings.Timeout = uint32(c.Uint("timeout"))
 
 	clientSession, xerr := client.New(c.String("server"))
 	if xerr != nil {
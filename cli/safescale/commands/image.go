@@ -21,6 +21,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/CS-SI/SafeScale/lib/client"
+	"github.com/CS-SI/SafeScale/lib/protocol"
 	clitools "github.com/CS-SI/SafeScale/lib/utils/cli"
 	"github.com/CS-SI/SafeScale/lib/utils/cli/enums/exitcode"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
@@ -47,7 +48,16 @@ var imageList = &cli.Command{
 		&cli.BoolFlag{
 			Name:  "all",
 			Usage: "ErrorList all available images in tenant (without any filter)",
-		}},
+		},
+		&cli.StringFlag{
+			Name:  "name",
+			Usage: "Keeps only images whose name contains this value",
+		},
+		&cli.StringFlag{
+			Name:  "os-family",
+			Usage: "Keeps only images of this OS family (ubuntu, debian, centos, rhel, ...)",
+		},
+	},
 	Action: func(c *cli.Context) error {
 		logrus.Tracef("SafeScale command: %s %s with args '%s'", imageCmdName, c.Command.Name, c.Args())
 
@@ -56,7 +66,12 @@ var imageList = &cli.Command{
 			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
 		}
 
-		images, err := clientSession.Image.List(c.Bool("all"), temporal.GetExecutionTimeout())
+		req := &protocol.TenantImageListRequest{
+			All:      c.Bool("all"),
+			Name:     c.String("name"),
+			OsFamily: c.String("os-family"),
+		}
+		images, err := clientSession.Tenant.ListImages(req, temporal.GetExecutionTimeout())
 		if err != nil {
 			err = fail.FromGRPCStatus(err)
 			return clitools.FailureResponse(clitools.ExitOnRPC(strprocess.Capitalize(client.DecorateTimeoutError(err, "list of images", false).Error())))
@@ -396,6 +396,12 @@ var networkSecurityGroupInspect = &cli.Command{
 	Aliases:   []string{"show"},
 	Usage:     "Shows details of Security Group",
 	ArgsUsage: "NETWORKREF GROUPREF",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "repair",
+			Usage: "Unbinds the Security Group from Hosts that do not exist anymore before inspecting it",
+		},
+	},
 	Action: func(c *cli.Context) error {
 		logrus.Tracef("SafeScale command: %s %s %s %s with args '%s'", networkCmdLabel, securityCmdLabel, groupCmdLabel, c.Command.Name, c.Args())
 
@@ -413,6 +419,13 @@ var networkSecurityGroupInspect = &cli.Command{
 			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
 		}
 
+		if c.Bool("repair") {
+			if err := clientSession.SecurityGroup.Sanitize(c.Args().Get(1), temporal.GetExecutionTimeout()); err != nil {
+				err = fail.FromGRPCStatus(err)
+				return clitools.FailureResponse(clitools.ExitOnRPC(err.Error()))
+			}
+		}
+
 		resp, err := clientSession.SecurityGroup.Inspect(c.Args().Get(1), temporal.GetExecutionTimeout())
 		if err != nil {
 			err = fail.FromGRPCStatus(err)
@@ -690,7 +703,7 @@ var networkSecurityGroupRuleAdd = &cli.Command{
 		},
 		&cli.StringSliceFlag{
 			Name:  "cidr",
-			Usage: "source/target of the rule; may be used multiple times",
+			Usage: "source/target of the rule: CIDR, Security Group name or ID, or named IP set of tenant as 'ipset:<name>'; may be used multiple times",
 		},
 	},
 	Action: func(c *cli.Context) error {
@@ -727,7 +740,11 @@ var networkSecurityGroupRuleAdd = &cli.Command{
 			Protocol:    c.String("protocol"),
 			PortFrom:    int32(c.Int("port-from")),
 			PortTo:      int32(c.Int("port-to")),
-			Targets:     c.StringSlice("cidr"),
+		}
+		if direction == securitygroupruledirection.Ingress {
+			rule.Sources = c.StringSlice("cidr")
+		} else {
+			rule.Targets = c.StringSlice("cidr")
 		}
 
 		if err := clientSession.SecurityGroup.AddRule(c.Args().Get(1), rule, temporal.GetExecutionTimeout()); err != nil {
@@ -811,7 +828,11 @@ var networkSecurityGroupRuleDelete = &cli.Command{
 			Protocol:  c.String("protocol"),
 			PortFrom:  int32(c.Int("port-from")),
 			PortTo:    int32(c.Int("port-to")),
-			Targets:   c.StringSlice("cidr"),
+		}
+		if direction == securitygroupruledirection.Ingress {
+			rule.Sources = c.StringSlice("cidr")
+		} else {
+			rule.Targets = c.StringSlice("cidr")
 		}
 		err := clientSession.SecurityGroup.DeleteRule(c.Args().Get(1), rule, temporal.GetExecutionTimeout())
 		if err != nil {
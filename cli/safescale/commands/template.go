@@ -23,6 +23,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/CS-SI/SafeScale/lib/client"
+	"github.com/CS-SI/SafeScale/lib/protocol"
 	clitools "github.com/CS-SI/SafeScale/lib/utils/cli"
 	"github.com/CS-SI/SafeScale/lib/utils/cli/enums/exitcode"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
@@ -57,6 +58,22 @@ var templateList = &cli.Command{
 			Aliases: []string{"S"},
 			Usage:   "Display only templates with scanned information",
 		},
+		&cli.StringFlag{
+			Name:  "name",
+			Usage: "Keeps only templates whose name contains this value",
+		},
+		&cli.IntFlag{
+			Name:  "min-cores",
+			Usage: "Keeps only templates with at least this number of cores",
+		},
+		&cli.Float64Flag{
+			Name:  "min-ram",
+			Usage: "Keeps only templates with at least this RAM size (in GB)",
+		},
+		&cli.IntFlag{
+			Name:  "min-disk",
+			Usage: "Keeps only templates with at least this disk size (in GB)",
+		},
 	},
 	Action: func(c *cli.Context) error {
 		logrus.Tracef("SafeScale command: %s %s with args '%s'", templateCmdName, c.Command.Name, c.Args())
@@ -66,7 +83,27 @@ var templateList = &cli.Command{
 			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
 		}
 
-		templates, err := clientSession.Template.List(c.Bool("all"), c.Bool("scanned-only"), temporal.GetExecutionTimeout())
+		var (
+			templates *protocol.TemplateList
+			err       error
+		)
+		filtered := c.IsSet("name") || c.IsSet("min-cores") || c.IsSet("min-ram") || c.IsSet("min-disk")
+		if filtered && c.Bool("scanned-only") {
+			return clitools.FailureResponse(clitools.ExitOnInvalidOption("--scanned-only cannot be combined with --name, --min-cores, --min-ram or --min-disk"))
+		}
+		if !filtered {
+			// scanned information is only provided by the template service
+			templates, err = clientSession.Template.List(c.Bool("all"), c.Bool("scanned-only"), temporal.GetExecutionTimeout())
+		} else {
+			req := &protocol.TenantTemplateListRequest{
+				All:         c.Bool("all"),
+				Name:        c.String("name"),
+				MinCores:    int32(c.Int("min-cores")),
+				MinRamSize:  float32(c.Float64("min-ram")),
+				MinDiskSize: int32(c.Int("min-disk")),
+			}
+			templates, err = clientSession.Tenant.ListTemplates(req, temporal.GetExecutionTimeout())
+		}
 		if err != nil {
 			err = fail.FromGRPCStatus(err)
 			return clitools.FailureResponse(clitools.ExitOnRPC(strprocess.Capitalize(client.DecorateTimeoutError(err, "list of templates", false).Error())))
@@ -41,6 +41,7 @@ var TenantCommand = &cli.Command{
 		tenantSetCommand,
 		tenantInspectCommand,
 		tenantScanCommand,
+		tenantSnapshotCommand,
 		tenantMetadataCommands,
 	},
 }
@@ -172,6 +173,33 @@ var tenantScanCommand = &cli.Command{
 	},
 }
 
+// tenantSnapshotCommand handles 'safescale tenant snapshot'
+var tenantSnapshotCommand = &cli.Command{
+	Name:      "snapshot",
+	Usage:     "Describe all the resources of a tenant",
+	ArgsUsage: "[<tenant_name>]",
+	Action: func(c *cli.Context) error {
+		if c.NArg() > 1 {
+			_ = cli.ShowSubcommandHelp(c)
+			return clitools.FailureResponse(clitools.ExitOnInvalidArgument("Too many arguments."))
+		}
+
+		logrus.Tracef("SafeScale command: %s %s with args '%s'", tenantCmdLabel, c.Command.Name, c.Args())
+
+		clientSession, xerr := client.New(c.String("server"))
+		if xerr != nil {
+			return clitools.FailureResponse(clitools.ExitOnErrorWithMessage(exitcode.Run, xerr.Error()))
+		}
+
+		resp, err := clientSession.Tenant.Snapshot(c.Args().First(), temporal.GetExecutionTimeout())
+		if err != nil {
+			err = fail.FromGRPCStatus(err)
+			return clitools.FailureResponse(clitools.ExitOnRPC(strprocess.Capitalize(client.DecorateTimeoutError(err, "take snapshot of tenant", false).Error())))
+		}
+		return clitools.SuccessResponse(resp)
+	},
+}
+
 const tenantMetadataCmdLabel = "metadata"
 
 // tenantMetadataCommands handles 'safescale tenant metadata' commands
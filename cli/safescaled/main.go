@@ -40,9 +40,14 @@ import (
 	app2 "github.com/CS-SI/SafeScale/lib/utils/app"
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
 	"github.com/CS-SI/SafeScale/lib/utils/debug/tracing"
+	"github.com/CS-SI/SafeScale/lib/utils/metrics"
 )
 
-var profileCloseFunc = func() {}
+var (
+	profileCloseFunc    = func() {}
+	spanExportCloseFunc = func() {}
+	metricsCloseFunc    = func() {}
+)
 
 const (
 	defaultDaemonHost string = "localhost" // By default, safescaled only listen on localhost
@@ -53,6 +58,8 @@ func cleanup(onAbort bool) {
 	if onAbort {
 		fmt.Println("Cleaning up...")
 	}
+	metricsCloseFunc()
+	spanExportCloseFunc()
 	profileCloseFunc()
 	exit.Exit(1)
 }
@@ -73,6 +80,18 @@ func work(c *cli.Context) {
 		logrus.Errorf(err.Error())
 	}
 
+	// Export also traces as spans if OpenTelemetry is configured; if it cannot be set up, report it but do not fail
+	spanExportCloseFunc, err = tracing.SetupOpenTelemetry("safescaled")
+	if err != nil {
+		logrus.Errorf(err.Error())
+	}
+
+	// Export metrics of provisioning to Prometheus if configured; if it cannot be set up, report it but do not fail
+	metricsCloseFunc, err = metrics.SetupPrometheus()
+	if err != nil {
+		logrus.Errorf(err.Error())
+	}
+
 	logrus.Infoln("Checking configuration")
 	_, err = iaas.GetTenantNames()
 	if err != nil {
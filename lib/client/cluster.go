@@ -17,6 +17,7 @@
 package client
 
 import (
+	"io"
 	"time"
 
 	"github.com/CS-SI/SafeScale/lib/protocol"
@@ -33,7 +34,7 @@ type cluster struct {
 }
 
 // List ...
-func (c cluster) List(timeout time.Duration) (*protocol.ClusterListResponse, error) {
+func (c cluster) List(flavor, complexity, state string, timeout time.Duration) (*protocol.ClusterListResponse, error) {
 	c.session.Connect()
 	defer c.session.Disconnect()
 
@@ -43,7 +44,12 @@ func (c cluster) List(timeout time.Duration) (*protocol.ClusterListResponse, err
 		return nil, xerr
 	}
 
-	result, err := service.List(ctx, &protocol.Reference{})
+	req := &protocol.ClusterListRequest{
+		Flavor:     flavor,
+		Complexity: complexity,
+		State:      state,
+	}
+	result, err := service.List(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -72,6 +78,40 @@ func (c cluster) Inspect(clusterName string, timeout time.Duration) (*protocol.C
 	return result, nil
 }
 
+// InspectWithHealth inspects the cluster, including its health collected by probing its hosts
+func (c cluster) InspectWithHealth(clusterName string, timeout time.Duration) (*protocol.ClusterResponse, error) {
+	if clusterName == "" {
+		return nil, fail.InvalidParameterCannotBeEmptyStringError("clusterName")
+	}
+
+	c.session.Connect()
+	defer c.session.Disconnect()
+	service := protocol.NewClusterServiceClient(c.session.connection)
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	return service.InspectWithHealth(ctx, &protocol.Reference{Name: clusterName})
+}
+
+// VerifyFeatures checks the features installed on the cluster and returns their health on each host
+func (c cluster) VerifyFeatures(clusterName string, timeout time.Duration) (*protocol.ClusterFeaturesVerification, error) {
+	if clusterName == "" {
+		return nil, fail.InvalidParameterCannotBeEmptyStringError("clusterName")
+	}
+
+	c.session.Connect()
+	defer c.session.Disconnect()
+	service := protocol.NewClusterServiceClient(c.session.connection)
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	return service.VerifyFeatures(ctx, &protocol.Reference{Name: clusterName})
+}
+
 // GetState gets cluster status
 func (c cluster) GetState(clusteName string, timeout time.Duration) (*protocol.ClusterStateResponse, error) {
 	if clusteName == "" {
@@ -135,6 +175,24 @@ func (c cluster) Create(def *protocol.ClusterCreateRequest, timeout time.Duratio
 	return service.Create(ctx, def)
 }
 
+// Resume continues the creation of a cluster interrupted while in state Creating
+func (c cluster) Resume(clusterName string, timeout time.Duration) (*protocol.ClusterResponse, error) {
+	if clusterName == "" {
+		return nil, fail.InvalidParameterCannotBeEmptyStringError("clusterName")
+	}
+
+	c.session.Connect()
+	defer c.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	service := protocol.NewClusterServiceClient(c.session.connection)
+	return service.Resume(ctx, &protocol.Reference{Name: clusterName})
+}
+
 // Delete deletes a cluster
 func (c cluster) Delete(clusterName string, timeout time.Duration) error {
 	if clusterName == "" {
@@ -190,6 +248,63 @@ func (c cluster) Shrink(req *protocol.ClusterResizeRequest, duration time.Durati
 	return service.Shrink(ctx, req)
 }
 
+// ResizeGateways resizes the gateways of the cluster
+func (c cluster) ResizeGateways(req *protocol.ClusterGatewayResizeRequest, duration time.Duration) error {
+	if req == nil {
+		return fail.InvalidParameterCannotBeNilError("req")
+	}
+
+	c.session.Connect()
+	defer c.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return xerr
+	}
+
+	service := protocol.NewClusterServiceClient(c.session.connection)
+	_, err := service.ResizeGateways(ctx, req)
+	return err
+}
+
+// SetDomain changes the DNS domain of the cluster
+func (c cluster) SetDomain(req *protocol.ClusterDomainRequest, duration time.Duration) error {
+	if req == nil {
+		return fail.InvalidParameterCannotBeNilError("req")
+	}
+
+	c.session.Connect()
+	defer c.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return xerr
+	}
+
+	service := protocol.NewClusterServiceClient(c.session.connection)
+	_, err := service.SetDomain(ctx, req)
+	return err
+}
+
+// RetagResources sets again the tags of the cluster on its resources, after merging the tags of the request
+func (c cluster) RetagResources(req *protocol.ClusterTagsRequest, duration time.Duration) error {
+	if req == nil {
+		return fail.InvalidParameterCannotBeNilError("req")
+	}
+
+	c.session.Connect()
+	defer c.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return xerr
+	}
+
+	service := protocol.NewClusterServiceClient(c.session.connection)
+	_, err := service.RetagResources(ctx, req)
+	return err
+}
+
 // CheckFeature ...
 func (c cluster) CheckFeature(clusterName, featureName string, params map[string]string, settings *protocol.FeatureSettings, duration time.Duration) error {
 	if clusterName == "" {
@@ -368,3 +483,207 @@ func (c cluster) ListNodes(clusterName string, duration time.Duration) (*protoco
 	}
 	return list, nil
 }
+
+// CordonNode marks a node of the cluster as unschedulable
+func (c cluster) CordonNode(clusterName, nodeRef string, duration time.Duration) error {
+	if clusterName == "" {
+		return fail.InvalidParameterError("clusterName", "cannot be empty string")
+	}
+	if nodeRef == "" {
+		return fail.InvalidParameterError("nodeRef", "cannot be empty string")
+	}
+
+	c.session.Connect()
+	defer c.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return xerr
+	}
+
+	service := protocol.NewClusterServiceClient(c.session.connection)
+	_, err := service.CordonNode(ctx, &protocol.ClusterNodeRequest{Name: clusterName, Host: &protocol.Reference{Name: nodeRef}})
+	return err
+}
+
+// UncordonNode marks a cordoned node of the cluster as schedulable again
+func (c cluster) UncordonNode(clusterName, nodeRef string, duration time.Duration) error {
+	if clusterName == "" {
+		return fail.InvalidParameterError("clusterName", "cannot be empty string")
+	}
+	if nodeRef == "" {
+		return fail.InvalidParameterError("nodeRef", "cannot be empty string")
+	}
+
+	c.session.Connect()
+	defer c.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return xerr
+	}
+
+	service := protocol.NewClusterServiceClient(c.session.connection)
+	_, err := service.UncordonNode(ctx, &protocol.ClusterNodeRequest{Name: clusterName, Host: &protocol.Reference{Name: nodeRef}})
+	return err
+}
+
+// LabelNode sets labels on a node of the cluster; a label with an empty value is removed
+func (c cluster) LabelNode(clusterName, nodeRef string, labels map[string]string, duration time.Duration) error {
+	if clusterName == "" {
+		return fail.InvalidParameterError("clusterName", "cannot be empty string")
+	}
+	if nodeRef == "" {
+		return fail.InvalidParameterError("nodeRef", "cannot be empty string")
+	}
+
+	c.session.Connect()
+	defer c.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return xerr
+	}
+
+	service := protocol.NewClusterServiceClient(c.session.connection)
+	_, err := service.LabelNode(ctx, &protocol.ClusterNodeLabelRequest{Name: clusterName, Host: &protocol.Reference{Name: nodeRef}, Labels: labels})
+	return err
+}
+
+// StartNodes starts the nodes of the cluster matching the label selector and returns their names
+func (c cluster) StartNodes(clusterName string, selector map[string]string, duration time.Duration) ([]string, error) {
+	if clusterName == "" {
+		return nil, fail.InvalidParameterError("clusterName", "cannot be empty string")
+	}
+
+	c.session.Connect()
+	defer c.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	service := protocol.NewClusterServiceClient(c.session.connection)
+	resp, err := service.StartNodes(ctx, &protocol.ClusterNodeSelectorRequest{Name: clusterName, Selector: selector})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetNames(), nil
+}
+
+// StopNodes stops the nodes of the cluster matching the label selector and returns their names
+func (c cluster) StopNodes(clusterName string, selector map[string]string, duration time.Duration) ([]string, error) {
+	if clusterName == "" {
+		return nil, fail.InvalidParameterError("clusterName", "cannot be empty string")
+	}
+
+	c.session.Connect()
+	defer c.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	service := protocol.NewClusterServiceClient(c.session.connection)
+	resp, err := service.StopNodes(ctx, &protocol.ClusterNodeSelectorRequest{Name: clusterName, Selector: selector})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetNames(), nil
+}
+
+// GetKubeconfig returns the admin kubeconfig of a K8S cluster
+func (c cluster) GetKubeconfig(clusterName string, duration time.Duration) (string, error) {
+	if clusterName == "" {
+		return "", fail.InvalidParameterError("clusterName", "cannot be empty string")
+	}
+
+	c.session.Connect()
+	defer c.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return "", xerr
+	}
+
+	service := protocol.NewClusterServiceClient(c.session.connection)
+	resp, err := service.GetKubeconfig(ctx, &protocol.Reference{Name: clusterName})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetKubeconfig(), nil
+}
+
+// StreamLog follows the file 'path' on all the masters and nodes of the cluster, calling handler with the name of the
+// host and each line added to the file, starting with its last 'lines' lines; returns only when the daemon ends the stream
+func (c cluster) StreamLog(clusterName, path string, lines uint, handler func(host, line string)) error {
+	if clusterName == "" {
+		return fail.InvalidParameterError("clusterName", "cannot be empty string")
+	}
+	if path == "" {
+		return fail.InvalidParameterError("path", "cannot be empty string")
+	}
+	if handler == nil {
+		return fail.InvalidParameterCannotBeNilError("handler")
+	}
+
+	c.session.Connect()
+	defer c.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return xerr
+	}
+
+	service := protocol.NewClusterServiceClient(c.session.connection)
+	stream, err := service.StreamLog(ctx, &protocol.LogRequest{Target: &protocol.Reference{Name: clusterName}, Path: path, Lines: int32(lines)})
+	if err != nil {
+		return err
+	}
+	return receiveLogLines(stream, handler)
+}
+
+// CollectDiagnostics collects files and command outputs on all the masters and nodes of the Cluster, writing the
+// resulting tar bundle in 'w'
+func (c cluster) CollectDiagnostics(clusterName string, paths, commands []string, maxSize uint64, w io.Writer) error {
+	if clusterName == "" {
+		return fail.InvalidParameterError("clusterName", "cannot be empty string")
+	}
+	if w == nil {
+		return fail.InvalidParameterCannotBeNilError("w")
+	}
+
+	c.session.Connect()
+	defer c.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return xerr
+	}
+
+	service := protocol.NewClusterServiceClient(c.session.connection)
+	req := &protocol.ClusterDiagnosticsRequest{
+		Cluster:  &protocol.Reference{Name: clusterName},
+		Paths:    paths,
+		Commands: commands,
+		MaxSize:  maxSize,
+	}
+	stream, err := service.CollectDiagnostics(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		in, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if _, err = w.Write(in.GetData()); err != nil {
+			return err
+		}
+	}
+}
@@ -17,6 +17,7 @@
 package client
 
 import (
+	"io"
 	"strings"
 	"sync"
 	"time"
@@ -123,6 +124,67 @@ func (h host) Stop(name string, timeout time.Duration) error {
 	return err
 }
 
+// StopGracefully stops host, leaving its operating system gracePeriod to shut down before stopping it with the provider
+// Returns the way the host has been stopped ("guest" or "provider")
+func (h host) StopGracefully(name string, gracePeriod, timeout time.Duration) (string, error) {
+	h.session.Connect()
+	defer h.session.Disconnect()
+	service := protocol.NewHostServiceClient(h.session.connection)
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return "", xerr
+	}
+
+	req := &protocol.HostStopRequest{
+		Host:        &protocol.Reference{Name: name},
+		GracePeriod: int32(gracePeriod.Seconds()),
+	}
+	resp, err := service.StopGracefully(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return resp.GetMethod(), nil
+}
+
+// CreateImage creates an image named imageName from the disk of the host, and returns the ID of the image
+func (h host) CreateImage(name, imageName string, timeout time.Duration) (string, error) {
+	h.session.Connect()
+	defer h.session.Disconnect()
+	service := protocol.NewHostServiceClient(h.session.connection)
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return "", xerr
+	}
+
+	req := &protocol.HostImageRequest{
+		Host:      &protocol.Reference{Name: name},
+		ImageName: imageName,
+	}
+	resp, err := service.CreateImage(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return resp.GetImageId(), nil
+}
+
+// WaitState waits for host to reach one of states, and returns the state reached
+func (h host) WaitState(name string, states []string, waitTimeout, timeout time.Duration) (*protocol.HostStatus, error) {
+	h.session.Connect()
+	defer h.session.Disconnect()
+	service := protocol.NewHostServiceClient(h.session.connection)
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	req := &protocol.HostWaitStateRequest{
+		Host:    &protocol.Reference{Name: name},
+		States:  states,
+		Timeout: int32(waitTimeout.Seconds()),
+	}
+	return service.WaitState(ctx, req)
+}
+
 // Create creates a new host
 func (h host) Create(req *protocol.HostDefinition, timeout time.Duration) (*protocol.Host, error) {
 	h.session.Connect()
@@ -137,8 +199,52 @@ func (h host) Create(req *protocol.HostDefinition, timeout time.Duration) (*prot
 	return service.Create(ctx, req)
 }
 
+// Import brings under management a host created outside SafeScale
+func (h host) Import(req *protocol.HostImportRequest, timeout time.Duration) (*protocol.Host, error) {
+	h.session.Connect()
+	defer h.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	service := protocol.NewHostServiceClient(h.session.connection)
+	return service.Import(ctx, req)
+}
+
+// CheckCreate validates the creation of a host, without creating it
+func (h host) CheckCreate(req *protocol.HostDefinition, timeout time.Duration) (*protocol.HostCreationCheck, error) {
+	h.session.Connect()
+	defer h.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	service := protocol.NewHostServiceClient(h.session.connection)
+	return service.CheckCreate(ctx, req)
+}
+
+// CreateMany creates several hosts at the same time; the outcome of each creation is returned in the same order than the requests
+func (h host) CreateMany(reqs []*protocol.HostDefinition, timeout time.Duration) (*protocol.HostCreationResultList, error) {
+	h.session.Connect()
+	defer h.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	service := protocol.NewHostServiceClient(h.session.connection)
+	return service.CreateMany(ctx, &protocol.HostDefinitionList{Hosts: reqs})
+}
+
 // Delete deletes several hosts at the same time in goroutines
-func (h host) Delete(names []string, timeout time.Duration) error {
+// If cleanupFeatures is true, the features installed on the hosts are removed before deletion; a failure to remove a
+// feature prevents the deletion of the host only if strictFeatureCleanup is true
+func (h host) Delete(names []string, cleanupFeatures, strictFeatureCleanup bool, timeout time.Duration) error {
 	h.session.Connect()
 	defer h.session.Disconnect()
 
@@ -157,7 +263,12 @@ func (h host) Delete(names []string, timeout time.Duration) error {
 	hostDeleter := func(aname string) {
 		defer wg.Done()
 
-		if _, xerr := service.Delete(ctx, &protocol.Reference{Name: aname}); xerr != nil {
+		req := &protocol.HostDeleteRequest{
+			Host:                 &protocol.Reference{Name: aname},
+			CleanupFeatures:      cleanupFeatures,
+			StrictFeatureCleanup: strictFeatureCleanup,
+		}
+		if _, xerr := service.Delete(ctx, req); xerr != nil {
 			mutex.Lock()
 			errs = append(errs, xerr.Error())
 			mutex.Unlock()
@@ -211,6 +322,66 @@ func (h host) Resize(def *protocol.HostDefinition, duration time.Duration) (*pro
 	return service.Resize(ctx, def)
 }
 
+// AttachToSubnet connects the host to an additional subnet
+func (h host) AttachToSubnet(req *protocol.HostSubnetRequest, duration time.Duration) error {
+	h.session.Connect()
+	defer h.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return xerr
+	}
+
+	service := protocol.NewHostServiceClient(h.session.connection)
+	_, err := service.AttachToSubnet(ctx, req)
+	return err
+}
+
+// DetachFromSubnet disconnects the host from a subnet
+func (h host) DetachFromSubnet(req *protocol.HostSubnetRequest, duration time.Duration) error {
+	h.session.Connect()
+	defer h.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return xerr
+	}
+
+	service := protocol.NewHostServiceClient(h.session.connection)
+	_, err := service.DetachFromSubnet(ctx, req)
+	return err
+}
+
+// RunPhase runs again an install phase on the host
+func (h host) RunPhase(req *protocol.HostPhaseRequest, duration time.Duration) error {
+	h.session.Connect()
+	defer h.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return xerr
+	}
+
+	service := protocol.NewHostServiceClient(h.session.connection)
+	_, err := service.RunPhase(ctx, req)
+	return err
+}
+
+// RotateKeypair replaces the SSH keypair used to connect to the host
+func (h host) RotateKeypair(name string, timeout time.Duration) error {
+	h.session.Connect()
+	defer h.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return xerr
+	}
+
+	service := protocol.NewHostServiceClient(h.session.connection)
+	_, err := service.RotateKeypair(ctx, &protocol.Reference{Name: name})
+	return err
+}
+
 // ListFeatures ...
 func (h host) ListFeatures(hostRef string, all bool, duration time.Duration) (*protocol.FeatureListResponse, error) {
 	h.session.Connect()
@@ -411,3 +582,51 @@ func (h host) ListSecurityGroups(hostRef, state string, duration time.Duration)
 	}
 	return service.ListSecurityGroups(ctx, req)
 }
+
+// StreamLog follows the file 'path' on the host, calling handler with each line added to the file, starting with
+// its last 'lines' lines; returns only when the daemon ends the stream
+func (h host) StreamLog(hostRef, path string, lines uint, handler func(host, line string)) error {
+	if hostRef == "" {
+		return fail.InvalidParameterError("hostRef", "cannot be empty string")
+	}
+	if path == "" {
+		return fail.InvalidParameterError("path", "cannot be empty string")
+	}
+	if handler == nil {
+		return fail.InvalidParameterCannotBeNilError("handler")
+	}
+
+	h.session.Connect()
+	defer h.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return xerr
+	}
+
+	service := protocol.NewHostServiceClient(h.session.connection)
+	stream, err := service.StreamLog(ctx, &protocol.LogRequest{Target: &protocol.Reference{Name: hostRef}, Path: path, Lines: int32(lines)})
+	if err != nil {
+		return err
+	}
+	return receiveLogLines(stream, handler)
+}
+
+// logLineReceiver is the part of the gRPC streams of log lines used by receiveLogLines
+type logLineReceiver interface {
+	Recv() (*protocol.LogLine, error)
+}
+
+// receiveLogLines calls handler with each log line received from stream, until the end of the stream
+func receiveLogLines(stream logLineReceiver, handler func(host, line string)) error {
+	for {
+		in, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		handler(in.GetHost(), in.GetLine())
+	}
+}
@@ -165,6 +165,21 @@ func (sg securityGroup) Reset(ref string, timeout time.Duration) error {
 	return err
 }
 
+// Sanitize unbinds the security group from the hosts that do not exist anymore
+func (sg securityGroup) Sanitize(ref string, timeout time.Duration) error {
+	sg.session.Connect()
+	defer sg.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return xerr
+	}
+
+	service := protocol.NewSecurityGroupServiceClient(sg.session.connection)
+	_, err := service.Sanitize(ctx, &protocol.Reference{Name: ref})
+	return err
+}
+
 // AddRule ...
 func (sg securityGroup) AddRule(group string, rule abstract.SecurityGroupRule, duration time.Duration) error {
 	sg.session.Connect()
@@ -405,6 +405,6 @@ func (s ssh) WaitReady( /*ctx context.Context, */ hostName string, timeout time.
 		return err
 	}
 
-	_, xerr = sshCfg.WaitServerReady(ctx, "ready", timeout)
+	_, xerr = sshCfg.WaitServerReady(ctx, "ready", temporal.GetConnectSSHTimeout(), timeout)
 	return xerr
 }
@@ -136,3 +136,73 @@ func (t tenant) Upgrade(name string, timeout time.Duration) ([]string, error) {
 	}
 	return nil, err
 }
+
+// Snapshot returns the consolidated view of the resources of the tenant
+func (t tenant) Snapshot(name string, timeout time.Duration) (*protocol.TenantSnapshot, error) {
+	t.session.Connect()
+	defer t.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	service := protocol.NewTenantServiceClient(t.session.connection)
+	return service.Snapshot(ctx, &protocol.TenantName{Name: name})
+}
+
+// ListRegions lists the regions available for the current tenant
+func (t tenant) ListRegions(timeout time.Duration) (*protocol.RegionList, error) {
+	t.session.Connect()
+	defer t.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	service := protocol.NewTenantServiceClient(t.session.connection)
+	return service.ListRegions(ctx, &googleprotobuf.Empty{})
+}
+
+// ListAvailabilityZones lists the availability zones usable by the current tenant
+func (t tenant) ListAvailabilityZones(timeout time.Duration) (*protocol.AvailabilityZoneList, error) {
+	t.session.Connect()
+	defer t.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	service := protocol.NewTenantServiceClient(t.session.connection)
+	return service.ListAvailabilityZones(ctx, &googleprotobuf.Empty{})
+}
+
+// ListImages lists the images available for the current tenant, filtered by the content of 'req'
+func (t tenant) ListImages(req *protocol.TenantImageListRequest, timeout time.Duration) (*protocol.ImageList, error) {
+	t.session.Connect()
+	defer t.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	service := protocol.NewTenantServiceClient(t.session.connection)
+	return service.ListImages(ctx, req)
+}
+
+// ListTemplates lists the templates available for the current tenant, filtered by the content of 'req'
+func (t tenant) ListTemplates(req *protocol.TenantTemplateListRequest, timeout time.Duration) (*protocol.TemplateList, error) {
+	t.session.Connect()
+	defer t.session.Disconnect()
+
+	ctx, xerr := utils.GetContext(true)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	service := protocol.NewTenantServiceClient(t.session.connection)
+	return service.ListTemplates(ctx, req)
+}
@@ -282,7 +282,7 @@ func (handler *sshHandler) WaitServerReady(hostParam stacks.HostParameter, timeo
 	if xerr != nil {
 		return xerr
 	}
-	_, xerr = ssh.WaitServerReady(task.GetContext(), "ready", timeout)
+	_, xerr = ssh.WaitServerReady(task.GetContext(), "ready", temporal.GetConnectSSHTimeout(), timeout)
 	return xerr
 }
 
@@ -18,10 +18,13 @@ package iaas
 
 import (
 	"sync"
+	"time"
 
+	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/utils/data"
 	"github.com/CS-SI/SafeScale/lib/utils/data/cache"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
+	"github.com/CS-SI/SafeScale/lib/utils/temporal"
 )
 
 // ResourceCache contains the caches for all kinds of resources
@@ -94,8 +97,12 @@ func (rc *ResourceCache) Get(key string, options ...data.ImmutableKeyValue) (ce
 				return nil, xerr
 			}
 
+			// reading the missing content may last, keeps the reservation alive meanwhile
+			stopRefresh := rc.keepReserved(key)
 			var content cache.Cacheable
-			if content, xerr = onMissFunc(); xerr == nil {
+			content, xerr = onMissFunc()
+			stopRefresh()
+			if xerr == nil {
 				ce, xerr = rc.unsafeCommitEntry(key, content)
 			}
 			if xerr != nil {
@@ -131,6 +138,55 @@ func (rc *ResourceCache) unsafeReserveEntry(key string) fail.Error {
 	return rc.byID.ReserveEntry(key)
 }
 
+// RefreshEntry extends the lease of the reservation of key
+func (rc *ResourceCache) RefreshEntry(key string) fail.Error {
+	if rc.isNull() {
+		return fail.InvalidInstanceError()
+	}
+	if key == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("key")
+	}
+
+	return rc.byID.RefreshEntry(key)
+}
+
+// KeepEntryReserved refreshes periodically the reservation of key, until the returned function is called
+// Must be used by the owner of a reservation doing lengthy work before committing or freeing it, to prevent the
+// reservation to be considered as stale
+func (rc *ResourceCache) KeepEntryReserved(key string) (func(), fail.Error) {
+	if rc.isNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if key == "" {
+		return nil, fail.InvalidParameterCannotBeEmptyStringError("key")
+	}
+
+	return rc.keepReserved(key), nil
+}
+
+// keepReserved does the real work of KeepEntryReserved
+func (rc *ResourceCache) keepReserved(key string) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(temporal.GetReservationTimeout() / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if xerr := rc.byID.RefreshEntry(key); xerr != nil {
+					// the reservation has been committed or freed meanwhile, nothing left to refresh
+					return
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
 // CommitEntry confirms the entry in the cache with the content passed as parameter
 func (rc *ResourceCache) CommitEntry(key string, content cache.Cacheable) (ce *cache.Entry, xerr fail.Error) {
 	if rc.isNull() {
@@ -193,6 +249,63 @@ func (rc *ResourceCache) AddEntry(content cache.Cacheable) (ce *cache.Entry, xer
 	return ce, nil
 }
 
+// DeleteEntry removes from cache the entry identified by key (being an ID or a name), and every name pointing to it
+// Removing an entry that is not in cache is not considered as an error
+func (rc *ResourceCache) DeleteEntry(key string) fail.Error {
+	if rc.isNull() {
+		return fail.InvalidInstanceError()
+	}
+	if key == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("key")
+	}
+
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+
+	id := key
+	if v, ok := rc.byName[key]; ok {
+		id = v
+	}
+	for k, v := range rc.byName {
+		if v == id {
+			delete(rc.byName, k)
+		}
+	}
+
+	// If the entry is only reserved, free the reservation; otherwise, remove the committed entry
+	if xerr := rc.byID.FreeEntry(id); xerr != nil {
+		rc.byID.MarkAsDeleted(id)
+	}
+	return nil
+}
+
 type serviceCache struct {
 	resources map[string]*ResourceCache
+	placement placementCache
+	catalog   *catalogCache
+}
+
+// placementCache keeps the regions and availability zones of a service for a limited time
+type placementCache struct {
+	regions       []string
+	regionsExpiry time.Time
+	zones         map[string]bool
+	zonesExpiry   time.Time
+}
+
+// catalogCache keeps the images and templates of a service for a limited time, indexed by the 'all' flag of the listing
+type catalogCache struct {
+	images          map[bool][]abstract.Image
+	imagesExpiry    map[bool]time.Time
+	templates       map[bool][]abstract.HostTemplate
+	templatesExpiry map[bool]time.Time
+}
+
+func newCatalogCache() *catalogCache {
+	return &catalogCache{
+		images:          map[bool][]abstract.Image{},
+		imagesExpiry:    map[bool]time.Time{},
+		templates:       map[bool][]abstract.HostTemplate{},
+		templatesExpiry: map[bool]time.Time{},
+	}
 }
@@ -17,11 +17,40 @@
 package iaas
 
 import (
+	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CS-SI/SafeScale/lib/utils/data/observer"
 )
 
+// cacheableResource is a minimal cache.Cacheable used by tests
+type cacheableResource struct {
+	id, name  string
+	observers map[string]observer.Observer
+}
+
+func newCacheableResource(id, name string) *cacheableResource {
+	return &cacheableResource{id: id, name: name, observers: map[string]observer.Observer{}}
+}
+
+func (r *cacheableResource) GetID() string   { return r.id }
+func (r *cacheableResource) GetName() string { return r.name }
+func (r *cacheableResource) AddObserver(o observer.Observer) error {
+	r.observers[o.GetID()] = o
+	return nil
+}
+func (r *cacheableResource) NotifyObservers() error { return nil }
+func (r *cacheableResource) RemoveObserver(name string) error {
+	delete(r.observers, name)
+	return nil
+}
+func (r *cacheableResource) Released()  {}
+func (r *cacheableResource) Destroyed() {}
+
 // FIXME: implement tests for caches.go
 func TestBadCreateResource(t *testing.T) {
 	tr, err := NewResourceCache("")
@@ -53,3 +82,94 @@ func TestUseResource(t *testing.T) {
 	_ = tr
 	// FIXME: Need a cacheable first
 }
+
+func TestDeleteEntryAllowsImmediateRecreation(t *testing.T) {
+	rc, xerr := NewResourceCache("hosts")
+	require.Nil(t, xerr)
+
+	// Creation of a Host: reserve then commit, as done by Host.carry()
+	require.Nil(t, rc.ReserveEntry("id-1"))
+	_, xerr = rc.CommitEntry("id-1", newCacheableResource("id-1", "myhost"))
+	require.Nil(t, xerr)
+
+	ce, xerr := rc.Get("myhost")
+	require.Nil(t, xerr)
+	assert.Equal(t, "id-1", ce.Content().(*cacheableResource).GetID())
+
+	// Deletion of the Host
+	require.Nil(t, rc.DeleteEntry("id-1"))
+	_, xerr = rc.Get("myhost")
+	assert.NotNil(t, xerr)
+	_, xerr = rc.Get("id-1")
+	assert.NotNil(t, xerr)
+
+	// Immediate recreation of a Host with the same name, reusing the same key
+	require.Nil(t, rc.ReserveEntry("id-1"))
+	_, xerr = rc.CommitEntry("id-1", newCacheableResource("id-1", "myhost"))
+	require.Nil(t, xerr)
+
+	// Immediate recreation of a Host with the same name, with another ID
+	require.Nil(t, rc.DeleteEntry("myhost"))
+	require.Nil(t, rc.ReserveEntry("id-2"))
+	_, xerr = rc.CommitEntry("id-2", newCacheableResource("id-2", "myhost"))
+	require.Nil(t, xerr)
+
+	ce, xerr = rc.Get("myhost")
+	require.Nil(t, xerr)
+	assert.Equal(t, "id-2", ce.Content().(*cacheableResource).GetID())
+}
+
+func TestDeleteEntryOfReservedOrMissingEntry(t *testing.T) {
+	rc, xerr := NewResourceCache("hosts")
+	require.Nil(t, xerr)
+
+	assert.Nil(t, rc.DeleteEntry("unknown"))
+
+	require.Nil(t, rc.ReserveEntry("id-1"))
+	assert.Nil(t, rc.DeleteEntry("id-1"))
+	assert.Nil(t, rc.ReserveEntry("id-1"))
+}
+
+func TestStaleReservationIsFree(t *testing.T) {
+	defer os.Unsetenv("SAFESCALE_RESERVATION_TIMEOUT")
+	require.Nil(t, os.Setenv("SAFESCALE_RESERVATION_TIMEOUT", "100ms"))
+
+	rc, xerr := NewResourceCache("hosts")
+	require.Nil(t, xerr)
+
+	// Reservation left behind by a creation that never ended
+	require.Nil(t, rc.ReserveEntry("id-1"))
+	assert.NotNil(t, rc.ReserveEntry("id-1"))
+	_, xerr = rc.Get("id-1")
+	assert.NotNil(t, xerr)
+
+	time.Sleep(200 * time.Millisecond)
+
+	// Once expired, the key can be reserved again
+	require.Nil(t, rc.ReserveEntry("id-1"))
+	_, xerr = rc.CommitEntry("id-1", newCacheableResource("id-1", "myhost"))
+	require.Nil(t, xerr)
+	ce, xerr := rc.Get("myhost")
+	require.Nil(t, xerr)
+	assert.Equal(t, "id-1", ce.Content().(*cacheableResource).GetID())
+}
+
+func TestRefreshedReservationIsKept(t *testing.T) {
+	defer os.Unsetenv("SAFESCALE_RESERVATION_TIMEOUT")
+	require.Nil(t, os.Setenv("SAFESCALE_RESERVATION_TIMEOUT", "150ms"))
+
+	rc, xerr := NewResourceCache("hosts")
+	require.Nil(t, xerr)
+
+	require.Nil(t, rc.ReserveEntry("id-1"))
+	stopRefresh, xerr := rc.KeepEntryReserved("id-1")
+	require.Nil(t, xerr)
+
+	// a creation still in progress keeps its reservation beyond the lease
+	time.Sleep(300 * time.Millisecond)
+	assert.NotNil(t, rc.ReserveEntry("id-1"))
+
+	stopRefresh()
+	_, xerr = rc.CommitEntry("id-1", newCacheableResource("id-1", "myhost"))
+	require.Nil(t, xerr)
+}
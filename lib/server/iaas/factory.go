@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"net"
 	"regexp"
 	"strconv"
 	"strings"
@@ -234,11 +235,14 @@ func UseService(tenantName, metadataVersion string) (newService Service, xerr fa
 			Location:       objectStorageLocation,
 			metadataBucket: metadataBucket,
 			metadataKey:    metadataCryptKey,
-			cache:          serviceCache{map[string]*ResourceCache{}},
+			cache:          serviceCache{resources: map[string]*ResourceCache{}, catalog: newCatalogCache()},
 			cacheLock:      &sync.Mutex{},
 			tenantName:     tenantName,
 		}
-		return newS, validateRegexps(newS /*tenantClient*/, tenant)
+		if xerr = validateRegexps(newS /*tenantClient*/, tenant); xerr != nil {
+			return newS, xerr
+		}
+		return newS, validateIPSets(newS, tenant)
 	}
 
 	if !tenantInCfg {
@@ -281,6 +285,44 @@ func validateRegexps(svc *service, tenant map[string]interface{}) fail.Error {
 	return nil
 }
 
+// validateIPSets validates the named IP sets from tenants file (keyword 'IPSets' of section 'network')
+func validateIPSets(svc *service, tenant map[string]interface{}) fail.Error {
+	svc.ipSets = map[string][]string{}
+
+	network, ok := tenant["network"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	anon, ok := network["IPSets"]
+	if !ok {
+		return nil
+	}
+	sets, ok := anon.(map[string]interface{})
+	if !ok {
+		return fail.SyntaxError("invalid value for keyword 'IPSets': must be a table of lists of CIDRs")
+	}
+
+	for name, content := range sets {
+		list, ok := content.([]interface{})
+		if !ok {
+			return fail.SyntaxError("invalid value for IP set '%s': must be a list of CIDRs", name)
+		}
+		cidrs := make([]string, 0, len(list))
+		for _, v := range list {
+			cidr, ok := v.(string)
+			if !ok {
+				return fail.SyntaxError("invalid value '%v' in IP set '%s': must be a CIDR", v, name)
+			}
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return fail.SyntaxError("invalid CIDR '%s' in IP set '%s': %s", cidr, name, err.Error())
+			}
+			cidrs = append(cidrs, cidr)
+		}
+		svc.ipSets[name] = cidrs
+	}
+	return nil
+}
+
 // validateRegexpsOfKeyword reads the content of the keyword passed as parameter and returns an array of compiled regexps
 func validateRegexpsOfKeyword(keyword string, content interface{}) (out []*regexp.Regexp, _ fail.Error) {
 	var emptySlice []*regexp.Regexp
@@ -0,0 +1,47 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iaas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateIPSets(t *testing.T) {
+	svc := &service{}
+	xerr := validateIPSets(svc, map[string]interface{}{})
+	require.Nil(t, xerr)
+	require.Empty(t, svc.ipSets)
+
+	tenant := map[string]interface{}{
+		"network": map[string]interface{}{
+			"IPSets": map[string]interface{}{
+				"office": []interface{}{"192.168.10.0/24", "10.1.0.0/16"},
+			},
+		},
+	}
+	xerr = validateIPSets(svc, tenant)
+	require.Nil(t, xerr)
+	require.EqualValues(t, []string{"192.168.10.0/24", "10.1.0.0/16"}, svc.ipSets["office"])
+
+	tenant["network"].(map[string]interface{})["IPSets"] = map[string]interface{}{
+		"office": []interface{}{"192.168.10.0"},
+	}
+	xerr = validateIPSets(svc, tenant)
+	require.NotNil(t, xerr)
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iaas
+
+import (
+	"strings"
+
+	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+)
+
+// metadataBucketService is a Service storing metadata in another bucket than the one of the tenant
+type metadataBucketService struct {
+	Service
+
+	metadataBucket abstract.ObjectStorageBucket
+}
+
+// WithMetadataBucket returns a Service identical to 'svc', except it reads and writes metadata in the bucket named
+// 'bucketName', which must exist
+func WithMetadataBucket(svc Service, bucketName string) (Service, fail.Error) {
+	if svc == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("svc")
+	}
+	if bucketName = strings.TrimSpace(bucketName); bucketName == "" {
+		return nil, fail.InvalidParameterError("bucketName", "cannot be empty string")
+	}
+
+	svc = TenantService(svc)
+	if bucketName == svc.GetMetadataBucket().Name {
+		return svc, nil
+	}
+
+	found, xerr := svc.FindBucket(bucketName)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if !found {
+		return nil, fail.NotFoundError("failed to find metadata bucket '%s'", bucketName)
+	}
+
+	bucket, xerr := svc.InspectBucket(bucketName)
+	if xerr != nil {
+		return nil, xerr
+	}
+	return metadataBucketService{Service: svc, metadataBucket: bucket}, nil
+}
+
+// TenantService returns the Service storing metadata in the bucket of the tenant, 'svc' itself if it is not a Service
+// returned by WithMetadataBucket
+func TenantService(svc Service) Service {
+	if mbs, ok := svc.(metadataBucketService); ok {
+		return mbs.Service
+	}
+	return svc
+}
+
+// GetMetadataBucket returns the bucket instance describing metadata bucket
+func (svc metadataBucketService) GetMetadataBucket() abstract.ObjectStorageBucket {
+	return svc.metadataBucket
+}
@@ -257,7 +257,10 @@ func (p provider) ListTemplates(all bool) ([]abstract.HostTemplate, fail.Error)
 // GetCapabilities returns the capabilities of the provider
 func (p provider) GetCapabilities() providers.Capabilities {
 	return providers.Capabilities{
-		PrivateVirtualIP: false,
+		PrivateVirtualIP:  false,
+		DedicatedTenancy:  true,
+		EphemeralPublicIP: true,
+		HostImageCapture:  true,
 	}
 }
 
@@ -26,6 +26,15 @@ type Capabilities struct {
 	Layer3Networking bool
 	// CanDisableSecurityGroup indicates if the provider supports to disable a Security Group
 	CanDisableSecurityGroup bool
+	// DedicatedTenancy indicates if the provider supports to place a Host on dedicated hardware or on a dedicated host
+	DedicatedTenancy bool
+	// MultipleInterfaces indicates if the provider supports to create a Host with additional network interfaces
+	MultipleInterfaces bool
+	// EphemeralPublicIP indicates that the provider releases the public IP of a Host when the Host is stopped, unless it
+	// has been made static (see PreserveHostPublicIP of the stack)
+	EphemeralPublicIP bool
+	// HostImageCapture indicates if the provider supports to create an image from the disk of a Host (see CreateImageFromHost of the stack)
+	HostImageCapture bool
 	// // SubnetSecurityGroup indicates if the provider supports to bind security group to subnet
 	// SubnetSecurityGroup bool
 }
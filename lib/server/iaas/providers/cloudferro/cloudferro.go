@@ -239,7 +239,9 @@ func (p *provider) GetCapabilities() providers.Capabilities {
 	}
 
 	return providers.Capabilities{
-		PrivateVirtualIP: true,
+		PrivateVirtualIP:   true,
+		MultipleInterfaces: true,
+		HostImageCapture:   true,
 	}
 }
 
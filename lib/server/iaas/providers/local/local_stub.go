@@ -28,6 +28,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/iaas/userdata"
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hoststate"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/taggableresource"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
 )
 
@@ -172,6 +173,15 @@ func (provider *provider) CreateHost(request abstract.HostRequest) (*abstract.Ho
 func (provider *provider) ClearHostStartupScript(hostParam stacks.HostParameter) fail.Error {
 	return gReport
 }
+func (provider *provider) PreserveHostPublicIP(hostParam stacks.HostParameter) (string, fail.Error) {
+	return "", gReport
+}
+func (provider *provider) CreateImageFromHost(hostParam stacks.HostParameter, imageName string) (*abstract.Image, fail.Error) {
+	return nil, gReport
+}
+func (provider *provider) TagResource(kind taggableresource.Enum, id string, tags map[string]string) fail.Error {
+	return gReport
+}
 func (provider *provider) ResizeHost(hostParam stacks.HostParameter, request abstract.HostSizingRequirements) (*abstract.HostFull, fail.Error) {
 	return nil, gReport
 }
@@ -247,6 +257,16 @@ func (provider *provider) UnbindSecurityGroupFromHost(sgParam stacks.SecurityGro
 	return gReport
 }
 
+// AttachHostToSubnet ...
+func (provider *provider) AttachHostToSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) (string, fail.Error) {
+	return "", gReport
+}
+
+// DetachHostFromSubnet ...
+func (provider *provider) DetachHostFromSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) fail.Error {
+	return gReport
+}
+
 // BindSecurityGroupToSubnet ...
 func (provider *provider) BindSecurityGroupToSubnet(sgParam stacks.SecurityGroupParameter, subnetID string) fail.Error {
 	return gReport
@@ -216,6 +216,7 @@ func (p provider) GetCapabilities() providers.Capabilities {
 		// PrivateVirtualIP: true,
 		PrivateVirtualIP: false,
 		Layer3Networking: false,
+		HostImageCapture: true,
 	}
 }
 
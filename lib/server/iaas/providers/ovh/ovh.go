@@ -348,7 +348,9 @@ func (p provider) GetTenantParameters() map[string]interface{} {
 // GetCapabilities returns the capabilities of the provider
 func (p provider) GetCapabilities() providers.Capabilities {
 	return providers.Capabilities{
-		PrivateVirtualIP: true,
+		PrivateVirtualIP:   true,
+		MultipleInterfaces: true,
+		HostImageCapture:   true,
 	}
 }
 
@@ -17,6 +17,7 @@
 package iaas
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math"
@@ -34,6 +35,7 @@ import (
 
 	"github.com/CS-SI/SafeScale/lib/server/iaas/objectstorage"
 	"github.com/CS-SI/SafeScale/lib/server/iaas/providers"
+	"github.com/CS-SI/SafeScale/lib/server/iaas/stacks"
 	"github.com/CS-SI/SafeScale/lib/server/iaas/userdata"
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	imagefilters "github.com/CS-SI/SafeScale/lib/server/resources/abstract/filters/images"
@@ -44,7 +46,9 @@ import (
 	"github.com/CS-SI/SafeScale/lib/utils/crypt"
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
+	"github.com/CS-SI/SafeScale/lib/utils/metrics"
 	"github.com/CS-SI/SafeScale/lib/utils/strprocess"
+	"github.com/CS-SI/SafeScale/lib/utils/temporal"
 )
 
 //go:generate minimock -o mocks/mock_serviceapi.go -i github.com/CS-SI/SafeScale/lib/server/iaas.Service
@@ -57,6 +61,7 @@ type Service interface {
 	FilterImages(string) ([]abstract.Image, fail.Error)
 	FindTemplateBySizing(abstract.HostSizingRequirements) (*abstract.HostTemplate, fail.Error)
 	FindTemplateByName(string) (*abstract.HostTemplate, fail.Error)
+	GetIPSet(string) ([]string, fail.Error)
 	GetName() string
 	GetProviderName() string
 	GetMetadataBucket() abstract.ObjectStorageBucket
@@ -97,6 +102,8 @@ type service struct {
 	whitelistImageREs    []*regexp.Regexp
 	blacklistImageREs    []*regexp.Regexp
 
+	ipSets map[string][]string // named sets of CIDRs, usable as sources/targets of Security Group rules
+
 	cache     serviceCache
 	cacheLock *sync.Mutex
 }
@@ -108,6 +115,16 @@ const (
 	RAMDRFWeight float32 = 1.0 / 8.0
 	// DiskDRFWeight is the Dominant Resource Fairness weight of 1 GB of Disk
 	DiskDRFWeight float32 = 1.0 / 16.0
+
+	// DefaultRegion is the name of the synthetic region returned for providers without the concept of region
+	DefaultRegion = "default"
+	// DefaultAvailabilityZone is the name of the synthetic zone returned for providers without the concept of availability zone
+	DefaultAvailabilityZone = "default"
+
+	// placementCacheTTL is the duration the regions and availability zones are kept in cache
+	placementCacheTTL = 30 * time.Minute
+	// catalogCacheTTL is the duration the images and templates are kept in cache
+	catalogCacheTTL = 10 * time.Minute
 )
 
 // RankDRF computes the Dominant Resource Fairness Rank of an host template
@@ -154,6 +171,24 @@ func (svc service) GetName() string {
 	return svc.tenantName
 }
 
+// GetIPSet returns the CIDRs of the IP set named 'name', defined in tenant configuration
+func (svc service) GetIPSet(name string) ([]string, fail.Error) {
+	if svc.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if name == "" {
+		return nil, fail.InvalidParameterCannotBeEmptyStringError("name")
+	}
+
+	set, ok := svc.ipSets[name]
+	if !ok {
+		return nil, fail.NotFoundError("failed to find IP set '%s' in configuration of tenant '%s'", name, svc.tenantName)
+	}
+	out := make([]string, len(set))
+	copy(out, set)
+	return out, nil
+}
+
 // GetID ...
 // Satisfies interface data.Identifiable
 func (svc service) GetID() string {
@@ -218,6 +253,92 @@ func (svc *service) ChangeProvider(provider providers.Provider) fail.Error {
 	return nil
 }
 
+// ListRegions returns the regions available for the tenant
+// Result is cached for placementCacheTTL; providers without the concept of region return a single synthetic region
+// Overrides providers.Provider.ListRegions()
+func (svc *service) ListRegions() (_ []string, xerr fail.Error) {
+	if svc.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+
+	svc.cacheLock.Lock()
+	defer svc.cacheLock.Unlock()
+
+	if svc.cache.placement.regions != nil && time.Now().Before(svc.cache.placement.regionsExpiry) {
+		return append([]string{}, svc.cache.placement.regions...), nil
+	}
+
+	list, xerr := svc.Provider.ListRegions()
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotImplemented:
+			list = nil
+		default:
+			return nil, xerr
+		}
+	}
+	if len(list) == 0 {
+		list = []string{svc.syntheticRegion()}
+	}
+	sort.Strings(list)
+
+	svc.cache.placement.regions = list
+	svc.cache.placement.regionsExpiry = time.Now().Add(placementCacheTTL)
+	return append([]string{}, list...), nil
+}
+
+// syntheticRegion returns the region set in tenant configuration, or DefaultRegion if there is none
+func (svc *service) syntheticRegion() string {
+	for _, getter := range []func() (providers.Config, fail.Error){svc.GetAuthenticationOptions, svc.GetConfigurationOptions} {
+		cfg, xerr := getter()
+		if xerr != nil {
+			continue
+		}
+		if region, ok := cfg.Get("Region"); ok {
+			if r, ok := region.(string); ok && r != "" {
+				return r
+			}
+		}
+	}
+	return DefaultRegion
+}
+
+// ListAvailabilityZones returns the availability zones usable by the tenant
+// Result is cached for placementCacheTTL; providers without the concept of availability zone return a single synthetic zone
+// Overrides providers.Provider.ListAvailabilityZones()
+func (svc *service) ListAvailabilityZones() (_ map[string]bool, xerr fail.Error) {
+	if svc.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+
+	svc.cacheLock.Lock()
+	defer svc.cacheLock.Unlock()
+
+	if svc.cache.placement.zones == nil || !time.Now().Before(svc.cache.placement.zonesExpiry) {
+		list, xerr := svc.Provider.ListAvailabilityZones()
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrNotImplemented:
+				list = nil
+			default:
+				return nil, xerr
+			}
+		}
+		if len(list) == 0 {
+			list = map[string]bool{DefaultAvailabilityZone: true}
+		}
+
+		svc.cache.placement.zones = list
+		svc.cache.placement.zonesExpiry = time.Now().Add(placementCacheTTL)
+	}
+
+	out := make(map[string]bool, len(svc.cache.placement.zones))
+	for k, v := range svc.cache.placement.zones {
+		out[k] = v
+	}
+	return out, nil
+}
+
 // WaitHostState waits an host achieve state
 // If host in error state, returns utils.ErrNotAvailable
 // If timeout is reached, returns utils.ErrTimeout
@@ -307,21 +428,55 @@ func pollVolume(svc service, volumeID string, state volumestate.Enum, cout chan
 
 // ListTemplates lists available host templates
 // IPAddress templates are sorted using Dominant Resource Fairness Algorithm
+// Result is cached for catalogCacheTTL
 func (svc service) ListTemplates(all bool) ([]abstract.HostTemplate, fail.Error) {
 	if svc.IsNull() {
 		return nil, fail.InvalidInstanceError()
 	}
 
+	if list, ok := svc.cachedTemplates(all); ok {
+		return list, nil
+	}
+
 	allTemplates, err := svc.Provider.ListTemplates(all)
 	if err != nil {
 		return nil, err
 	}
 
-	if all {
-		return allTemplates, nil
+	if !all {
+		allTemplates = svc.reduceTemplates(allTemplates, svc.whitelistTemplateREs, svc.blacklistTemplateREs)
 	}
+	svc.cacheTemplates(all, allTemplates)
+	return allTemplates, nil
+}
+
+// cachedTemplates returns a copy of the templates in cache, if still valid
+func (svc service) cachedTemplates(all bool) ([]abstract.HostTemplate, bool) {
+	if svc.cache.catalog == nil {
+		return nil, false
+	}
+
+	svc.cacheLock.Lock()
+	defer svc.cacheLock.Unlock()
 
-	return svc.reduceTemplates(allTemplates, svc.whitelistTemplateREs, svc.blacklistTemplateREs), nil
+	list, ok := svc.cache.catalog.templates[all]
+	if !ok || !time.Now().Before(svc.cache.catalog.templatesExpiry[all]) {
+		return nil, false
+	}
+	return append([]abstract.HostTemplate{}, list...), true
+}
+
+// cacheTemplates stores a copy of the templates in cache for catalogCacheTTL
+func (svc service) cacheTemplates(all bool, list []abstract.HostTemplate) {
+	if svc.cache.catalog == nil {
+		return
+	}
+
+	svc.cacheLock.Lock()
+	defer svc.cacheLock.Unlock()
+
+	svc.cache.catalog.templates[all] = append([]abstract.HostTemplate{}, list...)
+	svc.cache.catalog.templatesExpiry[all] = time.Now().Add(catalogCacheTTL)
 }
 
 // FindTemplateByName returns the template by its name
@@ -344,12 +499,21 @@ func (svc service) FindTemplateByName(name string) (*abstract.HostTemplate, fail
 
 // FindTemplateBySizing returns an abstracted template corresponding to the Host Sizing Requirements
 func (svc service) FindTemplateBySizing(sizing abstract.HostSizingRequirements) (*abstract.HostTemplate, fail.Error) {
+	// a GPU type implies at least one GPU
+	if sizing.GPUType != "" && sizing.MinGPU < 1 {
+		sizing.MinGPU = 1
+	}
+
 	useScannerDB := sizing.MinGPU > 0 || sizing.MinCPUFreq > 0
 	templates, xerr := svc.ListTemplatesBySizing(sizing, useScannerDB)
 	if xerr != nil {
 		return nil, fail.Wrap(xerr, "failed to find template corresponding to requested resources")
 	}
 
+	if len(templates) == 0 && sizing.MinGPU > 0 {
+		return nil, svc.gpuTemplatesNotFoundError(sizing)
+	}
+
 	var template *abstract.HostTemplate
 	if len(templates) > 0 {
 		template = templates[0]
@@ -368,11 +532,51 @@ func (svc service) FindTemplateBySizing(sizing abstract.HostSizingRequirements)
 		logrus.Infof(msg)
 	} else {
 		logrus.Errorf("failed to find template corresponding to requested resources")
-		return nil, fail.Wrap(xerr, "failed to find template corresponding to requested resources")
+		return nil, fail.NotFoundError("failed to find template corresponding to requested resources")
 	}
 	return template, nil
 }
 
+// gpuTemplatesNotFoundError builds the error returned when no template provides the GPUs requested by 'sizing',
+// listing the available templates with GPU
+func (svc service) gpuTemplatesNotFoundError(sizing abstract.HostSizingRequirements) fail.Error {
+	requested := fmt.Sprintf("%d GPU%s", sizing.MinGPU, strprocess.Plural(uint(sizing.MinGPU)))
+	if sizing.GPUType != "" {
+		requested += fmt.Sprintf(" of type '%s'", sizing.GPUType)
+	}
+
+	allTemplates, xerr := svc.ListTemplates(false)
+	if xerr != nil {
+		return fail.NotFoundError("failed to find template providing %s", requested)
+	}
+
+	var available []string
+	for _, v := range svc.reduceTemplates(allTemplates, svc.whitelistTemplateREs, svc.blacklistTemplateREs) {
+		if v.GPUNumber > 0 {
+			item := fmt.Sprintf("%s (%d GPU%s", v.Name, v.GPUNumber, strprocess.Plural(uint(v.GPUNumber)))
+			if v.GPUType != "" {
+				item += " " + v.GPUType
+			}
+			available = append(available, item+")")
+		}
+	}
+	if len(available) == 0 {
+		return fail.NotFoundError("failed to find template providing %s: no template with GPU available", requested)
+	}
+
+	sort.Strings(available)
+	return fail.NotFoundError("failed to find template providing %s; available templates with GPU: %s", requested, strings.Join(available, ", "))
+}
+
+// gpuTypeMatches tells if the GPU type 'provided' by a template satisfies the GPU type 'requested'
+// The comparison is case-insensitive and 'requested' may be only a part of 'provided' (ie "v100" matches "NVIDIA Tesla V100")
+func gpuTypeMatches(requested, provided string) bool {
+	if requested == "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(provided), strings.ToLower(requested))
+}
+
 // reduceTemplates filters from template slice the entries satisfyin whitelist and blacklist regexps
 func (svc service) reduceTemplates(tpls []abstract.HostTemplate, whitelistREs, blacklistREs []*regexp.Regexp) []abstract.HostTemplate {
 	var finalFilter *templatefilters.Filter
@@ -421,7 +625,12 @@ func (svc service) ListTemplatesBySizing(sizing abstract.HostSizingRequirements,
 		return nil, rerr
 	}
 
-	scannerTpls := map[string]bool{}
+	// a GPU type implies at least one GPU
+	if sizing.GPUType != "" && sizing.MinGPU < 1 {
+		sizing.MinGPU = 1
+	}
+
+	scannerTpls := map[string]abstract.StoredCPUInfo{}
 	askedForSpecificScannerInfo := sizing.MinGPU >= 0 || sizing.MinCPUFreq != 0
 	if askedForSpecificScannerInfo {
 		_ = os.MkdirAll(utils.AbsPathify("$HOME/.safescale/scanner"), 0777)
@@ -482,6 +691,10 @@ func (svc service) ListTemplatesBySizing(sizing abstract.HostSizingRequirements,
 						continue
 					}
 
+					if !gpuTypeMatches(sizing.GPUType, imageFound.GPUModel) {
+						continue
+					}
+
 					if imageFound.CPUFrequency < float64(sizing.MinCPUFreq) {
 						continue
 					}
@@ -501,7 +714,7 @@ func (svc service) ListTemplatesBySizing(sizing abstract.HostSizingRequirements,
 				}
 
 				for _, image := range images {
-					scannerTpls[image.TemplateID] = true
+					scannerTpls[image.TemplateID] = image
 				}
 			}
 		}
@@ -543,11 +756,24 @@ func (svc service) ListTemplatesBySizing(sizing abstract.HostSizingRequirements,
 		gpuMsg := ""
 		if sizing.MinGPU >= 0 {
 			gpuMsg = fmt.Sprintf("%d GPU%s", sizing.MinGPU, strprocess.Plural(uint(sizing.MinGPU)))
+			if sizing.GPUType != "" {
+				gpuMsg += fmt.Sprintf(" of type '%s'", sizing.GPUType)
+			}
 		}
 		logrus.Debugf(fmt.Sprintf("Looking for a host template with: %s cores, %s RAM, %s%s", coreMsg, ramMsg, gpuMsg, diskMsg))
 	}
 
 	for _, t := range reducedTmpls {
+		// Completes GPU information of the template with the one collected by the scanner, if the provider does not give it
+		if info, ok := scannerTpls[t.ID]; ok {
+			if t.GPUNumber == 0 {
+				t.GPUNumber = info.GPU
+			}
+			if t.GPUType == "" {
+				t.GPUType = info.GPUModel
+			}
+		}
+
 		msg := fmt.Sprintf("Discarded host template '%s' with %d cores, %.01f GB of RAM, %d GPU and %d GB of Disk:", t.Name, t.Cores, t.RAMSize, t.GPUNumber, t.DiskSize)
 		msg += " %s"
 		if sizing.MinCores > 0 && t.Cores < sizing.MinCores {
@@ -574,6 +800,14 @@ func (svc service) ListTemplatesBySizing(sizing abstract.HostSizingRequirements,
 			logrus.Debugf(msg, "too many GPU")
 			continue
 		}
+		if sizing.MinGPU > 0 && t.GPUNumber < sizing.MinGPU {
+			logrus.Debugf(msg, "not enough GPU")
+			continue
+		}
+		if !gpuTypeMatches(sizing.GPUType, t.GPUType) {
+			logrus.Debugf(msg, "wrong GPU type")
+			continue
+		}
 
 		if _, ok := scannerTpls[t.ID]; (ok || !askedForSpecificScannerInfo) && t.ID != "" {
 			newT := t
@@ -669,16 +903,53 @@ func filterImagesByRegexSlice(res []*regexp.Regexp) imagefilters.Predicate {
 }
 
 // ListImages reduces the list of needed
+// Result is cached for catalogCacheTTL
 func (svc service) ListImages(all bool) ([]abstract.Image, fail.Error) {
 	if svc.IsNull() {
 		return nil, fail.InvalidInstanceError()
 	}
 
+	if list, ok := svc.cachedImages(all); ok {
+		return list, nil
+	}
+
 	imgs, err := svc.Provider.ListImages(all)
 	if err != nil {
 		return nil, err
 	}
-	return svc.reduceImages(imgs), nil
+
+	imgs = svc.reduceImages(imgs)
+	svc.cacheImages(all, imgs)
+	return imgs, nil
+}
+
+// cachedImages returns a copy of the images in cache, if still valid
+func (svc service) cachedImages(all bool) ([]abstract.Image, bool) {
+	if svc.cache.catalog == nil {
+		return nil, false
+	}
+
+	svc.cacheLock.Lock()
+	defer svc.cacheLock.Unlock()
+
+	list, ok := svc.cache.catalog.images[all]
+	if !ok || !time.Now().Before(svc.cache.catalog.imagesExpiry[all]) {
+		return nil, false
+	}
+	return append([]abstract.Image{}, list...), true
+}
+
+// cacheImages stores a copy of the images in cache for catalogCacheTTL
+func (svc service) cacheImages(all bool, list []abstract.Image) {
+	if svc.cache.catalog == nil {
+		return
+	}
+
+	svc.cacheLock.Lock()
+	defer svc.cacheLock.Unlock()
+
+	svc.cache.catalog.images[all] = append([]abstract.Image{}, list...)
+	svc.cache.catalog.imagesExpiry[all] = time.Now().Add(catalogCacheTTL)
 }
 
 // SearchImage search an image corresponding to OS Name
@@ -905,6 +1176,68 @@ func (svc service) LookupRuleInSecurityGroup(asg *abstract.SecurityGroup, rule *
 	return true, nil
 }
 
+// InspectHost returns the information about the Host from the provider, failing with fail.ErrTimeout if the provider does not answer in time
+// Overrides providers.Provider.InspectHost()
+func (svc service) InspectHost(hostParam stacks.HostParameter) (ahf *abstract.HostFull, xerr fail.Error) {
+	if svc.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	defer metrics.Measure(metrics.KindProviderCall, "InspectHost", svc.GetProviderName())(&xerr)
+
+	xerr = stacks.BoundedRemoteCall("InspectHost", temporal.GetProviderCallTimeout(context.Background()), func() (innerXErr fail.Error) {
+		ahf, innerXErr = svc.Provider.InspectHost(hostParam)
+		return innerXErr
+	})
+	if xerr != nil {
+		return nil, xerr
+	}
+	return ahf, nil
+}
+
+// GetHostState returns the current state of the Host from the provider, failing with fail.ErrTimeout if the provider does not answer in time
+// Overrides providers.Provider.GetHostState()
+func (svc service) GetHostState(hostParam stacks.HostParameter) (state hoststate.Enum, xerr fail.Error) {
+	if svc.IsNull() {
+		return hoststate.Unknown, fail.InvalidInstanceError()
+	}
+	defer metrics.Measure(metrics.KindProviderCall, "GetHostState", svc.GetProviderName())(&xerr)
+
+	xerr = stacks.BoundedRemoteCall("GetHostState", temporal.GetProviderCallTimeout(context.Background()), func() (innerXErr fail.Error) {
+		state, innerXErr = svc.Provider.GetHostState(hostParam)
+		return innerXErr
+	})
+	if xerr != nil {
+		return hoststate.Unknown, xerr
+	}
+	return state, nil
+}
+
+// DeleteHost requests the deletion of the Host to the provider, failing with fail.ErrTimeout if the provider does not accept it in time
+// Overrides providers.Provider.DeleteHost()
+func (svc service) DeleteHost(hostParam stacks.HostParameter) (xerr fail.Error) {
+	if svc.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	defer metrics.Measure(metrics.KindProviderCall, "DeleteHost", svc.GetProviderName())(&xerr)
+
+	// Deletion may legitimately take longer than an inspection, so allows the largest of both timeouts
+	timeout := temporal.MaxTimeout(temporal.GetProviderCallTimeout(context.Background()), temporal.GetHostDeletionTimeout(context.Background()))
+	return stacks.BoundedRemoteCall("DeleteHost", timeout, func() fail.Error {
+		return svc.Provider.DeleteHost(hostParam)
+	})
+}
+
+// CreateHost requests the creation of a Host to the provider, measuring the call
+// Overrides providers.Provider.CreateHost()
+func (svc service) CreateHost(request abstract.HostRequest) (ahf *abstract.HostFull, udc *userdata.Content, xerr fail.Error) {
+	if svc.IsNull() {
+		return nil, nil, fail.InvalidInstanceError()
+	}
+	defer metrics.Measure(metrics.KindProviderCall, "CreateHost", svc.GetProviderName())(&xerr)
+
+	return svc.Provider.CreateHost(request)
+}
+
 // InspectHostByName hides the "complexity" of the way to get Host by name
 func (svc service) InspectHostByName(name string) (*abstract.HostFull, fail.Error) {
 	if svc.IsNull() {
@@ -0,0 +1,195 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iaas
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+	"github.com/CS-SI/SafeScale/lib/utils/temporal"
+)
+
+// defaultServicePoolIdleTimeout is the default delay after which a Service not used anymore is dropped from the pool
+const defaultServicePoolIdleTimeout = 15 * time.Minute
+
+// sharedServices is the pool of Services used by UseSharedService
+var sharedServices = newServicePool(
+	func(tenantName string) (Service, fail.Error) {
+		return UseService(tenantName, "")
+	},
+	tenantConfigurationFingerprints,
+	temporal.GetTimeoutFromEnv("SAFESCALE_SERVICE_POOL_IDLE_TIMEOUT", defaultServicePoolIdleTimeout),
+)
+
+// UseSharedService returns the Service of the tenant 'tenantName', reusing (with its caches) the one built by a
+// previous call, unless the configuration of the tenant changed since
+// Services not used since SAFESCALE_SERVICE_POOL_IDLE_TIMEOUT (15 minutes by default) are dropped
+func UseSharedService(tenantName string) (Service, fail.Error) {
+	return sharedServices.get(tenantName)
+}
+
+// InvalidateSharedServices drops all the Services kept by UseSharedService, that will be built again on next use
+func InvalidateSharedServices() {
+	sharedServices.purge()
+}
+
+// servicePool keeps the Services built for tenants, so that operations on the same tenant reuse the same Service
+// instead of building a new one each time
+type servicePool struct {
+	lock    sync.Mutex
+	entries map[string]*servicePoolEntry
+
+	build        func(tenantName string) (Service, fail.Error) // builds the Service of a tenant
+	fingerprints func() (map[string]string, fail.Error)        // returns a fingerprint of the configuration of each tenant
+	idleTimeout  time.Duration                                 // delay after which a Service not used is dropped; 0 means never
+	now          func() time.Time
+}
+
+// servicePoolEntry is a Service of the pool, possibly being built
+type servicePoolEntry struct {
+	ready       chan struct{} // closed when the build of the Service is done
+	service     Service
+	err         fail.Error
+	fingerprint string
+	lastUsed    time.Time
+}
+
+func newServicePool(build func(string) (Service, fail.Error), fingerprints func() (map[string]string, fail.Error), idleTimeout time.Duration) *servicePool {
+	return &servicePool{
+		entries:      map[string]*servicePoolEntry{},
+		build:        build,
+		fingerprints: fingerprints,
+		idleTimeout:  idleTimeout,
+		now:          time.Now,
+	}
+}
+
+// get returns the Service of the tenant, building it if not in the pool or if the configuration of the tenant changed
+// Concurrent calls for the same tenant wait for the same build; a failed build is not kept
+func (p *servicePool) get(tenantName string) (Service, fail.Error) {
+	if tenantName == "" {
+		return NullService(), fail.InvalidParameterCannotBeEmptyStringError("tenantName")
+	}
+
+	fingerprints, xerr := p.fingerprints()
+	if xerr != nil {
+		return NullService(), xerr
+	}
+	fingerprint, ok := fingerprints[tenantName]
+	if !ok {
+		return NullService(), fail.NotFoundError("failed to find tenant '%s' in configuration", tenantName)
+	}
+
+	p.lock.Lock()
+	now := p.now()
+	p.sweep(now, fingerprints)
+
+	entry, ok := p.entries[tenantName]
+	if ok {
+		entry.lastUsed = now
+		p.lock.Unlock()
+
+		<-entry.ready
+		return entry.service, entry.err
+	}
+
+	entry = &servicePoolEntry{
+		ready:       make(chan struct{}),
+		fingerprint: fingerprint,
+		lastUsed:    now,
+	}
+	p.entries[tenantName] = entry
+	p.lock.Unlock()
+
+	defer close(entry.ready)
+
+	entry.service, entry.err = p.build(tenantName)
+	if entry.err != nil {
+		p.lock.Lock()
+		if p.entries[tenantName] == entry {
+			delete(p.entries, tenantName)
+		}
+		p.lock.Unlock()
+	}
+	return entry.service, entry.err
+}
+
+// sweep drops the built Services that are idle for too long, or whose tenant configuration changed or disappeared
+// Must be called with p.lock held
+func (p *servicePool) sweep(now time.Time, fingerprints map[string]string) {
+	for name, entry := range p.entries {
+		select {
+		case <-entry.ready:
+		default:
+			// still being built
+			continue
+		}
+
+		fingerprint, ok := fingerprints[name]
+		switch {
+		case !ok:
+			logrus.Debugf("dropping Service of tenant '%s' removed from configuration", name)
+		case fingerprint != entry.fingerprint:
+			logrus.Debugf("dropping Service of tenant '%s', its configuration has changed", name)
+		case p.idleTimeout > 0 && now.Sub(entry.lastUsed) > p.idleTimeout:
+			logrus.Debugf("dropping Service of tenant '%s', not used since %s", name, temporal.FormatDuration(now.Sub(entry.lastUsed)))
+		default:
+			continue
+		}
+		delete(p.entries, name)
+	}
+}
+
+// purge drops all the Services of the pool
+func (p *servicePool) purge() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.entries = map[string]*servicePoolEntry{}
+}
+
+// tenantConfigurationFingerprints returns, for each tenant of the configuration, a fingerprint of its settings allowing
+// to detect changes
+func tenantConfigurationFingerprints() (map[string]string, fail.Error) {
+	tenants, _, xerr := getTenantsFromCfg()
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	out := make(map[string]string, len(tenants))
+	for _, t := range tenants {
+		tenant, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, ok := tenant["name"].(string)
+		if !ok {
+			continue
+		}
+
+		// fmt prints maps sorted by keys, so the same settings always give the same fingerprint
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%v", tenant)))
+		out[name] = hex.EncodeToString(sum[:])
+	}
+	return out, nil
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iaas
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+)
+
+// fakeServicePool returns a servicePool building a new Service at each call of build, with a configuration
+// and a clock under control of the test
+func fakeServicePool(fingerprints map[string]string, builds *int32, now *time.Time) *servicePool {
+	var lock sync.Mutex
+	p := newServicePool(
+		func(string) (Service, fail.Error) {
+			atomic.AddInt32(builds, 1)
+			return &service{}, nil
+		},
+		func() (map[string]string, fail.Error) {
+			lock.Lock()
+			defer lock.Unlock()
+			out := make(map[string]string, len(fingerprints))
+			for k, v := range fingerprints {
+				out[k] = v
+			}
+			return out, nil
+		},
+		time.Minute,
+	)
+	p.now = func() time.Time { return *now }
+	return p
+}
+
+func Test_servicePool_Reuse(t *testing.T) {
+	var builds int32
+	now := time.Now()
+	p := fakeServicePool(map[string]string{"t1": "a", "t2": "b"}, &builds, &now)
+
+	s1, xerr := p.get("t1")
+	require.Nil(t, xerr)
+	s2, xerr := p.get("t1")
+	require.Nil(t, xerr)
+	assert.True(t, s1 == s2)
+	assert.EqualValues(t, 1, builds)
+
+	s3, xerr := p.get("t2")
+	require.Nil(t, xerr)
+	assert.False(t, s1 == s3)
+	assert.EqualValues(t, 2, builds)
+
+	_, xerr = p.get("unknown")
+	require.NotNil(t, xerr)
+	assert.IsType(t, &fail.ErrNotFound{}, xerr)
+
+	_, xerr = p.get("")
+	require.NotNil(t, xerr)
+}
+
+func Test_servicePool_Invalidation(t *testing.T) {
+	var builds int32
+	now := time.Now()
+	fingerprints := map[string]string{"t1": "a"}
+	p := fakeServicePool(fingerprints, &builds, &now)
+
+	s1, xerr := p.get("t1")
+	require.Nil(t, xerr)
+
+	// configuration of tenant changed
+	fingerprints["t1"] = "b"
+	s2, xerr := p.get("t1")
+	require.Nil(t, xerr)
+	assert.False(t, s1 == s2)
+	assert.EqualValues(t, 2, builds)
+
+	// idle for too long
+	now = now.Add(2 * time.Minute)
+	s3, xerr := p.get("t1")
+	require.Nil(t, xerr)
+	assert.False(t, s2 == s3)
+	assert.EqualValues(t, 3, builds)
+
+	p.purge()
+	_, xerr = p.get("t1")
+	require.Nil(t, xerr)
+	assert.EqualValues(t, 4, builds)
+}
+
+func Test_servicePool_FailedBuildNotKept(t *testing.T) {
+	var builds int32
+	p := newServicePool(
+		func(string) (Service, fail.Error) {
+			if atomic.AddInt32(&builds, 1) == 1 {
+				return NullService(), fail.NotAvailableError("provider unreachable")
+			}
+			return &service{}, nil
+		},
+		func() (map[string]string, fail.Error) { return map[string]string{"t1": "a"}, nil },
+		0,
+	)
+
+	_, xerr := p.get("t1")
+	require.NotNil(t, xerr)
+
+	_, xerr = p.get("t1")
+	require.Nil(t, xerr)
+	assert.EqualValues(t, 2, builds)
+}
+
+func Test_servicePool_ConcurrentSingleBuild(t *testing.T) {
+	var builds int32
+	release := make(chan struct{})
+	p := newServicePool(
+		func(string) (Service, fail.Error) {
+			atomic.AddInt32(&builds, 1)
+			<-release
+			return &service{}, nil
+		},
+		func() (map[string]string, fail.Error) { return map[string]string{"t1": "a"}, nil },
+		0,
+	)
+
+	const count = 10
+	results := make([]Service, count)
+	var wg sync.WaitGroup
+	wg.Add(count)
+	for i := 0; i < count; i++ {
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = p.get("t1")
+		}(i)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, builds)
+	for _, s := range results {
+		assert.True(t, s == results[0])
+	}
+}
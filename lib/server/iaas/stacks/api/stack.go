@@ -23,6 +23,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/iaas/userdata"
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hoststate"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/taggableresource"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
 )
 
@@ -129,6 +130,10 @@ type Stack interface {
 	StopHost(stacks.HostParameter) fail.Error
 	// StartHost starts the host identified by id
 	StartHost(stacks.HostParameter) fail.Error
+	// PreserveHostPublicIP makes static the public IP of the host, so that it is kept when the host is stopped, and returns it
+	PreserveHostPublicIP(stacks.HostParameter) (string, fail.Error)
+	// CreateImageFromHost captures the disk of the host (expected to be stopped) in a new image named imageName, and waits for the image to be available
+	CreateImageFromHost(hostParam stacks.HostParameter, imageName string) (*abstract.Image, fail.Error)
 	// RebootHost reboots a host
 	RebootHost(stacks.HostParameter) fail.Error
 	// ResizeHost resizes an host
@@ -139,6 +144,10 @@ type Stack interface {
 	BindSecurityGroupToHost(sgParam stacks.SecurityGroupParameter, hostParam stacks.HostParameter) fail.Error
 	// UnbindSecurityGroupFromHost detaches a security group from an host
 	UnbindSecurityGroupFromHost(sgParam stacks.SecurityGroupParameter, hostParam stacks.HostParameter) fail.Error
+	// AttachHostToSubnet adds to an host a network interface connected to the subnet, returning the private IP address allocated
+	AttachHostToSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) (string, fail.Error)
+	// DetachHostFromSubnet removes the network interface of an host connected to the subnet
+	DetachHostFromSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) fail.Error
 
 	// CreateVolume creates a block volume
 	CreateVolume(request abstract.VolumeRequest) (*abstract.Volume, fail.Error)
@@ -157,6 +166,9 @@ type Stack interface {
 	ListVolumeAttachments(serverID string) ([]abstract.VolumeAttachment, fail.Error)
 	// DeleteVolumeAttachment deletes the volume attachment identified by id
 	DeleteVolumeAttachment(serverID, id string) fail.Error
+
+	// TagResource adds tags to the resource of type 'kind' identified by id, keeping its other tags (if the stack can do it)
+	TagResource(kind taggableresource.Enum, id string, tags map[string]string) fail.Error
 }
 
 // ReservedForProviderUse is an interface about the methods only available to providers internally
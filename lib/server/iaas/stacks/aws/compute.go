@@ -29,6 +29,8 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/iaas/userdata"
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hoststate"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hosttenancy"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/taggableresource"
 	"github.com/CS-SI/SafeScale/lib/server/resources/operations/converters"
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
 	"github.com/CS-SI/SafeScale/lib/utils/debug/tracing"
@@ -503,6 +505,18 @@ func (s stack) CreateHost(request abstract.HostRequest) (ahf *abstract.HostFull,
 
 	logrus.Debugf("Selected template: '%s', '%s'", template.ID, template.Name)
 
+	// A Host placed on a dedicated host is created in the availability zone of the dedicated host
+	zone := s.AwsConfig.Zone
+	if request.Tenancy != hosttenancy.Default && request.Preemptible {
+		return nullAHF, nullUDC, fail.InvalidRequestError("a preemptible Host cannot be placed with tenancy '%s'", request.Tenancy.String())
+	}
+	if request.DedicatedHostID != "" {
+		zone, xerr = s.checkDedicatedHostCapacity(request.DedicatedHostID, template.ID)
+		if xerr != nil {
+			return nullAHF, nullUDC, xerr
+		}
+	}
+
 	// Select usable availability zone, the first one in the list
 	if s.AwsConfig.Zone == "" {
 		azList, xerr := s.ListAvailabilityZones()
@@ -516,6 +530,9 @@ func (s stack) CreateHost(request abstract.HostRequest) (ahf *abstract.HostFull,
 		s.AwsConfig.Zone = az
 		logrus.Debugf("Selected Availability Zone: '%s'", az)
 	}
+	if zone == "" {
+		zone = s.AwsConfig.Zone
+	}
 
 	// --- Initializes resources.IPAddress ---
 
@@ -557,9 +574,9 @@ func (s stack) CreateHost(request abstract.HostRequest) (ahf *abstract.HostFull,
 				innerXErr fail.Error
 			)
 			if request.Preemptible {
-				server, innerXErr = s.buildAwsSpotMachine(keyPairName, request.ResourceName, rim.ID, s.AwsConfig.Zone, defaultSubnet.ID, string(userDataPhase1), publicIP, template)
+				server, innerXErr = s.buildAwsSpotMachine(keyPairName, request.ResourceName, rim.ID, zone, defaultSubnet.ID, string(userDataPhase1), publicIP, template)
 			} else {
-				server, innerXErr = s.buildAwsMachine(keyPairName, request.ResourceName, rim.ID, s.AwsConfig.Zone, defaultSubnet.ID, string(userDataPhase1), publicIP, template)
+				server, innerXErr = s.buildAwsMachine(keyPairName, request.ResourceName, rim.ID, zone, defaultSubnet.ID, string(userDataPhase1), publicIP, template, request.Tenancy, request.DedicatedHostID)
 			}
 			if innerXErr != nil {
 				switch innerXErr.(type) {
@@ -667,9 +684,18 @@ func (s stack) buildAwsMachine(
 	data string,
 	publicIP bool,
 	template abstract.HostTemplate,
+	tenancy hosttenancy.Enum,
+	dedicatedHostID string,
 ) (*abstract.HostCore, fail.Error) {
 
-	instance, xerr := s.rpcRunInstance(aws.String(name), aws.String(zone), aws.String(subnetID), aws.String(template.ID), aws.String(imageID), aws.String(keypairName), aws.Bool(publicIP), []byte(data))
+	var placement placementParameters
+	if tenancy != hosttenancy.Default {
+		placement.tenancy = aws.String(tenancy.String())
+	}
+	if dedicatedHostID != "" {
+		placement.hostID = aws.String(dedicatedHostID)
+	}
+	instance, xerr := s.rpcRunInstance(aws.String(name), aws.String(zone), aws.String(subnetID), aws.String(template.ID), aws.String(imageID), aws.String(keypairName), aws.Bool(publicIP), placement, []byte(data))
 	if xerr != nil {
 		return nil, xerr
 	}
@@ -681,12 +707,60 @@ func (s stack) buildAwsMachine(
 	return &hostCore, nil
 }
 
+// checkDedicatedHostCapacity checks the dedicated host exists and can still receive an instance of the template
+// Returns the availability zone of the dedicated host
+func (s stack) checkDedicatedHostCapacity(hostID, templateID string) (string, fail.Error) {
+	dh, xerr := s.rpcDescribeHostByID(aws.String(hostID))
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotFound:
+			return "", fail.NotFoundError("failed to find dedicated host '%s'", hostID)
+		default:
+			return "", xerr
+		}
+	}
+
+	if state := aws.StringValue(dh.State); state != ec2.AllocationStateAvailable {
+		return "", fail.NotAvailableError("dedicated host '%s' is not available (state '%s')", hostID, state)
+	}
+	if dh.AvailableCapacity != nil {
+		for _, v := range dh.AvailableCapacity.AvailableInstanceCapacity {
+			if aws.StringValue(v.InstanceType) == templateID && aws.Int64Value(v.AvailableCapacity) > 0 {
+				return aws.StringValue(dh.AvailabilityZone), nil
+			}
+		}
+	}
+	return "", fail.NotAvailableError("dedicated host '%s' has no capacity left for template '%s'", hostID, templateID)
+}
+
 // ClearHostStartupScript clears the userdata startup script for Host instance (metadata service)
 // FIXME: see if anything is needed (does nothing for now)
 func (s stack) ClearHostStartupScript(hostParam stacks.HostParameter) fail.Error {
 	return nil
 }
 
+// TagResource adds tags to a resource (instance, VPC or subnet), keeping its other tags
+func (s stack) TagResource(kind taggableresource.Enum, id string, tags map[string]string) (xerr fail.Error) {
+	if s.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if id == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("id")
+	}
+
+	defer debug.NewTracer(nil, tracing.ShouldTrace("stack.aws") || tracing.ShouldTrace("stacks.compute"), "(%s, %s)", kind, id).WithStopwatch().Entering().Exiting()
+	defer fail.OnPanic(&xerr)
+
+	awsTags := make([]*ec2.Tag, 0, len(tags))
+	for k, v := range tags {
+		awsTags = append(awsTags, &ec2.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+	return s.rpcCreateTags(aws.StringSlice([]string{id}), awsTags)
+}
+
 // InspectHost loads information of a host from AWS
 func (s stack) InspectHost(hostParam stacks.HostParameter) (ahf *abstract.HostFull, xerr fail.Error) {
 	nullAHF := abstract.NewHostFull()
@@ -927,6 +1001,13 @@ func (s stack) DeleteHost(hostParam stacks.HostParameter) fail.Error {
 	}
 	var errors []error
 	for _, ip := range ips {
+		// an Elastic IP associated to the instance (see PreserveHostPublicIP) cannot be released before being disassociated
+		if ip.AssociationId != nil {
+			if derr := s.rpcDisassociateAddress(ip.AssociationId); derr != nil {
+				errors = append(errors, fail.Wrap(derr, "cleaning up on failure, failed to disassociate IP address"))
+				continue
+			}
+		}
 		if derr := s.rpcReleaseAddress(ip.AllocationId); derr != nil {
 			errors = append(errors, fail.Wrap(derr, "cleaning up on failure, failed to delete IP address"))
 		}
@@ -1101,6 +1182,124 @@ func (s stack) StopHost(hostParam stacks.HostParameter) (xerr fail.Error) {
 	return nil
 }
 
+// PreserveHostPublicIP associates an Elastic IP to the host, so that its public IP is kept when the host is stopped, and
+// returns this public IP
+// The public IP allocated by AWS at launch cannot become an Elastic IP, so the public IP of the host changes once, unless an
+// Elastic IP is already associated to the instance
+func (s stack) PreserveHostPublicIP(hostParam stacks.HostParameter) (_ string, xerr fail.Error) {
+	if s.IsNull() {
+		return "", fail.InvalidInstanceError()
+	}
+	ahf, hostRef, xerr := stacks.ValidateHostParameter(hostParam)
+	if xerr != nil {
+		return "", xerr
+	}
+
+	defer debug.NewTracer(nil, tracing.ShouldTrace("stack.aws") || tracing.ShouldTrace("stacks.compute"), "(%s)", hostRef).WithStopwatch().Entering().Exiting()
+	defer fail.OnExitTraceError(&xerr)
+	defer fail.OnPanic(&xerr)
+
+	ips, xerr := s.rpcDescribeAddresses([]*string{aws.String(ahf.Core.ID)})
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotFound:
+			// continue
+		default:
+			return "", xerr
+		}
+	}
+	for _, ip := range ips {
+		if publicIP := aws.StringValue(ip.PublicIp); publicIP != "" {
+			return publicIP, nil
+		}
+	}
+
+	address, xerr := s.rpcAllocateAddress()
+	if xerr != nil {
+		return "", fail.Wrap(xerr, "failed to allocate Elastic IP for host '%s'", hostRef)
+	}
+
+	defer func() {
+		if xerr != nil {
+			if derr := s.rpcReleaseAddress(address.AllocationId); derr != nil {
+				_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on failure, failed to release Elastic IP '%s'", aws.StringValue(address.PublicIp)))
+			}
+		}
+	}()
+
+	// Names the Elastic IP after the host, to identify it in the console; not critical
+	if derr := s.rpcCreateTags([]*string{address.AllocationId}, []*ec2.Tag{{Key: awsTagNameLabel, Value: aws.String("publicip-" + hostRef)}}); derr != nil {
+		logrus.Warnf("failed to tag Elastic IP '%s' of host '%s': %v", aws.StringValue(address.PublicIp), hostRef, derr)
+	}
+
+	if xerr = s.rpcAssociateAddress(address.AllocationId, aws.String(ahf.Core.ID)); xerr != nil {
+		return "", fail.Wrap(xerr, "failed to associate Elastic IP to host '%s'", hostRef)
+	}
+
+	return aws.StringValue(address.PublicIp), nil
+}
+
+// CreateImageFromHost creates an AMI from the instance, and waits for the AMI to be available
+func (s stack) CreateImageFromHost(hostParam stacks.HostParameter, imageName string) (_ *abstract.Image, xerr fail.Error) {
+	if s.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	ahf, hostRef, xerr := stacks.ValidateHostParameter(hostParam)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if imageName == "" {
+		return nil, fail.InvalidParameterCannotBeEmptyStringError("imageName")
+	}
+
+	defer debug.NewTracer(nil, tracing.ShouldTrace("stack.aws") || tracing.ShouldTrace("stacks.compute"), "(%s, %s)", hostRef, imageName).WithStopwatch().Entering().Exiting()
+	defer fail.OnExitTraceError(&xerr)
+	defer fail.OnPanic(&xerr)
+
+	imageID, xerr := s.rpcCreateImage(aws.String(ahf.Core.ID), aws.String(imageName))
+	if xerr != nil {
+		return nil, fail.Wrap(xerr, "failed to create image from host '%s'", hostRef)
+	}
+
+	var out abstract.Image
+	retryErr := retry.WhileUnsuccessful(
+		func() error {
+			resp, innerXErr := s.rpcDescribeImageByID(imageID)
+			if innerXErr != nil {
+				return innerXErr
+			}
+
+			switch state := aws.StringValue(resp.State); state {
+			case ec2.ImageStateAvailable:
+				out = toAbstractImage(*resp)
+				return nil
+			case ec2.ImageStateFailed, ec2.ImageStateError, ec2.ImageStateInvalid, ec2.ImageStateDeregistered:
+				reason := state
+				if resp.StateReason != nil {
+					reason = aws.StringValue(resp.StateReason.Message)
+				}
+				return retry.StopRetryError(fail.NewError("image '%s' in state '%s': %s", aws.StringValue(imageID), state, reason))
+			default:
+				return fail.NotAvailableError("image '%s' not available yet (current state: %s)", aws.StringValue(imageID), state)
+			}
+		},
+		temporal.GetDefaultDelay(),
+		temporal.GetLongOperationTimeout(),
+	)
+	if retryErr != nil {
+		switch retryErr.(type) {
+		case *retry.ErrStopRetry:
+			return nil, fail.Wrap(retryErr.Cause(), "failed to create image from host '%s'", hostRef)
+		case *retry.ErrTimeout:
+			return nil, fail.Wrap(retryErr.Cause(), "timeout waiting image '%s' of host '%s' to be available after %v", aws.StringValue(imageID), hostRef, temporal.GetLongOperationTimeout())
+		default:
+			return nil, retryErr
+		}
+	}
+
+	return &out, nil
+}
+
 // StartHost starts a stopped host
 func (s stack) StartHost(hostParam stacks.HostParameter) (xerr fail.Error) {
 	if s.IsNull() {
@@ -1319,3 +1518,13 @@ func (s stack) UnbindSecurityGroupFromHost(sgParam stacks.SecurityGroupParameter
 
 	return s.rpcModifyInstanceSecurityGroups(aws.String(ahf.GetID()), sgs)
 }
+
+// AttachHostToSubnet adds to the host a network interface connected to the subnet
+func (s stack) AttachHostToSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) (string, fail.Error) {
+	return "", fail.NotImplementedError("AttachHostToSubnet() not implemented yet") // FIXME: Technical debt
+}
+
+// DetachHostFromSubnet removes the network interface of the host connected to the subnet
+func (s stack) DetachHostFromSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) fail.Error {
+	return fail.NotImplementedError("DetachHostFromSubnet() not implemented yet") // FIXME: Technical debt
+}
@@ -25,16 +25,28 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/sirupsen/logrus"
 
+	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/utils/debug/callstack"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
 )
 
-// normalizeError translates AWS error to SafeScale one
+// normalizeError translates AWS error to SafeScale one, keeping AWS error code and message as annotations
 func normalizeError(err error) fail.Error {
 	if err == nil {
 		return nil
 	}
 
+	out := qualifyError(err)
+	if cerr, ok := err.(awserr.Error); ok && out != nil {
+		_ = out.Annotate(abstract.ProviderErrorCodeAnnotation, cerr.Code())
+		_ = out.Annotate(abstract.ProviderErrorMessageAnnotation, cerr.Message())
+	}
+	return out
+}
+
+// qualifyError converts AWS error to the corresponding fail.Error
+func qualifyError(err error) fail.Error {
+
 	switch cerr := err.(type) { //nolint
 	case awserr.Error:
 		switch cerr.Code() {
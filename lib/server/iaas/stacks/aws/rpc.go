@@ -742,7 +742,46 @@ func (s stack) rpcAuthorizeSecurityGroupEgress(id *string, egress []*ec2.IpPermi
 	)
 }
 
-func (s stack) rpcDisassociateAddress(id *string) fail.Error { // nolint
+func (s stack) rpcAllocateAddress() (*ec2.AllocateAddressOutput, fail.Error) {
+	request := ec2.AllocateAddressInput{
+		Domain: aws.String("vpc"),
+	}
+	var resp *ec2.AllocateAddressOutput
+	xerr := stacks.RetryableRemoteCall(
+		func() (err error) {
+			resp, err = s.EC2Service.AllocateAddress(&request)
+			return err
+		},
+		normalizeError,
+	)
+	if xerr != nil {
+		return &ec2.AllocateAddressOutput{}, xerr
+	}
+	return resp, nil
+}
+
+func (s stack) rpcAssociateAddress(allocationID, instanceID *string) fail.Error {
+	if xerr := validateAWSString(allocationID, "allocationID", true); xerr != nil {
+		return xerr
+	}
+	if xerr := validateAWSString(instanceID, "instanceID", true); xerr != nil {
+		return xerr
+	}
+
+	request := ec2.AssociateAddressInput{
+		AllocationId: allocationID,
+		InstanceId:   instanceID,
+	}
+	return stacks.RetryableRemoteCall(
+		func() error {
+			_, err := s.EC2Service.AssociateAddress(&request)
+			return err
+		},
+		normalizeError,
+	)
+}
+
+func (s stack) rpcDisassociateAddress(id *string) fail.Error {
 	if xerr := validateAWSString(id, "id", true); xerr != nil {
 		return xerr
 	}
@@ -793,6 +832,34 @@ func (s stack) rpcDescribeInstanceByID(id *string) (*ec2.Instance, fail.Error) {
 	return resp[0], nil
 }
 
+func (s stack) rpcDescribeHostByID(id *string) (*ec2.Host, fail.Error) {
+	if xerr := validateAWSString(id, "id", true); xerr != nil {
+		return &ec2.Host{}, xerr
+	}
+
+	request := ec2.DescribeHostsInput{
+		HostIds: []*string{id},
+	}
+	var resp *ec2.DescribeHostsOutput
+	xerr := stacks.RetryableRemoteCall(
+		func() (err error) {
+			resp, err = s.EC2Service.DescribeHosts(&request)
+			return err
+		},
+		normalizeError,
+	)
+	if xerr != nil {
+		return &ec2.Host{}, xerr
+	}
+	if len(resp.Hosts) == 0 {
+		return &ec2.Host{}, fail.NotFoundError("failed to find a dedicated host with ID %s", aws.StringValue(id))
+	}
+	if len(resp.Hosts) > 1 {
+		return &ec2.Host{}, fail.InconsistentError("found more than one dedicated host with ID %s", aws.StringValue(id))
+	}
+	return resp.Hosts[0], nil
+}
+
 func (s stack) rpcDescribeInstanceByName(name *string) (*ec2.Instance, fail.Error) {
 	if xerr := validateAWSString(name, "name", true); xerr != nil {
 		return &ec2.Instance{}, xerr
@@ -1115,6 +1182,33 @@ func (s stack) rpcDescribeImageByID(id *string) (*ec2.Image, fail.Error) {
 	return resp[0], nil
 }
 
+func (s stack) rpcCreateImage(instanceID, name *string) (*string, fail.Error) {
+	if xerr := validateAWSString(instanceID, "instanceID", true); xerr != nil {
+		return nil, xerr
+	}
+	if xerr := validateAWSString(name, "name", true); xerr != nil {
+		return nil, xerr
+	}
+
+	request := ec2.CreateImageInput{
+		InstanceId: instanceID,
+		Name:       name,
+		NoReboot:   aws.Bool(true), // the instance is stopped beforehand when needed, AWS must not restart it
+	}
+	var resp *ec2.CreateImageOutput
+	xerr := stacks.RetryableRemoteCall(
+		func() (err error) {
+			resp, err = s.EC2Service.CreateImage(&request)
+			return err
+		},
+		normalizeError,
+	)
+	if xerr != nil {
+		return nil, xerr
+	}
+	return resp.ImageId, nil
+}
+
 func (s stack) rpcModifyInstanceSecurityGroups(id *string, sgIDs []*string) fail.Error {
 	if xerr := validateAWSString(id, "id", true); xerr != nil {
 		return xerr
@@ -1354,7 +1448,13 @@ func (s stack) rpcRequestSpotInstance(price, zone, subnetID *string, publicIP *b
 	return resp.SpotInstanceRequests[0], nil
 }
 
-func (s stack) rpcRunInstance(name, zone, subnetID, templateID, imageID, keypairName *string, publicIP *bool, userdata []byte) (*ec2.Instance, fail.Error) {
+// placementParameters contains the optional placement of an instance on dedicated hardware
+type placementParameters struct {
+	tenancy *string
+	hostID  *string
+}
+
+func (s stack) rpcRunInstance(name, zone, subnetID, templateID, imageID, keypairName *string, publicIP *bool, placement placementParameters, userdata []byte) (*ec2.Instance, fail.Error) {
 	nullInstance := &ec2.Instance{}
 	if xerr := validateAWSString(name, "name", true); xerr != nil {
 		return nullInstance, xerr
@@ -1386,6 +1486,8 @@ func (s stack) rpcRunInstance(name, zone, subnetID, templateID, imageID, keypair
 		MinCount:     aws.Int64(1),
 		Placement: &ec2.Placement{
 			AvailabilityZone: zone,
+			Tenancy:          placement.tenancy,
+			HostId:           placement.hostID,
 		},
 		NetworkInterfaces: []*ec2.InstanceNetworkInterfaceSpecification{
 			{
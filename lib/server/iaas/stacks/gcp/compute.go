@@ -29,6 +29,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/iaas/userdata"
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hoststate"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/taggableresource"
 	"github.com/CS-SI/SafeScale/lib/server/resources/operations/converters"
 	"github.com/CS-SI/SafeScale/lib/utils"
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
@@ -439,6 +440,31 @@ func (s stack) ClearHostStartupScript(hostParam stacks.HostParameter) fail.Error
 	return s.rpcResetStartupScriptOfInstance(ahf.GetID())
 }
 
+// PreserveHostPublicIP returns the public IP of the host; public IPs of hosts are static external addresses, already kept when the host is stopped
+func (s stack) PreserveHostPublicIP(hostParam stacks.HostParameter) (string, fail.Error) {
+	if s.IsNull() {
+		return "", fail.InvalidInstanceError()
+	}
+
+	ahf, xerr := s.InspectHost(hostParam)
+	if xerr != nil {
+		return "", xerr
+	}
+	return ahf.Networking.PublicIPv4, nil
+}
+
+// CreateImageFromHost creates an image from the disk of the host
+// FIXME: not implemented for now
+func (s stack) CreateImageFromHost(hostParam stacks.HostParameter, imageName string) (*abstract.Image, fail.Error) {
+	return nil, fail.NotAvailableError("creation of image from host not available with gcp stack")
+}
+
+// TagResource adds tags to a resource
+// FIXME: not implemented for now, labels of GCP have restrictions on keys and values to take into account
+func (s stack) TagResource(kind taggableresource.Enum, id string, tags map[string]string) fail.Error {
+	return fail.NotImplementedError("tagging of %s not implemented by gcp stack", kind)
+}
+
 // InspectHost returns the host identified by ref (name or id) or by a *abstract.HostFull containing an id
 func (s stack) InspectHost(hostParam stacks.HostParameter) (host *abstract.HostFull, xerr fail.Error) {
 	nullAHF := abstract.NewHostFull()
@@ -824,3 +850,13 @@ func (s stack) UnbindSecurityGroupFromHost(sgParam stacks.SecurityGroupParameter
 
 	return s.rpcRemoveTagsFromInstance(ahf.GetID(), []string{asg.GetID()})
 }
+
+// AttachHostToSubnet adds to the host a network interface connected to the subnet
+func (s stack) AttachHostToSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) (string, fail.Error) {
+	return "", fail.NotImplementedError("AttachHostToSubnet() not implemented yet") // FIXME: Technical debt
+}
+
+// DetachHostFromSubnet removes the network interface of the host connected to the subnet
+func (s stack) DetachHostFromSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) fail.Error {
+	return fail.NotImplementedError("DetachHostFromSubnet() not implemented yet") // FIXME: Technical debt
+}
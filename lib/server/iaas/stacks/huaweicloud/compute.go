@@ -42,6 +42,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hoststate"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/ipversion"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/taggableresource"
 	"github.com/CS-SI/SafeScale/lib/server/resources/operations/converters"
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
@@ -820,6 +821,21 @@ func (s stack) ListHosts(details bool) (abstract.HostList, fail.Error) {
 	return hostList, nil
 }
 
+// TagResource adds tags to a resource, keeping its other tags
+// Only Hosts can be tagged (in the metadata of the instance), Networks are VPCs without support of tags
+func (s stack) TagResource(kind taggableresource.Enum, id string, tags map[string]string) fail.Error {
+	if s.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+
+	switch kind {
+	case taggableresource.Host:
+		return s.Stack.TagResource(kind, id, tags)
+	default:
+		return fail.NotImplementedError("tagging of %s not implemented by huaweicloud stack", kind)
+	}
+}
+
 // DeleteHost deletes the host identified by id
 func (s stack) DeleteHost(hostParam stacks.HostParameter) fail.Error {
 	if s.IsNull() {
@@ -1193,6 +1193,16 @@ func (s stack) UnbindSecurityGroupFromHost(sgParam stacks.SecurityGroupParameter
 	return fail.NotImplementedError("not yet implemented")
 }
 
+// AttachHostToSubnet ...
+func (s stack) AttachHostToSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) (string, fail.Error) {
+	return "", fail.NotImplementedError("not yet implemented")
+}
+
+// DetachHostFromSubnet ...
+func (s stack) DetachHostFromSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) fail.Error {
+	return fail.NotImplementedError("not yet implemented")
+}
+
 func (s Stack) InspectTemplate(id string) (*abstract.HostTemplate, fail.Error) {
 	return &abstract.HostTemplate{}, nil
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/iaas/userdata"
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hoststate"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/taggableresource"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
 )
 
@@ -197,6 +198,21 @@ func (s stack) ClearHostStartupScript(stacks.HostParameter) fail.Error {
 	return gError
 }
 
+// PreserveHostPublicIP stub
+func (s stack) PreserveHostPublicIP(stacks.HostParameter) (string, fail.Error) {
+	return "", gError
+}
+
+// CreateImageFromHost stub
+func (s stack) CreateImageFromHost(stacks.HostParameter, string) (*abstract.Image, fail.Error) {
+	return nil, gError
+}
+
+// TagResource stub
+func (s stack) TagResource(taggableresource.Enum, string, map[string]string) fail.Error {
+	return gError
+}
+
 // ResizeHost stub
 func (s stack) ResizeHost(hostParam stacks.HostParameter, request abstract.HostSizingRequirements) (*abstract.HostFull, fail.Error) {
 	return abstract.NewHostFull(), gError
@@ -302,6 +318,16 @@ func (s stack) UnbindSecurityGroupFromHost(sgParam stacks.SecurityGroupParameter
 	return gError
 }
 
+// AttachHostToSubnet ...
+func (s stack) AttachHostToSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) (string, fail.Error) {
+	return "", gError
+}
+
+// DetachHostFromSubnet ...
+func (s stack) DetachHostFromSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) fail.Error {
+	return gError
+}
+
 // BindSecurityGroupToSubnet ...
 func (s stack) BindSecurityGroupToSubnet(sgParam stacks.SecurityGroupParameter, subnetID string) fail.Error {
 	return gError
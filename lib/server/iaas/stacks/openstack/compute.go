@@ -18,6 +18,7 @@ package openstack
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -43,6 +44,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hoststate"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/ipversion"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/taggableresource"
 	"github.com/CS-SI/SafeScale/lib/server/resources/operations/converters"
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
 	"github.com/CS-SI/SafeScale/lib/utils/debug/tracing"
@@ -867,6 +869,143 @@ func (s Stack) ClearHostStartupScript(hostParam stacks.HostParameter) fail.Error
 	return nil
 }
 
+// PreserveHostPublicIP returns the public IP of the host; public IPs of hosts are floating IPs, already kept when the host is stopped
+func (s Stack) PreserveHostPublicIP(hostParam stacks.HostParameter) (string, fail.Error) {
+	if s.IsNull() {
+		return "", fail.InvalidInstanceError()
+	}
+
+	ahf, xerr := s.InspectHost(hostParam)
+	if xerr != nil {
+		return "", xerr
+	}
+	return ahf.Networking.PublicIPv4, nil
+}
+
+// CreateImageFromHost creates an image from the server, and waits for the image to be active
+func (s Stack) CreateImageFromHost(hostParam stacks.HostParameter, imageName string) (_ *abstract.Image, xerr fail.Error) {
+	if s.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	ahf, hostRef, xerr := stacks.ValidateHostParameter(hostParam)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if imageName == "" {
+		return nil, fail.InvalidParameterCannotBeEmptyStringError("imageName")
+	}
+
+	defer debug.NewTracer(nil, tracing.ShouldTrace("stack.openstack") || tracing.ShouldTrace("stacks.compute"), "(%s, %s)", hostRef, imageName).WithStopwatch().Entering().Exiting()
+	defer fail.OnPanic(&xerr)
+
+	var imageID string
+	xerr = stacks.RetryableRemoteCall(
+		func() (innerErr error) {
+			imageID, innerErr = servers.CreateImage(s.ComputeClient, ahf.Core.ID, servers.CreateImageOpts{Name: imageName}).ExtractImageID()
+			return innerErr
+		},
+		NormalizeError,
+	)
+	if xerr != nil {
+		return nil, fail.Wrap(xerr, "failed to create image from host '%s'", hostRef)
+	}
+
+	var out abstract.Image
+	retryErr := retry.WhileUnsuccessful(
+		func() error {
+			var img *images.Image
+			innerXErr := stacks.RetryableRemoteCall(
+				func() (innerErr error) {
+					img, innerErr = images.Get(s.ComputeClient, imageID).Extract()
+					return innerErr
+				},
+				NormalizeError,
+			)
+			if innerXErr != nil {
+				return innerXErr
+			}
+
+			switch img.Status {
+			case images.ImageStatusActive:
+				out = abstract.Image{
+					ID:       img.ID,
+					Name:     img.Name,
+					DiskSize: int64(img.MinDiskGigabytes),
+				}
+				return nil
+			case images.ImageStatusKilled, images.ImageStatusDeleted:
+				return retry.StopRetryError(fail.NewError("image '%s' in status '%s'", imageID, img.Status))
+			default:
+				return fail.NotAvailableError("image '%s' not available yet (current status: %s)", imageID, img.Status)
+			}
+		},
+		temporal.GetDefaultDelay(),
+		temporal.GetLongOperationTimeout(),
+	)
+	if retryErr != nil {
+		switch retryErr.(type) {
+		case *retry.ErrStopRetry:
+			return nil, fail.Wrap(retryErr.Cause(), "failed to create image from host '%s'", hostRef)
+		case *retry.ErrTimeout:
+			return nil, fail.Wrap(retryErr.Cause(), "timeout waiting image '%s' of host '%s' to be available after %v", imageID, hostRef, temporal.GetLongOperationTimeout())
+		default:
+			return nil, retryErr
+		}
+	}
+
+	return &out, nil
+}
+
+// TagResource adds tags to a resource, keeping its other tags
+// Tags of a Host are stored in the metadata of the instance; tags of a Network or a Subnet are stored as neutron tags
+// formatted as "key=value"
+func (s Stack) TagResource(kind taggableresource.Enum, id string, tags map[string]string) (xerr fail.Error) {
+	if s.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if id == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("id")
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	defer debug.NewTracer(nil, tracing.ShouldTrace("Stack.openstack") || tracing.ShouldTrace("stacks.compute"), "(%s, %s)", kind, id).WithStopwatch().Entering().Exiting()
+	defer fail.OnPanic(&xerr)
+
+	switch kind {
+	case taggableresource.Host:
+		return s.rpcUpdateMetadataOfInstance(id, tags)
+	case taggableresource.Network:
+		return s.rpcMergeNeutronTags("networks", id, tags)
+	case taggableresource.Subnet:
+		return s.rpcMergeNeutronTags("subnets", id, tags)
+	default:
+		return fail.InvalidParameterError("kind", "unsupported type of resource '%s'", kind)
+	}
+}
+
+// mergeNeutronTags returns the neutron tags 'current' where the tags "key=value" built from 'tags' replace the ones
+// with the same keys
+func mergeNeutronTags(current []string, tags map[string]string) []string {
+	out := make([]string, 0, len(current)+len(tags))
+	for _, v := range current {
+		key := strings.SplitN(v, "=", 2)[0]
+		if _, ok := tags[key]; !ok {
+			out = append(out, v)
+		}
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		out = append(out, k+"="+tags[k])
+	}
+	return out
+}
+
 func (s Stack) GetMetadataOfInstance(id string) (map[string]string, fail.Error) {
 	return s.rpcGetMetadataOfInstance(id)
 }
@@ -926,6 +1065,28 @@ func (s Stack) identifyOpenstackSubnetsAndPorts(request abstract.HostRequest, de
 		netPorts = append(netPorts, *port)
 	}
 
+	// additional interfaces
+	for k, v := range request.AdditionalInterfaces {
+		if v.Subnet == nil {
+			return nets, netPorts, createdPorts, fail.InvalidParameterError("request.AdditionalInterfaces", "cannot contain an interface without Subnet")
+		}
+
+		req := ports.CreateOpts{
+			NetworkID:   v.Subnet.Network,
+			Name:        fmt.Sprintf("nic_%s_additional_%d", request.ResourceName, k),
+			Description: fmt.Sprintf("additional nic of host '%s' on subnet '%s'", request.ResourceName, v.Subnet.Name),
+			FixedIPs:    []ports.IP{{SubnetID: v.Subnet.ID, IPAddress: v.IPAddress}},
+		}
+		port, xerr := s.rpcCreatePort(req)
+		if xerr != nil {
+			return nets, netPorts, createdPorts, fail.Wrap(xerr, "failed to create additional port on subnet '%s'", v.Subnet.Name)
+		}
+
+		createdPorts = append(createdPorts, port.ID)
+		nets = append(nets, servers.Network{Port: port.ID})
+		netPorts = append(netPorts, *port)
+	}
+
 	return nets, netPorts, createdPorts, nil
 }
 
@@ -1488,3 +1649,92 @@ func (s Stack) UnbindSecurityGroupFromHost(sgParam stacks.SecurityGroupParameter
 		NormalizeError,
 	)
 }
+
+// AttachHostToSubnet adds to the host a network interface connected to the subnet, returning the private IP address allocated
+func (s Stack) AttachHostToSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) (_ string, xerr fail.Error) {
+	if s.IsNull() {
+		return "", fail.InvalidInstanceError()
+	}
+	ahf, hostRef, xerr := stacks.ValidateHostParameter(hostParam)
+	if xerr != nil {
+		return "", xerr
+	}
+	if subnet == nil {
+		return "", fail.InvalidParameterCannotBeNilError("subnet")
+	}
+
+	defer debug.NewTracer(nil, tracing.ShouldTrace("stack.openstack") || tracing.ShouldTrace("stacks.compute"), "(%s, %s)", hostRef, subnet.Name).WithStopwatch().Entering().Exiting()
+
+	req := ports.CreateOpts{
+		NetworkID:   subnet.Network,
+		Name:        fmt.Sprintf("nic_%s_subnet_%s", ahf.Core.Name, subnet.Name),
+		Description: fmt.Sprintf("nic of host '%s' on subnet '%s'", ahf.Core.Name, subnet.Name),
+		FixedIPs:    []ports.IP{{SubnetID: subnet.ID}},
+	}
+	port, xerr := s.rpcCreatePort(req)
+	if xerr != nil {
+		return "", fail.Wrap(xerr, "failed to create port on subnet '%s'", subnet.Name)
+	}
+
+	defer func() {
+		if xerr != nil {
+			if derr := s.rpcDeletePort(port.ID); derr != nil {
+				_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on failure, failed to delete port '%s'", port.ID))
+			}
+		}
+	}()
+
+	if xerr = s.rpcAttachInterface(ahf.Core.ID, port.ID); xerr != nil {
+		return "", fail.Wrap(xerr, "failed to attach port on subnet '%s' to host '%s'", subnet.Name, hostRef)
+	}
+
+	for _, v := range port.FixedIPs {
+		if v.SubnetID == subnet.ID {
+			return v.IPAddress, nil
+		}
+	}
+	return "", fail.InconsistentError("no IP address allocated on subnet '%s' to port '%s'", subnet.Name, port.ID)
+}
+
+// DetachHostFromSubnet removes the network interface of the host connected to the subnet
+func (s Stack) DetachHostFromSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) fail.Error {
+	if s.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	ahf, hostRef, xerr := stacks.ValidateHostParameter(hostParam)
+	if xerr != nil {
+		return xerr
+	}
+	if subnet == nil {
+		return fail.InvalidParameterCannotBeNilError("subnet")
+	}
+
+	defer debug.NewTracer(nil, tracing.ShouldTrace("stack.openstack") || tracing.ShouldTrace("stacks.compute"), "(%s, %s)", hostRef, subnet.Name).WithStopwatch().Entering().Exiting()
+
+	list, xerr := s.rpcListPorts(ports.ListOpts{DeviceID: ahf.Core.ID, NetworkID: subnet.Network})
+	if xerr != nil {
+		return fail.Wrap(xerr, "failed to list ports of host '%s'", hostRef)
+	}
+	for _, p := range list {
+		for _, v := range p.FixedIPs {
+			if v.SubnetID != subnet.ID {
+				continue
+			}
+
+			if xerr = s.rpcDetachInterface(ahf.Core.ID, p.ID); xerr != nil {
+				return fail.Wrap(xerr, "failed to detach port '%s' from host '%s'", p.ID, hostRef)
+			}
+			// The port may have been deleted with the interface, if created by Nova
+			if xerr = s.rpcDeletePort(p.ID); xerr != nil {
+				switch xerr.(type) {
+				case *fail.ErrNotFound:
+					// continue
+				default:
+					return fail.Wrap(xerr, "failed to delete port '%s'", p.ID)
+				}
+			}
+			return nil
+		}
+	}
+	return fail.NotFoundError("host '%s' has no interface on subnet '%s'", hostRef, subnet.Name)
+}
@@ -21,8 +21,10 @@ import (
 	"strings"
 
 	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/attachinterfaces"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/floatingips"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/attributestags"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
 	"github.com/gophercloud/gophercloud/pagination"
 
@@ -133,6 +135,49 @@ func (s Stack) rpcGetMetadataOfInstance(id string) (map[string]string, fail.Erro
 	return out, nil
 }
 
+// rpcUpdateMetadataOfInstance adds or replaces entries of the metadata of the instance, keeping the other ones
+func (s Stack) rpcUpdateMetadataOfInstance(id string, metadata map[string]string) fail.Error {
+	if id = strings.TrimSpace(id); id == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("id")
+	}
+
+	return stacks.RetryableRemoteCall(
+		func() error {
+			_, innerErr := servers.UpdateMetadata(s.ComputeClient, id, servers.MetadataOpts(metadata)).Extract()
+			return innerErr
+		},
+		NormalizeError,
+	)
+}
+
+// rpcMergeNeutronTags sets the tags "key=value" built from 'tags' on the neutron resource of type 'resourceType'
+// ("networks", "subnets", ...), replacing the tags with the same keys and keeping the other ones
+func (s Stack) rpcMergeNeutronTags(resourceType, id string, tags map[string]string) fail.Error {
+	if id == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("id")
+	}
+
+	var current []string
+	xerr := stacks.RetryableRemoteCall(
+		func() (innerErr error) {
+			current, innerErr = attributestags.List(s.NetworkClient, resourceType, id).Extract()
+			return innerErr
+		},
+		NormalizeError,
+	)
+	if xerr != nil {
+		return xerr
+	}
+
+	return stacks.RetryableRemoteCall(
+		func() error {
+			_, innerErr := attributestags.ReplaceAll(s.NetworkClient, resourceType, id, attributestags.ReplaceAllOpts{Tags: mergeNeutronTags(current, tags)}).Extract()
+			return innerErr
+		},
+		NormalizeError,
+	)
+}
+
 // rpcListServers lists servers
 func (s Stack) rpcListServers() ([]*servers.Server, fail.Error) {
 	var resp []*servers.Server
@@ -301,6 +346,41 @@ func (s Stack) rpcGetPort(id string) (port *ports.Port, xerr fail.Error) {
 	return port, nil
 }
 
+// rpcAttachInterface attaches the port 'portID' to the server 'serverID'
+func (s Stack) rpcAttachInterface(serverID, portID string) fail.Error {
+	if serverID == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("serverID")
+	}
+	if portID == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("portID")
+	}
+
+	return stacks.RetryableRemoteCall(
+		func() error {
+			_, innerErr := attachinterfaces.Create(s.ComputeClient, serverID, attachinterfaces.CreateOpts{PortID: portID}).Extract()
+			return innerErr
+		},
+		NormalizeError,
+	)
+}
+
+// rpcDetachInterface detaches the port 'portID' from the server 'serverID'
+func (s Stack) rpcDetachInterface(serverID, portID string) fail.Error {
+	if serverID == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("serverID")
+	}
+	if portID == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("portID")
+	}
+
+	return stacks.RetryableRemoteCall(
+		func() error {
+			return attachinterfaces.Delete(s.ComputeClient, serverID, portID).ExtractErr()
+		},
+		NormalizeError,
+	)
+}
+
 // rpcCreateFloatingIP creates a floating IP
 func (s Stack) rpcCreateFloatingIP() (*floatingips.FloatingIP, fail.Error) {
 	var resp *floatingips.FloatingIP
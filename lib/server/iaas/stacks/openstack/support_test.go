@@ -68,3 +68,13 @@ func TestNotGophercloudErrorCodes(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestMergeNeutronTags(t *testing.T) {
+	current := []string{"owner=alice", "cost-center=1", "untagged"}
+	merged := mergeNeutronTags(current, map[string]string{"cost-center": "2", "safescale-cluster": "c1"})
+	expected := "[owner=alice untagged cost-center=2 safescale-cluster=c1]"
+	if fmt.Sprint(merged) != expected {
+		t.Errorf("Received: %v, expected: %s", merged, expected)
+		t.FailNow()
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/iaas/userdata"
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hoststate"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/taggableresource"
 	"github.com/CS-SI/SafeScale/lib/utils"
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
@@ -1001,6 +1002,94 @@ func (s stack) ClearHostStartupScript(hostParam stacks.HostParameter) fail.Error
 	return nil
 }
 
+// PreserveHostPublicIP returns the public IP of the host; public IPs of hosts are allocated then linked to their NIC, already kept when the host is stopped
+func (s stack) PreserveHostPublicIP(hostParam stacks.HostParameter) (string, fail.Error) {
+	if s.IsNull() {
+		return "", fail.InvalidInstanceError()
+	}
+
+	ahf, xerr := s.InspectHost(hostParam)
+	if xerr != nil {
+		return "", xerr
+	}
+	return ahf.Networking.PublicIPv4, nil
+}
+
+// CreateImageFromHost creates an OMI from the VM, and waits for the OMI to be available
+func (s stack) CreateImageFromHost(hostParam stacks.HostParameter, imageName string) (_ *abstract.Image, xerr fail.Error) {
+	if s.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	ahf, hostRef, xerr := stacks.ValidateHostParameter(hostParam)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if imageName == "" {
+		return nil, fail.InvalidParameterCannotBeEmptyStringError("imageName")
+	}
+
+	tracer := debug.NewTracer(nil, true /*tracing.ShouldTrace("stacks.compute") || tracing.ShouldTrace("stack.outscale")*/, "(%s, %s)", hostRef, imageName).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	resp, xerr := s.rpcCreateImage(ahf.Core.ID, imageName)
+	if xerr != nil {
+		return nil, fail.Wrap(xerr, "failed to create image from host '%s'", hostRef)
+	}
+
+	var out abstract.Image
+	retryErr := retry.WhileUnsuccessful(
+		func() error {
+			img, innerXErr := s.rpcReadImageByID(resp.ImageId)
+			if innerXErr != nil {
+				return innerXErr
+			}
+
+			switch img.State {
+			case "available":
+				out = toAbstractImage(img)
+				return nil
+			case "failed", "deleting":
+				return retry.StopRetryError(fail.NewError("image '%s' in state '%s': %s", resp.ImageId, img.State, img.StateComment.StateMessage))
+			default:
+				return fail.NotAvailableError("image '%s' not available yet (current state: %s)", resp.ImageId, img.State)
+			}
+		},
+		temporal.GetDefaultDelay(),
+		temporal.GetLongOperationTimeout(),
+	)
+	if retryErr != nil {
+		switch retryErr.(type) {
+		case *retry.ErrStopRetry:
+			return nil, fail.Wrap(retryErr.Cause(), "failed to create image from host '%s'", hostRef)
+		case *retry.ErrTimeout:
+			return nil, fail.Wrap(retryErr.Cause(), "timeout waiting image '%s' of host '%s' to be available after %v", resp.ImageId, hostRef, temporal.GetLongOperationTimeout())
+		default:
+			return nil, retryErr
+		}
+	}
+
+	return &out, nil
+}
+
+// TagResource adds tags to a resource (VM, Net or Subnet), keeping its other tags
+func (s stack) TagResource(kind taggableresource.Enum, id string, tags map[string]string) fail.Error {
+	if s.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if id == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("id")
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	tracer := debug.NewTracer(nil, true /*tracing.ShouldTrace("stacks.compute") || tracing.ShouldTrace("stack.outscale")*/, "(%s, %s)", kind, id).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	_, xerr := s.rpcCreateTags(id, tags)
+	return xerr
+}
+
 // DeleteHost deletes the host identified by id
 func (s stack) DeleteHost(hostParam stacks.HostParameter) (xerr fail.Error) {
 	if s.IsNull() {
@@ -1340,3 +1429,13 @@ func (s stack) UnbindSecurityGroupFromHost(sgParam stacks.SecurityGroupParameter
 	// Update Security Groups of IPAddress
 	return s.rpcUpdateVMSecurityGroups(ahf.Core.ID, sgs)
 }
+
+// AttachHostToSubnet adds to the host a network interface connected to the subnet
+func (s stack) AttachHostToSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) (string, fail.Error) {
+	return "", fail.NotImplementedError("AttachHostToSubnet() not implemented yet") // FIXME: Technical debt
+}
+
+// DetachHostFromSubnet removes the network interface of the host connected to the subnet
+func (s stack) DetachHostFromSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) fail.Error {
+	return fail.NotImplementedError("DetachHostFromSubnet() not implemented yet") // FIXME: Technical debt
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/outscale/osc-sdk-go/osc"
 
+	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
 )
 
@@ -36,12 +37,16 @@ func normalizeError(err error) fail.Error {
 		case osc.ErrorResponse:
 			if len(model.Errors) > 0 {
 				merr := model.Errors[0]
-				if out := qualifyFromCode(merr.Code, merr.Details); out != nil {
-					return out
+				out := qualifyFromCode(merr.Code, merr.Details)
+				if out == nil {
+					reqID := model.ResponseContext.RequestId
+					out = fail.UnknownError("from outscale driver, code='%s', type='%s', details='%s', requestId='%s'", merr.Code, merr.Type, merr.Details, reqID)
 				}
-
-				reqID := model.ResponseContext.RequestId
-				return fail.UnknownError("from outscale driver, code='%s', type='%s', details='%s', requestId='%s'", merr.Code, merr.Type, merr.Details, reqID)
+				_ = out.Annotate(abstract.ProviderErrorCodeAnnotation, merr.Code)
+				if merr.Details != "" {
+					_ = out.Annotate(abstract.ProviderErrorMessageAnnotation, merr.Details)
+				}
+				return out
 			}
 			if out := qualifyFromBody(realErr.Body()); out != nil {
 				return out
@@ -1574,6 +1574,36 @@ func (s stack) rpcReadImages(ids []string) ([]osc.Image, fail.Error) {
 	return resp.Images, nil
 }
 
+func (s stack) rpcCreateImage(vmID, name string) (osc.Image, fail.Error) {
+	if vmID == "" {
+		return osc.Image{}, fail.InvalidParameterError("vmID", "cannot be empty string")
+	}
+	if name == "" {
+		return osc.Image{}, fail.InvalidParameterError("name", "cannot be empty string")
+	}
+
+	opts := osc.CreateImageOpts{
+		CreateImageRequest: optional.NewInterface(osc.CreateImageRequest{
+			ImageName: name,
+			NoReboot:  true, // the VM is stopped beforehand when needed, Outscale must not restart it
+			VmId:      vmID,
+		}),
+	}
+	var resp osc.CreateImageResponse
+	xerr := stacks.RetryableRemoteCall(
+		func() (err error) {
+			// FIXME: *http.Response must be taken into account for retries
+			resp, _, err = s.client.ImageApi.CreateImage(s.auth, &opts)
+			return err
+		},
+		normalizeError,
+	)
+	if xerr != nil {
+		return osc.Image{}, xerr
+	}
+	return resp.Image, nil
+}
+
 func (s stack) rpcReadImageByID(id string) (osc.Image, fail.Error) {
 	if id == "" {
 		return osc.Image{}, fail.InvalidParameterError("id", "cannot be empty string")
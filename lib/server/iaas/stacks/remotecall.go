@@ -17,6 +17,7 @@
 package stacks
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
@@ -74,3 +75,33 @@ func RetryableRemoteCall(callback func() error, convertError func(error) fail.Er
 	}
 	return nil
 }
+
+// BoundedRemoteCall calls a remote API through 'callback' and returns a fail.ErrTimeout if the call does not end within 'timeout'
+// The remote call cannot be interrupted: when the timeout is reached, it keeps running in background and its result is dropped,
+// but the caller (and the locks it holds) is released.
+func BoundedRemoteCall(name string, timeout time.Duration, callback func() fail.Error) fail.Error {
+	if callback == nil {
+		return fail.InvalidParameterCannotBeNilError("callback")
+	}
+	if timeout <= 0 {
+		return callback()
+	}
+
+	done := make(chan fail.Error, 1)
+	go func() {
+		var xerr fail.Error
+		defer func() {
+			done <- xerr
+		}()
+		defer fail.OnPanic(&xerr)
+
+		xerr = callback()
+	}()
+
+	select {
+	case xerr := <-done:
+		return xerr
+	case <-time.After(timeout):
+		return fail.TimeoutError(nil, timeout, fmt.Sprintf("provider call '%s' did not return in time", name))
+	}
+}
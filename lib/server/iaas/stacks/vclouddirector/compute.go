@@ -32,6 +32,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/iaas/userdata"
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hoststate"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/taggableresource"
 	"github.com/CS-SI/SafeScale/lib/utils"
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
 	"github.com/CS-SI/SafeScale/lib/utils/debug/tracing"
@@ -652,6 +653,24 @@ func (s stack) ClearHostStartupScript(hostParam stacks.HostParameter) fail.Error
 	return nil
 }
 
+// PreserveHostPublicIP makes static the public IP of the host
+// FIXME: not implemented for now
+func (s stack) PreserveHostPublicIP(hostParam stacks.HostParameter) (string, fail.Error) {
+	return "", fail.NotImplementedError("preservation of public IP not implemented by vclouddirector stack")
+}
+
+// CreateImageFromHost creates an image from the disk of the host
+// FIXME: not implemented for now
+func (s stack) CreateImageFromHost(hostParam stacks.HostParameter, imageName string) (*abstract.Image, fail.Error) {
+	return nil, fail.NotAvailableError("creation of image from host not available with vclouddirector stack")
+}
+
+// TagResource adds tags to a resource
+// FIXME: not implemented for now
+func (s stack) TagResource(kind taggableresource.Enum, id string, tags map[string]string) fail.Error {
+	return fail.NotImplementedError("tagging of %s not implemented by vclouddirector stack", kind)
+}
+
 // InspectHost returns the host identified by ref (name or id) or by a *abstract.IPAddress containing an id
 func (s *stack) InspectHost(hostParam stacks.HostParameter) (ahf *abstract.HostFull, xerr fail.Error) {
 	ahf = &abstract.HostFull{}
@@ -1016,3 +1035,13 @@ func (s *stack) BindSecurityGroupToHost(sgParam stacks.SecurityGroupParameter, h
 func (s *stack) UnbindSecurityGroupFromHost(sgParam stacks.SecurityGroupParameter, hostParam stacks.HostParameter) fail.Error {
 	return fail.NotImplementedError("not yet implemented")
 }
+
+// AttachHostToSubnet adds to the host a network interface connected to the subnet
+func (s *stack) AttachHostToSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) (string, fail.Error) {
+	return "", fail.NotImplementedError("AttachHostToSubnet() not implemented yet")
+}
+
+// DetachHostFromSubnet removes the network interface of the host connected to the subnet
+func (s *stack) DetachHostFromSubnet(hostParam stacks.HostParameter, subnet *abstract.Subnet) fail.Error {
+	return fail.NotImplementedError("DetachHostFromSubnet() not implemented yet")
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -36,6 +37,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/system"
 	"github.com/CS-SI/SafeScale/lib/utils"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
+	"github.com/CS-SI/SafeScale/lib/utils/strprocess"
 	"github.com/CS-SI/SafeScale/lib/utils/template"
 )
 
@@ -90,6 +92,8 @@ type Content struct {
 	EmulatedPublicNet string
 	// HostName contains the name wanted as host name (default == name of the Cloud resource)
 	HostName string
+	// Domain contains the DNS domain of the host, taken from HostName if it is a FQDN; used as search domain
+	Domain string
 	// Tags contains tags and their content(s); a tag is named #<tag> in the template
 	Tags map[Phase]map[string][]string
 	// IsPrimaryGateway tells if the host is a primary gateway
@@ -100,6 +104,9 @@ type Content struct {
 	// PrivateVIP string // VPL: change to defaultRouteIP
 	// GatewayHAKeepalivedPassword contains the password to use in keepalived configurations
 	GatewayHAKeepalivedPassword string
+	// GuestLabels contains the labels written in /etc/safescale/labels, as lines KEY='value' (sorted by key) with values
+	// escaped for shell (see abstract.HostRequest.GuestLabels)
+	GuestLabels string
 
 	ProviderName     string
 	BuildSubnetworks bool
@@ -207,6 +214,14 @@ func (ud *Content) Prepare(options stacks.ConfigurationOptions, request abstract
 	} else {
 		ud.HostName = request.ResourceName
 	}
+	if idx := strings.Index(ud.HostName, "."); idx > 0 {
+		ud.Domain = strings.Trim(ud.HostName[idx+1:], ".")
+	}
+
+	if xerr := abstract.ValidateGuestLabels(request.GuestLabels); xerr != nil {
+		return xerr
+	}
+	ud.GuestLabels = formatGuestLabels(request.GuestLabels)
 
 	// Generate a keypair for first SSH connection, that will then be replace by FinalPxxxKey during phase2
 	kp, xerr := abstract.NewKeyPair("")
@@ -220,6 +235,62 @@ func (ud *Content) Prepare(options stacks.ConfigurationOptions, request abstract
 	return nil
 }
 
+// formatGuestLabels returns the content of the file of guest labels: one line KEY='value' per label, sorted by key
+func formatGuestLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, k := range keys {
+		lines = append(lines, k+"="+strprocess.ShellQuote(labels[k]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Sanitized returns a copy of the content without the secrets (password and private keys), that can be persisted to
+// generate the scripts again later; the bash library is removed too, being reloaded by Restore()
+func (ud Content) Sanitized() *Content {
+	out := ud
+	out.Password = ""
+	out.FirstPrivateKey = ""
+	out.FinalPrivateKey = ""
+	out.GatewayHAKeepalivedPassword = ""
+	out.BashLibrary = ""
+	out.Tags = make(map[Phase]map[string][]string, len(ud.Tags))
+	for phase, tags := range ud.Tags {
+		out.Tags[phase] = make(map[string][]string, len(tags))
+		for k, v := range tags {
+			out.Tags[phase][k] = append([]string{}, v...)
+		}
+	}
+	return &out
+}
+
+// Restore completes a content returned by Sanitized() with the secrets of the host and the current bash library, so
+// scripts of phases following PHASE1_INIT can be generated again
+// Note: the password of keepalived of gateways is not restored
+func (ud *Content) Restore(finalPrivateKey, password string) fail.Error {
+	if ud == nil {
+		return fail.InvalidInstanceError()
+	}
+
+	bashLibrary, xerr := system.GetBashLibrary()
+	if xerr != nil {
+		return xerr
+	}
+
+	ud.BashLibrary = bashLibrary
+	ud.FinalPrivateKey = strings.Trim(finalPrivateKey, "\n")
+	ud.Password = password
+	if ud.Tags == nil {
+		ud.Tags = map[Phase]map[string][]string{}
+	}
+	return nil
+}
+
 // Generate generates the script file corresponding to the phase
 func (ud *Content) Generate(phase Phase) ([]byte, fail.Error) {
 	var (
@@ -112,10 +112,30 @@ function install_python3() {
     esac
 }
 
+# Exports the labels written in /etc/safescale/labels during phase netsec as environment variables SAFESCALE_LABEL_<KEY>,
+# for login shells and for the services started by systemd
+function export_guest_labels() {
+    rm -f /etc/profile.d/safescale-labels.sh /etc/systemd/system.conf.d/safescale-labels.conf
+    [ -s /etc/safescale/labels ] || return 0
+
+    cat >/etc/profile.d/safescale-labels.sh <<'SAFESCALE_LABELS_EOF'
+[ -r /etc/safescale/labels ] && eval "$(sed -e 's/^\([A-Za-z][A-Za-z0-9_]*\)=/export SAFESCALE_LABEL_\1=/' /etc/safescale/labels)"
+SAFESCALE_LABELS_EOF
+    chmod 0644 /etc/profile.d/safescale-labels.sh
+
+    mkdir -p /etc/systemd/system.conf.d
+    {
+        echo "[Manager]"
+        sed -e 's/^\([A-Za-z][A-Za-z0-9_]*\)=/DefaultEnvironment=SAFESCALE_LABEL_\1=/' /etc/safescale/labels
+    } >/etc/systemd/system.conf.d/safescale-labels.conf
+    systemctl daemon-reexec || true
+}
+
 # ---- Main
 
 install_drivers_nvidia
 install_python3
+export_guest_labels
 
 echo -n "0,linux,${LINUX_KIND},${VERSION_ID},$(hostname),$(date +%Y/%m/%d-%H:%M:%S)" >/opt/safescale/var/state/user_data.final.done
 # For compatibility with previous user_data implementation (until v19.03.x)...
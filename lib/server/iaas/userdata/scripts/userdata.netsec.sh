@@ -735,6 +735,9 @@ function configure_dns_legacy() {
 	fi
 	{{- end }}
 	cat <<-'EOF' >/etc/resolv.conf
+		{{- if .Domain }}
+		search {{ .Domain }}
+		{{- end }}
 		{{- if .DNSServers }}
 		  {{- range .DNSServers }}
 		nameserver {{ . }}
@@ -757,6 +760,9 @@ function configure_dns_resolvconf() {
 	EXISTING_DNS=$(grep nameserver /etc/resolv.conf | awk '{print $2}')
 
 	cat <<-'EOF' >/etc/resolvconf/resolv.conf.d/head
+		{{- if .Domain }}
+		search {{ .Domain }}
+		{{- end }}
 		{{- if .DNSServers }}
 		  {{- range .DNSServers }}
 		nameserver {{ . }}
@@ -785,6 +791,9 @@ function configure_dns_systemd_resolved() {
 		{{- else }}
 		DNS=1.1.1.1
 		{{- end}}
+		{{- if .Domain }}
+		Domains={{ .Domain }}
+		{{- end }}
 		Cache=yes
 		DNSStubListener=yes
 	EOF
@@ -958,9 +967,24 @@ function update_credentials() {
 	chmod -R 0600 /home/{{.User}}/.ssh/*
 }
 
+# Writes the labels given at creation in a file readable by the agents running on the host
+# (values are already escaped, the file can be sourced by shell)
+function write_guest_labels() {
+{{- if .GuestLabels }}
+	mkdir -p /etc/safescale
+	cat >/etc/safescale/labels <<'SAFESCALE_LABELS_EOF'
+{{ .GuestLabels }}
+SAFESCALE_LABELS_EOF
+	chmod 0644 /etc/safescale/labels
+{{- else }}
+	rm -f /etc/safescale/labels
+{{- end }}
+}
+
 # ---- Main
 
 update_credentials
+write_guest_labels
 configure_locale
 configure_dns
 ensure_network_connectivity || true
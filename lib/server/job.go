@@ -98,6 +98,13 @@ func NewJob(ctx context.Context, cancel context.CancelFunc, svc iaas.Service, de
 		}
 	}
 
+	var tenant string
+	if svc != nil {
+		tenant = svc.GetName()
+	}
+
+	// The correlation carried by the context is inherited by all the subtasks of the job, so their traces can be correlated
+	ctx = concurrency.ContextWithCorrelation(ctx, concurrency.Correlation{ID: id, Tenant: tenant})
 	task, xerr := concurrency.NewTaskWithContext(ctx)
 	if xerr != nil {
 		return nil, xerr
@@ -110,15 +117,13 @@ func NewJob(ctx context.Context, cancel context.CancelFunc, svc iaas.Service, de
 	nj := job{
 		description: description,
 		uuid:        id,
+		tenant:      tenant,
 		ctx:         task.GetContext(),
 		task:        task,
 		cancel:      cancel,
 		service:     svc,
 		startTime:   time.Now(),
 	}
-	if svc != nil {
-		nj.tenant = svc.GetName()
-	}
 	if xerr = register(&nj); xerr != nil {
 		return nil, xerr
 	}
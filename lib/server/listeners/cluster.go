@@ -19,6 +19,7 @@ package listeners
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/asaskevich/govalidator"
 	googleprotobuf "github.com/golang/protobuf/ptypes/empty"
@@ -27,7 +28,10 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/CS-SI/SafeScale/lib/protocol"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clustercomplexity"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterflavor"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterproperty"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterstate"
 	clusterfactory "github.com/CS-SI/SafeScale/lib/server/resources/factories/cluster"
 	hostfactory "github.com/CS-SI/SafeScale/lib/server/resources/factories/host"
 	"github.com/CS-SI/SafeScale/lib/server/resources/operations/converters"
@@ -40,11 +44,14 @@ import (
 	"github.com/CS-SI/SafeScale/lib/utils/serialize"
 )
 
+// diagnosticsChunkSize is the size of the chunks in which the diagnostics bundle of a Cluster is sent
+const diagnosticsChunkSize = 1024 * 1024
+
 // ClusterListener host service server grpc
 type ClusterListener struct{}
 
 // List lists clusters
-func (s *ClusterListener) List(ctx context.Context, in *protocol.Reference) (hl *protocol.ClusterListResponse, err error) {
+func (s *ClusterListener) List(ctx context.Context, in *protocol.ClusterListRequest) (hl *protocol.ClusterListResponse, err error) {
 	defer fail.OnExitConvertToGRPCStatus(&err)
 	defer fail.OnExitWrapError(&err, "cannot list clusters")
 
@@ -59,6 +66,11 @@ func (s *ClusterListener) List(ctx context.Context, in *protocol.Reference) (hl
 		logrus.Warnf("Structure validation failure: %v", in) // FIXME: Generate json tags in protobuf
 	}
 
+	filter, xerr := convertClusterListFilter(in)
+	if xerr != nil {
+		return nil, xerr
+	}
+
 	job, xerr := PrepareJob(ctx, in.GetTenantId(), "cluster list")
 	if xerr != nil {
 		return nil, xerr
@@ -70,13 +82,44 @@ func (s *ClusterListener) List(ctx context.Context, in *protocol.Reference) (hl
 	defer tracer.Exiting()
 	defer fail.OnExitLogError(&err, tracer.TraceMessage())
 
-	list, xerr := clusterfactory.List(task.GetContext(), job.GetService())
+	list, xerr := clusterfactory.List(task.GetContext(), job.GetService(), filter)
 	if xerr != nil {
 		return nil, xerr
 	}
 	return converters.ClusterListFromAbstractToProtocol(list), nil
 }
 
+// convertClusterListFilter validates the criteria of a cluster list request and converts them to clusterfactory.ListFilter
+func convertClusterListFilter(in *protocol.ClusterListRequest) (clusterfactory.ListFilter, fail.Error) {
+	var filter clusterfactory.ListFilter
+	if in == nil {
+		return filter, nil
+	}
+
+	if in.GetFlavor() != "" {
+		flavor, err := clusterflavor.Parse(in.GetFlavor())
+		if err != nil {
+			return filter, fail.InvalidRequestError("invalid flavor '%s'", in.GetFlavor())
+		}
+		filter.Flavor = flavor
+	}
+	if in.GetComplexity() != "" {
+		complexity, err := clustercomplexity.Parse(in.GetComplexity())
+		if err != nil {
+			return filter, fail.InvalidRequestError("invalid complexity '%s'", in.GetComplexity())
+		}
+		filter.Complexity = complexity
+	}
+	if in.GetState() != "" {
+		state, err := clusterstate.Parse(in.GetState())
+		if err != nil {
+			return filter, fail.InvalidRequestError("invalid state '%s'", in.GetState())
+		}
+		filter.State = state
+	}
+	return filter, nil
+}
+
 // Create creates a new cluster
 func (s *ClusterListener) Create(ctx context.Context, in *protocol.ClusterCreateRequest) (_ *protocol.ClusterResponse, err error) {
 	defer fail.OnExitConvertToGRPCStatus(&err)
@@ -126,6 +169,53 @@ func (s *ClusterListener) Create(ctx context.Context, in *protocol.ClusterCreate
 	return rc.ToProtocol()
 }
 
+// Resume continues the creation of a cluster interrupted while in state Creating
+func (s *ClusterListener) Resume(ctx context.Context, in *protocol.Reference) (_ *protocol.ClusterResponse, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot resume cluster creation")
+
+	if s == nil {
+		return nil, fail.InvalidInstanceError()
+	}
+	if in == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("in")
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	ref, _ := srvutils.GetReference(in)
+	if ref == "" {
+		return nil, fail.InvalidRequestError("cluster name is missing")
+	}
+
+	if ok, err := govalidator.ValidateStruct(in); err != nil || !ok {
+		logrus.Warnf("Structure validation failure: %v", in) // FIXME: Generate json tags in protobuf
+	}
+
+	job, xerr := PrepareJob(ctx, in.GetTenantId(), "cluster resume")
+	if xerr != nil {
+		return nil, xerr
+	}
+	defer job.Close()
+
+	task := job.GetTask()
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.cluster"), "('%s')", ref).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rc, xerr := clusterfactory.Load(job.GetService(), ref)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	xerr = rc.Resume(task.GetContext())
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	return rc.ToProtocol()
+}
+
 // State returns the status of a cluster
 func (s *ClusterListener) State(ctx context.Context, in *protocol.Reference) (ht *protocol.ClusterStateResponse, err error) {
 	defer fail.OnExitConvertToGRPCStatus(&err)
@@ -215,6 +305,84 @@ func (s *ClusterListener) Inspect(ctx context.Context, in *protocol.Reference) (
 	return rc.ToProtocol()
 }
 
+// InspectWithHealth returns information about a cluster, completed with its health collected by probing its hosts
+func (s *ClusterListener) InspectWithHealth(ctx context.Context, in *protocol.Reference) (_ *protocol.ClusterResponse, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot inspect health of cluster")
+
+	if s == nil {
+		return nil, fail.InvalidInstanceError()
+	}
+	if in == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("in")
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	ref, _ := srvutils.GetReference(in)
+	if ref == "" {
+		return nil, fail.InvalidRequestError("cluster name is missing")
+	}
+
+	job, err := PrepareJob(ctx, in.GetTenantId(), "cluster inspect with health")
+	if err != nil {
+		return nil, err
+	}
+	defer job.Close()
+
+	task := job.GetTask()
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.cluster"), "('%s')", ref).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rc, xerr := clusterfactory.Load(job.GetService(), ref)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	return rc.InspectWithHealth(task.GetContext())
+}
+
+// VerifyFeatures checks the features installed on a cluster and returns their health on each host
+func (s *ClusterListener) VerifyFeatures(ctx context.Context, in *protocol.Reference) (_ *protocol.ClusterFeaturesVerification, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot verify features of cluster")
+
+	if s == nil {
+		return nil, fail.InvalidInstanceError()
+	}
+	if in == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("in")
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	ref, _ := srvutils.GetReference(in)
+	if ref == "" {
+		return nil, fail.InvalidRequestError("cluster name is missing")
+	}
+
+	job, err := PrepareJob(ctx, in.GetTenantId(), "cluster verify-features")
+	if err != nil {
+		return nil, err
+	}
+	defer job.Close()
+
+	task := job.GetTask()
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.cluster"), "('%s')", ref).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rc, xerr := clusterfactory.Load(job.GetService(), ref)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	return rc.VerifyFeatures(task.GetContext())
+}
+
 // Start ...
 func (s *ClusterListener) Start(ctx context.Context, in *protocol.Reference) (empty *googleprotobuf.Empty, err error) {
 	defer fail.OnExitConvertToGRPCStatus(&err)
@@ -389,7 +557,15 @@ func (s *ClusterListener) Expand(ctx context.Context, in *protocol.ClusterResize
 		return nil, xerr
 	}
 
-	resp, xerr := rc.AddNodes(task.GetContext(), uint(in.Count), *sizing)
+	var options []data.ImmutableKeyValue
+	if pool := in.GetNodePool(); pool != "" {
+		options = append(options, data.NewImmutableKeyValue("NodePool", pool))
+		if cidr := in.GetNodePoolCidr(); cidr != "" {
+			options = append(options, data.NewImmutableKeyValue("NodePoolCIDR", cidr))
+		}
+	}
+
+	resp, xerr := rc.AddNodes(task.GetContext(), uint(in.Count), *sizing, options...)
 	if xerr != nil {
 		return nil, xerr
 	}
@@ -407,6 +583,141 @@ func (s *ClusterListener) Expand(ctx context.Context, in *protocol.ClusterResize
 	return out, nil
 }
 
+// ResizeGateways resizes the gateways of a cluster
+func (s *ClusterListener) ResizeGateways(ctx context.Context, in *protocol.ClusterGatewayResizeRequest) (empty *googleprotobuf.Empty, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot resize gateways of cluster")
+
+	empty = &googleprotobuf.Empty{}
+	if s == nil {
+		return empty, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if in == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("in")
+	}
+
+	if ok, err := govalidator.ValidateStruct(in); err != nil || !ok {
+		logrus.Warnf("Structure validation failure: %v", in) // FIXME: Generate json tags in protobuf
+	}
+
+	ref := in.GetName()
+	if ref == "" {
+		return empty, fail.InvalidRequestError("cluster name is missing")
+	}
+	if in.GetGatewaySizing() == "" {
+		return empty, fail.InvalidRequestError("sizing of gateways is missing")
+	}
+
+	job, err := PrepareJob(ctx, in.GetTenantId(), "cluster resize gateways")
+	if err != nil {
+		return empty, err
+	}
+	defer job.Close()
+	task := job.GetTask()
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.cluster"), "('%s', '%s')", ref, in.GetGatewaySizing()).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	sizing, _, err := converters.HostSizingRequirementsFromStringToAbstract(in.GetGatewaySizing())
+	if err != nil {
+		return empty, err
+	}
+
+	rc, xerr := clusterfactory.Load(job.GetService(), ref)
+	if xerr != nil {
+		return empty, xerr
+	}
+
+	return empty, rc.ResizeGateways(task.GetContext(), *sizing)
+}
+
+// SetDomain changes the DNS domain of a cluster
+func (s *ClusterListener) SetDomain(ctx context.Context, in *protocol.ClusterDomainRequest) (empty *googleprotobuf.Empty, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot set domain of cluster")
+
+	empty = &googleprotobuf.Empty{}
+	if s == nil {
+		return empty, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if in == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("in")
+	}
+
+	ref := in.GetName()
+	if ref == "" {
+		return empty, fail.InvalidRequestError("cluster name is missing")
+	}
+	if in.GetDomain() == "" {
+		return empty, fail.InvalidRequestError("domain is missing")
+	}
+
+	job, err := PrepareJob(ctx, in.GetTenantId(), "cluster set domain")
+	if err != nil {
+		return empty, err
+	}
+	defer job.Close()
+	task := job.GetTask()
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.cluster"), "('%s', '%s')", ref, in.GetDomain()).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rc, xerr := clusterfactory.Load(job.GetService(), ref)
+	if xerr != nil {
+		return empty, xerr
+	}
+
+	return empty, rc.SetDomain(task.GetContext(), in.GetDomain())
+}
+
+// RetagResources sets again the tags of a cluster on its resources, after merging the tags of the request
+func (s *ClusterListener) RetagResources(ctx context.Context, in *protocol.ClusterTagsRequest) (empty *googleprotobuf.Empty, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot tag resources of cluster")
+
+	empty = &googleprotobuf.Empty{}
+	if s == nil {
+		return empty, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if in == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("in")
+	}
+
+	ref := in.GetName()
+	if ref == "" {
+		return empty, fail.InvalidRequestError("cluster name is missing")
+	}
+
+	job, err := PrepareJob(ctx, in.GetTenantId(), "cluster retag")
+	if err != nil {
+		return empty, err
+	}
+	defer job.Close()
+	task := job.GetTask()
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.cluster"), "('%s', %v)", ref, in.GetTags()).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rc, xerr := clusterfactory.Load(job.GetService(), ref)
+	if xerr != nil {
+		return empty, xerr
+	}
+
+	return empty, rc.RetagResources(task.GetContext(), in.GetTags())
+}
+
 // Shrink removes node(s) from a cluster
 func (s *ClusterListener) Shrink(ctx context.Context, in *protocol.ClusterResizeRequest) (_ *protocol.ClusterNodeListResponse, err error) {
 	defer fail.OnExitConvertToGRPCStatus(&err)
@@ -453,7 +764,11 @@ func (s *ClusterListener) Shrink(ctx context.Context, in *protocol.ClusterResize
 		return nil, fail.InvalidParameterError("count", "must be greater than 0")
 	}
 
-	removedNodes, xerr := instance.Shrink(task.GetContext(), count)
+	removedNodes, xerr := instance.Shrink(task.GetContext(), count,
+		data.NewImmutableKeyValue("AllowBelowMinimum", in.GetAllowBelowMinimum()),
+		data.NewImmutableKeyValue("SkipDrain", in.GetSkipDrain()),
+		data.NewImmutableKeyValue("DrainTimeout", time.Duration(in.GetDrainTimeout())*time.Second),
+	)
 	if xerr != nil {
 		return nil, xerr
 	}
@@ -693,6 +1008,218 @@ func (s *ClusterListener) StartNode(ctx context.Context, in *protocol.ClusterNod
 	return empty, fail.NotImplementedError()
 }
 
+// CordonNode marks a node of the cluster as unschedulable
+func (s *ClusterListener) CordonNode(ctx context.Context, in *protocol.ClusterNodeRequest) (empty *googleprotobuf.Empty, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot cordon cluster node")
+
+	empty = &googleprotobuf.Empty{}
+	if s == nil {
+		return empty, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if in == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("in")
+	}
+
+	if ok, err := govalidator.ValidateStruct(in); err != nil || !ok {
+		logrus.Warnf("Structure validation failure: %v", in) // FIXME: Generate json tags in protobuf
+	}
+
+	clusterName := in.GetName()
+	if clusterName == "" {
+		return empty, fail.InvalidRequestError("cluster name is missing")
+	}
+	nodeRef, nodeRefLabel := srvutils.GetReference(in.GetHost())
+	if nodeRef == "" {
+		return empty, fail.InvalidRequestError("neither name nor id of node is provided")
+	}
+
+	job, xerr := PrepareJob(ctx, in.GetHost().GetTenantId(), "cluster node cordon")
+	if xerr != nil {
+		return empty, xerr
+	}
+	defer job.Close()
+	task := job.GetTask()
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.cluster"), "('%s', %s)", clusterName, nodeRefLabel).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rc, xerr := clusterfactory.Load(job.GetService(), clusterName)
+	if xerr != nil {
+		return empty, xerr
+	}
+	return empty, rc.CordonNode(task.GetContext(), nodeRef)
+}
+
+// UncordonNode marks a cordoned node of the cluster as schedulable again
+func (s *ClusterListener) UncordonNode(ctx context.Context, in *protocol.ClusterNodeRequest) (empty *googleprotobuf.Empty, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot uncordon cluster node")
+
+	empty = &googleprotobuf.Empty{}
+	if s == nil {
+		return empty, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if in == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("in")
+	}
+
+	if ok, err := govalidator.ValidateStruct(in); err != nil || !ok {
+		logrus.Warnf("Structure validation failure: %v", in) // FIXME: Generate json tags in protobuf
+	}
+
+	clusterName := in.GetName()
+	if clusterName == "" {
+		return empty, fail.InvalidRequestError("cluster name is missing")
+	}
+	nodeRef, nodeRefLabel := srvutils.GetReference(in.GetHost())
+	if nodeRef == "" {
+		return empty, fail.InvalidRequestError("neither name nor id of node is provided")
+	}
+
+	job, xerr := PrepareJob(ctx, in.GetHost().GetTenantId(), "cluster node uncordon")
+	if xerr != nil {
+		return empty, xerr
+	}
+	defer job.Close()
+	task := job.GetTask()
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.cluster"), "('%s', %s)", clusterName, nodeRefLabel).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rc, xerr := clusterfactory.Load(job.GetService(), clusterName)
+	if xerr != nil {
+		return empty, xerr
+	}
+	return empty, rc.UncordonNode(task.GetContext(), nodeRef)
+}
+
+// LabelNode sets labels on a node of the cluster
+func (s *ClusterListener) LabelNode(ctx context.Context, in *protocol.ClusterNodeLabelRequest) (empty *googleprotobuf.Empty, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot label cluster node")
+
+	empty = &googleprotobuf.Empty{}
+	if s == nil {
+		return empty, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if in == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("in")
+	}
+
+	if ok, err := govalidator.ValidateStruct(in); err != nil || !ok {
+		logrus.Warnf("Structure validation failure: %v", in) // FIXME: Generate json tags in protobuf
+	}
+
+	clusterName := in.GetName()
+	if clusterName == "" {
+		return empty, fail.InvalidRequestError("cluster name is missing")
+	}
+	nodeRef, nodeRefLabel := srvutils.GetReference(in.GetHost())
+	if nodeRef == "" {
+		return empty, fail.InvalidRequestError("neither name nor id of node is provided")
+	}
+	if len(in.GetLabels()) == 0 {
+		return empty, fail.InvalidRequestError("no label provided")
+	}
+
+	job, xerr := PrepareJob(ctx, in.GetHost().GetTenantId(), "cluster node label")
+	if xerr != nil {
+		return empty, xerr
+	}
+	defer job.Close()
+	task := job.GetTask()
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.cluster"), "('%s', %s)", clusterName, nodeRefLabel).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rc, xerr := clusterfactory.Load(job.GetService(), clusterName)
+	if xerr != nil {
+		return empty, xerr
+	}
+	return empty, rc.LabelNode(task.GetContext(), nodeRef, in.GetLabels())
+}
+
+// StartNodes starts the nodes of the cluster matching a label selector
+func (s *ClusterListener) StartNodes(ctx context.Context, in *protocol.ClusterNodeSelectorRequest) (_ *protocol.ClusterNodeNamesResponse, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot start cluster nodes")
+
+	return s.changeSelectedNodesState(ctx, in, "start")
+}
+
+// StopNodes stops the nodes of the cluster matching a label selector
+func (s *ClusterListener) StopNodes(ctx context.Context, in *protocol.ClusterNodeSelectorRequest) (_ *protocol.ClusterNodeNamesResponse, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot stop cluster nodes")
+
+	return s.changeSelectedNodesState(ctx, in, "stop")
+}
+
+// changeSelectedNodesState does the real work of StartNodes and StopNodes
+func (s *ClusterListener) changeSelectedNodesState(ctx context.Context, in *protocol.ClusterNodeSelectorRequest, action string) (_ *protocol.ClusterNodeNamesResponse, err error) {
+	if s == nil {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if in == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("in")
+	}
+
+	if ok, err := govalidator.ValidateStruct(in); err != nil || !ok {
+		logrus.Warnf("Structure validation failure: %v", in) // FIXME: Generate json tags in protobuf
+	}
+
+	clusterName := in.GetName()
+	if clusterName == "" {
+		return nil, fail.InvalidRequestError("cluster name is missing")
+	}
+	if len(in.GetSelector()) == 0 {
+		return nil, fail.InvalidRequestError("label selector is missing")
+	}
+
+	job, xerr := PrepareJob(ctx, in.GetTenantId(), "cluster node "+action)
+	if xerr != nil {
+		return nil, xerr
+	}
+	defer job.Close()
+	task := job.GetTask()
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.cluster"), "('%s', %v)", clusterName, in.GetSelector()).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rc, xerr := clusterfactory.Load(job.GetService(), clusterName)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	var names []string
+	if action == "stop" {
+		names, xerr = rc.StopNodes(task.GetContext(), in.GetSelector())
+	} else {
+		names, xerr = rc.StartNodes(task.GetContext(), in.GetSelector())
+	}
+	if xerr != nil {
+		return nil, xerr
+	}
+	return &protocol.ClusterNodeNamesResponse{Names: names}, nil
+}
+
 // StateNode returns the state of a node of the cluster
 func (s *ClusterListener) StateNode(ctx context.Context, in *protocol.ClusterNodeRequest) (_ *protocol.ClusterStateResponse, err error) {
 	defer fail.OnExitConvertToGRPCStatus(&err)
@@ -917,3 +1444,156 @@ func (s *ClusterListener) InspectMaster(ctx context.Context, in *protocol.Cluste
 
 	return out, nil
 }
+
+// GetKubeconfig returns the admin kubeconfig of a K8S cluster, targeting the endpoint of the cluster
+func (s *ClusterListener) GetKubeconfig(ctx context.Context, in *protocol.Reference) (_ *protocol.ClusterKubeconfigResponse, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot get cluster kubeconfig")
+
+	if s == nil {
+		return nil, fail.InvalidInstanceError()
+	}
+	if in == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("in")
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	if ok, err := govalidator.ValidateStruct(in); err != nil || !ok {
+		logrus.Warnf("Structure validation failure: %v", in) // FIXME: Generate json tags in protobuf
+	}
+
+	clusterName, _ := srvutils.GetReference(in)
+	if clusterName == "" {
+		return nil, fail.InvalidRequestError("cluster name is missing")
+	}
+
+	job, err := PrepareJob(ctx, in.GetTenantId(), "cluster kubeconfig")
+	if err != nil {
+		return nil, err
+	}
+	defer job.Close()
+	task := job.GetTask()
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.cluster"), "('%s')", clusterName).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rc, xerr := clusterfactory.Load(job.GetService(), clusterName)
+	if xerr != nil {
+		return nil, xerr
+	}
+	kubeconfig, xerr := rc.GetKubeconfig(task.GetContext())
+	if xerr != nil {
+		return nil, xerr
+	}
+	return &protocol.ClusterKubeconfigResponse{Kubeconfig: kubeconfig}, nil
+}
+
+// StreamLog follows a file on all the masters and nodes of a Cluster, sending each line added to the file, tagged with
+// the name of the Host, until the client cancels the call
+func (s *ClusterListener) StreamLog(in *protocol.LogRequest, stream protocol.ClusterService_StreamLogServer) (err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot stream log of cluster")
+	defer fail.OnPanic(&err)
+
+	if s == nil {
+		return fail.InvalidInstanceError()
+	}
+	if in == nil {
+		return fail.InvalidParameterCannotBeNilError("in")
+	}
+	if stream == nil {
+		return fail.InvalidParameterCannotBeNilError("stream")
+	}
+
+	clusterName, _ := srvutils.GetReference(in.GetTarget())
+	if clusterName == "" {
+		return fail.InvalidRequestError("cluster name is missing")
+	}
+	if in.GetPath() == "" {
+		return fail.InvalidRequestError("path of the file to follow has not been provided")
+	}
+	if in.GetLines() < 0 {
+		return fail.InvalidRequestError("number of lines cannot be negative")
+	}
+
+	job, xerr := PrepareJob(stream.Context(), in.GetTarget().GetTenantId(), "cluster log")
+	if xerr != nil {
+		return xerr
+	}
+	defer job.Close()
+	task := job.GetTask()
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.cluster"), "('%s', '%s')", clusterName, in.GetPath()).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rc, xerr := clusterfactory.Load(job.GetService(), clusterName)
+	if xerr != nil {
+		return xerr
+	}
+
+	return rc.StreamLog(task.GetContext(), in.GetPath(), uint(in.GetLines()), func(host, line string) {
+		// a failure to send means the client is gone; the context of the stream is then cancelled, ending the following
+		if sendErr := stream.Send(&protocol.LogLine{Host: host, Line: line}); sendErr != nil {
+			logrus.Debugf("failed to send log line of Host '%s': %v", host, sendErr)
+		}
+	})
+}
+
+// CollectDiagnostics collects files and command outputs on all the masters and nodes of a Cluster, and sends the
+// resulting tar bundle in chunks
+func (s *ClusterListener) CollectDiagnostics(in *protocol.ClusterDiagnosticsRequest, stream protocol.ClusterService_CollectDiagnosticsServer) (err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot collect diagnostics of cluster")
+	defer fail.OnPanic(&err)
+
+	if s == nil {
+		return fail.InvalidInstanceError()
+	}
+	if in == nil {
+		return fail.InvalidParameterCannotBeNilError("in")
+	}
+	if stream == nil {
+		return fail.InvalidParameterCannotBeNilError("stream")
+	}
+
+	clusterName, _ := srvutils.GetReference(in.GetCluster())
+	if clusterName == "" {
+		return fail.InvalidRequestError("cluster name is missing")
+	}
+
+	job, xerr := PrepareJob(stream.Context(), in.GetCluster().GetTenantId(), "cluster diagnostics")
+	if xerr != nil {
+		return xerr
+	}
+	defer job.Close()
+	task := job.GetTask()
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.cluster"), "('%s')", clusterName).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rc, xerr := clusterfactory.Load(job.GetService(), clusterName)
+	if xerr != nil {
+		return xerr
+	}
+
+	bundle, xerr := rc.CollectDiagnostics(task.GetContext(), in.GetPaths(), in.GetCommands(), in.GetMaxSize())
+	if xerr != nil {
+		return xerr
+	}
+
+	for start := 0; start < len(bundle); start += diagnosticsChunkSize {
+		end := start + diagnosticsChunkSize
+		if end > len(bundle) {
+			end = len(bundle)
+		}
+		if err := stream.Send(&protocol.ClusterDiagnosticsChunk{Data: bundle[start:end]}); err != nil {
+			return fail.ConvertError(err)
+		}
+	}
+	return nil
+}
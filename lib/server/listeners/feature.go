@@ -25,6 +25,8 @@ import (
 	googleprotobuf "github.com/golang/protobuf/ptypes/empty"
 
 	"github.com/CS-SI/SafeScale/lib/protocol"
+	"github.com/CS-SI/SafeScale/lib/server/resources"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/featuretarget"
 	clusterfactory "github.com/CS-SI/SafeScale/lib/server/resources/factories/cluster"
 	featurefactory "github.com/CS-SI/SafeScale/lib/server/resources/factories/feature"
 	hostfactory "github.com/CS-SI/SafeScale/lib/server/resources/factories/host"
@@ -128,7 +130,10 @@ func (s *FeatureListener) Check(ctx context.Context, in *protocol.FeatureActionR
 	if xerr != nil {
 		return empty, fail.Wrap(xerr, "failed to check feature")
 	}
-	featureSettings := converters.FeatureSettingsFromProtocolToResource(in.GetSettings())
+	featureSettings, xerr := convertFeatureSettings(in.GetSettings())
+	if xerr != nil {
+		return empty, fail.Wrap(xerr, "failed to check feature")
+	}
 
 	job, err := PrepareJob(ctx, in.GetTenantId(), "feature check")
 	if err != nil {
@@ -204,6 +209,14 @@ func convertVariablesToDataMap(in map[string]string) (data.Map, fail.Error) {
 	return out, nil
 }
 
+// convertFeatureSettings converts protocol.FeatureSettings to resources.FeatureSettings, validating the content
+func convertFeatureSettings(in *protocol.FeatureSettings) (resources.FeatureSettings, fail.Error) {
+	if _, err := featuretarget.Parse(in.GetTarget()); err != nil {
+		return resources.FeatureSettings{}, fail.InvalidParameterError("settings.target", err.Error())
+	}
+	return converters.FeatureSettingsFromProtocolToResource(in), nil
+}
+
 // Add ...
 func (s *FeatureListener) Add(ctx context.Context, in *protocol.FeatureActionRequest) (empty *googleprotobuf.Empty, err error) {
 	defer fail.OnExitConvertToGRPCStatus(&err)
@@ -229,7 +242,10 @@ func (s *FeatureListener) Add(ctx context.Context, in *protocol.FeatureActionReq
 	if xerr != nil {
 		return empty, fail.Wrap(xerr, "failed to add feature")
 	}
-	featureSettings := converters.FeatureSettingsFromProtocolToResource(in.GetSettings())
+	featureSettings, xerr := convertFeatureSettings(in.GetSettings())
+	if xerr != nil {
+		return empty, fail.Wrap(xerr, "failed to add feature")
+	}
 
 	job, err := PrepareJob(ctx, in.GetTenantId(), "feature add")
 	if err != nil {
@@ -307,7 +323,10 @@ func (s *FeatureListener) Remove(ctx context.Context, in *protocol.FeatureAction
 	if xerr != nil {
 		return empty, fail.Wrap(xerr, "failed to check feature")
 	}
-	featureSettings := converters.FeatureSettingsFromProtocolToResource(in.GetSettings())
+	featureSettings, xerr := convertFeatureSettings(in.GetSettings())
+	if xerr != nil {
+		return empty, fail.Wrap(xerr, "failed to check feature")
+	}
 
 	job, err := PrepareJob(ctx, in.GetTenantId(), "feature remove")
 	if err != nil {
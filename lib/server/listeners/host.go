@@ -20,26 +20,32 @@ import (
 	"context"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/CS-SI/SafeScale/lib/server/resources"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/accesspreference"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hostproperty"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hoststate"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hosttenancy"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/installmethod"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/securitygroupstate"
 	propertiesv2 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v2"
 
 	"github.com/asaskevich/govalidator"
 	googleprotobuf "github.com/golang/protobuf/ptypes/empty"
 	"github.com/sirupsen/logrus"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 
 	"github.com/CS-SI/SafeScale/lib/protocol"
 	"github.com/CS-SI/SafeScale/lib/server/handlers"
+	"github.com/CS-SI/SafeScale/lib/server/iaas"
+	"github.com/CS-SI/SafeScale/lib/server/iaas/userdata"
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	hostfactory "github.com/CS-SI/SafeScale/lib/server/resources/factories/host"
 	securitygroupfactory "github.com/CS-SI/SafeScale/lib/server/resources/factories/securitygroup"
 	subnetfactory "github.com/CS-SI/SafeScale/lib/server/resources/factories/subnet"
 	"github.com/CS-SI/SafeScale/lib/server/resources/operations/converters"
 	srvutils "github.com/CS-SI/SafeScale/lib/server/utils"
+	"github.com/CS-SI/SafeScale/lib/utils/concurrency"
 	"github.com/CS-SI/SafeScale/lib/utils/data"
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
 	"github.com/CS-SI/SafeScale/lib/utils/debug/tracing"
@@ -166,6 +172,164 @@ func (s *HostListener) Stop(ctx context.Context, in *protocol.Reference) (empty
 	return empty, nil
 }
 
+// StopGracefully shutdowns a host, leaving its operating system a grace period to stop by itself
+func (s *HostListener) StopGracefully(ctx context.Context, in *protocol.HostStopRequest) (_ *protocol.HostStopResponse, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot stop host")
+
+	if s == nil {
+		return nil, fail.InvalidInstanceError()
+	}
+	if in == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("in")
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx").ToGRPCStatus()
+	}
+	ref, refLabel := srvutils.GetReference(in.GetHost())
+	if ref == "" {
+		return nil, fail.InvalidRequestError("neither name nor id of host has been provided")
+	}
+	if in.GetGracePeriod() < 0 {
+		return nil, fail.InvalidRequestError("grace period cannot be negative")
+	}
+
+	if ok, err := govalidator.ValidateStruct(in); err != nil || !ok {
+		logrus.Warnf("Structure validation failure: %v", in) // FIXME: Generate json tags in protobuf
+	}
+
+	job, xerr := PrepareJob(ctx, in.GetHost().GetTenantId(), "host stop")
+	if xerr != nil {
+		return nil, xerr
+	}
+	defer job.Close()
+	task := job.GetTask()
+
+	gracePeriod := time.Duration(in.GetGracePeriod()) * time.Second
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.host"), "(%s, %s)", refLabel, gracePeriod).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rh, xerr := hostfactory.Load(job.GetService(), ref)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	method, xerr := rh.StopGracefully(task.GetContext(), gracePeriod)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	tracer.Trace("Host %s stopped (method: %s)", refLabel, method)
+	return &protocol.HostStopResponse{Method: string(method)}, nil
+}
+
+// CreateImage creates an image from the disk of a host, stopping then restarting the host if it is started
+func (s *HostListener) CreateImage(ctx context.Context, in *protocol.HostImageRequest) (_ *protocol.HostImageResponse, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot create image from host")
+
+	if s == nil {
+		return nil, fail.InvalidInstanceError()
+	}
+	if in == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("in")
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	ref, refLabel := srvutils.GetReference(in.GetHost())
+	if ref == "" {
+		return nil, fail.InvalidRequestError("neither name nor id of host has been provided")
+	}
+	imageName := strings.TrimSpace(in.GetImageName())
+	if imageName == "" {
+		return nil, fail.InvalidRequestError("image name cannot be empty string")
+	}
+
+	job, xerr := PrepareJob(ctx, in.GetHost().GetTenantId(), "host create-image")
+	if xerr != nil {
+		return nil, xerr
+	}
+	defer job.Close()
+	task := job.GetTask()
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.host"), "(%s, '%s')", refLabel, imageName).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rh, xerr := hostfactory.Load(job.GetService(), ref)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	imageID, xerr := rh.CreateImage(task.GetContext(), imageName)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	tracer.Trace("Image '%s' (%s) created from Host %s", imageName, imageID, refLabel)
+	return &protocol.HostImageResponse{ImageId: imageID}, nil
+}
+
+// WaitState waits for a host to reach one of the requested states
+func (s *HostListener) WaitState(ctx context.Context, in *protocol.HostWaitStateRequest) (_ *protocol.HostStatus, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot wait host state")
+
+	if s == nil {
+		return nil, fail.InvalidInstanceError()
+	}
+	if in == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("in")
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	ref, refLabel := srvutils.GetReference(in.GetHost())
+	if ref == "" {
+		return nil, fail.InvalidRequestError("neither name nor id of host has been provided")
+	}
+	if len(in.GetStates()) == 0 {
+		return nil, fail.InvalidRequestError("no state to wait for has been provided")
+	}
+	states := make([]hoststate.Enum, 0, len(in.GetStates()))
+	for _, v := range in.GetStates() {
+		state, err := hoststate.Parse(v)
+		if err != nil {
+			return nil, fail.InvalidRequestError("invalid state '%s'", v)
+		}
+		states = append(states, state)
+	}
+
+	if ok, err := govalidator.ValidateStruct(in); err != nil || !ok {
+		logrus.Warnf("Structure validation failure: %v", in) // FIXME: Generate json tags in protobuf
+	}
+
+	job, xerr := PrepareJob(ctx, in.GetHost().GetTenantId(), "host wait state")
+	if xerr != nil {
+		return nil, xerr
+	}
+	defer job.Close()
+	task := job.GetTask()
+
+	timeout := time.Duration(in.GetTimeout()) * time.Second
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.host"), "(%s, %v, %s)", refLabel, in.GetStates(), timeout).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rh, xerr := hostfactory.Load(job.GetService(), ref)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	state, xerr := rh.WaitForAnyState(task.GetContext(), states, timeout)
+	if xerr != nil {
+		return nil, xerr
+	}
+	return converters.HostStatusFromAbstractToProtocol(rh.GetName(), state), nil
+}
+
 // Reboot reboots a host.
 func (s *HostListener) Reboot(ctx context.Context, in *protocol.Reference) (empty *googleprotobuf.Empty, err error) {
 	defer fail.OnExitConvertToGRPCStatus(&err)
@@ -289,11 +453,96 @@ func (s *HostListener) Create(ctx context.Context, in *protocol.HostDefinition)
 	defer tracer.Exiting()
 	defer fail.OnExitLogError(&err, tracer.TraceMessage())
 
+	host, xerr := createHost(task, job.GetService(), in)
+	if xerr != nil {
+		return nil, xerr
+	}
+	return host, nil
+}
+
+// CheckCreate validates the creation of the Host described by 'in', without creating it
+func (s *HostListener) CheckCreate(ctx context.Context, in *protocol.HostDefinition) (_ *protocol.HostCreationCheck, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot check host creation")
+	defer fail.OnPanic(&err)
+
+	if s == nil {
+		return nil, fail.InvalidInstanceError()
+	}
+	if in == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("in")
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	if ok, err := govalidator.ValidateStruct(in); err != nil || !ok {
+		logrus.Warnf("Structure validation failure: %v", in) // FIXME: Generate json tags in protobuf
+	}
+
+	job, xerr := PrepareJob(ctx, in.GetTenantId(), "host check create")
+	if xerr != nil {
+		return nil, xerr
+	}
+	defer job.Close()
+	task := job.GetTask()
+	svc := job.GetService()
+
+	name := in.GetName()
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.host"), "('%s')", name).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	hostReq, sizing, xerr := hostRequestFromProtocol(svc, in)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	hostInstance, xerr := hostfactory.New(svc)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	report, xerr := hostInstance.CheckCreation(task.GetContext(), hostReq, *sizing)
+	if xerr != nil {
+		return nil, xerr
+	}
+	return &protocol.HostCreationCheck{
+		Name:             name,
+		TemplateId:       report.TemplateID,
+		ImageId:          report.ImageID,
+		SubnetIds:        report.SubnetIDs,
+		SecurityGroupIds: report.SecurityGroupIDs,
+		Problems:         report.Problems,
+	}, nil
+}
+
+// createHost creates the Host described by 'in'
+func createHost(task concurrency.Task, svc iaas.Service, in *protocol.HostDefinition) (_ *protocol.Host, xerr fail.Error) {
+	hostReq, sizing, xerr := hostRequestFromProtocol(svc, in)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	hostInstance, xerr := hostfactory.New(svc)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	if _, xerr = hostInstance.Create(task.GetContext(), hostReq, *sizing); xerr != nil {
+		return nil, xerr
+	}
+
+	return hostInstance.ToProtocol()
+}
+
+// hostRequestFromProtocol builds the request and the sizing of the creation of the Host described by 'in'
+func hostRequestFromProtocol(svc iaas.Service, in *protocol.HostDefinition) (_ abstract.HostRequest, _ *abstract.HostSizingRequirements, xerr fail.Error) {
 	var sizing *abstract.HostSizingRequirements
 	if in.SizingAsString != "" {
-		sizing, _, err = converters.HostSizingRequirementsFromStringToAbstract(in.SizingAsString)
-		if err != nil {
-			return nil, err
+		sizing, _, xerr = converters.HostSizingRequirementsFromStringToAbstract(in.SizingAsString)
+		if xerr != nil {
+			return abstract.HostRequest{}, nil, xerr
 		}
 	} else if in.Sizing != nil {
 		sizing = converters.HostSizingRequirementsFromProtocolToAbstract(in.Sizing)
@@ -316,9 +565,9 @@ func (s *HostListener) Create(ctx context.Context, in *protocol.HostDefinition)
 	}
 	if len(in.GetSubnets()) > 0 {
 		for _, v := range in.GetSubnets() {
-			subnetInstance, xerr = subnetfactory.Load(job.GetService(), networkRef, v)
+			subnetInstance, xerr = subnetfactory.Load(svc, networkRef, v)
 			if xerr != nil {
-				return nil, xerr
+				return abstract.HostRequest{}, nil, xerr
 			}
 			defer subnetInstance.Released()
 
@@ -332,14 +581,14 @@ func (s *HostListener) Create(ctx context.Context, in *protocol.HostDefinition)
 				return nil
 			})
 			if xerr != nil {
-				return nil, xerr
+				return abstract.HostRequest{}, nil, xerr
 			}
 		}
 	}
 	if len(subnets) == 0 && networkRef != "" {
-		subnetInstance, xerr = subnetfactory.Load(job.GetService(), networkRef, networkRef)
+		subnetInstance, xerr = subnetfactory.Load(svc, networkRef, networkRef)
 		if xerr != nil {
-			return nil, xerr
+			return abstract.HostRequest{}, nil, xerr
 		}
 		defer subnetInstance.Released()
 
@@ -353,11 +602,33 @@ func (s *HostListener) Create(ctx context.Context, in *protocol.HostDefinition)
 			return nil
 		})
 		if xerr != nil {
-			return nil, xerr
+			return abstract.HostRequest{}, nil, xerr
 		}
 	}
 	if len(subnets) == 0 && !in.GetSingle() {
-		return nil, fail.InvalidRequestError("insufficient use of --network and/or --subnet or missing --single")
+		return abstract.HostRequest{}, nil, fail.InvalidRequestError("insufficient use of --network and/or --subnet or missing --single")
+	}
+
+	var interfaces []abstract.InterfaceRequest
+	for _, v := range in.GetAdditionalInterfaces() {
+		subnetInstance, xerr = subnetfactory.Load(svc, networkRef, v.GetSubnet())
+		if xerr != nil {
+			return abstract.HostRequest{}, nil, xerr
+		}
+		defer subnetInstance.Released()
+
+		xerr = subnetInstance.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+			as, ok := clonable.(*abstract.Subnet)
+			if !ok {
+				return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			interfaces = append(interfaces, abstract.InterfaceRequest{Subnet: as, IPAddress: v.GetIpAddress()})
+			return nil
+		})
+		if xerr != nil {
+			return abstract.HostRequest{}, nil, xerr
+		}
 	}
 
 	domain := in.Domain
@@ -366,27 +637,201 @@ func (s *HostListener) Create(ctx context.Context, in *protocol.HostDefinition)
 		domain = "." + domain
 	}
 
+	tenancy, xerr := hosttenancy.Parse(in.GetTenancy())
+	if xerr != nil {
+		return abstract.HostRequest{}, nil, fail.InvalidRequestError("invalid tenancy '%s'", in.GetTenancy())
+	}
+	accessPreference, xerr := accesspreference.Parse(in.GetAccessPreference())
+	if xerr != nil {
+		return abstract.HostRequest{}, nil, fail.InvalidRequestError("invalid access preference '%s'", in.GetAccessPreference())
+	}
+
+	var installMethods []installmethod.Enum
+	for _, v := range in.GetInstallMethods() {
+		method, err := installmethod.Parse(v)
+		if err != nil {
+			return abstract.HostRequest{}, nil, fail.InvalidRequestError("invalid install method '%s'", v)
+		}
+		installMethods = append(installMethods, method)
+	}
+
+	var sgIDs map[string]struct{}
+	if len(in.GetSecurityGroups()) > 0 {
+		sgIDs = make(map[string]struct{}, len(in.GetSecurityGroups()))
+		for _, v := range in.GetSecurityGroups() {
+			sgInstance, xerr := securitygroupfactory.Load(svc, v)
+			if xerr != nil {
+				return abstract.HostRequest{}, nil, xerr
+			}
+			sgIDs[sgInstance.GetID()] = struct{}{}
+			sgInstance.Released()
+		}
+	}
+
 	hostReq := abstract.HostRequest{
-		ResourceName:  name,
-		HostName:      name + domain,
-		Single:        in.GetSingle(),
-		KeepOnFailure: in.GetKeepOnFailure(),
-		Subnets:       subnets,
+		ResourceName:    in.GetName(),
+		HostName:        in.GetName() + domain,
+		Single:          in.GetSingle(),
+		KeepOnFailure:   in.GetKeepOnFailure(),
+		Subnets:         subnets,
+		Tenancy:         tenancy,
+		DedicatedHostID: in.GetDedicatedHostId(),
+		SyncClockOnSkew: in.GetSyncClock(),
+
+		AdditionalInterfaces:      interfaces,
+		AccessPreference:          accessPreference,
+		SecurityGroupIDs:          sgIDs,
+		SkipDefaultSecurityGroups: in.GetSkipDefaultSecurityGroups(),
+		InstallMethods:            installMethods,
+		GuestLabels:               in.GetGuestLabels(),
+		PreservePublicIP:          in.GetPreservePublicIp(),
+	}
+	if xerr = abstract.ValidateGuestLabels(hostReq.GuestLabels); xerr != nil {
+		return abstract.HostRequest{}, nil, xerr
+	}
+	return hostReq, sizing, nil
+}
+
+// hostCreateManyParallelism is the maximum number of Hosts created simultaneously by CreateMany
+const hostCreateManyParallelism = 8
+
+// Import brings under management a host created outside SafeScale
+func (s *HostListener) Import(ctx context.Context, in *protocol.HostImportRequest) (_ *protocol.Host, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot import host")
+	defer fail.OnPanic(&err)
+
+	if s == nil {
+		return nil, fail.InvalidInstanceError()
+	}
+	if in == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("in")
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	if ok, err := govalidator.ValidateStruct(in); err != nil || !ok {
+		logrus.Warnf("Structure validation failure: %v", in) // FIXME: Generate json tags in protobuf
+	}
+
+	providerHostID := in.GetProviderHostId()
+	if providerHostID == "" {
+		return nil, fail.InvalidRequestError("ID of the host on provider side has not been provided")
+	}
+	subnetRef := in.GetSubnet()
+	if subnetRef == "" {
+		return nil, fail.InvalidRequestError("subnet of the host has not been provided")
 	}
 
-	hostInstance, xerr := hostfactory.New(job.GetService())
+	job, xerr := PrepareJob(ctx, in.GetTenantId(), "host import")
 	if xerr != nil {
 		return nil, xerr
 	}
+	defer job.Close()
+	task := job.GetTask()
+	svc := job.GetService()
 
-	if _, xerr = hostInstance.Create(task.GetContext(), hostReq, *sizing); xerr != nil {
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.host"), "(%s, '%s')", providerHostID, subnetRef).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	subnetInstance, xerr := subnetfactory.Load(svc, in.GetNetwork(), subnetRef)
+	if xerr != nil {
 		return nil, xerr
 	}
+	subnetID := subnetInstance.GetID()
+	subnetInstance.Released()
 
-	// logrus.Infof("Host '%s' created", name)
+	hostInstance, xerr := hostfactory.New(svc)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	var options []data.ImmutableKeyValue
+	if in.GetPrivateKey() != "" {
+		options = append(options, data.NewImmutableKeyValue("PrivateKey", in.GetPrivateKey()))
+	}
+	if xerr = hostInstance.Import(task.GetContext(), providerHostID, subnetID, options...); xerr != nil {
+		return nil, xerr
+	}
+
+	tracer.Trace("Host '%s' imported", hostInstance.GetName())
 	return hostInstance.ToProtocol()
 }
 
+// CreateMany creates several hosts in parallel
+// Each Host is created independently (including the cleanup on failure); the failure of one creation does not fail
+// the whole batch, the outcome of each request is reported in the result at the same index
+func (s *HostListener) CreateMany(ctx context.Context, in *protocol.HostDefinitionList) (_ *protocol.HostCreationResultList, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot create hosts")
+	defer fail.OnPanic(&err)
+
+	if s == nil {
+		return nil, fail.InvalidInstanceError()
+	}
+	if in == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("in")
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	job, xerr := PrepareJob(ctx, in.GetTenantId(), "host create many")
+	if xerr != nil {
+		return nil, xerr
+	}
+	defer job.Close()
+	task := job.GetTask()
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.host"), "(%d hosts)", len(in.GetHosts())).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	definitions := in.GetHosts()
+	out := &protocol.HostCreationResultList{Results: make([]*protocol.HostCreationResult, len(definitions))}
+	if len(definitions) == 0 {
+		return out, nil
+	}
+
+	tg, xerr := concurrency.NewTaskGroupWithParent(task)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	svc := job.GetService()
+	semaphore := make(chan struct{}, hostCreateManyParallelism)
+	for i, v := range definitions {
+		index, def := i, v
+		out.Results[index] = &protocol.HostCreationResult{Name: def.GetName()}
+		_, xerr = tg.Start(func(t concurrency.Task, _ concurrency.TaskParameters) (concurrency.TaskResult, fail.Error) {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			// each goroutine only writes the result at its own index
+			host, innerXErr := createHost(t, svc, def)
+			if innerXErr != nil {
+				logrus.Errorf("failed to create Host '%s': %v", def.GetName(), innerXErr)
+				out.Results[index].Error = innerXErr.Error()
+				return nil, nil
+			}
+			out.Results[index].Host = host
+			return nil, nil
+		}, nil)
+		if xerr != nil {
+			_ = tg.Abort()
+			_, _ = tg.WaitGroup()
+			return nil, xerr
+		}
+	}
+
+	if _, xerr = tg.WaitGroup(); xerr != nil {
+		return nil, xerr
+	}
+	return out, nil
+}
+
 // Resize an host
 func (s *HostListener) Resize(ctx context.Context, in *protocol.HostDefinition) (_ *protocol.Host, err error) {
 	defer fail.OnExitConvertToGRPCStatus(&err)
@@ -463,6 +908,214 @@ func (s *HostListener) Resize(ctx context.Context, in *protocol.HostDefinition)
 	return rh.ToProtocol()
 }
 
+// AttachToSubnet connects a host to an additional Subnet
+func (s *HostListener) AttachToSubnet(ctx context.Context, in *protocol.HostSubnetRequest) (empty *googleprotobuf.Empty, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot attach Host to Subnet")
+	defer fail.OnPanic(&err)
+
+	empty = &googleprotobuf.Empty{}
+	if s == nil {
+		return empty, fail.InvalidInstanceError()
+	}
+	if in == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("in")
+	}
+	if ctx == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	hostRef, hostRefLabel := srvutils.GetReference(in.GetHost())
+	if hostRef == "" {
+		return empty, fail.InvalidRequestError("neither name nor id given as reference of host")
+	}
+	subnetRef := in.GetSubnet()
+	if subnetRef == "" {
+		return empty, fail.InvalidRequestError("subnet has not been provided")
+	}
+
+	job, xerr := PrepareJob(ctx, in.GetHost().GetTenantId(), "host attach-subnet")
+	if xerr != nil {
+		return empty, xerr
+	}
+	defer job.Close()
+	task := job.GetTask()
+	svc := job.GetService()
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.host"), "(%s, '%s')", hostRefLabel, subnetRef).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	subnetInstance, xerr := subnetfactory.Load(svc, in.GetNetwork(), subnetRef)
+	if xerr != nil {
+		return empty, xerr
+	}
+	subnetID := subnetInstance.GetID()
+	subnetInstance.Released()
+
+	rh, xerr := hostfactory.Load(svc, hostRef)
+	if xerr != nil {
+		return empty, xerr
+	}
+
+	if xerr = rh.AttachToSubnet(task.GetContext(), subnetID, data.NewImmutableKeyValue("Default", in.GetDefault())); xerr != nil {
+		return empty, xerr
+	}
+
+	tracer.Trace("Host %s successfully attached to Subnet '%s'", hostRefLabel, subnetRef)
+	return empty, nil
+}
+
+// DetachFromSubnet disconnects a host from a Subnet that is not its default one
+func (s *HostListener) DetachFromSubnet(ctx context.Context, in *protocol.HostSubnetRequest) (empty *googleprotobuf.Empty, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot detach Host from Subnet")
+	defer fail.OnPanic(&err)
+
+	empty = &googleprotobuf.Empty{}
+	if s == nil {
+		return empty, fail.InvalidInstanceError()
+	}
+	if in == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("in")
+	}
+	if ctx == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	hostRef, hostRefLabel := srvutils.GetReference(in.GetHost())
+	if hostRef == "" {
+		return empty, fail.InvalidRequestError("neither name nor id given as reference of host")
+	}
+	subnetRef := in.GetSubnet()
+	if subnetRef == "" {
+		return empty, fail.InvalidRequestError("subnet has not been provided")
+	}
+
+	job, xerr := PrepareJob(ctx, in.GetHost().GetTenantId(), "host detach-subnet")
+	if xerr != nil {
+		return empty, xerr
+	}
+	defer job.Close()
+	task := job.GetTask()
+	svc := job.GetService()
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.host"), "(%s, '%s')", hostRefLabel, subnetRef).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	subnetInstance, xerr := subnetfactory.Load(svc, in.GetNetwork(), subnetRef)
+	if xerr != nil {
+		return empty, xerr
+	}
+	subnetID := subnetInstance.GetID()
+	subnetInstance.Released()
+
+	rh, xerr := hostfactory.Load(svc, hostRef)
+	if xerr != nil {
+		return empty, xerr
+	}
+
+	if xerr = rh.DetachFromSubnet(task.GetContext(), subnetID); xerr != nil {
+		return empty, xerr
+	}
+
+	tracer.Trace("Host %s successfully detached from Subnet '%s'", hostRefLabel, subnetRef)
+	return empty, nil
+}
+
+// RunPhase runs again an install phase on a host, typically after a provisioning failure of a host kept with KeepOnFailure
+func (s *HostListener) RunPhase(ctx context.Context, in *protocol.HostPhaseRequest) (empty *googleprotobuf.Empty, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot run install phase on Host")
+	defer fail.OnPanic(&err)
+
+	empty = &googleprotobuf.Empty{}
+	if s == nil {
+		return empty, fail.InvalidInstanceError()
+	}
+	if in == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("in")
+	}
+	if ctx == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	hostRef, hostRefLabel := srvutils.GetReference(in.GetHost())
+	if hostRef == "" {
+		return empty, fail.InvalidRequestError("neither name nor id given as reference of host")
+	}
+	phase := in.GetPhase()
+	if phase == "" {
+		return empty, fail.InvalidRequestError("phase has not been provided")
+	}
+
+	job, xerr := PrepareJob(ctx, in.GetHost().GetTenantId(), "host run-phase")
+	if xerr != nil {
+		return empty, xerr
+	}
+	defer job.Close()
+	task := job.GetTask()
+	svc := job.GetService()
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.host"), "(%s, '%s')", hostRefLabel, phase).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rh, xerr := hostfactory.Load(svc, hostRef)
+	if xerr != nil {
+		return empty, xerr
+	}
+
+	if xerr = rh.RunPhase(task.GetContext(), userdata.Phase(phase)); xerr != nil {
+		return empty, xerr
+	}
+
+	tracer.Trace("Install phase '%s' successfully run on Host %s", phase, hostRefLabel)
+	return empty, nil
+}
+
+// RotateKeypair replaces the SSH keypair used to connect to a host
+func (s *HostListener) RotateKeypair(ctx context.Context, in *protocol.Reference) (empty *googleprotobuf.Empty, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot rotate keypair of host")
+	defer fail.OnPanic(&err)
+
+	empty = &googleprotobuf.Empty{}
+	if s == nil {
+		return empty, fail.InvalidInstanceError()
+	}
+	ref, refLabel := srvutils.GetReference(in)
+	if ref == "" {
+		return empty, fail.InvalidParameterError("ref", "cannot be empty string")
+	}
+	if ctx == nil {
+		return empty, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	job, xerr := PrepareJob(ctx, in.GetTenantId(), "host rotate-keypair")
+	if xerr != nil {
+		return empty, xerr
+	}
+	defer job.Close()
+	task := job.GetTask()
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.host"), "(%s)", refLabel).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rh, xerr := hostfactory.Load(job.GetService(), ref)
+	if xerr != nil {
+		return empty, xerr
+	}
+	if xerr = rh.RotateKeypair(task.GetContext()); xerr != nil {
+		return empty, xerr
+	}
+
+	tracer.Trace("Keypair of Host %s successfully rotated", refLabel)
+	return empty, nil
+}
+
 // Status returns the status of a host (running or stopped mainly)
 func (s *HostListener) Status(ctx context.Context, in *protocol.Reference) (ht *protocol.HostStatus, err error) {
 	defer fail.OnExitConvertToGRPCStatus(&err)
@@ -562,7 +1215,7 @@ func (s *HostListener) Inspect(ctx context.Context, in *protocol.Reference) (h *
 }
 
 // Delete an host
-func (s *HostListener) Delete(ctx context.Context, in *protocol.Reference) (empty *googleprotobuf.Empty, err error) {
+func (s *HostListener) Delete(ctx context.Context, in *protocol.HostDeleteRequest) (empty *googleprotobuf.Empty, err error) {
 	defer fail.OnExitConvertToGRPCStatus(&err)
 	defer fail.OnExitWrapError(&err, "cannot delete host")
 	defer fail.OnPanic(&err)
@@ -582,12 +1235,12 @@ func (s *HostListener) Delete(ctx context.Context, in *protocol.Reference) (empt
 		logrus.Warnf("Structure validation failure: %v", in) // FIXME: Generate json tags in protobuf
 	}
 
-	ref, refLabel := srvutils.GetReference(in)
+	ref, refLabel := srvutils.GetReference(in.GetHost())
 	if ref == "" {
-		return empty, status.Errorf(codes.FailedPrecondition, "neither name nor id given as reference")
+		return empty, fail.InvalidRequestError("neither name nor id given as reference")
 	}
 
-	job, err := PrepareJob(ctx, in.GetTenantId(), "host delete")
+	job, err := PrepareJob(ctx, in.GetHost().GetTenantId(), "host delete")
 	if err != nil {
 		return nil, err
 	}
@@ -603,7 +1256,11 @@ func (s *HostListener) Delete(ctx context.Context, in *protocol.Reference) (empt
 		return empty, xerr
 	}
 
-	if xerr = rh.Delete(task.GetContext()); xerr != nil {
+	options := []data.ImmutableKeyValue{
+		data.NewImmutableKeyValue("CleanupFeatures", in.GetCleanupFeatures()),
+		data.NewImmutableKeyValue("StrictFeatureCleanup", in.GetStrictFeatureCleanup()),
+	}
+	if xerr = rh.Delete(task.GetContext(), options...); xerr != nil {
 		return empty, xerr
 	}
 
@@ -953,3 +1610,55 @@ func (s *HostListener) ListSecurityGroups(ctx context.Context, in *protocol.Secu
 
 	return resp, nil
 }
+
+// StreamLog follows a file on a Host, sending each line added to the file until the client cancels the call
+func (s *HostListener) StreamLog(in *protocol.LogRequest, stream protocol.HostService_StreamLogServer) (err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot stream log of host")
+	defer fail.OnPanic(&err)
+
+	if s == nil {
+		return fail.InvalidInstanceError()
+	}
+	if in == nil {
+		return fail.InvalidParameterCannotBeNilError("in")
+	}
+	if stream == nil {
+		return fail.InvalidParameterCannotBeNilError("stream")
+	}
+
+	ref, refLabel := srvutils.GetReference(in.GetTarget())
+	if ref == "" {
+		return fail.InvalidRequestError("neither name nor id of host has been provided")
+	}
+	if in.GetPath() == "" {
+		return fail.InvalidRequestError("path of the file to follow has not been provided")
+	}
+	if in.GetLines() < 0 {
+		return fail.InvalidRequestError("number of lines cannot be negative")
+	}
+
+	job, xerr := PrepareJob(stream.Context(), in.GetTarget().GetTenantId(), "host log")
+	if xerr != nil {
+		return xerr
+	}
+	defer job.Close()
+	task := job.GetTask()
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("listeners.host"), "(%s, '%s')", refLabel, in.GetPath()).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	rh, xerr := hostfactory.Load(job.GetService(), ref)
+	if xerr != nil {
+		return xerr
+	}
+
+	hostName := rh.GetName()
+	return rh.StreamLog(task.GetContext(), in.GetPath(), uint(in.GetLines()), func(line string) {
+		// a failure to send means the client is gone; the context of the stream is then cancelled, ending the following
+		if sendErr := stream.Send(&protocol.LogLine{Host: hostName, Line: line}); sendErr != nil {
+			logrus.Debugf("failed to send log line of Host '%s': %v", hostName, sendErr)
+		}
+	})
+}
@@ -253,7 +253,18 @@ func (s *NetworkListener) Inspect(ctx context.Context, in *protocol.Reference) (
 		return nil, xerr
 	}
 
-	return networkInstance.ToProtocol()
+	out, xerr := networkInstance.ToProtocol()
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	usage, xerr := networkInstance.InspectUsage(task.GetContext())
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	out.Usage = converters.NetworkUsageFromAbstractToProtocol(usage)
+	return out, nil
 }
 
 // Delete a network
@@ -488,13 +488,17 @@ func (s *SecurityGroupListener) Sanitize(ctx context.Context, in *protocol.Refer
 		return nil, err
 	}
 	defer job.Close()
-	// task := job.GetTask()
 
 	tracer := debug.NewTracer(job.GetTask(), tracing.ShouldTrace("listeners.security-group"), "(%s)", refLabel).WithStopwatch().Entering()
 	defer tracer.Exiting()
 	defer fail.OnExitLogError(&err, tracer.TraceMessage())
 
-	return empty, fail.NotImplementedError("not yet implemented")
+	rsg, xerr := securitygroupfactory.Load(job.GetService(), ref)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	return empty, rsg.RepairBindings(job.GetTask().GetContext())
 }
 
 // Bonds lists the resources bound to the Security Group
@@ -18,6 +18,8 @@ package listeners
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"github.com/CS-SI/SafeScale/lib/server/resources/operations/metadataupgrade"
 	"github.com/asaskevich/govalidator"
@@ -27,7 +29,11 @@ import (
 	"github.com/CS-SI/SafeScale/lib/protocol"
 	"github.com/CS-SI/SafeScale/lib/server/handlers"
 	"github.com/CS-SI/SafeScale/lib/server/iaas"
+	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
+	imagefilters "github.com/CS-SI/SafeScale/lib/server/resources/abstract/filters/images"
+	templatefilters "github.com/CS-SI/SafeScale/lib/server/resources/abstract/filters/templates"
 	"github.com/CS-SI/SafeScale/lib/server/resources/operations"
+	"github.com/CS-SI/SafeScale/lib/server/resources/operations/converters"
 	// "github.com/CS-SI/SafeScale/lib/server/resources/operations/metadataupgrade"
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
 	"github.com/CS-SI/SafeScale/lib/utils/debug/tracing"
@@ -303,3 +309,254 @@ func (s *TenantListener) Upgrade(ctx context.Context, in *protocol.TenantUpgrade
 
 	return &protocol.TenantUpgradeResponse{}, nil
 }
+
+// Snapshot returns a consolidated view of the resources of the tenant, read from metadata
+func (s *TenantListener) Snapshot(ctx context.Context, in *protocol.TenantName) (_ *protocol.TenantSnapshot, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot take snapshot of tenant")
+
+	if s == nil {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterError("ctx", "cannot be nil")
+	}
+	if in == nil {
+		return nil, fail.InvalidParameterError("in", "cannot be nil")
+	}
+
+	ok, err := govalidator.ValidateStruct(in)
+	if err != nil || !ok {
+		logrus.Warnf("Structure validation failure: %v", in) // FIXME: Generate json tags in protobuf
+	}
+
+	job, xerr := PrepareJob(ctx, in.GetName(), "tenant snapshot")
+	if xerr != nil {
+		return nil, xerr
+	}
+	defer job.Close()
+
+	tracer := debug.NewTracer(job.GetTask(), tracing.ShouldTrace("listeners.tenant"), "('%s')", job.GetService().GetName()).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	return operations.Snapshot(job.GetTask(), job.GetService())
+}
+
+// ListRegions lists the regions available for the current tenant
+func (s *TenantListener) ListRegions(ctx context.Context, in *googleprotobuf.Empty) (_ *protocol.RegionList, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot list regions")
+
+	if s == nil {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterError("ctx", "cannot be nil")
+	}
+
+	job, xerr := PrepareJob(ctx, "", "tenant list regions")
+	if xerr != nil {
+		return nil, xerr
+	}
+	defer job.Close()
+
+	tracer := debug.NewTracer(job.GetTask(), tracing.ShouldTrace("listeners.tenant")).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	list, xerr := job.GetService().ListRegions()
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	return &protocol.RegionList{Regions: list}, nil
+}
+
+// ListAvailabilityZones lists the availability zones usable by the current tenant
+func (s *TenantListener) ListAvailabilityZones(ctx context.Context, in *googleprotobuf.Empty) (_ *protocol.AvailabilityZoneList, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot list availability zones")
+
+	if s == nil {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterError("ctx", "cannot be nil")
+	}
+
+	job, xerr := PrepareJob(ctx, "", "tenant list availability zones")
+	if xerr != nil {
+		return nil, xerr
+	}
+	defer job.Close()
+
+	tracer := debug.NewTracer(job.GetTask(), tracing.ShouldTrace("listeners.tenant")).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	zones, xerr := job.GetService().ListAvailabilityZones()
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	names := make([]string, 0, len(zones))
+	for k := range zones {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	out := &protocol.AvailabilityZoneList{Zones: make([]*protocol.AvailabilityZone, 0, len(names))}
+	for _, v := range names {
+		out.Zones = append(out.Zones, &protocol.AvailabilityZone{Name: v, Available: zones[v]})
+	}
+	return out, nil
+}
+
+// imageOSFamilies lists the OS families recognized in image names, with the aliases used by providers
+// Order matters: the first family matching the image name wins
+var imageOSFamilies = []struct {
+	family  string
+	aliases []string
+}{
+	{family: "coreos", aliases: []string{"coreos"}},
+	{family: "ubuntu", aliases: []string{"ubuntu"}},
+	{family: "debian", aliases: []string{"debian"}},
+	{family: "centos", aliases: []string{"centos"}},
+	{family: "rhel", aliases: []string{"rhel", "redhat", "red hat"}},
+	{family: "rocky", aliases: []string{"rocky"}},
+	{family: "fedora", aliases: []string{"fedora"}},
+	{family: "suse", aliases: []string{"suse", "sles"}},
+	{family: "alpine", aliases: []string{"alpine"}},
+	{family: "windows", aliases: []string{"windows"}},
+}
+
+// imageOSFamily returns the OS family deduced from the name of the image, or "" if unknown
+func imageOSFamily(name string) string {
+	lowered := strings.ToLower(name)
+	for _, v := range imageOSFamilies {
+		for _, alias := range v.aliases {
+			if strings.Contains(lowered, alias) {
+				return v.family
+			}
+		}
+	}
+	return ""
+}
+
+// ListImages lists the images available for the tenant, filtered by name substring and OS family, sorted by name
+func (s *TenantListener) ListImages(ctx context.Context, in *protocol.TenantImageListRequest) (_ *protocol.ImageList, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot list images")
+
+	if s == nil {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterError("ctx", "cannot be nil")
+	}
+	if in == nil {
+		return nil, fail.InvalidParameterError("in", "cannot be nil")
+	}
+
+	job, xerr := PrepareJob(ctx, in.GetTenantId(), "tenant list images")
+	if xerr != nil {
+		return nil, xerr
+	}
+	defer job.Close()
+
+	name := strings.ToLower(in.GetName())
+	family := strings.ToLower(in.GetOsFamily())
+	tracer := debug.NewTracer(job.GetTask(), tracing.ShouldTrace("listeners.tenant"), "(name='%s', os_family='%s')", name, family).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	list, xerr := job.GetService().ListImages(in.GetAll())
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	filter := imagefilters.NewFilter(func(img abstract.Image) bool {
+		if name != "" && !strings.Contains(strings.ToLower(img.Name), name) {
+			return false
+		}
+		return family == "" || imageOSFamily(img.Name) == family
+	})
+	list = imagefilters.FilterImages(list, filter)
+	sort.SliceStable(list, func(i, j int) bool {
+		left, right := strings.ToLower(list[i].Name), strings.ToLower(list[j].Name)
+		if left != right {
+			return left < right
+		}
+		return list[i].ID < list[j].ID
+	})
+
+	out := &protocol.ImageList{Images: make([]*protocol.Image, 0, len(list))}
+	for k := range list {
+		out.Images = append(out.Images, converters.ImageFromAbstractToProtocol(&list[k]))
+	}
+	return out, nil
+}
+
+// ListTemplates lists the templates available for the tenant, filtered by name substring and minimum sizing,
+// sorted by increasing cores, RAM and disk sizes
+func (s *TenantListener) ListTemplates(ctx context.Context, in *protocol.TenantTemplateListRequest) (_ *protocol.TemplateList, err error) {
+	defer fail.OnExitConvertToGRPCStatus(&err)
+	defer fail.OnExitWrapError(&err, "cannot list templates")
+
+	if s == nil {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterError("ctx", "cannot be nil")
+	}
+	if in == nil {
+		return nil, fail.InvalidParameterError("in", "cannot be nil")
+	}
+	if in.GetMinCores() < 0 || in.GetMinRamSize() < 0 || in.GetMinDiskSize() < 0 {
+		return nil, fail.InvalidRequestError("minimum sizing values cannot be negative")
+	}
+
+	job, xerr := PrepareJob(ctx, in.GetTenantId(), "tenant list templates")
+	if xerr != nil {
+		return nil, xerr
+	}
+	defer job.Close()
+
+	name := strings.ToLower(in.GetName())
+	minCores, minRAM, minDisk := int(in.GetMinCores()), in.GetMinRamSize(), int(in.GetMinDiskSize())
+	tracer := debug.NewTracer(job.GetTask(), tracing.ShouldTrace("listeners.tenant"), "(name='%s', min_cores=%d, min_ram=%.1f, min_disk=%d)", name, minCores, minRAM, minDisk).WithStopwatch().Entering()
+	defer tracer.Exiting()
+	defer fail.OnExitLogError(&err, tracer.TraceMessage())
+
+	list, xerr := job.GetService().ListTemplates(in.GetAll())
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	filter := templatefilters.NewFilter(func(tpl abstract.HostTemplate) bool {
+		if name != "" && !strings.Contains(strings.ToLower(tpl.Name), name) {
+			return false
+		}
+		return tpl.Cores >= minCores && tpl.RAMSize >= minRAM && tpl.DiskSize >= minDisk
+	})
+	list = templatefilters.FilterTemplates(list, filter)
+	sort.SliceStable(list, func(i, j int) bool {
+		switch {
+		case list[i].Cores != list[j].Cores:
+			return list[i].Cores < list[j].Cores
+		case list[i].RAMSize != list[j].RAMSize:
+			return list[i].RAMSize < list[j].RAMSize
+		case list[i].DiskSize != list[j].DiskSize:
+			return list[i].DiskSize < list[j].DiskSize
+		default:
+			return list[i].Name < list[j].Name
+		}
+	})
+
+	out := &protocol.TemplateList{Templates: make([]*protocol.HostTemplate, 0, len(list))}
+	for _, v := range list {
+		out.Templates = append(out.Templates, converters.HostTemplateFromAbstractToProtocol(v))
+	}
+	return out, nil
+}
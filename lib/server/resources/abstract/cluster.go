@@ -33,6 +33,9 @@ type ClusterRequest struct {
 	Complexity              clustercomplexity.Enum // is the implementation wanted, can be Small, Normal or Large
 	Flavor                  clusterflavor.Enum     // tells what kind of cluster to create
 	NetworkID               string                 // is the ID of the network to use; may be empty and in this case a new Network will be created
+	ExistingGatewayID       string                 // is the ID or name of an existing gateway to use; if set, its Subnet is used by the Cluster and no gateway is created
+	MetadataBucket          string                 // is the name of the bucket storing the metadata of the Cluster and its resources; if empty, the metadata bucket of the tenant is used
+	Tags                    map[string]string      // contains the tags to set on the resources of the Cluster in the provider (cost allocation, ...), besides the tag identifying the Cluster
 	Tenant                  string                 // contains the name of the tenant
 	KeepOnFailure           bool                   // tells if resources have to be kept in case of failure (for further analysis)
 	GatewaysDef             HostSizingRequirements // sizing of gateways
@@ -41,6 +44,7 @@ type ClusterRequest struct {
 	InitialNodeCount        uint                   // contains the initial count of nodes to create (cannot be less than flavor requirement)
 	OS                      string                 // contains the name of the linux distribution wanted
 	DisabledDefaultFeatures map[string]struct{}    // contains the list of features that should be installed by default but we don't want actually
+	BestEffortFeatures      map[string]struct{}    // contains the list of features installed by default whose installation failure must not fail the Cluster
 	Force                   bool                   // Force is set to True in order to ignore sizing recommendations
 }
 
@@ -24,6 +24,13 @@ import (
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
 )
 
+const (
+	// ProviderErrorMessageAnnotation is the key of the annotation containing the original message of a provider error
+	ProviderErrorMessageAnnotation = "provider_message"
+	// ProviderErrorCodeAnnotation is the key of the annotation containing the code of a provider error, if any
+	ProviderErrorCodeAnnotation = "provider_code"
+)
+
 // ResourceNotFoundError creates a ErrNotFound error
 func ResourceNotFoundError(resource, name string) fail.Error {
 	msgFinal := fmt.Sprintf("failed to find %s", resource)
@@ -19,11 +19,17 @@ package abstract
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"time"
+	"unicode"
 
 	uuid "github.com/satori/go.uuid"
 
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/accesspreference"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hoststate"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hosttenancy"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/installmethod"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/privilegeescalation"
 	"github.com/CS-SI/SafeScale/lib/utils/crypt"
 	"github.com/CS-SI/SafeScale/lib/utils/data"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
@@ -77,6 +83,7 @@ type HostSizingRequirements struct {
 	MaxRAMSize  float32
 	MinDiskSize int
 	MinGPU      int
+	GPUType     string // if != "", restricts the templates to the ones providing this model of GPU (case-insensitive partial match)
 	MinCPUFreq  float32
 	Replaceable bool // Tells if we accept server that could be removed without notice (AWS proposes such kind of server with SPOT
 	Image       string
@@ -102,6 +109,9 @@ func (hsr HostSizingRequirements) Equals(in HostSizingRequirements) bool {
 	if hsr.MinGPU != in.MinGPU {
 		return false
 	}
+	if hsr.GPUType != in.GPUType {
+		return false
+	}
 	if hsr.MinCPUFreq != in.MinCPUFreq {
 		return false
 	}
@@ -158,22 +168,96 @@ func (i Image) OK() bool {
 
 // HostRequest represents requirements to create host
 type HostRequest struct {
-	ResourceName     string              // ResourceName contains the name of the compute resource
-	HostName         string              // HostName contains the hostname on the system (if empty, will use ResourceName)
-	Subnets          []*Subnet           // lists the Subnets the host must be connected to
-	DefaultRouteIP   string              // DefaultRouteIP is the IP used as default route
-	TemplateID       string              // TemplateID is the UUID of the template used to size the host (see SelectTemplates)
-	ImageID          string              // ImageID is the UUID of the image that contains the server's OS and initial state.
-	KeyPair          *KeyPair            // KeyPair is the (optional) specific KeyPair to use (if not provided, a new KeyPair will be generated)
-	SSHPort          uint32              // contains the port to use for SSH
-	Password         string              // Password contains the password of OperatorUsername account, usable on host console only
-	DiskSize         int                 // DiskSize allows to ask for a specific size for system disk (in GB)
-	Single           bool                // Single tells if the Host is single
-	PublicIP         bool                // PublicIP a flag telling if the host must have a public IP
-	IsGateway        bool                // IsGateway tells if the host will act as a gateway
-	KeepOnFailure    bool                // KeepOnFailure tells if resource must be kept on failure
-	Preemptible      bool                // Use spot-like instance
-	SecurityGroupIDs map[string]struct{} // List of Security Groups to attach to IPAddress (using map as dict)
+	ResourceName     string                  // ResourceName contains the name of the compute resource
+	HostName         string                  // HostName contains the hostname on the system (if empty, will use ResourceName)
+	Subnets          []*Subnet               // lists the Subnets the host must be connected to
+	NetworkID        string                  // NetworkID is the Network in which a Subnet is automatically selected if Subnets is empty
+	SubnetSelection  SubnetSelectionCriteria // SubnetSelection contains the criteria used to select automatically the Subnet (see NetworkID)
+	DefaultRouteIP   string                  // DefaultRouteIP is the IP used as default route
+	TemplateID       string                  // TemplateID is the UUID of the template used to size the host (see SelectTemplates)
+	ImageID          string                  // ImageID is the UUID of the image that contains the server's OS and initial state.
+	KeyPair          *KeyPair                // KeyPair is the (optional) specific KeyPair to use (if not provided, a new KeyPair will be generated)
+	SSHPort          uint32                  // contains the port to use for SSH
+	Password         string                  // Password contains the password of OperatorUsername account, usable on host console only
+	DiskSize         int                     // DiskSize allows to ask for a specific size for system disk (in GB)
+	Single           bool                    // Single tells if the Host is single
+	PublicIP         bool                    // PublicIP a flag telling if the host must have a public IP
+	IsGateway        bool                    // IsGateway tells if the host will act as a gateway
+	KeepOnFailure    bool                    // KeepOnFailure tells if resource must be kept on failure
+	Preemptible      bool                    // Use spot-like instance
+	SecurityGroupIDs map[string]struct{}     // List of Security Groups to attach to IPAddress (using map as dict)
+	// PrivilegeEscalation tells how to gain administrative privileges on the host (sudo by default)
+	PrivilegeEscalation privilegeescalation.Enum
+	DefaultShell        string // DefaultShell contains the shell used to run scripts on the host (if empty, will use bash)
+	TempFolder          string // TempFolder contains the remote folder where provisioning scripts are uploaded (if empty, will use utils.TempFolder)
+	// Tenancy tells on which kind of physical host the Host has to be placed (shared by default)
+	Tenancy         hosttenancy.Enum
+	DedicatedHostID string // DedicatedHostID is the ID of the dedicated host to place the Host on (only with hosttenancy.Host)
+	DryRun          bool   // DryRun tells to only validate the request, without creating the Host
+	// SyncClockOnSkew tells to force a NTP synchronization of the clock of the Host if its skew exceeds the threshold
+	SyncClockOnSkew bool
+	// AdditionalInterfaces lists the network interfaces to add to the Host, besides the ones on Subnets
+	AdditionalInterfaces []InterfaceRequest
+	// AccessPreference tells which IP address to use to access the Host (preference of the tenant by default)
+	AccessPreference accesspreference.Enum
+	// SkipDefaultSecurityGroups tells to bind only the Security Groups of SecurityGroupIDs, without the ones SafeScale binds
+	// automatically (gateway, public IP and internal Security Groups of the Subnets)
+	// Warning: the firewalling of the Host is then entirely up to these Security Groups; they must at least allow SSH from
+	// the gateway (or from the daemon for a Host with public IP), otherwise the Host cannot be provisioned, and the Host
+	// is not reachable from the other Hosts of its Subnets unless allowed explicitly
+	SkipDefaultSecurityGroups bool
+	// InstallMethods lists the methods allowed to install Features on the Host, by order of preference; if empty, the
+	// preference of the tenant applies, or the methods detected from the operating system if none
+	InstallMethods []installmethod.Enum
+	// GuestLabels contains labels written in the system of the Host (in /etc/safescale/labels and as environment
+	// variables SAFESCALE_LABEL_<KEY>), for agents running on the Host (see ValidateGuestLabels)
+	GuestLabels map[string]string
+	// PreservePublicIP tells to keep the public IP of the Host across stop and start, making it static if the provider
+	// releases the public IP of a stopped Host
+	PreservePublicIP bool
+}
+
+const (
+	maxGuestLabelKeyLength   = 63
+	maxGuestLabelValueLength = 255
+)
+
+// guestLabelKeyRegexp is the format of the keys of guest labels, usable as names of environment variables
+var guestLabelKeyRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// ValidateGuestLabels checks the labels to write in the system of a Host: a key must start with a letter and contain
+// only letters, digits and underscores; a value must not contain control characters (newlines included)
+func ValidateGuestLabels(labels map[string]string) fail.Error {
+	for k, v := range labels {
+		if len(k) > maxGuestLabelKeyLength || !guestLabelKeyRegexp.MatchString(k) {
+			return fail.InvalidRequestError("invalid guest label key '%s': must start with a letter and contain only letters, digits and underscores (%d characters max)", k, maxGuestLabelKeyLength)
+		}
+		if len(v) > maxGuestLabelValueLength {
+			return fail.InvalidRequestError("invalid value of guest label '%s': longer than %d characters", k, maxGuestLabelValueLength)
+		}
+		for _, r := range v {
+			if unicode.IsControl(r) {
+				return fail.InvalidRequestError("invalid value of guest label '%s': must not contain control characters", k)
+			}
+		}
+	}
+	return nil
+}
+
+// InterfaceRequest represents the request of an additional network interface of a Host
+type InterfaceRequest struct {
+	Subnet    *Subnet // Subnet is the Subnet the interface is connected to
+	IPAddress string  // IPAddress is the fixed private IP address of the interface (if empty, the provider allocates one)
+}
+
+// HostCreationReport contains the resources resolved from a HostRequest without creating the Host, and the problems
+// that would prevent its creation
+type HostCreationReport struct {
+	TemplateID       string   // TemplateID is the ID of the template that would be used
+	ImageID          string   // ImageID is the ID of the image that would be used
+	SubnetIDs        []string // SubnetIDs lists the IDs of the Subnets the Host would be attached to (first is default)
+	SecurityGroupIDs []string // SecurityGroupIDs lists the IDs of the Security Groups that would be bound to the Host
+	Problems         []string // Problems lists the reasons that would prevent the creation of the Host
 }
 
 // HostEffectiveSizing ...
@@ -18,9 +18,12 @@ package abstract
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
 )
 
 func TestHostCore_Clone(t *testing.T) {
@@ -41,3 +44,14 @@ func TestHostCore_Clone(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestValidateGuestLabels(t *testing.T) {
+	assert.Nil(t, ValidateGuestLabels(nil))
+	assert.Nil(t, ValidateGuestLabels(map[string]string{"role": "worker", "Site_2": "it's \"quoted\" $HOME"}))
+
+	for _, k := range []string{"", "2role", "my-role", "role.name", "rôle"} {
+		assert.IsType(t, &fail.ErrInvalidRequest{}, ValidateGuestLabels(map[string]string{k: "v"}), k)
+	}
+	assert.IsType(t, &fail.ErrInvalidRequest{}, ValidateGuestLabels(map[string]string{"role": "a\nb"}))
+	assert.IsType(t, &fail.ErrInvalidRequest{}, ValidateGuestLabels(map[string]string{"role": strings.Repeat("x", maxGuestLabelValueLength+1)}))
+}
@@ -18,6 +18,8 @@ package abstract
 
 import (
 	"encoding/json"
+	"net"
+	"strings"
 
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/ipversion"
 	"github.com/sirupsen/logrus"
@@ -137,3 +139,35 @@ func (n *Network) GetID() string {
 	}
 	return n.ID
 }
+
+// SubnetUsage describes a Subnet of a Network and its occupation
+type SubnetUsage struct {
+	ID          string
+	Name        string
+	CIDR        string
+	GatewayIDs  []string
+	HostCount   uint // number of Hosts attached to the Subnet, gateways excluded
+	FreeIPCount uint // number of IP addresses still available for new Hosts
+}
+
+// NetworkUsage describes the Subnets of a Network and the occupation of its address space
+type NetworkUsage struct {
+	Subnets             []SubnetUsage
+	FreeSingleHostSlots uint // number of CIDR still available for single Hosts
+}
+
+// NormalizeCIDR validates the CIDR 'cidr' and returns its canonical form, with host bits masked (for example,
+// "10.0.0.5/24" becomes "10.0.0.0/24")
+// Returns fail.ErrInvalidRequest if 'cidr' is not a valid CIDR
+func NormalizeCIDR(cidr string) (string, fail.Error) {
+	trimmed := strings.TrimSpace(cidr)
+	if trimmed == "" {
+		return "", fail.InvalidRequestError("invalid empty CIDR")
+	}
+
+	_, ipNet, err := net.ParseCIDR(trimmed)
+	if err != nil {
+		return "", fail.InvalidRequestError("invalid CIDR '%s': %v", cidr, err)
+	}
+	return ipNet.String(), nil
+}
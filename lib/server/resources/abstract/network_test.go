@@ -0,0 +1,45 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package abstract
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+)
+
+func TestNormalizeCIDR(t *testing.T) {
+	cidr, xerr := NormalizeCIDR("192.168.1.0/24")
+	require.Nil(t, xerr)
+	assert.Equal(t, "192.168.1.0/24", cidr)
+
+	cidr, xerr = NormalizeCIDR(" 10.0.0.5/24 ")
+	require.Nil(t, xerr)
+	assert.Equal(t, "10.0.0.0/24", cidr)
+
+	cidr, xerr = NormalizeCIDR("2001:db8::1/32")
+	require.Nil(t, xerr)
+	assert.Equal(t, "2001:db8::/32", cidr)
+
+	for _, v := range []string{"", "10.0.0.0", "10.0.0.0/33", "10.0.0.256/24", "not a cidr"} {
+		_, xerr = NormalizeCIDR(v)
+		assert.IsType(t, &fail.ErrInvalidRequest{}, xerr, "CIDR '%s' should be invalid", v)
+	}
+}
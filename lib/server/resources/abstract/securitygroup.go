@@ -37,6 +37,7 @@ type SecurityGroupRule struct {
 	PortTo      int32                           `json:"port_to,omitempty"`     // last port of the rule
 	Sources     []string                        `json:"sources"`               // concerned sources (depending of Direction); can be array of IP ranges or array of Security Group IDs (no mix)
 	Targets     []string                        `json:"targets"`               // concerned source or target (depending of Direction); can be array of IP ranges or array of Security Group IDs (no mix)
+	References  []string                        `json:"references,omitempty"`  // sources or targets as requested (Security Group names, IP sets), before their resolution in Sources or Targets
 }
 
 // IsNull tells if the Security Group Rule is a null value
@@ -223,6 +224,12 @@ func (sgr *SecurityGroupRule) Replace(p data.Clonable) data.Clonable {
 	copy(sgr.IDs, src.IDs)
 	sgr.Sources = make([]string, len(src.Sources))
 	copy(sgr.Sources, src.Sources)
+	sgr.Targets = make([]string, len(src.Targets))
+	copy(sgr.Targets, src.Targets)
+	if src.References != nil {
+		sgr.References = make([]string, len(src.References))
+		copy(sgr.References, src.References)
+	}
 	return sgr
 }
 
@@ -295,6 +302,122 @@ func (sgrs SecurityGroupRules) RemoveRuleByIndex(index int) (SecurityGroupRules,
 	return newRules, nil
 }
 
+// SameTrafficAs tells if 2 rules concern the same traffic; contrary to EquivalentTo, provider IDs and Description are ignored
+func (sgr *SecurityGroupRule) SameTrafficAs(in *SecurityGroupRule) bool {
+	if sgr == nil || in == nil {
+		return false
+	}
+
+	if sgr.Direction != in.Direction || sgr.EtherType != in.EtherType || sgr.Protocol != in.Protocol {
+		return false
+	}
+	if sgr.PortFrom != in.PortFrom || sgr.PortTo != in.PortTo {
+		return false
+	}
+	return sameStringSet(sgr.Sources, in.Sources) && sameStringSet(sgr.Targets, in.Targets)
+}
+
+// sameStringSet tells if 2 slices contain the same strings, regardless of order and duplicates
+func sameStringSet(a, b []string) bool {
+	set := make(map[string]bool, len(a))
+	for _, v := range a {
+		set[v] = false
+	}
+	for _, v := range b {
+		if _, ok := set[v]; !ok {
+			return false
+		}
+		set[v] = true
+	}
+	for _, v := range set {
+		if !v {
+			return false
+		}
+	}
+	return true
+}
+
+// Diff compares the rules with the desired ones, returning the rules to delete (concerning a traffic not desired) and
+// the desired rules to add (concerning a traffic not allowed yet); rules are compared with SameTrafficAs
+func (sgrs SecurityGroupRules) Diff(desired SecurityGroupRules) (toDelete SecurityGroupRules, toAdd SecurityGroupRules) {
+	for _, v := range sgrs {
+		found := false
+		for _, w := range desired {
+			if v.SameTrafficAs(w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			toDelete = append(toDelete, v)
+		}
+	}
+	for k, v := range desired {
+		found := false
+		for _, w := range sgrs {
+			if v.SameTrafficAs(w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			// a desired rule present several times is added once
+			for _, w := range desired[:k] {
+				if v.SameTrafficAs(w) {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			toAdd = append(toAdd, v)
+		}
+	}
+	return toDelete, toAdd
+}
+
+// SecurityGroupRuleOrigin describes a Security Group bound to a Host from which a rule comes
+type SecurityGroupRuleOrigin struct {
+	GroupID    string
+	GroupName  string
+	FromSubnet bool // true if the Security Group is bound to the Host through its Subnet
+}
+
+// EffectiveSecurityGroupRule is a rule applied to a Host, with the Security Groups defining it
+type EffectiveSecurityGroupRule struct {
+	Rule    *SecurityGroupRule // the rule, without provider IDs
+	Origins []SecurityGroupRuleOrigin
+}
+
+// EffectiveSecurityGroupRules ...
+type EffectiveSecurityGroupRules []*EffectiveSecurityGroupRule
+
+// Merge adds the rules of a Security Group, merging the ones concerning a traffic already present
+func (esgrs EffectiveSecurityGroupRules) Merge(rules SecurityGroupRules, origin SecurityGroupRuleOrigin) EffectiveSecurityGroupRules {
+	for _, v := range rules {
+		if v == nil {
+			continue
+		}
+
+		merged := false
+		for _, e := range esgrs {
+			if e.Rule.SameTrafficAs(v) {
+				e.Origins = append(e.Origins, origin)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			rule := *v
+			rule.IDs = nil
+			rule.Sources = append([]string{}, v.Sources...)
+			rule.Targets = append([]string{}, v.Targets...)
+			esgrs = append(esgrs, &EffectiveSecurityGroupRule{Rule: &rule, Origins: []SecurityGroupRuleOrigin{origin}})
+		}
+	}
+	return esgrs
+}
+
 // SecurityGroup represents a security group
 // Note: by design, security group names must be unique tenant-wide
 type SecurityGroup struct {
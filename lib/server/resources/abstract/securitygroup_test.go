@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/securitygroupruledirection"
 )
 
 func TestSecurityGroup_Clone(t *testing.T) {
@@ -66,3 +68,77 @@ func TestSecurityGroup_Clone(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestEffectiveSecurityGroupRules_Merge(t *testing.T) {
+	ssh := &SecurityGroupRule{
+		IDs:       []string{"rule-1"},
+		Direction: securitygroupruledirection.Ingress,
+		Protocol:  "tcp",
+		PortFrom:  22,
+		Sources:   []string{"0.0.0.0/0"},
+	}
+	sameSSH := &SecurityGroupRule{
+		IDs:         []string{"rule-2"},
+		Description: "ssh from everywhere",
+		Direction:   securitygroupruledirection.Ingress,
+		Protocol:    "tcp",
+		PortFrom:    22,
+		Sources:     []string{"0.0.0.0/0"},
+	}
+	https := &SecurityGroupRule{
+		IDs:       []string{"rule-3"},
+		Direction: securitygroupruledirection.Ingress,
+		Protocol:  "tcp",
+		PortFrom:  443,
+		Sources:   []string{"0.0.0.0/0"},
+	}
+
+	var rules EffectiveSecurityGroupRules
+	rules = rules.Merge(SecurityGroupRules{ssh}, SecurityGroupRuleOrigin{GroupID: "sg-1", GroupName: "host-sg"})
+	rules = rules.Merge(SecurityGroupRules{sameSSH, https}, SecurityGroupRuleOrigin{GroupID: "sg-2", GroupName: "subnet-sg", FromSubnet: true})
+	assert.Equal(t, 2, len(rules))
+	assert.Equal(t, 2, len(rules[0].Origins))
+	assert.Equal(t, "sg-2", rules[0].Origins[1].GroupID)
+	assert.True(t, rules[0].Origins[1].FromSubnet)
+	assert.Empty(t, rules[0].Rule.IDs)
+	assert.Equal(t, 1, len(rules[1].Origins))
+	assert.Equal(t, []string{"rule-1"}, ssh.IDs)
+}
+
+func TestSecurityGroupRules_Diff(t *testing.T) {
+	ssh := &SecurityGroupRule{
+		IDs:       []string{"rule-1"},
+		Direction: securitygroupruledirection.Ingress,
+		Protocol:  "tcp",
+		PortFrom:  22,
+		Sources:   []string{"0.0.0.0/0"},
+	}
+	http := &SecurityGroupRule{
+		IDs:       []string{"rule-2"},
+		Direction: securitygroupruledirection.Ingress,
+		Protocol:  "tcp",
+		PortFrom:  80,
+		Sources:   []string{"0.0.0.0/0"},
+	}
+	desiredSSH := &SecurityGroupRule{
+		Description: "ssh from everywhere",
+		Direction:   securitygroupruledirection.Ingress,
+		Protocol:    "tcp",
+		PortFrom:    22,
+		Sources:     []string{"0.0.0.0/0"},
+	}
+	https := &SecurityGroupRule{
+		Direction: securitygroupruledirection.Ingress,
+		Protocol:  "tcp",
+		PortFrom:  443,
+		Sources:   []string{"0.0.0.0/0"},
+	}
+
+	toDelete, toAdd := SecurityGroupRules{ssh, http}.Diff(SecurityGroupRules{desiredSSH, https, https})
+	assert.Equal(t, SecurityGroupRules{http}, toDelete)
+	assert.Equal(t, SecurityGroupRules{https}, toAdd)
+
+	toDelete, toAdd = SecurityGroupRules{ssh}.Diff(SecurityGroupRules{desiredSSH})
+	assert.Empty(t, toDelete)
+	assert.Empty(t, toAdd)
+}
@@ -52,21 +52,28 @@ type SubnetRequest struct {
 
 // Subnet represents a subnet
 type Subnet struct {
-	ID                      string           `json:"id"`                                   // ID of the subnet (from provider)
-	Name                    string           `json:"name"`                                 // Name of the subnet
-	Network                 string           `json:"network"`                              // parent Network of the subnet
-	CIDR                    string           `json:"mask"`                                 // ip network in CIDR notation
-	Domain                  string           `json:"domain,omitempty"`                     // contains the domain used to define host FQDN
-	DNSServers              []string         `json:"dns_servers,omitempty"`                // contains the DNSServers used on the subnet
-	GatewayIDs              []string         `json:"gateway_id,omitempty"`                 // contains the id of the host(s) acting as gateway(s) for the subnet
-	VIP                     *VirtualIP       `json:"vip,omitempty"`                        // contains the VIP of the network if created with HA
-	IPVersion               ipversion.Enum   `json:"ip_version,omitempty"`                 // IPVersion is IPv4 or IPv6 (see IPVersion)
-	State                   subnetstate.Enum `json:"status,omitempty"`                     // indicates the current state of the Subnet
-	GWSecurityGroupID       string           `json:"gw_security_group_id,omitempty"`       // Contains the ID of the Security Group for external access of gateways in Subnet
-	PublicIPSecurityGroupID string           `json:"publicip_security_group_id,omitempty"` // contains the ID of the Security Group for hosts with public IP in Subnet
-	InternalSecurityGroupID string           `json:"internal_security_group_id,omitempty"` // contains the ID of the security group for internal access of hosts
-	DefaultSSHPort          uint32           `json:"default_ssh_port,omitempty"`           // contains the port to use for SSH by default on hosts in the Subnet
-	SingleHostCIDRIndex     uint             `json:"single_host_cidr_index,omitempty"`     // if > 0, contains the index of the CIDR in the single Host Network
+	ID                      string            `json:"id"`                                   // ID of the subnet (from provider)
+	Name                    string            `json:"name"`                                 // Name of the subnet
+	Network                 string            `json:"network"`                              // parent Network of the subnet
+	CIDR                    string            `json:"mask"`                                 // ip network in CIDR notation
+	Domain                  string            `json:"domain,omitempty"`                     // contains the domain used to define host FQDN
+	DNSServers              []string          `json:"dns_servers,omitempty"`                // contains the DNSServers used on the subnet
+	GatewayIDs              []string          `json:"gateway_id,omitempty"`                 // contains the id of the host(s) acting as gateway(s) for the subnet
+	VIP                     *VirtualIP        `json:"vip,omitempty"`                        // contains the VIP of the network if created with HA
+	IPVersion               ipversion.Enum    `json:"ip_version,omitempty"`                 // IPVersion is IPv4 or IPv6 (see IPVersion)
+	State                   subnetstate.Enum  `json:"status,omitempty"`                     // indicates the current state of the Subnet
+	GWSecurityGroupID       string            `json:"gw_security_group_id,omitempty"`       // Contains the ID of the Security Group for external access of gateways in Subnet
+	PublicIPSecurityGroupID string            `json:"publicip_security_group_id,omitempty"` // contains the ID of the Security Group for hosts with public IP in Subnet
+	InternalSecurityGroupID string            `json:"internal_security_group_id,omitempty"` // contains the ID of the security group for internal access of hosts
+	DefaultSSHPort          uint32            `json:"default_ssh_port,omitempty"`           // contains the port to use for SSH by default on hosts in the Subnet
+	SingleHostCIDRIndex     uint              `json:"single_host_cidr_index,omitempty"`     // if > 0, contains the index of the CIDR in the single Host Network
+	Tags                    map[string]string `json:"tags,omitempty"`                       // contains the tags set on the Subnet (used to select Subnet on Host creation)
+}
+
+// SubnetSelectionCriteria contains the criteria used to select automatically a Subnet of a Network for a Host
+type SubnetSelectionCriteria struct {
+	Excluded     []string          // contains the IDs or names of the Subnets that must not be selected
+	RequiredTags map[string]string // contains the tags (with their value) the Subnet must have to be selected
 }
 
 // NewSubnet initializes a new instance of Subnet
@@ -91,7 +98,14 @@ func (s *Subnet) Replace(p data.Clonable) data.Clonable {
 		return s
 	}
 
-	*s = *p.(*Subnet)
+	src := p.(*Subnet)
+	*s = *src
+	if len(src.Tags) > 0 {
+		s.Tags = make(map[string]string, len(src.Tags))
+		for k, v := range src.Tags {
+			s.Tags[k] = v
+		}
+	}
 	return s
 }
 
@@ -18,12 +18,14 @@ package resources
 
 import (
 	"context"
+	"time"
 
 	"github.com/CS-SI/SafeScale/lib/protocol"
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clustercomplexity"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterflavor"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterstate"
+	propertiesv1 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v1"
 	propertiesv3 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v3"
 	"github.com/CS-SI/SafeScale/lib/utils/data"
 	"github.com/CS-SI/SafeScale/lib/utils/data/cache"
@@ -41,39 +43,111 @@ type Cluster interface {
 	observer.Observable
 	cache.Cacheable
 
-	AddFeature(ctx context.Context, name string, vars data.Map, settings FeatureSettings) (Results, fail.Error)    // adds feature on cluster
-	AddNode(ctx context.Context, def abstract.HostSizingRequirements) (Host, fail.Error)                           // adds a node
-	AddNodes(ctx context.Context, count uint, def abstract.HostSizingRequirements) ([]Host, fail.Error)            // adds several nodes
-	Browse(ctx context.Context, callback func(*abstract.ClusterIdentity) fail.Error) fail.Error                    // browse in metadata clusters and execute a callback on each entry
-	CheckFeature(ctx context.Context, name string, vars data.Map, settings FeatureSettings) (Results, fail.Error)  // checks feature on cluster
+	AddFeature(ctx context.Context, name string, vars data.Map, settings FeatureSettings) (Results, fail.Error) // adds feature on cluster
+	AddNode(ctx context.Context, def abstract.HostSizingRequirements) (Host, fail.Error)                        // adds a node
+	// AddNodes adds several nodes, optionally in a node pool
+	AddNodes(ctx context.Context, count uint, def abstract.HostSizingRequirements, options ...data.ImmutableKeyValue) ([]Host, fail.Error)
+	Browse(ctx context.Context, callback func(*abstract.ClusterIdentity) fail.Error) fail.Error // browse in metadata clusters and execute a callback on each entry
+	// BrowseFor browses like Browse, giving up after timeout (0 means no limit) with partial results and fail.ErrTimeout
+	BrowseFor(ctx context.Context, timeout time.Duration, callback func(*abstract.ClusterIdentity) fail.Error) fail.Error
+	// BrowseWithState browses in metadata clusters and executes a callback on each entry, with the last state known in metadata
+	BrowseWithState(ctx context.Context, callback func(*abstract.ClusterIdentity, clusterstate.Enum) fail.Error) fail.Error
+	CheckFeature(ctx context.Context, name string, vars data.Map, settings FeatureSettings) (Results, fail.Error) // checks feature on cluster
+	// CollectDiagnostics collects files and command outputs on all the masters and nodes, bundled in a tar archive
+	CollectDiagnostics(ctx context.Context, paths, commands []string, maxSize uint64) ([]byte, fail.Error)
+	CordonNode(ctx context.Context, ref string) fail.Error                                                         // marks a node as unschedulable
 	CountNodes(ctx context.Context) (uint, fail.Error)                                                             // counts the nodes of the cluster
 	Create(ctx context.Context, req abstract.ClusterRequest) fail.Error                                            // creates a new cluster and save its metadata
-	DeleteLastNode(ctx context.Context) (*propertiesv3.ClusterNode, fail.Error)                                    // deletes the last added node and returns its name
-	DeleteSpecificNode(ctx context.Context, hostID string, selectedMasterID string) fail.Error                     // deletes a node identified by its ID
-	Delete(ctx context.Context, force bool) fail.Error                                                             // deletes the cluster (Delete is not used to not collision with metadata)
-	FindAvailableMaster(ctx context.Context) (Host, fail.Error)                                                    // returns ID of the first master available to execute order
-	FindAvailableNode(ctx context.Context) (Host, fail.Error)                                                      // returns node instance of the first node available to execute order
-	GetIdentity() (abstract.ClusterIdentity, fail.Error)                                                           // returns Cluster Identity
-	GetFlavor() (clusterflavor.Enum, fail.Error)                                                                   // returns the flavor of the cluster
-	GetComplexity() (clustercomplexity.Enum, fail.Error)                                                           // returns the complexity of the cluster
-	GetAdminPassword() (string, fail.Error)                                                                        // returns the password of the cluster admin account
-	GetKeyPair() (abstract.KeyPair, fail.Error)                                                                    // returns the key pair used in the cluster
-	GetNetworkConfig() (*propertiesv3.ClusterNetwork, fail.Error)                                                  // returns network configuration of the cluster
-	GetState() (clusterstate.Enum, fail.Error)                                                                     // returns the current state of the cluster
-	IsFeatureInstalled(ctx context.Context, name string) (found bool, xerr fail.Error)                             // tells if a feature is installed in Cluster using only metadata
-	ListInstalledFeatures(ctx context.Context) ([]Feature, fail.Error)                                             // returns the list of installed features
-	ListMasters(ctx context.Context) (IndexedListOfClusterNodes, fail.Error)                                       // lists the node instances corresponding to masters (if there is such masters in the flavor...)
-	ListMasterIDs(ctx context.Context) (data.IndexedListOfStrings, fail.Error)                                     // lists the IDs of masters (if there is such masters in the flavor...)
-	ListMasterIPs(ctx context.Context) (data.IndexedListOfStrings, fail.Error)                                     // lists the IPs of masters (if there is such masters in the flavor...)
-	ListMasterNames(ctx context.Context) (data.IndexedListOfStrings, fail.Error)                                   // lists the names of the master nodes in the Cluster
-	ListNodes(ctx context.Context) (IndexedListOfClusterNodes, fail.Error)                                         // lists node instances corresponding to the nodes in the cluster
-	ListNodeIDs(ctx context.Context) (data.IndexedListOfStrings, fail.Error)                                       // lists the IDs of the nodes in the cluster
-	ListNodeIPs(ctx context.Context) (data.IndexedListOfStrings, fail.Error)                                       // lists the IPs of the nodes in the cluster
-	ListNodeNames(ctx context.Context) (data.IndexedListOfStrings, fail.Error)                                     // lists the names of the nodes in the Cluster
-	LookupNode(ctx context.Context, ref string) (bool, fail.Error)                                                 // tells if the ID of the host passed as parameter is a node
-	RemoveFeature(ctx context.Context, name string, vars data.Map, settings FeatureSettings) (Results, fail.Error) // removes feature from cluster
-	Shrink(ctx context.Context, count uint) ([]*propertiesv3.ClusterNode, fail.Error)                              // reduce the size of the cluster of 'count' nodes (the last created)
-	Start(ctx context.Context) fail.Error                                                                          // starts the cluster
-	Stop(ctx context.Context) fail.Error                                                                           // stops the cluster
+	DeleteLastNode(ctx context.Context, options ...data.ImmutableKeyValue) (*propertiesv3.ClusterNode, fail.Error) // deletes the last added node and returns its name
+	// DeleteSpecificNode deletes a node identified by its ID, draining it first if possible
+	DeleteSpecificNode(ctx context.Context, hostID, selectedMasterID string, options ...data.ImmutableKeyValue) fail.Error
+	Delete(ctx context.Context, force bool) fail.Error                                                                   // deletes the cluster (Delete is not used to not collision with metadata)
+	FindAvailableMaster(ctx context.Context, options ...data.ImmutableKeyValue) (Host, fail.Error)                       // returns ID of the first master available to execute order
+	FindAvailableNode(ctx context.Context, options ...data.ImmutableKeyValue) (Host, fail.Error)                         // returns node instance of the first node available to execute order
+	GetIdentity() (abstract.ClusterIdentity, fail.Error)                                                                 // returns Cluster Identity
+	GetFlavor() (clusterflavor.Enum, fail.Error)                                                                         // returns the flavor of the cluster
+	GetComplexity() (clustercomplexity.Enum, fail.Error)                                                                 // returns the complexity of the cluster
+	GetAdminPassword() (string, fail.Error)                                                                              // returns the password of the cluster admin account
+	GetKeyPair() (abstract.KeyPair, fail.Error)                                                                          // returns the key pair used in the cluster
+	GetKubeconfig(ctx context.Context) (string, fail.Error)                                                              // returns the admin kubeconfig of a K8S cluster, targeting the cluster endpoint
+	GetNetworkConfig() (*propertiesv3.ClusterNetwork, fail.Error)                                                        // returns network configuration of the cluster
+	ForceGetState(ctx context.Context) (clusterstate.Enum, fail.Error)                                                   // returns the current state of the cluster, always probing it
+	GetState() (clusterstate.Enum, fail.Error)                                                                           // returns the current state of the cluster
+	GetStateHistory(ctx context.Context) ([]propertiesv1.ClusterStateTransition, fail.Error)                             // returns the last state transitions of the cluster
+	InspectWithHealth(ctx context.Context) (*protocol.ClusterResponse, fail.Error)                                       // returns the description of the cluster as ToProtocol, with the health collected by probing its hosts
+	IsFeatureInstalled(ctx context.Context, name string) (found bool, xerr fail.Error)                                   // tells if a feature is installed in Cluster using only metadata
+	LabelNode(ctx context.Context, ref string, labels map[string]string) fail.Error                                      // sets labels on a node (a label with empty value is removed)
+	ListInstalledFeatures(ctx context.Context) ([]Feature, fail.Error)                                                   // returns the list of installed features
+	ListMasters(ctx context.Context) (IndexedListOfClusterNodes, fail.Error)                                             // lists the node instances corresponding to masters (if there is such masters in the flavor...)
+	ListMasterIDs(ctx context.Context) (data.IndexedListOfStrings, fail.Error)                                           // lists the IDs of masters (if there is such masters in the flavor...)
+	ListMasterIPs(ctx context.Context) (data.IndexedListOfStrings, fail.Error)                                           // lists the IPs of masters (if there is such masters in the flavor...)
+	ListMasterNames(ctx context.Context) (data.IndexedListOfStrings, fail.Error)                                         // lists the names of the master nodes in the Cluster
+	ListNodes(ctx context.Context) (IndexedListOfClusterNodes, fail.Error)                                               // lists node instances corresponding to the nodes in the cluster
+	ListNodeIDs(ctx context.Context) (data.IndexedListOfStrings, fail.Error)                                             // lists the IDs of the nodes in the cluster
+	ListNodeIPs(ctx context.Context) (data.IndexedListOfStrings, fail.Error)                                             // lists the IPs of the nodes in the cluster
+	ListNodeNames(ctx context.Context) (data.IndexedListOfStrings, fail.Error)                                           // lists the names of the nodes in the Cluster
+	LookupNode(ctx context.Context, ref string) (bool, fail.Error)                                                       // tells if the ID of the host passed as parameter is a node
+	Reconcile(ctx context.Context, apply bool) (ClusterReconciliationReport, fail.Error)                                 // checks the masters, nodes and gateways in metadata against the provider, removing the dead ones if 'apply'
+	RemoveFeature(ctx context.Context, name string, vars data.Map, settings FeatureSettings) (Results, fail.Error)       // removes feature from cluster
+	ResizeGateways(ctx context.Context, def abstract.HostSizingRequirements) fail.Error                                  // resizes the gateways of the cluster, secondary first
+	Resume(ctx context.Context) fail.Error                                                                               // continues the creation of a cluster interrupted while in state Creating
+	RetagResources(ctx context.Context, tags map[string]string) fail.Error                                               // merges tags in the tags of the cluster and sets them again on its resources in the provider
+	SetDomain(ctx context.Context, domain string) fail.Error                                                             // changes the DNS domain of the cluster, hosts resolving each other as <host>.<domain>
+	Shrink(ctx context.Context, count uint, options ...data.ImmutableKeyValue) ([]*propertiesv3.ClusterNode, fail.Error) // reduce the size of the cluster of 'count' nodes (the last created), draining them first if possible
+	Start(ctx context.Context) fail.Error                                                                                // starts the cluster
+	StartNodes(ctx context.Context, selector map[string]string) ([]string, fail.Error)                                   // starts the nodes matching the label selector
+	Stop(ctx context.Context, options ...data.ImmutableKeyValue) fail.Error                                              // stops the cluster
+	StopNodes(ctx context.Context, selector map[string]string) ([]string, fail.Error)                                    // stops the nodes matching the label selector
+	StreamLog(ctx context.Context, path string, lines uint, handler func(host, line string)) fail.Error                  // follows the file 'path' on all the masters and nodes, calling handler with each new line until aborted
+	UncordonNode(ctx context.Context, ref string) fail.Error                                                             // marks a cordoned node as schedulable again
+	Verify(ctx context.Context) fail.Error                                                                               // cross-checks the metadata of the cluster, reporting masters and nodes sharing the same name
+	VerifyFeatures(ctx context.Context) (*protocol.ClusterFeaturesVerification, fail.Error)                              // runs the checks of the installed features and returns their health on each host, changing nothing
 	ToProtocol() (*protocol.ClusterResponse, fail.Error)
 }
+
+// ClusterDriftKind identifies a kind of discrepancy between the metadata of a Cluster and the Hosts in the provider
+type ClusterDriftKind string
+
+const (
+	// DeadClusterNode means a master or a node of the Cluster does not exist in the provider anymore (fixed: the node is removed from the Cluster)
+	DeadClusterNode ClusterDriftKind = "dead-node"
+	// DeadClusterGateway means a gateway of the Cluster does not exist in the provider anymore (fixed for the secondary gateway only: it is removed from the Cluster)
+	DeadClusterGateway ClusterDriftKind = "dead-gateway"
+	// ClusterHostMismatch means the Host in the provider does not have the name recorded in the Cluster (not fixed)
+	ClusterHostMismatch ClusterDriftKind = "host-mismatch"
+	// ClusterSizingDrift means the sizing of the Host in the provider differs from the one in its metadata (not fixed)
+	ClusterSizingDrift ClusterDriftKind = "sizing-drift"
+)
+
+// ClusterDrift describes a discrepancy found by Cluster.Reconcile()
+type ClusterDrift struct {
+	Kind        ClusterDriftKind `json:"kind"`
+	Role        string           `json:"role"` // "gateway", "master" or "node"
+	HostID      string           `json:"host_id"`
+	HostName    string           `json:"host_name,omitempty"` // name recorded in metadata, if known
+	Description string           `json:"description"`
+	Fixed       bool             `json:"fixed,omitempty"` // true if the metadata of the Cluster has been fixed
+}
+
+// ClusterReconciliationReport contains the discrepancies found by Cluster.Reconcile()
+type ClusterReconciliationReport struct {
+	ClusterName string         `json:"cluster_name"`
+	Applied     bool           `json:"applied"` // true if the fixes have been requested
+	Drifts      []ClusterDrift `json:"drifts,omitempty"`
+}
+
+// IsConsistent tells if no discrepancy has been found
+func (r ClusterReconciliationReport) IsConsistent() bool {
+	return len(r.Drifts) == 0
+}
+
+// DriftsOfKind returns the discrepancies of kind 'kind'
+func (r ClusterReconciliationReport) DriftsOfKind(kind ClusterDriftKind) []ClusterDrift {
+	var out []ClusterDrift
+	for _, v := range r.Drifts {
+		if v.Kind == kind {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -52,4 +52,10 @@ const (
 	NetworkV3 = "13"
 	// NodesV3 contains optional additional info about network of the cluster
 	NodesV3 = "14"
+	// StateHistoryV1 contains optional additional info about the state transitions of the cluster
+	StateHistoryV1 = "15"
+	// TagsV1 contains optional additional info about the tags set on the resources of the cluster in the provider
+	TagsV1 = "16"
+	// LastOperationV1 contains optional additional info about the last operation run on the cluster
+	LastOperationV1 = "17"
 )
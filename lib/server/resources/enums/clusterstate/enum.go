@@ -19,6 +19,12 @@ package clusterstate
 
 //go:generate stringer -type=Enum
 
+import (
+	"strings"
+
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+)
+
 // Enum represents the state of a node
 type Enum int
 
@@ -48,3 +54,32 @@ const (
 	// Unknown ...
 	Unknown
 )
+
+var stringMap = map[string]Enum{
+	"nominal":      Nominal,
+	"degraded":     Degraded,
+	"stopped":      Stopped,
+	"initializing": Initializing,
+	"created":      Created,
+	"creating":     Creating,
+	"error":        Error,
+	"removed":      Removed,
+	"stopping":     Stopping,
+	"starting":     Starting,
+	"unknown":      Unknown,
+}
+
+// Parse returns a Enum corresponding to the string parameter
+// If the string doesn't correspond to any Enum, returns an error (nil otherwise)
+// This function is intended to be used to parse user input.
+func Parse(v string) (Enum, error) {
+	var (
+		e  Enum
+		ok bool
+	)
+	lowered := strings.ToLower(v)
+	if e, ok = stringMap[lowered]; !ok {
+		return e, fail.NotFoundError("failed to find a State.Enum corresponding to '%s'", v)
+	}
+	return e, nil
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package featuretarget
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+)
+
+// Enum tells on which Hosts of a Cluster a feature is applied
+type Enum uint8
+
+const (
+	// Default applies the feature on the Hosts targeted by the steps of its specification file
+	Default Enum = iota
+	// Gateways applies the feature on all the gateways of the Cluster
+	Gateways
+	// Masters applies the feature on all the masters of the Cluster
+	Masters
+	// Nodes applies the feature on all the nodes of the Cluster
+	Nodes
+	// AllHosts applies the feature on all the gateways, masters and nodes of the Cluster
+	AllHosts
+	// SingleMaster applies the feature on one available master of the Cluster
+	SingleMaster
+)
+
+var (
+	stringMap = map[string]Enum{
+		"":             Default,
+		"default":      Default,
+		"gateways":     Gateways,
+		"masters":      Masters,
+		"nodes":        Nodes,
+		"allhosts":     AllHosts,
+		"all":          AllHosts,
+		"singlemaster": SingleMaster,
+		"master":       SingleMaster,
+	}
+
+	enumMap = map[Enum]string{
+		Default:      "Default",
+		Gateways:     "Gateways",
+		Masters:      "Masters",
+		Nodes:        "Nodes",
+		AllHosts:     "AllHosts",
+		SingleMaster: "SingleMaster",
+	}
+)
+
+// Parse returns a Enum corresponding to the string parameter
+// If the string doesn't correspond to any Enum, returns an error (nil otherwise)
+// This function is intended to be used to parse user input.
+func Parse(v string) (Enum, error) {
+	var (
+		e  Enum
+		ok bool
+	)
+	lowered := strings.ToLower(v)
+	if e, ok = stringMap[lowered]; !ok {
+		return e, fail.NotFoundError("failed to find a FeatureTarget.Enum corresponding to '%s'", v)
+	}
+	return e, nil
+}
+
+// FromString returns a Enum corresponding to the string parameter
+// This method is intended to be used from validated input.
+func FromString(v string) (e Enum) {
+	e, err := Parse(v)
+	if err != nil {
+		panic(err.Error())
+	}
+	return
+}
+
+// String returns a string representaton of an Enum
+func (e Enum) String() string {
+	if str, found := enumMap[e]; found {
+		return str
+	}
+	panic(fmt.Sprintf("failed to find a FeatureTarget.Enum string corresponding to value '%d'!", e))
+}
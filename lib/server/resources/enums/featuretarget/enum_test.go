@@ -0,0 +1,46 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package featuretarget
+
+import (
+	"testing"
+)
+
+func TestEnum_String(t *testing.T) {
+	for k, v := range enumMap {
+		e, err := Parse(v)
+		if err != nil {
+			t.Errorf("failed to parse '%s': %v", v, err)
+			continue
+		}
+		if e != k {
+			t.Errorf("Value mismatch: %s, %s", k, e)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	if e, err := Parse(""); err != nil || e != Default {
+		t.Errorf("empty string should parse as Default")
+	}
+	if e, err := Parse("Nodes"); err != nil || e != Nodes {
+		t.Errorf("'Nodes' should parse as Nodes")
+	}
+	if _, err := Parse("workers"); err == nil {
+		t.Errorf("'workers' should not be parsed")
+	}
+}
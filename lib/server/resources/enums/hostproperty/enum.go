@@ -29,4 +29,7 @@ const (
 	ClusterMembershipV1 = "10" // optional additional information about the cluster membership of the host
 	SecurityGroupsV1    = "11" // optional additional information about security groups binded to the host
 	NetworkV2           = "12" // NetworkV2 contains optional additional information about network of the host
+	UserdataV1          = "13" // optional sanitized copy of the userdata used to provision the host, to be able to run again its install phases
+	LastOperationV1     = "14" // optional additional information about the last operation run on the host
+	InventoryV1         = "15" // optional inventory of the operating system and the packages installed on the host
 )
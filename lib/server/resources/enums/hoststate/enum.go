@@ -18,6 +18,12 @@ package hoststate
 
 //go:generate stringer -type=Enum
 
+import (
+	"strings"
+
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+)
+
 // Enum represents the state of an host
 type Enum int
 
@@ -32,3 +38,28 @@ const (
 
 	Unknown = 255 // when the state is undetermined
 )
+
+var stringMap = map[string]Enum{
+	"stopped":    Stopped,
+	"starting":   Starting,
+	"started":    Started,
+	"stopping":   Stopping,
+	"terminated": Terminated,
+	"error":      Error,
+	"unknown":    Unknown,
+}
+
+// Parse returns a Enum corresponding to the string parameter
+// If the string doesn't correspond to any Enum, returns an error (nil otherwise)
+// This function is intended to be used to parse user input.
+func Parse(v string) (Enum, error) {
+	var (
+		e  Enum
+		ok bool
+	)
+	lowered := strings.ToLower(v)
+	if e, ok = stringMap[lowered]; !ok {
+		return e, fail.NotFoundError("failed to find a HostState.Enum corresponding to '%s'", v)
+	}
+	return e, nil
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package hosttenancy defines an enum to represent the kind of physical host a Host is placed on
+package hosttenancy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+)
+
+// Enum represents the tenancy of a Host
+type Enum int
+
+const (
+	// Default places the Host on hardware shared with other tenants
+	Default Enum = iota
+	// Dedicated places the Host on hardware dedicated to the tenant
+	Dedicated
+	// Host places the Host on a specific dedicated host (or bare metal server) allocated to the tenant
+	Host
+)
+
+var (
+	stringMap = map[string]Enum{
+		"":          Default,
+		"default":   Default,
+		"dedicated": Dedicated,
+		"host":      Host,
+	}
+
+	enumMap = map[Enum]string{
+		Default:   "default",
+		Dedicated: "dedicated",
+		Host:      "host",
+	}
+)
+
+// Parse returns a Enum corresponding to the string parameter
+// If the string doesn't correspond to any Enum, returns an error (nil otherwise)
+// This function is intended to be used to parse user input.
+func Parse(v string) (Enum, fail.Error) {
+	var (
+		e  Enum
+		ok bool
+	)
+	lowered := strings.ToLower(strings.TrimSpace(v))
+	if e, ok = stringMap[lowered]; !ok {
+		return e, fail.NotFoundError("failed to find a Tenancy matching with '%s'", v)
+	}
+	return e, nil
+}
+
+// String returns a string representation of an Enum
+func (e Enum) String() string {
+	if str, found := enumMap[e]; found {
+		return str
+	}
+	panic(fmt.Sprintf("failed to find a string matching with Tenancy '%d'!", e))
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package hosttenancy
+
+
+import (
+	"testing"
+)
+
+func TestEnum_String(t *testing.T) {
+	for k, v := range enumMap {
+		e, err := Parse(v)
+		if err != nil {
+			t.Errorf("failed to parse '%s': %v", v, err)
+			continue
+		}
+		if e != k {
+			t.Errorf("Value mismatch: %s, %s", k, e)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	if e, err := Parse(""); err != nil || e != Default {
+		t.Errorf("empty string should parse as Default")
+	}
+	if e, err := Parse(" Dedicated "); err != nil || e != Dedicated {
+		t.Errorf("' Dedicated ' should parse as Dedicated")
+	}
+	if _, err := Parse("shared"); err == nil {
+		t.Errorf("'shared' should not be parsed")
+	}
+}
@@ -20,8 +20,9 @@ package networkproperty
 type Enum string
 
 const (
-	DescriptionV1 = "1" // contains optional additional info describing Networking (purpose, ...)
-	HostsV1       = "2" // OBSOLETE: moved to subnetproperty: contains list of hosts attached to the network
-	SubnetsV1     = "3" // contains the subnets created in the network
-	SingleHostsV1 = "4" // contains the CIDRs usable for single Hosts
+	DescriptionV1            = "1" // contains optional additional info describing Networking (purpose, ...)
+	HostsV1                  = "2" // OBSOLETE: moved to subnetproperty: contains list of hosts attached to the network
+	SubnetsV1                = "3" // contains the subnets created in the network
+	SingleHostsV1            = "4" // contains the CIDRs usable for single Hosts
+	SingleHostReservationsV1 = "5" // contains the CIDRs of single Hosts reserved but not yet committed
 )
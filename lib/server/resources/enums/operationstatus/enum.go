@@ -0,0 +1,29 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operationstatus
+
+// Enum represents the status of an operation on a resource
+type Enum string
+
+const (
+	// Running tells the operation is in progress (or has been interrupted without being able to record its outcome)
+	Running Enum = "running"
+	// Succeeded tells the operation ended successfully
+	Succeeded Enum = "succeeded"
+	// Failed tells the operation ended with an error
+	Failed Enum = "failed"
+)
@@ -0,0 +1,32 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package privilegeescalation defines an enum to represent the way to gain administrative privileges on a host
+package privilegeescalation
+
+//go:generate stringer -type=Enum
+
+// Enum represents the privilege escalation method available on a host
+type Enum int
+
+const (
+	// Sudo uses 'sudo' to run commands with privileges (default)
+	Sudo Enum = iota
+	// Doas uses 'doas' to run commands with privileges
+	Doas
+	// None means the host does not allow privilege escalation
+	None
+)
@@ -0,0 +1,29 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package taggableresource
+
+// Enum represents the type of a resource that can be tagged in the provider
+type Enum string
+
+const (
+	// Host designates a Host
+	Host Enum = "host"
+	// Network designates a Network
+	Network Enum = "network"
+	// Subnet designates a Subnet
+	Subnet Enum = "subnet"
+)
@@ -22,12 +22,36 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/iaas"
 	"github.com/CS-SI/SafeScale/lib/server/resources"
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clustercomplexity"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterflavor"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterstate"
 	"github.com/CS-SI/SafeScale/lib/server/resources/operations"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
 )
 
-// List returns a list of available hosts
-func List(ctx context.Context, svc iaas.Service) (list []abstract.ClusterIdentity, xerr fail.Error) {
+// ListFilter contains the criteria a Cluster must match to be listed; a zero value criterion matches everything
+type ListFilter struct {
+	Flavor     clusterflavor.Enum
+	Complexity clustercomplexity.Enum
+	State      clusterstate.Enum
+}
+
+// Matches tells if the Cluster identified by identity and in state matches the filter
+func (f ListFilter) Matches(identity abstract.ClusterIdentity, state clusterstate.Enum) bool {
+	if f.Flavor != 0 && identity.Flavor != f.Flavor {
+		return false
+	}
+	if f.Complexity != 0 && identity.Complexity != f.Complexity {
+		return false
+	}
+	if f.State != 0 && state != f.State {
+		return false
+	}
+	return true
+}
+
+// List returns a list of available clusters matching the filter
+func List(ctx context.Context, svc iaas.Service, filter ListFilter) (list []abstract.ClusterIdentity, xerr fail.Error) {
 	var emptyList []abstract.ClusterIdentity
 
 	if ctx == nil {
@@ -43,8 +67,10 @@ func List(ctx context.Context, svc iaas.Service) (list []abstract.ClusterIdentit
 	}
 
 	list = []abstract.ClusterIdentity{}
-	xerr = instance.Browse(ctx, func(hc *abstract.ClusterIdentity) fail.Error {
-		list = append(list, *hc)
+	xerr = instance.BrowseWithState(ctx, func(hc *abstract.ClusterIdentity, state clusterstate.Enum) fail.Error {
+		if filter.Matches(*hc, state) {
+			list = append(list, *hc)
+		}
 		return nil
 	})
 	return list, xerr
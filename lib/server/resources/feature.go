@@ -18,8 +18,10 @@ package resources
 
 import (
 	"context"
+	"time"
 
 	"github.com/CS-SI/SafeScale/lib/protocol"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/featuretarget"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/featuretargettype"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/installmethod"
 	"github.com/CS-SI/SafeScale/lib/utils/data"
@@ -55,10 +57,12 @@ type Feature interface {
 
 // FeatureSettings are used to tune the feature
 type FeatureSettings struct {
-	SkipProxy               bool // to tell not to try to set reverse proxy
-	Serialize               bool // force not to parallel hosts in step
-	SkipFeatureRequirements bool // tells not to install required features
-	SkipSizingRequirements  bool // tells not to check sizing requirements
-	AddUnconditionally      bool // tells to not check before addition (no effect for check or removal)
-	IgnoreSuitability       bool // allows to not check if the feature is suitable for the target
+	SkipProxy               bool               // to tell not to try to set reverse proxy
+	Serialize               bool               // force not to parallel hosts in step
+	SkipFeatureRequirements bool               // tells not to install required features
+	SkipSizingRequirements  bool               // tells not to check sizing requirements
+	AddUnconditionally      bool               // tells to not check before addition (no effect for check or removal)
+	IgnoreSuitability       bool               // allows to not check if the feature is suitable for the target
+	Target                  featuretarget.Enum // tells on which Hosts of a Cluster the feature is applied, overriding the targets of its steps (no effect on Host)
+	Timeout                 time.Duration      // limits the duration of the action on the feature, overriding the timeout of its specification file (0 to not override)
 }
@@ -18,17 +18,20 @@ package resources
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/securitygroupstate"
 	propertiesv1 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v1"
 	"github.com/CS-SI/SafeScale/lib/system"
+	"github.com/CS-SI/SafeScale/lib/utils/data"
 	"github.com/CS-SI/SafeScale/lib/utils/data/cache"
 	"github.com/CS-SI/SafeScale/lib/utils/data/observer"
 
 	"github.com/CS-SI/SafeScale/lib/protocol"
 	"github.com/CS-SI/SafeScale/lib/server/iaas/userdata"
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusternodetype"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hoststate"
 	"github.com/CS-SI/SafeScale/lib/utils/cli/enums/outputs"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
@@ -41,39 +44,130 @@ type Host interface {
 	observer.Observable
 	cache.Cacheable
 
-	BindSecurityGroup(ctx context.Context, sg SecurityGroup, enable SecurityGroupActivation) fail.Error                                // Binds a security group to host
-	Browse(ctx context.Context, callback func(*abstract.HostCore) fail.Error) fail.Error                                               // ...
-	Create(ctx context.Context, hostReq abstract.HostRequest, hostDef abstract.HostSizingRequirements) (*userdata.Content, fail.Error) // creates a new host and its metadata
-	Delete(ctx context.Context) fail.Error
-	DisableSecurityGroup(ctx context.Context, sg SecurityGroup) fail.Error                                                                       // disables a binded security group on host
-	EnableSecurityGroup(ctx context.Context, sg SecurityGroup) fail.Error                                                                        // enables a binded security group on host
-	ForceGetState(ctx context.Context) (hoststate.Enum, fail.Error)                                                                              // returns the real current state of the host, with error handling
-	GetAccessIP() (string, fail.Error)                                                                                                           // returns the IP to reach the host, with error handling
-	GetDefaultSubnet() (Subnet, fail.Error)                                                                                                      // returns the resources.Subnet instance corresponding to the default subnet of the host, with error handling
-	GetMounts() (*propertiesv1.HostMounts, fail.Error)                                                                                           // returns the mounts on the host
-	GetPrivateIP() (ip string, err fail.Error)                                                                                                   // returns the IP address of the host on the default subnet, with error handling
-	GetPrivateIPOnSubnet(subnetID string) (ip string, err fail.Error)                                                                            // returns the IP address of the host on the requested subnet, with error handling
-	GetPublicIP() (ip string, err fail.Error)                                                                                                    // returns the public IP address of the host, with error handling
-	GetShare(shareRef string) (*propertiesv1.HostShare, fail.Error)                                                                              // returns a clone of the propertiesv1.HostShare corresponding to share 'shareRef'
-	GetShares() (*propertiesv1.HostShares, fail.Error)                                                                                           // returns the shares hosted on the host
-	GetSSHConfig() (*system.SSHConfig, fail.Error)                                                                                               // loads SSH configuration for host from metadata
-	GetState() hoststate.Enum                                                                                                                    // returns the current state of the host, with error handling
-	GetVolumes() (*propertiesv1.HostVolumes, fail.Error)                                                                                         // returns the volumes attached to the host
-	IsClusterMember() (bool, fail.Error)                                                                                                         // returns true if the host is member of a cluster
-	IsFeatureInstalled(f string) (bool, fail.Error)                                                                                              // tells if a feature is installed on Host, using only metadata
-	IsGateway() (bool, fail.Error)                                                                                                               // tells of  the host acts as a gateway
-	IsSingle() (bool, fail.Error)                                                                                                                // tells of  the host acts as a gateway
-	ListSecurityGroups(state securitygroupstate.Enum) ([]*propertiesv1.SecurityGroupBond, fail.Error)                                            // returns a slice of properties.SecurityGroupBond corresponding to bound Security Group of the host
-	Pull(ctx context.Context, target, source string, timeout time.Duration) (int, string, string, fail.Error)                                    // downloads a file from host
-	Push(ctx context.Context, source, target, owner, mode string, timeout time.Duration) (int, string, string, fail.Error)                       // uploads a file to host
-	PushStringToFile(ctx context.Context, content string, filename string) fail.Error                                                            // creates a file 'filename' on remote 'host' with the content 'content'
-	PushStringToFileWithOwnership(ctx context.Context, content string, filename string, owner, mode string) fail.Error                           // creates a file 'filename' on remote 'host' with the content 'content' and apply ownership to it
-	Reboot(ctx context.Context) fail.Error                                                                                                       // reboots the host
-	Resize(ctx context.Context, hostSize abstract.HostSizingRequirements) fail.Error                                                             // resize the host (probably not yet implemented on some proviers if not all)
-	Run(ctx context.Context, cmd string, outs outputs.Enum, connectionTimeout, executionTimeout time.Duration) (int, string, string, fail.Error) // tries to execute command 'cmd' on the host
-	Start(ctx context.Context) fail.Error                                                                                                        // starts the host
-	Stop(ctx context.Context) fail.Error                                                                                                         // stops the host
-	ToProtocol() (*protocol.Host, fail.Error)                                                                                                    // converts a host to equivalent gRPC message
-	UnbindSecurityGroup(ctx context.Context, sg SecurityGroup) fail.Error                                                                        // Unbinds a security group from host
-	WaitSSHReady(ctx context.Context, timeout time.Duration) (status string, err fail.Error)                                                     // Wait for remote SSH to respond
+	AttachToSubnet(ctx context.Context, subnetID string, options ...data.ImmutableKeyValue) fail.Error                                                   // connects the host to an additional subnet
+	BindSecurityGroup(ctx context.Context, sg SecurityGroup, enable SecurityGroupActivation) fail.Error                                                  // Binds a security group to host
+	Browse(ctx context.Context, callback func(*abstract.HostCore) fail.Error) fail.Error                                                                 // ...
+	BrowseFor(ctx context.Context, timeout time.Duration, callback func(*abstract.HostCore) fail.Error) fail.Error                                       // browses like Browse, giving up after timeout with partial results and fail.ErrTimeout
+	CheckCreation(ctx context.Context, hostReq abstract.HostRequest, hostDef abstract.HostSizingRequirements) (*abstract.HostCreationReport, fail.Error) // resolves the resources a creation would use, without creating anything
+	CollectInventory(ctx context.Context) (*propertiesv1.HostInventory, fail.Error)                                                                      // collects and records the releases of OS and kernel, and the versions of the installed packages
+	Create(ctx context.Context, hostReq abstract.HostRequest, hostDef abstract.HostSizingRequirements) (*userdata.Content, fail.Error)                   // creates a new host and its metadata
+	CreateImage(ctx context.Context, imageName string) (string, fail.Error)                                                                              // captures the disk of the host in a new image, stopping then restarting the host if needed, and returns the ID of the image
+	Delete(ctx context.Context, options ...data.ImmutableKeyValue) fail.Error
+	DetachFromSubnet(ctx context.Context, subnetID string) fail.Error                                                                                                                                         // disconnects the host from a subnet that is not its default one
+	DisableSecurityGroup(ctx context.Context, sg SecurityGroup) fail.Error                                                                                                                                    // disables a binded security group on host
+	EnableSecurityGroup(ctx context.Context, sg SecurityGroup) fail.Error                                                                                                                                     // enables a binded security group on host
+	Execute(ctx context.Context, cmd string, options ...data.ImmutableKeyValue) (*ExecutionResult, fail.Error)                                                                                                // executes command 'cmd' on the host, returning its outcome
+	ForceGetState(ctx context.Context) (hoststate.Enum, fail.Error)                                                                                                                                           // returns the real current state of the host, with error handling
+	GetAccessIP() (string, fail.Error)                                                                                                                                                                        // returns the IP to reach the host, with error handling
+	GetDefaultSubnet() (Subnet, fail.Error)                                                                                                                                                                   // returns the resources.Subnet instance corresponding to the default subnet of the host, with error handling
+	GetEffectiveRules(ctx context.Context) (abstract.EffectiveSecurityGroupRules, fail.Error)                                                                                                                 // returns the rules applied by the enabled Security Groups bound to the host
+	GetInventory() (*propertiesv1.HostInventory, fail.Error)                                                                                                                                                  // returns the inventory recorded by the last CollectInventory
+	GetMounts() (*propertiesv1.HostMounts, fail.Error)                                                                                                                                                        // returns the mounts on the host
+	GetPrivateIP() (ip string, err fail.Error)                                                                                                                                                                // returns the IP address of the host on the default subnet, with error handling
+	GetPrivateIPOnSubnet(subnetID string) (ip string, err fail.Error)                                                                                                                                         // returns the IP address of the host on the requested subnet, with error handling
+	GetPrivateIPs() (map[string]string, fail.Error)                                                                                                                                                           // returns the IP addresses of the host on all its subnets, indexed by subnet ID
+	GetPublicIP() (ip string, err fail.Error)                                                                                                                                                                 // returns the preferred public IP address of the host (IPv4 first), with error handling
+	GetPublicIPv4() (ip string, err fail.Error)                                                                                                                                                               // returns the public IPv4 address of the host, fail.ErrNotFound if there is none
+	GetPublicIPv6() (ip string, err fail.Error)                                                                                                                                                               // returns the public IPv6 address of the host, fail.ErrNotFound if there is none
+	GetShare(shareRef string) (*propertiesv1.HostShare, fail.Error)                                                                                                                                           // returns a clone of the propertiesv1.HostShare corresponding to share 'shareRef'
+	GetShares() (*propertiesv1.HostShares, fail.Error)                                                                                                                                                        // returns the shares hosted on the host
+	GetSSHConfig() (*system.SSHConfig, fail.Error)                                                                                                                                                            // loads SSH configuration for host from metadata
+	GetState() hoststate.Enum                                                                                                                                                                                 // returns the current state of the host, with error handling
+	GetVolumes() (*propertiesv1.HostVolumes, fail.Error)                                                                                                                                                      // returns the volumes attached to the host
+	Import(ctx context.Context, providerHostID, subnetID string, options ...data.ImmutableKeyValue) fail.Error                                                                                                // brings under management a host created outside SafeScale
+	IsClusterMember() (bool, fail.Error)                                                                                                                                                                      // returns true if the host is member of a cluster
+	IsFeatureInstalled(f string) (bool, fail.Error)                                                                                                                                                           // tells if a feature is installed on Host, using only metadata
+	IsGateway() (bool, fail.Error)                                                                                                                                                                            // tells of  the host acts as a gateway
+	IsSingle() (bool, fail.Error)                                                                                                                                                                             // tells of  the host acts as a gateway
+	JoinCluster(ctx context.Context, clusterName string, role clusternodetype.Enum) fail.Error                                                                                                                // makes the host join an existing cluster
+	LeaveCluster(ctx context.Context) fail.Error                                                                                                                                                              // makes the host leave the cluster it is member of
+	ListSecurityGroups(state securitygroupstate.Enum) ([]*propertiesv1.SecurityGroupBond, fail.Error)                                                                                                         // returns a slice of properties.SecurityGroupBond corresponding to bound Security Group of the host
+	Pull(ctx context.Context, target, source string, timeout time.Duration) (int, string, string, fail.Error)                                                                                                 // downloads a file from host
+	Push(ctx context.Context, source, target, owner, mode string, timeout time.Duration) (int, string, string, fail.Error)                                                                                    // uploads a file to host
+	PushStringToFile(ctx context.Context, content string, filename string) fail.Error                                                                                                                         // creates a file 'filename' on remote 'host' with the content 'content'
+	PushStringToFileWithOwnership(ctx context.Context, content string, filename string, owner, mode string) fail.Error                                                                                        // creates a file 'filename' on remote 'host' with the content 'content' and apply ownership to it
+	Reboot(ctx context.Context) fail.Error                                                                                                                                                                    // reboots the host
+	RefreshConnectivity(ctx context.Context) (bool, fail.Error)                                                                                                                                               // resolves again the gateways of the host without reloading its metadata, and tells if the host can be reached
+	Repair(ctx context.Context, report ConsistencyReport, kinds ...ConsistencyIssueKind) fail.Error                                                                                                           // fixes the discrepancies of the report of the requested kinds
+	Resize(ctx context.Context, hostSize abstract.HostSizingRequirements) fail.Error                                                                                                                          // resize the host (probably not yet implemented on some proviers if not all)
+	Run(ctx context.Context, cmd string, outs outputs.Enum, connectionTimeout, executionTimeout time.Duration, options ...data.ImmutableKeyValue) (int, string, string, fail.Error)                           // tries to execute command 'cmd' on the host
+	RunPhase(ctx context.Context, phase userdata.Phase) fail.Error                                                                                                                                            // runs again an install phase on the host, from the userdata recorded at creation
+	RotateKeypair(ctx context.Context) fail.Error                                                                                                                                                             // replaces the SSH keypair used to connect to the host
+	RunWithStdin(ctx context.Context, cmd string, stdin io.Reader, outs outputs.Enum, connectionTimeout, executionTimeout time.Duration, options ...data.ImmutableKeyValue) (int, string, string, fail.Error) // tries to execute command 'cmd' on the host, streaming 'stdin' to its standard input
+	Start(ctx context.Context) fail.Error                                                                                                                                                                     // starts the host
+	Stop(ctx context.Context) fail.Error                                                                                                                                                                      // stops the host
+	StopGracefully(ctx context.Context, gracePeriod time.Duration) (HostStopMethod, fail.Error)                                                                                                               // stops the host from inside, falling back to a provider stop if not stopped after gracePeriod
+	StreamLog(ctx context.Context, path string, lines uint, handler func(string)) fail.Error                                                                                                                  // follows the file 'path' on the host, calling handler with each new line until aborted
+	ToProtocol() (*protocol.Host, fail.Error)                                                                                                                                                                 // converts a host to equivalent gRPC message
+	UnbindSecurityGroup(ctx context.Context, sg SecurityGroup) fail.Error                                                                                                                                     // Unbinds a security group from host
+	Verify(ctx context.Context) (ConsistencyReport, fail.Error)                                                                                                                                               // cross-checks the metadata of the host against the resources it references
+	WaitForAnyState(ctx context.Context, states []hoststate.Enum, timeout time.Duration) (hoststate.Enum, fail.Error)                                                                                         // waits for the host to reach one of the states on provider side, and returns the state reached
+	WaitForState(ctx context.Context, state hoststate.Enum, timeout time.Duration) fail.Error                                                                                                                 // waits for the host to reach the state on provider side
+	WaitSSHReady(ctx context.Context, timeout time.Duration) (status string, err fail.Error)                                                                                                                  // Wait for remote SSH to respond
+}
+
+// ExecutionResult contains the outcome of a command executed on a Host by Host.Execute()
+type ExecutionResult struct {
+	Command  string        // command really executed on the Host (including privilege escalation, if requested)
+	Retcode  int           // exit code of the command (255 if the SSH connection failed)
+	Stdout   string        // standard output of the command (empty if not collected)
+	Stderr   string        // standard error of the command (empty if not collected)
+	Duration time.Duration // time taken by the execution, including the SSH connection
+}
+
+// HostStopMethod tells how a Host has been stopped
+type HostStopMethod string
+
+const (
+	// HostStoppedByProvider means the Host has been stopped by a power action of the provider
+	HostStoppedByProvider HostStopMethod = "provider"
+	// HostStoppedFromGuest means the Host has been stopped by a shutdown of its operating system
+	HostStoppedFromGuest HostStopMethod = "guest"
+)
+
+// ConsistencyIssueKind identifies a kind of discrepancy between the metadata of a Host and the resources it references
+type ConsistencyIssueKind string
+
+const (
+	// MissingSubnet means a Subnet referenced by the Host does not exist anymore (not repairable)
+	MissingSubnet ConsistencyIssueKind = "missing-subnet"
+	// MissingSubnetLink means a Subnet of the Host does not reference the Host (repairable: the Host is re-added to the Subnet)
+	MissingSubnetLink ConsistencyIssueKind = "missing-subnet-link"
+	// DeadSecurityGroupBond means a Security Group bound to the Host does not exist anymore (repairable: the bond is dropped)
+	DeadSecurityGroupBond ConsistencyIssueKind = "dead-security-group-bond"
+	// DeadVolumeAttachment means a Volume attached to the Host does not exist anymore (repairable: the attachment is dropped)
+	DeadVolumeAttachment ConsistencyIssueKind = "dead-volume-attachment"
+	// DeadRemoteMount means a Share mounted on the Host does not exist anymore (repairable: the mount is dropped)
+	DeadRemoteMount ConsistencyIssueKind = "dead-remote-mount"
+)
+
+// ConsistencyIssue describes a discrepancy found in the metadata of a Host
+type ConsistencyIssue struct {
+	Kind         ConsistencyIssueKind `json:"kind"`
+	ResourceID   string               `json:"resource_id"`             // ID of the referenced resource
+	ResourceName string               `json:"resource_name,omitempty"` // name of the referenced resource, if known
+	Description  string               `json:"description"`
+}
+
+// ConsistencyReport contains the discrepancies found by Host.Verify()
+type ConsistencyReport struct {
+	HostID   string             `json:"host_id"`
+	HostName string             `json:"host_name"`
+	Issues   []ConsistencyIssue `json:"issues,omitempty"`
+}
+
+// IsConsistent tells if no discrepancy has been found
+func (r ConsistencyReport) IsConsistent() bool {
+	return len(r.Issues) == 0
+}
+
+// IssuesOfKind returns the discrepancies of kind 'kind'
+func (r ConsistencyReport) IssuesOfKind(kind ConsistencyIssueKind) []ConsistencyIssue {
+	var out []ConsistencyIssue
+	for _, v := range r.Issues {
+		if v.Kind == kind {
+			out = append(out, v)
+		}
+	}
+	return out
 }
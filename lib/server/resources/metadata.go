@@ -32,14 +32,15 @@ type Metadata interface {
 	cache.Cacheable
 
 	IsNull() bool
-	Alter(callback Callback, options ...data.ImmutableKeyValue) fail.Error // protects the data for exclusive write
-	BrowseFolder(callback func(buf []byte) fail.Error) fail.Error          // walks through host folder and executes a callback for each entries
-	Deserialize(buf []byte) fail.Error                                     // Transforms a slice of bytes in struct
-	GetService() iaas.Service                                              // returns the iaas.Service used
-	Inspect(callback Callback) fail.Error                                  // protects the data for shared read with first reloading data from Object Storage
-	Review(callback Callback) fail.Error                                   // protects the data for shared read without reloading first (uses in-memory data); use with caution
-	Read(ref string) fail.Error                                            // reads the data from Object Storage using ref as id or name
-	ReadByID(id string) fail.Error                                         // reads the data from Object Storage by id
-	Reload() fail.Error                                                    // Reloads the metadata from the Object Storage, overriding what is in the object
+	Alter(callback Callback, options ...data.ImmutableKeyValue) fail.Error        // protects the data for exclusive write
+	AlterMany(callbacks []Callback, options ...data.ImmutableKeyValue) fail.Error // protects the data for exclusive write, running several callbacks before writing once
+	BrowseFolder(callback func(buf []byte) fail.Error) fail.Error                 // walks through host folder and executes a callback for each entries
+	Deserialize(buf []byte) fail.Error                                            // Transforms a slice of bytes in struct
+	GetService() iaas.Service                                                     // returns the iaas.Service used
+	Inspect(callback Callback) fail.Error                                         // protects the data for shared read with first reloading data from Object Storage
+	Review(callback Callback) fail.Error                                          // protects the data for shared read without reloading first (uses in-memory data); use with caution
+	Read(ref string) fail.Error                                                   // reads the data from Object Storage using ref as id or name
+	ReadByID(id string) fail.Error                                                // reads the data from Object Storage by id
+	Reload() fail.Error                                                           // Reloads the metadata from the Object Storage, overriding what is in the object
 	Serialize() ([]byte, fail.Error)
 }
@@ -40,6 +40,8 @@ type Network interface {
 	Browse(ctx context.Context, callback func(*abstract.Network) fail.Error) fail.Error // call the callback for each entry of the metadata folder of Networks
 	Create(ctx context.Context, req abstract.NetworkRequest) fail.Error                 // creates a Network
 	Delete(ctx context.Context) fail.Error
-	InspectSubnet(ubnetRef string) (Subnet, fail.Error) // returns the Subnet instance corresponding to Subnet reference (ID or name) provided (if Subnet is attached to the Network)
-	ToProtocol() (*protocol.Network, fail.Error)        // converts the network to protobuf message
+	InspectUsage(ctx context.Context) (*abstract.NetworkUsage, fail.Error)                                   // returns the Subnets of the Network with their occupation, and the free CIDR slots for single Hosts
+	InspectSubnet(ubnetRef string) (Subnet, fail.Error)                                                      // returns the Subnet instance corresponding to Subnet reference (ID or name) provided (if Subnet is attached to the Network)
+	SelectSubnetForHost(ctx context.Context, criteria abstract.SubnetSelectionCriteria) (Subnet, fail.Error) // returns the Subnet of the Network with the most free IP addresses respecting criteria
+	ToProtocol() (*protocol.Network, fail.Error)                                                             // converts the network to protobuf message
 }
@@ -17,11 +17,16 @@
 package operations
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -39,21 +44,26 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clustercomplexity"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterflavor"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusternodestate"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusternodetype"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterproperty"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterstate"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hostproperty"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/installmethod"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/taggableresource"
 	"github.com/CS-SI/SafeScale/lib/server/resources/operations/converters"
 	propertiesv1 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v1"
 	propertiesv2 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v2"
 	propertiesv3 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v3"
 	"github.com/CS-SI/SafeScale/lib/utils"
+	"github.com/CS-SI/SafeScale/lib/utils/cli/enums/outputs"
 	"github.com/CS-SI/SafeScale/lib/utils/concurrency"
 	"github.com/CS-SI/SafeScale/lib/utils/data"
 	"github.com/CS-SI/SafeScale/lib/utils/data/cache"
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
 	"github.com/CS-SI/SafeScale/lib/utils/debug/tracing"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
+	"github.com/CS-SI/SafeScale/lib/utils/metrics"
 	"github.com/CS-SI/SafeScale/lib/utils/retry"
 	"github.com/CS-SI/SafeScale/lib/utils/serialize"
 	"github.com/CS-SI/SafeScale/lib/utils/strprocess"
@@ -75,6 +85,9 @@ type Cluster struct {
 	installMethods      map[uint8]installmethod.Enum
 	lastStateCollection time.Time
 	makers              clusterflavors2.Makers
+	masterCursor        uint32 // used by round-robin selection of available master (accessed atomically)
+	nodeCursor          uint32 // used by round-robin selection of available node (accessed atomically)
+	healthCache         clusterHealthCache
 }
 
 // ClusterNullValue returns a *Cluster representing a null value
@@ -127,29 +140,18 @@ func LoadCluster(svc iaas.Service, name string) (rc resources.Cluster, xerr fail
 			}
 			// TODO: core.Read() does not check communication failure, side effect of limitations of Stow (waiting for stow replacement)
 			if innerXErr = rc.Read(name); innerXErr != nil {
-				return nil, innerXErr
+				switch innerXErr.(type) {
+				case *fail.ErrNotFound:
+					// the Cluster may store its metadata in its own bucket
+					rc, innerXErr = loadClusterFromMetadataBucket(svc, name)
+					if innerXErr != nil {
+						return nil, innerXErr
+					}
+				default:
+					return nil, innerXErr
+				}
 			}
 
-			// VPL: disabled silent metadata upgrade; will be implemented in a global one-pass migration
-			// // deal with legacy
-			// xerr = rc.(*cluster).updateClusterNodesPropertyIfNeeded()
-			// xerr = debug.InjectPlannedFail(xerr)
-			// if xerr != nil {
-			// 	return nullCluster(), xerr
-			// }
-			//
-			// xerr = rc.(*cluster).updateClusterNetworkPropertyIfNeeded()
-			// xerr = debug.InjectPlannedFail(xerr)
-			// if xerr != nil {
-			// 	return nullCluster(), xerr
-			// }
-			//
-			// xerr = rc.(*cluster).updateClusterDefaultsPropertyIfNeeded()
-			// xerr = debug.InjectPlannedFail(xerr)
-			// if xerr != nil {
-			// 	return nullCluster(), xerr
-			// }
-
 			rc.(*Cluster).updateCachedInformation()
 
 			return rc, nil
@@ -175,259 +177,189 @@ func LoadCluster(svc iaas.Service, name string) (rc resources.Cluster, xerr fail
 	return rc, nil
 }
 
-// VPL: disabled silent metadata upgrade; will be implemented in a global one-pass migration
-// // updateClusterNodesPropertyIfNeeded upgrades current Nodes property to last Nodes property (currently NodesV2)
-// func (instance *cluster) updateClusterNodesPropertyIfNeeded() fail.Error {
-// 	if instance.isNull() {
-// 		return fail.InvalidInstanceError()
-// 	}
-// 	xerr := instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-// 		if props.Lookup(clusterproperty.NodesV3) {
-// 			return nil
-// 		}
-//
-// 		if props.Lookup(clusterproperty.NodesV2) {
-// 			var (
-// 				nodesV2 *propertiesv2.ClusterNodes
-// 				ok      bool
-// 			)
-// 			innerXErr := props.Inspect(clusterproperty.NodesV2, func(clonable data.Clonable) fail.Error {
-// 				nodesV2, ok = clonable.(*propertiesv2.ClusterNodes)
-// 				if !ok {
-// 					return fail.InconsistentError("'*propertiesv2.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
-// 				}
-// 				return nil
-// 			})
-// 			if innerXErr != nil {
-// 				return innerXErr
-// 			}
-//
-// 			return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
-// 				nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
-// 				if !ok {
-// 					return fail.InconsistentError("'*propertiesv3.Nodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
-// 				}
-//
-// 				for _, i := range nodesV2.Masters {
-// 					nodesV3.GlobalLastIndex++
-//
-// 					node := &propertiesv3.ClusterNode{
-// 						ID:          i.ID,
-// 						NumericalID: nodesV3.GlobalLastIndex,
-// 						Name:        i.Name,
-// 						PrivateIP:   i.PrivateIP,
-// 						PublicIP:    i.PublicIP,
-// 					}
-// 					nodesV3.Masters = append(nodesV3.Masters, nodesV3.GlobalLastIndex)
-// 					nodesV3.ByNumericalID[nodesV3.GlobalLastIndex] = node
-// 				}
-// 				for _, i := range nodesV2.PrivateNodes {
-// 					nodesV3.GlobalLastIndex++
-//
-// 					node := &propertiesv3.ClusterNode{
-// 						ID:          i.ID,
-// 						NumericalID: nodesV3.GlobalLastIndex,
-// 						Name:        i.Name,
-// 						PrivateIP:   i.PrivateIP,
-// 						PublicIP:    i.PublicIP,
-// 					}
-// 					nodesV3.PrivateNodes = append(nodesV3.PrivateNodes, nodesV3.GlobalLastIndex)
-// 					nodesV3.ByNumericalID[nodesV3.GlobalLastIndex] = node
-// 				}
-// 				nodesV3.MasterLastIndex = nodesV2.MasterLastIndex
-// 				nodesV3.PrivateLastIndex = nodesV2.PrivateLastIndex
-// 				return nil
-// 			})
-// 		}
-//
-// 		if props.Lookup(clusterproperty.NodesV1) {
-// 			var (
-// 				nodesV1 *propertiesv1.ClusterNodes
-// 				ok      bool
-// 			)
-//
-// 			innerXErr := props.Inspect(clusterproperty.NodesV1, func(clonable data.Clonable) fail.Error {
-// 				nodesV1, ok = clonable.(*propertiesv1.ClusterNodes)
-// 				if !ok {
-// 					return fail.InconsistentError("'*propertiesv1.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
-// 				}
-// 				return nil
-// 			})
-// 			if innerXErr != nil {
-// 				return innerXErr
-// 			}
-//
-// 			return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
-// 				nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
-// 				if !ok {
-// 					return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
-// 				}
-//
-// 				for _, i := range nodesV1.Masters {
-// 					nodesV3.GlobalLastIndex++
-//
-// 					node := &propertiesv3.ClusterNode{
-// 						ID:          i.ID,
-// 						NumericalID: nodesV3.GlobalLastIndex,
-// 						Name:        i.Name,
-// 						PrivateIP:   i.PrivateIP,
-// 						PublicIP:    i.PublicIP,
-// 					}
-// 					nodesV3.Masters = append(nodesV3.Masters, node.NumericalID)
-// 					nodesV3.ByNumericalID[node.NumericalID] = node
-// 				}
-// 				for _, i := range nodesV1.PrivateNodes {
-// 					nodesV3.GlobalLastIndex++
-//
-// 					node := &propertiesv3.ClusterNode{
-// 						ID:          i.ID,
-// 						NumericalID: nodesV3.GlobalLastIndex,
-// 						Name:        i.Name,
-// 						PrivateIP:   i.PrivateIP,
-// 						PublicIP:    i.PublicIP,
-// 					}
-// 					nodesV3.PrivateNodes = append(nodesV3.PrivateNodes, node.NumericalID)
-// 					nodesV3.ByNumericalID[node.NumericalID] = node
-// 				}
-// 				nodesV3.MasterLastIndex = nodesV1.MasterLastIndex
-// 				nodesV3.PrivateLastIndex = nodesV1.PrivateLastIndex
-// 				return nil
-// 			})
-// 		}
-//
-// 		// Returning explicitly this error tells Alter not to try to commit changes, there are none
-// 		return fail.AlteredNothingError()
-// 	})
-// 	xerr = debug.InjectPlannedFail(xerr)
-// 	return xerr
-// }
-//
-// // updateClusterNetworkPropertyIfNeeded creates a clusterproperty.NetworkV3 property if previous versions are found
-// func (instance *cluster) updateClusterNetworkPropertyIfNeeded() fail.Error {
-// 	if instance.isNull() {
-// 		return fail.InvalidInstanceError()
-// 	}
-// 	xerr := instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) (innerXErr fail.Error) {
-// 		if props.Lookup(clusterproperty.NetworkV3) {
-// 			return fail.AlteredNothingError()
-// 		}
-//
-// 		var (
-// 			config *propertiesv3.ClusterNetwork
-// 			update bool
-// 		)
-//
-// 		if props.Lookup(clusterproperty.NetworkV2) {
-// 			// Having a clusterproperty.NetworkV2, need to update instance with clusterproperty.NetworkV3
-// 			innerXErr = props.Inspect(clusterproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
-// 				networkV2, ok := clonable.(*propertiesv2.ClusterNetwork)
-// 				if !ok {
-// 					return fail.InconsistentError("'*propertiesv2.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
-// 				}
-//
-// 				// In v2, NetworkID actually contains the subnet ID; we do not need ID of the Network owning the Subnet in
-// 				// the property, meaning that Network would have to be deleted also on cluster deletion because Network
-// 				// AND Subnet were created forcibly at cluster creation.
-// 				config = &propertiesv3.ClusterNetwork{
-// 					NetworkID:          "",
-// 					SubnetID:           networkV2.NetworkID,
-// 					CIDR:               networkV2.CIDR,
-// 					GatewayID:          networkV2.GatewayID,
-// 					GatewayIP:          networkV2.GatewayIP,
-// 					SecondaryGatewayID: networkV2.SecondaryGatewayID,
-// 					SecondaryGatewayIP: networkV2.SecondaryGatewayIP,
-// 					PrimaryPublicIP:    networkV2.PrimaryPublicIP,
-// 					SecondaryPublicIP:  networkV2.SecondaryPublicIP,
-// 					DefaultRouteIP:     networkV2.DefaultRouteIP,
-// 					EndpointIP:         networkV2.EndpointIP,
-// 					Domain:             networkV2.Domain,
-// 				}
-// 				update = true
-// 				return nil
-// 			})
-// 		} else {
-// 			// Having a clusterproperty.NetworkV1, need to update instance with clusterproperty.NetworkV3
-// 			innerXErr = props.Inspect(clusterproperty.NetworkV1, func(clonable data.Clonable) fail.Error {
-// 				networkV1, ok := clonable.(*propertiesv1.ClusterNetwork)
-// 				if !ok {
-// 					return fail.InconsistentError()
-// 				}
-//
-// 				config = &propertiesv3.ClusterNetwork{
-// 					SubnetID:       networkV1.NetworkID,
-// 					CIDR:           networkV1.CIDR,
-// 					GatewayID:      networkV1.GatewayID,
-// 					GatewayIP:      networkV1.GatewayIP,
-// 					DefaultRouteIP: networkV1.GatewayIP,
-// 					EndpointIP:     networkV1.PublicIP,
-// 				}
-// 				update = true
-// 				return nil
-// 			})
-// 		}
-// 		if innerXErr != nil {
-// 			return innerXErr
-// 		}
-//
-// 		if update {
-// 			return props.Alter(clusterproperty.NetworkV3, func(clonable data.Clonable) fail.Error {
-// 				networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
-// 				if !ok {
-// 					return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
-// 				}
-// 				networkV3.Replace(config)
-// 				return nil
-// 			})
-// 		}
-// 		return nil
-// 	})
-// 	xerr = debug.InjectPlannedFail(xerr)
-// 	if xerr != nil {
-// 		switch xerr.(type) { //nolint
-// 		case *fail.ErrAlteredNothing:
-// 			xerr = nil
-// 		}
-// 	}
-// 	return xerr
-// }
-//
-// // updateClusterDefaultsPropertyIfNeeded ...
-// func (instance *cluster) updateClusterDefaultsPropertyIfNeeded() fail.Error {
-// 	if instance.isNull() {
-// 		return fail.InvalidInstanceError()
-// 	}
-// 	xerr := instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-// 		if props.Lookup(clusterproperty.DefaultsV2) {
-// 			return fail.AlteredNothingError()
-// 		}
-//
-// 		// If property.DefaultsV2 is not found but there is a property.DefaultsV1, converts it to DefaultsV2
-// 		return props.Inspect(clusterproperty.DefaultsV1, func(clonable data.Clonable) fail.Error {
-// 			defaultsV1, ok := clonable.(*propertiesv1.ClusterDefaults)
-// 			if !ok {
-// 				return fail.InconsistentError("'*propertiesv1.ClusterDefaults' expected, '%s' provided", reflect.TypeOf(clonable).String())
-// 			}
-// 			return props.Alter(clusterproperty.DefaultsV2, func(clonable data.Clonable) fail.Error {
-// 				defaultsV2, ok := clonable.(*propertiesv2.ClusterDefaults)
-// 				if !ok {
-// 					return fail.InconsistentError("'*propertiesv2.ClusterDefaults' expected, '%s' provided", reflect.TypeOf(clonable).String())
-// 				}
-//
-// 				convertDefaultsV1ToDefaultsV2(defaultsV1, defaultsV2)
-// 				return nil
-// 			})
-// 		})
-// 	})
-// 	xerr = debug.InjectPlannedFail(xerr)
-// 	if xerr != nil {
-// 		switch xerr.(type) {
-// 		case *fail.ErrAlteredNothing:
-// 			xerr = nil
-// 		default:
-// 		}
-// 	}
-// 	return xerr
-// }
+// migrateClusterNodesToV3 converts legacy properties clusterproperty.NodesV1 or clusterproperty.NodesV2 to clusterproperty.NodesV3
+// Returns fail.ErrAlteredNothing if there is nothing to convert
+func migrateClusterNodesToV3(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+	if props.Lookup(clusterproperty.NodesV3) {
+		return fail.AlteredNothingError()
+	}
+
+	var (
+		masters, privateNodes             []*propertiesv2.ClusterNode
+		masterLastIndex, privateLastIndex int
+	)
+	switch {
+	case props.Lookup(clusterproperty.NodesV2):
+		xerr := props.Inspect(clusterproperty.NodesV2, func(clonable data.Clonable) fail.Error {
+			nodesV2, ok := clonable.(*propertiesv2.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			masters, privateNodes = nodesV2.Masters, nodesV2.PrivateNodes
+			masterLastIndex, privateLastIndex = nodesV2.MasterLastIndex, nodesV2.PrivateLastIndex
+			return nil
+		})
+		if xerr != nil {
+			return xerr
+		}
+	case props.Lookup(clusterproperty.NodesV1):
+		xerr := props.Inspect(clusterproperty.NodesV1, func(clonable data.Clonable) fail.Error {
+			nodesV1, ok := clonable.(*propertiesv1.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			for _, v := range nodesV1.Masters {
+				masters = append(masters, &propertiesv2.ClusterNode{ID: v.ID, Name: v.Name, PublicIP: v.PublicIP, PrivateIP: v.PrivateIP})
+			}
+			for _, v := range nodesV1.PrivateNodes {
+				privateNodes = append(privateNodes, &propertiesv2.ClusterNode{ID: v.ID, Name: v.Name, PublicIP: v.PublicIP, PrivateIP: v.PrivateIP})
+			}
+			masterLastIndex, privateLastIndex = nodesV1.MasterLastIndex, nodesV1.PrivateLastIndex
+			return nil
+		})
+		if xerr != nil {
+			return xerr
+		}
+	default:
+		return fail.AlteredNothingError()
+	}
+
+	return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+		nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+		if !ok {
+			return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		for _, v := range masters {
+			nodesV3.GlobalLastIndex++
+			node := &propertiesv3.ClusterNode{
+				ID:          v.ID,
+				NumericalID: nodesV3.GlobalLastIndex,
+				Name:        v.Name,
+				PrivateIP:   v.PrivateIP,
+				PublicIP:    v.PublicIP,
+			}
+			nodesV3.Masters = append(nodesV3.Masters, node.NumericalID)
+			nodesV3.MasterByName[node.Name] = node.NumericalID
+			nodesV3.MasterByID[node.ID] = node.NumericalID
+			nodesV3.ByNumericalID[node.NumericalID] = node
+		}
+		for _, v := range privateNodes {
+			nodesV3.GlobalLastIndex++
+			node := &propertiesv3.ClusterNode{
+				ID:          v.ID,
+				NumericalID: nodesV3.GlobalLastIndex,
+				Name:        v.Name,
+				PrivateIP:   v.PrivateIP,
+				PublicIP:    v.PublicIP,
+			}
+			nodesV3.PrivateNodes = append(nodesV3.PrivateNodes, node.NumericalID)
+			nodesV3.PrivateNodeByName[node.Name] = node.NumericalID
+			nodesV3.PrivateNodeByID[node.ID] = node.NumericalID
+			nodesV3.ByNumericalID[node.NumericalID] = node
+		}
+		nodesV3.MasterLastIndex = masterLastIndex
+		nodesV3.PrivateLastIndex = privateLastIndex
+		return nil
+	})
+}
+
+// migrateClusterNetworkToV3 converts legacy properties clusterproperty.NetworkV1 or clusterproperty.NetworkV2 to clusterproperty.NetworkV3
+// Returns fail.ErrAlteredNothing if there is nothing to convert
+func migrateClusterNetworkToV3(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+	if props.Lookup(clusterproperty.NetworkV3) {
+		return fail.AlteredNothingError()
+	}
+
+	var (
+		config *propertiesv3.ClusterNetwork
+		xerr   fail.Error
+	)
+	switch {
+	case props.Lookup(clusterproperty.NetworkV2):
+		xerr = props.Inspect(clusterproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+			networkV2, ok := clonable.(*propertiesv2.ClusterNetwork)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			// In v2, NetworkID actually contains the Subnet ID
+			config = &propertiesv3.ClusterNetwork{
+				SubnetID:           networkV2.NetworkID,
+				CIDR:               networkV2.CIDR,
+				GatewayID:          networkV2.GatewayID,
+				GatewayIP:          networkV2.GatewayIP,
+				SecondaryGatewayID: networkV2.SecondaryGatewayID,
+				SecondaryGatewayIP: networkV2.SecondaryGatewayIP,
+				PrimaryPublicIP:    networkV2.PrimaryPublicIP,
+				SecondaryPublicIP:  networkV2.SecondaryPublicIP,
+				DefaultRouteIP:     networkV2.DefaultRouteIP,
+				EndpointIP:         networkV2.EndpointIP,
+				Domain:             networkV2.Domain,
+			}
+			return nil
+		})
+	case props.Lookup(clusterproperty.NetworkV1):
+		xerr = props.Inspect(clusterproperty.NetworkV1, func(clonable data.Clonable) fail.Error {
+			networkV1, ok := clonable.(*propertiesv1.ClusterNetwork)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			config = &propertiesv3.ClusterNetwork{
+				SubnetID:       networkV1.NetworkID,
+				CIDR:           networkV1.CIDR,
+				GatewayID:      networkV1.GatewayID,
+				GatewayIP:      networkV1.GatewayIP,
+				DefaultRouteIP: networkV1.GatewayIP,
+				EndpointIP:     networkV1.PublicIP,
+			}
+			return nil
+		})
+	default:
+		return fail.AlteredNothingError()
+	}
+	if xerr != nil {
+		return xerr
+	}
+
+	return props.Alter(clusterproperty.NetworkV3, func(clonable data.Clonable) fail.Error {
+		networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
+		if !ok {
+			return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		_ = networkV3.Replace(config)
+		return nil
+	})
+}
+
+// migrateClusterDefaultsToV2 converts legacy property clusterproperty.DefaultsV1 to clusterproperty.DefaultsV2
+// Returns fail.ErrAlteredNothing if there is nothing to convert
+func migrateClusterDefaultsToV2(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+	if props.Lookup(clusterproperty.DefaultsV2) || !props.Lookup(clusterproperty.DefaultsV1) {
+		return fail.AlteredNothingError()
+	}
+
+	return props.Inspect(clusterproperty.DefaultsV1, func(clonable data.Clonable) fail.Error {
+		defaultsV1, ok := clonable.(*propertiesv1.ClusterDefaults)
+		if !ok {
+			return fail.InconsistentError("'*propertiesv1.ClusterDefaults' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		return props.Alter(clusterproperty.DefaultsV2, func(clonable data.Clonable) fail.Error {
+			defaultsV2, ok := clonable.(*propertiesv2.ClusterDefaults)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.ClusterDefaults' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			convertClusterDefaultsV1ToDefaultsV2(defaultsV1, defaultsV2)
+			return nil
+		})
+	})
+}
 
 // updateCachedInformation updates information cached in the instance
 func (instance *Cluster) updateCachedInformation() {
@@ -555,6 +487,36 @@ func (instance *Cluster) Create(ctx context.Context, req abstract.ClusterRequest
 	instance.lock.Lock()
 	defer instance.lock.Unlock()
 
+	defer metrics.Measure(metrics.KindOperation, "cluster.create", instance.GetService().GetProviderName())(&xerr)
+
+	if req.Domain != "" {
+		req.Domain, xerr = normalizeClusterDomain(req.Domain)
+		if xerr != nil {
+			return xerr
+		}
+	}
+
+	if req.CIDR != "" {
+		req.CIDR, xerr = abstract.NormalizeCIDR(req.CIDR)
+		if xerr != nil {
+			return xerr
+		}
+	}
+
+	if req.MetadataBucket != "" {
+		xerr = createMetadataBucketIfNeeded(instance.GetService(), req.MetadataBucket)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+
+		xerr = instance.MetadataCore.useMetadataBucket(req.MetadataBucket)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+	}
+
 	_, xerr = task.Run(instance.taskCreateCluster, req)
 	if xerr != nil {
 		return xerr
@@ -562,6 +524,44 @@ func (instance *Cluster) Create(ctx context.Context, req abstract.ClusterRequest
 	return nil
 }
 
+// Resume continues the creation of a Cluster interrupted while in state Creating (daemon restart for example),
+// reusing the resources already created
+func (instance *Cluster) Resume(ctx context.Context) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).Entering()
+	defer tracer.Exiting()
+	defer temporal.NewStopwatch().OnExitLogInfo(
+		fmt.Sprintf("Resuming creation of infrastructure of Cluster '%s'...", instance.GetName()),
+		fmt.Sprintf("Ending resumed creation of infrastructure of Cluster '%s'", instance.GetName()),
+	)()
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	defer recordOperation(instance, clusterproperty.LastOperationV1, "resume")(&xerr)
+
+	_, xerr = task.Run(instance.taskResumeCluster, nil)
+	return xerr
+}
+
 // Serialize converts Cluster data to JSON
 func (instance *Cluster) Serialize() (_ []byte, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
@@ -632,7 +632,7 @@ func (instance *Cluster) Browse(ctx context.Context, callback func(*abstract.Clu
 		return fail.AbortedError(nil, "aborted")
 	}
 
-	return instance.MetadataCore.BrowseFolder(func(buf []byte) fail.Error {
+	decoder := func(buf []byte) fail.Error {
 		aci := abstract.NewClusterIdentity()
 		xerr := aci.Deserialize(buf)
 		xerr = debug.InjectPlannedFail(xerr)
@@ -645,7 +645,105 @@ func (instance *Cluster) Browse(ctx context.Context, callback func(*abstract.Clu
 		}
 
 		return callback(aci)
-	})
+	}
+	xerr = instance.MetadataCore.BrowseFolderWithContext(ctx, decoder)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	return browseClustersInMetadataBuckets(ctx, instance.GetService(), decoder)
+}
+
+// BrowseFor walks through Cluster MetadataFolder like Browse, giving up after 'timeout' (0 means no limit)
+// On timeout, returns *fail.ErrTimeout; 'callback' has then been executed only on the Clusters read in time, which are
+// the partial results of the browse
+func (instance *Cluster) BrowseFor(ctx context.Context, timeout time.Duration, callback func(*abstract.ClusterIdentity) fail.Error) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if timeout < 0 {
+		return fail.InvalidParameterError("timeout", "cannot be negative")
+	}
+	if timeout == 0 {
+		return instance.Browse(ctx, callback)
+	}
+
+	browseCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	xerr = instance.Browse(browseCtx, callback)
+	return boundedBrowseError(ctx, xerr, timeout, "Clusters")
+}
+
+// BrowseWithState walks through Cluster MetadataFolder and executes a callback for each entry, giving it the last state
+// of the Cluster known in metadata
+// Note: the state is peeked from metadata, it is not collected; clusterstate.Unknown is used when not available
+func (instance *Cluster) BrowseWithState(ctx context.Context, callback func(*abstract.ClusterIdentity, clusterstate.Enum) fail.Error) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	// Note: BrowseWithState is intended to be callable from null value, so do not validate instance
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if callback == nil {
+		return fail.InvalidParameterCannotBeNilError("callback")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	decoder := func(buf []byte) fail.Error {
+		aci := abstract.NewClusterIdentity()
+		xerr := aci.Deserialize(buf)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+
+		if task.Aborted() {
+			return fail.AbortedError(nil, "aborted")
+		}
+
+		return callback(aci, peekClusterState(buf))
+	}
+	xerr = instance.MetadataCore.BrowseFolderWithContext(ctx, decoder)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	return browseClustersInMetadataBuckets(ctx, instance.GetService(), decoder)
+}
+
+// peekClusterState extracts the state of the Cluster from its serialized metadata, without loading the Cluster
+func peekClusterState(buf []byte) clusterstate.Enum {
+	var content struct {
+		Properties map[string]string `json:"properties"`
+	}
+	if err := json.Unmarshal(buf, &content); err != nil {
+		return clusterstate.Unknown
+	}
+
+	jsoned, ok := content.Properties[clusterproperty.StateV1]
+	if !ok {
+		return clusterstate.Unknown
+	}
+
+	stateV1 := propertiesv1.ClusterState{}
+	if err := json.Unmarshal([]byte(jsoned), &stateV1); err != nil || stateV1.State == 0 {
+		return clusterstate.Unknown
+	}
+	return stateV1.State
 }
 
 // GetIdentity returns the identity of the Cluster
@@ -758,70 +856,174 @@ func (instance *Cluster) GetNetworkConfig() (config *propertiesv3.ClusterNetwork
 	return config, nil
 }
 
-// Start starts the Cluster
-func (instance *Cluster) Start(ctx context.Context) (xerr fail.Error) {
+// kubeconfigServerRegexp matches the scheme and host part of the server URLs in a kubeconfig
+var kubeconfigServerRegexp = regexp.MustCompile(`(server:\s*https?://)(\[[^\]]+\]|[^:/\s]+)`)
+
+// GetKubeconfig returns the content of the kubeconfig of the admin of a K8S Cluster, with the address of the server
+// replaced by the endpoint IP of the Cluster
+// Returns fail.ErrNotAvailable if the flavor of the Cluster is not K8S
+func (instance *Cluster) GetKubeconfig(ctx context.Context) (_ string, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
 	if instance == nil || instance.IsNull() {
-		return fail.InvalidInstanceError()
+		return "", fail.InvalidInstanceError()
 	}
 	if ctx == nil {
-		return fail.InvalidParameterCannotBeNilError("ctx")
+		return "", fail.InvalidParameterCannotBeNilError("ctx")
 	}
 
 	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return xerr
+		return "", xerr
 	}
 
 	if task.Aborted() {
-		return fail.AbortedError(nil, "aborted")
+		return "", fail.AbortedError(nil, "aborted")
 	}
 
 	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).Entering()
 	defer tracer.Exiting()
 
-	// make sure no other parallel actions interferes
-	instance.lock.Lock()
-	defer instance.lock.Unlock()
+	instance.lock.RLock()
+	defer instance.lock.RUnlock()
 
-	// If the Cluster is in state Stopping or Stopped, do nothing
-	var prevState clusterstate.Enum
-	prevState, xerr = instance.unsafeGetState()
+	flavor, xerr := instance.UnsafeGetFlavor()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return xerr
+		return "", xerr
+	}
+	if flavor != clusterflavor.K8S {
+		return "", fail.NotAvailableError("kubeconfig is only available for Cluster of flavor K8S, Cluster '%s' is of flavor '%s'", instance.GetName(), flavor.String())
 	}
-	switch prevState {
-	case clusterstate.Removed:
-		return fail.NotAvailableError("Cluster is being removed")
-	case clusterstate.Stopping:
-		return nil
-	case clusterstate.Starting:
-		// If the Cluster is in state Starting, wait for it to finish its start procedure
-		xerr = retry.WhileUnsuccessfulDelay5Seconds(
-			func() error {
-				state, innerErr := instance.unsafeGetState()
-				if innerErr != nil {
-					return innerErr
-				}
-
-				if state == clusterstate.Nominal || state == clusterstate.Degraded {
-					return nil
-				}
 
-				return fail.NewError("current state of Cluster is '%s'", state.String())
-			},
-			5*time.Minute, // FIXME: hardcoded timeout
-		)
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			if _, ok := xerr.(*retry.ErrTimeout); ok {
-				xerr = fail.Wrap(xerr, "timeout waiting Cluster to become started")
+	var endpointIP string
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NetworkV3, func(clonable data.Clonable) fail.Error {
+			networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
-			return xerr
-		}
+			endpointIP = networkV3.EndpointIP
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return "", xerr
+	}
+	if endpointIP == "" {
+		return "", fail.InconsistentError("no endpoint IP found in metadata of Cluster '%s'", instance.GetName())
+	}
+
+	master, xerr := instance.UnsafeFindAvailableMaster(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return "", fail.Wrap(xerr, "failed to find an available master to retrieve kubeconfig")
+	}
+
+	localFile, err := ioutil.TempFile("", "kubeconfig-")
+	if err != nil {
+		return "", fail.ConvertError(err)
+	}
+	localPath := localFile.Name()
+	_ = localFile.Close()
+	defer func() {
+		if derr := os.Remove(localPath); derr != nil {
+			logrus.Warnf("failed to remove temporary file '%s': %v", localPath, derr)
+		}
+	}()
+
+	retcode, _, stderr, xerr := master.Pull(ctx, "/etc/kubernetes/admin.conf", localPath, temporal.GetExecutionTimeout())
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return "", fail.Wrap(xerr, "failed to retrieve kubeconfig from master '%s'", master.GetName())
+	}
+	if retcode != 0 {
+		return "", fail.ExecutionError(nil, "failed to retrieve kubeconfig from master '%s': %s", master.GetName(), stderr)
+	}
+
+	content, err := ioutil.ReadFile(localPath)
+	if err != nil {
+		return "", fail.ConvertError(err)
+	}
+
+	return kubeconfigServerRegexp.ReplaceAllString(string(content), "${1}"+endpointIP), nil
+}
+
+// Start starts the Cluster
+func (instance *Cluster) Start(ctx context.Context) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).Entering()
+	defer tracer.Exiting()
+
+	// make sure no other operation on the same Cluster runs in parallel in the daemon
+	unlockCluster, xerr := lockCluster(ctx, instance)
+	if xerr != nil {
+		return xerr
+	}
+	defer unlockCluster()
+
+	// make sure no other parallel actions interferes
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	defer recordOperation(instance, clusterproperty.LastOperationV1, "start")(&xerr)
+
+	// If the Cluster is in state Stopping or Stopped, do nothing
+	var prevState clusterstate.Enum
+	prevState, xerr = instance.unsafeGetState()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+	switch prevState {
+	case clusterstate.Removed:
+		return fail.NotAvailableError("Cluster is being removed")
+	case clusterstate.Stopping:
+		return nil
+	case clusterstate.Starting:
+		// If the Cluster is in state Starting, wait for it to finish its start procedure
+		xerr = retry.WhileUnsuccessfulDelay5Seconds(
+			func() error {
+				state, innerErr := instance.unsafeGetState()
+				if innerErr != nil {
+					return innerErr
+				}
+
+				if state == clusterstate.Nominal || state == clusterstate.Degraded {
+					return nil
+				}
+
+				return fail.NewError("current state of Cluster is '%s'", state.String())
+			},
+			temporal.GetClusterStateChangeTimeout(ctx),
+		)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			if _, ok := xerr.(*retry.ErrTimeout); ok {
+				xerr = fail.Wrap(xerr, "timeout waiting Cluster to become started")
+			}
+			return xerr
+		}
 		return nil
 	case clusterstate.Stopped:
 		// continue
@@ -831,15 +1033,7 @@ func (instance *Cluster) Start(ctx context.Context) (xerr fail.Error) {
 
 	// First mark Cluster to be in state Starting
 	xerr = instance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Alter(clusterproperty.StateV1, func(clonable data.Clonable) fail.Error {
-			stateV1, ok := clonable.(*propertiesv1.ClusterState)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv1.ClusterState' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
-
-			stateV1.State = clusterstate.Starting
-			return nil
-		})
+		return setClusterStateInProperties(props, clusterstate.Starting, "start requested")
 	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
@@ -901,15 +1095,7 @@ func (instance *Cluster) Start(ctx context.Context) (xerr fail.Error) {
 		}
 
 		// Mark Cluster as state Starting
-		return props.Alter(clusterproperty.StateV1, func(clonable data.Clonable) fail.Error {
-			stateV1, ok := clonable.(*propertiesv1.ClusterState)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv1.ClusterState' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
-
-			stateV1.State = clusterstate.Starting
-			return nil
-		})
+		return setClusterStateInProperties(props, clusterstate.Starting, "start requested")
 	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
@@ -960,19 +1146,28 @@ func (instance *Cluster) Start(ctx context.Context) (xerr fail.Error) {
 	}
 
 	return instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Alter(clusterproperty.StateV1, func(clonable data.Clonable) fail.Error {
-			stateV1, ok := clonable.(*propertiesv1.ClusterState)
+		// All the nodes are started, including the ones stopped by StopNodes
+		innerXErr := props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
 			if !ok {
-				return fail.InconsistentError("'*propertiesv1.ClusterState' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
-			stateV1.State = clusterstate.Nominal
+
+			nodesV3.States = map[uint]clusternodestate.Enum{}
 			return nil
 		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		return setClusterStateInProperties(props, clusterstate.Nominal, "started")
 	})
 }
 
-// Stop stops the Cluster
-func (instance *Cluster) Stop(ctx context.Context) (xerr fail.Error) {
+// Stop stops the Cluster, in strict phases: nodes first, then masters, then gateways; each phase completes before the next one starts
+// options may contain:
+//   - "Drain" (bool): if true and the Cluster is of flavor K8S, drains the workloads from the nodes before stopping them
+func (instance *Cluster) Stop(ctx context.Context, options ...data.ImmutableKeyValue) (xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
 	if instance == nil || instance.IsNull() {
@@ -995,10 +1190,28 @@ func (instance *Cluster) Stop(ctx context.Context) (xerr fail.Error) {
 	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).Entering()
 	defer tracer.Exiting()
 
+	var drain bool
+	for _, v := range options {
+		switch v.Key() {
+		case "Drain":
+			drain = v.Value().(bool)
+		default:
+		}
+	}
+
+	// make sure no other operation on the same Cluster runs in parallel in the daemon
+	unlockCluster, xerr := lockCluster(ctx, instance)
+	if xerr != nil {
+		return xerr
+	}
+	defer unlockCluster()
+
 	// make sure no other parallel actions interferes
 	instance.lock.Lock()
 	defer instance.lock.Unlock()
 
+	defer recordOperation(instance, clusterproperty.LastOperationV1, "stop")(&xerr)
+
 	// If the Cluster is stopped, do nothing
 	var prevState clusterstate.Enum
 	prevState, xerr = instance.unsafeGetState()
@@ -1029,7 +1242,7 @@ func (instance *Cluster) Stop(ctx context.Context) (xerr fail.Error) {
 
 				return nil
 			},
-			5*time.Minute, // FIXME: hardcoded timeout
+			temporal.GetClusterStateChangeTimeout(ctx),
 		)
 		xerr = debug.InjectPlannedFail(xerr)
 		if xerr != nil {
@@ -1050,15 +1263,7 @@ func (instance *Cluster) Stop(ctx context.Context) (xerr fail.Error) {
 
 	// First mark Cluster to be in state Stopping
 	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Alter(clusterproperty.StateV1, func(clonable data.Clonable) fail.Error {
-			stateV1, ok := clonable.(*propertiesv1.ClusterState)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv1.ClusterState' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
-
-			stateV1.State = clusterstate.Stopping
-			return nil
-		})
+		return setClusterStateInProperties(props, clusterstate.Stopping, "stop requested")
 	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
@@ -1069,13 +1274,14 @@ func (instance *Cluster) Stop(ctx context.Context) (xerr fail.Error) {
 		return fail.AbortedError(nil, "aborted")
 	}
 
-	// Then stop it and mark it as STOPPED on success
-	return instance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
-		var (
-			nodes                         []string
-			masters                       []string
-			gatewayID, secondaryGatewayID string
-		)
+	// Collect the Hosts to stop, grouped by phase
+	var (
+		nodes                         []*propertiesv3.ClusterNode
+		masters                       []string
+		gatewayID, secondaryGatewayID string
+		externalGateway               bool
+	)
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
 		innerXErr := props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
 			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
 			if !ok {
@@ -1088,10 +1294,10 @@ func (instance *Cluster) Stop(ctx context.Context) (xerr fail.Error) {
 					masters = append(masters, node.ID)
 				}
 			}
-			nodes = make([]string, 0, len(nodesV3.PrivateNodes))
+			nodes = make([]*propertiesv3.ClusterNode, 0, len(nodesV3.PrivateNodes))
 			for _, v := range nodesV3.PrivateNodes {
 				if node, found := nodesV3.ByNumericalID[v]; found {
-					nodes = append(nodes, node.ID)
+					nodes = append(nodes, node)
 				}
 			}
 			return nil
@@ -1100,682 +1306,885 @@ func (instance *Cluster) Stop(ctx context.Context) (xerr fail.Error) {
 			return fail.Wrap(innerXErr, "failed to get list of hosts")
 		}
 
-		innerXErr = props.Inspect(clusterproperty.NetworkV3, func(clonable data.Clonable) fail.Error {
+		return props.Inspect(clusterproperty.NetworkV3, func(clonable data.Clonable) fail.Error {
 			networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
 			if !ok {
-				return fail.InconsistentError("'*propertiesv2.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
 			gatewayID = networkV3.GatewayID
 			secondaryGatewayID = networkV3.SecondaryGatewayID
+			externalGateway = networkV3.ExternalGateway
 			return nil
 		})
-		if innerXErr != nil {
-			return innerXErr
-		}
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
 
-		// Stop nodes
-		taskGroup, innerXErr := concurrency.NewTaskGroup(task)
-		if innerXErr != nil {
-			return innerXErr
+	// Phase 1: drain workloads from nodes, while control plane and gateways are still up
+	if drain {
+		xerr = instance.unsafeDrainNodes(ctx, nodes)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
 		}
+	}
 
-		for _, n := range nodes {
-			if _, innerXErr = taskGroup.StartInSubtask(instance.taskStopHost, n); innerXErr != nil {
-				return innerXErr
-			}
-		}
-		// Stop masters
-		for _, n := range masters {
-			if _, innerXErr = taskGroup.StartInSubtask(instance.taskStopHost, n); innerXErr != nil {
-				return innerXErr
-			}
-		}
-		// Stop gateway(s)
-		if _, innerXErr = taskGroup.StartInSubtask(instance.taskStopHost, gatewayID); innerXErr != nil {
-			return innerXErr
-		}
+	// Phase 2: stop nodes
+	nodeIDs := make([]string, 0, len(nodes))
+	for _, v := range nodes {
+		nodeIDs = append(nodeIDs, v.ID)
+	}
+	xerr = instance.stopHostsInPhase(task, "nodes", nodeIDs)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// Phase 3: stop masters
+	xerr = instance.stopHostsInPhase(task, "masters", masters)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// Phase 4: stop gateway(s), unless it is an existing gateway that may be used by others
+	if !externalGateway {
+		gateways := []string{gatewayID}
 		if secondaryGatewayID != "" {
-			if _, innerXErr = taskGroup.StartInSubtask(instance.taskStopHost, secondaryGatewayID); innerXErr != nil {
-				return innerXErr
-			}
+			gateways = append(gateways, secondaryGatewayID)
 		}
-
-		if _, innerXErr = taskGroup.WaitGroup(); innerXErr != nil {
-			return innerXErr
+		xerr = instance.stopHostsInPhase(task, "gateways", gateways)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
 		}
+	}
 
-		return props.Alter(clusterproperty.StateV1, func(clonable data.Clonable) fail.Error {
-			stateV1, ok := clonable.(*propertiesv1.ClusterState)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv1.ClusterState' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
-			stateV1.State = clusterstate.Stopped
-			return nil
-		})
+	// Finally mark the Cluster as STOPPED
+	return instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return setClusterStateInProperties(props, clusterstate.Stopped, "stopped")
 	})
 }
 
-// GetState returns the current state of the Cluster
-// Uses the "maker" ForceGetState
-func (instance *Cluster) GetState() (state clusterstate.Enum, xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
-
-	state = clusterstate.Unknown
-	if instance == nil || instance.IsNull() {
-		return state, fail.InvalidInstanceError()
+// stopHostsInPhase stops in parallel the Hosts in 'ids', and returns only when all of them are stopped (or failed to)
+func (instance *Cluster) stopHostsInPhase(task concurrency.Task, phase string, ids []string) fail.Error {
+	if len(ids) == 0 {
+		return nil
 	}
 
-	// make sure no other parallel actions interferes
-	instance.lock.Lock()
-	defer instance.lock.Unlock()
-
-	return instance.unsafeGetState()
-}
-
-// AddNode adds a node
-func (instance *Cluster) AddNode(ctx context.Context, def abstract.HostSizingRequirements) (_ resources.Host, xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
-
-	if instance == nil || instance.IsNull() {
-		return HostNullValue(), fail.InvalidInstanceError()
-	}
-	if ctx == nil {
-		return HostNullValue(), fail.InvalidParameterCannotBeNilError("ctx")
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
 	}
 
-	task, xerr := concurrency.TaskFromContext(ctx)
+	taskGroup, xerr := concurrency.NewTaskGroup(task)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, xerr
+		return xerr
 	}
 
-	if task.Aborted() {
-		return HostNullValue(), fail.AbortedError(nil, "aborted")
+	for _, v := range ids {
+		if _, xerr = taskGroup.StartInSubtask(instance.taskStopHost, v); xerr != nil {
+			_ = taskGroup.Abort()
+			_, _ = taskGroup.WaitGroup()
+			return fail.Wrap(xerr, "failed to start stopping %s", phase)
+		}
 	}
 
-	nodes, xerr := instance.AddNodes(ctx, 1, def)
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return HostNullValue(), xerr
+	if _, xerr = taskGroup.WaitGroup(); xerr != nil {
+		return fail.Wrap(xerr, "failed to stop %s", phase)
 	}
 
-	return nodes[0], nil
+	return nil
 }
 
-// AddNodes adds several nodes
-func (instance *Cluster) AddNodes(ctx context.Context, count uint, def abstract.HostSizingRequirements) (_ []resources.Host, xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
-
-	if instance == nil || instance.IsNull() {
-		return nil, fail.InvalidInstanceError()
-	}
-	if ctx == nil {
-		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+// startHostsInPhase starts in parallel the Hosts in 'ids', and returns only when all of them are started (or failed to)
+func (instance *Cluster) startHostsInPhase(task concurrency.Task, phase string, ids []string) fail.Error {
+	if len(ids) == 0 {
+		return nil
 	}
-	if count == 0 {
-		return nil, fail.InvalidParameterError("count", "must be an int > 0")
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
 	}
 
-	tgo, xerr := concurrency.TaskFromContext(ctx)
+	taskGroup, xerr := concurrency.NewTaskGroup(task)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, xerr
+		return xerr
 	}
 
-	if tgo.Aborted() {
-		return nil, fail.AbortedError(nil, "aborted")
+	for _, v := range ids {
+		if _, xerr = taskGroup.StartInSubtask(instance.taskStartHost, v); xerr != nil {
+			_ = taskGroup.Abort()
+			_, _ = taskGroup.WaitGroup()
+			return fail.Wrap(xerr, "failed to start starting %s", phase)
+		}
 	}
 
-	task, err := concurrency.NewTaskGroupWithParent(tgo)
-	if err != nil {
-		return nil, err
+	if _, xerr = taskGroup.WaitGroup(); xerr != nil {
+		return fail.Wrap(xerr, "failed to start %s", phase)
 	}
 
-	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster"), "(%d)", count)
-	defer tracer.Entering().Exiting()
-
-	// make sure no other parallel actions interferes
-	instance.lock.Lock()
-	defer instance.lock.Unlock()
+	return nil
+}
 
-	xerr = instance.beingRemoved()
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return nil, xerr
+// unsafeDrainNodes drains the workloads from the nodes of a K8S Cluster, using an available master
+// For other flavors, does nothing.
+// Failure to drain a node is not fatal (the node will be stopped anyway), but is logged as warning.
+func (instance *Cluster) unsafeDrainNodes(ctx context.Context, nodes []*propertiesv3.ClusterNode) fail.Error {
+	if len(nodes) == 0 {
+		return nil
 	}
 
-	var (
-		hostImage             string
-		nodeDefaultDefinition *propertiesv2.HostSizingRequirements
-	)
-	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Inspect(clusterproperty.DefaultsV2, func(clonable data.Clonable) fail.Error {
-			defaultsV2, ok := clonable.(*propertiesv2.ClusterDefaults)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv2.ClusterDefaults' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
-			nodeDefaultDefinition = &defaultsV2.NodeSizing
-			hostImage = defaultsV2.Image
-			return nil
-		})
-	})
+	flavor, xerr := instance.UnsafeGetFlavor()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, xerr
-	}
-
-	if task.Aborted() {
-		return nil, fail.AbortedError(nil, "aborted")
+		return xerr
 	}
-
-	nodeDef := complementHostDefinition(def, *nodeDefaultDefinition)
-	if nodeDef.Image == "" {
-		nodeDef.Image = hostImage
+	if flavor != clusterflavor.K8S {
+		logrus.Debugf("drain of nodes is only supported by K8S flavor, skipping")
+		return nil
 	}
 
-	var (
-		nodeTypeStr string
-		errors      []string
-		hosts       []resources.Host
-	)
-
-	timeout := temporal.GetExecutionTimeout() + time.Duration(count)*time.Minute
-
-	var newHosts []resources.Host
-	defer func() {
-		if xerr != nil && len(newHosts) > 0 {
-			logrus.Debugf("Cleaning up on failure, deleting Nodes...")
-			if derr := instance.deleteHosts(task, newHosts); derr != nil {
-				logrus.Errorf("Cleaning up on failure, failed to delete Nodes")
-				_ = xerr.AddConsequence(derr)
-			} else {
-				logrus.Debugf("Cleaning up on failure, successfully deleted Nodes")
-			}
-		}
-	}()
-	for i := uint(0); i < count; i++ {
-		_, xerr := task.StartInSubtask(instance.taskCreateNode, taskCreateNodeParameters{
-			index:         i + 1,
-			nodeDef:       nodeDef,
-			timeout:       timeout,
-			keepOnFailure: false,
-		})
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			return nil, xerr
-		}
-	}
-	res, err := task.WaitGroup()
-	if res != nil {
-		for _, v := range res {
-			if aHost, ok := v.(resources.Host); ok {
-				newHosts = append(newHosts, aHost)
-			}
-		}
-	}
-	err = debug.InjectPlannedFail(err)
-	if err != nil {
-		return nil, fail.NewErrorWithCause(err, "errors occurred on %s node%s addition", nodeTypeStr, strprocess.Plural(uint(len(errors))))
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
 	}
 
-	// Now configure new nodes
-	xerr = instance.configureNodesFromList(task, hosts)
+	master, xerr := instance.UnsafeFindAvailableMaster(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, xerr
+		return fail.Wrap(xerr, "failed to find an available master to drain nodes")
 	}
 
-	// At last join nodes to Cluster
-	xerr = instance.joinNodesFromList(ctx, hosts)
+	taskGroup, xerr := concurrency.NewTaskGroup(task)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, xerr
+		return xerr
 	}
 
-	return hosts, nil
-}
-
-// complementHostDefinition complements req with default values if needed
-func complementHostDefinition(req abstract.HostSizingRequirements, def propertiesv2.HostSizingRequirements) abstract.HostSizingRequirements {
-	if def.MinCores > 0 && req.MinCores == 0 {
-		req.MinCores = def.MinCores
-	}
-	if def.MaxCores > 0 && req.MaxCores == 0 {
-		req.MaxCores = def.MaxCores
-	}
-	if def.MinRAMSize > 0.0 && req.MinRAMSize == 0.0 {
-		req.MinRAMSize = def.MinRAMSize
-	}
-	if def.MaxRAMSize > 0.0 && req.MaxRAMSize == 0.0 {
-		req.MaxRAMSize = def.MaxRAMSize
-	}
-	if def.MinDiskSize > 0 && req.MinDiskSize == 0 {
-		req.MinDiskSize = def.MinDiskSize
-	}
-	if req.MinGPU <= 0 && def.MinGPU > 0 {
-		req.MinGPU = def.MinGPU
-	}
-	if req.MinCPUFreq == 0 && def.MinCPUFreq > 0 {
-		req.MinCPUFreq = def.MinCPUFreq
-	}
-	if req.MinCores <= 0 {
-		req.MinCores = 2
-	}
-	if req.MaxCores <= 0 {
-		req.MaxCores = 4
-	}
-	if req.MinRAMSize <= 0.0 {
-		req.MinRAMSize = 7.0
-	}
-	if req.MaxRAMSize <= 0.0 {
-		req.MaxRAMSize = 16.0
-	}
-	if req.MinDiskSize <= 0 {
-		req.MinDiskSize = 50
+	for _, v := range nodes {
+		if _, xerr = taskGroup.StartInSubtask(instance.taskDrainNode, taskDrainNodeParameters{master: master, nodeName: v.Name}); xerr != nil {
+			_ = taskGroup.Abort()
+			_, _ = taskGroup.WaitGroup()
+			return fail.Wrap(xerr, "failed to start draining nodes")
+		}
 	}
 
-	return req
+	if _, xerr = taskGroup.WaitGroup(); xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrAborted:
+			return xerr
+		default:
+			logrus.Warnf("failed to drain some nodes of Cluster '%s': %s", instance.GetName(), xerr.Error())
+		}
+	}
+	return nil
 }
 
-// DeleteLastNode deletes the last added node and returns its name
-func (instance *Cluster) DeleteLastNode(ctx context.Context) (node *propertiesv3.ClusterNode, xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
+// getClusterDrainTimeout returns the maximum duration of the drain of a node, that can be overridden by environment
+// variable SAFESCALE_CLUSTER_DRAIN_TIMEOUT (default: the long operation timeout)
+func getClusterDrainTimeout() time.Duration {
+	return temporal.GetTimeoutFromEnv("SAFESCALE_CLUSTER_DRAIN_TIMEOUT", temporal.GetLongOperationTimeout())
+}
 
-	if instance == nil || instance.IsNull() {
-		return nil, fail.InvalidInstanceError()
+// drainOptionsFrom extracts from 'options' the settings of the drain of nodes before their removal:
+//   - "SkipDrain" (bool): if true, the nodes are removed without being drained
+//   - "DrainTimeout" (time.Duration): maximum duration of the drain of each node; 0 uses the default (see getClusterDrainTimeout)
+func drainOptionsFrom(options ...data.ImmutableKeyValue) (skip bool, timeout time.Duration) {
+	for _, v := range options {
+		switch v.Key() {
+		case "SkipDrain":
+			skip = v.Value().(bool)
+		case "DrainTimeout":
+			timeout = v.Value().(time.Duration)
+		default:
+		}
 	}
-	if ctx == nil {
-		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	return skip, timeout
+}
+
+// unsafeDrainNodesBeforeRemoval drains the workloads from the nodes of a K8S Cluster about to be removed, using 'master'
+// (or an available master if nil), waiting at most 'timeout' for each node
+// Returns the nodes that can be removed; a node that failed to drain must be kept, its failure is returned in 'failures'
+// and it is made schedulable again, unless it was cordoned before.
+// For other flavors, all the nodes can be removed.
+func (instance *Cluster) unsafeDrainNodesBeforeRemoval(ctx context.Context, master resources.Host, nodes []*propertiesv3.ClusterNode, timeout time.Duration) (removable []*propertiesv3.ClusterNode, failures []error, xerr fail.Error) {
+	if len(nodes) == 0 {
+		return nil, nil, nil
 	}
 
-	task, xerr := concurrency.TaskFromContext(ctx)
+	flavor, xerr := instance.UnsafeGetFlavor()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, xerr
+		return nil, nil, xerr
 	}
-
-	if task.Aborted() {
-		return nil, fail.AbortedError(nil, "aborted")
+	if flavor != clusterflavor.K8S {
+		logrus.Debugf("drain of nodes is only supported by K8S flavor, skipping")
+		return nodes, nil, nil
 	}
 
-	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).Entering()
-	defer tracer.Exiting()
-
-	// make sure no other parallel actions interferes
-	instance.lock.Lock()
-	defer instance.lock.Unlock()
-
-	xerr = instance.beingRemoved()
+	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, xerr
+		return nil, nil, xerr
 	}
 
-	// Removed reference of the node from Cluster
-	xerr = instance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+	if master == nil {
+		master, xerr = instance.UnsafeFindAvailableMaster(ctx)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return nil, nil, fail.Wrap(xerr, "failed to find an available master to drain nodes")
+		}
+	}
+
+	var cordoned map[uint]bool
+	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
 		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
 			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
 			if !ok {
 				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			numericalID := nodesV3.PrivateNodes[len(nodesV3.PrivateNodes)-1]
-			if node, ok = nodesV3.ByNumericalID[numericalID]; !ok {
-				return fail.InconsistentError("the last recorded node in metadata points to missing Host")
+			cordoned = make(map[uint]bool, len(nodesV3.Cordoned))
+			for k, v := range nodesV3.Cordoned {
+				cordoned[k] = v
 			}
-
 			return nil
 		})
 	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, xerr
-	}
-	if node == nil {
-		return nil, fail.NotFoundError("failed to find last node")
+		return nil, nil, xerr
 	}
 
-	selectedMaster, xerr := instance.UnsafeFindAvailableMaster(ctx)
+	taskGroup, xerr := concurrency.NewTaskGroupWithParent(task)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, xerr
+		return nil, nil, xerr
+	}
+
+	var drainErrorsMu sync.Mutex
+	drainErrors := make(map[uint]fail.Error, len(nodes))
+	for _, v := range nodes {
+		node := v
+		_, xerr = taskGroup.StartInSubtask(func(t concurrency.Task, p concurrency.TaskParameters) (concurrency.TaskResult, fail.Error) {
+			// the failure of a node is recorded and not returned, to not interrupt the drain of the other nodes
+			if _, innerXErr := instance.taskDrainNode(t, p); innerXErr != nil {
+				drainErrorsMu.Lock()
+				drainErrors[node.NumericalID] = innerXErr
+				drainErrorsMu.Unlock()
+			}
+			return nil, nil
+		}, taskDrainNodeParameters{master: master, nodeName: node.Name, timeout: timeout, uncordonOnFailure: !cordoned[node.NumericalID]})
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			_ = taskGroup.Abort()
+			_, _ = taskGroup.WaitGroup()
+			return nil, nil, fail.Wrap(xerr, "failed to start draining nodes")
+		}
 	}
 
-	xerr = instance.deleteNode(ctx, node, selectedMaster.(*Host))
+	_, xerr = taskGroup.WaitGroup()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, xerr
+		return nil, nil, xerr
 	}
 
-	return node, nil
+	for _, v := range nodes {
+		if derr, ok := drainErrors[v.NumericalID]; ok {
+			logrus.Warnf("node '%s' of Cluster '%s' failed to drain, it will not be removed: %s", v.Name, instance.GetName(), derr.Error())
+			failures = append(failures, fail.Wrap(derr, "node '%s' not removed", v.Name))
+			continue
+		}
+		removable = append(removable, v)
+	}
+	return removable, failures, nil
 }
 
-// DeleteSpecificNode deletes a node identified by its ID
-func (instance *Cluster) DeleteSpecificNode(ctx context.Context, hostID string, selectedMasterID string) (xerr fail.Error) {
+// GetState returns the current state of the Cluster
+// If the state has been collected by the "maker" less than the freshness window ago, returns the state stored in
+// metadata without probing the Cluster again (see getClusterStateCacheWindowFromCfg)
+func (instance *Cluster) GetState() (state clusterstate.Enum, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
+	state = clusterstate.Unknown
 	if instance == nil || instance.IsNull() {
-		return fail.InvalidInstanceError()
+		return state, fail.InvalidInstanceError()
 	}
-	if ctx == nil {
-		return fail.InvalidParameterCannotBeNilError("task")
+
+	// make sure no other parallel actions interferes
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	if !instance.lastStateCollection.IsZero() && time.Since(instance.lastStateCollection) < getClusterStateCacheWindowFromCfg(instance.GetService()) {
+		xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+			return props.Inspect(clusterproperty.StateV1, func(clonable data.Clonable) fail.Error {
+				stateV1, ok := clonable.(*propertiesv1.ClusterState)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv1.ClusterState' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				state = stateV1.State
+				return nil
+			})
+		})
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return clusterstate.Unknown, xerr
+		}
+
+		return state, nil
 	}
-	if hostID = strings.TrimSpace(hostID); hostID == "" {
-		return fail.InvalidParameterError("hostID", "cannot be empty string")
+
+	return instance.unsafeGetState()
+}
+
+// ForceGetState returns the current state of the Cluster, always probing it with the "maker" GetState
+func (instance *Cluster) ForceGetState(ctx context.Context) (state clusterstate.Enum, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	state = clusterstate.Unknown
+	if instance == nil || instance.IsNull() {
+		return state, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return state, fail.InvalidParameterCannotBeNilError("ctx")
 	}
 
 	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return xerr
+		return state, xerr
 	}
 
 	if task.Aborted() {
-		return fail.AbortedError(nil, "aborted")
+		return state, fail.AbortedError(nil, "aborted")
 	}
 
-	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster"), "(hostID=%s)", hostID).Entering()
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).WithStopwatch().Entering()
 	defer tracer.Exiting()
 
 	// make sure no other parallel actions interferes
 	instance.lock.Lock()
 	defer instance.lock.Unlock()
 
-	xerr = instance.beingRemoved()
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return xerr
-	}
+	return instance.unsafeGetState()
+}
 
-	var selectedMaster resources.Host
-	if selectedMasterID != "" {
-		selectedMaster, xerr = LoadHost(instance.GetService(), selectedMasterID)
-	} else {
-		selectedMaster, xerr = instance.UnsafeFindAvailableMaster(ctx)
+// defaultClusterStateCacheWindow is the duration during which the state of a Cluster collected by the "maker" is
+// considered fresh by GetState
+const defaultClusterStateCacheWindow = 10 * time.Second
+
+// getClusterStateCacheWindowFromCfg returns the freshness window of the collected state of the Clusters of the tenant
+// (option 'ClusterStateCacheWindow' of section 'compute' of the tenant, in seconds or as a duration string like "30s");
+// 0 disables the cache
+func getClusterStateCacheWindowFromCfg(svc iaas.Service) time.Duration {
+	compute, ok := svc.GetTenantParameters()["compute"].(map[string]interface{})
+	if !ok {
+		return defaultClusterStateCacheWindow
+	}
+
+	var window time.Duration
+	switch v := compute["ClusterStateCacheWindow"].(type) {
+	case int:
+		window = time.Duration(v) * time.Second
+	case int64:
+		window = time.Duration(v) * time.Second
+	case float64:
+		window = time.Duration(v * float64(time.Second))
+	case string:
+		var err error
+		if window, err = time.ParseDuration(v); err != nil {
+			logrus.Warnf("invalid value '%s' for option 'ClusterStateCacheWindow' of tenant, using default %s", v, defaultClusterStateCacheWindow)
+			return defaultClusterStateCacheWindow
+		}
+	default:
+		return defaultClusterStateCacheWindow
 	}
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return xerr
+	if window < 0 {
+		return defaultClusterStateCacheWindow
 	}
+	return window
+}
 
-	var node *propertiesv3.ClusterNode
-	xerr = instance.Review(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
-			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
+// GetStateHistory returns the last state transitions of the Cluster, ordered from the oldest to the newest
+func (instance *Cluster) GetStateHistory(ctx context.Context) (_ []propertiesv1.ClusterStateTransition, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
 
-			numericalID, ok := nodesV3.PrivateNodeByID[hostID]
-			if !ok {
-				return fail.NotFoundError("failed to find a node identified by %s", hostID)
-			}
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
 
-			node, ok = nodesV3.ByNumericalID[numericalID]
+	// make sure no other parallel actions interferes
+	instance.lock.RLock()
+	defer instance.lock.RUnlock()
+
+	var list []propertiesv1.ClusterStateTransition
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.StateHistoryV1, func(clonable data.Clonable) fail.Error {
+			historyV1, ok := clonable.(*propertiesv1.ClusterStateHistory)
 			if !ok {
-				return fail.NotFoundError("failed to find a node identified by %s", hostID)
+				return fail.InconsistentError("'*propertiesv1.ClusterStateHistory' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
+			list = make([]propertiesv1.ClusterStateTransition, len(historyV1.Transitions))
+			copy(list, historyV1.Transitions)
 			return nil
 		})
 	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return xerr
+		return nil, xerr
 	}
 
-	return instance.deleteNode(ctx, node, selectedMaster.(*Host))
+	return list, nil
 }
 
-// ListMasters lists the node instances corresponding to masters (if there is such masters in the flavor...)
-func (instance *Cluster) ListMasters(ctx context.Context) (list resources.IndexedListOfClusterNodes, xerr fail.Error) {
-	emptyList := resources.IndexedListOfClusterNodes{}
+// AddNode adds a node
+func (instance *Cluster) AddNode(ctx context.Context, def abstract.HostSizingRequirements) (_ resources.Host, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
 	if instance == nil || instance.IsNull() {
-		return emptyList, fail.InvalidInstanceError()
+		return HostNullValue(), fail.InvalidInstanceError()
 	}
 	if ctx == nil {
-		return emptyList, fail.InvalidParameterCannotBeNilError("ctx")
+		return HostNullValue(), fail.InvalidParameterCannotBeNilError("ctx")
 	}
 
 	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return emptyList, xerr
+		return nil, xerr
 	}
 
 	if task.Aborted() {
-		return emptyList, fail.AbortedError(nil, "aborted")
+		return HostNullValue(), fail.AbortedError(nil, "aborted")
 	}
 
-	// make sure no other parallel actions interferes
-	instance.lock.Lock()
-	defer instance.lock.Unlock()
-
-	xerr = instance.beingRemoved()
+	nodes, xerr := instance.AddNodes(ctx, 1, def)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return emptyList, xerr
+		return HostNullValue(), xerr
 	}
 
-	return instance.UnsafeListMasters()
+	return nodes[0], nil
 }
 
-// ListMasterNames lists the names of the master nodes in the Cluster
-func (instance *Cluster) ListMasterNames(ctx context.Context) (list data.IndexedListOfStrings, xerr fail.Error) {
+// AddNodes adds several nodes
+// options may contain:
+//   - "NodePool" (string): name of the node pool where to add the nodes; nodes of a pool land in the Subnet dedicated to the pool
+//   - "NodePoolCIDR" (string): CIDR of the Subnet to create for the node pool, if the pool does not exist yet
+func (instance *Cluster) AddNodes(ctx context.Context, count uint, def abstract.HostSizingRequirements, options ...data.ImmutableKeyValue) (_ []resources.Host, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
-	emptyList := data.IndexedListOfStrings{}
 	if instance == nil || instance.IsNull() {
-		return emptyList, fail.InvalidInstanceError()
+		return nil, fail.InvalidInstanceError()
 	}
 	if ctx == nil {
-		return emptyList, fail.InvalidParameterCannotBeNilError("ctx")
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if count == 0 {
+		return nil, fail.InvalidParameterError("count", "must be an int > 0")
 	}
 
-	task, xerr := concurrency.TaskFromContext(ctx)
+	tgo, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return emptyList, xerr
+		return nil, xerr
 	}
 
-	if task.Aborted() {
-		return emptyList, fail.AbortedError(nil, "aborted")
+	if tgo.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	task, err := concurrency.NewTaskGroupWithParent(tgo)
+	if err != nil {
+		return nil, err
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster"), "(%d)", count)
+	defer tracer.Entering().Exiting()
+
+	// make sure no other operation on the same Cluster runs in parallel in the daemon
+	unlockCluster, xerr := lockCluster(ctx, instance)
+	if xerr != nil {
+		return nil, xerr
 	}
+	defer unlockCluster()
 
 	// make sure no other parallel actions interferes
 	instance.lock.Lock()
 	defer instance.lock.Unlock()
 
+	defer recordOperation(instance, clusterproperty.LastOperationV1, "add-nodes")(&xerr)
+
 	xerr = instance.beingRemoved()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return emptyList, xerr
+		return nil, xerr
 	}
 
-	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
-			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+	var (
+		hostImage             string
+		nodeDefaultDefinition *propertiesv2.HostSizingRequirements
+	)
+	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.DefaultsV2, func(clonable data.Clonable) fail.Error {
+			defaultsV2, ok := clonable.(*propertiesv2.ClusterDefaults)
 			if !ok {
-				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
-
-			list = make(data.IndexedListOfStrings, len(nodesV3.Masters))
-			for _, v := range nodesV3.Masters {
-				if task.Aborted() {
-					return fail.AbortedError(nil, "aborted")
-				}
-
-				if node, found := nodesV3.ByNumericalID[v]; found {
-					list[node.NumericalID] = node.Name
-				}
+				return fail.InconsistentError("'*propertiesv2.ClusterDefaults' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
+			nodeDefaultDefinition = &defaultsV2.NodeSizing
+			hostImage = defaultsV2.Image
 			return nil
 		})
 	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return emptyList, xerr
+		return nil, xerr
 	}
 
-	return list, nil
-}
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
 
-// ListMasterIDs lists the IDs of masters (if there is such masters in the flavor...)
-func (instance *Cluster) ListMasterIDs(ctx context.Context) (list data.IndexedListOfStrings, xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
+	nodeDef := complementHostDefinition(def, *nodeDefaultDefinition)
+	if nodeDef.Image == "" {
+		nodeDef.Image = hostImage
+	}
 
-	emptyList := data.IndexedListOfStrings{}
-	if instance == nil || instance.IsNull() {
-		return emptyList, fail.InvalidInstanceError()
+	var poolName, poolCIDR string
+	for _, v := range options {
+		switch v.Key() {
+		case "NodePool":
+			poolName = v.Value().(string)
+		case "NodePoolCIDR":
+			poolCIDR = v.Value().(string)
+		default:
+		}
 	}
-	if ctx == nil {
-		return emptyList, fail.InvalidParameterCannotBeNilError("ctx")
+	var subnetID string
+	if poolName != "" {
+		subnetID, xerr = instance.lookupNodePoolSubnet(ctx, poolName, poolCIDR)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return nil, xerr
+		}
 	}
 
-	// make sure no other parallel actions interferes
-	instance.lock.Lock()
-	defer instance.lock.Unlock()
+	var (
+		nodeTypeStr string
+		errors      []string
+		hosts       []resources.Host
+	)
 
-	return instance.unsafeListMasterIDs(ctx)
-}
+	timeout := temporal.GetExecutionTimeout() + time.Duration(count)*time.Minute
 
-func (instance *Cluster) unsafeListMasterIDs(ctx context.Context) (list data.IndexedListOfStrings, xerr fail.Error) {
-	emptyList := data.IndexedListOfStrings{}
-	task, xerr := concurrency.TaskFromContext(ctx)
+	var newHosts []resources.Host
+	defer func() {
+		if xerr != nil && len(newHosts) > 0 {
+			// Nodes are removed from the Cluster before being deleted, for their metadata not to reference deleted Hosts
+			for _, h := range newHosts {
+				if derr := instance.unregisterHost(h); derr != nil {
+					switch derr.(type) {
+					case *fail.ErrNotFound:
+					default:
+						_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on failure, failed to remove Node '%s' from Cluster", h.GetName()))
+					}
+				}
+			}
+
+			logrus.Debugf("Cleaning up on failure, deleting Nodes...")
+			if derr := instance.deleteHosts(task, newHosts); derr != nil {
+				logrus.Errorf("Cleaning up on failure, failed to delete Nodes")
+				_ = xerr.AddConsequence(derr)
+			} else {
+				logrus.Debugf("Cleaning up on failure, successfully deleted Nodes")
+			}
+		}
+	}()
+	slots, xerr := instance.reserveNodeSlots(clusternodetype.Node, count)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return emptyList, xerr
+		return nil, xerr
 	}
 
-	if task.Aborted() {
-		return emptyList, fail.AbortedError(nil, "aborted")
+	for i, slot := range slots {
+		_, xerr := task.StartInSubtask(instance.taskCreateNode, taskCreateNodeParameters{
+			index:         uint(i) + 1,
+			slot:          slot,
+			subnetID:      subnetID,
+			nodeDef:       nodeDef,
+			timeout:       timeout,
+			keepOnFailure: false,
+		})
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return nil, xerr
+		}
+	}
+	res, err := task.WaitGroup()
+	if res != nil {
+		for _, v := range res {
+			if aHost, ok := v.(resources.Host); ok {
+				newHosts = append(newHosts, aHost)
+			}
+		}
+	}
+	err = debug.InjectPlannedFail(err)
+	if err != nil {
+		return nil, fail.NewErrorWithCause(err, "errors occurred on %s node%s addition", nodeTypeStr, strprocess.Plural(uint(len(errors))))
 	}
+	hosts = newHosts
 
-	xerr = instance.beingRemoved()
+	// Now configure new nodes
+	xerr = instance.configureNodesFromList(task, hosts)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return emptyList, xerr
+		return nil, xerr
 	}
 
-	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
-			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
-
-			list = make(data.IndexedListOfStrings, len(nodesV3.Masters))
-			for _, v := range nodesV3.Masters {
-				if task.Aborted() {
-					return fail.AbortedError(nil, "aborted")
-				}
-
-				if node, found := nodesV3.ByNumericalID[v]; found {
-					list[node.NumericalID] = node.ID
-				}
-			}
-			return nil
-		})
-	})
+	// At last join nodes to Cluster, and wait for them to be confirmed members; if not, all the new nodes are deleted
+	xerr = instance.joinNodesFromList(ctx, hosts)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return emptyList, xerr
+		return nil, xerr
 	}
 
-	return list, nil
+	// Hosts of the Cluster have to resolve the new nodes in the domain of the Cluster
+	netCfg, xerr := instance.GetNetworkConfig()
+	if xerr == nil && netCfg.Domain != "" {
+		xerr = instance.unsafeConfigureDomain(ctx, netCfg.Domain)
+	}
+	if xerr != nil {
+		logrus.Warnf("failed to configure domain of Cluster '%s' for the new nodes: %v", instance.GetName(), xerr)
+	}
+
+	return hosts, nil
 }
 
-// ListMasterIPs lists the IPs of masters (if there is such masters in the flavor...)
-func (instance *Cluster) ListMasterIPs(ctx context.Context) (list data.IndexedListOfStrings, xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
+// lookupNodePoolSubnet returns the ID of the Subnet dedicated to the node pool 'name'
+// If the pool does not exist and cidr is not empty, creates the Subnet of the pool in the Network of the Cluster, without gateway:
+// nodes of the pool use the gateway(s) of the Cluster Subnet, and the internal Security Group of the Cluster Subnet is bound
+// to the new Subnet to allow traffic between them.
+func (instance *Cluster) lookupNodePoolSubnet(ctx context.Context, name, cidr string) (_ string, xerr fail.Error) {
+	netCfg, xerr := instance.GetNetworkConfig()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return "", xerr
+	}
 
-	emptyList := data.IndexedListOfStrings{}
-	if instance == nil || instance.IsNull() {
-		return emptyList, fail.InvalidInstanceError()
+	if subnetID, ok := netCfg.NodePoolSubnets[name]; ok {
+		return subnetID, nil
 	}
-	if ctx == nil {
-		return emptyList, fail.InvalidParameterCannotBeNilError("ctx")
+	if cidr == "" {
+		return "", fail.NotFoundError("failed to find node pool '%s' in Cluster '%s'", name, instance.GetName())
 	}
 
-	task, xerr := concurrency.TaskFromContext(ctx)
+	svc := instance.GetService()
+	clusterSubnet, xerr := LoadSubnet(svc, netCfg.NetworkID, netCfg.SubnetID)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return emptyList, xerr
+		return "", xerr
 	}
 
-	if task.Aborted() {
-		return emptyList, fail.AbortedError(nil, "aborted")
+	internalSG, xerr := clusterSubnet.InspectInternalSecurityGroup()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return "", xerr
 	}
 
-	// make sure no other parallel actions interferes
-	instance.lock.Lock()
-	defer instance.lock.Unlock()
-
-	return instance.UnsafeListMasterIPs()
-}
+	poolSubnet, xerr := NewSubnet(svc)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return "", xerr
+	}
 
-// FindAvailableMaster returns ID of the first master available to execute order
-// satisfies interface Cluster.Cluster.Controller
-func (instance *Cluster) FindAvailableMaster(ctx context.Context) (master resources.Host, xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
-
-	master = nil
-	if instance == nil || instance.IsNull() {
-		return nil, fail.InvalidInstanceError()
+	req := abstract.SubnetRequest{
+		NetworkID: netCfg.NetworkID,
+		Name:      fmt.Sprintf("%s-pool-%s", instance.GetName(), name),
+		CIDR:      cidr,
 	}
-	if ctx == nil {
-		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	xerr = poolSubnet.(*Subnet).CreateSubnetWithoutGateway(ctx, req)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return "", fail.Wrap(xerr, "failed to create Subnet of node pool '%s'", name)
 	}
 
-	task, xerr := concurrency.TaskFromContext(ctx)
+	defer func() {
+		if xerr != nil {
+			if derr := poolSubnet.Delete(concurrency.DetachedContext(ctx)); derr != nil {
+				_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on %s, failed to delete Subnet of node pool '%s'", ActionFromError(xerr), name))
+			}
+		}
+	}()
+
+	xerr = poolSubnet.BindSecurityGroup(ctx, internalSG, resources.SecurityGroupEnable)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, xerr
+		return "", xerr
 	}
 
-	if task.Aborted() {
-		return nil, fail.AbortedError(nil, "aborted")
+	subnetID := poolSubnet.GetID()
+
+	// a failure to tag does not compromise the node pool, tags can be set again with RetagResources()
+	tags, tagErr := instance.getTags()
+	if tagErr == nil {
+		tagErr = instance.tagResource(taggableresource.Subnet, subnetID, req.Name, tags)
+	}
+	if tagErr != nil {
+		logrus.Warnf("failed to tag Subnet of node pool '%s' of Cluster '%s': %v", name, instance.GetName(), tagErr)
 	}
+	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(clusterproperty.NetworkV3, func(clonable data.Clonable) fail.Error {
+			networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
 
-	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).Entering()
-	defer tracer.Exiting()
+			if networkV3.NodePoolSubnets == nil {
+				networkV3.NodePoolSubnets = map[string]string{}
+			}
+			networkV3.NodePoolSubnets[name] = subnetID
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return "", xerr
+	}
 
-	// make sure no other parallel actions interferes
-	instance.lock.Lock()
-	defer instance.lock.Unlock()
+	return subnetID, nil
+}
 
-	xerr = instance.beingRemoved()
+// deleteNodePoolSubnets deletes the Subnets dedicated to node pools
+func (instance *Cluster) deleteNodePoolSubnets(ctx context.Context) fail.Error {
+	netCfg, xerr := instance.GetNetworkConfig()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, xerr
+		return xerr
+	}
+
+	var errors []error
+	for name, subnetID := range netCfg.NodePoolSubnets {
+		subnetInstance, xerr := LoadSubnet(instance.GetService(), netCfg.NetworkID, subnetID)
+		if xerr == nil {
+			xerr = subnetInstance.Delete(ctx)
+		}
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrNotFound:
+				// Subnet not found, consider as a successful deletion and continue
+			default:
+				errors = append(errors, fail.Wrap(xerr, "failed to delete Subnet of node pool '%s'", name))
+				continue
+			}
+		}
+
+		xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+			return props.Alter(clusterproperty.NetworkV3, func(clonable data.Clonable) fail.Error {
+				networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				delete(networkV3.NodePoolSubnets, name)
+				return nil
+			})
+		})
+		if xerr != nil {
+			errors = append(errors, xerr)
+		}
+	}
+	if len(errors) > 0 {
+		return fail.NewErrorList(errors)
 	}
 
-	return instance.UnsafeFindAvailableMaster(ctx)
+	return nil
 }
 
-// ListNodes lists node instances corresponding to the nodes in the Cluster
-// satisfies interface Cluster.Controller
-func (instance *Cluster) ListNodes(ctx context.Context) (list resources.IndexedListOfClusterNodes, xerr fail.Error) {
+// complementHostDefinition complements req with default values if needed
+func complementHostDefinition(req abstract.HostSizingRequirements, def propertiesv2.HostSizingRequirements) abstract.HostSizingRequirements {
+	if def.MinCores > 0 && req.MinCores == 0 {
+		req.MinCores = def.MinCores
+	}
+	if def.MaxCores > 0 && req.MaxCores == 0 {
+		req.MaxCores = def.MaxCores
+	}
+	if def.MinRAMSize > 0.0 && req.MinRAMSize == 0.0 {
+		req.MinRAMSize = def.MinRAMSize
+	}
+	if def.MaxRAMSize > 0.0 && req.MaxRAMSize == 0.0 {
+		req.MaxRAMSize = def.MaxRAMSize
+	}
+	if def.MinDiskSize > 0 && req.MinDiskSize == 0 {
+		req.MinDiskSize = def.MinDiskSize
+	}
+	if req.MinGPU <= 0 && def.MinGPU > 0 {
+		req.MinGPU = def.MinGPU
+	}
+	if req.MinCPUFreq == 0 && def.MinCPUFreq > 0 {
+		req.MinCPUFreq = def.MinCPUFreq
+	}
+	if req.MinCores <= 0 {
+		req.MinCores = 2
+	}
+	if req.MaxCores <= 0 {
+		req.MaxCores = 4
+	}
+	if req.MinRAMSize <= 0.0 {
+		req.MinRAMSize = 7.0
+	}
+	if req.MaxRAMSize <= 0.0 {
+		req.MaxRAMSize = 16.0
+	}
+	if req.MinDiskSize <= 0 {
+		req.MinDiskSize = 50
+	}
+
+	return req
+}
+
+// DeleteLastNode deletes the last added node and returns its name
+// Refuses to leave less nodes than the minimum required by the flavor of the Cluster, unless option "AllowBelowMinimum"
+// (bool) is set to true
+func (instance *Cluster) DeleteLastNode(ctx context.Context, options ...data.ImmutableKeyValue) (node *propertiesv3.ClusterNode, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
-	emptyList := resources.IndexedListOfClusterNodes{}
 	if instance == nil || instance.IsNull() {
-		return emptyList, fail.InvalidInstanceError()
+		return nil, fail.InvalidInstanceError()
 	}
 	if ctx == nil {
-		return emptyList, fail.InvalidParameterCannotBeNilError("ctx")
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
 	}
 
 	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return emptyList, xerr
+		return nil, xerr
 	}
 
 	if task.Aborted() {
-		return emptyList, fail.AbortedError(nil, "aborted")
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).Entering()
+	defer tracer.Exiting()
+
+	// make sure no other operation on the same Cluster runs in parallel in the daemon
+	unlockCluster, xerr := lockCluster(ctx, instance)
+	if xerr != nil {
+		return nil, xerr
 	}
+	defer unlockCluster()
 
 	// make sure no other parallel actions interferes
 	instance.lock.Lock()
@@ -1787,89 +2196,158 @@ func (instance *Cluster) ListNodes(ctx context.Context) (list resources.IndexedL
 		return nil, xerr
 	}
 
-	return instance.unsafeListNodes()
-}
+	minimumNodes, xerr := instance.minimumNodesToKeep(options...)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
 
-// beingRemoved tells if the Cluster is currently marked as Removed (meaning a removal operation is running)
-func (instance *Cluster) beingRemoved() fail.Error {
-	state, xerr := instance.unsafeGetState()
+	// Removed reference of the node from Cluster
+	xerr = instance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			if innerXErr := checkMinimumNodes(instance.GetName(), uint(len(nodesV3.PrivateNodes)), 1, minimumNodes); innerXErr != nil {
+				return innerXErr
+			}
+
+			numericalID := nodesV3.PrivateNodes[len(nodesV3.PrivateNodes)-1]
+			if node, ok = nodesV3.ByNumericalID[numericalID]; !ok {
+				return fail.InconsistentError("the last recorded node in metadata points to missing Host")
+			}
+
+			return nil
+		})
+	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return xerr
+		return nil, xerr
+	}
+	if node == nil {
+		return nil, fail.NotFoundError("failed to find last node")
 	}
 
-	if state == clusterstate.Removed {
-		return fail.NotAvailableError("Cluster is being removed")
+	selectedMaster, xerr := instance.UnsafeFindAvailableMaster(ctx, data.NewImmutableKeyValue("RoundRobin", true))
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
 	}
 
-	return nil
+	xerr = instance.deleteNode(ctx, node, selectedMaster.(*Host))
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	return node, nil
 }
 
-// ListNodeNames lists the names of the nodes in the Cluster
-func (instance *Cluster) ListNodeNames(ctx context.Context) (list data.IndexedListOfStrings, xerr fail.Error) {
+// DeleteSpecificNode deletes a node identified by its ID
+// If the Cluster is of flavor K8S, the node is drained first, and is not deleted if the drain fails; options may contain:
+//   - "SkipDrain" (bool): if true, the node is deleted without being drained
+//   - "DrainTimeout" (time.Duration): maximum duration of the drain; 0 uses the default
+func (instance *Cluster) DeleteSpecificNode(ctx context.Context, hostID string, selectedMasterID string, options ...data.ImmutableKeyValue) (xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
-	emptyList := data.IndexedListOfStrings{}
 	if instance == nil || instance.IsNull() {
-		return emptyList, fail.InvalidInstanceError()
+		return fail.InvalidInstanceError()
 	}
 	if ctx == nil {
-		return emptyList, fail.InvalidParameterCannotBeNilError("ctx")
+		return fail.InvalidParameterCannotBeNilError("task")
+	}
+	if hostID = strings.TrimSpace(hostID); hostID == "" {
+		return fail.InvalidParameterError("hostID", "cannot be empty string")
 	}
 
 	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return emptyList, xerr
+		return xerr
 	}
 
 	if task.Aborted() {
-		return emptyList, fail.AbortedError(nil, "aborted")
+		return fail.AbortedError(nil, "aborted")
 	}
 
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster"), "(hostID=%s)", hostID).Entering()
+	defer tracer.Exiting()
+
+	// make sure no other operation on the same Cluster runs in parallel in the daemon
+	unlockCluster, xerr := lockCluster(ctx, instance)
+	if xerr != nil {
+		return xerr
+	}
+	defer unlockCluster()
+
 	// make sure no other parallel actions interferes
 	instance.lock.Lock()
 	defer instance.lock.Unlock()
 
+	defer recordOperation(instance, clusterproperty.LastOperationV1, "delete-node")(&xerr)
+
 	xerr = instance.beingRemoved()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, xerr
+		return xerr
 	}
 
-	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+	var selectedMaster resources.Host
+	if selectedMasterID != "" {
+		selectedMaster, xerr = LoadHost(instance.GetService(), selectedMasterID)
+	} else {
+		selectedMaster, xerr = instance.UnsafeFindAvailableMaster(ctx, data.NewImmutableKeyValue("RoundRobin", true))
+	}
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	var node *propertiesv3.ClusterNode
+	xerr = instance.Review(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
 		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
 			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
 			if !ok {
 				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			list = make(data.IndexedListOfStrings, len(nodesV3.PrivateNodes))
-			for _, v := range nodesV3.PrivateNodes {
-				if task.Aborted() {
-					return fail.AbortedError(nil, "aborted")
-				}
+			numericalID, ok := nodesV3.PrivateNodeByID[hostID]
+			if !ok {
+				return fail.NotFoundError("failed to find a node identified by %s", hostID)
+			}
 
-				if node, found := nodesV3.ByNumericalID[v]; found {
-					list[node.NumericalID] = node.Name
-				}
+			node, ok = nodesV3.ByNumericalID[numericalID]
+			if !ok {
+				return fail.NotFoundError("failed to find a node identified by %s", hostID)
 			}
+
 			return nil
 		})
 	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return emptyList, xerr
+		return xerr
 	}
 
-	return list, nil
-}
+	if skipDrain, drainTimeout := drainOptionsFrom(options...); !skipDrain {
+		_, failures, xerr := instance.unsafeDrainNodesBeforeRemoval(ctx, selectedMaster, []*propertiesv3.ClusterNode{node}, drainTimeout)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+		if len(failures) > 0 {
+			return fail.ConvertError(failures[0])
+		}
+	}
 
-// ListNodeIDs lists IDs of the nodes in the Cluster
-func (instance *Cluster) ListNodeIDs(ctx context.Context) (list data.IndexedListOfStrings, xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
+	return instance.deleteNode(ctx, node, selectedMaster.(*Host))
+}
 
-	emptyList := data.IndexedListOfStrings{}
+// ListMasters lists the node instances corresponding to masters (if there is such masters in the flavor...)
+func (instance *Cluster) ListMasters(ctx context.Context) (list resources.IndexedListOfClusterNodes, xerr fail.Error) {
+	emptyList := resources.IndexedListOfClusterNodes{}
 	if instance == nil || instance.IsNull() {
 		return emptyList, fail.InvalidInstanceError()
 	}
@@ -1891,12 +2369,18 @@ func (instance *Cluster) ListNodeIDs(ctx context.Context) (list data.IndexedList
 	instance.lock.Lock()
 	defer instance.lock.Unlock()
 
-	return instance.UnsafeListNodeIDs(ctx)
-}
-
-// ListNodeIPs lists the IPs of the nodes in the Cluster
-func (instance *Cluster) ListNodeIPs(ctx context.Context) (list data.IndexedListOfStrings, xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
+	xerr = instance.beingRemoved()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return emptyList, xerr
+	}
+
+	return instance.UnsafeListMasters()
+}
+
+// ListMasterNames lists the names of the master nodes in the Cluster
+func (instance *Cluster) ListMasterNames(ctx context.Context) (list data.IndexedListOfStrings, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
 
 	emptyList := data.IndexedListOfStrings{}
 	if instance == nil || instance.IsNull() {
@@ -1923,118 +2407,194 @@ func (instance *Cluster) ListNodeIPs(ctx context.Context) (list data.IndexedList
 	xerr = instance.beingRemoved()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, xerr
+		return emptyList, xerr
 	}
 
-	return instance.unsafeListNodeIPs()
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			list = make(data.IndexedListOfStrings, len(nodesV3.Masters))
+			for _, v := range nodesV3.Masters {
+				if task.Aborted() {
+					return fail.AbortedError(nil, "aborted")
+				}
+
+				if node, found := nodesV3.ByNumericalID[v]; found {
+					list[node.NumericalID] = node.Name
+				}
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return emptyList, xerr
+	}
+
+	return list, nil
 }
 
-// FindAvailableNode returns node instance of the first node available to execute order
-func (instance *Cluster) FindAvailableNode(ctx context.Context) (node resources.Host, xerr fail.Error) {
+// ListMasterIDs lists the IDs of masters (if there is such masters in the flavor...)
+func (instance *Cluster) ListMasterIDs(ctx context.Context) (list data.IndexedListOfStrings, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
+	emptyList := data.IndexedListOfStrings{}
 	if instance == nil || instance.IsNull() {
-		return nil, fail.InvalidInstanceError()
+		return emptyList, fail.InvalidInstanceError()
 	}
 	if ctx == nil {
-		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+		return emptyList, fail.InvalidParameterCannotBeNilError("ctx")
 	}
 
+	// make sure no other parallel actions interferes
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	return instance.unsafeListMasterIDs(ctx)
+}
+
+func (instance *Cluster) unsafeListMasterIDs(ctx context.Context) (list data.IndexedListOfStrings, xerr fail.Error) {
+	emptyList := data.IndexedListOfStrings{}
 	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, xerr
+		return emptyList, xerr
 	}
 
 	if task.Aborted() {
-		return nil, fail.AbortedError(nil, "aborted")
+		return emptyList, fail.AbortedError(nil, "aborted")
 	}
 
-	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).Entering()
-	defer tracer.Exiting()
+	xerr = instance.beingRemoved()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return emptyList, xerr
+	}
 
-	// make sure no other parallel actions interferes
-	instance.lock.Lock()
-	defer instance.lock.Unlock()
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			list = make(data.IndexedListOfStrings, len(nodesV3.Masters))
+			for _, v := range nodesV3.Masters {
+				if task.Aborted() {
+					return fail.AbortedError(nil, "aborted")
+				}
+
+				if node, found := nodesV3.ByNumericalID[v]; found {
+					list[node.NumericalID] = node.ID
+				}
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return emptyList, xerr
+	}
 
-	return instance.UnsafeFindAvailableNode(ctx)
+	return list, nil
 }
 
-// LookupNode tells if the ID of the master passed as parameter is a node
-func (instance *Cluster) LookupNode(ctx context.Context, ref string) (found bool, xerr fail.Error) {
+// ListMasterIPs lists the IPs of masters (if there is such masters in the flavor...)
+func (instance *Cluster) ListMasterIPs(ctx context.Context) (list data.IndexedListOfStrings, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
+	emptyList := data.IndexedListOfStrings{}
 	if instance == nil || instance.IsNull() {
-		return false, fail.InvalidInstanceError()
+		return emptyList, fail.InvalidInstanceError()
 	}
 	if ctx == nil {
-		return false, fail.InvalidParameterCannotBeNilError("ctx")
-	}
-	if ref == "" {
-		return false, fail.InvalidParameterError("ref", "cannot be empty string")
+		return emptyList, fail.InvalidParameterCannotBeNilError("ctx")
 	}
 
 	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return false, xerr
+		return emptyList, xerr
 	}
 
 	if task.Aborted() {
-		return false, fail.AbortedError(nil, "aborted")
+		return emptyList, fail.AbortedError(nil, "aborted")
 	}
 
 	// make sure no other parallel actions interferes
 	instance.lock.Lock()
 	defer instance.lock.Unlock()
 
-	xerr = instance.beingRemoved()
+	return instance.UnsafeListMasterIPs()
+}
+
+// FindAvailableMaster returns ID of the first master available to execute order
+// satisfies interface Cluster.Cluster.Controller
+// options:
+// - "Exclude": []string = IDs of the masters that must not be selected
+// - "RoundRobin": bool = if true, rotates among available masters between calls (default is false)
+func (instance *Cluster) FindAvailableMaster(ctx context.Context, options ...data.ImmutableKeyValue) (master resources.Host, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	master = nil
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return false, xerr
+		return nil, xerr
 	}
 
-	var hostInstance resources.Host
-	hostInstance, xerr = LoadHost(instance.GetService(), ref)
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).Entering()
+	defer tracer.Exiting()
+
+	// make sure no other parallel actions interferes
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	xerr = instance.beingRemoved()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return false, xerr
+		return nil, xerr
 	}
 
-	found = false
-	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
-			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
-
-			_, found = nodesV3.PrivateNodeByID[hostInstance.GetID()]
-			return nil
-		})
-	})
-	return found, xerr
+	return instance.UnsafeFindAvailableMaster(ctx, options...)
 }
 
-// CountNodes counts the nodes of the Cluster
-func (instance *Cluster) CountNodes(ctx context.Context) (count uint, xerr fail.Error) {
+// ListNodes lists node instances corresponding to the nodes in the Cluster
+// satisfies interface Cluster.Controller
+func (instance *Cluster) ListNodes(ctx context.Context) (list resources.IndexedListOfClusterNodes, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
+	emptyList := resources.IndexedListOfClusterNodes{}
 	if instance == nil || instance.IsNull() {
-		return 0, fail.InvalidInstanceError()
+		return emptyList, fail.InvalidInstanceError()
 	}
 	if ctx == nil {
-		return 0, fail.InvalidParameterCannotBeNilError("ctx")
+		return emptyList, fail.InvalidParameterCannotBeNilError("ctx")
 	}
 
 	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return 0, xerr
+		return emptyList, xerr
 	}
 
 	if task.Aborted() {
-		return 0, fail.AbortedError(nil, "aborted")
+		return emptyList, fail.AbortedError(nil, "aborted")
 	}
 
 	// make sure no other parallel actions interferes
@@ -2044,55 +2604,49 @@ func (instance *Cluster) CountNodes(ctx context.Context) (count uint, xerr fail.
 	xerr = instance.beingRemoved()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return 0, xerr
+		return nil, xerr
 	}
 
-	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
-			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
+	return instance.unsafeListNodes()
+}
 
-			count = uint(len(nodesV3.PrivateNodes))
-			return nil
-		})
-	})
+// beingRemoved tells if the Cluster is currently marked as Removed (meaning a removal operation is running)
+func (instance *Cluster) beingRemoved() fail.Error {
+	state, xerr := instance.unsafeGetState()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return 0, xerr
+		return xerr
 	}
 
-	return count, nil
+	if state == clusterstate.Removed {
+		return fail.NotAvailableError("Cluster is being removed")
+	}
+
+	return nil
 }
 
-// GetNodeByID returns a node based on its ID
-func (instance *Cluster) GetNodeByID(ctx context.Context, hostID string) (hostInstance resources.Host, xerr fail.Error) {
+// ListNodeNames lists the names of the nodes in the Cluster
+func (instance *Cluster) ListNodeNames(ctx context.Context) (list data.IndexedListOfStrings, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
+	emptyList := data.IndexedListOfStrings{}
 	if instance == nil || instance.IsNull() {
-		return nil, fail.InvalidInstanceError()
+		return emptyList, fail.InvalidInstanceError()
 	}
 	if ctx == nil {
-		return nil, fail.InvalidParameterCannotBeNilError("ctx")
-	}
-	if hostID == "" {
-		return nil, fail.InvalidParameterError("hostID", "cannot be empty string")
+		return emptyList, fail.InvalidParameterCannotBeNilError("ctx")
 	}
 
 	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, xerr
+		return emptyList, xerr
 	}
 
 	if task.Aborted() {
-		return nil, fail.AbortedError(nil, "aborted")
+		return emptyList, fail.AbortedError(nil, "aborted")
 	}
 
-	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster"), "(%s)", hostID)
-	defer tracer.Entering().Exiting()
-
 	// make sure no other parallel actions interferes
 	instance.lock.Lock()
 	defer instance.lock.Unlock()
@@ -2103,120 +2657,1773 @@ func (instance *Cluster) GetNodeByID(ctx context.Context, hostID string) (hostIn
 		return nil, xerr
 	}
 
-	found := false
-	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
 		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
 			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
 			if !ok {
 				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			_, found = nodesV3.PrivateNodeByID[hostID]
+			list = make(data.IndexedListOfStrings, len(nodesV3.PrivateNodes))
+			for _, v := range nodesV3.PrivateNodes {
+				if task.Aborted() {
+					return fail.AbortedError(nil, "aborted")
+				}
+
+				if node, found := nodesV3.ByNumericalID[v]; found {
+					list[node.NumericalID] = node.Name
+				}
+			}
 			return nil
 		})
 	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, xerr
-	}
-	if !found {
-		return nil, fail.NotFoundError("failed to find node %s in Cluster '%s'", hostID, instance.GetName())
+		return emptyList, xerr
 	}
 
-	return LoadHost(instance.GetService(), hostID)
+	return list, nil
 }
 
-// deleteMaster deletes the master specified by its ID
-func (instance *Cluster) deleteMaster(ctx context.Context, host resources.Host) fail.Error {
+// ListNodeIDs lists IDs of the nodes in the Cluster
+func (instance *Cluster) ListNodeIDs(ctx context.Context) (list data.IndexedListOfStrings, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	emptyList := data.IndexedListOfStrings{}
+	if instance == nil || instance.IsNull() {
+		return emptyList, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return emptyList, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
 	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return xerr
+		return emptyList, xerr
+	}
+
+	if task.Aborted() {
+		return emptyList, fail.AbortedError(nil, "aborted")
+	}
+
+	// make sure no other parallel actions interferes
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	return instance.UnsafeListNodeIDs(ctx)
+}
+
+// ListNodeIPs lists the IPs of the nodes in the Cluster
+func (instance *Cluster) ListNodeIPs(ctx context.Context) (list data.IndexedListOfStrings, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	emptyList := data.IndexedListOfStrings{}
+	if instance == nil || instance.IsNull() {
+		return emptyList, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return emptyList, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return emptyList, xerr
+	}
+
+	if task.Aborted() {
+		return emptyList, fail.AbortedError(nil, "aborted")
+	}
+
+	// make sure no other parallel actions interferes
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	xerr = instance.beingRemoved()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	return instance.unsafeListNodeIPs()
+}
+
+// FindAvailableNode returns node instance of the first node available to execute order
+// options:
+// - "Exclude": []string = IDs of the nodes that must not be selected
+// - "RoundRobin": bool = if true, rotates among available nodes between calls (default is false)
+func (instance *Cluster) FindAvailableNode(ctx context.Context, options ...data.ImmutableKeyValue) (node resources.Host, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).Entering()
+	defer tracer.Exiting()
+
+	// make sure no other parallel actions interferes
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	return instance.UnsafeFindAvailableNode(ctx, options...)
+}
+
+// LookupNode tells if the ID of the master passed as parameter is a node
+func (instance *Cluster) LookupNode(ctx context.Context, ref string) (found bool, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return false, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return false, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if ref == "" {
+		return false, fail.InvalidParameterError("ref", "cannot be empty string")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return false, xerr
+	}
+
+	if task.Aborted() {
+		return false, fail.AbortedError(nil, "aborted")
+	}
+
+	// make sure no other parallel actions interferes
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	xerr = instance.beingRemoved()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return false, xerr
+	}
+
+	var hostInstance resources.Host
+	hostInstance, xerr = LoadHost(instance.GetService(), ref)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return false, xerr
+	}
+
+	found = false
+	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			_, found = nodesV3.PrivateNodeByID[hostInstance.GetID()]
+			return nil
+		})
+	})
+	return found, xerr
+}
+
+// CordonNode marks a node of the Cluster as unschedulable, without stopping the workloads already running on it
+// Only available for Clusters of flavor K8S.
+func (instance *Cluster) CordonNode(ctx context.Context, ref string) (xerr fail.Error) {
+	return instance.setNodeCordoned(ctx, ref, true)
+}
+
+// UncordonNode marks a node of the Cluster as schedulable again
+// Only available for Clusters of flavor K8S.
+func (instance *Cluster) UncordonNode(ctx context.Context, ref string) (xerr fail.Error) {
+	return instance.setNodeCordoned(ctx, ref, false)
+}
+
+// setNodeCordoned does the real work of CordonNode and UncordonNode
+func (instance *Cluster) setNodeCordoned(ctx context.Context, ref string, cordon bool) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if ref == "" {
+		return fail.InvalidParameterError("ref", "cannot be empty string")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	verb := "uncordon"
+	if cordon {
+		verb = "cordon"
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster"), "(%s '%s')", verb, ref).Entering()
+	defer tracer.Exiting()
+
+	// make sure no other parallel actions interferes
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	xerr = instance.beingRemoved()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	flavor, xerr := instance.UnsafeGetFlavor()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+	if flavor != clusterflavor.K8S {
+		return fail.NotAvailableError("cannot %s node of Cluster '%s': only available for Cluster of flavor K8S", verb, instance.GetName())
+	}
+
+	var node *propertiesv3.ClusterNode
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			numericalID, found := nodesV3.PrivateNodeByID[ref]
+			if !found {
+				numericalID, found = nodesV3.PrivateNodeByName[ref]
+			}
+			if found {
+				node, found = nodesV3.ByNumericalID[numericalID]
+			}
+			if !found {
+				return fail.NotFoundError("failed to find node '%s' in Cluster '%s'", ref, instance.GetName())
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	master, xerr := instance.UnsafeFindAvailableMaster(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	cmd := fmt.Sprintf("sudo -u cladm -i kubectl %s %s", verb, node.Name)
+	retcode, stdout, stderr, xerr := master.Run(ctx, cmd, outputs.COLLECT, temporal.GetConnectionTimeout(), temporal.GetExecutionTimeout())
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return fail.Wrap(xerr, "failed to %s node '%s'", verb, node.Name)
+	}
+	if retcode != 0 {
+		xerr = fail.ExecutionError(nil, "failed to %s node '%s'", verb, node.Name)
+		_ = xerr.Annotate("retcode", retcode).Annotate("stdout", stdout).Annotate("stderr", stderr)
+		return xerr
+	}
+
+	return instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			if nodesV3.Cordoned == nil {
+				nodesV3.Cordoned = map[uint]bool{}
+			}
+			if cordon {
+				nodesV3.Cordoned[node.NumericalID] = true
+			} else {
+				delete(nodesV3.Cordoned, node.NumericalID)
+			}
+			return nil
+		})
+	})
+}
+
+// LabelNode sets labels on a node of the Cluster; a label with an empty value is removed
+func (instance *Cluster) LabelNode(ctx context.Context, ref string, labels map[string]string) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if ref == "" {
+		return fail.InvalidParameterError("ref", "cannot be empty string")
+	}
+	if len(labels) == 0 {
+		return fail.InvalidParameterError("labels", "cannot be empty")
+	}
+	for k := range labels {
+		if k == "" {
+			return fail.InvalidParameterError("labels", "cannot contain a label with empty name")
+		}
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster"), "('%s')", ref).Entering()
+	defer tracer.Exiting()
+
+	// make sure no other parallel actions interferes
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	xerr = instance.beingRemoved()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	return instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			numericalID, found := nodesV3.PrivateNodeByID[ref]
+			if !found {
+				numericalID, found = nodesV3.PrivateNodeByName[ref]
+			}
+			if !found {
+				return fail.NotFoundError("failed to find node '%s' in Cluster '%s'", ref, instance.GetName())
+			}
+
+			if nodesV3.Labels == nil {
+				nodesV3.Labels = map[uint]map[string]string{}
+			}
+			current, ok := nodesV3.Labels[numericalID]
+			if !ok {
+				current = map[string]string{}
+			}
+			for k, v := range labels {
+				if v == "" {
+					delete(current, k)
+				} else {
+					current[k] = v
+				}
+			}
+			if len(current) == 0 {
+				delete(nodesV3.Labels, numericalID)
+			} else {
+				nodesV3.Labels[numericalID] = current
+			}
+			return nil
+		})
+	})
+}
+
+// StreamLog follows the file 'path' on all the masters and private nodes of the Cluster, calling 'handler' with the
+// name of the Host and each line added to the file, starting with its last 'lines' lines
+// The following ends when the task is aborted (or the context cancelled); returns nil in this case. A Host where the
+// file cannot be followed does not stop the following on the others.
+func (instance *Cluster) StreamLog(ctx context.Context, path string, lines uint, handler func(host, line string)) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if path == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("path")
+	}
+	if handler == nil {
+		return fail.InvalidParameterCannotBeNilError("handler")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster"), "(%s, %d)", path, lines).Entering()
+	defer tracer.Exiting()
+
+	var ids []string
+	instance.lock.RLock()
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			for _, v := range append(append([]uint{}, nodesV3.Masters...), nodesV3.PrivateNodes...) {
+				if node, found := nodesV3.ByNumericalID[v]; found {
+					ids = append(ids, node.ID)
+				}
+			}
+			return nil
+		})
+	})
+	instance.lock.RUnlock()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	taskGroup, xerr := concurrency.NewTaskGroup(task)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// handler is called from several tasks, calls are serialized
+	var handlerLock sync.Mutex
+	serializedHandler := func(host, line string) {
+		handlerLock.Lock()
+		defer handlerLock.Unlock()
+		handler(host, line)
+	}
+	for _, v := range ids {
+		params := taskStreamHostLogParameters{hostID: v, path: path, lines: lines, handler: serializedHandler}
+		if _, xerr = taskGroup.StartInSubtask(instance.taskStreamHostLog, params); xerr != nil {
+			_ = taskGroup.Abort()
+			_, _ = taskGroup.WaitGroup()
+			return fail.Wrap(xerr, "failed to start following '%s' on Cluster '%s'", path, instance.GetName())
+		}
+	}
+
+	_, xerr = taskGroup.WaitGroup()
+	if xerr != nil {
+		if task.Aborted() || task.GetContext().Err() != nil {
+			return nil
+		}
+		return fail.Wrap(xerr, "failed to follow '%s' on Cluster '%s'", path, instance.GetName())
+	}
+	return nil
+}
+
+const (
+	// defaultDiagnosticsMaxSize is the default maximum size in bytes of the bundle built by CollectDiagnostics
+	defaultDiagnosticsMaxSize uint64 = 64 * 1024 * 1024
+	// diagnosticsEntryMaxSize is the maximum size in bytes kept of each file and command output collected (the end is kept)
+	diagnosticsEntryMaxSize = 4 * 1024 * 1024
+	// diagnosticsParallelism is the maximum number of Hosts on which diagnostics are collected simultaneously
+	diagnosticsParallelism = 4
+	// diagnosticsManifest is the name of the file of the bundle describing its content
+	diagnosticsManifest = "MANIFEST.txt"
+)
+
+var (
+	// defaultDiagnosticsPaths are the files collected by CollectDiagnostics when nothing is requested
+	defaultDiagnosticsPaths = []string{"/var/log/syslog", "/var/log/messages", "/var/log/cloud-init*.log", "/opt/safescale/var/log"}
+	// defaultDiagnosticsCommands are the commands whose outputs are collected by CollectDiagnostics when nothing is requested
+	defaultDiagnosticsCommands = []string{"journalctl --no-pager --since -1day", "systemctl --no-pager --failed", "df -h", "free -m", "ip addr"}
+
+	diagnosticsPathRegexp = regexp.MustCompile(`^/[A-Za-z0-9_.*?/-]*$`)
+)
+
+// CollectDiagnostics collects on all the masters and private nodes of the Cluster the files matching 'paths' (shell
+// patterns accepted; the files inside a directory are collected) and the outputs of 'commands' (run as root), and
+// returns them as a tar bundle containing MANIFEST.txt and an archive '<host>.tar.gz' for each Host
+// When both 'paths' and 'commands' are empty, the system and SafeScale logs are collected.
+// Each file and command output is truncated to its last 4 MiB, and the archives of the Hosts do not exceed 'maxSize'
+// bytes in total (0 means 64 MiB); the Hosts whose diagnostics cannot be collected, or do not fit in the bundle, are
+// reported in the manifest. Fails only if the diagnostics of no Host can be collected.
+func (instance *Cluster) CollectDiagnostics(ctx context.Context, paths, commands []string, maxSize uint64) (_ []byte, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if xerr = validateDiagnosticsRequest(paths, commands); xerr != nil {
+		return nil, xerr
+	}
+	if len(paths) == 0 && len(commands) == 0 {
+		paths, commands = defaultDiagnosticsPaths, defaultDiagnosticsCommands
+	}
+	if maxSize == 0 {
+		maxSize = defaultDiagnosticsMaxSize
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster"), "(%v, %v, %d)", paths, commands, maxSize).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	var ids []string
+	instance.lock.RLock()
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			for _, v := range append(append([]uint{}, nodesV3.Masters...), nodesV3.PrivateNodes...) {
+				if node, found := nodesV3.ByNumericalID[v]; found {
+					ids = append(ids, node.ID)
+				}
+			}
+			return nil
+		})
+	})
+	instance.lock.RUnlock()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if len(ids) == 0 {
+		return nil, fail.NotFoundError("no master nor node in Cluster '%s'", instance.GetName())
+	}
+
+	taskGroup, xerr := concurrency.NewTaskGroup(task)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	// the work directory on the Hosts is unique to this collection, a collection running in parallel cannot interfere
+	workdir := fmt.Sprintf("/tmp/safescale-diagnostics.%d", time.Now().UnixNano())
+	bundle := newDiagnosticsBundle(maxSize)
+	semaphore := make(chan struct{}, diagnosticsParallelism)
+	for _, v := range ids {
+		params := taskCollectHostDiagnosticsParameters{
+			hostID:    v,
+			workdir:   workdir,
+			script:    diagnosticsScript(workdir, paths, commands),
+			bundle:    bundle,
+			semaphore: semaphore,
+		}
+		if _, xerr = taskGroup.StartInSubtask(instance.taskCollectHostDiagnostics, params); xerr != nil {
+			_ = taskGroup.Abort()
+			_, _ = taskGroup.WaitGroup()
+			return nil, fail.Wrap(xerr, "failed to start collection of diagnostics on Cluster '%s'", instance.GetName())
+		}
+	}
+
+	_, _, xerr = taskGroup.WaitGroupFor(temporal.GetClusterDiagnosticsTimeout(ctx))
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, fail.Wrap(xerr, "failed to collect diagnostics on Cluster '%s'", instance.GetName())
+	}
+
+	if len(bundle.archives) == 0 {
+		return nil, fail.NewError("failed to collect diagnostics on any Host of Cluster '%s': %s", instance.GetName(), strings.Join(bundle.listProblems(), "; "))
+	}
+
+	content, err := bundle.write(instance.GetName(), paths, commands)
+	if err != nil {
+		return nil, fail.Wrap(fail.ConvertError(err), "failed to build the diagnostics bundle of Cluster '%s'", instance.GetName())
+	}
+	return content, nil
+}
+
+// validateDiagnosticsRequest checks that the paths are absolute shell patterns and the commands are single lines, for
+// them to be used safely in the script run on the Hosts
+func validateDiagnosticsRequest(paths, commands []string) fail.Error {
+	for _, v := range paths {
+		if !diagnosticsPathRegexp.MatchString(v) {
+			return fail.InvalidParameterError("paths", "'%s' is not an absolute path made of letters, digits and characters '_.-/*?'", v)
+		}
+	}
+	for _, v := range commands {
+		if strings.TrimSpace(v) == "" {
+			return fail.InvalidParameterError("commands", "cannot contain empty command")
+		}
+		if strings.ContainsAny(v, "\r\n") {
+			return fail.InvalidParameterError("commands", "command '%s' cannot span several lines", v)
+		}
+	}
+	return nil
+}
+
+// diagnosticsScript returns the script that collects on a Host the files matching 'paths' and the outputs of
+// 'commands', in the archive '<workdir>.tar.gz', and displays the size of the archive
+func diagnosticsScript(workdir string, paths, commands []string) string {
+	var b strings.Builder
+	b.WriteString("sudo -n bash -s <<'SAFESCALE_DIAGNOSTICS_EOF'\n")
+	fmt.Fprintf(&b, "d=%s\n", workdir)
+	b.WriteString(`rm -rf "$d" "$d.tar.gz" && mkdir -p "$d/files" "$d/commands" || exit 1` + "\n")
+	if len(paths) > 0 {
+		fmt.Fprintf(&b, `for p in %s; do find "$p" -type f 2>/dev/null; done | while read -r f; do mkdir -p "$d/files$(dirname "$f")" && tail -c %d "$f" >"$d/files$f"; done`+"\n", strings.Join(paths, " "), diagnosticsEntryMaxSize)
+	}
+	for i, v := range commands {
+		// stdin of the commands is not the script
+		fmt.Fprintf(&b, "{ %s\n} </dev/null 2>&1 | tail -c %d >\"$d/commands/%02d.txt\"\n", v, diagnosticsEntryMaxSize, i+1)
+	}
+	b.WriteString(`tar -czf "$d.tar.gz" -C "$d" . || exit 1` + "\n")
+	b.WriteString(`rm -rf "$d" && chown "${SUDO_USER:-root}" "$d.tar.gz" && chmod 0600 "$d.tar.gz" && stat -c %s "$d.tar.gz"` + "\n")
+	b.WriteString("SAFESCALE_DIAGNOSTICS_EOF\n")
+	return b.String()
+}
+
+// diagnosticsBundle gathers the archives of the diagnostics collected on the Hosts, within a maximum total size
+type diagnosticsBundle struct {
+	lock      sync.Mutex
+	remaining uint64
+	archives  map[string][]byte // archive of each Host, indexed by name of Host
+	problems  map[string]string // reason why the diagnostics of a Host are missing, indexed by name (or ID) of Host
+}
+
+func newDiagnosticsBundle(maxSize uint64) *diagnosticsBundle {
+	return &diagnosticsBundle{
+		remaining: maxSize,
+		archives:  map[string][]byte{},
+		problems:  map[string]string{},
+	}
+}
+
+// reserve books 'size' bytes of the bundle; returns false if the bundle cannot hold them
+func (b *diagnosticsBundle) reserve(size uint64) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if size > b.remaining {
+		return false
+	}
+	b.remaining -= size
+	return true
+}
+
+// release gives back 'size' bytes previously reserved
+func (b *diagnosticsBundle) release(size uint64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.remaining += size
+}
+
+func (b *diagnosticsBundle) addArchive(host string, archive []byte) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.archives[host] = archive
+}
+
+func (b *diagnosticsBundle) addProblem(host, format string, args ...interface{}) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.problems[host] = fmt.Sprintf(format, args...)
+}
+
+// listProblems returns the problems as '<host>: <problem>', sorted by Host
+func (b *diagnosticsBundle) listProblems() []string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	list := make([]string, 0, len(b.problems))
+	for k, v := range b.problems {
+		list = append(list, k+": "+v)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// write returns the tar bundle made of the manifest and of the archives of the Hosts, sorted by name of Host
+func (b *diagnosticsBundle) write(clusterName string, paths, commands []string) ([]byte, error) {
+	problems := b.listProblems()
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	hosts := make([]string, 0, len(b.archives))
+	for k := range b.archives {
+		hosts = append(hosts, k)
+	}
+	sort.Strings(hosts)
+
+	var manifest strings.Builder
+	fmt.Fprintf(&manifest, "Cluster: %s\nCollected at: %s\n\nPaths:\n", clusterName, time.Now().UTC().Format(time.RFC3339))
+	for _, v := range paths {
+		fmt.Fprintf(&manifest, "  %s\n", v)
+	}
+	manifest.WriteString("\nCommands (output in commands/<number>.txt):\n")
+	for i, v := range commands {
+		fmt.Fprintf(&manifest, "  %02d: %s\n", i+1, v)
+	}
+	manifest.WriteString("\nHosts collected:\n")
+	for _, v := range hosts {
+		fmt.Fprintf(&manifest, "  %s.tar.gz: %d bytes\n", v, len(b.archives[v]))
+	}
+	if len(problems) > 0 {
+		manifest.WriteString("\nHosts not collected:\n")
+		for _, v := range problems {
+			fmt.Fprintf(&manifest, "  %s\n", v)
+		}
+	}
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	now := time.Now()
+	add := func(name string, content []byte) error {
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), ModTime: now, Typeflag: tar.TypeReg})
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(content)
+		return err
+	}
+	if err := add(diagnosticsManifest, []byte(manifest.String())); err != nil {
+		return nil, err
+	}
+	for _, v := range hosts {
+		if err := add(v+".tar.gz", b.archives[v]); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// StartNodes starts the private nodes of the Cluster whose labels match selector (all the labels of selector
+// must be set on the node with the same value); masters and gateways are never touched
+// Returns the names of the nodes started
+func (instance *Cluster) StartNodes(ctx context.Context, selector map[string]string) (_ []string, xerr fail.Error) {
+	return instance.setSelectedNodesState(ctx, selector, clusternodestate.Started)
+}
+
+// StopNodes stops the private nodes of the Cluster whose labels match selector (all the labels of selector
+// must be set on the node with the same value); masters and gateways are never touched
+// Returns the names of the nodes stopped
+func (instance *Cluster) StopNodes(ctx context.Context, selector map[string]string) (_ []string, xerr fail.Error) {
+	return instance.setSelectedNodesState(ctx, selector, clusternodestate.Stopped)
+}
+
+// setSelectedNodesState does the real work of StartNodes and StopNodes
+// Note: the state of the Cluster is left unchanged, the nodes being stopped on purpose
+func (instance *Cluster) setSelectedNodesState(ctx context.Context, selector map[string]string, state clusternodestate.Enum) (_ []string, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if len(selector) == 0 {
+		return nil, fail.InvalidParameterError("selector", "cannot be empty")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster"), "(%v, %s)", selector, state.String()).Entering()
+	defer tracer.Exiting()
+
+	// make sure no other operation on the same Cluster runs in parallel in the daemon
+	unlockCluster, xerr := lockCluster(ctx, instance)
+	if xerr != nil {
+		return nil, xerr
+	}
+	defer unlockCluster()
+
+	// make sure no other parallel actions interferes
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	clusterState, xerr := instance.unsafeGetState()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if clusterState != clusterstate.Nominal && clusterState != clusterstate.Degraded {
+		return nil, fail.NotAvailableError("failed to change state of nodes of Cluster '%s' because of its current state: %s", instance.GetName(), clusterState.String())
+	}
+
+	var (
+		selected []*propertiesv3.ClusterNode
+		ids      []string
+		names    []string
+	)
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			for _, v := range nodesV3.PrivateNodes {
+				node, found := nodesV3.ByNumericalID[v]
+				if !found || !labelsMatchSelector(nodesV3.Labels[v], selector) {
+					continue
+				}
+
+				selected = append(selected, node)
+				ids = append(ids, node.ID)
+				names = append(names, node.Name)
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if len(selected) == 0 {
+		return []string{}, nil
+	}
+
+	if state == clusternodestate.Stopped {
+		xerr = instance.stopHostsInPhase(task, "selected nodes", ids)
+	} else {
+		xerr = instance.startHostsInPhase(task, "selected nodes", ids)
+	}
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			if nodesV3.States == nil {
+				nodesV3.States = map[uint]clusternodestate.Enum{}
+			}
+			for _, v := range selected {
+				if state == clusternodestate.Started {
+					delete(nodesV3.States, v.NumericalID)
+				} else {
+					nodesV3.States[v.NumericalID] = state
+				}
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	return names, nil
+}
+
+// labelsMatchSelector tells if all the labels of selector are in labels with the same value
+func labelsMatchSelector(labels, selector map[string]string) bool {
+	for k, v := range selector {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// CountNodes counts the nodes of the Cluster
+func (instance *Cluster) CountNodes(ctx context.Context) (count uint, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return 0, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return 0, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return 0, xerr
+	}
+
+	if task.Aborted() {
+		return 0, fail.AbortedError(nil, "aborted")
+	}
+
+	// make sure no other parallel actions interferes
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	xerr = instance.beingRemoved()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return 0, xerr
+	}
+
+	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			count = uint(len(nodesV3.PrivateNodes))
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return 0, xerr
+	}
+
+	return count, nil
+}
+
+// GetNodeByID returns a node based on its ID
+func (instance *Cluster) GetNodeByID(ctx context.Context, hostID string) (hostInstance resources.Host, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if hostID == "" {
+		return nil, fail.InvalidParameterError("hostID", "cannot be empty string")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster"), "(%s)", hostID)
+	defer tracer.Entering().Exiting()
+
+	// make sure no other parallel actions interferes
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	xerr = instance.beingRemoved()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	found := false
+	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			_, found = nodesV3.PrivateNodeByID[hostID]
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if !found {
+		return nil, fail.NotFoundError("failed to find node %s in Cluster '%s'", hostID, instance.GetName())
+	}
+
+	return LoadHost(instance.GetService(), hostID)
+}
+
+// deleteMaster deletes the master specified by its ID
+func (instance *Cluster) deleteMaster(ctx context.Context, host resources.Host) fail.Error {
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+
+	var master *propertiesv3.ClusterNode
+	xerr = instance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			// Removes master from Cluster properties
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			numericalID, found := nodesV3.MasterByID[host.GetID()]
+			if !found {
+				return abstract.ResourceNotFoundError("master", host.GetName())
+			}
+
+			master = nodesV3.ByNumericalID[numericalID]
+			delete(nodesV3.ByNumericalID, numericalID)
+			delete(nodesV3.MasterByName, host.GetName())
+			delete(nodesV3.MasterByID, host.GetID())
+			if found, indexInSlice := containsClusterNode(nodesV3.Masters, numericalID); found {
+				length := len(nodesV3.Masters)
+				if indexInSlice < length-1 {
+					nodesV3.Masters = append(nodesV3.Masters[:indexInSlice], nodesV3.Masters[indexInSlice+1:]...)
+				} else {
+					nodesV3.Masters = nodesV3.Masters[:indexInSlice]
+				}
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// Starting from here, restore master in Cluster properties if exiting with error
+	defer func() {
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			derr := instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+				return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+					nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+					if !ok {
+						return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+					}
+
+					nodesV3.Masters = append(nodesV3.Masters, master.NumericalID)
+					nodesV3.MasterByName[master.Name] = master.NumericalID
+					nodesV3.MasterByID[master.ID] = master.NumericalID
+					nodesV3.ByNumericalID[master.NumericalID] = master
+					return nil
+				})
+			})
+			if derr != nil {
+				_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on %s, failed to restore master '%s' in Cluster metadata", ActionFromError(xerr), master.Name))
+			}
+		}
+	}()
+
+	// Finally delete host
+	xerr = host.Delete(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotFound:
+			// master seems already deleted, so consider it as a success
+			logrus.Tracef("master not found, deletion considered successful")
+		default:
+			return xerr
+		}
+	}
+	return nil
+}
+
+// deleteNode deletes a node identified by its ID
+func (instance *Cluster) deleteNode(ctx context.Context, node *propertiesv3.ClusterNode, master *Host) (xerr fail.Error) {
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).Entering()
+	defer tracer.Exiting()
+
+	nodeRef := node.ID
+	if nodeRef == "" {
+		nodeRef = node.Name
+	}
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+
+	// Identify the node to delete and remove it preventive from metadata
+	xerr = instance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			delete(nodesV3.ByNumericalID, node.NumericalID)
+
+			if found, indexInSlice := containsClusterNode(nodesV3.PrivateNodes, node.NumericalID); found {
+				length := len(nodesV3.PrivateNodes)
+				if indexInSlice < length-1 {
+					nodesV3.PrivateNodes = append(nodesV3.PrivateNodes[:indexInSlice], nodesV3.PrivateNodes[indexInSlice+1:]...)
+				} else {
+					nodesV3.PrivateNodes = nodesV3.PrivateNodes[:indexInSlice]
+				}
+			}
+			delete(nodesV3.PrivateNodeByID, node.ID)
+			delete(nodesV3.PrivateNodeByName, node.Name)
+			delete(nodesV3.Cordoned, node.NumericalID)
+			delete(nodesV3.Labels, node.NumericalID)
+			delete(nodesV3.States, node.NumericalID)
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// Starting from here, restore node in Cluster metadata if exiting with error
+	defer func() {
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			derr := instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+				return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+					nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+					if !ok {
+						return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+					}
+
+					nodesV3.PrivateNodes = append(nodesV3.PrivateNodes, node.NumericalID)
+					if node.Name != "" {
+						nodesV3.PrivateNodeByName[node.Name] = node.NumericalID
+					}
+					if node.ID != "" {
+						nodesV3.PrivateNodeByID[node.ID] = node.NumericalID
+					}
+					nodesV3.ByNumericalID[node.NumericalID] = node
+					return nil
+				})
+			})
+			if derr != nil {
+				logrus.Errorf("failed to restore node ownership in Cluster")
+				_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on %s, failed to restore node ownership in Cluster metadata", ActionFromError(xerr)))
+			}
+		}
+	}()
+
+	// Deletes node
+	return instance.Alter(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+		hostInstance, xerr := LoadHost(instance.GetService(), nodeRef)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+
+		// Leave node from Cluster, if master is not null
+		if master != nil && !master.IsNull() {
+			if innerXErr := instance.leaveNodesFromList([]resources.Host{hostInstance}, master); innerXErr != nil {
+				return innerXErr
+			}
+			if instance.makers.UnconfigureNode != nil {
+				if innerXErr := instance.makers.UnconfigureNode(instance, hostInstance, master); innerXErr != nil {
+					return innerXErr
+				}
+			}
+		}
+
+		// Finally delete host
+		if innerXErr := hostInstance.Delete(ctx); innerXErr != nil {
+			switch innerXErr.(type) {
+			case *fail.ErrNotFound:
+				// host seems already deleted, so it's a success
+			default:
+				return innerXErr
+			}
+		}
+		return nil
+	})
+}
+
+// Delete deletes the Cluster
+func (instance *Cluster) Delete(ctx context.Context, force bool) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	if !force {
+		xerr = instance.beingRemoved()
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+	}
+
+	// make sure no other operation on the same Cluster runs in parallel in the daemon
+	unlockCluster, xerr := lockCluster(ctx, instance)
+	if xerr != nil {
+		return xerr
+	}
+	defer unlockCluster()
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	defer metrics.Measure(metrics.KindOperation, "cluster.delete", instance.GetService().GetProviderName())(&xerr)
+
+	return instance.delete(ctx)
+}
+
+// delete does the work to delete Cluster
+func (instance *Cluster) delete(ctx context.Context) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	tog, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if tog.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	task, err := concurrency.NewTaskGroupWithParent(tog)
+	if err != nil {
+		return err
+	}
+
+	var cleaningErrors []error
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+
+	metadataDeleted := false
+	defer func() {
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil && !metadataDeleted {
+			derr := instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+				return setClusterStateInProperties(props, clusterstate.Degraded, "failed to delete: "+xerr.Error())
+			})
+			if derr != nil {
+				_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on %s, failed to set Cluster state to DEGRADED", ActionFromError(xerr)))
+			}
+		}
+	}()
+
+	var (
+		all            map[uint]*propertiesv3.ClusterNode
+		nodes, masters []uint
+	)
+	// Mark the Cluster as Removed and get nodes from properties
+	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		// Updates Cluster state to mark Cluster as Removing
+		innerXErr := setClusterStateInProperties(props, clusterstate.Removed, "delete requested")
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			nodes = nodesV3.PrivateNodes
+			masters = nodesV3.Masters
+			all = nodesV3.ByNumericalID
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// Deletion of Hosts is bounded in time, so a Host that does not want to die does not block the whole deletion;
+	// in this case, the remaining cleanup is done anyway and the stuck Hosts are reported
+	timeout := temporal.GetClusterHostsDeletionTimeout(ctx)
+	options := []data.ImmutableKeyValue{
+		data.NewImmutableKeyValue("normalizeError", func(err error) error {
+			err = debug.InjectPlannedError(err)
+			if err != nil {
+				switch err.(type) {
+				case *fail.ErrNotFound:
+					return nil
+				default:
+				}
+			}
+			return err
+		}),
+	}
+
+	deletions := make([]clusterHostDeletion, 0, len(masters)+len(nodes))
+	for _, v := range nodes {
+		if n, ok := all[v]; ok {
+			deletions = append(deletions, clusterHostDeletion{node: n, action: instance.taskDeleteNode})
+		}
+	}
+	for _, v := range masters {
+		if n, ok := all[v]; ok {
+			deletions = append(deletions, clusterHostDeletion{node: n, action: instance.taskDeleteMaster})
+		}
+	}
+	stuck, xerr := instance.deleteHostsWithDeadline(task, deletions, timeout, options...)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		cleaningErrors = append(cleaningErrors, xerr)
+		return fail.Wrap(fail.NewErrorList(cleaningErrors), "failed to delete Hosts")
+	}
+
+	// From here, make sure there is nothing in nodesV3.ByNumericalID; if there is something, delete all the remaining
+	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			all = nodesV3.ByNumericalID
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// Hosts already stuck are not tried again
+	stuckNames := make(map[string]struct{}, len(stuck))
+	for _, v := range stuck {
+		stuckNames[v] = struct{}{}
+	}
+	deletions = make([]clusterHostDeletion, 0, len(all))
+	for _, v := range all {
+		if _, ok := stuckNames[v.Name]; !ok {
+			deletions = append(deletions, clusterHostDeletion{node: v, action: instance.taskDeleteNode})
+		}
+	}
+	remainingStuck, xerr := instance.deleteHostsWithDeadline(task, deletions, timeout)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		cleaningErrors = append(cleaningErrors, xerr)
+		return fail.Wrap(fail.NewErrorList(cleaningErrors), "failed to delete Hosts")
+	}
+
+	stuck = append(stuck, remainingStuck...)
+	if len(stuck) > 0 {
+		logrus.Errorf("failed to delete Host%s %s of Cluster '%s' in %s, they have to be deleted manually", strprocess.Plural(uint(len(stuck))), strings.Join(stuck, ", "), instance.GetName(), temporal.FormatDuration(timeout))
+		cleaningErrors = append(cleaningErrors, fail.TimeoutError(nil, timeout, fmt.Sprintf("deletion of Host%s %s did not end in time", strprocess.Plural(uint(len(stuck))), strings.Join(stuck, ", "))))
+	}
+
+	// --- Deletes the Subnets of node pools ---
+	xerr = instance.deleteNodePoolSubnets(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		if len(stuck) == 0 {
+			return xerr
+		}
+		cleaningErrors = append(cleaningErrors, xerr)
+	}
+
+	// --- Deletes the Network, Subnet and gateway ---
+	rn, deleteNetwork, rs, xerr := instance.extractNetworkingInfo(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotFound:
+			// missing Network and Subnet is considered as successful deletion, continue
+		default:
+			return xerr
+		}
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	if rs != nil && !rs.IsNull() {
+		subnetName := rs.GetName()
+		logrus.Debugf("Cluster Deleting Subnet '%s'", subnetName)
+		xerr = retry.WhileUnsuccessfulDelay5SecondsTimeout(
+			func() error {
+				if innerXErr := rs.Delete(ctx); innerXErr != nil {
+					switch innerXErr.(type) {
+					case *fail.ErrNotAvailable:
+						return retry.StopRetryError(innerXErr)
+					case *fail.ErrNotFound:
+						return retry.StopRetryError(innerXErr)
+					default:
+						return innerXErr
+					}
+				}
+				return nil
+			},
+			temporal.GetHostTimeout(),
+		)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrTimeout:
+				xerr = fail.ConvertError(xerr.Cause())
+			case *fail.ErrAborted:
+				xerr = fail.ConvertError(xerr.Cause())
+			}
+		}
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrNotFound:
+				// Subnet not found, consider as a successful deletion and continue
+			default:
+				if len(stuck) == 0 {
+					return fail.Wrap(xerr, "failed to delete Subnet '%s'", subnetName)
+				}
+				cleaningErrors = append(cleaningErrors, fail.Wrap(xerr, "failed to delete Subnet '%s'", subnetName))
+			}
+		}
 	}
 
 	if task.Aborted() {
 		return fail.AbortedError(nil, "aborted")
 	}
 
-	if instance == nil || instance.IsNull() {
-		return fail.InvalidInstanceError()
+	if rn != nil && !rn.IsNull() && deleteNetwork {
+		networkName := rn.GetName()
+		logrus.Debugf("Deleting Network '%s'...", networkName)
+		xerr = retry.WhileUnsuccessfulDelay5SecondsTimeout(
+			func() error {
+				if innerXErr := rn.Delete(ctx); innerXErr != nil {
+					switch innerXErr.(type) {
+					case *fail.ErrNotFound:
+						return retry.StopRetryError(innerXErr)
+					default:
+						return innerXErr
+					}
+				}
+				return nil
+			},
+			temporal.GetHostTimeout(),
+		)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrTimeout:
+				xerr = fail.ConvertError(xerr.Cause())
+			case *fail.ErrAborted:
+				xerr = fail.ConvertError(xerr.Cause())
+			}
+		}
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrNotFound:
+				// network not found, consider as a successful deletion and continue
+			default:
+				logrus.Errorf("Failed to delete Network '%s'", networkName)
+				if len(stuck) == 0 {
+					return fail.Wrap(xerr, "failed to delete Network '%s'", networkName)
+				}
+				cleaningErrors = append(cleaningErrors, fail.Wrap(xerr, "failed to delete Network '%s'", networkName))
+			}
+		} else {
+			logrus.Infof("Network '%s' successfully deleted.", networkName)
+		}
 	}
 
-	var master *propertiesv3.ClusterNode
-	xerr = instance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
-			// Removes master from Cluster properties
-			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+	// --- Delete metadata ---
+	xerr = instance.MetadataCore.Delete()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+	metadataDeleted = true
+
+	// the bucket of the Cluster is left in place, it may be shared or managed by the user
+	xerr = unregisterClusterMetadataBucket(instance.GetService(), instance.GetName())
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		cleaningErrors = append(cleaningErrors, fail.Wrap(xerr, "failed to remove reference to metadata bucket of Cluster"))
+	}
+
+	if len(cleaningErrors) > 0 {
+		return fail.Wrap(fail.NewErrorList(cleaningErrors), "Cluster '%s' deleted, but some of its resources have to be deleted manually", instance.GetName())
+	}
+	return nil
+}
+
+// clusterHostDeletion associates a node of the Cluster with the task action to use to delete it
+type clusterHostDeletion struct {
+	node   *propertiesv3.ClusterNode
+	action concurrency.TaskAction
+}
+
+// deleteHostsWithDeadline deletes in parallel the Hosts of 'deletions', waiting at most 'timeout' for the deletions to end
+// returns the names of the Hosts whose deletion did not end before timeout (these deletions are aborted)
+func (instance *Cluster) deleteHostsWithDeadline(parent concurrency.Task, deletions []clusterHostDeletion, timeout time.Duration, options ...data.ImmutableKeyValue) (stuck []string, xerr fail.Error) {
+	if len(deletions) == 0 {
+		return nil, nil
+	}
+
+	tg, xerr := concurrency.NewTaskGroupWithParent(parent)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	var (
+		errors  []error
+		started []*propertiesv3.ClusterNode
+		doneMu  sync.Mutex
+	)
+	done := make(map[uint]bool, len(deletions))
+	for _, v := range deletions {
+		d := v
+		_, xerr = tg.StartInSubtask(func(t concurrency.Task, p concurrency.TaskParameters) (concurrency.TaskResult, fail.Error) {
+			defer func() {
+				doneMu.Lock()
+				done[d.node.NumericalID] = true
+				doneMu.Unlock()
+			}()
+
+			return d.action(t, p)
+		}, taskDeleteNodeParameters{node: d.node}, options...)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			errors = append(errors, fail.Wrap(xerr, "failed to start deletion of Host '%s'", d.node.Name))
+			break
+		}
+		started = append(started, d.node)
+	}
+
+	if len(started) > 0 {
+		_, _, xerr = tg.WaitGroupFor(timeout)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrTimeout:
+				doneMu.Lock()
+				for _, v := range started {
+					if !done[v.NumericalID] {
+						stuck = append(stuck, v.Name)
+					}
+				}
+				doneMu.Unlock()
+			default:
+				errors = append(errors, xerr)
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return stuck, fail.NewErrorList(errors)
+	}
+	return stuck, nil
+}
+
+// extractNetworkingInfo returns the ID of the network from properties, taking care of ascending compatibility
+func (instance *Cluster) extractNetworkingInfo(ctx context.Context) (networkInstance resources.Network, deleteNetwork bool, subnetInstance resources.Subnet, xerr fail.Error) {
+	networkInstance, subnetInstance = nil, nil
+	deleteNetwork = false
+	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) (innerXErr fail.Error) {
+		return props.Inspect(clusterproperty.NetworkV3, func(clonable data.Clonable) (innerXErr fail.Error) {
+			networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
 			if !ok {
-				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			numericalID, found := nodesV3.MasterByID[host.GetID()]
-			if !found {
-				return abstract.ResourceNotFoundError("master", host.GetName())
+			if networkV3.SubnetID != "" && !networkV3.ExternalGateway {
+				if subnetInstance, innerXErr = LoadSubnet(instance.GetService(), networkV3.NetworkID, networkV3.SubnetID); innerXErr != nil {
+					return innerXErr
+				}
 			}
 
-			master = nodesV3.ByNumericalID[numericalID]
-			delete(nodesV3.ByNumericalID, numericalID)
-			delete(nodesV3.MasterByName, host.GetName())
-			delete(nodesV3.MasterByID, host.GetID())
-			if found, indexInSlice := containsClusterNode(nodesV3.Masters, numericalID); found {
-				length := len(nodesV3.Masters)
-				if indexInSlice < length-1 {
-					nodesV3.Masters = append(nodesV3.Masters[:indexInSlice], nodesV3.Masters[indexInSlice+1:]...)
-				} else {
-					nodesV3.Masters = nodesV3.Masters[:indexInSlice]
+			if networkV3.NetworkID != "" {
+				networkInstance, innerXErr = LoadNetwork(instance.GetService(), networkV3.NetworkID)
+				if innerXErr != nil {
+					return innerXErr
+				}
+				deleteNetwork = networkV3.CreatedNetwork
+			}
+			// Subnet of an existing gateway is not owned by the Cluster
+			if networkV3.SubnetID != "" && !networkV3.ExternalGateway {
+				subnetInstance, innerXErr = LoadSubnet(instance.GetService(), networkV3.NetworkID, networkV3.SubnetID)
+				if innerXErr != nil {
+					return innerXErr
+				}
+				if networkInstance == nil {
+					networkInstance, innerXErr = subnetInstance.InspectNetwork()
+					if innerXErr != nil {
+						return innerXErr
+					}
 				}
+				deleteNetwork = networkV3.CreatedNetwork
 			}
+
 			return nil
 		})
 	})
 	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, deleteNetwork, NullSubnet(), xerr
+	}
+
+	return networkInstance, deleteNetwork, subnetInstance, nil
+}
+
+func containsClusterNode(list []uint, numericalID uint) (bool, int) {
+	var idx int
+	found := false
+	for i, v := range list {
+		if v == numericalID {
+			found = true
+			idx = i
+			break
+		}
+	}
+	return found, idx
+}
+
+// configureCluster ...
+// params contains a data.Map with primary and secondary getGateway hosts
+func (instance *Cluster) configureCluster(ctx context.Context) (xerr fail.Error) {
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return xerr
 	}
 
-	// Starting from here, restore master in Cluster properties if exiting with error
-	defer func() {
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			derr := instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-				return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
-					nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
-					if !ok {
-						return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
-					}
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).Entering()
+	defer tracer.Exiting()
 
-					nodesV3.Masters = append(nodesV3.Masters, master.NumericalID)
-					nodesV3.MasterByName[master.Name] = master.NumericalID
-					nodesV3.MasterByID[master.ID] = master.NumericalID
-					nodesV3.ByNumericalID[master.NumericalID] = master
-					return nil
-				})
-			})
-			if derr != nil {
-				_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on %s, failed to restore master '%s' in Cluster metadata", ActionFromError(xerr), master.Name))
-			}
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	logrus.Infof("[Cluster %s] configuring Cluster...", instance.GetName())
+	defer func() {
+		if xerr == nil {
+			logrus.Infof("[Cluster %s] configuration successful.", instance.GetName())
+		} else {
+			logrus.Errorf("[Cluster %s] configuration failed: %s", instance.GetName(), xerr.Error())
 		}
 	}()
 
-	// Finally delete host
-	xerr = host.Delete(ctx)
+	// Install reverseproxy feature on Cluster (gateways)
+	xerr = instance.installDefaultFeature(ctx, "reverseproxy", instance.installReverseProxy)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		switch xerr.(type) {
-		case *fail.ErrNotFound:
-			// master seems already deleted, so consider it as a success
-			logrus.Tracef("master not found, deletion considered successful")
-		default:
-			return xerr
-		}
+		return xerr
 	}
+
+	// Install remotedesktop feature on Cluster (all masters)
+	xerr = instance.installDefaultFeature(ctx, "remotedesktop", instance.installRemoteDesktop)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// configure what has to be done Cluster-wide
+	if instance.makers.ConfigureCluster != nil {
+		return instance.makers.ConfigureCluster(ctx, instance)
+	}
+
+	// Not finding a callback isn't an error, so return nil in this case
 	return nil
 }
 
-// deleteNode deletes a node identified by its ID
-func (instance *Cluster) deleteNode(ctx context.Context, node *propertiesv3.ClusterNode, master *Host) (xerr fail.Error) {
+// minimumNodesToKeep returns the minimum number of nodes the Cluster must keep when removing nodes, as required by its
+// flavor; returns 0 if option "AllowBelowMinimum" (bool) is set to true
+func (instance *Cluster) minimumNodesToKeep(options ...data.ImmutableKeyValue) (uint, fail.Error) {
+	for _, v := range options {
+		switch v.Key() {
+		case "AllowBelowMinimum":
+			if v.Value().(bool) {
+				return 0, nil
+			}
+		}
+	}
+
+	_, minimumNodes, _, xerr := instance.determineRequiredNodes()
+	if xerr != nil {
+		return 0, xerr
+	}
+	return minimumNodes, nil
+}
+
+// checkMinimumNodes verifies that removing 'count' nodes out of 'current' leaves at least 'minimum' nodes in Cluster
+func checkMinimumNodes(clusterName string, current, count, minimum uint) fail.Error {
+	if current < count || current-count >= minimum {
+		return nil
+	}
+
+	remaining := current - count
+	return fail.InvalidRequestError("cannot remove %d node%s from Cluster '%s': %d node%s would remain out of %d, below the minimum of %d required by its flavor (use AllowBelowMinimum to force)",
+		count, strprocess.Plural(count), clusterName, remaining, strprocess.Plural(remaining), current, minimum)
+}
+
+// ResizeGateways resizes the gateways of the Cluster to satisfy 'def', without recreating them
+// With a secondary gateway (HA setup), the secondary gateway is resized first and must be reachable again before the
+// primary one is resized, so the Virtual IP fails over to a working gateway while the primary one is unavailable.
+// 'def' must meet the minimum sizing of gateways of the flavor of the Cluster.
+func (instance *Cluster) ResizeGateways(ctx context.Context, def abstract.HostSizingRequirements) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
 	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
@@ -2227,38 +4434,50 @@ func (instance *Cluster) deleteNode(ctx context.Context, node *propertiesv3.Clus
 		return fail.AbortedError(nil, "aborted")
 	}
 
-	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).Entering()
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster"), "(%v)", def).WithStopwatch().Entering()
 	defer tracer.Exiting()
 
-	nodeRef := node.ID
-	if nodeRef == "" {
-		nodeRef = node.Name
+	// make sure no other operation on the same Cluster runs in parallel in the daemon
+	unlockCluster, xerr := lockCluster(ctx, instance)
+	if xerr != nil {
+		return xerr
+	}
+	defer unlockCluster()
+
+	// make sure no other parallel actions interferes
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	defer recordOperation(instance, clusterproperty.LastOperationV1, "resize-gateways")(&xerr)
+
+	clusterState, xerr := instance.unsafeGetState()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+	if clusterState != clusterstate.Nominal && clusterState != clusterstate.Degraded {
+		return fail.NotAvailableError("failed to resize gateways of Cluster '%s' because of its current state: %s", instance.GetName(), clusterState.String())
 	}
 
-	if instance == nil || instance.IsNull() {
-		return fail.InvalidInstanceError()
+	if instance.makers.DefaultGatewaySizing != nil {
+		if xerr = checkGatewaySizing(def, instance.makers.DefaultGatewaySizing(instance)); xerr != nil {
+			return xerr
+		}
 	}
 
-	// Identify the node to delete and remove it preventive from metadata
-	xerr = instance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
-			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+	var primaryID, secondaryID string
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NetworkV3, func(clonable data.Clonable) fail.Error {
+			networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
 			if !ok {
-				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			delete(nodesV3.ByNumericalID, node.NumericalID)
-
-			if found, indexInSlice := containsClusterNode(nodesV3.PrivateNodes, node.NumericalID); found {
-				length := len(nodesV3.PrivateNodes)
-				if indexInSlice < length-1 {
-					nodesV3.PrivateNodes = append(nodesV3.PrivateNodes[:indexInSlice], nodesV3.PrivateNodes[indexInSlice+1:]...)
-				} else {
-					nodesV3.PrivateNodes = nodesV3.PrivateNodes[:indexInSlice]
-				}
+			if networkV3.ExternalGateway {
+				return fail.InvalidRequestError("cannot resize gateway of Cluster '%s': it is an existing gateway not owned by the Cluster", instance.GetName())
 			}
-			delete(nodesV3.PrivateNodeByID, node.ID)
-			delete(nodesV3.PrivateNodeByName, node.Name)
+			primaryID = networkV3.GatewayID
+			secondaryID = networkV3.SecondaryGatewayID
 			return nil
 		})
 	})
@@ -2266,244 +4485,400 @@ func (instance *Cluster) deleteNode(ctx context.Context, node *propertiesv3.Clus
 	if xerr != nil {
 		return xerr
 	}
+	if primaryID == "" {
+		return fail.InconsistentError("no primary gateway registered for Cluster '%s'", instance.GetName())
+	}
 
-	// Starting from here, restore node in Cluster metadata if exiting with error
+	svc := instance.GetService()
+	resized := map[string]resources.Host{}
 	defer func() {
+		for _, v := range resized {
+			v.Released()
+		}
+	}()
+	// secondary gateway first, the primary one carrying the Virtual IP meanwhile
+	for _, id := range []string{secondaryID, primaryID} {
+		if id == "" {
+			continue
+		}
+		if task.Aborted() {
+			return fail.AbortedError(nil, "aborted")
+		}
+
+		gw, xerr := LoadHost(svc, id)
 		xerr = debug.InjectPlannedFail(xerr)
 		if xerr != nil {
-			derr := instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-				return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
-					nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
-					if !ok {
-						return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
-					}
+			return xerr
+		}
+		resized[id] = gw
 
-					nodesV3.PrivateNodes = append(nodesV3.PrivateNodes, node.NumericalID)
-					if node.Name != "" {
-						nodesV3.PrivateNodeByName[node.Name] = node.NumericalID
-					}
-					if node.ID != "" {
-						nodesV3.PrivateNodeByID[node.ID] = node.NumericalID
-					}
-					nodesV3.ByNumericalID[node.NumericalID] = node
-					return nil
-				})
-			})
-			if derr != nil {
-				logrus.Errorf("failed to restore node ownership in Cluster")
-				_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on %s, failed to restore node ownership in Cluster metadata", ActionFromError(xerr)))
-			}
+		xerr = gw.Resize(ctx, def)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return fail.Wrap(xerr, "failed to resize gateway '%s' of Cluster '%s'", gw.GetName(), instance.GetName())
 		}
-	}()
 
-	// Deletes node
-	return instance.Alter(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
-		hostInstance, xerr := LoadHost(instance.GetService(), nodeRef)
+		// the gateway must be back before the other one becomes unavailable
+		_, xerr = gw.WaitSSHReady(ctx, temporal.GetHostTimeout())
 		xerr = debug.InjectPlannedFail(xerr)
 		if xerr != nil {
-			return xerr
+			return fail.Wrap(xerr, "gateway '%s' of Cluster '%s' is not available after resize", gw.GetName(), instance.GetName())
 		}
+		logrus.Infof("gateway '%s' of Cluster '%s' resized", gw.GetName(), instance.GetName())
+	}
 
-		// Leave node from Cluster, if master is not null
-		if master != nil && !master.IsNull() {
-			if innerXErr := instance.leaveNodesFromList([]resources.Host{hostInstance}, master); innerXErr != nil {
-				return innerXErr
-			}
-			if instance.makers.UnconfigureNode != nil {
-				if innerXErr := instance.makers.UnconfigureNode(instance, hostInstance, master); innerXErr != nil {
-					return innerXErr
-				}
+	return instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		innerXErr := props.Alter(clusterproperty.DefaultsV2, func(clonable data.Clonable) fail.Error {
+			defaultsV2, ok := clonable.(*propertiesv2.ClusterDefaults)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.ClusterDefaults' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
+
+			defaultsV2.GatewaySizing = *converters.HostSizingRequirementsFromAbstractToPropertyV2(def)
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
 		}
 
-		// Finally delete host
-		if innerXErr := hostInstance.Delete(ctx); innerXErr != nil {
-			switch innerXErr.(type) {
-			case *fail.ErrNotFound:
-				// host seems already deleted, so it's a success
-			default:
-				return innerXErr
+		// IP addresses of gateways may have changed with the resize on some providers
+		return props.Alter(clusterproperty.NetworkV3, func(clonable data.Clonable) fail.Error {
+			networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
-		}
-		return nil
+
+			if gw, ok := resized[primaryID]; ok {
+				if ip, _ := gw.GetPrivateIP(); ip != "" {
+					networkV3.GatewayIP = ip
+				}
+				if ip, _ := gw.GetPublicIP(); ip != "" {
+					networkV3.PrimaryPublicIP = ip
+				}
+			}
+			if gw, ok := resized[secondaryID]; ok {
+				if ip, _ := gw.GetPrivateIP(); ip != "" {
+					networkV3.SecondaryGatewayIP = ip
+				}
+				if ip, _ := gw.GetPublicIP(); ip != "" {
+					networkV3.SecondaryPublicIP = ip
+				}
+			}
+			return nil
+		})
 	})
 }
 
-// Delete deletes the Cluster
-func (instance *Cluster) Delete(ctx context.Context, force bool) (xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
+// clusterDomainRegexp matches a DNS domain made of labels of letters, digits and hyphens (RFC 1123)
+var clusterDomainRegexp = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)*[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
 
-	if instance == nil || instance.IsNull() {
-		return fail.InvalidInstanceError()
+// normalizeClusterDomain returns the domain in lower case without leading and trailing dots, validating its format
+func normalizeClusterDomain(domain string) (string, fail.Error) {
+	domain = strings.ToLower(strings.Trim(strings.TrimSpace(domain), "."))
+	if domain == "" {
+		return "", fail.InvalidParameterError("domain", "cannot be empty string")
 	}
-	if ctx == nil {
-		return fail.InvalidParameterCannotBeNilError("ctx")
+	if len(domain) > 253 || !clusterDomainRegexp.MatchString(domain) {
+		return "", fail.InvalidParameterError("domain", "'%s' is not a valid DNS domain", domain)
 	}
+	return domain, nil
+}
 
-	if !force {
-		xerr = instance.beingRemoved()
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			return xerr
-		}
-	}
+// clusterDomainMarker delimits in /etc/hosts the entries of the Hosts of the Cluster managed by SafeScale
+const clusterDomainMarker = "SafeScale cluster domain"
 
-	instance.lock.Lock()
-	defer instance.lock.Unlock()
+// clusterDomainEntry associates the name of a Host of the Cluster with its private IP
+type clusterDomainEntry struct {
+	name string
+	ip   string
+}
 
-	return instance.delete(ctx)
+// buildClusterDomainScript returns the script making a Host of the Cluster use 'domain' as search domain and resolve
+// each Host of 'entries' as <name>.<domain>, replacing what a previous run may have set
+func buildClusterDomainScript(domain string, entries []clusterDomainEntry) string {
+	var hosts strings.Builder
+	for _, v := range entries {
+		hosts.WriteString(fmt.Sprintf("%s\t%s.%s %s\n", v.ip, v.name, domain, v.name))
+	}
+
+	return fmt.Sprintf(`set -e
+if systemctl status systemd-resolved &>/dev/null; then
+	sed -i '/^Domains=/d' /etc/systemd/resolved.conf
+	sed -i '/^\[Resolve\]/a Domains=%[1]s' /etc/systemd/resolved.conf
+	systemctl restart systemd-resolved
+elif [ -d /etc/resolvconf/resolv.conf.d ]; then
+	touch /etc/resolvconf/resolv.conf.d/head
+	sed -i '/^search /d' /etc/resolvconf/resolv.conf.d/head
+	echo "search %[1]s" >>/etc/resolvconf/resolv.conf.d/head
+	resolvconf -u
+else
+	sed -i '/^search /d' /etc/resolv.conf
+	sed -i '1i search %[1]s' /etc/resolv.conf
+fi
+sed -i '/^# BEGIN %[2]s$/,/^# END %[2]s$/d' /etc/hosts
+cat <<'EOF' >>/etc/hosts
+# BEGIN %[2]s
+%[3]s# END %[2]s
+EOF
+`, domain, clusterDomainMarker, hosts.String())
 }
 
-// delete does the work to delete Cluster
-func (instance *Cluster) delete(ctx context.Context) (xerr fail.Error) {
+// SetDomain changes the DNS domain of the Cluster; the domain is recorded in metadata, then the gateways, masters and
+// nodes are configured to use it as search domain and to resolve each Host of the Cluster as <host>.<domain>
+// The hostnames of existing Hosts are not changed; Hosts created afterwards get <host>.<domain> as hostname
+func (instance *Cluster) SetDomain(ctx context.Context, domain string) (xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
-	tog, xerr := concurrency.TaskFromContext(ctx)
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	domain, xerr = normalizeClusterDomain(domain)
+	if xerr != nil {
+		return xerr
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return xerr
 	}
 
-	if tog.Aborted() {
+	if task.Aborted() {
 		return fail.AbortedError(nil, "aborted")
 	}
 
-	task, err := concurrency.NewTaskGroupWithParent(tog)
-	if err != nil {
-		return err
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster"), "('%s')", domain).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	// make sure no other operation on the same Cluster runs in parallel in the daemon
+	unlockCluster, xerr := lockCluster(ctx, instance)
+	if xerr != nil {
+		return xerr
 	}
+	defer unlockCluster()
 
-	var cleaningErrors []error
+	// make sure no other parallel actions interferes
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
 
-	if instance == nil || instance.IsNull() {
-		return fail.InvalidInstanceError()
-	}
+	defer recordOperation(instance, clusterproperty.LastOperationV1, "set-domain")(&xerr)
 
-	defer func() {
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			derr := instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-				return props.Alter(clusterproperty.StateV1, func(clonable data.Clonable) fail.Error {
-					stateV1, ok := clonable.(*propertiesv1.ClusterState)
-					if !ok {
-						return fail.InconsistentError("'*propertiesv1.ClusterState' expected, '%s' provided", reflect.TypeOf(clonable).String())
-					}
+	clusterState, xerr := instance.unsafeGetState()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+	if clusterState != clusterstate.Nominal && clusterState != clusterstate.Degraded {
+		return fail.NotAvailableError("failed to set domain of Cluster '%s' because of its current state: %s", instance.GetName(), clusterState.String())
+	}
 
-					stateV1.State = clusterstate.Degraded
-					return nil
-				})
-			})
-			if derr != nil {
-				_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on %s, failed to set Cluster state to DEGRADED", ActionFromError(xerr)))
+	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(clusterproperty.NetworkV3, func(clonable data.Clonable) fail.Error {
+			networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
-		}
-	}()
 
+			networkV3.Domain = domain
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	return instance.unsafeConfigureDomain(ctx, domain)
+}
+
+// unsafeConfigureDomain configures the domain on all the Hosts of the Cluster
+func (instance *Cluster) unsafeConfigureDomain(ctx context.Context, domain string) fail.Error {
+	svc := instance.GetService()
 	var (
-		all            map[uint]*propertiesv3.ClusterNode
-		nodes, masters []uint
+		gatewayIDs []string
+		members    []*propertiesv3.ClusterNode
 	)
-	// Mark the Cluster as Removed and get nodes from properties
-	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		// Updates Cluster state to mark Cluster as Removing
-		innerXErr := props.Alter(clusterproperty.StateV1, func(clonable data.Clonable) fail.Error {
-			stateV1, ok := clonable.(*propertiesv1.ClusterState)
+	xerr := instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		innerXErr := props.Inspect(clusterproperty.NetworkV3, func(clonable data.Clonable) fail.Error {
+			networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
 			if !ok {
-				return fail.InconsistentError("'*propertiesv1.ClusterState' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			stateV1.State = clusterstate.Removed
+			for _, v := range []string{networkV3.GatewayID, networkV3.SecondaryGatewayID} {
+				if v != "" {
+					gatewayIDs = append(gatewayIDs, v)
+				}
+			}
 			return nil
 		})
 		if innerXErr != nil {
 			return innerXErr
 		}
 
-		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
-			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			for _, list := range [][]uint{nodesV3.Masters, nodesV3.PrivateNodes} {
+				for _, v := range list {
+					if node, found := nodesV3.ByNumericalID[v]; found && node.ID != "" {
+						members = append(members, node)
+					}
+				}
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	hosts := make([]resources.Host, 0, len(gatewayIDs)+len(members))
+	defer func() {
+		for _, v := range hosts {
+			v.Released()
+		}
+	}()
+	entries := make([]clusterDomainEntry, 0, cap(hosts))
+	for _, id := range gatewayIDs {
+		gw, xerr := LoadHost(svc, id)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+		hosts = append(hosts, gw)
+
+		ip, xerr := gw.GetPrivateIP()
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+		entries = append(entries, clusterDomainEntry{name: gw.GetName(), ip: ip})
+	}
+	for _, v := range members {
+		host, xerr := LoadHost(svc, v.ID)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+		hosts = append(hosts, host)
+		entries = append(entries, clusterDomainEntry{name: v.Name, ip: v.PrivateIP})
+	}
+
+	script := buildClusterDomainScript(domain, entries)
+	var errors []error
+	for _, v := range hosts {
+		retcode, stdout, stderr, xerr := v.RunWithStdin(ctx, "sudo bash -s", strings.NewReader(script), outputs.COLLECT, temporal.GetConnectionTimeout(), temporal.GetExecutionTimeout())
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			errors = append(errors, fail.Wrap(xerr, "failed to configure domain on Host '%s'", v.GetName()))
+			continue
+		}
+		if retcode != 0 {
+			xerr = fail.ExecutionError(nil, "failed to configure domain on Host '%s'", v.GetName())
+			_ = xerr.Annotate("retcode", retcode).Annotate("stdout", stdout).Annotate("stderr", stderr)
+			errors = append(errors, xerr)
+		}
+	}
+	if len(errors) > 0 {
+		return fail.NewErrorList(errors)
+	}
+
+	logrus.Infof("domain '%s' configured on the %d Hosts of Cluster '%s'", domain, len(hosts), instance.GetName())
+	return nil
+}
+
+// clusterNameTagKey is the key of the tag identifying the Cluster owning a resource in the provider
+const clusterNameTagKey = "safescale-cluster"
+
+// buildClusterTags returns the tags to set on the resources of the Cluster named 'clusterName': 'tags' completed with
+// the tag identifying the Cluster, that cannot be overridden
+func buildClusterTags(clusterName string, tags map[string]string) (map[string]string, fail.Error) {
+	out := make(map[string]string, len(tags)+1)
+	for k, v := range tags {
+		if k = strings.TrimSpace(k); k == "" {
+			return nil, fail.InvalidParameterError("tags", "cannot contain a tag with an empty key")
+		}
+		out[k] = v
+	}
+	out[clusterNameTagKey] = clusterName
+	return out, nil
+}
+
+// RetagResources sets again the tags of the Cluster on its resources in the provider (Network if created with the
+// Cluster, Subnets, gateways, masters and nodes), after merging 'tags' in the tags recorded in metadata
+// With empty 'tags', only re-applies the recorded tags, to tag resources the provider failed to tag or that were tagged
+// before the tags of the Cluster were changed
+func (instance *Cluster) RetagResources(ctx context.Context, tags map[string]string) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
 
-			nodes = nodesV3.PrivateNodes
-			masters = nodesV3.Masters
-			all = nodesV3.ByNumericalID
-			return nil
-		})
-	})
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return xerr
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
 	}
 
-	// Note: we are not using a TaskGroup here because we need to filter ErrNotFound for each Host (a missing Host is considered a successful deletion)
-	// TaskGroup, as currently designed, does not allow that
-	masterCount, nodeCount := len(masters), len(nodes)
-	//	subtasks := make([]concurrency.Task, 0, masterCount+nodeCount)
-	tg, xerr := concurrency.NewTaskGroupWithParent(task)
+	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return xerr
 	}
 
-	options := []data.ImmutableKeyValue{
-		data.NewImmutableKeyValue("normalizeError", func(err error) error {
-			err = debug.InjectPlannedError(err)
-			if err != nil {
-				switch err.(type) {
-				case *fail.ErrNotFound:
-					return nil
-				default:
-				}
-			}
-			return err
-		}),
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
 	}
 
-	if nodeCount > 0 {
-		for _, v := range nodes {
-			if n, ok := all[v]; ok {
-				_, xerr = tg.StartInSubtask(instance.taskDeleteNode, taskDeleteNodeParameters{node: n}, options...)
-				xerr = debug.InjectPlannedFail(xerr)
-				if xerr != nil {
-					cleaningErrors = append(cleaningErrors, fail.Wrap(xerr, "failed to start deletion of Host '%s'", n.Name))
-					break
-				}
-			}
-		}
-	}
-	if masterCount > 0 {
-		for _, v := range masters {
-			if n, ok := all[v]; ok {
-				_, xerr := tg.StartInSubtask(instance.taskDeleteMaster, taskDeleteNodeParameters{node: n}, options...)
-				xerr = debug.InjectPlannedFail(xerr)
-				if xerr != nil {
-					cleaningErrors = append(cleaningErrors, fail.Wrap(xerr, "failed to start deletion of Host '%s'", n.Name))
-					break
-				}
-			}
-		}
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster"), "(%v)", tags).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	// make sure no other operation on the same Cluster runs in parallel in the daemon
+	unlockCluster, xerr := lockCluster(ctx, instance)
+	if xerr != nil {
+		return xerr
 	}
-	_, xerr = tg.WaitGroup()
+	defer unlockCluster()
+
+	// make sure no other parallel actions interferes
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	defer recordOperation(instance, clusterproperty.LastOperationV1, "retag")(&xerr)
+
+	clusterState, xerr := instance.unsafeGetState()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		cleaningErrors = append(cleaningErrors, xerr)
+		return xerr
 	}
-
-	if len(cleaningErrors) > 0 {
-		return fail.Wrap(fail.NewErrorList(cleaningErrors), "failed to delete Hosts")
+	if clusterState == clusterstate.Creating || clusterState == clusterstate.Removed {
+		return fail.NotAvailableError("failed to tag resources of Cluster '%s' because of its current state: %s", instance.GetName(), clusterState.String())
 	}
 
-	// From here, make sure there is nothing in nodesV3.ByNumericalID; if there is something, delete all the remaining
+	clusterName := instance.GetName()
 	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
-			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+		return props.Alter(clusterproperty.TagsV1, func(clonable data.Clonable) fail.Error {
+			tagsV1, ok := clonable.(*propertiesv1.ClusterTags)
 			if !ok {
-				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				return fail.InconsistentError("'*propertiesv1.ClusterTags' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			all = nodesV3.ByNumericalID
-			return nil
+			merged := make(map[string]string, len(tagsV1.Tags)+len(tags))
+			for k, v := range tagsV1.Tags {
+				merged[k] = v
+			}
+			for k, v := range tags {
+				merged[k] = v
+			}
+			var innerXErr fail.Error
+			tagsV1.Tags, innerXErr = buildClusterTags(clusterName, merged)
+			return innerXErr
 		})
 	})
 	xerr = debug.InjectPlannedFail(xerr)
@@ -2511,242 +4886,183 @@ func (instance *Cluster) delete(ctx context.Context) (xerr fail.Error) {
 		return xerr
 	}
 
-	allCount := len(all)
-	tg, xerr = concurrency.NewTaskGroupWithParent(task)
+	var errors []error
+	xerr = instance.tagNetworkingResources()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return xerr
+		errors = append(errors, xerr)
 	}
 
-	if allCount > 0 {
-		for _, v := range all {
-			_, xerr = tg.StartInSubtask(instance.taskDeleteNode, taskDeleteNodeParameters{node: v})
-			xerr = debug.InjectPlannedFail(xerr)
-			if xerr != nil {
-				cleaningErrors = append(cleaningErrors, fail.Wrap(xerr, "failed to start deletion of Host '%s'", v.Name))
-				break
+	var members []*propertiesv3.ClusterNode
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
-		}
-	}
 
-	_, xerr = tg.WaitGroup()
+			for _, list := range [][]uint{nodesV3.Masters, nodesV3.PrivateNodes} {
+				for _, v := range list {
+					if node, found := nodesV3.ByNumericalID[v]; found && node.ID != "" {
+						members = append(members, node)
+					}
+				}
+			}
+			return nil
+		})
+	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		cleaningErrors = append(cleaningErrors, xerr)
-	}
-	if len(cleaningErrors) > 0 {
-		return fail.Wrap(fail.NewErrorList(cleaningErrors), "failed to delete Hosts")
+		return xerr
 	}
 
-	// --- Deletes the Network, Subnet and gateway ---
-	rn, deleteNetwork, rs, xerr := instance.extractNetworkingInfo(ctx)
+	recordedTags, xerr := instance.getTags()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		switch xerr.(type) {
-		case *fail.ErrNotFound:
-			// missing Network and Subnet is considered as successful deletion, continue
-		default:
-			return xerr
-		}
-	}
-
-	if task.Aborted() {
-		return fail.AbortedError(nil, "aborted")
+		return xerr
 	}
 
-	if rs != nil && !rs.IsNull() {
-		subnetName := rs.GetName()
-		logrus.Debugf("Cluster Deleting Subnet '%s'", subnetName)
-		xerr = retry.WhileUnsuccessfulDelay5SecondsTimeout(
-			func() error {
-				if innerXErr := rs.Delete(ctx); innerXErr != nil {
-					switch innerXErr.(type) {
-					case *fail.ErrNotAvailable:
-						return retry.StopRetryError(innerXErr)
-					case *fail.ErrNotFound:
-						return retry.StopRetryError(innerXErr)
-					default:
-						return innerXErr
-					}
-				}
-				return nil
-			},
-			temporal.GetHostTimeout(),
-		)
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			switch xerr.(type) {
-			case *fail.ErrTimeout:
-				xerr = fail.ConvertError(xerr.Cause())
-			case *fail.ErrAborted:
-				xerr = fail.ConvertError(xerr.Cause())
-			}
-		}
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			switch xerr.(type) {
-			case *fail.ErrNotFound:
-				// Subnet not found, consider as a successful deletion and continue
-			default:
-				return fail.Wrap(xerr, "failed to delete Subnet '%s'", subnetName)
-			}
+	for _, v := range members {
+		if task.Aborted() {
+			return fail.AbortedError(nil, "aborted")
 		}
-	}
-
-	if task.Aborted() {
-		return fail.AbortedError(nil, "aborted")
-	}
 
-	if rn != nil && !rn.IsNull() && deleteNetwork {
-		networkName := rn.GetName()
-		logrus.Debugf("Deleting Network '%s'...", networkName)
-		xerr = retry.WhileUnsuccessfulDelay5SecondsTimeout(
-			func() error {
-				if innerXErr := rn.Delete(ctx); innerXErr != nil {
-					switch innerXErr.(type) {
-					case *fail.ErrNotFound:
-						return retry.StopRetryError(innerXErr)
-					default:
-						return innerXErr
-					}
-				}
-				return nil
-			},
-			temporal.GetHostTimeout(),
-		)
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			switch xerr.(type) {
-			case *fail.ErrTimeout:
-				xerr = fail.ConvertError(xerr.Cause())
-			case *fail.ErrAborted:
-				xerr = fail.ConvertError(xerr.Cause())
-			}
-		}
+		xerr = instance.tagResource(taggableresource.Host, v.ID, v.Name, recordedTags)
 		xerr = debug.InjectPlannedFail(xerr)
 		if xerr != nil {
-			switch xerr.(type) {
-			case *fail.ErrNotFound:
-				// network not found, consider as a successful deletion and continue
-			default:
-				logrus.Errorf("Failed to delete Network '%s'", networkName)
-				return fail.Wrap(xerr, "failed to delete Network '%s'", networkName)
-			}
+			errors = append(errors, xerr)
 		}
-		logrus.Infof("Network '%s' successfully deleted.", networkName)
+	}
+	if len(errors) > 0 {
+		return fail.NewErrorList(errors)
 	}
 
-	// --- Delete metadata ---
-	return instance.MetadataCore.Delete()
+	logrus.Infof("resources of Cluster '%s' successfully tagged", clusterName)
+	return nil
 }
 
-// extractNetworkingInfo returns the ID of the network from properties, taking care of ascending compatibility
-func (instance *Cluster) extractNetworkingInfo(ctx context.Context) (networkInstance resources.Network, deleteNetwork bool, subnetInstance resources.Subnet, xerr fail.Error) {
-	networkInstance, subnetInstance = nil, nil
-	deleteNetwork = false
-	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) (innerXErr fail.Error) {
-		return props.Inspect(clusterproperty.NetworkV3, func(clonable data.Clonable) (innerXErr fail.Error) {
-			networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
+// getTags returns the tags of the Cluster recorded in metadata, always containing the tag identifying the Cluster
+func (instance *Cluster) getTags() (map[string]string, fail.Error) {
+	var tags map[string]string
+	xerr := instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.TagsV1, func(clonable data.Clonable) fail.Error {
+			tagsV1, ok := clonable.(*propertiesv1.ClusterTags)
 			if !ok {
-				return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				return fail.InconsistentError("'*propertiesv1.ClusterTags' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			if networkV3.SubnetID != "" {
-				if subnetInstance, innerXErr = LoadSubnet(instance.GetService(), networkV3.NetworkID, networkV3.SubnetID); innerXErr != nil {
-					return innerXErr
-				}
-			}
-
-			if networkV3.NetworkID != "" {
-				networkInstance, innerXErr = LoadNetwork(instance.GetService(), networkV3.NetworkID)
-				if innerXErr != nil {
-					return innerXErr
-				}
-				deleteNetwork = networkV3.CreatedNetwork
-			}
-			if networkV3.SubnetID != "" {
-				subnetInstance, innerXErr = LoadSubnet(instance.GetService(), networkV3.NetworkID, networkV3.SubnetID)
-				if innerXErr != nil {
-					return innerXErr
-				}
-				if networkInstance == nil {
-					networkInstance, innerXErr = subnetInstance.InspectNetwork()
-					if innerXErr != nil {
-						return innerXErr
-					}
-				}
-				deleteNetwork = networkV3.CreatedNetwork
-			}
-
-			return nil
+			var innerXErr fail.Error
+			tags, innerXErr = buildClusterTags(instance.GetName(), tagsV1.Tags)
+			return innerXErr
 		})
 	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return nil, deleteNetwork, NullSubnet(), xerr
+		return nil, xerr
 	}
 
-	return networkInstance, deleteNetwork, subnetInstance, nil
-}
-
-func containsClusterNode(list []uint, numericalID uint) (bool, int) {
-	var idx int
-	found := false
-	for i, v := range list {
-		if v == numericalID {
-			found = true
-			idx = i
-			break
-		}
-	}
-	return found, idx
+	return tags, nil
 }
 
-// configureCluster ...
-// params contains a data.Map with primary and secondary getGateway hosts
-func (instance *Cluster) configureCluster(ctx context.Context) (xerr fail.Error) {
-	task, xerr := concurrency.TaskFromContext(ctx)
-	xerr = debug.InjectPlannedFail(xerr)
+// tagResource sets 'tags' on the resource of type 'kind' identified by 'id' in the provider
+// A provider unable to tag this type of resource is not considered as an error
+func (instance *Cluster) tagResource(kind taggableresource.Enum, id, name string, tags map[string]string) fail.Error {
+	xerr := instance.GetService().TagResource(kind, id, tags)
 	if xerr != nil {
-		return xerr
-	}
-
-	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).Entering()
-	defer tracer.Exiting()
-
-	if task.Aborted() {
-		return fail.AbortedError(nil, "aborted")
-	}
-
-	logrus.Infof("[Cluster %s] configuring Cluster...", instance.GetName())
-	defer func() {
-		if xerr == nil {
-			logrus.Infof("[Cluster %s] configuration successful.", instance.GetName())
-		} else {
-			logrus.Errorf("[Cluster %s] configuration failed: %s", instance.GetName(), xerr.Error())
+		switch xerr.(type) {
+		case *fail.ErrNotImplemented:
+			logrus.Warnf("cannot tag %s '%s' of Cluster '%s': %v", kind, name, instance.GetName(), xerr)
+			return nil
+		default:
+			return fail.Wrap(xerr, "failed to tag %s '%s'", kind, name)
 		}
-	}()
+	}
+	return nil
+}
 
-	// Install reverseproxy feature on Cluster (gateways)
-	xerr = instance.installReverseProxy(ctx)
+// tagNetworkingResources sets the tags of the Cluster on the Network (if created with the Cluster), the Subnets and
+// the gateways (if not pre-existing) of the Cluster
+func (instance *Cluster) tagNetworkingResources() fail.Error {
+	tags, xerr := instance.getTags()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return xerr
 	}
 
-	// Install remotedesktop feature on Cluster (all masters)
-	xerr = instance.installRemoteDesktop(ctx)
+	netCfg, xerr := instance.GetNetworkConfig()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return xerr
 	}
 
-	// configure what has to be done Cluster-wide
-	if instance.makers.ConfigureCluster != nil {
-		return instance.makers.ConfigureCluster(ctx, instance)
+	type taggable struct {
+		kind taggableresource.Enum
+		id   string
+	}
+	var list []taggable
+	if netCfg.CreatedNetwork && netCfg.NetworkID != "" {
+		list = append(list, taggable{kind: taggableresource.Network, id: netCfg.NetworkID})
+	}
+	if !netCfg.ExternalGateway {
+		for _, v := range []string{netCfg.SubnetID, netCfg.GatewayID, netCfg.SecondaryGatewayID} {
+			if v == "" {
+				continue
+			}
+			kind := taggableresource.Host
+			if v == netCfg.SubnetID {
+				kind = taggableresource.Subnet
+			}
+			list = append(list, taggable{kind: kind, id: v})
+		}
+	}
+	for _, v := range netCfg.NodePoolSubnets {
+		list = append(list, taggable{kind: taggableresource.Subnet, id: v})
 	}
 
-	// Not finding a callback isn't an error, so return nil in this case
+	var errors []error
+	for _, v := range list {
+		xerr = instance.tagResource(v.kind, v.id, v.id, tags)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			errors = append(errors, xerr)
+		}
+	}
+	if len(errors) > 0 {
+		return fail.NewErrorList(errors)
+	}
+	return nil
+}
+
+// tagNewHost sets the tags of the Cluster on a Host just created for the Cluster
+// A failure is only reported in logs: it does not compromise the Host, and tags can be set again with RetagResources()
+func (instance *Cluster) tagNewHost(host resources.Host) {
+	tags, xerr := instance.getTags()
+	if xerr == nil {
+		xerr = instance.tagResource(taggableresource.Host, host.GetID(), host.GetName(), tags)
+	}
+	if xerr != nil {
+		logrus.Warnf("failed to tag Host '%s' of Cluster '%s': %v", host.GetName(), instance.GetName(), xerr)
+	}
+}
+
+// checkGatewaySizing verifies that 'def' meets the 'minimum' sizing of gateways
+func checkGatewaySizing(def, minimum abstract.HostSizingRequirements) fail.Error {
+	var problems []string
+	if def.MinCores < minimum.MinCores {
+		problems = append(problems, fmt.Sprintf("%d cores requested, %d required", def.MinCores, minimum.MinCores))
+	}
+	if def.MinRAMSize < minimum.MinRAMSize {
+		problems = append(problems, fmt.Sprintf("%.1f GB of RAM requested, %.1f GB required", def.MinRAMSize, minimum.MinRAMSize))
+	}
+	if def.MinDiskSize < minimum.MinDiskSize {
+		problems = append(problems, fmt.Sprintf("%d GB of disk requested, %d GB required", def.MinDiskSize, minimum.MinDiskSize))
+	}
+	if minimum.MinGPU > 0 && def.MinGPU < minimum.MinGPU {
+		problems = append(problems, fmt.Sprintf("%d GPU requested, %d required", def.MinGPU, minimum.MinGPU))
+	}
+	if len(problems) > 0 {
+		return fail.InvalidRequestError("sizing of gateways below the minimum of the flavor: %s", strings.Join(problems, ", "))
+	}
 	return nil
 }
 
@@ -2799,118 +5115,687 @@ func (instance *Cluster) configureNodesFromList(tgo concurrency.Task, hosts []re
 	tracer := debug.NewTracer(tgo, tracing.ShouldTrace("resources.cluster")).Entering()
 	defer tracer.Exiting()
 
-	if tgo.Aborted() {
+	if tgo.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	task, err := concurrency.NewTaskGroupWithParent(tgo)
+	if err != nil {
+		return err
+	}
+
+	length := len(hosts)
+	for i := 0; i < length; i++ {
+		_, ierr := task.StartInSubtask(instance.taskConfigureNode, taskConfigureNodeParameters{
+			Index: uint(i + 1),
+			Host:  hosts[i],
+		})
+		ierr = debug.InjectPlannedFail(ierr)
+		if ierr != nil {
+			_ = task.Abort()
+			break
+		}
+	}
+	_, err = task.WaitGroup()
+	err = debug.InjectPlannedFail(err)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// joinNodesFromList makes nodes from a list join the Cluster
+func (instance *Cluster) joinNodesFromList(ctx context.Context, hosts []resources.Host) fail.Error {
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	if instance.makers.JoinNodeToCluster == nil {
+		// configure what has to be done Cluster-wide
+		if instance.makers.ConfigureCluster != nil {
+			xerr = instance.makers.ConfigureCluster(ctx, instance)
+			xerr = debug.InjectPlannedFail(xerr)
+			if xerr != nil {
+				return xerr
+			}
+		}
+	} else {
+		logrus.Debugf("Joining nodes to Cluster...")
+
+		// Joins to Cluster is done sequentially, experience shows too many join at the same time
+		// may fail (depending of the Cluster Flavor)
+		for _, hostInstance := range hosts {
+			xerr = instance.makers.JoinNodeToCluster(instance, hostInstance)
+			xerr = debug.InjectPlannedFail(xerr)
+			if xerr != nil {
+				return xerr
+			}
+		}
+	}
+
+	return instance.verifyNodesJoined(ctx, hosts)
+}
+
+// getClusterJoinVerificationTimeout returns the maximum duration to wait for new nodes to be confirmed members of the
+// Cluster, that can be overridden by environment variable SAFESCALE_CLUSTER_JOIN_VERIFICATION_TIMEOUT (default: the
+// Host timeout); 0 disables the verification
+func getClusterJoinVerificationTimeout() time.Duration {
+	return temporal.GetTimeoutFromEnv("SAFESCALE_CLUSTER_JOIN_VERIFICATION_TIMEOUT", temporal.GetHostTimeout())
+}
+
+// verifyNodesJoined waits for the nodes to be confirmed members of the Cluster by the flavor, if it knows how to
+// Nodes joined sequentially, all the verifications share the same timeout
+// Returns *fail.ErrorList with an error for each node not confirmed
+func (instance *Cluster) verifyNodesJoined(ctx context.Context, hosts []resources.Host) fail.Error {
+	timeout := getClusterJoinVerificationTimeout()
+	if instance.makers.VerifyNodeJoined == nil || timeout <= 0 || len(hosts) == 0 {
+		return nil
+	}
+
+	logrus.Debugf("[Cluster %s] verifying that the nodes joined...", instance.GetName())
+	deadline := time.Now().Add(timeout)
+	var errors []error
+	for _, hostInstance := range hosts {
+		remaining := time.Until(deadline)
+		if remaining < temporal.GetMinDelay() {
+			remaining = temporal.GetMinDelay()
+		}
+		xerr := instance.makers.VerifyNodeJoined(ctx, instance, hostInstance, remaining)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			if _, ok := xerr.(*fail.ErrAborted); ok {
+				return xerr
+			}
+			errors = append(errors, fail.Wrap(xerr, "node '%s' failed to join Cluster '%s'", hostInstance.GetName(), instance.GetName()))
+		}
+	}
+	if len(errors) > 0 {
+		return fail.NewErrorList(errors)
+	}
+	return nil
+}
+
+// registerHost adds an existing Host in the nodes of the Cluster, as master or node depending on 'role'
+func (instance *Cluster) registerHost(hostInstance resources.Host, role clusternodetype.Enum) (node *propertiesv3.ClusterNode, xerr fail.Error) {
+	privateIP, xerr := hostInstance.GetPrivateIP()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	publicIP, xerr := hostInstance.GetPublicIP()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotFound:
+			// No public IP, this can happen; continue
+		default:
+			return nil, xerr
+		}
+	}
+
+	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			hostID := hostInstance.GetID()
+			if _, found := nodesV3.MasterByID[hostID]; found {
+				return fail.DuplicateError("Host '%s' is already a master of Cluster '%s'", hostInstance.GetName(), instance.GetName())
+			}
+			if _, found := nodesV3.PrivateNodeByID[hostID]; found {
+				return fail.DuplicateError("Host '%s' is already a node of Cluster '%s'", hostInstance.GetName(), instance.GetName())
+			}
+			innerXErr := checkClusterNodeNameAvailable(nodesV3, instance.GetName(), hostInstance.GetName(), hostID)
+			if innerXErr != nil {
+				return innerXErr
+			}
+
+			nodesV3.GlobalLastIndex++
+			node = &propertiesv3.ClusterNode{
+				ID:          hostID,
+				NumericalID: nodesV3.GlobalLastIndex,
+				Name:        hostInstance.GetName(),
+				PrivateIP:   privateIP,
+				PublicIP:    publicIP,
+			}
+			nodesV3.ByNumericalID[node.NumericalID] = node
+
+			switch role {
+			case clusternodetype.Master:
+				nodesV3.Masters = append(nodesV3.Masters, node.NumericalID)
+				nodesV3.MasterByName[node.Name] = node.NumericalID
+				nodesV3.MasterByID[node.ID] = node.NumericalID
+			case clusternodetype.Node:
+				nodesV3.PrivateNodes = append(nodesV3.PrivateNodes, node.NumericalID)
+				nodesV3.PrivateNodeByName[node.Name] = node.NumericalID
+				nodesV3.PrivateNodeByID[node.ID] = node.NumericalID
+			default:
+				return fail.InvalidParameterError("role", "must be Master or Node")
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	return node, nil
+}
+
+// unregisterHost removes an Host from the nodes of the Cluster, without deleting it
+func (instance *Cluster) unregisterHost(hostInstance resources.Host) fail.Error {
+	return instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			if removeClusterNode(nodesV3, hostInstance.GetID()) {
+				return nil
+			}
+			return fail.NotFoundError("failed to find Host '%s' in Cluster '%s'", hostInstance.GetName(), instance.GetName())
+		})
+	})
+}
+
+// leaveNodesFromList makes nodes from a list leave the Cluster
+func (instance *Cluster) leaveNodesFromList(hosts []resources.Host, master resources.Host) (xerr fail.Error) {
+	logrus.Debugf("Instructing nodes to leave Cluster...")
+
+	// Unjoins from Cluster are done sequentially, experience shows too many join at the same time
+	// may fail (depending of the Cluster Flavor)
+	for _, rh := range hosts {
+		if instance.makers.LeaveNodeFromCluster != nil {
+			xerr = instance.makers.LeaveNodeFromCluster(instance, rh, master)
+			xerr = debug.InjectPlannedFail(xerr)
+			if xerr != nil {
+				return xerr
+			}
+		}
+	}
+
+	return nil
+}
+
+// clusterNodeSlot is the place reserved in the metadata of the Cluster for a Host to create
+type clusterNodeSlot struct {
+	numericalID uint   // NumericalID of the Host in the Cluster
+	hostName    string // name of the Host
+}
+
+// reserveNodeSlots reserves, in a single update of metadata, the NumericalIDs and the names of 'count' Hosts of type
+// 'nodeType' to create, so that Hosts created in parallel do not collide and are numbered in order
+func (instance *Cluster) reserveNodeSlots(nodeType clusternodetype.Enum, count uint) (_ []clusterNodeSlot, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	var slots []clusterNodeSlot
+	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) (innerXErr fail.Error) {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			slots, innerXErr = allocateNodeSlots(nodesV3, instance.GetName(), nodeType, count)
+			return innerXErr
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+	return slots, nil
+}
+
+// allocateNodeSlots allocates in 'nodesV3' the NumericalIDs and the names of 'count' Hosts of type 'nodeType' of the
+// Cluster 'clusterName'
+func allocateNodeSlots(nodesV3 *propertiesv3.ClusterNodes, clusterName string, nodeType clusternodetype.Enum, count uint) ([]clusterNodeSlot, fail.Error) {
+	var (
+		core      string
+		lastIndex *int
+	)
+	switch nodeType {
+	case clusternodetype.Node:
+		core, lastIndex = "node", &nodesV3.PrivateLastIndex
+	case clusternodetype.Master:
+		core, lastIndex = "master", &nodesV3.MasterLastIndex
+	default:
+		return nil, fail.InvalidParameterError("nodeType", "must be Master or Node")
+	}
+
+	slots := make([]clusterNodeSlot, 0, count)
+	for i := uint(0); i < count; i++ {
+		nodesV3.GlobalLastIndex++
+		*lastIndex++
+		slot := clusterNodeSlot{
+			numericalID: nodesV3.GlobalLastIndex,
+			hostName:    clusterName + "-" + core + "-" + strconv.Itoa(*lastIndex),
+		}
+		// an imported Host or an edited metadata may already use the name
+		if xerr := checkClusterNodeNameAvailable(nodesV3, clusterName, slot.hostName, ""); xerr != nil {
+			return nil, xerr
+		}
+		slots = append(slots, slot)
+	}
+	return slots, nil
+}
+
+// findClusterNodesByName returns the masters and private nodes of 'nodesV3' named 'name'
+// The nodes are searched in ByNumericalID, not in the maps indexed by name, that keep only one of the nodes sharing a name
+func findClusterNodesByName(nodesV3 *propertiesv3.ClusterNodes, name string) []*propertiesv3.ClusterNode {
+	var out []*propertiesv3.ClusterNode
+	for _, list := range [][]uint{nodesV3.Masters, nodesV3.PrivateNodes} {
+		for _, numericalID := range list {
+			if node, ok := nodesV3.ByNumericalID[numericalID]; ok && node != nil && node.Name == name {
+				out = append(out, node)
+			}
+		}
+	}
+	return out
+}
+
+// checkClusterNodeNameAvailable returns *fail.ErrDuplicate if a master or a private node of 'nodesV3', other than the
+// Host with ID 'hostID', is named 'name'
+func checkClusterNodeNameAvailable(nodesV3 *propertiesv3.ClusterNodes, clusterName, name, hostID string) fail.Error {
+	for _, node := range findClusterNodesByName(nodesV3, name) {
+		if node.ID != hostID {
+			return fail.DuplicateError("name '%s' is already used in Cluster '%s' by Host with ID '%s', cannot be used by Host with ID '%s'", name, clusterName, node.ID, hostID)
+		}
+	}
+	return nil
+}
+
+// findDuplicateClusterNodeNames returns a *fail.ErrDuplicate for each pair of masters or private nodes of 'nodesV3'
+// sharing the same name, in order of NumericalID
+func findDuplicateClusterNodeNames(nodesV3 *propertiesv3.ClusterNodes, clusterName string) []error {
+	var numericalIDs []uint
+	numericalIDs = append(numericalIDs, nodesV3.Masters...)
+	numericalIDs = append(numericalIDs, nodesV3.PrivateNodes...)
+	sort.Slice(numericalIDs, func(i, j int) bool { return numericalIDs[i] < numericalIDs[j] })
+
+	var errors []error
+	firstByName := map[string]*propertiesv3.ClusterNode{}
+	for _, numericalID := range numericalIDs {
+		node, ok := nodesV3.ByNumericalID[numericalID]
+		if !ok || node == nil {
+			continue
+		}
+		if first, ok := firstByName[node.Name]; ok {
+			errors = append(errors, fail.DuplicateError("name '%s' is used in Cluster '%s' by Hosts with IDs '%s' and '%s'", node.Name, clusterName, first.ID, node.ID))
+			continue
+		}
+		firstByName[node.Name] = node
+	}
+	return errors
+}
+
+// Verify cross-checks the metadata of the Cluster, and returns *fail.ErrDuplicate if masters or private nodes share the
+// same name (with the IDs of the colliding Hosts), or *fail.ErrorList of them if there are several duplicates
+// Nothing is changed on the Cluster or in its metadata
+func (instance *Cluster) Verify(ctx context.Context) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
 		return fail.AbortedError(nil, "aborted")
 	}
 
-	task, err := concurrency.NewTaskGroupWithParent(tgo)
-	if err != nil {
-		return err
-	}
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).Entering()
+	defer tracer.Exiting()
 
-	length := len(hosts)
-	for i := 0; i < length; i++ {
-		_, ierr := task.StartInSubtask(instance.taskConfigureNode, taskConfigureNodeParameters{
-			Index: uint(i + 1),
-			Host:  hosts[i],
+	var duplicates []error
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			duplicates = findDuplicateClusterNodeNames(nodesV3, instance.GetName())
+			return nil
 		})
-		ierr = debug.InjectPlannedFail(ierr)
-		if ierr != nil {
-			_ = task.Abort()
-			break
-		}
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
 	}
-	_, err = task.WaitGroup()
-	err = debug.InjectPlannedFail(err)
-	if err != nil {
-		return err
+
+	switch len(duplicates) {
+	case 0:
+		return nil
+	case 1:
+		return duplicates[0].(fail.Error)
+	default:
+		return fail.NewErrorList(duplicates)
 	}
+}
 
-	return nil
+// clusterReconcileTarget is a Host referenced by the metadata of a Cluster, checked by Reconcile
+type clusterReconcileTarget struct {
+	id   string
+	name string // name recorded in the metadata of the Cluster; empty for gateways
+	role string // "gateway", "master" or "node"
 }
 
-// joinNodesFromList makes nodes from a list join the Cluster
-func (instance *Cluster) joinNodesFromList(ctx context.Context, hosts []resources.Host) fail.Error {
+// Reconcile checks that the gateways, masters and private nodes recorded in the metadata of the Cluster exist in the
+// provider with the recorded name, and that their sizing in the provider is the one in their metadata; returns the
+// discrepancies found
+// If 'apply' is true, the dead masters and nodes, and the dead secondary gateway, are removed from the metadata of the
+// Cluster (their drifts are marked as fixed); the other discrepancies are only reported. Without 'apply', nothing is
+// changed. A Host that cannot be inspected in the provider fails the reconciliation, not to take a transient failure
+// for a deletion.
+func (instance *Cluster) Reconcile(ctx context.Context, apply bool) (_ resources.ClusterReconciliationReport, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	report := resources.ClusterReconciliationReport{Applied: apply}
+	if instance == nil || instance.IsNull() {
+		return report, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return report, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
 	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return xerr
+		return report, xerr
 	}
 
 	if task.Aborted() {
-		return fail.AbortedError(nil, "aborted")
+		return report, fail.AbortedError(nil, "aborted")
 	}
 
-	if instance.makers.JoinNodeToCluster == nil {
-		// configure what has to be done Cluster-wide
-		if instance.makers.ConfigureCluster != nil {
-			return instance.makers.ConfigureCluster(ctx, instance)
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster"), "(%v)", apply).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	report.ClusterName = instance.GetName()
+	if apply {
+		// make sure no other operation on the same Cluster runs in parallel in the daemon
+		var unlockCluster func()
+		unlockCluster, xerr = lockCluster(ctx, instance)
+		if xerr != nil {
+			return report, xerr
 		}
+		defer unlockCluster()
+
+		defer recordOperation(instance, clusterproperty.LastOperationV1, "reconcile")(&xerr)
 	}
 
-	logrus.Debugf("Joining nodes to Cluster...")
+	var (
+		targets            []clusterReconcileTarget
+		secondaryGatewayID string
+	)
+	instance.lock.RLock()
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		innerXErr := props.Inspect(clusterproperty.NetworkV3, func(clonable data.Clonable) fail.Error {
+			networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
 
-	// Joins to Cluster is done sequentially, experience shows too many join at the same time
-	// may fail (depending of the Cluster Flavor)
-	if instance.makers.JoinMasterToCluster != nil {
-		for _, hostInstance := range hosts {
-			xerr = instance.makers.JoinNodeToCluster(instance, hostInstance)
-			xerr = debug.InjectPlannedFail(xerr)
-			if xerr != nil {
-				return xerr
+			for _, v := range []string{networkV3.GatewayID, networkV3.SecondaryGatewayID} {
+				if v != "" {
+					targets = append(targets, clusterReconcileTarget{id: v, role: "gateway"})
+				}
 			}
+			secondaryGatewayID = networkV3.SecondaryGatewayID
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
 		}
-	}
-
-	return nil
-}
 
-// leaveNodesFromList makes nodes from a list leave the Cluster
-func (instance *Cluster) leaveNodesFromList(hosts []resources.Host, master resources.Host) (xerr fail.Error) {
-	logrus.Debugf("Instructing nodes to leave Cluster...")
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
 
-	// Unjoins from Cluster are done sequentially, experience shows too many join at the same time
-	// may fail (depending of the Cluster Flavor)
-	for _, rh := range hosts {
-		if instance.makers.LeaveNodeFromCluster != nil {
-			xerr = instance.makers.LeaveNodeFromCluster(instance, rh, master)
-			xerr = debug.InjectPlannedFail(xerr)
-			if xerr != nil {
-				return xerr
+			for _, v := range nodesV3.Masters {
+				if node, found := nodesV3.ByNumericalID[v]; found {
+					targets = append(targets, clusterReconcileTarget{id: node.ID, name: node.Name, role: "master"})
+				}
 			}
-		}
+			for _, v := range nodesV3.PrivateNodes {
+				if node, found := nodesV3.ByNumericalID[v]; found {
+					targets = append(targets, clusterReconcileTarget{id: node.ID, name: node.Name, role: "node"})
+				}
+			}
+			return nil
+		})
+	})
+	instance.lock.RUnlock()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return report, xerr
 	}
 
-	return nil
-}
+	for _, v := range targets {
+		if task.Aborted() {
+			return report, fail.AbortedError(nil, "aborted")
+		}
 
-// BuildHostname builds a unique hostname in the Cluster
-func (instance *Cluster) buildHostname(core string, nodeType clusternodetype.Enum) (_ string, xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
+		drifts, xerr := instance.reconcileHost(v)
+		if xerr != nil {
+			return report, fail.Wrap(xerr, "failed to reconcile %s '%s' of Cluster '%s'", v.role, v.id, instance.GetName())
+		}
+		report.Drifts = append(report.Drifts, drifts...)
+	}
+	if !apply {
+		return report, nil
+	}
 
-	var index int
+	var fixed []int
 	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+		innerXErr := props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
 			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
 			if !ok {
 				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
-			switch nodeType {
-			case clusternodetype.Node:
-				nodesV3.PrivateLastIndex++
-				index = nodesV3.PrivateLastIndex
-			case clusternodetype.Master:
-				nodesV3.MasterLastIndex++
-				index = nodesV3.MasterLastIndex
+
+			for k, v := range report.Drifts {
+				if v.Kind == resources.DeadClusterNode && removeClusterNode(nodesV3, v.HostID) {
+					fixed = append(fixed, k)
+				}
+			}
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		return props.Alter(clusterproperty.NetworkV3, func(clonable data.Clonable) fail.Error {
+			networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			for k, v := range report.Drifts {
+				// the primary gateway carries the network of the Cluster, it cannot be simply forgotten
+				if v.Kind == resources.DeadClusterGateway && v.HostID == secondaryGatewayID && networkV3.SecondaryGatewayID == v.HostID {
+					networkV3.SecondaryGatewayID = ""
+					networkV3.SecondaryGatewayIP = ""
+					networkV3.SecondaryPublicIP = ""
+					fixed = append(fixed, k)
+				}
 			}
 			return nil
 		})
 	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return "", xerr
+		return report, fail.Wrap(xerr, "failed to fix metadata of Cluster '%s'", instance.GetName())
+	}
+
+	for _, v := range fixed {
+		report.Drifts[v].Fixed = true
+		logrus.Infof("Cluster '%s': removed dead %s '%s' from metadata", instance.GetName(), report.Drifts[v].Role, report.Drifts[v].HostID)
+	}
+	return report, nil
+}
+
+// reconcileHost returns the discrepancies between a Host referenced by the Cluster and the provider
+func (instance *Cluster) reconcileHost(target clusterReconcileTarget) ([]resources.ClusterDrift, fail.Error) {
+	svc := instance.GetService()
+	ahf, xerr := svc.InspectHost(target.id)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotFound:
+			kind := resources.DeadClusterNode
+			if target.role == "gateway" {
+				kind = resources.DeadClusterGateway
+			}
+			return []resources.ClusterDrift{{
+				Kind:        kind,
+				Role:        target.role,
+				HostID:      target.id,
+				HostName:    target.name,
+				Description: fmt.Sprintf("%s '%s' does not exist in the provider", target.role, target.id),
+			}}, nil
+		default:
+			return nil, xerr
+		}
+	}
+
+	var drifts []resources.ClusterDrift
+	if target.name != "" && ahf.Core != nil && ahf.Core.Name != "" && ahf.Core.Name != target.name {
+		drifts = append(drifts, resources.ClusterDrift{
+			Kind:        resources.ClusterHostMismatch,
+			Role:        target.role,
+			HostID:      target.id,
+			HostName:    target.name,
+			Description: fmt.Sprintf("Host '%s' is named '%s' in the provider", target.id, ahf.Core.Name),
+		})
+	}
+
+	hostInstance, xerr := LoadHost(svc, target.id)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotFound:
+			// no metadata to compare the sizing with
+			logrus.Debugf("no metadata found for %s '%s' of Cluster '%s', sizing not checked", target.role, target.id, instance.GetName())
+			return drifts, nil
+		default:
+			return nil, xerr
+		}
+	}
+	defer hostInstance.Released()
+
+	var recorded *propertiesv2.HostEffectiveSizing
+	xerr = hostInstance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		// Older metadata may not have sizing, it's not an error
+		if !props.Lookup(hostproperty.SizingV2) {
+			return nil
+		}
+
+		return props.Inspect(hostproperty.SizingV2, func(clonable data.Clonable) fail.Error {
+			hostSizingV2, ok := clonable.(*propertiesv2.HostSizing)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.HostSizing' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			recorded = hostSizingV2.AllocatedSize
+			return nil
+		})
+	})
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	if diffs := compareHostSizing(recorded, ahf.Sizing); len(diffs) > 0 {
+		drifts = append(drifts, resources.ClusterDrift{
+			Kind:        resources.ClusterSizingDrift,
+			Role:        target.role,
+			HostID:      target.id,
+			HostName:    hostInstance.GetName(),
+			Description: fmt.Sprintf("Host '%s' has in the provider %s", hostInstance.GetName(), strings.Join(diffs, ", ")),
+		})
+	}
+	return drifts, nil
+}
+
+// compareHostSizing returns the differences between the sizing of a Host recorded in metadata and the one reported by
+// the provider; the values not reported by the provider are not compared
+func compareHostSizing(recorded *propertiesv2.HostEffectiveSizing, actual *abstract.HostEffectiveSizing) []string {
+	if recorded == nil || actual == nil {
+		return nil
+	}
+
+	var diffs []string
+	if actual.Cores > 0 && actual.Cores != recorded.Cores {
+		diffs = append(diffs, fmt.Sprintf("%d cores instead of %d", actual.Cores, recorded.Cores))
+	}
+	if delta := actual.RAMSize - recorded.RAMSize; actual.RAMSize > 0 && (delta > 0.01 || delta < -0.01) {
+		diffs = append(diffs, fmt.Sprintf("%.1f GB of RAM instead of %.1f", actual.RAMSize, recorded.RAMSize))
+	}
+	if actual.DiskSize > 0 && actual.DiskSize != recorded.DiskSize {
+		diffs = append(diffs, fmt.Sprintf("%d GB of disk instead of %d", actual.DiskSize, recorded.DiskSize))
+	}
+	if actual.GPUNumber > 0 && actual.GPUNumber != recorded.GPUNumber {
+		diffs = append(diffs, fmt.Sprintf("%d GPUs instead of %d", actual.GPUNumber, recorded.GPUNumber))
+	}
+	return diffs
+}
+
+// removeClusterNode removes the master or private node 'hostID' from 'nodesV3'; returns false if it is not found
+func removeClusterNode(nodesV3 *propertiesv3.ClusterNodes, hostID string) bool {
+	// removes the entries of byName pointing to numericalID, the name recorded being possibly shared (see Verify)
+	dropName := func(byName map[string]uint, numericalID uint) {
+		for k, v := range byName {
+			if v == numericalID {
+				delete(byName, k)
+			}
+		}
 	}
-	return instance.GetName() + "-" + core + "-" + strconv.Itoa(index), nil
+
+	if numericalID, found := nodesV3.MasterByID[hostID]; found {
+		if found, indexInSlice := containsClusterNode(nodesV3.Masters, numericalID); found {
+			nodesV3.Masters = append(nodesV3.Masters[:indexInSlice], nodesV3.Masters[indexInSlice+1:]...)
+		}
+		delete(nodesV3.MasterByID, hostID)
+		dropName(nodesV3.MasterByName, numericalID)
+		delete(nodesV3.ByNumericalID, numericalID)
+		return true
+	}
+	if numericalID, found := nodesV3.PrivateNodeByID[hostID]; found {
+		if found, indexInSlice := containsClusterNode(nodesV3.PrivateNodes, numericalID); found {
+			nodesV3.PrivateNodes = append(nodesV3.PrivateNodes[:indexInSlice], nodesV3.PrivateNodes[indexInSlice+1:]...)
+		}
+		delete(nodesV3.PrivateNodeByID, hostID)
+		dropName(nodesV3.PrivateNodeByName, numericalID)
+		delete(nodesV3.ByNumericalID, numericalID)
+		delete(nodesV3.Cordoned, numericalID)
+		delete(nodesV3.Labels, numericalID)
+		delete(nodesV3.States, numericalID)
+		return true
+	}
+	return false
 }
 
 func (instance *Cluster) deleteHosts(task concurrency.Task, hosts []resources.Host) fail.Error {
@@ -3054,6 +5939,21 @@ func (instance *Cluster) ToProtocol() (_ *protocol.ClusterResponse, xerr fail.Er
 			return innerXErr
 		}
 
+		// Older metadata may not have last operation, it's not an error
+		if props.Lookup(clusterproperty.LastOperationV1) {
+			innerXErr = props.Inspect(clusterproperty.LastOperationV1, func(clonable data.Clonable) fail.Error {
+				lastOperationV1, ok := clonable.(*propertiesv1.LastOperation)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv1.LastOperation' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+				out.LastOperation = converters.LastOperationFromPropertyToProtocol(*lastOperationV1)
+				return nil
+			})
+			if innerXErr != nil {
+				return innerXErr
+			}
+		}
+
 		return props.Inspect(clusterproperty.StateV1, func(clonable data.Clonable) fail.Error {
 			stateV1, ok := clonable.(*propertiesv1.ClusterState)
 			if !ok {
@@ -3070,7 +5970,13 @@ func (instance *Cluster) ToProtocol() (_ *protocol.ClusterResponse, xerr fail.Er
 	return out, nil
 }
 
-func (instance *Cluster) Shrink(ctx context.Context, count uint) (_ []*propertiesv3.ClusterNode, xerr fail.Error) {
+// Shrink reduces the size of the Cluster of 'count' nodes (the last created)
+// Refuses to leave less nodes than the minimum required by the flavor of the Cluster, unless option "AllowBelowMinimum"
+// (bool) is set to true
+// If the Cluster is of flavor K8S, the nodes are drained before their deletion, unless option "SkipDrain" (bool) is set
+// to true; option "DrainTimeout" (time.Duration) limits the duration of the drain of each node. A node that fails to drain
+// is kept; the other nodes are removed, and the failures are returned with them.
+func (instance *Cluster) Shrink(ctx context.Context, count uint, options ...data.ImmutableKeyValue) (_ []*propertiesv3.ClusterNode, xerr fail.Error) {
 	emptySlice := make([]*propertiesv3.ClusterNode, 0)
 	if instance == nil || instance.IsNull() {
 		return emptySlice, fail.InvalidInstanceError()
@@ -3092,16 +5998,74 @@ func (instance *Cluster) Shrink(ctx context.Context, count uint) (_ []*propertie
 		return emptySlice, fail.AbortedError(nil, "aborted")
 	}
 
+	// make sure no other operation on the same Cluster runs in parallel in the daemon
+	unlockCluster, xerr := lockCluster(ctx, instance)
+	if xerr != nil {
+		return emptySlice, xerr
+	}
+	defer unlockCluster()
+
 	// make sure no other parallel actions interferes
 	instance.lock.Lock()
 	defer instance.lock.Unlock()
 
+	defer recordOperation(instance, clusterproperty.LastOperationV1, "shrink")(&xerr)
+
 	xerr = instance.beingRemoved()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return emptySlice, xerr
 	}
 
+	minimumNodes, xerr := instance.minimumNodesToKeep(options...)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return emptySlice, xerr
+	}
+
+	var candidates []*propertiesv3.ClusterNode
+	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) (innerXErr fail.Error) {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			length := uint(len(nodesV3.PrivateNodes))
+			if length < count {
+				return fail.InvalidRequestError("cannot shrink by %d node%s, only %d node%s available", count, strprocess.Plural(count), length, strprocess.Plural(length))
+			}
+			if innerXErr = checkMinimumNodes(instance.GetName(), length, count, minimumNodes); innerXErr != nil {
+				return innerXErr
+			}
+
+			for _, v := range nodesV3.PrivateNodes[length-count:] {
+				if node, ok := nodesV3.ByNumericalID[v]; ok {
+					candidates = append(candidates, node)
+				}
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return emptySlice, xerr
+	}
+
+	// Drain the nodes before removing them; the ones that fail to drain are kept
+	var drainFailures []error
+	removable := candidates
+	if skipDrain, drainTimeout := drainOptionsFrom(options...); !skipDrain {
+		removable, drainFailures, xerr = instance.unsafeDrainNodesBeforeRemoval(ctx, nil, candidates, drainTimeout)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return emptySlice, xerr
+		}
+		if len(removable) == 0 && len(drainFailures) > 0 {
+			return emptySlice, fail.Wrap(fail.NewErrorList(drainFailures), "no node removed from Cluster '%s'", instance.GetName())
+		}
+	}
+
 	tg, xerr := concurrency.NewTaskGroup(task)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
@@ -3112,6 +6076,7 @@ func (instance *Cluster) Shrink(ctx context.Context, count uint) (_ []*propertie
 		removedNodes []*propertiesv3.ClusterNode
 		errors       []error
 		toRemove     []uint
+		nodesDeleted bool
 	)
 	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
 		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) (innerXErr fail.Error) {
@@ -3120,14 +6085,19 @@ func (instance *Cluster) Shrink(ctx context.Context, count uint) (_ []*propertie
 				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			length := uint(len(nodesV3.PrivateNodes))
-			if length < count {
-				return fail.InvalidRequestError("cannot shrink by %d node%s, only %d node%s available", count, strprocess.Plural(count), length, strprocess.Plural(length))
+			selected := make(map[uint]bool, len(removable))
+			for _, v := range removable {
+				selected[v.NumericalID] = true
 			}
-
-			first := length - count
-			toRemove = nodesV3.PrivateNodes[first:]
-			nodesV3.PrivateNodes = nodesV3.PrivateNodes[:first-1]
+			kept := make([]uint, 0, len(nodesV3.PrivateNodes))
+			for _, v := range nodesV3.PrivateNodes {
+				if selected[v] {
+					toRemove = append(toRemove, v)
+				} else {
+					kept = append(kept, v)
+				}
+			}
+			nodesV3.PrivateNodes = kept
 			for _, v := range toRemove {
 				if node, ok := nodesV3.ByNumericalID[v]; ok {
 					removedNodes = append(removedNodes, node)
@@ -3141,12 +6111,12 @@ func (instance *Cluster) Shrink(ctx context.Context, count uint) (_ []*propertie
 	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return emptySlice, nil
+		return emptySlice, xerr
 	}
 
 	defer func() {
 		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
+		if xerr != nil && !nodesDeleted {
 			derr := instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
 				return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) (innerXErr fail.Error) {
 					nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
@@ -3184,7 +6154,12 @@ func (instance *Cluster) Shrink(ctx context.Context, count uint) (_ []*propertie
 	if len(errors) > 0 {
 		return emptySlice, fail.NewErrorList(errors)
 	}
+	nodesDeleted = true
 
+	if len(drainFailures) > 0 {
+		kept := uint(len(drainFailures))
+		return removedNodes, fail.Wrap(fail.NewErrorList(drainFailures), "removed %d node%s from Cluster '%s', but kept %d node%s that failed to drain", len(removedNodes), strprocess.Plural(uint(len(removedNodes))), instance.GetName(), kept, strprocess.Plural(kept))
+	}
 	return removedNodes, nil
 }
 
@@ -0,0 +1,242 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operations
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/CS-SI/SafeScale/lib/protocol"
+	"github.com/CS-SI/SafeScale/lib/server/resources"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterproperty"
+	propertiesv1 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v1"
+	"github.com/CS-SI/SafeScale/lib/utils/concurrency"
+	"github.com/CS-SI/SafeScale/lib/utils/data"
+	"github.com/CS-SI/SafeScale/lib/utils/debug"
+	"github.com/CS-SI/SafeScale/lib/utils/debug/tracing"
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+	"github.com/CS-SI/SafeScale/lib/utils/serialize"
+)
+
+// featureCheckOutcome contains the outcome of the check of a Feature on the Cluster
+type featureCheckOutcome struct {
+	results resources.Results // results of the check steps, by step then by Host
+	err     error             // error preventing the check to run on the Hosts
+}
+
+// featureCheckOutcomes collects the outcomes of the checks of the Features, shared by the checking tasks
+type featureCheckOutcomes struct {
+	lock     sync.Mutex
+	outcomes map[string]featureCheckOutcome // indexed by name of Feature
+}
+
+// set records the outcome of the check of a Feature
+func (o *featureCheckOutcomes) set(name string, outcome featureCheckOutcome) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.outcomes[name] = outcome
+}
+
+// VerifyFeatures runs in parallel the check steps of the Features installed on the Cluster, on the Hosts targeted by
+// these steps, and returns the health of each Feature on each Host
+// Nothing is changed on the Cluster or in its metadata; the verification is successful only if all the Features are
+// found healthy on all their Hosts
+func (instance *Cluster) VerifyFeatures(ctx context.Context) (_ *protocol.ClusterFeaturesVerification, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	var names []string
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.FeaturesV1, func(clonable data.Clonable) fail.Error {
+			featuresV1, ok := clonable.(*propertiesv1.ClusterFeatures)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.ClusterFeatures' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			for k := range featuresV1.Installed {
+				names = append(names, k)
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	outcomes := &featureCheckOutcomes{outcomes: make(map[string]featureCheckOutcome, len(names))}
+	if len(names) > 0 {
+		tg, xerr := concurrency.NewTaskGroupWithParent(task)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return nil, xerr
+		}
+
+		for _, v := range names {
+			_, xerr = tg.Start(instance.taskCheckFeature, taskCheckFeatureParameters{outcomes: outcomes, name: v})
+			xerr = debug.InjectPlannedFail(xerr)
+			if xerr != nil {
+				_ = tg.Abort()
+				return nil, xerr
+			}
+		}
+
+		// The checking tasks report failed checks as outcomes, not errors
+		_, xerr = tg.WaitGroup()
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return nil, xerr
+		}
+	}
+
+	return summarizeFeaturesVerification(outcomes.outcomes), nil
+}
+
+type taskCheckFeatureParameters struct {
+	outcomes *featureCheckOutcomes
+	name     string
+}
+
+// taskCheckFeature runs the check of a Feature on the Cluster and records its outcome in the parameters
+func (instance *Cluster) taskCheckFeature(task concurrency.Task, params concurrency.TaskParameters) (_ concurrency.TaskResult, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if task == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("task")
+	}
+	p, ok := params.(taskCheckFeatureParameters)
+	if !ok {
+		return nil, fail.InvalidParameterError("params", "must be a 'taskCheckFeatureParameters'")
+	}
+
+	var outcome featureCheckOutcome
+	defer func() {
+		p.outcomes.set(p.name, outcome)
+	}()
+
+	feat, xerr := NewFeature(instance.GetService(), p.name)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		outcome.err = xerr
+		return nil, nil
+	}
+
+	outcome.results, xerr = feat.Check(task.GetContext(), instance, data.Map{}, resources.FeatureSettings{})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		outcome.err = xerr
+	}
+	return nil, nil
+}
+
+// summarizeFeaturesVerification builds the health of the Features on the Hosts of the Cluster from the outcomes of their
+// checks; a Feature is healthy on a Host if all the check steps run on this Host are successful
+func summarizeFeaturesVerification(outcomes map[string]featureCheckOutcome) *protocol.ClusterFeaturesVerification {
+	out := &protocol.ClusterFeaturesVerification{Successful: true}
+
+	featureNames := make([]string, 0, len(outcomes))
+	for k := range outcomes {
+		featureNames = append(featureNames, k)
+	}
+	sort.Strings(featureNames)
+
+	byHost := map[string]*protocol.ClusterHostFeaturesVerification{}
+	for _, featureName := range featureNames {
+		outcome := outcomes[featureName]
+		feature := &protocol.FeatureVerification{Name: featureName, Successful: true}
+		out.Features = append(out.Features, feature)
+
+		if outcome.err != nil {
+			feature.Successful, feature.Error = false, outcome.err.Error()
+			out.Successful = false
+			continue
+		}
+		if outcome.results == nil {
+			continue
+		}
+
+		// merges the results of the steps by Host
+		hostResults := map[string]*protocol.FeatureVerification{}
+		for _, step := range outcome.results.Keys() {
+			urs := outcome.results.ResultsOfKey(step)
+			for _, hostName := range urs.Keys() {
+				hr, ok := hostResults[hostName]
+				if !ok {
+					hr = &protocol.FeatureVerification{Name: featureName, Successful: true}
+					hostResults[hostName] = hr
+				}
+				ur := urs.ResultOfKey(hostName)
+				if !ur.Successful() {
+					hr.Successful = false
+					if msg := strings.TrimSpace(ur.ErrorMessage()); msg != "" {
+						if hr.Error != "" {
+							hr.Error += "; "
+						}
+						hr.Error += step + ": " + msg
+					}
+				}
+			}
+		}
+
+		for hostName, hr := range hostResults {
+			h, ok := byHost[hostName]
+			if !ok {
+				h = &protocol.ClusterHostFeaturesVerification{Host: hostName, Successful: true}
+				byHost[hostName] = h
+			}
+			h.Features = append(h.Features, hr)
+			if !hr.Successful {
+				h.Successful = false
+				feature.Successful = false
+				out.Successful = false
+			}
+		}
+	}
+
+	hostNames := make([]string, 0, len(byHost))
+	for k := range byHost {
+		hostNames = append(hostNames, k)
+	}
+	sort.Strings(hostNames)
+	for _, v := range hostNames {
+		out.Hosts = append(out.Hosts, byHost[v])
+	}
+	return out
+}
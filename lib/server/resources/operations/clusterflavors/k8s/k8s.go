@@ -18,6 +18,8 @@ package k8s
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
@@ -26,8 +28,11 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clustercomplexity"
 	"github.com/CS-SI/SafeScale/lib/server/resources/operations/clusterflavors"
+	"github.com/CS-SI/SafeScale/lib/utils/cli/enums/outputs"
 	"github.com/CS-SI/SafeScale/lib/utils/data"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
+	"github.com/CS-SI/SafeScale/lib/utils/retry"
+	"github.com/CS-SI/SafeScale/lib/utils/temporal"
 )
 
 var (
@@ -42,6 +47,7 @@ var (
 		// GetGlobalSystemRequirements: flavors.GetGlobalSystemRequirements,
 		// GetNodeInstallationScript: getNodeInstallationScript,
 		ConfigureCluster: configureCluster,
+		VerifyNodeJoined: verifyNodeJoined,
 	}
 )
 
@@ -120,3 +126,42 @@ func configureCluster(ctx context.Context, c resources.Cluster) fail.Error {
 
 	return nil
 }
+
+// verifyNodeJoined waits at most 'timeout' for the node 'host' to be registered and Ready in Kubernetes, asking a master
+func verifyNodeJoined(ctx context.Context, c resources.Cluster, host resources.Host, timeout time.Duration) fail.Error {
+	master, xerr := c.FindAvailableMaster(ctx)
+	if xerr != nil {
+		return fail.Wrap(xerr, "failed to find an available master to verify node '%s'", host.GetName())
+	}
+
+	nodeName := host.GetName()
+	cmd := fmt.Sprintf(`sudo -u cladm -i kubectl get node %s -o jsonpath='{.status.conditions[?(@.type=="Ready")].status}'`, nodeName)
+	xerr = retry.WhileUnsuccessful(
+		func() error {
+			retcode, stdout, stderr, innerXErr := master.Run(ctx, cmd, outputs.COLLECT, temporal.GetConnectSSHTimeout(), temporal.GetExecutionTimeout())
+			if innerXErr != nil {
+				return innerXErr
+			}
+			if retcode != 0 {
+				return fail.NotFoundError("node '%s' not registered in Kubernetes yet: %s", nodeName, strings.TrimSpace(stderr))
+			}
+			if status := strings.TrimSpace(stdout); status != "True" {
+				return fail.NotAvailableError("node '%s' not Ready yet (Ready=%s)", nodeName, status)
+			}
+			return nil
+		},
+		temporal.GetDefaultDelay(),
+		timeout,
+	)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *retry.ErrTimeout:
+			return fail.TimeoutError(xerr, timeout, fmt.Sprintf("node '%s' did not become Ready in Kubernetes", nodeName))
+		default:
+			return xerr
+		}
+	}
+
+	logrus.Debugf("[cluster %s] node '%s' is Ready in Kubernetes", c.GetName(), nodeName)
+	return nil
+}
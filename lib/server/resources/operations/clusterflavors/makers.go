@@ -18,6 +18,7 @@ package clusterflavors
 
 import (
 	"sync/atomic"
+	"time"
 
 	rice "github.com/GeertJohan/go.rice"
 	"golang.org/x/net/context"
@@ -53,6 +54,7 @@ type Makers struct {
 	UnconfigureCluster     func(c resources.Cluster) fail.Error
 	JoinMasterToCluster    func(c resources.Cluster, host resources.Host) fail.Error
 	JoinNodeToCluster      func(c resources.Cluster, host resources.Host) fail.Error
+	VerifyNodeJoined       func(ctx context.Context, c resources.Cluster, host resources.Host, timeout time.Duration) fail.Error // waits at most timeout for the node to be confirmed member of the cluster
 	LeaveMasterFromCluster func(c resources.Cluster, host resources.Host) fail.Error
 	LeaveNodeFromCluster   func(c resources.Cluster, host resources.Host, selectedMaster resources.Host) fail.Error
 	GetState               func(c resources.Cluster) (clusterstate.Enum, fail.Error)
@@ -0,0 +1,345 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operations
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CS-SI/SafeScale/lib/protocol"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterflavor"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterproperty"
+	"github.com/CS-SI/SafeScale/lib/server/resources/operations/converters"
+	propertiesv3 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v3"
+	"github.com/CS-SI/SafeScale/lib/utils/cli/enums/outputs"
+	"github.com/CS-SI/SafeScale/lib/utils/concurrency"
+	"github.com/CS-SI/SafeScale/lib/utils/data"
+	"github.com/CS-SI/SafeScale/lib/utils/debug"
+	"github.com/CS-SI/SafeScale/lib/utils/debug/tracing"
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+	"github.com/CS-SI/SafeScale/lib/utils/serialize"
+)
+
+const (
+	// clusterHealthProbeTimeout bounds each probe of a Host of a Cluster; as the Hosts are probed in parallel, it bounds
+	// also the collection of the health of the whole Cluster, whatever the number of unreachable Hosts
+	clusterHealthProbeTimeout = 10 * time.Second
+	// clusterHealthCacheWindow is the duration during which the collected health of a Cluster is reused
+	clusterHealthCacheWindow = 5 * time.Second
+
+	// values of the reachability of the API server of a K8S Cluster
+	apiServerReachable   = "reachable"
+	apiServerUnreachable = "unreachable"
+	apiServerUnknown     = "unknown" // no master reachable to check it
+)
+
+// clusterHealthCache keeps the last health collected for a Cluster
+type clusterHealthCache struct {
+	lock        sync.Mutex // held during the collection, so concurrent inspects reuse the same collection
+	health      *protocol.ClusterHealth
+	collectedAt time.Time
+}
+
+// clusterHealthProbes collects the results of the probes of the Hosts of a Cluster, shared by the probing tasks
+type clusterHealthProbes struct {
+	lock    sync.Mutex
+	results map[string]*protocol.ClusterHostHealth // indexed by ID of Host
+}
+
+// set records the result of the probe of a Host
+func (p *clusterHealthProbes) set(result *protocol.ClusterHostHealth) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.results[result.Id] = result
+}
+
+// get returns a copy of the result of the probe of the Host identified by 'id'
+func (p *clusterHealthProbes) get(id string) *protocol.ClusterHostHealth {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	r := p.results[id]
+	return &protocol.ClusterHostHealth{Id: r.Id, Name: r.Name, Reachable: r.Reachable, Error: r.Error}
+}
+
+// InspectWithHealth returns the description of the Cluster as ToProtocol does, completed with its health: reachability
+// of gateways, masters and nodes and, for a K8S Cluster, of the API server
+// The Hosts are probed in parallel, each probe being bounded by clusterHealthProbeTimeout; the health collected is reused
+// during clusterHealthCacheWindow
+func (instance *Cluster) InspectWithHealth(ctx context.Context) (_ *protocol.ClusterResponse, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster")).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	out, xerr := instance.ToProtocol()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	out.Health, xerr = instance.getHealth(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	return out, nil
+}
+
+// getHealth returns the health of the Cluster, collected again if the cached one is older than clusterHealthCacheWindow
+func (instance *Cluster) getHealth(ctx context.Context) (*protocol.ClusterHealth, fail.Error) {
+	instance.healthCache.lock.Lock()
+	defer instance.healthCache.lock.Unlock()
+
+	if instance.healthCache.health != nil && time.Since(instance.healthCache.collectedAt) < clusterHealthCacheWindow {
+		return instance.healthCache.health, nil
+	}
+
+	health, xerr := instance.collectHealth(ctx)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	instance.healthCache.health = health
+	instance.healthCache.collectedAt = time.Now()
+	return health, nil
+}
+
+// collectHealth probes in parallel the Hosts of the Cluster and, for a K8S Cluster, its API server
+func (instance *Cluster) collectHealth(ctx context.Context) (_ *protocol.ClusterHealth, xerr fail.Error) {
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	var gateways, masters, nodes []*protocol.ClusterHostHealth
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		innerXErr := props.Inspect(clusterproperty.NetworkV3, func(clonable data.Clonable) fail.Error {
+			networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			for _, v := range []string{networkV3.GatewayID, networkV3.SecondaryGatewayID} {
+				if v != "" {
+					gateways = append(gateways, &protocol.ClusterHostHealth{Id: v})
+				}
+			}
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			for _, v := range nodesV3.Masters {
+				if node, found := nodesV3.ByNumericalID[v]; found && node.ID != "" {
+					masters = append(masters, &protocol.ClusterHostHealth{Id: node.ID, Name: node.Name})
+				}
+			}
+			for _, v := range nodesV3.PrivateNodes {
+				if node, found := nodesV3.ByNumericalID[v]; found && node.ID != "" {
+					nodes = append(nodes, &protocol.ClusterHostHealth{Id: node.ID, Name: node.Name})
+				}
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	// Until probed, a Host is considered unreachable; so a probe that did not end in time leaves its Host unreachable
+	probes := &clusterHealthProbes{results: map[string]*protocol.ClusterHostHealth{}}
+	all := append(append(append([]*protocol.ClusterHostHealth{}, gateways...), masters...), nodes...)
+	for _, v := range all {
+		probes.set(&protocol.ClusterHostHealth{Id: v.Id, Name: v.Name, Error: "probe did not end in time"})
+	}
+
+	tg, xerr := concurrency.NewTaskGroupWithParent(task)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	for _, v := range all {
+		_, xerr = tg.Start(instance.taskProbeClusterHost, taskProbeClusterHostParameters{probes: probes, id: v.Id, name: v.Name})
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			_ = tg.Abort()
+			return nil, xerr
+		}
+	}
+
+	// The probes report unreachable Hosts as results, not errors; the timeout is only a safety net
+	_, _, xerr = tg.WaitGroupFor(clusterHealthProbeTimeout + clusterHealthProbeTimeout/2)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrTimeout:
+			logrus.Warnf("probes of the Hosts of Cluster '%s' did not end in time", instance.GetName())
+		default:
+			return nil, xerr
+		}
+	}
+
+	for _, list := range [][]*protocol.ClusterHostHealth{gateways, masters, nodes} {
+		for k, v := range list {
+			list[k] = probes.get(v.Id)
+		}
+	}
+
+	health := summarizeClusterHealth(gateways, masters, nodes)
+	health.CollectedAt = converters.TimeToProtocol(time.Now())
+
+	flavor, xerr := instance.UnsafeGetFlavor()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if flavor == clusterflavor.K8S {
+		health.ApiServer = instance.probeAPIServer(task.GetContext(), masters)
+	}
+
+	return health, nil
+}
+
+// summarizeClusterHealth builds the health of a Cluster from the results of the probes of its Hosts
+func summarizeClusterHealth(gateways, masters, nodes []*protocol.ClusterHostHealth) *protocol.ClusterHealth {
+	out := &protocol.ClusterHealth{Gateways: gateways}
+	for _, v := range masters {
+		if v.Reachable {
+			out.ReachableMasters++
+		} else {
+			out.UnreachableMasters++
+			out.UnreachableHosts = append(out.UnreachableHosts, v)
+		}
+	}
+	for _, v := range nodes {
+		if v.Reachable {
+			out.ReachableNodes++
+		} else {
+			out.UnreachableNodes++
+			out.UnreachableHosts = append(out.UnreachableHosts, v)
+		}
+	}
+	return out
+}
+
+type taskProbeClusterHostParameters struct {
+	probes *clusterHealthProbes
+	id     string
+	name   string
+}
+
+// taskProbeClusterHost checks a Host of the Cluster is reachable by SSH, within clusterHealthProbeTimeout
+// The result is recorded in the probes of the parameters; an unreachable Host is not an error of the task
+func (instance *Cluster) taskProbeClusterHost(task concurrency.Task, params concurrency.TaskParameters) (_ concurrency.TaskResult, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if task == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("task")
+	}
+	p, ok := params.(taskProbeClusterHostParameters)
+	if !ok {
+		return nil, fail.InvalidParameterError("params", "must be a 'taskProbeClusterHostParameters'")
+	}
+
+	result := &protocol.ClusterHostHealth{Id: p.id, Name: p.name}
+	defer func() {
+		p.probes.set(result)
+	}()
+
+	hostInstance, xerr := LoadHost(instance.GetService(), p.id)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		result.Error = xerr.Error()
+		return nil, nil
+	}
+	defer hostInstance.Released()
+
+	result.Name = hostInstance.GetName()
+	retcode, _, stderr, xerr := hostInstance.Run(task.GetContext(), "true", outputs.COLLECT, clusterHealthProbeTimeout, clusterHealthProbeTimeout)
+	xerr = debug.InjectPlannedFail(xerr)
+	switch {
+	case xerr != nil:
+		result.Error = xerr.Error()
+	case retcode != 0:
+		result.Error = strings.TrimSpace(stderr)
+	default:
+		result.Reachable = true
+	}
+	return nil, nil
+}
+
+// probeAPIServer checks, from the first reachable master, that the API server of a K8S Cluster answers
+func (instance *Cluster) probeAPIServer(ctx context.Context, masters []*protocol.ClusterHostHealth) string {
+	for _, v := range masters {
+		if !v.Reachable {
+			continue
+		}
+
+		master, xerr := LoadHost(instance.GetService(), v.Id)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			continue
+		}
+
+		retcode, stdout, _, xerr := master.Run(ctx, "sudo -u cladm -i kubectl get --raw=/healthz", outputs.COLLECT, clusterHealthProbeTimeout, clusterHealthProbeTimeout)
+		master.Released()
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			// the master may have become unreachable in between, try with next one
+			continue
+		}
+		if retcode == 0 && strings.TrimSpace(stdout) == "ok" {
+			return apiServerReachable
+		}
+		return apiServerUnreachable
+	}
+	return apiServerUnknown
+}
@@ -24,6 +24,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -35,6 +36,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusternodetype"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterproperty"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/featuretarget"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/featuretargettype"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/installmethod"
 	"github.com/CS-SI/SafeScale/lib/server/resources/operations/remotefile"
@@ -46,6 +48,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
 	"github.com/CS-SI/SafeScale/lib/utils/debug/tracing"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
+	"github.com/CS-SI/SafeScale/lib/utils/retry"
 	"github.com/CS-SI/SafeScale/lib/utils/serialize"
 	"github.com/CS-SI/SafeScale/lib/utils/strprocess"
 	"github.com/CS-SI/SafeScale/lib/utils/temporal"
@@ -56,6 +59,13 @@ var (
 	clusterTemplateBox atomic.Value
 )
 
+const (
+	// defaultPackageInstallAttempts is the default maximum number of tries of a step installing packages on a Cluster node
+	defaultPackageInstallAttempts uint = 3
+	// packageInstallRetryDelay is the base delay between tries of a step installing packages (grows exponentially)
+	packageInstallRetryDelay = 10 * time.Second
+)
+
 // getTemplateBox
 func getTemplateBox() (*rice.Box, error) {
 	var (
@@ -338,6 +348,8 @@ func (instance *Cluster) ListInstalledFeatures(ctx context.Context) (_ []resourc
 }
 
 // AddFeature installs a feature on the Cluster
+// settings.Target allows to choose the Hosts of the Cluster on which the feature is installed, instead of the ones
+// targeted by the steps of the feature
 func (instance *Cluster) AddFeature(ctx context.Context, name string, vars data.Map, settings resources.FeatureSettings) (resources.Results, fail.Error) {
 	if instance == nil || instance.IsNull() {
 		return nil, fail.InvalidInstanceError()
@@ -621,14 +633,20 @@ func (instance *Cluster) installNodeRequirements(ctx context.Context, nodeType c
 	params["SSHPublicKey"] = identity.Keypair.PublicKey
 	params["SSHPrivateKey"] = identity.Keypair.PrivateKey
 
-	retcode, stdout, stderr, xerr := instance.ExecuteScript(ctx, "node_install_requirements.sh", params, host)
-	xerr = debug.InjectPlannedFail(xerr)
+	xerr = retryPackageInstall(ctx, hostLabel, "system requirements installation", func() fail.Error {
+		retcode, stdout, stderr, innerXErr := instance.ExecuteScript(ctx, "node_install_requirements.sh", params, host)
+		innerXErr = debug.InjectPlannedFail(innerXErr)
+		if innerXErr != nil {
+			return fail.Wrap(innerXErr, "[%s] system requirements installation failed", hostLabel)
+		}
+		if retcode != 0 {
+			innerXErr = fail.ExecutionError(nil, "failed to install common node requirements")
+			_ = innerXErr.Annotate("retcode", retcode).Annotate("stdout", stdout).Annotate("stderr", stderr)
+			return innerXErr
+		}
+		return nil
+	})
 	if xerr != nil {
-		return fail.Wrap(xerr, "[%s] system requirements installation failed", hostLabel)
-	}
-	if retcode != 0 {
-		xerr = fail.ExecutionError(nil, "failed to install common node requirements")
-		_ = xerr.Annotate("retcode", retcode).Annotate("stdout", stdout).Annotate("stderr", stderr)
 		return xerr
 	}
 
@@ -636,7 +654,63 @@ func (instance *Cluster) installNodeRequirements(ctx context.Context, nodeType c
 	return nil
 }
 
-// Installs reverseproxy
+// installDefaultFeature runs 'install' to add the default feature 'name' to the Cluster
+// If the feature has been declared best-effort at creation, a failure of the installation is only recorded in property
+// FeaturesV1 and does not fail the configuration of the Cluster; the record of a previous failure is removed on success
+func (instance *Cluster) installDefaultFeature(ctx context.Context, name string, install func(context.Context) fail.Error) fail.Error {
+	bestEffort := false
+	xerr := instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.FeaturesV1, func(clonable data.Clonable) fail.Error {
+			featuresV1, ok := clonable.(*propertiesv1.ClusterFeatures)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.ClusterFeatures' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+			_, bestEffort = featuresV1.BestEffort[name]
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	installXErr := install(ctx)
+	if installXErr != nil {
+		if !bestEffort {
+			return installXErr
+		}
+		if _, ok := installXErr.(*fail.ErrAborted); ok {
+			return installXErr
+		}
+		logrus.Warnf("[Cluster %s] failed to install feature '%s', ignored as best-effort: %v", instance.GetName(), name, installXErr)
+	}
+
+	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(clusterproperty.FeaturesV1, func(clonable data.Clonable) fail.Error {
+			featuresV1, ok := clonable.(*propertiesv1.ClusterFeatures)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.ClusterFeatures' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			if installXErr != nil {
+				if featuresV1.Failed == nil {
+					featuresV1.Failed = map[string]string{}
+				}
+				featuresV1.Failed[name] = installXErr.Error()
+			} else {
+				delete(featuresV1.Failed, name)
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return fail.Wrap(xerr, "failed to record outcome of installation of feature '%s'", name)
+	}
+	return nil
+}
+
+// installReverseProxy installs feature edgeproxy4subnet on all gateways of the Cluster
 func (instance *Cluster) installReverseProxy(ctx context.Context) (xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
@@ -671,7 +745,7 @@ func (instance *Cluster) installReverseProxy(ctx context.Context) (xerr fail.Err
 			return xerr
 		}
 
-		results, xerr := feat.Add(ctx, instance, data.Map{}, resources.FeatureSettings{})
+		results, xerr := feat.Add(ctx, instance, data.Map{}, resources.FeatureSettings{Target: featuretarget.Gateways})
 		xerr = debug.InjectPlannedFail(xerr)
 		if xerr != nil {
 			return xerr
@@ -725,12 +799,12 @@ func (instance *Cluster) installRemoteDesktop(ctx context.Context) (xerr fail.Er
 			return xerr
 		}
 
-		// Adds remotedesktop feature on Cluster (ie masters)
+		// Adds remotedesktop feature on masters of the Cluster
 		vars := data.Map{
 			"Username": "cladm",
 			"Password": identity.AdminPassword,
 		}
-		r, xerr := feat.Add(ctx, instance, vars, resources.FeatureSettings{})
+		r, xerr := feat.Add(ctx, instance, vars, resources.FeatureSettings{Target: featuretarget.Masters})
 		xerr = debug.InjectPlannedFail(xerr)
 		if xerr != nil {
 			return xerr
@@ -778,15 +852,21 @@ func (instance *Cluster) installProxyCacheClient(ctx context.Context, host resou
 			return xerr
 		}
 
-		r, xerr := feat.Add(ctx, host, data.Map{}, resources.FeatureSettings{})
+		xerr = retryPackageInstall(ctx, hostLabel, "installation of feature 'proxycache-client'", func() fail.Error {
+			r, innerXErr := feat.Add(ctx, host, data.Map{}, resources.FeatureSettings{})
+			if innerXErr != nil {
+				return innerXErr
+			}
+
+			if !r.Successful() {
+				msg := r.AllErrorMessages()
+				return fail.NewError("[%s] failed to install feature 'proxycache-client': %s", hostLabel, msg)
+			}
+			return nil
+		})
 		if xerr != nil {
 			return xerr
 		}
-
-		if !r.Successful() {
-			msg := r.AllErrorMessages()
-			return fail.NewError("[%s] failed to install feature 'proxycache-client': %s", hostLabel, msg)
-		}
 	}
 	return nil
 }
@@ -825,16 +905,22 @@ func (instance *Cluster) installProxyCacheServer(ctx context.Context, host resou
 			return xerr
 		}
 
-		r, xerr := feat.Add(ctx, host, data.Map{}, resources.FeatureSettings{})
-		xerr = debug.InjectPlannedFail(xerr)
+		xerr = retryPackageInstall(ctx, hostLabel, "installation of feature 'proxycache-server'", func() fail.Error {
+			r, innerXErr := feat.Add(ctx, host, data.Map{}, resources.FeatureSettings{})
+			innerXErr = debug.InjectPlannedFail(innerXErr)
+			if innerXErr != nil {
+				return innerXErr
+			}
+
+			if !r.Successful() {
+				msg := r.AllErrorMessages()
+				return fail.NewError("[%s] failed to install feature 'proxycache-server': %s", hostLabel, msg)
+			}
+			return nil
+		})
 		if xerr != nil {
 			return xerr
 		}
-
-		if !r.Successful() {
-			msg := r.AllErrorMessages()
-			return fail.NewError("[%s] failed to install feature 'proxycache-server': %s", hostLabel, msg)
-		}
 	}
 	return nil
 }
@@ -848,17 +934,124 @@ func (instance *Cluster) installDocker(ctx context.Context, host resources.Host,
 		return xerr
 	}
 
-	r, xerr := feat.Add(ctx, host, data.Map{}, resources.FeatureSettings{})
+	xerr = retryPackageInstall(ctx, hostLabel, "addition of feature 'docker'", func() fail.Error {
+		r, innerXErr := feat.Add(ctx, host, data.Map{}, resources.FeatureSettings{})
+		innerXErr = debug.InjectPlannedFail(innerXErr)
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		if !r.Successful() {
+			msg := r.AllErrorMessages()
+			logrus.Errorf("[%s] failed to add feature 'docker': %s", hostLabel, msg)
+			return fail.NewError("failed to add feature 'docker' on Host '%s': %s", host.GetName(), msg)
+		}
+		return nil
+	})
+	if xerr != nil {
+		return xerr
+	}
+	logrus.Debugf("[%s] feature 'docker' addition successful.", hostLabel)
+	return nil
+}
+
+// IsTransientPackageInstallError tells if a failure of a step installing packages is worth a retry (package manager lock
+// held, temporary failure of a mirror, ...). A package not found is a permanent failure, as is any failure not
+// recognized as transient.
+// May be replaced to adapt the classification to specific distributions.
+var IsTransientPackageInstallError = func(xerr fail.Error) bool {
+	switch xerr.(type) {
+	case *fail.ErrAborted, *fail.ErrInvalidRequest, *fail.ErrInvalidParameter, *fail.ErrNotFound:
+		return false
+	default:
+	}
+
+	// the output of the package manager is kept in annotations by script executions
+	msg := strings.ToLower(xerr.Error())
+	for _, v := range []string{"stdout", "stderr"} {
+		if anon, ok := xerr.Annotation(v); ok {
+			if output, ok := anon.(string); ok {
+				msg += "\n" + strings.ToLower(output)
+			}
+		}
+	}
+
+	for _, v := range []string{"unable to locate package", "has no installation candidate", "no match for argument", "no package "} {
+		if strings.Contains(msg, v) {
+			return false
+		}
+	}
+	for _, v := range []string{
+		"could not get lock", "unable to acquire the dpkg frontend lock", "is another process using it", "another app is currently holding the yum lock",
+		"temporary failure resolving", "temporary failure in name resolution", "could not resolve host", "failed to fetch", "hash sum mismatch",
+		"cannot find a valid baseurl", "failed to download metadata", "timeout was reached", "connection timed out", "connection reset by peer",
+	} {
+		if strings.Contains(msg, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// getPackageInstallAttempts returns the maximum number of tries of a step installing packages on a Cluster node,
+// that can be overridden by environment variable SAFESCALE_PACKAGE_INSTALL_ATTEMPTS
+func getPackageInstallAttempts() uint {
+	if candidate := os.Getenv("SAFESCALE_PACKAGE_INSTALL_ATTEMPTS"); candidate != "" {
+		if num, err := strconv.Atoi(candidate); err == nil && num > 0 {
+			return uint(num)
+		}
+		logrus.Warnf("Invalid value '%s' for SAFESCALE_PACKAGE_INSTALL_ATTEMPTS, using default", candidate)
+	}
+	return defaultPackageInstallAttempts
+}
+
+// retryPackageInstall runs 'install', retrying with exponential backoff on transient failure (as classified by
+// IsTransientPackageInstallError)
+// These retries are distinct from the retries of SSH connection done by each execution of 'install'.
+func retryPackageInstall(ctx context.Context, hostLabel, what string, install func() fail.Error) fail.Error {
+	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return xerr
 	}
 
-	if !r.Successful() {
-		msg := r.AllErrorMessages()
-		logrus.Errorf("[%s] failed to add feature 'docker': %s", hostLabel, msg)
-		return fail.NewError("failed to add feature 'docker': %s", host.GetName(), msg)
+	var (
+		lastXErr fail.Error
+		attempt  uint
+	)
+	attempts := getPackageInstallAttempts()
+	xerr = retry.Action(
+		func() error {
+			if task.Aborted() {
+				lastXErr = fail.AbortedError(nil, "aborted")
+				return retry.StopRetryError(lastXErr)
+			}
+
+			attempt++
+			innerXErr := install()
+			if innerXErr == nil {
+				return nil
+			}
+
+			lastXErr = innerXErr
+			if !IsTransientPackageInstallError(innerXErr) || attempt >= attempts {
+				return retry.StopRetryError(innerXErr)
+			}
+
+			logrus.Warnf("[%s] transient failure of %s (attempt %d/%d), retrying: %v", hostLabel, what, attempt, attempts, innerXErr)
+			return innerXErr
+		},
+		retry.PrevailDone(retry.Unsuccessful(), retry.Max(attempts)),
+		retry.Exponential(packageInstallRetryDelay),
+		nil,
+		nil,
+		nil,
+	)
+	if xerr != nil {
+		if lastXErr != nil {
+			return lastXErr
+		}
+		return xerr
 	}
-	logrus.Debugf("[%s] feature 'docker' addition successful.", hostLabel)
 	return nil
 }
@@ -0,0 +1,172 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operations
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CS-SI/SafeScale/lib/server/iaas"
+	"github.com/CS-SI/SafeScale/lib/server/resources"
+	"github.com/CS-SI/SafeScale/lib/utils/debug"
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+)
+
+// clusterMetadataBucketsFolderName is the folder, in the metadata bucket of the tenant, referencing the Clusters storing
+// their metadata in their own bucket
+const clusterMetadataBucketsFolderName = "clusterbuckets"
+
+// clusterMetadataBucket references the bucket storing the metadata of a Cluster
+type clusterMetadataBucket struct {
+	Name   string `json:"name"`
+	Bucket string `json:"bucket"`
+}
+
+// createMetadataBucketIfNeeded creates the bucket named 'bucketName' if it does not exist yet
+func createMetadataBucketIfNeeded(svc iaas.Service, bucketName string) fail.Error {
+	found, xerr := svc.FindBucket(bucketName)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+	if found {
+		return nil
+	}
+
+	_, xerr = svc.CreateBucket(bucketName)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return fail.Wrap(xerr, "failed to create metadata bucket '%s'", bucketName)
+	}
+	return nil
+}
+
+// newClusterMetadataBucketsFolder returns the MetadataFolder containing the references to Cluster metadata buckets,
+// always in the metadata bucket of the tenant
+func newClusterMetadataBucketsFolder(svc iaas.Service) (MetadataFolder, fail.Error) {
+	return NewMetadataFolder(iaas.TenantService(svc), clusterMetadataBucketsFolderName)
+}
+
+// registerClusterMetadataBucket records that the metadata of the Cluster named 'clusterName' are stored in the bucket
+// named 'bucketName'
+func registerClusterMetadataBucket(svc iaas.Service, clusterName, bucketName string) fail.Error {
+	folder, xerr := newClusterMetadataBucketsFolder(svc)
+	if xerr != nil {
+		return xerr
+	}
+
+	jsoned, err := json.Marshal(clusterMetadataBucket{Name: clusterName, Bucket: bucketName})
+	if err != nil {
+		return fail.ConvertError(err)
+	}
+	return folder.Write("", clusterName, jsoned)
+}
+
+// unregisterClusterMetadataBucket removes the reference to the metadata bucket of the Cluster named 'clusterName', if
+// there is one
+func unregisterClusterMetadataBucket(svc iaas.Service, clusterName string) fail.Error {
+	folder, xerr := newClusterMetadataBucketsFolder(svc)
+	if xerr != nil {
+		return xerr
+	}
+
+	xerr = folder.Lookup("", clusterName)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotFound:
+			return nil
+		default:
+			return xerr
+		}
+	}
+	return folder.Delete("", clusterName)
+}
+
+// loadClusterFromMetadataBucket reads the Cluster named 'name' from the bucket referenced for it
+// Returns fail.ErrNotFound if the Cluster does not store its metadata in its own bucket
+func loadClusterFromMetadataBucket(svc iaas.Service, name string) (resources.Cluster, fail.Error) {
+	folder, xerr := newClusterMetadataBucketsFolder(svc)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	var record clusterMetadataBucket
+	xerr = folder.Read("", name, func(buf []byte) fail.Error {
+		return fail.ConvertError(json.Unmarshal(buf, &record))
+	})
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	rc, xerr := NewCluster(iaas.TenantService(svc))
+	if xerr != nil {
+		return nil, xerr
+	}
+	xerr = rc.(*Cluster).MetadataCore.useMetadataBucket(record.Bucket)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if xerr = rc.Read(name); xerr != nil {
+		return nil, xerr
+	}
+	return rc, nil
+}
+
+// browseClustersInMetadataBuckets calls 'callback' with the metadata of each Cluster stored in its own bucket
+// Stops as soon as 'ctx' is done (see MetadataFolder.BrowseWithContext)
+func browseClustersInMetadataBuckets(ctx context.Context, svc iaas.Service, callback folderDecoderCallback) fail.Error {
+	if svc == nil {
+		return nil
+	}
+
+	folder, xerr := newClusterMetadataBucketsFolder(svc)
+	if xerr != nil {
+		return xerr
+	}
+
+	return folder.BrowseWithContext(ctx, "", func(buf []byte) fail.Error {
+		var record clusterMetadataBucket
+		if err := json.Unmarshal(buf, &record); err != nil {
+			return fail.ConvertError(err)
+		}
+
+		bucketSvc, xerr := iaas.WithMetadataBucket(svc, record.Bucket)
+		if xerr == nil {
+			var clusterFolder MetadataFolder
+			clusterFolder, xerr = NewMetadataFolder(bucketSvc, clustersFolderName)
+			if xerr != nil {
+				return xerr
+			}
+			if xerr = browseContextError(ctx); xerr != nil {
+				return xerr
+			}
+			xerr = clusterFolder.Read("", record.Name, callback)
+		}
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrNotFound:
+				logrus.Warnf("metadata of Cluster '%s' not found in bucket '%s', ignored: %v", record.Name, record.Bucket, xerr)
+				return nil
+			default:
+				return xerr
+			}
+		}
+		return nil
+	})
+}
@@ -19,8 +19,11 @@ package operations
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net"
+	"os"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -37,6 +40,7 @@ import (
 	propertiesv2 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v2"
 	propertiesv3 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v3"
 	"github.com/CS-SI/SafeScale/lib/utils"
+	"github.com/CS-SI/SafeScale/lib/utils/cli/enums/outputs"
 	"github.com/CS-SI/SafeScale/lib/utils/concurrency"
 	"github.com/CS-SI/SafeScale/lib/utils/data"
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
@@ -90,9 +94,13 @@ func (instance *Cluster) taskCreateCluster(tc concurrency.Task, params concurren
 				_ = xerr.AddConsequence(derr)
 			} else {
 				logrus.Debugf("Cleaning up on %s, successfully deleted metadata of Cluster '%s'", ActionFromError(xerr), req.Name)
+				if derr := unregisterClusterMetadataBucket(instance.GetService(), req.Name); derr != nil {
+					_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on %s, failed to remove reference to metadata bucket of Cluster '%s'", ActionFromError(xerr), req.Name))
+				}
 			}
 		}
 	}()
+	defer recordOperation(instance, clusterproperty.LastOperationV1, "create")(&xerr)
 
 	if task.Aborted() {
 		return nil, fail.AbortedError(nil, "aborted")
@@ -133,10 +141,11 @@ func (instance *Cluster) taskCreateCluster(tc concurrency.Task, params concurren
 	var rs resources.Subnet
 
 	defer func() {
-		if xerr != nil && !req.KeepOnFailure {
+		// Subnet of an existing gateway is not owned by the Cluster
+		if xerr != nil && !req.KeepOnFailure && req.ExistingGatewayID == "" {
 			if rs != nil && rn != nil {
 				logrus.Debugf("Cleaning up on failure, deleting Subnet '%s'...", rs.GetName())
-				if derr := rs.Delete(context.Background()); derr != nil {
+				if derr := rs.Delete(concurrency.DetachedContext(tc.GetContext())); derr != nil {
 					switch derr.(type) {
 					case *fail.ErrNotFound:
 						// missing Subnet is considered as a successful deletion, continue
@@ -149,7 +158,7 @@ func (instance *Cluster) taskCreateCluster(tc concurrency.Task, params concurren
 					logrus.Debugf("Cleaning up on %s, successfully deleted Subnet '%s'", ActionFromError(xerr), rs.GetName())
 					if req.NetworkID == "" {
 						logrus.Debugf("Cleaning up on %s, deleting Network '%s'...", ActionFromError(xerr), rn.GetName())
-						if derr := rn.Delete(context.Background()); derr != nil {
+						if derr := rn.Delete(concurrency.DetachedContext(tc.GetContext())); derr != nil {
 							switch derr.(type) {
 							case *fail.ErrNotFound:
 								// missing Network is considered as a successful deletion, continue
@@ -217,6 +226,11 @@ func (instance *Cluster) taskCreateCluster(tc concurrency.Task, params concurren
 		return nil, xerr
 	}
 
+	// Tags the networking resources; a failure does not compromise the Cluster, tags can be set again with RetagResources()
+	if tagErr := instance.tagNetworkingResources(); tagErr != nil {
+		logrus.Warnf("failed to tag networking resources of Cluster '%s': %v", req.Name, tagErr)
+	}
+
 	// Creates and configures hosts
 	xerr = instance.createHostResources(task, rs, *mastersDef, *nodesDef, req.InitialNodeCount, req.KeepOnFailure)
 	xerr = debug.InjectPlannedFail(xerr)
@@ -233,19 +247,285 @@ func (instance *Cluster) taskCreateCluster(tc concurrency.Task, params concurren
 
 	// Sets nominal state of the new Cluster in metadata
 	xerr = instance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Alter(clusterproperty.StateV1, func(clonable data.Clonable) fail.Error {
+		return setClusterStateInProperties(props, clusterstate.Nominal, "created")
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	return nil, xerr
+}
+
+// taskResumeCluster is the TaskAction that continues the creation of a Cluster interrupted while in state Creating
+// Every step is run again, reusing the resources already created and recorded in metadata
+func (instance *Cluster) taskResumeCluster(tc concurrency.Task, _ concurrency.TaskParameters) (_ concurrency.TaskResult, xerr fail.Error) {
+	task, xerr := concurrency.NewTaskGroupWithParent(tc)
+	if xerr != nil {
+		return nil, xerr
+	}
+	ctx := task.GetContext()
+
+	var state clusterstate.Enum
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.StateV1, func(clonable data.Clonable) fail.Error {
 			stateV1, ok := clonable.(*propertiesv1.ClusterState)
 			if !ok {
 				return fail.InconsistentError("'*propertiesv1.ClusterState' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
-			stateV1.State = clusterstate.Nominal
+
+			state = stateV1.State
 			return nil
 		})
 	})
 	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+	if state != clusterstate.Creating {
+		return nil, fail.InvalidRequestError("cannot resume creation of Cluster '%s': its state is '%s', not '%s'", instance.GetName(), state.String(), clusterstate.Creating.String())
+	}
+
+	req, xerr := instance.buildResumeRequest()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	// Hosts registered in metadata without ID were being created when the interruption occurred; remove them to start clean
+	xerr = instance.purgeUncompletedHosts()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	gatewaysDef, mastersDef, nodesDef, xerr := instance.determineSizingRequirements(req)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	// Create or reuse the Network and Subnet
+	_, rs, xerr := instance.createNetworkingResources(task, req, gatewaysDef)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	// Tags the networking resources; a failure does not compromise the Cluster, tags can be set again with RetagResources()
+	if tagErr := instance.tagNetworkingResources(); tagErr != nil {
+		logrus.Warnf("failed to tag networking resources of Cluster '%s': %v", req.Name, tagErr)
+	}
+
+	// Creates missing hosts and (re)configures all of them
+	xerr = instance.createHostResources(task, rs, *mastersDef, *nodesDef, req.InitialNodeCount, req.KeepOnFailure)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	xerr = instance.configureCluster(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	xerr = instance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return setClusterStateInProperties(props, clusterstate.Nominal, "creation resumed")
+	})
+	xerr = debug.InjectPlannedFail(xerr)
 	return nil, xerr
 }
 
+// buildResumeRequest rebuilds, from metadata, the ClusterRequest used to create the Cluster
+// The initial count of nodes is not recorded in metadata, so the minimum required by the Flavor is used; more nodes can be
+// added once the Cluster is created.
+// KeepOnFailure is forced to true, so a failing resume does not destroy what previous attempts built.
+func (instance *Cluster) buildResumeRequest() (req abstract.ClusterRequest, xerr fail.Error) {
+	var defaults *propertiesv2.ClusterDefaults
+	xerr = instance.Review(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+		aci, ok := clonable.(*abstract.ClusterIdentity)
+		if !ok {
+			return fail.InconsistentError("'*abstract.ClusterIdentity' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		req.Name = aci.Name
+		req.Flavor = aci.Flavor
+		req.Complexity = aci.Complexity
+
+		innerXErr := props.Inspect(clusterproperty.DefaultsV2, func(clonable data.Clonable) fail.Error {
+			defaultsV2, ok := clonable.(*propertiesv2.ClusterDefaults)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.ClusterDefaults' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			defaults = defaultsV2.Clone().(*propertiesv2.ClusterDefaults)
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		innerXErr = props.Inspect(clusterproperty.FeaturesV1, func(clonable data.Clonable) fail.Error {
+			featuresV1, ok := clonable.(*propertiesv1.ClusterFeatures)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.ClusterFeatures' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			req.DisabledDefaultFeatures = make(map[string]struct{}, len(featuresV1.Disabled))
+			for k := range featuresV1.Disabled {
+				req.DisabledDefaultFeatures[k] = struct{}{}
+			}
+			req.BestEffortFeatures = make(map[string]struct{}, len(featuresV1.BestEffort))
+			for k := range featuresV1.BestEffort {
+				req.BestEffortFeatures[k] = struct{}{}
+			}
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		innerXErr = props.Inspect(clusterproperty.CompositeV1, func(clonable data.Clonable) fail.Error {
+			compositeV1, ok := clonable.(*propertiesv1.ClusterComposite)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.ClusterComposite' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			if len(compositeV1.Tenants) > 0 {
+				req.Tenant = compositeV1.Tenants[0]
+			}
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		return props.Inspect(clusterproperty.NetworkV3, func(clonable data.Clonable) fail.Error {
+			networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			req.CIDR = networkV3.CIDR
+			req.Domain = networkV3.Domain
+			if !networkV3.CreatedNetwork {
+				req.NetworkID = networkV3.NetworkID
+			}
+			if networkV3.ExternalGateway {
+				req.ExistingGatewayID = networkV3.GatewayID
+			}
+			return nil
+		})
+	})
+	if xerr != nil {
+		return abstract.ClusterRequest{}, xerr
+	}
+
+	if req.CIDR == "" && req.NetworkID == "" {
+		return abstract.ClusterRequest{}, fail.InconsistentError("cannot resume creation of Cluster '%s': CIDR of the Network has not been recorded", req.Name)
+	}
+
+	req.OS = defaults.Image
+	req.GatewaysDef = complementHostDefinition(abstract.HostSizingRequirements{Image: defaults.Image}, defaults.GatewaySizing)
+	req.MastersDef = complementHostDefinition(abstract.HostSizingRequirements{Image: defaults.Image}, defaults.MasterSizing)
+	req.NodesDef = complementHostDefinition(abstract.HostSizingRequirements{Image: defaults.Image}, defaults.NodeSizing)
+	req.KeepOnFailure = true
+
+	_, req.InitialNodeCount, _, xerr = instance.determineRequiredNodes()
+	if xerr != nil {
+		return abstract.ClusterRequest{}, xerr
+	}
+
+	return req, nil
+}
+
+// countCreatedHosts returns the number of masters and nodes completely created and recorded in metadata
+func (instance *Cluster) countCreatedHosts() (masters uint, nodes uint, xerr fail.Error) {
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			masters = uint(len(nodesV3.Masters))
+			nodes = uint(len(nodesV3.PrivateNodes))
+			return nil
+		})
+	})
+	if xerr != nil {
+		return 0, 0, xerr
+	}
+
+	return masters, nodes, nil
+}
+
+// purgeUncompletedHosts deletes the hosts registered in metadata but whose creation did not complete
+func (instance *Cluster) purgeUncompletedHosts() fail.Error {
+	var uncompleted []*propertiesv3.ClusterNode
+	xerr := instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			for _, v := range nodesV3.ByNumericalID {
+				if v.ID == "" {
+					uncompleted = append(uncompleted, v)
+				}
+			}
+			return nil
+		})
+	})
+	if xerr != nil {
+		return xerr
+	}
+
+	svc := instance.GetService()
+	for _, v := range uncompleted {
+		// The Host may exist on provider side, created just before the interruption; if so, deletes it
+		hostInstance, xerr := LoadHost(svc, v.Name)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrNotFound:
+				// Host has not been created, continue
+			default:
+				return xerr
+			}
+		} else {
+			logrus.Debugf("[Cluster %s] deleting Host '%s' whose creation has been interrupted", instance.GetName(), v.Name)
+			xerr = hostInstance.Delete(context.Background())
+			if xerr != nil {
+				switch xerr.(type) {
+				case *fail.ErrNotFound:
+					// missing Host is considered as a successful deletion, continue
+				default:
+					return fail.Wrap(xerr, "failed to delete Host '%s' whose creation has been interrupted", v.Name)
+				}
+			}
+		}
+
+		xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+			return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+				nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				delete(nodesV3.ByNumericalID, v.NumericalID)
+				return nil
+			})
+		})
+		if xerr != nil {
+			return xerr
+		}
+	}
+
+	return nil
+}
+
 // firstLight contains the code leading to Cluster first metadata written
 func (instance *Cluster) firstLight(req abstract.ClusterRequest) fail.Error {
 	if req.Name = strings.TrimSpace(req.Name); req.Name == "" {
@@ -264,6 +544,15 @@ func (instance *Cluster) firstLight(req abstract.ClusterRequest) fail.Error {
 		return xerr
 	}
 
+	// Cluster stored in its own bucket must be findable from the metadata bucket of the tenant
+	if req.MetadataBucket != "" {
+		xerr = registerClusterMetadataBucket(instance.GetService(), req.Name, instance.GetService().GetMetadataBucket().Name)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+	}
+
 	xerr = instance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
 		aci, ok := clonable.(*abstract.ClusterIdentity)
 		if !ok {
@@ -281,6 +570,12 @@ func (instance *Cluster) firstLight(req abstract.ClusterRequest) fail.Error {
 			for k := range req.DisabledDefaultFeatures {
 				featuresV1.Disabled[k] = struct{}{}
 			}
+			if featuresV1.BestEffort == nil {
+				featuresV1.BestEffort = map[string]struct{}{}
+			}
+			for k := range req.BestEffortFeatures {
+				featuresV1.BestEffort[k] = struct{}{}
+			}
 			return nil
 		})
 		if innerXErr != nil {
@@ -288,14 +583,7 @@ func (instance *Cluster) firstLight(req abstract.ClusterRequest) fail.Error {
 		}
 
 		// Sets initial state of the new Cluster and create metadata
-		innerXErr = props.Alter(clusterproperty.StateV1, func(clonable data.Clonable) fail.Error {
-			stateV1, ok := clonable.(*propertiesv1.ClusterState)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv1.ClusterState' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
-			stateV1.State = clusterstate.Creating
-			return nil
-		})
+		innerXErr = setClusterStateInProperties(props, clusterstate.Creating, "create requested")
 		if innerXErr != nil {
 			return fail.Wrap(innerXErr, "failed to set initial state of Cluster")
 		}
@@ -331,6 +619,20 @@ func (instance *Cluster) firstLight(req abstract.ClusterRequest) fail.Error {
 			return innerXErr
 		}
 
+		// records the tags to set on the resources of the Cluster in the provider
+		innerXErr = props.Alter(clusterproperty.TagsV1, func(clonable data.Clonable) (innerXErr fail.Error) {
+			tagsV1, ok := clonable.(*propertiesv1.ClusterTags)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.ClusterTags' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			tagsV1.Tags, innerXErr = buildClusterTags(req.Name, req.Tags)
+			return innerXErr
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
 		// Create a KeyPair for the user cladm
 		kpName := "cluster_" + req.Name + "_cladm_key"
 		kp, innerXErr := abstract.NewKeyPair(kpName)
@@ -537,9 +839,38 @@ func (instance *Cluster) createNetworkingResources(task concurrency.Task, req ab
 
 	req.Name = strings.ToLower(strings.TrimSpace(req.Name))
 
+	if req.ExistingGatewayID != "" {
+		return instance.adoptExistingGateway(ctx, req)
+	}
+
+	// Recovers what may have been recorded by a previous interrupted creation, to reuse it
+	var previous *propertiesv3.ClusterNetwork
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(clusterproperty.NetworkV3, func(clonable data.Clonable) fail.Error {
+			networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			previous = networkV3.Clone().(*propertiesv3.ClusterNetwork)
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, nil, xerr
+	}
+	resuming := previous.NetworkID != ""
+
 	// Creates Network
 	var rn resources.Network
-	if req.NetworkID != "" {
+	if resuming {
+		rn, xerr = LoadNetwork(instance.GetService(), previous.NetworkID)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return nil, nil, fail.Wrap(xerr, "failed to reuse Network %s recorded for Cluster", previous.NetworkID)
+		}
+	} else if req.NetworkID != "" {
 		rn, xerr = LoadNetwork(instance.GetService(), req.NetworkID)
 		xerr = debug.InjectPlannedFail(xerr)
 		if xerr != nil {
@@ -567,8 +898,8 @@ func (instance *Cluster) createNetworkingResources(task concurrency.Task, req ab
 
 		defer func() {
 			if xerr != nil && !req.KeepOnFailure {
-				// Using context.Background() here disables abort
-				if derr := rn.Delete(context.Background()); derr != nil {
+				// Using a detached context here disables abort
+				if derr := rn.Delete(concurrency.DetachedContext(task.GetContext())); derr != nil {
 					switch derr.(type) {
 					case *fail.ErrNotFound:
 						// missing Network is considered as a successful deletion, continue
@@ -587,8 +918,11 @@ func (instance *Cluster) createNetworkingResources(task concurrency.Task, req ab
 			}
 
 			networkV3.NetworkID = rn.GetID()
-			networkV3.CreatedNetwork = req.NetworkID == "" // empty NetworkID means that the Network would have to be deleted when the Cluster will be
-			networkV3.CIDR = req.CIDR
+			if !resuming {
+				networkV3.CreatedNetwork = req.NetworkID == "" // empty NetworkID means that the Network would have to be deleted when the Cluster will be
+				networkV3.CIDR = req.CIDR
+				networkV3.Domain = req.Domain
+			}
 			return nil
 		})
 	})
@@ -608,49 +942,38 @@ func (instance *Cluster) createNetworkingResources(task concurrency.Task, req ab
 		NetworkID:     rn.GetID(),
 		CIDR:          req.CIDR,
 		HA:            !gwFailoverDisabled,
+		Domain:        req.Domain,
 		Image:         gatewaysDef.Image,
 		KeepOnFailure: false, // We consider subnet and its gateways as a whole; if any error occurs during the creation of the whole, do keep nothing
 	}
 
-	subnetInstance, xerr := NewSubnet(instance.GetService())
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return nil, nil, xerr
-	}
-
-	xerr = subnetInstance.Create(ctx, subnetReq, "", gatewaysDef)
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		switch xerr.(type) {
-		case *fail.ErrInvalidRequest:
-			// Some cloud providers do not allow to create a Subnet with the same CIDR than the Network; try with a sub-CIDR once
-			logrus.Warnf("Cloud Provider does not allow to use the same CIDR than the Network one, trying a subset of CIDR...")
-			_, ipNet, err := net.ParseCIDR(subnetReq.CIDR)
-			err = debug.InjectPlannedError(err)
-			if err != nil {
-				_ = xerr.AddConsequence(fail.Wrap(err, "failed to compute subset of CIDR '%s'", req.CIDR))
-				return nil, nil, xerr
-			}
-
-			if subIPNet, subXErr := netutils.FirstIncludedSubnet(*ipNet, 1); subXErr == nil {
-				subnetReq.CIDR = subIPNet.String()
-			} else {
-				_ = xerr.AddConsequence(fail.Wrap(subXErr, "failed to compute subset of CIDR '%s'", req.CIDR))
+	// When resuming, the Subnet may have been created without being recorded in Cluster metadata; reuse it if it exists
+	var subnetInstance resources.Subnet
+	if resuming {
+		subnetRef := previous.SubnetID
+		if subnetRef == "" {
+			subnetRef = req.Name
+		}
+		subnetInstance, xerr = LoadSubnet(instance.GetService(), rn.GetID(), subnetRef)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrNotFound:
+				subnetInstance = nil
+			default:
 				return nil, nil, xerr
 			}
-			if subXErr := subnetInstance.Create(ctx, subnetReq, "", gatewaysDef); subXErr != nil {
-				return nil, nil, fail.Wrap(subXErr, "failed to create Subnet '%s' (with CIDR %s) in Network '%s' (with CIDR %s)", subnetReq.Name, subnetReq.CIDR, rn.GetName(), req.CIDR)
-			}
-			logrus.Infof("CIDR '%s' used successfully for Subnet, there will be less available private IP Addresses than expected.", subnetReq.CIDR)
-			xerr = nil
-		default:
-			return nil, nil, fail.Wrap(xerr, "failed to create Subnet '%s' in Network '%s'", req.Name, rn.GetName())
 		}
 	}
+	if subnetInstance != nil {
+		logrus.Debugf("[Cluster %s] reusing Subnet '%s' created previously", req.Name, subnetInstance.GetName())
+	} else if subnetInstance, xerr = instance.createClusterSubnet(ctx, rn, req, subnetReq, gatewaysDef); xerr != nil {
+		return nil, nil, xerr
+	}
 
 	defer func() {
 		if xerr != nil && !req.KeepOnFailure {
-			if derr := subnetInstance.Delete(context.Background()); derr != nil {
+			if derr := subnetInstance.Delete(concurrency.DetachedContext(task.GetContext())); derr != nil {
 				switch derr.(type) {
 				case *fail.ErrNotFound:
 					// missing Subnet is considered as a successful deletion, continue
@@ -719,6 +1042,164 @@ func (instance *Cluster) createNetworkingResources(task concurrency.Task, req ab
 	return rn, subnetInstance, nil
 }
 
+// adoptExistingGateway makes the Cluster use the Subnet of the existing gateway designated by req.ExistingGatewayID,
+// instead of creating a Subnet with its gateways
+// The gateway has to be reachable by SSH and be a gateway of its default Subnet; the Subnet has to be in the Network
+// req.NetworkID if set. Gateway and Subnet are recorded as external, so they are not deleted with the Cluster.
+func (instance *Cluster) adoptExistingGateway(ctx context.Context, req abstract.ClusterRequest) (_ resources.Network, _ resources.Subnet, xerr fail.Error) {
+	svc := instance.GetService()
+	gateway, xerr := LoadHost(svc, req.ExistingGatewayID)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, nil, fail.Wrap(xerr, "failed to load gateway '%s'", req.ExistingGatewayID)
+	}
+	defer gateway.Released()
+
+	isGateway, xerr := gateway.IsGateway()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, nil, xerr
+	}
+	if !isGateway {
+		return nil, nil, fail.InvalidRequestError("Host '%s' is not a gateway", gateway.GetName())
+	}
+
+	subnetInstance, xerr := gateway.GetDefaultSubnet()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, nil, xerr
+	}
+
+	// The Host has to be the primary or the secondary gateway of the Subnet
+	onSubnet := false
+	for _, primary := range []bool{true, false} {
+		subnetGateway, innerXErr := subnetInstance.InspectGateway(primary)
+		if innerXErr != nil {
+			switch innerXErr.(type) {
+			case *fail.ErrNotFound:
+				continue
+			default:
+				return nil, nil, innerXErr
+			}
+		}
+		if subnetGateway.GetID() == gateway.GetID() {
+			onSubnet = true
+			break
+		}
+	}
+	if !onSubnet {
+		return nil, nil, fail.InvalidRequestError("Host '%s' is not a gateway of Subnet '%s'", gateway.GetName(), subnetInstance.GetName())
+	}
+
+	networkInstance, xerr := subnetInstance.InspectNetwork()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, nil, xerr
+	}
+	if req.NetworkID != "" && req.NetworkID != networkInstance.GetID() && req.NetworkID != networkInstance.GetName() {
+		return nil, nil, fail.InvalidRequestError("Subnet '%s' of gateway '%s' is not in Network '%s'", subnetInstance.GetName(), gateway.GetName(), req.NetworkID)
+	}
+
+	_, xerr = gateway.WaitSSHReady(ctx, temporal.GetConnectSSHTimeout())
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, nil, fail.Wrap(xerr, "gateway '%s' is not reachable", gateway.GetName())
+	}
+
+	var cidr string
+	xerr = subnetInstance.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+		as, ok := clonable.(*abstract.Subnet)
+		if !ok {
+			return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		cidr = as.CIDR
+		return nil
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, nil, xerr
+	}
+
+	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(clusterproperty.NetworkV3, func(clonable data.Clonable) fail.Error {
+			networkV3, ok := clonable.(*propertiesv3.ClusterNetwork)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv3.ClusterNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			var innerXErr fail.Error
+			networkV3.NetworkID = networkInstance.GetID()
+			networkV3.CreatedNetwork = false
+			networkV3.ExternalGateway = true
+			networkV3.SubnetID = subnetInstance.GetID()
+			networkV3.CIDR = cidr
+			networkV3.Domain = req.Domain
+			networkV3.GatewayID = gateway.GetID()
+			if networkV3.GatewayIP, innerXErr = gateway.GetPrivateIP(); innerXErr != nil {
+				return innerXErr
+			}
+			if networkV3.PrimaryPublicIP, innerXErr = gateway.GetPublicIP(); innerXErr != nil {
+				return innerXErr
+			}
+			if networkV3.DefaultRouteIP, innerXErr = subnetInstance.GetDefaultRouteIP(); innerXErr != nil {
+				return innerXErr
+			}
+			if networkV3.EndpointIP, innerXErr = subnetInstance.GetEndpointIP(); innerXErr != nil {
+				return innerXErr
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, nil, xerr
+	}
+
+	logrus.Debugf("[Cluster %s] using Subnet '%s' of existing gateway '%s'", req.Name, subnetInstance.GetName(), gateway.GetName())
+	return networkInstance, subnetInstance, nil
+}
+
+// createClusterSubnet creates the Subnet of the Cluster, retrying with a sub-CIDR if the provider refuses to use the CIDR of the Network
+func (instance *Cluster) createClusterSubnet(ctx context.Context, rn resources.Network, req abstract.ClusterRequest, subnetReq abstract.SubnetRequest, gatewaysDef *abstract.HostSizingRequirements) (resources.Subnet, fail.Error) {
+	subnetInstance, xerr := NewSubnet(instance.GetService())
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	xerr = subnetInstance.Create(ctx, subnetReq, "", gatewaysDef)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrInvalidRequest:
+			// Some cloud providers do not allow to create a Subnet with the same CIDR than the Network; try with a sub-CIDR once
+			logrus.Warnf("Cloud Provider does not allow to use the same CIDR than the Network one, trying a subset of CIDR...")
+			_, ipNet, err := net.ParseCIDR(subnetReq.CIDR)
+			err = debug.InjectPlannedError(err)
+			if err != nil {
+				_ = xerr.AddConsequence(fail.Wrap(err, "failed to compute subset of CIDR '%s'", req.CIDR))
+				return nil, xerr
+			}
+
+			if subIPNet, subXErr := netutils.FirstIncludedSubnet(*ipNet, 1); subXErr == nil {
+				subnetReq.CIDR = subIPNet.String()
+			} else {
+				_ = xerr.AddConsequence(fail.Wrap(subXErr, "failed to compute subset of CIDR '%s'", req.CIDR))
+				return nil, xerr
+			}
+			if subXErr := subnetInstance.Create(ctx, subnetReq, "", gatewaysDef); subXErr != nil {
+				return nil, fail.Wrap(subXErr, "failed to create Subnet '%s' (with CIDR %s) in Network '%s' (with CIDR %s)", subnetReq.Name, subnetReq.CIDR, rn.GetName(), req.CIDR)
+			}
+			logrus.Infof("CIDR '%s' used successfully for Subnet, there will be less available private IP Addresses than expected.", subnetReq.CIDR)
+		default:
+			return nil, fail.Wrap(xerr, "failed to create Subnet '%s' in Network '%s'", req.Name, rn.GetName())
+		}
+	}
+
+	return subnetInstance, nil
+}
+
 // createHostResources creates and configures hosts for the Cluster
 func (instance *Cluster) createHostResources(
 	task concurrency.Task,
@@ -753,22 +1234,36 @@ func (instance *Cluster) createHostResources(
 		mastersStatus, privateNodesStatus fail.Error
 	)
 
-	primaryGateway, xerr = subnet.InspectGateway(true)
+	netCfg, xerr := instance.GetNetworkConfig()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// An existing gateway is used as is, only the one designated is used by the Cluster
+	externalGateway := netCfg.ExternalGateway
+	haveSecondaryGateway := !externalGateway
+	if externalGateway {
+		primaryGateway, xerr = LoadHost(instance.GetService(), netCfg.GatewayID)
+	} else {
+		primaryGateway, xerr = subnet.InspectGateway(true)
+	}
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return xerr
 	}
 
-	haveSecondaryGateway := true
-	secondaryGateway, xerr = subnet.InspectGateway(false)
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		switch xerr.(type) {
-		case *fail.ErrNotFound:
-			// It's a valid state not to have a secondary gateway, so continue
-			haveSecondaryGateway = false
-		default:
-			return xerr
+	if haveSecondaryGateway {
+		secondaryGateway, xerr = subnet.InspectGateway(false)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrNotFound:
+				// It's a valid state not to have a secondary gateway, so continue
+				haveSecondaryGateway = false
+			default:
+				return xerr
+			}
 		}
 	}
 
@@ -796,6 +1291,23 @@ func (instance *Cluster) createHostResources(
 		return xerr
 	}
 
+	// Hosts already created (when resuming an interrupted creation) are not created again
+	existingMasters, existingNodes, xerr := instance.countCreatedHosts()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+	if existingMasters >= masterCount {
+		masterCount = 0
+	} else {
+		masterCount -= existingMasters
+	}
+	if existingNodes >= initialNodeCount {
+		initialNodeCount = 0
+	} else {
+		initialNodeCount -= existingNodes
+	}
+
 	if task.Aborted() {
 		return fail.AbortedError(nil, "aborted")
 	}
@@ -806,10 +1318,12 @@ func (instance *Cluster) createHostResources(
 	}
 
 	// Step 1: starts gateway installation plus masters creation plus nodes creation
-	_, xerr = gwInstallTasks.StartInSubtask(instance.taskInstallGateway, taskInstallGatewayParameters{primaryGateway})
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return xerr
+	if !externalGateway {
+		_, xerr = gwInstallTasks.StartInSubtask(instance.taskInstallGateway, taskInstallGatewayParameters{primaryGateway})
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
 	}
 	startedTasks = append(startedTasks, gwInstallTasks)
 
@@ -858,16 +1372,20 @@ func (instance *Cluster) createHostResources(
 		}
 	}()
 
-	mastersTask, xerr := task.StartInSubtask(instance.taskCreateMasters, taskCreateMastersParameters{
-		count:         masterCount,
-		mastersDef:    mastersDef,
-		keepOnFailure: keepOnFailure,
-	})
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return xerr
+	var mastersTask concurrency.Task
+	if masterCount > 0 {
+		mastersTask, xerr = task.StartInSubtask(instance.taskCreateMasters, taskCreateMastersParameters{
+			count:         masterCount,
+			firstIndex:    existingMasters + 1,
+			mastersDef:    mastersDef,
+			keepOnFailure: keepOnFailure,
+		})
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+		startedTasks = append(startedTasks, mastersTask)
 	}
-	startedTasks = append(startedTasks, mastersTask)
 
 	if task.Aborted() {
 		return fail.AbortedError(nil, "aborted")
@@ -902,17 +1420,21 @@ func (instance *Cluster) createHostResources(
 		}
 	}()
 
-	privateNodesTask, xerr := task.StartInSubtask(instance.taskCreateNodes, taskCreateNodesParameters{
-		count:         initialNodeCount,
-		public:        false,
-		nodesDef:      nodesDef,
-		keepOnFailure: keepOnFailure,
-	})
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return xerr
+	var privateNodesTask concurrency.Task
+	if initialNodeCount > 0 {
+		privateNodesTask, xerr = task.StartInSubtask(instance.taskCreateNodes, taskCreateNodesParameters{
+			count:         initialNodeCount,
+			firstIndex:    existingNodes + 1,
+			public:        false,
+			nodesDef:      nodesDef,
+			keepOnFailure: keepOnFailure,
+		})
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+		startedTasks = append(startedTasks, privateNodesTask)
 	}
-	startedTasks = append(startedTasks, privateNodesTask)
 
 	if task.Aborted() {
 		return fail.AbortedError(nil, "aborted")
@@ -923,8 +1445,10 @@ func (instance *Cluster) createHostResources(
 		return gatewayInstallStatus
 	}
 
-	if _, mastersStatus = mastersTask.Wait(); mastersStatus != nil {
-		return mastersStatus
+	if mastersTask != nil {
+		if _, mastersStatus = mastersTask.Wait(); mastersStatus != nil {
+			return mastersStatus
+		}
 	}
 
 	if task.Aborted() {
@@ -964,8 +1488,10 @@ func (instance *Cluster) createHostResources(
 	}
 
 	// Step 5: awaits nodes creation
-	if _, privateNodesStatus = privateNodesTask.Wait(); privateNodesStatus != nil {
-		return privateNodesStatus
+	if privateNodesTask != nil {
+		if _, privateNodesStatus = privateNodesTask.Wait(); privateNodesStatus != nil {
+			return privateNodesStatus
+		}
 	}
 
 	if task.Aborted() {
@@ -1103,6 +1629,248 @@ func (instance *Cluster) taskStopHost(task concurrency.Task, params concurrency.
 	return nil, xerr
 }
 
+type taskStreamHostLogParameters struct {
+	hostID  string
+	path    string
+	lines   uint
+	handler func(host, line string)
+}
+
+// taskStreamHostLog follows a file on a Host of the Cluster, until the task is aborted
+func (instance *Cluster) taskStreamHostLog(task concurrency.Task, params concurrency.TaskParameters) (_ concurrency.TaskResult, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if task == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("task")
+	}
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	p, ok := params.(taskStreamHostLogParameters)
+	if !ok {
+		return nil, fail.InvalidParameterError("params", "must be a 'taskStreamHostLogParameters'")
+	}
+	if p.hostID == "" {
+		return nil, fail.InvalidParameterCannotBeEmptyStringError("params.hostID")
+	}
+	if p.handler == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("params.handler")
+	}
+
+	host, xerr := LoadHost(instance.GetService(), p.hostID)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		logrus.Warnf("failed to follow '%s' on Host '%s' of Cluster '%s': %s", p.path, p.hostID, instance.GetName(), xerr.Error())
+		return nil, xerr
+	}
+
+	hostName := host.GetName()
+	xerr = host.StreamLog(task.GetContext(), p.path, p.lines, func(line string) {
+		p.handler(hostName, line)
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		logrus.Warnf("failed to follow '%s' on Host '%s' of Cluster '%s': %s", p.path, hostName, instance.GetName(), xerr.Error())
+		return nil, xerr
+	}
+	return nil, nil
+}
+
+type taskCollectHostDiagnosticsParameters struct {
+	hostID    string
+	workdir   string // work directory of the script on the Host; the archive is '<workdir>.tar.gz'
+	script    string
+	bundle    *diagnosticsBundle
+	semaphore chan struct{} // bounds the number of Hosts on which diagnostics are collected simultaneously
+}
+
+// taskCollectHostDiagnostics runs the diagnostics script on a Host and adds the resulting archive to the bundle
+// A failure on the Host is reported as a problem of the bundle and does not fail the task, except abort
+func (instance *Cluster) taskCollectHostDiagnostics(task concurrency.Task, params concurrency.TaskParameters) (_ concurrency.TaskResult, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if task == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("task")
+	}
+
+	p, ok := params.(taskCollectHostDiagnosticsParameters)
+	if !ok {
+		return nil, fail.InvalidParameterError("params", "must be a 'taskCollectHostDiagnosticsParameters'")
+	}
+	if p.hostID == "" {
+		return nil, fail.InvalidParameterCannotBeEmptyStringError("params.hostID")
+	}
+	if p.bundle == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("params.bundle")
+	}
+
+	p.semaphore <- struct{}{}
+	defer func() { <-p.semaphore }()
+
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	host, xerr := LoadHost(instance.GetService(), p.hostID)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		p.bundle.addProblem(p.hostID, "failed to load Host: %s", xerr.Error())
+		return nil, nil
+	}
+
+	ctx := task.GetContext()
+	hostName := host.GetName()
+	archive := p.workdir + ".tar.gz"
+	defer func() {
+		cmd := fmt.Sprintf("sudo -n rm -rf %s %s", p.workdir, archive)
+		if _, _, _, derr := host.Run(ctx, cmd, outputs.COLLECT, temporal.GetConnectSSHTimeout(), temporal.GetExecutionTimeout()); derr != nil {
+			logrus.Warnf("failed to remove diagnostics archive '%s' on Host '%s': %s", archive, hostName, derr.Error())
+		}
+	}()
+
+	retcode, stdout, stderr, xerr := host.Run(ctx, p.script, outputs.COLLECT, temporal.GetConnectSSHTimeout(), temporal.GetLongOperationTimeout())
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		if task.Aborted() {
+			return nil, fail.AbortedError(xerr, "aborted")
+		}
+		p.bundle.addProblem(hostName, "failed to collect diagnostics: %s", xerr.Error())
+		return nil, nil
+	}
+	if retcode != 0 {
+		p.bundle.addProblem(hostName, "failed to collect diagnostics (retcode=%d): %s", retcode, strings.TrimSpace(stderr))
+		return nil, nil
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	size, err := strconv.ParseUint(strings.TrimSpace(lines[len(lines)-1]), 10, 64)
+	if err != nil {
+		p.bundle.addProblem(hostName, "failed to read size of diagnostics archive: %s", err.Error())
+		return nil, nil
+	}
+	if !p.bundle.reserve(size) {
+		p.bundle.addProblem(hostName, "archive of %d bytes does not fit in the maximum size of the bundle", size)
+		return nil, nil
+	}
+
+	content, xerr := pullDiagnosticsArchive(ctx, host, archive)
+	if xerr != nil {
+		p.bundle.release(size)
+		if task.Aborted() {
+			return nil, fail.AbortedError(xerr, "aborted")
+		}
+		p.bundle.addProblem(hostName, "%s", xerr.Error())
+		return nil, nil
+	}
+
+	p.bundle.addArchive(hostName, content)
+	return nil, nil
+}
+
+// pullDiagnosticsArchive downloads the file 'archive' from 'host' and returns its content
+func pullDiagnosticsArchive(ctx context.Context, host resources.Host, archive string) ([]byte, fail.Error) {
+	localFile, err := ioutil.TempFile("", "safescale-diagnostics-")
+	if err != nil {
+		return nil, fail.ConvertError(err)
+	}
+	localPath := localFile.Name()
+	_ = localFile.Close()
+	defer func() {
+		if derr := os.Remove(localPath); derr != nil {
+			logrus.Warnf("failed to remove temporary file '%s': %v", localPath, derr)
+		}
+	}()
+
+	retcode, _, stderr, xerr := host.Pull(ctx, archive, localPath, temporal.GetLongOperationTimeout())
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, fail.Wrap(xerr, "failed to download diagnostics archive")
+	}
+	if retcode != 0 {
+		return nil, fail.ExecutionError(nil, "failed to download diagnostics archive: %s", stderr)
+	}
+
+	content, err := ioutil.ReadFile(localPath)
+	if err != nil {
+		return nil, fail.ConvertError(err)
+	}
+	return content, nil
+}
+
+type taskDrainNodeParameters struct {
+	master            resources.Host
+	nodeName          string
+	timeout           time.Duration // maximum duration of the drain; 0 uses the default (see getClusterDrainTimeout)
+	uncordonOnFailure bool          // if true, makes the node schedulable again if the drain fails
+}
+
+// taskDrainNode evicts the workloads from a K8S node, using kubectl on a master
+func (instance *Cluster) taskDrainNode(task concurrency.Task, params concurrency.TaskParameters) (_ concurrency.TaskResult, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if task == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("task")
+	}
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	p, ok := params.(taskDrainNodeParameters)
+	if !ok {
+		return nil, fail.InvalidParameterError("params", "must be a 'taskDrainNodeParameters'")
+	}
+	if p.master == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("params.master")
+	}
+	if p.nodeName == "" {
+		return nil, fail.InvalidParameterCannotBeEmptyStringError("params.nodeName")
+	}
+
+	timeout := p.timeout
+	if timeout <= 0 {
+		timeout = getClusterDrainTimeout()
+	}
+
+	// kubectl drain cordons the node first; if asked, make the node schedulable again when the drain fails
+	defer func() {
+		if xerr != nil && p.uncordonOnFailure {
+			cmd := fmt.Sprintf("sudo -u cladm -i kubectl uncordon %s", p.nodeName)
+			retcode, _, _, derr := p.master.Run(task.GetContext(), cmd, outputs.COLLECT, temporal.GetConnectionTimeout(), temporal.GetExecutionTimeout())
+			if derr == nil && retcode != 0 {
+				derr = fail.ExecutionError(nil, "kubectl uncordon returned %d", retcode)
+			}
+			if derr != nil {
+				_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on failure, failed to uncordon node '%s'", p.nodeName))
+			}
+		}
+	}()
+
+	cmd := fmt.Sprintf("sudo -u cladm -i kubectl drain %s --ignore-daemonsets --delete-emptydir-data --force --timeout=%ds", p.nodeName, int(timeout.Seconds()))
+	retcode, stdout, stderr, xerr := p.master.Run(task.GetContext(), cmd, outputs.COLLECT, temporal.GetConnectionTimeout(), timeout+temporal.GetExecutionTimeout())
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, fail.Wrap(xerr, "failed to drain node '%s'", p.nodeName)
+	}
+	if retcode != 0 {
+		xerr = fail.ExecutionError(nil, "failed to drain node '%s' in %s", p.nodeName, temporal.FormatDuration(timeout))
+		_ = xerr.Annotate("retcode", retcode).Annotate("stdout", stdout).Annotate("stderr", stderr)
+		return nil, xerr
+	}
+
+	logrus.Debugf("node '%s' drained", p.nodeName)
+	return nil, nil
+}
+
 type taskInstallGatewayParameters struct {
 	Host resources.Host
 }
@@ -1214,6 +1982,7 @@ func (instance *Cluster) taskConfigureGateway(task concurrency.Task, params conc
 
 type taskCreateMastersParameters struct {
 	count         uint
+	firstIndex    uint // index of the first master to create; 0 is considered as 1
 	mastersDef    abstract.HostSizingRequirements
 	keepOnFailure bool
 }
@@ -1260,11 +2029,21 @@ func (instance *Cluster) taskCreateMasters(toc concurrency.Task, params concurre
 
 	logrus.Debugf("[Cluster %s] creating %d master%s...", clusterName, p.count, strprocess.Plural(p.count))
 
+	firstIndex := p.firstIndex
+	if firstIndex == 0 {
+		firstIndex = 1
+	}
+	slots, xerr := instance.reserveNodeSlots(clusternodetype.Master, p.count)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
 	timeout := temporal.GetContextTimeout() + time.Duration(p.count)*time.Minute
-	var i uint
-	for ; i < p.count; i++ {
+	for i, slot := range slots {
 		_, xerr := task.StartInSubtask(instance.taskCreateMaster, taskCreateMasterParameters{
-			index:         i + 1,
+			index:         firstIndex + uint(i),
+			slot:          slot,
 			masterDef:     p.mastersDef,
 			timeout:       timeout,
 			keepOnFailure: p.keepOnFailure,
@@ -1285,6 +2064,7 @@ func (instance *Cluster) taskCreateMasters(toc concurrency.Task, params concurre
 
 type taskCreateMasterParameters struct {
 	index         uint
+	slot          clusterNodeSlot // NumericalID and name reserved for the master (see reserveNodeSlots)
 	masterDef     abstract.HostSizingRequirements
 	timeout       time.Duration
 	keepOnFailure bool
@@ -1317,19 +2097,18 @@ func (instance *Cluster) taskCreateMaster(task concurrency.Task, params concurre
 	if p.index < 1 {
 		return nil, fail.InvalidParameterError("params.index", "must be an integer greater than 0")
 	}
+	if p.slot.numericalID == 0 || p.slot.hostName == "" {
+		return nil, fail.InvalidParameterError("params.slot", "must be reserved with reserveNodeSlots()")
+	}
 
 	hostLabel := fmt.Sprintf("master #%d", p.index)
 	logrus.Debugf("[%s] starting master Host creation...", hostLabel)
 
 	hostReq := abstract.HostRequest{}
-	hostReq.ResourceName, xerr = instance.buildHostname("master", clusternodetype.Master)
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return nil, xerr
-	}
+	hostReq.ResourceName = p.slot.hostName
 
 	// First creates master in metadata, to keep track of its tried creation, in case of failure
-	var nodeIdx uint
+	nodeIdx := p.slot.numericalID
 	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
 		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
 			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
@@ -1337,9 +2116,6 @@ func (instance *Cluster) taskCreateMaster(task concurrency.Task, params concurre
 				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			nodesV3.GlobalLastIndex++
-			nodeIdx = nodesV3.GlobalLastIndex
-
 			node := &propertiesv3.ClusterNode{
 				NumericalID: nodeIdx,
 				Name:        hostReq.ResourceName,
@@ -1404,6 +2180,11 @@ func (instance *Cluster) taskCreateMaster(task concurrency.Task, params concurre
 		return nil, xerr
 	}
 
+	// the hostname is the FQDN in the domain of the Cluster
+	if netCfg.Domain != "" {
+		hostReq.HostName = hostReq.ResourceName + "." + netCfg.Domain
+	}
+
 	hostReq.DefaultRouteIP, xerr = subnet.GetDefaultRouteIP()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
@@ -1427,7 +2208,7 @@ func (instance *Cluster) taskCreateMaster(task concurrency.Task, params concurre
 
 	defer func() {
 		if xerr != nil && !p.keepOnFailure {
-			if derr := rh.Delete(context.Background()); derr != nil {
+			if derr := rh.Delete(concurrency.DetachedContext(task.GetContext())); derr != nil {
 				switch derr.(type) {
 				case *fail.ErrNotFound:
 					// missing Host is considered as a successful deletion, continue
@@ -1438,6 +2219,8 @@ func (instance *Cluster) taskCreateMaster(task concurrency.Task, params concurre
 		}
 	}()
 
+	instance.tagNewHost(rh)
+
 	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
 		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) (innerXErr fail.Error) {
 			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
@@ -1534,28 +2317,39 @@ func (instance *Cluster) taskConfigureMasters(task concurrency.Task, _ concurren
 		return nil, xerr
 	}
 
-	var loadErrors []error
-	var taskErrors []error
+	var (
+		refs    []string
+		indexes []uint
+	)
 	for i, master := range masters {
 		if master.ID == "" {
 			continue
 		}
 
-		host, xerr := LoadHost(instance.GetService(), master.ID)
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			logrus.Warnf("failed to get metadata of Host: %s", xerr.Error())
-			loadErrors = append(loadErrors, xerr)
-			continue
-		}
+		refs = append(refs, master.ID)
+		indexes = append(indexes, i)
+	}
 
-		//goland:noinspection ALL
-		defer func(hostInstance resources.Host) {
-			hostInstance.Released()
-		}(host)
+	// Reads metadata of all masters in parallel
+	hosts, loadXErr := LoadHosts(instance.GetService(), refs)
+	loadXErr = debug.InjectPlannedFail(loadXErr)
+	for _, host := range hosts {
+		if host != nil {
+			//goland:noinspection ALL
+			defer func(hostInstance resources.Host) {
+				hostInstance.Released()
+			}(host)
+		}
+	}
+	if loadXErr != nil {
+		logrus.Warnf("there were error reading master's metadata: %s", loadXErr.Error())
+		return nil, loadXErr
+	}
 
+	var taskErrors []error
+	for k, host := range hosts {
 		_, xerr = tg.Start(instance.taskConfigureMaster, taskConfigureMasterParameters{
-			Index: i + 1,
+			Index: indexes[k] + 1,
 			Host:  host,
 		})
 		xerr = debug.InjectPlannedFail(xerr)
@@ -1564,11 +2358,6 @@ func (instance *Cluster) taskConfigureMasters(task concurrency.Task, _ concurren
 		}
 	}
 
-	if len(loadErrors) != 0 {
-		logrus.Warnf("there were error reading master's metadata")
-		return nil, fail.NewErrorList(loadErrors)
-	}
-
 	if len(taskErrors) != 0 {
 		return nil, fail.NewErrorList(taskErrors)
 	}
@@ -1649,6 +2438,7 @@ func (instance *Cluster) taskConfigureMaster(task concurrency.Task, params concu
 
 type taskCreateNodesParameters struct {
 	count         uint
+	firstIndex    uint // index of the first node to create; 0 is considered as 1
 	public        bool
 	nodesDef      abstract.HostSizingRequirements
 	keepOnFailure bool
@@ -1695,11 +2485,22 @@ func (instance *Cluster) taskCreateNodes(toc concurrency.Task, params concurrenc
 	}
 	logrus.Debugf("[Cluster %s] creating %d node%s...", clusterName, p.count, strprocess.Plural(p.count))
 
+	slots, xerr := instance.reserveNodeSlots(clusternodetype.Node, p.count)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
 	timeout := temporal.GetContextTimeout() + time.Duration(p.count)*time.Minute
+	firstIndex := p.firstIndex
+	if firstIndex == 0 {
+		firstIndex = 1
+	}
 	var subtasks []concurrency.Task
-	for i := uint(1); i <= p.count; i++ {
+	for i, slot := range slots {
 		subtask, xerr := task.StartInSubtask(instance.taskCreateNode, taskCreateNodeParameters{
-			index:         i,
+			index:         firstIndex + uint(i),
+			slot:          slot,
 			nodeDef:       p.nodesDef,
 			timeout:       timeout,
 			keepOnFailure: p.keepOnFailure,
@@ -1723,6 +2524,8 @@ func (instance *Cluster) taskCreateNodes(toc concurrency.Task, params concurrenc
 
 type taskCreateNodeParameters struct {
 	index         uint
+	slot          clusterNodeSlot // NumericalID and name reserved for the node (see reserveNodeSlots)
+	subnetID      string          // ID of the Subnet of a node pool where to create the node; empty means the Subnet of the Cluster
 	nodeDef       abstract.HostSizingRequirements
 	timeout       time.Duration // Not used currently
 	keepOnFailure bool
@@ -1752,6 +2555,9 @@ func (instance *Cluster) taskCreateNode(task concurrency.Task, params concurrenc
 	if p.index < 1 {
 		return nil, fail.InvalidParameterError("params.indexindex", "cannot be an integer less than 1")
 	}
+	if p.slot.numericalID == 0 || p.slot.hostName == "" {
+		return nil, fail.InvalidParameterError("params.slot", "must be reserved with reserveNodeSlots()")
+	}
 
 	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.cluster"), "(%d)", p.index).WithStopwatch().Entering()
 	defer tracer.Exiting()
@@ -1760,14 +2566,10 @@ func (instance *Cluster) taskCreateNode(task concurrency.Task, params concurrenc
 	logrus.Debugf("[%s] starting Host creation...", hostLabel)
 
 	hostReq := abstract.HostRequest{}
-	hostReq.ResourceName, xerr = instance.buildHostname("node", clusternodetype.Node)
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return nil, xerr
-	}
+	hostReq.ResourceName = p.slot.hostName
 
 	// First creates node in metadata, to keep track of its tried creation, in case of failure
-	var nodeIdx uint
+	nodeIdx := p.slot.numericalID
 	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
 		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
 			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
@@ -1775,8 +2577,6 @@ func (instance *Cluster) taskCreateNode(task concurrency.Task, params concurrenc
 				return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			nodesV3.GlobalLastIndex++
-			nodeIdx = nodesV3.GlobalLastIndex
 			node := &propertiesv3.ClusterNode{
 				NumericalID: nodeIdx,
 				Name:        hostReq.ResourceName,
@@ -1825,6 +2625,21 @@ func (instance *Cluster) taskCreateNode(task concurrency.Task, params concurrenc
 		return nil, xerr
 	}
 
+	// Node of a node pool lands in the Subnet of the pool, but keeps the gateway(s) of the Cluster Subnet as default route
+	hostReq.DefaultRouteIP, xerr = subnet.GetDefaultRouteIP()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	if p.subnetID != "" && p.subnetID != netCfg.SubnetID {
+		subnet, xerr = LoadSubnet(instance.GetService(), netCfg.NetworkID, p.subnetID)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return nil, xerr
+		}
+	}
+
 	// Create the rh
 	xerr = subnet.Inspect(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
 		as, ok := clonable.(*abstract.Subnet)
@@ -1840,10 +2655,9 @@ func (instance *Cluster) taskCreateNode(task concurrency.Task, params concurrenc
 		return nil, xerr
 	}
 
-	hostReq.DefaultRouteIP, xerr = subnet.GetDefaultRouteIP()
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return nil, xerr
+	// the hostname is the FQDN in the domain of the Cluster
+	if netCfg.Domain != "" {
+		hostReq.HostName = hostReq.ResourceName + "." + netCfg.Domain
 	}
 
 	hostReq.PublicIP = false
@@ -1863,7 +2677,7 @@ func (instance *Cluster) taskCreateNode(task concurrency.Task, params concurrenc
 
 	defer func() {
 		if xerr != nil && !p.keepOnFailure {
-			if derr := rh.Delete(context.Background()); derr != nil {
+			if derr := rh.Delete(concurrency.DetachedContext(task.GetContext())); derr != nil {
 				switch derr.(type) {
 				case *fail.ErrNotFound:
 					// missing Host is considered as a successful deletion, continue
@@ -1874,6 +2688,8 @@ func (instance *Cluster) taskCreateNode(task concurrency.Task, params concurrenc
 		}
 	}()
 
+	instance.tagNewHost(rh)
+
 	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
 		return props.Alter(clusterproperty.NodesV3, func(clonable data.Clonable) (innerXErr fail.Error) {
 			nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
@@ -1972,16 +2788,29 @@ func (instance *Cluster) taskConfigureNodes(task concurrency.Task, _ concurrency
 		return nil, xerr
 	}
 
-	// var subtasks []concurrency.Task
+	var (
+		refs    []string
+		indexes []uint
+	)
 	for i, node := range list {
 		if node.ID == "" {
 			continue
 		}
 
-		host, xerr := LoadHost(svc, node.ID)
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			errs = append(errs, fail.Wrap(xerr, "failed to get metadata of Host '%s'", node.Name))
+		refs = append(refs, node.ID)
+		indexes = append(indexes, i)
+	}
+
+	// Reads metadata of all nodes in parallel
+	hosts, loadXErr := LoadHosts(svc, refs)
+	loadXErr = debug.InjectPlannedFail(loadXErr)
+	if loadXErr != nil {
+		errs = append(errs, loadXErr)
+	}
+
+	// var subtasks []concurrency.Task
+	for k, host := range hosts {
+		if host == nil {
 			continue
 		}
 
@@ -1991,7 +2820,7 @@ func (instance *Cluster) taskConfigureNodes(task concurrency.Task, _ concurrency
 		}(host)
 
 		_, xerr = tg.Start(instance.taskConfigureNode, taskConfigureNodeParameters{
-			Index: i + 1,
+			Index: indexes[k] + 1,
 			Host:  host,
 		})
 		xerr = debug.InjectPlannedFail(xerr)
@@ -2105,7 +2934,7 @@ func (instance *Cluster) taskDeleteNodeOnFailure(task concurrency.Task, params c
 		return nil, xerr
 	}
 
-	xerr = rh.Delete(context.Background())
+	xerr = rh.Delete(concurrency.DetachedContext(task.GetContext()))
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		switch xerr.(type) {
@@ -2258,7 +3087,7 @@ func (instance *Cluster) taskDeleteHostOnFailure(task concurrency.Task, params c
 	hostName := hostInstance.GetName()
 	logrus.Debugf(prefix + fmt.Sprintf("deleting Host '%s'", hostName))
 
-	xerr = hostInstance.Delete(context.Background())
+	xerr = hostInstance.Delete(concurrency.DetachedContext(task.GetContext()))
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		switch xerr.(type) {
@@ -18,6 +18,8 @@ package operations
 
 import (
 	"reflect"
+	"sort"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/net/context"
@@ -94,16 +96,8 @@ func (instance *Cluster) unsafeGetState() (state clusterstate.Enum, xerr fail.Er
 		}
 
 		return state, instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-			return props.Alter(clusterproperty.StateV1, func(clonable data.Clonable) fail.Error {
-				stateV1, ok := clonable.(*propertiesv1.ClusterState)
-				if !ok {
-					return fail.InconsistentError("'*propertiesv1.ClusterState' expected, '%s' provided", reflect.TypeOf(clonable).String())
-				}
-
-				stateV1.State = state
-				instance.lastStateCollection = time.Now()
-				return nil
-			})
+			instance.lastStateCollection = time.Now()
+			return setClusterStateInProperties(props, state, "state collected")
 		})
 	}
 
@@ -126,6 +120,40 @@ func (instance *Cluster) unsafeGetState() (state clusterstate.Enum, xerr fail.Er
 	return state, nil
 }
 
+// setClusterStateInProperties updates the state of the Cluster in properties and, if the state changes, records the
+// transition in property clusterproperty.StateHistoryV1
+// Note: must be called inside a callback of instance.Alter()
+func setClusterStateInProperties(props *serialize.JSONProperties, state clusterstate.Enum, reason string) fail.Error {
+	prevState := clusterstate.Unknown
+	xerr := props.Alter(clusterproperty.StateV1, func(clonable data.Clonable) fail.Error {
+		stateV1, ok := clonable.(*propertiesv1.ClusterState)
+		if !ok {
+			return fail.InconsistentError("'*propertiesv1.ClusterState' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		prevState = stateV1.State
+		stateV1.State = state
+		return nil
+	})
+	if xerr != nil {
+		return xerr
+	}
+
+	if prevState == state {
+		return nil
+	}
+
+	return props.Alter(clusterproperty.StateHistoryV1, func(clonable data.Clonable) fail.Error {
+		historyV1, ok := clonable.(*propertiesv1.ClusterStateHistory)
+		if !ok {
+			return fail.InconsistentError("'*propertiesv1.ClusterStateHistory' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		historyV1.Append(prevState, state, reason)
+		return nil
+	})
+}
+
 // UnsafeListMasters is the not goroutine-safe equivalent of ListMasters, that does the real work
 // Note: must be used with wisdom
 func (instance *Cluster) UnsafeListMasters() (list resources.IndexedListOfClusterNodes, xerr fail.Error) {
@@ -270,48 +298,16 @@ func (instance *Cluster) unsafeListNodeIPs() (list data.IndexedListOfStrings, xe
 
 // UnsafeFindAvailableMaster is the not go-routine-safe version of FindAvailableMaster, that does the real work
 // Must be used with wisdom
-func (instance *Cluster) UnsafeFindAvailableMaster(ctx context.Context) (master resources.Host, xerr fail.Error) {
+func (instance *Cluster) UnsafeFindAvailableMaster(ctx context.Context, options ...data.ImmutableKeyValue) (master resources.Host, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
-	master = nil
 	masters, xerr := instance.UnsafeListMasters()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return nil, xerr
 	}
 
-	var lastError fail.Error
-	lastError = fail.NotFoundError("no master found")
-	master = nil
-	for _, v := range masters {
-		if v.ID == "" {
-			continue
-		}
-
-		master, xerr = LoadHost(instance.GetService(), v.ID)
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			return nil, xerr
-		}
-
-		_, xerr = master.WaitSSHReady(ctx, temporal.GetConnectSSHTimeout())
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			switch xerr.(type) {
-			case *retry.ErrTimeout:
-				lastError = xerr
-				continue
-			default:
-				return nil, xerr
-			}
-		}
-		break
-	}
-	if master == nil {
-		return nil, lastError
-	}
-
-	return master, nil
+	return instance.unsafeFindAvailableHost(ctx, masters, &instance.masterCursor, fail.NotFoundError("no master found"), options...)
 }
 
 // unsafeListNodes is the not goroutine-safe version of ListNodes and no parameter validation, that does the real work
@@ -394,7 +390,7 @@ func (instance *Cluster) UnsafeListNodeIDs(ctx context.Context) (list data.Index
 
 // UnsafeFindAvailableNode is the package restricted, not goroutine-safe, no parameter validation version of FindAvailableNode, that does the real work
 // Note: must be used wisely
-func (instance *Cluster) UnsafeFindAvailableNode(ctx context.Context) (node resources.Host, xerr fail.Error) {
+func (instance *Cluster) UnsafeFindAvailableNode(ctx context.Context, options ...data.ImmutableKeyValue) (node resources.Host, xerr fail.Error) {
 	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
@@ -417,41 +413,86 @@ func (instance *Cluster) UnsafeFindAvailableNode(ctx context.Context) (node reso
 		return nil, xerr
 	}
 
+	return instance.unsafeFindAvailableHost(ctx, list, &instance.nodeCursor, fail.NotAvailableError("failed to find available node"), options...)
+}
+
+// unsafeFindAvailableHost returns the first Host of 'list' reachable by SSH, or 'notFoundErr' if there is none
+// options:
+// - "Exclude": []string = IDs of the Hosts that must not be selected
+// - "RoundRobin": bool = if true, starts the search after the Host selected by the previous round-robin call using the same 'cursor',
+// to spread load over Hosts (default is false, the search always starts from the first Host)
+func (instance *Cluster) unsafeFindAvailableHost(ctx context.Context, list resources.IndexedListOfClusterNodes, cursor *uint32, notFoundErr fail.Error, options ...data.ImmutableKeyValue) (_ resources.Host, xerr fail.Error) {
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	excluded := map[string]struct{}{}
+	roundRobin := false
+	for _, v := range options {
+		switch v.Key() {
+		case "Exclude":
+			for _, id := range v.Value().([]string) {
+				excluded[id] = struct{}{}
+			}
+		case "RoundRobin":
+			roundRobin = v.Value().(bool)
+		default:
+		}
+	}
+
+	// Map iteration order is random, so sorts the indexes to have a stable order between calls
+	indexes := make([]uint, 0, len(list))
+	for k, v := range list {
+		if v.ID == "" {
+			continue
+		}
+		if _, ok := excluded[v.ID]; ok {
+			continue
+		}
+		indexes = append(indexes, k)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+	count := len(indexes)
+	start := 0
+	if roundRobin && count > 0 {
+		start = int(atomic.LoadUint32(cursor) % uint32(count))
+	}
+
 	svc := instance.GetService()
-	node = nil
-	found := false
-	for _, v := range list {
+	lastError := notFoundErr
+	for i := 0; i < count; i++ {
 		if task.Aborted() {
 			return nil, fail.AbortedError(nil, "aborted")
 		}
 
-		node, xerr = LoadHost(svc, v.ID)
+		pos := (start + i) % count
+		hostInstance, xerr := LoadHost(svc, list[indexes[pos]].ID)
 		xerr = debug.InjectPlannedFail(xerr)
 		if xerr != nil {
 			return nil, xerr
 		}
 
-		//goland:noinspection ALL
-		defer func(hostInstance resources.Host) {
-			hostInstance.Released()
-		}(node)
-
-		_, xerr = node.WaitSSHReady(ctx, temporal.GetConnectSSHTimeout())
+		_, xerr = hostInstance.WaitSSHReady(ctx, temporal.GetConnectSSHTimeout())
 		xerr = debug.InjectPlannedFail(xerr)
 		if xerr != nil {
+			hostInstance.Released()
 			switch xerr.(type) {
 			case *retry.ErrTimeout:
+				lastError = xerr
 				continue
 			default:
 				return nil, xerr
 			}
 		}
-		found = true
-		break
-	}
-	if !found {
-		return nil, fail.NotAvailableError("failed to find available node")
+
+		if roundRobin {
+			atomic.StoreUint32(cursor, uint32(pos+1))
+		}
+		return hostInstance, nil
 	}
 
-	return node, nil
+	return nil, lastError
 }
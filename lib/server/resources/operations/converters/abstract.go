@@ -21,6 +21,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterstate"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hoststate"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/securitygroupruledirection"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/volumespeed"
 	propertiesv1 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v1"
 	propertiesv2 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v2"
@@ -36,6 +37,7 @@ func HostTemplateToHostEffectiveSizing(ht abstract.HostTemplate) *abstract.HostE
 	hes.RAMSize = ht.RAMSize
 	hes.DiskSize = ht.DiskSize
 	hes.GPUNumber = ht.GPUNumber
+	hes.GPUType = ht.GPUType
 	hes.CPUFreq = ht.CPUFreq
 	return hes
 }
@@ -98,6 +100,25 @@ func NetworkFromAbstractToProtocol(in *abstract.Network) *protocol.Network {
 	return out
 }
 
+// NetworkUsageFromAbstractToProtocol ...
+func NetworkUsageFromAbstractToProtocol(in *abstract.NetworkUsage) *protocol.NetworkUsage {
+	out := &protocol.NetworkUsage{
+		Subnets:             make([]*protocol.SubnetUsage, 0, len(in.Subnets)),
+		FreeSingleHostSlots: uint32(in.FreeSingleHostSlots),
+	}
+	for _, v := range in.Subnets {
+		out.Subnets = append(out.Subnets, &protocol.SubnetUsage{
+			Id:          v.ID,
+			Name:        v.Name,
+			Cidr:        v.CIDR,
+			GatewayIds:  v.GatewayIDs,
+			HostCount:   uint32(v.HostCount),
+			FreeIpCount: uint32(v.FreeIPCount),
+		})
+	}
+	return out
+}
+
 // SubnetFromAbstractToProtocol ...
 func SubnetFromAbstractToProtocol(in *abstract.Subnet) *protocol.Subnet {
 	var pbVIP *protocol.VirtualIp
@@ -122,6 +143,7 @@ func HostSizingRequirementsFromAbstractToProtocol(src abstract.HostSizingRequire
 		MaxCpuCount: int32(src.MaxCores),
 		MinCpuFreq:  src.MinCPUFreq,
 		GpuCount:    int32(src.MinGPU),
+		GpuType:     src.GPUType,
 		MinRamSize:  src.MinRAMSize,
 		MaxRamSize:  src.MaxRAMSize,
 		MinDiskSize: int32(src.MinDiskSize),
@@ -164,6 +186,7 @@ func HostEffectiveSizingFromAbstractToPropertyV2(ahes *abstract.HostEffectiveSiz
 	phes.RAMSize = ahes.RAMSize
 	phes.DiskSize = ahes.DiskSize
 	phes.GPUNumber = ahes.GPUNumber
+	phes.GPUType = ahes.GPUType
 	phes.CPUFreq = ahes.CPUFreq
 	return phes
 }
@@ -192,6 +215,11 @@ func HostFullFromAbstractToProtocol(in *abstract.HostFull) *protocol.Host {
 	}
 	if in.Networking != nil {
 		ph.PublicIp = in.Networking.PublicIPv4
+		if ph.PublicIp == "" {
+			ph.PublicIp = in.Networking.PublicIPv6
+		}
+		ph.PublicIpv4 = in.Networking.PublicIPv4
+		ph.PublicIpv6 = in.Networking.PublicIPv6
 		if ip, ok := in.Networking.IPv4Addresses[in.Networking.DefaultSubnetID]; ok {
 			ph.PrivateIp = ip
 		}
@@ -331,6 +359,10 @@ func SecurityGroupRuleFromAbstractToProtocol(in abstract.SecurityGroupRule) *pro
 		PortFrom:    in.PortFrom,
 		PortTo:      in.PortTo,
 		Involved:    in.Targets,
+		References:  in.References,
+	}
+	if in.Direction == securitygroupruledirection.Ingress {
+		out.Involved = in.Sources
 	}
 	return out
 }
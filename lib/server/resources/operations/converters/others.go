@@ -23,8 +23,10 @@ import (
 	"strconv"
 	"strings"
 	"text/scanner"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/CS-SI/SafeScale/lib/protocol"
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
@@ -43,6 +45,14 @@ func BucketListToProtocol(in []string) *protocol.BucketList {
 	}
 }
 
+// TimeToProtocol converts a time.Time to protobuf Timestamp; a zero time.Time is converted to nil (meaning unset)
+func TimeToProtocol(in time.Time) *timestamppb.Timestamp {
+	if in.IsZero() {
+		return nil
+	}
+	return timestamppb.New(in)
+}
+
 // NFSExportOptionsFromStringToProtocol converts a string containing NFS export options as string to the (now deprecated) protocol message
 func NFSExportOptionsFromStringToProtocol(in string) *protocol.NFSExportOptions {
 	parts := strings.Split(in, ",")
@@ -207,6 +217,19 @@ func HostSizingRequirementsFromStringToAbstract(in string) (*abstract.HostSizing
 			return nil, 0, xerr
 		}
 	}
+	if t, ok := tokens["gputype"]; ok {
+		var xerr fail.Error
+		out.GPUType, _, xerr = t.Validate()
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return nil, 0, xerr
+		}
+
+		// a GPU type implies at least one GPU
+		if out.MinGPU < 1 {
+			out.MinGPU = 1
+		}
+	}
 	return &out, count, nil
 }
 
@@ -308,8 +331,8 @@ func (t *sizingToken) Validate() (string, string, fail.Error) {
 		if keyword == "count" {
 			return "", "", fail.InvalidRequestError("'count' can only use '='")
 		}
-		if keyword == "template" {
-			return "", "", fail.InvalidRequestError("'template' can only use '='")
+		if keyword == "template" || keyword == "gputype" {
+			return "", "", fail.InvalidRequestError("'%s' can only use '='", keyword)
 		}
 
 		vali, err := strconv.Atoi(value)
@@ -324,7 +347,7 @@ func (t *sizingToken) Validate() (string, string, fail.Error) {
 		}
 		return fmt.Sprintf("%d", vali), fmt.Sprintf("%d", 2*vali), nil
 	case "=":
-		if keyword == "template" {
+		if keyword == "template" || keyword == "gputype" {
 			return value, "", nil
 		}
 		if keyword != "count" {
@@ -368,8 +391,8 @@ func (t *sizingToken) Validate() (string, string, fail.Error) {
 		if keyword == "count" {
 			return "", "", fail.InvalidRequestError("'count' can only use '='")
 		}
-		if keyword == "template" {
-			return "", "", fail.InvalidRequestError("'template' can only use '='")
+		if keyword == "template" || keyword == "gputype" {
+			return "", "", fail.InvalidRequestError("'%s' can only use '='", keyword)
 		}
 
 		vali, err := strconv.Atoi(value)
@@ -390,8 +413,8 @@ func (t *sizingToken) Validate() (string, string, fail.Error) {
 		if keyword == "count" {
 			return "", "", fail.InvalidRequestError("'count' can only use '='")
 		}
-		if keyword == "template" {
-			return "", "", fail.InvalidRequestError("'template' can only use '='")
+		if keyword == "template" || keyword == "gputype" {
+			return "", "", fail.InvalidRequestError("'%s' can only use '='", keyword)
 		}
 
 		_, err := strconv.Atoi(value)
@@ -411,8 +434,8 @@ func (t *sizingToken) Validate() (string, string, fail.Error) {
 		if keyword == "count" {
 			return "", "", fail.InvalidRequestError("'count' can only use '='")
 		}
-		if keyword == "template" {
-			return "", "", fail.InvalidRequestError("'template' can only use '='")
+		if keyword == "template" || keyword == "gputype" {
+			return "", "", fail.InvalidRequestError("'%s' can only use '='", keyword)
 		}
 
 		vali, err := strconv.Atoi(value)
@@ -433,6 +456,9 @@ func (t *sizingToken) Validate() (string, string, fail.Error) {
 		if keyword == "count" {
 			return "", "", fail.InvalidRequestError("'count' can only use '='")
 		}
+		if keyword == "gputype" {
+			return "", "", fail.InvalidRequestError("'gputype' can only use '='")
+		}
 
 		_, err := strconv.Atoi(value)
 		err = debug.InjectPlannedError(err)
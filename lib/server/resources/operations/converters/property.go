@@ -123,6 +123,17 @@ func ClusterNetworkFromPropertyToProtocol(in propertiesv3.ClusterNetwork) *proto
 	}
 }
 
+// LastOperationFromPropertyToProtocol converts the record of the last operation of a Host or a Cluster to protocol message
+func LastOperationFromPropertyToProtocol(in propertiesv1.LastOperation) *protocol.OperationStatus {
+	return &protocol.OperationStatus{
+		Type:    in.Type,
+		Status:  string(in.Status),
+		Started: TimeToProtocol(in.Started),
+		Ended:   TimeToProtocol(in.Ended),
+		Error:   in.Error,
+	}
+}
+
 // ClusterFeaturesFromPropertyToProtocol does what the name says
 func ClusterFeaturesFromPropertyToProtocol(in propertiesv1.ClusterFeatures) (*protocol.FeatureListResponse, *protocol.FeatureListResponse) {
 	installed := &protocol.FeatureListResponse{}
@@ -20,6 +20,7 @@ package converters
 
 import (
 	"strings"
+	"time"
 
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/ipversion"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/securitygroupruledirection"
@@ -29,6 +30,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clustercomplexity"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterflavor"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/featuretarget"
 	"github.com/CS-SI/SafeScale/lib/system"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
 )
@@ -60,6 +62,8 @@ func FeatureSettingsFromProtocolToResource(in *protocol.FeatureSettings) resourc
 		SkipFeatureRequirements: in.IgnoreFeatureRequirements,
 		SkipSizingRequirements:  in.IgnoreSizingRequirements,
 		AddUnconditionally:      in.AddUnconditionally,
+		Target:                  featuretarget.FromString(in.Target),
+		Timeout:                 time.Duration(in.Timeout) * time.Minute,
 	}
 }
 
@@ -74,6 +78,7 @@ func HostSizingRequirementsFromProtocolToAbstract(in *protocol.HostSizing) *abst
 		MaxRAMSize:  in.MaxRamSize,
 		MinDiskSize: int(in.MinDiskSize),
 		MinGPU:      int(in.GpuCount),
+		GPUType:     in.GpuType,
 		MinCPUFreq:  in.MinCpuFreq,
 	}
 }
@@ -162,11 +167,18 @@ func ClusterRequestFromProtocolToAbstract(in *protocol.ClusterCreateRequest) (_
 	for _, v := range in.Disabled {
 		disabled[v] = struct{}{}
 	}
+	bestEffort := map[string]struct{}{}
+	for _, v := range in.BestEffort {
+		bestEffort[v] = struct{}{}
+	}
 
 	out := abstract.ClusterRequest{
 		Name:                    in.Name,
 		CIDR:                    in.Cidr,
 		Domain:                  in.Domain,
+		ExistingGatewayID:       in.ExistingGateway,
+		MetadataBucket:          in.MetadataBucket,
+		Tags:                    in.Tags,
 		Complexity:              clustercomplexity.Enum(in.Complexity),
 		Flavor:                  clusterflavor.Enum(in.Flavor),
 		GatewaysDef:             *gatewaySizing,
@@ -176,6 +188,7 @@ func ClusterRequestFromProtocolToAbstract(in *protocol.ClusterCreateRequest) (_
 		KeepOnFailure:           in.KeepOnFailure,
 		Force:                   in.Force,
 		DisabledDefaultFeatures: disabled,
+		BestEffortFeatures:      bestEffort,
 		InitialNodeCount:        uint(nodeCount),
 	}
 	return out, nil
@@ -376,24 +376,50 @@ func (f *Feature) Check(ctx context.Context, target resources.Targetable, v data
 	return r, xerr
 }
 
+// requiredInstallMethodYamlKey is the key in specification file of the install method a Feature must be installed with
+const requiredInstallMethodYamlKey = "feature.requirements.installMethod"
+
 // findInstallerForTarget isolates the available installer to use for target (one that is define in the file and applicable on target)
 func (f *Feature) findInstallerForTarget(target resources.Targetable, action string) (installer Installer, xerr fail.Error) {
-	methods := target.InstallMethods()
-	w := f.specs.GetStringMap("feature.install")
-	for i := uint8(1); i <= uint8(len(methods)); i++ {
-		meth := methods[i]
-		if _, ok := w[strings.ToLower(meth.String())]; ok {
-			if installer = f.installerOfMethod(meth); installer != nil {
-				break
-			}
-		}
+	meth, xerr := selectInstallMethod(target.InstallMethods(), f.specs.GetStringMap("feature.install"), f.specs.GetString(requiredInstallMethodYamlKey))
+	if xerr != nil {
+		return nil, fail.Wrap(xerr, "failed to find a way to %s '%s' on %s '%s'", action, f.GetName(), strings.ToLower(target.TargetType().String()), target.GetName())
 	}
-	if installer == nil {
+	if installer = f.installerOfMethod(meth); installer == nil {
 		return nil, fail.NotAvailableError("failed to find a way to %s '%s'", action, f.GetName())
 	}
 	return installer, nil
 }
 
+// selectInstallMethod returns the method of highest preference in 'methods' (indexed from 1) defined in 'defined' (the
+// content of 'feature.install' of a specification file)
+// If 'required' is not empty, this method only is acceptable and must be available.
+func selectInstallMethod(methods map[uint8]installmethod.Enum, defined map[string]interface{}, required string) (installmethod.Enum, fail.Error) {
+	if required != "" {
+		meth, err := installmethod.Parse(required)
+		if err != nil {
+			return 0, fail.SyntaxError("invalid required install method '%s'", required)
+		}
+		if _, ok := defined[strings.ToLower(meth.String())]; !ok {
+			return 0, fail.SyntaxError("required install method '%s' is not defined", meth.String())
+		}
+		for _, v := range methods {
+			if v == meth {
+				return meth, nil
+			}
+		}
+		return 0, fail.NotAvailableError("required install method '%s' is not available", meth.String())
+	}
+
+	for i := uint8(1); i <= uint8(len(methods)); i++ {
+		meth := methods[i]
+		if _, ok := defined[strings.ToLower(meth.String())]; ok {
+			return meth, nil
+		}
+	}
+	return 0, fail.NotAvailableError("no install method available")
+}
+
 // Check if required parameters defined in specification file have been set in 'v'
 func checkParameters(f Feature, v data.Map) fail.Error {
 	if f.specs.IsSet("feature.parameters") {
@@ -18,14 +18,16 @@ package operations
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"os/user"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -36,11 +38,15 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/iaas/userdata"
 	"github.com/CS-SI/SafeScale/lib/server/resources"
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/accesspreference"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusternodetype"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hostproperty"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hoststate"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hosttenancy"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/installmethod"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/ipversion"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/networkproperty"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/privilegeescalation"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/securitygroupstate"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/subnetproperty"
 	"github.com/CS-SI/SafeScale/lib/server/resources/operations/converters"
@@ -50,11 +56,13 @@ import (
 	"github.com/CS-SI/SafeScale/lib/utils"
 	"github.com/CS-SI/SafeScale/lib/utils/cli/enums/outputs"
 	"github.com/CS-SI/SafeScale/lib/utils/concurrency"
+	"github.com/CS-SI/SafeScale/lib/utils/crypt"
 	"github.com/CS-SI/SafeScale/lib/utils/data"
 	"github.com/CS-SI/SafeScale/lib/utils/data/cache"
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
 	"github.com/CS-SI/SafeScale/lib/utils/debug/tracing"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
+	"github.com/CS-SI/SafeScale/lib/utils/metrics"
 	netretry "github.com/CS-SI/SafeScale/lib/utils/net"
 	"github.com/CS-SI/SafeScale/lib/utils/retry"
 	"github.com/CS-SI/SafeScale/lib/utils/serialize"
@@ -68,6 +76,23 @@ const (
 	hostsFolderName = "hosts"
 
 	// defaultHostSecurityGroupNamePattern = "safescale-sg_host_%s.%s.%s" // safescale-sg_host_<hostname>.<subnet name>.<network name>; should be unique across a tenant
+
+	// defaultHostCreationAttempts is the default maximum number of tries to create a Host on provider side on transient failure
+	defaultHostCreationAttempts uint = 3
+	// hostCreationRetryDelay is the base delay between tries to create a Host (grows exponentially)
+	hostCreationRetryDelay = 5 * time.Second
+
+	// defaultHostSettleAttempts is the default maximum number of queries of the state of a new Host, waiting for it to be
+	// consistently queryable after its creation
+	defaultHostSettleAttempts uint = 10
+	// hostSettleSuccesses is the number of successful queries in a row needed to consider a new Host as queryable
+	hostSettleSuccesses uint = 2
+	// hostSettleDelay is the delay between queries of the state of a new Host
+	hostSettleDelay = 2 * time.Second
+
+	// hostImageShutdownGracePeriod is the delay left to the operating system of a Host to shut down by itself before the
+	// capture of its disk in an image
+	hostImageShutdownGracePeriod = 2 * time.Minute
 )
 
 // Host ...
@@ -75,10 +100,16 @@ const (
 type Host struct {
 	*MetadataCore
 
-	lock                          sync.RWMutex
+	lock                          concurrency.TimedRWMutex // read-only getters give up after temporal.GetHostReadLockTimeout()
 	installMethods                map[uint8]installmethod.Enum
 	privateIP, publicIP, accessIP string
+	publicIPv4, publicIPv6        string            // public IP addresses of the Host by family; publicIP is the preferred one (IPv4 first)
+	privateIPs                    map[string]string // private IP addresses of the Host on all its Subnets, indexed by Subnet ID
 	sshProfile                    *system.SSHConfig
+	privilegeEscalation           privilegeescalation.Enum
+	defaultShell                  string
+	tempFolder                    string
+	keepScriptsOnFailure          bool // set during Create from abstract.HostRequest.KeepOnFailure, to keep phase scripts for debugging
 }
 
 // NewHost ...
@@ -166,51 +197,65 @@ func LoadHost(svc iaas.Service, ref string) (_ resources.Host, xerr fail.Error)
 	return hostInstance, hostInstance.(*Host).updateCachedInformation()
 }
 
-// VPL: disabled silent metadata upgrade; will be implemented in a global one-pass migration
-// // upgradeIfNeeded upgrades Host properties if needed
-// func (instance *host) upgradeIfNeeded() fail.Error {
-// 	return instance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
-// 		if !props.Lookup(hostproperty.NetworkV2) {
-// 			// upgrade hostproperty.NetworkV1 to hostproperty.NetworkV2
-// 			var hnV1 *propertiesv1.HostNetwork
-// 			innerXErr := props.Alter(hostproperty.NetworkV1, func(clonable data.Clonable) fail.Error {
-// 				var ok bool
-// 				hnV1, ok = clonable.(*propertiesv1.HostNetwork)
-// 				if !ok {
-// 					return fail.InconsistentError("'*propertiesv1.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
-// 				}
-// 				return nil
-// 			})
-// 			if innerXErr != nil {
-// 				return innerXErr
-// 			}
-//
-// 			innerXErr = props.Alter(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
-// 				hnV2, ok := clonable.(*propertiesv2.HostNetworking)
-// 				if !ok {
-// 					return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
-// 				}
-//
-// 				hnV2.DefaultSubnetID = hnV1.DefaultNetworkID
-// 				hnV2.IPv4Addresses = hnV1.IPv4Addresses
-// 				hnV2.IPv6Addresses = hnV1.IPv6Addresses
-// 				hnV2.IsGateway = hnV1.IsGateway
-// 				hnV2.PublicIPv4 = hnV1.PublicIPv4
-// 				hnV2.PublicIPv6 = hnV1.PublicIPv6
-// 				hnV2.SubnetsByID = hnV1.NetworksByID
-// 				hnV2.SubnetsByName = hnV1.NetworksByName
-// 				return nil
-// 			})
-// 			if innerXErr != nil {
-// 				return innerXErr
-// 			}
-//
-// 			// FIXME: clean old property or leave it ? will differ from v2 through time if Subnets are added for example
-// 		}
-//
-// 		return fail.AlteredNothingError()
-// 	})
-// }
+// migrateHostNetworkV1ToV2 converts legacy property hostproperty.NetworkV1 to hostproperty.NetworkV2
+// Returns fail.ErrAlteredNothing if there is nothing to convert
+func migrateHostNetworkV1ToV2(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+	if props.Lookup(hostproperty.NetworkV2) || !props.Lookup(hostproperty.NetworkV1) {
+		return fail.AlteredNothingError()
+	}
+
+	var hnV1 *propertiesv1.HostNetwork
+	xerr := props.Inspect(hostproperty.NetworkV1, func(clonable data.Clonable) fail.Error {
+		var ok bool
+		hnV1, ok = clonable.(*propertiesv1.HostNetwork)
+		if !ok {
+			return fail.InconsistentError("'*propertiesv1.HostNetwork' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+		return nil
+	})
+	if xerr != nil {
+		return xerr
+	}
+
+	return props.Alter(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+		hnV2, ok := clonable.(*propertiesv2.HostNetworking)
+		if !ok {
+			return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		hnV2.DefaultSubnetID = hnV1.DefaultNetworkID
+		hnV2.IPv4Addresses = hnV1.IPv4Addresses
+		hnV2.IPv6Addresses = hnV1.IPv6Addresses
+		hnV2.IsGateway = hnV1.IsGateway
+		hnV2.PublicIPv4 = hnV1.PublicIPv4
+		hnV2.PublicIPv6 = hnV1.PublicIPv6
+		hnV2.SubnetsByID = hnV1.NetworksByID
+		hnV2.SubnetsByName = hnV1.NetworksByName
+		return nil
+	})
+}
+
+// LoadHosts loads in parallel the Hosts referenced by 'refs', populating the cache
+// Returns the Hosts loaded in the same order than 'refs' (nil for the ones that failed), and a fail.ErrorList
+// containing an error for each ref that failed to load
+func LoadHosts(svc iaas.Service, refs []string) (_ []resources.Host, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if svc == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("svc")
+	}
+
+	loaded, xerr := ReadBatch(refs, func(ref string) (data.Identifiable, fail.Error) {
+		return LoadHost(svc, ref)
+	})
+	list := make([]resources.Host, len(loaded))
+	for k, v := range loaded {
+		if v != nil {
+			list[k] = v.(resources.Host)
+		}
+	}
+	return list, xerr
+}
 
 // updateCachedInformation loads in cache SSH configuration to access host; this information will not change over time
 func (instance *Host) updateCachedInformation() fail.Error {
@@ -229,6 +274,26 @@ func (instance *Host) updateCachedInformation() fail.Error {
 			return fail.InconsistentError("'*abstract.HostCore' expected, '%s' provided", reflect.TypeOf(clonable).String())
 		}
 
+		preferPrivateIP := getPreferPrivateAccessIPFromCfg(svc)
+		innerXErr := props.Inspect(hostproperty.DescriptionV1, func(clonable data.Clonable) fail.Error {
+			hostDescriptionV1, ok := clonable.(*propertiesv1.HostDescription)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostDescription' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			switch hostDescriptionV1.AccessPreference {
+			case accesspreference.Public:
+				preferPrivateIP = false
+			case accesspreference.Private:
+				preferPrivateIP = true
+			default:
+			}
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
 		// Do not try to cache hostproperty.NetworkV2 if it's not there; migration upgrade will take care of this
 		// when needed
 		if props.Lookup(hostproperty.NetworkV2) {
@@ -245,83 +310,28 @@ func (instance *Host) updateCachedInformation() fail.Error {
 						instance.privateIP = hnV2.IPv6Addresses[hnV2.DefaultSubnetID]
 					}
 				}
+				instance.privateIPs = make(map[string]string, len(hnV2.SubnetsByID))
+				for k, v := range hnV2.IPv6Addresses {
+					instance.privateIPs[k] = v
+				}
+				for k, v := range hnV2.IPv4Addresses {
+					instance.privateIPs[k] = v
+				}
+				instance.publicIPv4 = hnV2.PublicIPv4
+				instance.publicIPv6 = hnV2.PublicIPv6
 				instance.publicIP = hnV2.PublicIPv4
 				if instance.publicIP == "" {
 					instance.publicIP = hnV2.PublicIPv6
 				}
-				if instance.publicIP != "" {
+				if instance.publicIP != "" && !preferPrivateIP {
 					instance.accessIP = instance.publicIP
 				} else {
 					instance.accessIP = instance.privateIP
 				}
 
-				// During upgrade, hnV2.DefaultSubnetID may be empty string, do not execute the following code in this case
-				// Do not execute neither if Host is single or is a gateway
-				if !hnV2.Single && !hnV2.IsGateway && hnV2.DefaultSubnetID != "" {
-					subnetInstance, xerr := LoadSubnet(svc, "", hnV2.DefaultSubnetID)
-					xerr = debug.InjectPlannedFail(xerr)
-					if xerr != nil {
-						return xerr
-					}
-
-					rgw, xerr := subnetInstance.(*Subnet).UnsafeInspectGateway(true)
-					xerr = debug.InjectPlannedFail(xerr)
-					if xerr != nil {
-						return xerr
-					}
-
-					gwErr := rgw.Inspect(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
-						gwahc, ok := clonable.(*abstract.HostCore)
-						if !ok {
-							return fail.InconsistentError("'*abstract.HostCore' expected, '%s' provided", reflect.TypeOf(clonable).String())
-						}
-
-						ip := rgw.(*Host).accessIP
-						primaryGatewayConfig = &system.SSHConfig{
-							PrivateKey: gwahc.PrivateKey,
-							Port:       int(gwahc.SSHPort),
-							IPAddress:  ip,
-							Hostname:   gwahc.Name,
-							User:       opUser,
-						}
-						return nil
-					})
-					if gwErr != nil {
-						return gwErr
-					}
-
-					// Secondary gateway may not exist...
-					rgw, xerr = subnetInstance.(*Subnet).UnsafeInspectGateway(false)
-					xerr = debug.InjectPlannedFail(xerr)
-					if xerr != nil {
-						switch xerr.(type) {
-						case *fail.ErrNotFound:
-							// continue
-						default:
-							return xerr
-						}
-					} else {
-						gwErr = rgw.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
-							gwahc, ok := clonable.(*abstract.HostCore)
-							if !ok {
-								return fail.InconsistentError("'*abstract.HostCore' expected, '%s' provided", reflect.TypeOf(clonable).String())
-							}
-
-							secondaryGatewayConfig = &system.SSHConfig{
-								PrivateKey: gwahc.PrivateKey,
-								Port:       int(gwahc.SSHPort),
-								IPAddress:  rgw.(*Host).accessIP,
-								Hostname:   rgw.GetName(),
-								User:       opUser,
-							}
-							return nil
-						})
-						if gwErr != nil {
-							return gwErr
-						}
-					}
-				}
-				return nil
+				var xerr fail.Error
+				primaryGatewayConfig, secondaryGatewayConfig, xerr = resolveGatewaysSSHConfig(svc, hnV2, opUser)
+				return xerr
 			})
 			if innerXErr != nil {
 				return innerXErr
@@ -338,39 +348,279 @@ func (instance *Host) updateCachedInformation() fail.Error {
 			}
 		}
 
-		var index uint8
-		innerXErr := props.Inspect(hostproperty.SystemV1, func(clonable data.Clonable) fail.Error {
+		var detected, override []installmethod.Enum
+		innerXErr = props.Inspect(hostproperty.SystemV1, func(clonable data.Clonable) fail.Error {
 			systemV1, ok := clonable.(*propertiesv1.HostSystem)
 			if !ok {
 				logrus.Error(fail.InconsistentError("'*propertiesv1.HostSystem' expected, '%s' provided", reflect.TypeOf(clonable).String()))
 			}
+			instance.privilegeEscalation = systemV1.PrivilegeEscalation
+			instance.defaultShell = systemV1.DefaultShell
+			instance.tempFolder = systemV1.TempFolder
 			if systemV1.Type == "linux" {
 				switch systemV1.Flavor {
 				case "centos", "redhat":
-					index++
-					instance.installMethods[index] = installmethod.Yum
+					detected = append(detected, installmethod.Yum)
 				case "debian":
 					fallthrough
 				case "ubuntu":
-					index++
-					instance.installMethods[index] = installmethod.Apt
+					detected = append(detected, installmethod.Apt)
 				case "fedora", "rhel":
-					index++
-					instance.installMethods[index] = installmethod.Dnf
+					detected = append(detected, installmethod.Dnf)
 				}
 			}
+			override = systemV1.InstallMethods
 			return nil
 		})
 		if innerXErr != nil {
 			return innerXErr
 		}
 
+		// The preference of the Host prevails over the one of the tenant
+		if len(override) == 0 {
+			override = getInstallMethodsFromCfg(svc)
+		}
+		instance.installMethods = buildInstallMethods(detected, override)
+		return nil
+	})
+}
+
+// resolveGatewaysSSHConfig returns the SSH configurations of the gateways to go through to reach the Host described by
+// 'hnV2', or nil if the Host is reached directly
+// Gateways SSH configurations are shared by all the Hosts of the Subnet
+func resolveGatewaysSSHConfig(svc iaas.Service, hnV2 *propertiesv2.HostNetworking, opUser string) (*system.SSHConfig, *system.SSHConfig, fail.Error) {
+	// During upgrade, hnV2.DefaultSubnetID may be empty string, do not execute the following code in this case
+	// Do not execute neither if Host is single or is a gateway
+	if hnV2.Single || hnV2.IsGateway || hnV2.DefaultSubnetID == "" {
+		return nil, nil, nil
+	}
+
+	return subnetGatewaysCache.get(svc.GetName(), hnV2.DefaultSubnetID, func() (*system.SSHConfig, *system.SSHConfig, fail.Error) {
+		return loadGatewaysSSHConfig(svc, hnV2.DefaultSubnetID, opUser)
+	})
+}
+
+// unsafeRefreshGateways resolves again the gateways to go through to reach the Host and updates the cached SSH
+// profile with them, without reloading the metadata of the Host
+func (instance *Host) unsafeRefreshGateways() fail.Error {
+	if instance.sshProfile == nil {
+		return fail.NotAvailableError("no SSH configuration available for Host '%s'", instance.GetName())
+	}
+
+	svc := instance.GetService()
+	var primaryGatewayConfig, secondaryGatewayConfig *system.SSHConfig
+	xerr := instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		if !props.Lookup(hostproperty.NetworkV2) {
+			return nil
+		}
+
+		return props.Inspect(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+			hnV2, ok := clonable.(*propertiesv2.HostNetworking)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			var innerXErr fail.Error
+			primaryGatewayConfig, secondaryGatewayConfig, innerXErr = resolveGatewaysSSHConfig(svc, hnV2, instance.sshProfile.User)
+			return innerXErr
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// the previous profile may be in use, replace it instead of modifying it
+	profile := *instance.sshProfile
+	profile.GatewayConfig = primaryGatewayConfig
+	profile.SecondaryGatewayConfig = secondaryGatewayConfig
+	instance.sshProfile = &profile
+	return nil
+}
+
+// RefreshConnectivity resolves again the gateways to go through to reach the Host, without reloading its metadata, to
+// take into account a change of the gateways of its Subnet, then tells if the Host can now be reached with SSH
+// Not reaching the Host is not an error: returns false with a nil error in this case
+func (instance *Host) RefreshConnectivity(ctx context.Context) (_ bool, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return false, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return false, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return false, xerr
+	}
+
+	if task.Aborted() {
+		return false, fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host")).Entering()
+	defer tracer.Exiting()
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	xerr = instance.unsafeRefreshGateways()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return false, xerr
+	}
+
+	retcode, _, stderr, xerr := instance.UnsafeRun(ctx, "true", outputs.COLLECT, temporal.GetConnectSSHTimeout(), 30*time.Second)
+	if xerr != nil {
+		logrus.Debugf("Host '%s' not reachable after refresh of its gateways: %v", instance.GetName(), xerr)
+		return false, nil
+	}
+	if retcode != 0 {
+		logrus.Debugf("Host '%s' not reachable after refresh of its gateways (retcode=%d): %s", instance.GetName(), retcode, stderr)
+		return false, nil
+	}
+	return true, nil
+}
+
+// buildInstallMethods returns the methods to install Features on a Host, indexed by order of preference (1 = highest)
+// If 'override' is not empty, its methods are used in this order; otherwise the methods 'detected' from the operating
+// system are used, followed by Bash. None, which does not install anything, always comes last.
+func buildInstallMethods(detected, override []installmethod.Enum) map[uint8]installmethod.Enum {
+	methods := override
+	if len(methods) == 0 {
+		methods = append(append([]installmethod.Enum{}, detected...), installmethod.Bash)
+	}
+
+	out := make(map[uint8]installmethod.Enum, len(methods)+1)
+	var index uint8
+	for _, v := range methods {
+		if v == installmethod.None {
+			continue
+		}
 		index++
-		instance.installMethods[index] = installmethod.Bash
-		index++
-		instance.installMethods[index] = installmethod.None
+		out[index] = v
+	}
+	index++
+	out[index] = installmethod.None
+	return out
+}
+
+// getInstallMethodsFromCfg returns the methods to install Features on Hosts set by the tenant option 'InstallMethods'
+// of section 'compute' (list, or string of comma-separated values), or nil if not set or invalid
+func getInstallMethodsFromCfg(svc iaas.Service) []installmethod.Enum {
+	compute, ok := svc.GetTenantParameters()["compute"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var list []string
+	switch v := compute["InstallMethods"].(type) {
+	case string:
+		list = strings.Split(v, ",")
+	case []interface{}:
+		for _, item := range v {
+			list = append(list, fmt.Sprintf("%v", item))
+		}
+	case []string:
+		list = v
+	default:
+		return nil
+	}
+
+	out, xerr := parseInstallMethods(list)
+	if xerr != nil {
+		logrus.Warnf("ignoring tenant option 'InstallMethods': %v", xerr)
+		return nil
+	}
+	return out
+}
+
+// parseInstallMethods converts a list of names of install methods to installmethod.Enum, ignoring empty items
+func parseInstallMethods(list []string) ([]installmethod.Enum, fail.Error) {
+	out := make([]installmethod.Enum, 0, len(list))
+	for _, v := range list {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		method, err := installmethod.Parse(v)
+		if err != nil {
+			return nil, fail.InvalidRequestError("invalid install method '%s'", v)
+		}
+		out = append(out, method)
+	}
+	return out, nil
+}
+
+// loadGatewaysSSHConfig loads the gateways of the Subnet and returns their SSH configurations (the secondary one may be nil)
+func loadGatewaysSSHConfig(svc iaas.Service, subnetID string, opUser string) (primaryGatewayConfig, secondaryGatewayConfig *system.SSHConfig, _ fail.Error) {
+	subnetInstance, xerr := LoadSubnet(svc, "", subnetID)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, nil, xerr
+	}
+
+	rgw, xerr := subnetInstance.(*Subnet).UnsafeInspectGateway(true)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, nil, xerr
+	}
+
+	gwErr := rgw.Inspect(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+		gwahc, ok := clonable.(*abstract.HostCore)
+		if !ok {
+			return fail.InconsistentError("'*abstract.HostCore' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		ip := rgw.(*Host).accessIP
+		primaryGatewayConfig = &system.SSHConfig{
+			PrivateKey: gwahc.PrivateKey,
+			Port:       int(gwahc.SSHPort),
+			IPAddress:  ip,
+			Hostname:   gwahc.Name,
+			User:       opUser,
+		}
 		return nil
 	})
+	if gwErr != nil {
+		return nil, nil, gwErr
+	}
+
+	// Secondary gateway may not exist...
+	rgw, xerr = subnetInstance.(*Subnet).UnsafeInspectGateway(false)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotFound:
+			// continue
+		default:
+			return nil, nil, xerr
+		}
+	} else {
+		gwErr = rgw.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+			gwahc, ok := clonable.(*abstract.HostCore)
+			if !ok {
+				return fail.InconsistentError("'*abstract.HostCore' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			secondaryGatewayConfig = &system.SSHConfig{
+				PrivateKey: gwahc.PrivateKey,
+				Port:       int(gwahc.SSHPort),
+				IPAddress:  rgw.(*Host).accessIP,
+				Hostname:   rgw.GetName(),
+				User:       opUser,
+			}
+			return nil
+		})
+		if gwErr != nil {
+			return nil, nil, gwErr
+		}
+	}
+
+	return primaryGatewayConfig, secondaryGatewayConfig, nil
 }
 
 func getOperatorUsernameFromCfg(svc iaas.Service) (string, fail.Error) {
@@ -394,6 +644,17 @@ func getOperatorUsernameFromCfg(svc iaas.Service) (string, fail.Error) {
 	return userName, nil
 }
 
+// getPreferPrivateAccessIPFromCfg tells if the tenant prefers to access the Hosts with their private IP, even if they have
+// a public one (option 'PreferPrivateAccessIP' of section 'compute' of the tenant)
+func getPreferPrivateAccessIPFromCfg(svc iaas.Service) bool {
+	compute, ok := svc.GetTenantParameters()["compute"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	prefer, _ := compute["PreferPrivateAccessIP"].(bool)
+	return prefer
+}
+
 func (instance *Host) IsNull() bool {
 	return instance == nil || instance.MetadataCore == nil || instance.MetadataCore.IsNull()
 }
@@ -429,8 +690,15 @@ func (instance *Host) carry(clonable data.Clonable) (xerr fail.Error) {
 		}
 	}()
 
+	// writing metadata may last; the reservation must not be considered as stale meanwhile
+	stopRefresh, xerr := kindCache.KeepEntryReserved(identifiable.GetID())
+	if xerr != nil {
+		return xerr
+	}
+
 	// Note: do not validate parameters, this call will do it
 	xerr = instance.MetadataCore.Carry(clonable)
+	stopRefresh()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return xerr
@@ -477,7 +745,7 @@ func (instance *Host) Browse(ctx context.Context, callback func(*abstract.HostCo
 	instance.lock.RLock()
 	defer instance.lock.RUnlock()
 
-	return instance.MetadataCore.BrowseFolder(func(buf []byte) (innerXErr fail.Error) {
+	return instance.MetadataCore.BrowseFolderWithContext(ctx, func(buf []byte) (innerXErr fail.Error) {
 		if task.Aborted() {
 			return fail.AbortedError(nil, "aborted")
 		}
@@ -491,6 +759,43 @@ func (instance *Host) Browse(ctx context.Context, callback func(*abstract.HostCo
 	})
 }
 
+// BrowseFor walks through Host MetadataFolder like Browse, giving up after 'timeout' (0 means no limit)
+// On timeout, returns *fail.ErrTimeout; 'callback' has then been executed only on the Hosts read in time, which are the
+// partial results of the browse
+func (instance *Host) BrowseFor(ctx context.Context, timeout time.Duration, callback func(*abstract.HostCore) fail.Error) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if timeout < 0 {
+		return fail.InvalidParameterError("timeout", "cannot be negative")
+	}
+	if timeout == 0 {
+		return instance.Browse(ctx, callback)
+	}
+
+	browseCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	xerr = instance.Browse(browseCtx, callback)
+	return boundedBrowseError(ctx, xerr, timeout, "Hosts")
+}
+
+// boundedBrowseError returns the error to report for a browse of 'kind' resources bounded by 'timeout'
+// The timeout of the browse is reported with its duration; a deadline of 'parentCtx' itself is reported as is
+func boundedBrowseError(parentCtx context.Context, xerr fail.Error, timeout time.Duration, kind string) fail.Error {
+	switch xerr.(type) {
+	case *fail.ErrTimeout:
+		if parentCtx.Err() != nil {
+			return xerr
+		}
+		return fail.TimeoutError(xerr, timeout, "browse of %s not completed in %s, results are partial", kind, temporal.FormatDuration(timeout))
+	default:
+		return xerr
+	}
+}
+
 // ForceGetState returns the current state of the provider Host after reloading metadata
 func (instance *Host) ForceGetState(ctx context.Context) (state hoststate.Enum, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
@@ -530,6 +835,8 @@ func (instance *Host) ForceGetState(ctx context.Context) (state hoststate.Enum,
 }
 
 // Reload reloads Host from metadata and current Host state on provider state
+// If the Host on provider side does not correspond to the ID stored in metadata (Host replaced out of band),
+// returns *fail.ErrInconsistent
 func (instance *Host) Reload() (xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
@@ -552,10 +859,25 @@ func (instance *Host) Reload() (xerr fail.Error) {
 	}
 
 	// Request Host inspection from provider
-	ahf, xerr := instance.GetService().InspectHost(instance.GetID())
+	hostID := instance.GetID()
+	svc := instance.GetService()
+	ahf, xerr := svc.InspectHost(hostID)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return xerr
+		switch xerr.(type) {
+		case *fail.ErrNotFound:
+			// Host may have been replaced out of band by another one with the same name; check it to report a meaningful error
+			replacement, innerXErr := svc.InspectHostByName(instance.GetName())
+			if innerXErr == nil && replacement.Core.ID != hostID {
+				return fail.InconsistentError("Host '%s' has been replaced on provider side (ID '%s' in metadata, ID '%s' on provider)", instance.GetName(), hostID, replacement.Core.ID)
+			}
+			return xerr
+		default:
+			return xerr
+		}
+	}
+	if ahf.Core.ID != "" && ahf.Core.ID != hostID {
+		return fail.InconsistentError("Host '%s' has been replaced on provider side (ID '%s' in metadata, ID '%s' on provider)", instance.GetName(), hostID, ahf.Core.ID)
 	}
 
 	// Updates the Host metadata
@@ -569,6 +891,19 @@ func (instance *Host) Reload() (xerr fail.Error) {
 		if ahc.LastState != ahf.CurrentState {
 			ahc.LastState = ahf.CurrentState
 			changed = true
+
+			innerXErr := props.Alter(hostproperty.DescriptionV1, func(clonable data.Clonable) fail.Error {
+				hostDescriptionV1, ok := clonable.(*propertiesv1.HostDescription)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv1.HostDescription' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				hostDescriptionV1.LastStateChanged = time.Now()
+				return nil
+			})
+			if innerXErr != nil {
+				return innerXErr
+			}
 		}
 
 		innerXErr := props.Alter(hostproperty.SizingV2, func(clonable data.Clonable) fail.Error {
@@ -620,15 +955,29 @@ func (instance *Host) Reload() (xerr fail.Error) {
 	return instance.updateCachedInformation()
 }
 
+// rLockWithTimeout locks the instance for read, returning fail.ErrTimeout if the lock is held by another operation (a
+// long Create or Delete for example) for more than temporal.GetHostReadLockTimeout()
+func (instance *Host) rLockWithTimeout() fail.Error {
+	timeout := temporal.GetHostReadLockTimeout()
+	if !instance.lock.RLockWithTimeout(timeout) {
+		return fail.TimeoutError(nil, timeout, fmt.Sprintf("Host '%s' is busy with another operation", instance.GetName()))
+	}
+	return nil
+}
+
 // GetState returns the last known state of the Host, without forced inspect
+// If another operation holds the Host for too long, returns the state recorded in metadata without waiting for it
 func (instance *Host) GetState() (state hoststate.Enum) {
 	state = hoststate.Unknown
 	if instance == nil || instance.IsNull() {
 		return state
 	}
 
-	instance.lock.RLock()
-	defer instance.lock.RUnlock()
+	if xerr := instance.rLockWithTimeout(); xerr != nil {
+		logrus.Debugf("%v, returning last known state", xerr)
+	} else {
+		defer instance.lock.RUnlock()
+	}
 
 	_ = instance.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
 		ahc, ok := clonable.(*abstract.HostCore)
@@ -674,7 +1023,39 @@ func (instance *Host) Create(ctx context.Context, hostReq abstract.HostRequest,
 	instance.lock.Lock()
 	defer instance.lock.Unlock()
 
+	xerr = abstract.ValidateGuestLabels(hostReq.GuestLabels)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	// On dry run, only validates the request
+	if hostReq.DryRun {
+		report, xerr := instance.unsafeCheckCreation(ctx, hostReq, hostDef)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return nil, xerr
+		}
+		if len(report.Problems) > 0 {
+			return nil, fail.InvalidRequestError("Host '%s' cannot be created: %s", hostReq.ResourceName, strings.Join(report.Problems, "; "))
+		}
+		return nil, nil
+	}
+
+	instance.keepScriptsOnFailure = hostReq.KeepOnFailure
 	svc := instance.GetService()
+	defer metrics.Measure(metrics.KindOperation, "host.create", svc.GetProviderName())(&xerr)
+
+	// Placement on dedicated hardware is only possible with providers supporting it
+	if hostReq.Tenancy != hosttenancy.Default && !svc.GetCapabilities().DedicatedTenancy {
+		return nil, fail.NotAvailableError("tenancy '%s' is not supported by the provider of tenant '%s'", hostReq.Tenancy.String(), svc.GetName())
+	}
+	if hostReq.DedicatedHostID != "" && hostReq.Tenancy != hosttenancy.Host {
+		return nil, fail.InvalidRequestError("a dedicated host can only be requested with tenancy '%s'", hosttenancy.Host.String())
+	}
+	// Additional network interfaces are only possible with providers supporting them
+	if len(hostReq.AdditionalInterfaces) > 0 && !svc.GetCapabilities().MultipleInterfaces {
+		return nil, fail.NotAvailableError("additional network interfaces are not supported by the provider of tenant '%s'", svc.GetName())
+	}
 
 	// Check if Host exists and is managed bySafeScale
 	hostInstance, xerr := LoadHost(svc, hostReq.ResourceName)
@@ -706,6 +1087,8 @@ func (instance *Host) Create(ctx context.Context, hostReq abstract.HostRequest,
 	}
 
 	// If TemplateID is not explicitly provided, search the appropriate template to satisfy 'hostDef'
+	// (in this case, other templates satisfying 'hostDef' may be tried on transient failure of Host creation)
+	templateSelected := hostReq.TemplateID == ""
 	if hostReq.TemplateID == "" {
 		if hostDef.Template != "" {
 			tmpl, xerr := svc.FindTemplateByName(hostDef.Template)
@@ -767,9 +1150,11 @@ func (instance *Host) Create(ctx context.Context, hostReq abstract.HostRequest,
 			}
 
 			hostReq.Subnets = append(hostReq.Subnets, as)
-			hostReq.SecurityGroupIDs = map[string]struct{}{
-				as.PublicIPSecurityGroupID: {},
-				as.GWSecurityGroupID:       {},
+			if !hostReq.SkipDefaultSecurityGroups {
+				hostReq.SecurityGroupIDs = map[string]struct{}{
+					as.PublicIPSecurityGroupID: {},
+					as.GWSecurityGroupID:       {},
+				}
 			}
 			hostReq.PublicIP = true
 			return nil
@@ -778,6 +1163,41 @@ func (instance *Host) Create(ctx context.Context, hostReq abstract.HostRequest,
 			return nil, xerr
 		}
 	} else {
+		// If no Subnet is requested but a Network is, selects automatically the Subnet of the Network with the most free IPs
+		if len(hostReq.Subnets) == 0 {
+			if hostReq.NetworkID == "" {
+				return nil, fail.InvalidRequestError("no Subnet nor Network provided to create Host '%s'", hostReq.ResourceName)
+			}
+
+			networkInstance, xerr := LoadNetwork(svc, hostReq.NetworkID)
+			xerr = debug.InjectPlannedFail(xerr)
+			if xerr != nil {
+				return nil, xerr
+			}
+
+			subnetInstance, xerr := networkInstance.SelectSubnetForHost(ctx, hostReq.SubnetSelection)
+			networkInstance.Released()
+			xerr = debug.InjectPlannedFail(xerr)
+			if xerr != nil {
+				return nil, fail.Wrap(xerr, "failed to select a Subnet for Host '%s'", hostReq.ResourceName)
+			}
+
+			xerr = subnetInstance.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+				as, ok := clonable.(*abstract.Subnet)
+				if !ok {
+					return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				hostReq.Subnets = append(hostReq.Subnets, as)
+				return nil
+			})
+			subnetInstance.Released()
+			xerr = debug.InjectPlannedFail(xerr)
+			if xerr != nil {
+				return nil, xerr
+			}
+		}
+
 		// By convention, default subnet is the first of the list
 		as := hostReq.Subnets[0]
 		defaultSubnet, xerr = LoadSubnet(svc, "", as.ID)
@@ -791,7 +1211,7 @@ func (instance *Host) Create(ctx context.Context, hostReq abstract.HostRequest,
 		}
 
 		// list IDs of Security Groups to apply to Host
-		if len(hostReq.SecurityGroupIDs) == 0 {
+		if len(hostReq.SecurityGroupIDs) == 0 && !hostReq.SkipDefaultSecurityGroups {
 			hostReq.SecurityGroupIDs = make(map[string]struct{}, len(hostReq.Subnets)+1)
 			for _, v := range hostReq.Subnets {
 				hostReq.SecurityGroupIDs[v.InternalSecurityGroupID] = struct{}{}
@@ -824,16 +1244,25 @@ func (instance *Host) Create(ctx context.Context, hostReq abstract.HostRequest,
 			}
 		}
 	}
+	if xerr = checkDefaultSubnetGateway(hostReq); xerr != nil {
+		return nil, xerr
+	}
+	if xerr = checkAdditionalInterfaces(hostReq); xerr != nil {
+		return nil, xerr
+	}
+	if xerr = checkRequestedSecurityGroups(hostReq); xerr != nil {
+		return nil, xerr
+	}
 	defaultSubnetID := defaultSubnet.GetID()
 
 	// instruct Cloud Provider to create host
-	ahf, userdataContent, xerr := svc.CreateHost(hostReq)
+	ahf, userdataContent, xerr := instance.createHostWithRetry(ctx, &hostReq, hostDef, templateSelected)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		if _, ok := xerr.(*fail.ErrInvalidRequest); ok {
 			return nil, xerr
 		}
-		return nil, fail.Wrap(xerr, "failed to create Host '%s'", hostReq.ResourceName)
+		return nil, surfaceProviderError(xerr, "failed to create Host '%s'", hostReq.ResourceName)
 	}
 
 	defer func() {
@@ -844,6 +1273,19 @@ func (instance *Host) Create(ctx context.Context, hostReq abstract.HostRequest,
 		}
 	}()
 
+	// Some providers return the ID of the Host before it can be queried; wait for it before going further
+	xerr = instance.waitHostSettled(ctx, ahf.Core.ID, hostReq.ResourceName)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	// Makes the public IP static if requested; a failure is recorded (and tried again on stop), the Host being usable anyway
+	var publicIPPreservationError string
+	if hostReq.PreservePublicIP {
+		publicIPPreservationError = preserveHostPublicIP(svc, ahf)
+	}
+
 	// Make sure ssh port wanted is set
 	if hostReq.SSHPort > 0 {
 		ahf.Core.SSHPort = hostReq.SSHPort
@@ -866,69 +1308,78 @@ func (instance *Host) Create(ctx context.Context, hostReq abstract.HostRequest,
 			}
 		}
 	}()
+	defer recordOperation(instance, hostproperty.LastOperationV1, "create")(&xerr)
 
-	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		innerXErr := props.Alter(hostproperty.SizingV2, func(clonable data.Clonable) fail.Error {
-			hostSizingV2, ok := clonable.(*propertiesv2.HostSizing)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv2.HostSizing' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
-
-			hostSizingV2.AllocatedSize = converters.HostEffectiveSizingFromAbstractToPropertyV2(ahf.Sizing)
-			hostSizingV2.RequestedSize = converters.HostSizingRequirementsFromAbstractToPropertyV2(hostDef)
-			return nil
-		})
-		if innerXErr != nil {
-			return innerXErr
-		}
+	// Properties are written at once; if one of them cannot be set, none is kept
+	xerr = instance.AlterMany([]resources.Callback{
+		func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+			return props.Alter(hostproperty.SizingV2, func(clonable data.Clonable) fail.Error {
+				hostSizingV2, ok := clonable.(*propertiesv2.HostSizing)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv2.HostSizing' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
 
+				hostSizingV2.AllocatedSize = converters.HostEffectiveSizingFromAbstractToPropertyV2(ahf.Sizing)
+				hostSizingV2.RequestedSize = converters.HostSizingRequirementsFromAbstractToPropertyV2(hostDef)
+				return nil
+			})
+		},
 		// Sets Host extension DescriptionV1
-		innerXErr = props.Alter(hostproperty.DescriptionV1, func(clonable data.Clonable) fail.Error {
-			hostDescriptionV1, ok := clonable.(*propertiesv1.HostDescription)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv1.HostDescription' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
-
-			_ = hostDescriptionV1.Replace(converters.HostDescriptionFromAbstractToPropertyV1(*ahf.Description))
-			creator := ""
-			hostname, _ := os.Hostname()
-			if curUser, err := user.Current(); err == nil {
-				creator = curUser.Username
-				if hostname != "" {
-					creator += "@" + hostname
+		func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+			return props.Alter(hostproperty.DescriptionV1, func(clonable data.Clonable) fail.Error {
+				hostDescriptionV1, ok := clonable.(*propertiesv1.HostDescription)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv1.HostDescription' expected, '%s' provided", reflect.TypeOf(clonable).String())
 				}
-				if curUser.Name != "" {
-					creator += " (" + curUser.Name + ")"
+
+				_ = hostDescriptionV1.Replace(converters.HostDescriptionFromAbstractToPropertyV1(*ahf.Description))
+				hostDescriptionV1.Creator = currentCreator()
+				hostDescriptionV1.Tenancy = hostReq.Tenancy
+				hostDescriptionV1.DedicatedHostID = hostReq.DedicatedHostID
+				hostDescriptionV1.AccessPreference = hostReq.AccessPreference
+				hostDescriptionV1.Domain = userdataContent.Domain
+				hostDescriptionV1.PreservePublicIP = hostReq.PreservePublicIP
+				hostDescriptionV1.PublicIPPreserved = hostReq.PreservePublicIP && publicIPPreservationError == ""
+				hostDescriptionV1.PublicIPPreservationError = publicIPPreservationError
+				return nil
+			})
+		},
+		// Sets the way to run commands with privileges on the Host
+		func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+			return props.Alter(hostproperty.SystemV1, func(clonable data.Clonable) fail.Error {
+				systemV1, ok := clonable.(*propertiesv1.HostSystem)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv1.HostSystem' expected, '%s' provided", reflect.TypeOf(clonable).String())
 				}
-			} else {
-				creator = "unknown@" + hostname
-			}
-			hostDescriptionV1.Creator = creator
-			return nil
-		})
-		if innerXErr != nil {
-			return innerXErr
-		}
 
+				systemV1.PrivilegeEscalation = hostReq.PrivilegeEscalation
+				systemV1.DefaultShell = hostReq.DefaultShell
+				systemV1.TempFolder = strings.TrimRight(hostReq.TempFolder, "/")
+				systemV1.InstallMethods = hostReq.InstallMethods
+				return nil
+			})
+		},
 		// Updates Host property propertiesv2.HostNetworking
-		return props.Alter(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
-			hnV2, ok := clonable.(*propertiesv2.HostNetworking)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
+		func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+			return props.Alter(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+				hnV2, ok := clonable.(*propertiesv2.HostNetworking)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
 
-			_ = hnV2.Replace(converters.HostNetworkingFromAbstractToPropertyV2(*ahf.Networking))
-			hnV2.DefaultSubnetID = defaultSubnetID
-			hnV2.IsGateway = hostReq.IsGateway
-			hnV2.Single = hostReq.Single
-			hnV2.PublicIPv4 = ahf.Networking.PublicIPv4
-			hnV2.PublicIPv6 = ahf.Networking.PublicIPv6
-			hnV2.SubnetsByID = ahf.Networking.SubnetsByID
-			hnV2.SubnetsByName = ahf.Networking.SubnetsByName
-			hnV2.IPv4Addresses = ahf.Networking.IPv4Addresses
-			hnV2.IPv6Addresses = ahf.Networking.IPv6Addresses
-			return nil
-		})
+				_ = hnV2.Replace(converters.HostNetworkingFromAbstractToPropertyV2(*ahf.Networking))
+				hnV2.DefaultSubnetID = defaultSubnetID
+				hnV2.IsGateway = hostReq.IsGateway
+				hnV2.Single = hostReq.Single
+				hnV2.PublicIPv4 = ahf.Networking.PublicIPv4
+				hnV2.PublicIPv6 = ahf.Networking.PublicIPv6
+				hnV2.SubnetsByID = ahf.Networking.SubnetsByID
+				hnV2.SubnetsByName = ahf.Networking.SubnetsByName
+				hnV2.IPv4Addresses = ahf.Networking.IPv4Addresses
+				hnV2.IPv6Addresses = ahf.Networking.IPv6Addresses
+				return nil
+			})
+		},
 	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
@@ -1001,7 +1452,14 @@ func (instance *Host) Create(ctx context.Context, hostReq abstract.HostRequest,
 		instance.undoUpdateSubnets(hostReq, &xerr)
 	}()
 
-	xerr = instance.finalizeProvisioning(ctx, userdataContent)
+	// keeps the userdata, to be able to run again a failed install phase (with KeepOnFailure) without recreating the Host
+	xerr = instance.persistUserdata(userdataContent)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	xerr = instance.finalizeProvisioning(ctx, hostReq, userdataContent)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return nil, xerr
@@ -1023,231 +1481,471 @@ func (instance *Host) Create(ctx context.Context, hostReq abstract.HostRequest,
 	return userdataContent, nil
 }
 
-// setSecurityGroups sets the Security Groups for the host
-func (instance *Host) setSecurityGroups(ctx context.Context, req abstract.HostRequest, defaultSubnet resources.Subnet) fail.Error {
-	if req.Single {
-		svc := instance.GetService()
-		hostID := instance.GetID()
-		for k := range req.SecurityGroupIDs {
-			if k != "" {
-				xerr := svc.BindSecurityGroupToHost(k, hostID)
-				xerr = debug.InjectPlannedFail(xerr)
-				if xerr != nil {
-					return xerr
-				}
-			}
+// currentCreator forges the description of the creator of a Host, from the user running the daemon
+func currentCreator() string {
+	creator := ""
+	hostname, _ := os.Hostname()
+	if curUser, err := user.Current(); err == nil {
+		creator = curUser.Username
+		if hostname != "" {
+			creator += "@" + hostname
 		}
-		return nil
+		if curUser.Name != "" {
+			creator += " (" + curUser.Name + ")"
+		}
+	} else {
+		creator = "unknown@" + hostname
 	}
+	return creator
+}
 
-	return instance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Alter(hostproperty.SecurityGroupsV1, func(clonable data.Clonable) (innerXErr fail.Error) {
-			hsgV1, ok := clonable.(*propertiesv1.HostSecurityGroups)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv1.HostSecurityGroups' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
-
-			svc := instance.GetService()
-
-			// get default Subnet core data
-			var (
-				defaultAbstractSubnet *abstract.Subnet
-				defaultSubnetID       string
-			)
-			innerXErr = defaultSubnet.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
-				var ok bool
-				defaultAbstractSubnet, ok = clonable.(*abstract.Subnet)
-				if !ok {
-					return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
-				}
+// Import brings under management a Host created outside SafeScale, identified by its ID on provider side; 'subnetID'
+// is the ID of the SafeScale Subnet the Host is attached to, that becomes its default Subnet
+// Metadata are built from the information of the provider; the Host must be reachable by SSH with the operator user of
+// the tenant, using the private key passed in option "PrivateKey" (string), to support the commands running on it.
+// Returns *fail.ErrDuplicate if the Host is already managed by SafeScale.
+func (instance *Host) Import(ctx context.Context, providerHostID, subnetID string, options ...data.ImmutableKeyValue) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
 
-				defaultSubnetID = defaultAbstractSubnet.ID
-				return nil
-			})
-			if innerXErr != nil {
-				return innerXErr
-			}
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if providerHostID == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("providerHostID")
+	}
+	if subnetID == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("subnetID")
+	}
+	if hostname := instance.GetName(); hostname != "" {
+		return fail.NotAvailableError("already carrying Host '%s'", hostname)
+	}
 
-			var gwsg, pubipsg, lansg resources.SecurityGroup
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
 
-			// Apply Security Group for gateways in default Subnet
-			if req.IsGateway && defaultAbstractSubnet.GWSecurityGroupID != "" {
-				if gwsg, innerXErr = LoadSecurityGroup(svc, defaultAbstractSubnet.GWSecurityGroupID); innerXErr != nil {
-					return fail.Wrap(innerXErr, "failed to query Subnet '%s' Security Group '%s'", defaultSubnet.GetName(), defaultAbstractSubnet.GWSecurityGroupID)
-				}
-				if innerXErr = gwsg.BindToHost(ctx, instance, resources.SecurityGroupEnable, resources.MarkSecurityGroupAsSupplemental); innerXErr != nil {
-					return fail.Wrap(innerXErr, "failed to apply Subnet's Security Group for gateway '%s' on Host '%s'", gwsg.GetName(), req.ResourceName)
-				}
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
 
-				defer func() {
-					if innerXErr != nil && !req.KeepOnFailure {
-						if derr := gwsg.UnbindFromHost(context.Background(), instance); derr != nil {
-							_ = innerXErr.AddConsequence(fail.Wrap(derr, "cleaning up on %s, failed to unbind Security Group '%s' from Host '%s'", ActionFromError(innerXErr), gwsg.GetName(), instance.GetName()))
-						}
-					}
-				}()
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(%s, %s)", providerHostID, subnetID).WithStopwatch().Entering()
+	defer tracer.Exiting()
 
-				item := &propertiesv1.SecurityGroupBond{
-					ID:         gwsg.GetID(),
-					Name:       gwsg.GetName(),
-					Disabled:   false,
-					FromSubnet: true,
-				}
-				hsgV1.ByID[item.ID] = item
-				hsgV1.ByName[item.Name] = item.ID
-			}
+	var privateKey string
+	for _, v := range options {
+		switch v.Key() {
+		case "PrivateKey":
+			privateKey, _ = v.Value().(string)
+		default:
+		}
+	}
 
-			// Apply Security Group for hosts with public IP in default Subnet
-			if (req.IsGateway || req.PublicIP) && defaultAbstractSubnet.PublicIPSecurityGroupID != "" {
-				if pubipsg, innerXErr = LoadSecurityGroup(svc, defaultAbstractSubnet.PublicIPSecurityGroupID); innerXErr != nil {
-					return fail.Wrap(innerXErr, "failed to query Subnet '%s' Security Group with ID %s", defaultSubnet.GetName(), defaultAbstractSubnet.PublicIPSecurityGroupID)
-				}
-				defer pubipsg.Released()
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
 
-				if innerXErr = pubipsg.BindToHost(ctx, instance, resources.SecurityGroupEnable, resources.MarkSecurityGroupAsSupplemental); innerXErr != nil {
-					return fail.Wrap(innerXErr, "failed to apply Subnet's Security Group for gateway '%s' on Host '%s'", pubipsg.GetName(), req.ResourceName)
-				}
+	svc := instance.GetService()
 
-				defer func() {
-					if innerXErr != nil && !req.KeepOnFailure {
-						if derr := pubipsg.UnbindFromHost(context.Background(), instance); derr != nil {
-							_ = innerXErr.AddConsequence(fail.Wrap(derr, "cleaning up on %s, failed to unbind Security Group '%s' from Host '%s'", ActionFromError(innerXErr), pubipsg.GetName(), instance.GetName()))
-						}
-					}
-				}()
+	ahf, xerr := svc.InspectHost(providerHostID)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return fail.Wrap(xerr, "failed to inspect Host '%s' on provider side", providerHostID)
+	}
 
-				item := &propertiesv1.SecurityGroupBond{
-					ID:         pubipsg.GetID(),
-					Name:       pubipsg.GetName(),
-					Disabled:   false,
-					FromSubnet: true,
-				}
-				hsgV1.ByID[item.ID] = item
-				hsgV1.ByName[item.Name] = item.ID
+	// Refuses a Host already managed, by its ID or by its name
+	for _, ref := range []string{ahf.Core.ID, ahf.Core.Name} {
+		if ref == "" {
+			continue
+		}
+		hostInstance, xerr := LoadHost(svc, ref)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrNotFound:
+				// continue
+			default:
+				return fail.Wrap(xerr, "failed to check if Host '%s' is already managed", ref)
 			}
+		} else {
+			hostInstance.Released()
+			return fail.DuplicateError("Host '%s' is already managed by SafeScale", ref)
+		}
+	}
 
-			// Apply internal Security Group of each other subnets
-			defer func() {
-				if innerXErr != nil && !req.KeepOnFailure {
-					var (
-						sg     resources.SecurityGroup
-						derr   error
-						errors []error
-					)
-					for _, v := range req.Subnets {
-						if v.ID == defaultSubnetID {
-							continue
-						}
+	subnetInstance, xerr := LoadSubnet(svc, "", subnetID)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+	defer subnetInstance.Released()
 
-						subnetInstance, deeperXErr := LoadSubnet(svc, "", v.ID)
-						if deeperXErr != nil {
-							_ = innerXErr.AddConsequence(deeperXErr)
-							continue
-						}
+	var as *abstract.Subnet
+	xerr = subnetInstance.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+		var ok bool
+		as, ok = clonable.(*abstract.Subnet)
+		if !ok {
+			return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+		return nil
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
 
-						//goland:noinspection ALL
-						defer func(item resources.Subnet) {
-							item.Released()
-						}(subnetInstance)
+	if ahf.Networking.IPv4Addresses[as.ID] == "" && ahf.Networking.IPv6Addresses[as.ID] == "" {
+		return fail.InvalidRequestError("Host '%s' is not attached to Subnet '%s'", ahf.Core.Name, as.Name)
+	}
+	for _, v := range as.GatewayIDs {
+		if v == ahf.Core.ID {
+			return fail.InvalidRequestError("Host '%s' is a gateway of Subnet '%s' and cannot be imported", ahf.Core.Name, as.Name)
+		}
+	}
 
-						sgName := sg.GetName()
-						deeperXErr = subnetInstance.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
-							abstractSubnet, ok := clonable.(*abstract.Subnet)
-							if !ok {
-								return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
-							}
+	if ahf.Core.SSHPort == 0 {
+		ahf.Core.SSHPort = 22
+	}
+	if privateKey != "" {
+		ahf.Core.PrivateKey = privateKey
+	}
 
-							if abstractSubnet.InternalSecurityGroupID != "" {
-								if sg, derr = LoadSecurityGroup(svc, abstractSubnet.InternalSecurityGroupID); derr == nil {
-									derr = sg.UnbindFromHost(context.Background(), instance)
-									sg.Released()
-								}
-								if derr != nil {
-									errors = append(errors, derr)
-								}
-							}
-							return nil
-						})
-						if deeperXErr != nil {
-							_ = innerXErr.AddConsequence(fail.Wrap(deeperXErr, "cleaning up on failure, failed to unbind Security Group '%s' from Host", sgName))
-						}
-					}
-					if len(errors) > 0 {
-						_ = innerXErr.AddConsequence(fail.Wrap(fail.NewErrorList(errors), "failed to unbind Subnets Security Group from Host '%s'", sg.GetName(), req.ResourceName))
-					}
-				}
-			}()
+	xerr = instance.carry(ahf.Core)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
 
-			for _, v := range req.Subnets {
-				// Do not try to bind defaultSubnet on gateway, because this code is running under a lock on defaultSubnet in this case, and this will lead to deadlock
-				// (binding of gateway on defaultSubnet is done inside Subnet.Create() call)
-				if req.IsGateway && v.ID == defaultSubnetID {
-					continue
-				}
+	// On failure, only metadata are removed: the Host on provider side has not been created by the import
+	defer func() {
+		if xerr != nil {
+			if derr := instance.MetadataCore.Delete(); derr != nil {
+				logrus.Errorf("cleaning up on %s, failed to delete Host '%s' metadata: %v", ActionFromError(xerr), ahf.Core.Name, derr)
+				_ = xerr.AddConsequence(derr)
+			}
+		}
+	}()
 
-				otherSubnetInstance, xerr := LoadSubnet(svc, "", v.ID)
-				xerr = debug.InjectPlannedFail(xerr)
-				if xerr != nil {
-					return xerr
-				}
-				//goland:noinspection ALL
-				defer func(subnetInstance resources.Subnet) {
-					subnetInstance.Released()
-				}(otherSubnetInstance)
+	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		innerXErr := props.Alter(hostproperty.SizingV2, func(clonable data.Clonable) fail.Error {
+			hostSizingV2, ok := clonable.(*propertiesv2.HostSizing)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.HostSizing' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
 
-				var otherAbstractSubnet *abstract.Subnet
-				xerr = otherSubnetInstance.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
-					var ok bool
-					otherAbstractSubnet, ok = clonable.(*abstract.Subnet)
-					if !ok {
-						return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
-					}
+			hostSizingV2.AllocatedSize = converters.HostEffectiveSizingFromAbstractToPropertyV2(ahf.Sizing)
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
 
-					return nil
-				})
-				if xerr != nil {
-					return xerr
-				}
+		innerXErr = props.Alter(hostproperty.DescriptionV1, func(clonable data.Clonable) fail.Error {
+			hostDescriptionV1, ok := clonable.(*propertiesv1.HostDescription)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostDescription' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
 
-				if otherAbstractSubnet.InternalSecurityGroupID != "" {
-					if lansg, innerXErr = LoadSecurityGroup(svc, otherAbstractSubnet.InternalSecurityGroupID); innerXErr != nil {
-						return fail.Wrap(innerXErr, "failed to load Subnet '%s' internal Security Group %s", otherAbstractSubnet.Name, otherAbstractSubnet.InternalSecurityGroupID)
-					}
-					defer func(sgInstance resources.SecurityGroup) {
-						sgInstance.Released()
-					}(lansg)
+			_ = hostDescriptionV1.Replace(converters.HostDescriptionFromAbstractToPropertyV1(*ahf.Description))
+			hostDescriptionV1.Creator = currentCreator()
+			hostDescriptionV1.Tenant = svc.GetName()
+			hostDescriptionV1.Imported = true
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
 
-					if innerXErr = lansg.BindToHost(ctx, instance, resources.SecurityGroupEnable, resources.MarkSecurityGroupAsSupplemental); innerXErr != nil {
-						return fail.Wrap(innerXErr, "failed to apply Subnet '%s' internal Security Group '%s' to Host '%s'", otherAbstractSubnet.Name, lansg.GetName(), req.ResourceName)
-					}
-					defer func(sgInstance resources.SecurityGroup) {
-						sgInstance.Released()
-					}(lansg)
+		return props.Alter(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+			hnV2, ok := clonable.(*propertiesv2.HostNetworking)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
 
-					if innerXErr = lansg.BindToHost(ctx, instance, resources.SecurityGroupEnable, resources.MarkSecurityGroupAsSupplemental); innerXErr != nil {
-						return fail.Wrap(innerXErr, "failed to apply Subnet '%s' internal Security Group '%s' to Host '%s'", otherAbstractSubnet.GetName(), lansg.GetName(), req.ResourceName)
-					}
-					return nil
-				}
+			_ = hnV2.Replace(converters.HostNetworkingFromAbstractToPropertyV2(*ahf.Networking))
+			hnV2.DefaultSubnetID = as.ID
+			hnV2.PublicIPv4 = ahf.Networking.PublicIPv4
+			hnV2.PublicIPv6 = ahf.Networking.PublicIPv6
+			hnV2.SubnetsByID = ahf.Networking.SubnetsByID
+			hnV2.SubnetsByName = ahf.Networking.SubnetsByName
+			hnV2.IPv4Addresses = ahf.Networking.IPv4Addresses
+			hnV2.IPv6Addresses = ahf.Networking.IPv6Addresses
+			if hnV2.SubnetsByID == nil {
+				hnV2.SubnetsByID = map[string]string{}
+			}
+			if hnV2.SubnetsByName == nil {
+				hnV2.SubnetsByName = map[string]string{}
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	xerr = instance.updateCachedInformation()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// -- Links the Host with its Subnet --
+	req := abstract.HostRequest{ResourceName: ahf.Core.Name, Subnets: []*abstract.Subnet{as}}
+	xerr = instance.updateSubnets(task, req)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+	defer func() {
+		instance.undoUpdateSubnets(req, &xerr)
+	}()
+
+	logrus.Infof("Host '%s' imported successfully", instance.GetName())
+	return nil
+}
+
+// setSecurityGroups sets the Security Groups for the host
+func (instance *Host) setSecurityGroups(ctx context.Context, req abstract.HostRequest, defaultSubnet resources.Subnet) fail.Error {
+	if req.Single {
+		svc := instance.GetService()
+		hostID := instance.GetID()
+		for k := range req.SecurityGroupIDs {
+			if k != "" {
+				xerr := svc.BindSecurityGroupToHost(k, hostID)
 				xerr = debug.InjectPlannedFail(xerr)
 				if xerr != nil {
 					return xerr
 				}
+			}
+		}
+		return nil
+	}
 
-				// register security group in properties
-				item := &propertiesv1.SecurityGroupBond{
-					ID:         lansg.GetID(),
-					Name:       lansg.GetName(),
-					Disabled:   false,
-					FromSubnet: true,
-				}
-				hsgV1.ByID[item.ID] = item
-				hsgV1.ByName[item.Name] = item.ID
+	if req.SkipDefaultSecurityGroups {
+		return instance.setRequestedSecurityGroups(ctx, req)
+	}
+
+	svc := instance.GetService()
+
+	// get default Subnet core data
+	var (
+		defaultAbstractSubnet *abstract.Subnet
+		defaultSubnetID       string
+	)
+	xerr := defaultSubnet.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+		var ok bool
+		defaultAbstractSubnet, ok = clonable.(*abstract.Subnet)
+		if !ok {
+			return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		defaultSubnetID = defaultAbstractSubnet.ID
+		return nil
+	})
+	if xerr != nil {
+		return xerr
+	}
+
+	var bindings hostSecurityGroupBindings
+	defer bindings.release()
+
+	// Security Group for gateways in default Subnet
+	if req.IsGateway && defaultAbstractSubnet.GWSecurityGroupID != "" {
+		gwsg, xerr := LoadSecurityGroup(svc, defaultAbstractSubnet.GWSecurityGroupID)
+		if xerr != nil {
+			return fail.Wrap(xerr, "failed to query Subnet '%s' Security Group '%s'", defaultSubnet.GetName(), defaultAbstractSubnet.GWSecurityGroupID)
+		}
+		bindings.add(gwsg, true)
+	}
+
+	// Security Group for hosts with public IP in default Subnet
+	if (req.IsGateway || req.PublicIP) && defaultAbstractSubnet.PublicIPSecurityGroupID != "" {
+		pubipsg, xerr := LoadSecurityGroup(svc, defaultAbstractSubnet.PublicIPSecurityGroupID)
+		if xerr != nil {
+			return fail.Wrap(xerr, "failed to query Subnet '%s' Security Group with ID %s", defaultSubnet.GetName(), defaultAbstractSubnet.PublicIPSecurityGroupID)
+		}
+		bindings.add(pubipsg, true)
+	}
+
+	// Internal Security Group of each Subnet
+	for _, v := range req.Subnets {
+		// Do not try to bind defaultSubnet on gateway, because this code is running under a lock on defaultSubnet in this case, and this will lead to deadlock
+		// (binding of gateway on defaultSubnet is done inside Subnet.Create() call)
+		if req.IsGateway && v.ID == defaultSubnetID {
+			continue
+		}
+
+		otherSubnetInstance, xerr := LoadSubnet(svc, "", v.ID)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+
+		var otherAbstractSubnet *abstract.Subnet
+		xerr = otherSubnetInstance.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+			var ok bool
+			otherAbstractSubnet, ok = clonable.(*abstract.Subnet)
+			if !ok {
+				return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			return nil
+		})
+		otherSubnetInstance.Released()
+		if xerr != nil {
+			return xerr
+		}
+
+		if otherAbstractSubnet.InternalSecurityGroupID != "" {
+			lansg, xerr := LoadSecurityGroup(svc, otherAbstractSubnet.InternalSecurityGroupID)
+			if xerr != nil {
+				return fail.Wrap(xerr, "failed to load Subnet '%s' internal Security Group %s", otherAbstractSubnet.Name, otherAbstractSubnet.InternalSecurityGroupID)
+			}
+			bindings.add(lansg, true)
+		}
+	}
+
+	return instance.applySecurityGroups(ctx, bindings, req.KeepOnFailure)
+}
+
+// setRequestedSecurityGroups binds to the Host only the Security Groups listed in the request, for a Host created with
+// SkipDefaultSecurityGroups
+func (instance *Host) setRequestedSecurityGroups(ctx context.Context, req abstract.HostRequest) fail.Error {
+	svc := instance.GetService()
+
+	var bindings hostSecurityGroupBindings
+	defer bindings.release()
+
+	for k := range req.SecurityGroupIDs {
+		if k == "" {
+			continue
+		}
+
+		sg, xerr := LoadSecurityGroup(svc, k)
+		if xerr != nil {
+			return fail.Wrap(xerr, "failed to load Security Group '%s'", k)
+		}
+		bindings.add(sg, false)
+	}
+
+	return instance.applySecurityGroups(ctx, bindings, req.KeepOnFailure)
+}
+
+// applySecurityGroups binds the Security Groups to the Host, then records in metadata, in one go, the Security Groups
+// actually bound on provider side, whatever the outcome of the bindings
+func (instance *Host) applySecurityGroups(ctx context.Context, bindings hostSecurityGroupBindings, keepOnFailure bool) fail.Error {
+	bonds, xerr := bindings.bind(ctx, instance, keepOnFailure)
+	if len(bonds) == 0 {
+		return xerr
+	}
+
+	rerr := instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(hostproperty.SecurityGroupsV1, func(clonable data.Clonable) fail.Error {
+			hsgV1, ok := clonable.(*propertiesv1.HostSecurityGroups)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostSecurityGroups' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
+			recordSecurityGroupBonds(hsgV1, bonds)
 			return nil
 		})
 	})
+	if rerr != nil {
+		rerr = fail.Wrap(rerr, "failed to record Security Groups bound to Host '%s'", instance.GetName())
+		if xerr != nil {
+			_ = xerr.AddConsequence(rerr)
+			return xerr
+		}
+
+		// all the bindings succeeded, so bonds match bindings
+		if !keepOnFailure {
+			_ = bindings.unbind(instance, bonds, rerr)
+		}
+		return rerr
+	}
+
+	return xerr
+}
+
+// hostSecurityGroupBinding describes a Security Group to bind to a Host
+type hostSecurityGroupBinding struct {
+	sg         resources.SecurityGroup
+	fromSubnet bool // tells if the Security Group is bound because it is one of a Subnet of the Host
+}
+
+// hostSecurityGroupBindings stages the Security Groups to bind to a Host, so that metadata of the Host are updated only
+// with the bindings in place on provider side
+type hostSecurityGroupBindings []hostSecurityGroupBinding
+
+// add appends 'sg' to the Security Groups to bind, if not already there
+func (bindings *hostSecurityGroupBindings) add(sg resources.SecurityGroup, fromSubnet bool) {
+	for _, v := range *bindings {
+		if v.sg.GetID() == sg.GetID() {
+			sg.Released()
+			return
+		}
+	}
+	*bindings = append(*bindings, hostSecurityGroupBinding{sg: sg, fromSubnet: fromSubnet})
+}
+
+// release releases the instances of the Security Groups
+func (bindings hostSecurityGroupBindings) release() {
+	for _, v := range bindings {
+		v.sg.Released()
+	}
+}
+
+// bind binds the Security Groups to the Host in order, and returns the bonds to record in metadata
+// On failure, the Security Groups already bound are unbound, unless keepOnFailure is true; the bonds returned are the
+// ones of the Security Groups still bound (kept, or which failed to be unbound), so metadata match provider side
+func (bindings hostSecurityGroupBindings) bind(ctx context.Context, host resources.Host, keepOnFailure bool) (_ []*propertiesv1.SecurityGroupBond, xerr fail.Error) {
+	bonds := make([]*propertiesv1.SecurityGroupBond, 0, len(bindings))
+	for _, v := range bindings {
+		xerr = v.sg.BindToHost(ctx, host, resources.SecurityGroupEnable, resources.MarkSecurityGroupAsSupplemental)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			xerr = fail.Wrap(xerr, "failed to apply Security Group '%s' on Host '%s'", v.sg.GetName(), host.GetName())
+			break
+		}
+
+		bonds = append(bonds, &propertiesv1.SecurityGroupBond{
+			ID:         v.sg.GetID(),
+			Name:       v.sg.GetName(),
+			Disabled:   false,
+			FromSubnet: v.fromSubnet,
+		})
+	}
+	if xerr == nil || keepOnFailure {
+		return bonds, xerr
+	}
+
+	return bindings.unbind(host, bonds, xerr), xerr
+}
+
+// unbind unbinds from the Host, in reverse order, the Security Groups of 'bonds' (the first ones of bindings, in the
+// same order), adding failures as consequences of 'cause'; returns the bonds of the Security Groups still bound
+func (bindings hostSecurityGroupBindings) unbind(host resources.Host, bonds []*propertiesv1.SecurityGroupBond, cause fail.Error) []*propertiesv1.SecurityGroupBond {
+	var kept []*propertiesv1.SecurityGroupBond
+	for i := len(bonds) - 1; i >= 0; i-- {
+		sg := bindings[i].sg
+		if derr := sg.UnbindFromHost(context.Background(), host); derr != nil {
+			_ = cause.AddConsequence(fail.Wrap(derr, "cleaning up on %s, failed to unbind Security Group '%s' from Host '%s'", ActionFromError(cause), sg.GetName(), host.GetName()))
+			kept = append(kept, bonds[i])
+		}
+	}
+	return kept
+}
+
+// recordSecurityGroupBonds registers the bonds in the Security Groups property of a Host
+func recordSecurityGroupBonds(hsgV1 *propertiesv1.HostSecurityGroups, bonds []*propertiesv1.SecurityGroupBond) {
+	for _, v := range bonds {
+		hsgV1.ByID[v.ID] = v
+		hsgV1.ByName[v.Name] = v.ID
+	}
 }
 
 func (instance *Host) undoSetSecurityGroups(errorPtr *fail.Error, keepOnFailure bool) {
@@ -1316,590 +2014,3016 @@ func (instance *Host) unbindDefaultSecurityGroupIfNeeded(networkID string) fail.
 	return nil
 }
 
-func (instance *Host) findTemplateID(hostDef abstract.HostSizingRequirements) (string, fail.Error) {
-	svc := instance.GetService()
-	if hostDef.Template != "" {
-		if tpl, xerr := svc.FindTemplateByName(hostDef.Template); xerr == nil {
-			return tpl.ID, nil
+// IsTransientHostCreationError tells if an error returned by the provider on Host creation is worth a retry
+// (lack of capacity, rate limiting, ...). Quota exhaustion or invalid request must not be considered as transient.
+// May be replaced to adapt the classification to a specific provider.
+var IsTransientHostCreationError = func(xerr fail.Error) bool {
+	switch xerr.(type) {
+	case *fail.ErrOverload, *fail.ErrNotAvailable, *fail.ErrTimeout:
+		return true
+	case *fail.ErrInvalidRequest, *fail.ErrInvalidParameter, *fail.ErrOverflow, *fail.ErrForbidden, *fail.ErrNotFound, *fail.ErrDuplicate:
+		return false
+	default:
+	}
+
+	msg := strings.ToLower(xerr.Error())
+	for _, v := range []string{"insufficient capacity", "insufficientinstancecapacity", "rate limit", "too many requests", "try again later"} {
+		if strings.Contains(msg, v) {
+			return true
 		}
-		logrus.Warning(fail.NotFoundError("failed to find template '%s', trying to guess from sizing...", hostDef.Template))
 	}
-
-	template, xerr := svc.FindTemplateBySizing(hostDef)
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return "", xerr
-	}
-
-	return template.ID, nil
+	return false
 }
 
-func (instance *Host) findImageID(hostDef *abstract.HostSizingRequirements) (string, fail.Error) {
-	svc := instance.GetService()
-	if hostDef.Image == "" {
-		cfg, xerr := svc.GetConfigurationOptions()
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			return "", xerr
+// getHostCreationAttempts returns the maximum number of tries to create a Host on provider side,
+// that can be overridden by environment variable SAFESCALE_HOST_CREATION_ATTEMPTS
+func getHostCreationAttempts() uint {
+	if candidate := os.Getenv("SAFESCALE_HOST_CREATION_ATTEMPTS"); candidate != "" {
+		if num, err := strconv.Atoi(candidate); err == nil && num > 0 {
+			return uint(num)
 		}
-		hostDef.Image = cfg.GetString("DefaultImage")
-	}
-
-	var img *abstract.Image
-	xerr := retry.WhileUnsuccessfulDelay1Second(
-		func() error {
-			var innerXErr fail.Error
-			img, innerXErr = svc.SearchImage(hostDef.Image)
-			return innerXErr
-		},
-		30*time.Second,
-	)
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return "", xerr
+		logrus.Warnf("Invalid value '%s' for SAFESCALE_HOST_CREATION_ATTEMPTS, using default", candidate)
 	}
-	return img.ID, nil
+	return defaultHostCreationAttempts
 }
 
-// runInstallPhase uploads then starts script corresponding to phase 'phase'
-func (instance *Host) runInstallPhase(ctx context.Context, phase userdata.Phase, userdataContent *userdata.Content) fail.Error {
-	content, xerr := userdataContent.Generate(phase)
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return xerr
+// preserveHostPublicIP makes static the public IP of the Host described by 'ahf' if the provider releases the public IP
+// of a stopped Host, updating 'ahf' with the public IP kept (depending on the provider, it may differ from the previous
+// one); returns the reason why the public IP is not preserved, empty if it is
+func preserveHostPublicIP(svc iaas.Service, ahf *abstract.HostFull) string {
+	if ahf.Networking.PublicIPv4 == "" {
+		return "Host has no public IP"
 	}
-
-	file := fmt.Sprintf("%s/user_data.%s.sh", utils.TempFolder, phase)
-	xerr = instance.unsafePushStringToFile(ctx, string(content), file)
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return xerr
+	if !svc.GetCapabilities().EphemeralPublicIP {
+		// the provider keeps the public IP of a stopped Host
+		return ""
 	}
 
-	command := fmt.Sprintf("sudo bash %s; exit $?", file)
-	// Executes the script on the remote Host
-	retcode, _, stderr, xerr := instance.UnsafeRun(ctx, command, outputs.COLLECT, 0, 0)
+	publicIP, xerr := svc.PreserveHostPublicIP(ahf.Core.ID)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return fail.Wrap(xerr, "failed to apply configuration phase '%s'", phase)
+		logrus.Warnf("failed to preserve public IP of Host '%s', it may change when the Host is stopped: %v", ahf.Core.Name, xerr)
+		return xerr.Error()
 	}
-	if retcode != 0 {
-		if retcode == 255 {
-			return fail.NewError("failed to execute install phase '%s' on Host '%s': SSH connection failed", phase, instance.GetName())
-		}
-		return fail.NewError("failed to execute install phase '%s' on Host '%s': %s", phase, instance.GetName(), stderr)
+	if publicIP != ahf.Networking.PublicIPv4 {
+		logrus.Infof("public IP of Host '%s' changed from %s to %s to be preserved", ahf.Core.Name, ahf.Networking.PublicIPv4, publicIP)
+		ahf.Networking.PublicIPv4 = publicIP
 	}
-	return nil
+	return ""
 }
 
-func (instance *Host) waitInstallPhase(ctx context.Context, phase userdata.Phase, timeout time.Duration) (string, fail.Error) {
-	sshDefaultTimeout := int(temporal.GetHostTimeout().Minutes())
-	if sshDefaultTimeoutCandidate := os.Getenv("SSH_TIMEOUT"); sshDefaultTimeoutCandidate != "" {
-		if num, err := strconv.Atoi(sshDefaultTimeoutCandidate); err == nil {
-			logrus.Debugf("Using custom timeout of %d minutes", num)
-			sshDefaultTimeout = num
+// getHostSettleAttempts returns the maximum number of queries of the state of a new Host waiting for it to be
+// consistently queryable, that can be overridden by environment variable SAFESCALE_HOST_SETTLE_ATTEMPTS (0 disables
+// the wait)
+func getHostSettleAttempts() uint {
+	if candidate := os.Getenv("SAFESCALE_HOST_SETTLE_ATTEMPTS"); candidate != "" {
+		if num, err := strconv.Atoi(candidate); err == nil && num >= 0 {
+			return uint(num)
 		}
+		logrus.Warnf("Invalid value '%s' for SAFESCALE_HOST_SETTLE_ATTEMPTS, using default", candidate)
+	}
+	return defaultHostSettleAttempts
+}
+
+// waitHostSettled waits for the Host with ID 'hostID', just created by the provider, to be consistently queryable:
+// some providers with eventual consistency return the ID of a new Host before it can be inspected, and the next steps
+// of the creation would fail on a transient "not found"
+func (instance *Host) waitHostSettled(ctx context.Context, hostID, hostName string) fail.Error {
+	attempts := getHostSettleAttempts()
+	if attempts == 0 {
+		return nil
+	}
+	if attempts < hostSettleSuccesses {
+		attempts = hostSettleSuccesses
 	}
 
-	// TODO: configurable timeout here
-	duration := time.Duration(sshDefaultTimeout) * time.Minute
-	status, xerr := instance.sshProfile.WaitServerReady(ctx, string(phase), time.Duration(sshDefaultTimeout)*time.Minute)
+	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		switch xerr.(type) {
-		case *fail.ErrTimeout:
-			return status, fail.Wrap(xerr.Cause(), "failed to wait for SSH on Host '%s' to be ready after %s (phase %s): %s", instance.GetName(), temporal.FormatDuration(duration), phase, status)
-		default:
-		}
-		if abstract.IsProvisioningError(xerr) {
-			logrus.Errorf("%+v", xerr)
-			return status, fail.Wrap(xerr, "error provisioning Host '%s', please check safescaled logs", instance.GetName())
-		}
+		return xerr
 	}
-	return status, xerr
-}
 
-// updateSubnets updates subnets on which host is attached and host property HostNetworkV2
-func (instance *Host) updateSubnets(task concurrency.Task, req abstract.HostRequest) fail.Error {
-	if task.Aborted() {
-		return fail.AbortedError(nil, "aborted")
-	}
+	var (
+		attempt, successes uint
+		seen               bool
+		lastXErr           fail.Error
+	)
+	svc := instance.GetService()
+	xerr = retry.Action(
+		func() error {
+			if task.Aborted() {
+				lastXErr = fail.AbortedError(nil, "aborted")
+				return retry.StopRetryError(lastXErr)
+			}
 
-	// If Host is a gateway or is single, do not add it as Host attached to the Subnet, it's considered as part of the subnet
-	if !req.IsGateway && !req.Single {
-		return instance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
-			return props.Alter(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
-				hnV2, ok := clonable.(*propertiesv2.HostNetworking)
-				if !ok {
-					return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			attempt++
+			_, innerXErr := svc.GetHostState(hostID)
+			if innerXErr == nil {
+				seen = true
+				successes++
+				if successes >= hostSettleSuccesses {
+					return nil
 				}
+				return fail.NotAvailableError("Host '%s' queried successfully %d time(s) in a row", hostName, successes)
+			}
 
-				hostID := instance.GetID()
-				hostName := instance.GetName()
-
-				for _, as := range req.Subnets {
-					rs, innerXErr := LoadSubnet(instance.MetadataCore.GetService(), "", as.ID)
-					if innerXErr != nil {
-						return innerXErr
-					}
-
-					innerXErr = rs.Alter(func(clonable data.Clonable, properties *serialize.JSONProperties) fail.Error {
-						return properties.Alter(subnetproperty.HostsV1, func(clonable data.Clonable) fail.Error {
-							subnetHostsV1, ok := clonable.(*propertiesv1.SubnetHosts)
-							if !ok {
-								return fail.InconsistentError("'*propertiesv1.SubnetHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
-							}
-
-							subnetHostsV1.ByName[hostName] = hostID
-							subnetHostsV1.ByID[hostID] = hostName
-							return nil
-						})
-					})
-					if innerXErr != nil {
-						return innerXErr
-					}
-
-					hnV2.SubnetsByID[as.ID] = as.Name
-					hnV2.SubnetsByName[as.Name] = as.ID
+			successes = 0
+			lastXErr = innerXErr
+			switch innerXErr.(type) {
+			case *fail.ErrNotFound:
+				logrus.Debugf("Host '%s' not queryable yet (attempt %d/%d): %v", hostName, attempt, attempts, innerXErr)
+				return innerXErr
+			default:
+				if IsTransientHostCreationError(innerXErr) {
+					return innerXErr
 				}
-				return nil
-			})
-		})
+				return retry.StopRetryError(innerXErr)
+			}
+		},
+		retry.PrevailDone(retry.Unsuccessful(), retry.Max(attempts)),
+		retry.Constant(hostSettleDelay),
+		nil,
+		nil,
+		nil,
+	)
+	if xerr != nil {
+		return classifyHostSettleFailure(hostName, seen, lastXErr)
 	}
 	return nil
 }
 
-// undoUpdateSubnets removes what updateSubnets have done
-func (instance *Host) undoUpdateSubnets(req abstract.HostRequest, errorPtr *fail.Error) {
-	if errorPtr != nil && *errorPtr != nil && !req.IsGateway && !req.Single && !req.KeepOnFailure {
-		// // Without this,the undo will not be able to complete in case it's called on an abort...
-		// defer task.DisarmAbortSignal()()
-
-		xerr := instance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
-			return props.Alter(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
-				hsV1, ok := clonable.(*propertiesv2.HostNetworking)
-				if !ok {
-					return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
-				}
-
-				hostID := instance.GetID()
-				hostName := instance.GetName()
-
-				for _, as := range req.Subnets {
-					rs, innerXErr := LoadSubnet(instance.MetadataCore.GetService(), "", as.ID)
-					if innerXErr != nil {
-						return innerXErr
-					}
-
-					innerXErr = rs.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
-						return props.Alter(subnetproperty.HostsV1, func(clonable data.Clonable) fail.Error {
-							subnetHostsV1, ok := clonable.(*propertiesv1.SubnetHosts)
-							if !ok {
-								return fail.InconsistentError("'*propertiesv1.SubnetHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
-							}
-
-							delete(subnetHostsV1.ByID, hostID)
-							delete(subnetHostsV1.ByName, hostName)
-							return nil
-						})
-					})
-					if innerXErr != nil {
-						return innerXErr
-					}
-
-					delete(hsV1.SubnetsByID, as.ID)
-					delete(hsV1.SubnetsByName, as.ID)
-				}
-				return nil
-			})
-		})
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			_ = (*errorPtr).AddConsequence(fail.Wrap(xerr, "cleaning up on %s, failed to remove Host relationships with Subnets", ActionFromError(xerr)))
+// classifyHostSettleFailure returns the error to report when a new Host did not become consistently queryable
+// A Host found at least once is only not settled yet (transient, fail.ErrNotAvailable), whereas a Host never found
+// does not exist (genuine fail.ErrNotFound)
+func classifyHostSettleFailure(hostName string, seen bool, lastXErr fail.Error) fail.Error {
+	switch lastXErr.(type) {
+	case *fail.ErrNotFound:
+		if seen {
+			return fail.NotAvailableError("Host '%s' created by the provider is not consistently queryable yet: %v", hostName, lastXErr)
 		}
+		return fail.NotFoundError("Host '%s' created by the provider cannot be found: %v", hostName, lastXErr)
+	case nil:
+		return fail.NotAvailableError("Host '%s' created by the provider is not consistently queryable yet", hostName)
+	default:
+		return fail.Wrap(lastXErr, "failed to query Host '%s' created by the provider", hostName)
 	}
 }
 
-func (instance *Host) finalizeProvisioning(ctx context.Context, userdataContent *userdata.Content) fail.Error {
-	task, xerr := concurrency.TaskFromContext(ctx)
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return xerr
-	}
-
-	if task.Aborted() {
-		return fail.AbortedError(nil, "aborted")
+// surfaceProviderError wraps 'xerr' with 'msg', bringing the original provider error message (and code if any) at the
+// top of the error message, where intermediate wrappings would otherwise bury it
+// Provider message and code are also kept as annotations, to be transmitted as details of the gRPC status
+func surfaceProviderError(xerr fail.Error, msg ...interface{}) fail.Error {
+	if xerr == nil {
+		return nil
 	}
 
-	// Reset userdata script for Host from Cloud Provider metadata service (if stack is able to do so)
-	xerr = instance.GetService().ClearHostStartupScript(instance.GetID())
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return xerr
+	var providerMsg, providerCode string
+	if anno, ok := xerr.Annotation(abstract.ProviderErrorMessageAnnotation); ok {
+		providerMsg, _ = anno.(string)
 	}
-
-	// Executes userdata.PHASE2_NETWORK_AND_SECURITY script to configure networking and security
-	xerr = instance.runInstallPhase(ctx, userdata.PHASE2_NETWORK_AND_SECURITY, userdataContent)
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return xerr
+	if anno, ok := xerr.Annotation(abstract.ProviderErrorCodeAnnotation); ok {
+		providerCode, _ = anno.(string)
 	}
-
-	// Update Keypair of the Host with the final one
-	xerr = instance.Alter(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
-		ah, ok := clonable.(*abstract.HostCore)
-		if !ok {
-			return fail.InconsistentError("'*abstract.HostCore' expected, '%s' provided", reflect.TypeOf(clonable).String())
+	if providerMsg == "" {
+		// no annotation from the stack, use the deepest cause as the provider message
+		if cause := fail.RootCause(xerr); cause != nil && cause != error(xerr) {
+			providerMsg = cause.Error()
+			_ = xerr.Annotate(abstract.ProviderErrorMessageAnnotation, providerMsg)
 		}
-
-		ah.PrivateKey = userdataContent.FinalPrivateKey
-		return nil
-	})
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return fail.Wrap(xerr, "failed to update Keypair")
-	}
-	xerr = instance.updateCachedInformation()
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return xerr
 	}
 
-	logrus.Infof("finalizing Host provisioning of '%s': rebooting", instance.GetName())
-	// Reboot Host
-	command := "sudo systemctl reboot"
-	_, _, _, _ = instance.UnsafeRun(ctx, command, outputs.COLLECT, 10*time.Second, 30*time.Second)
+	prefix := strprocess.FormatStrings(msg...)
+	switch {
+	case providerMsg != "" && providerCode != "":
+		prefix += fmt.Sprintf(" (provider error %s: %s)", providerCode, providerMsg)
+	case providerMsg != "":
+		prefix += fmt.Sprintf(" (provider error: %s)", providerMsg)
+	}
+	return fail.Wrap(xerr, prefix)
+}
 
-	_, xerr = instance.waitInstallPhase(ctx, userdata.PHASE2_NETWORK_AND_SECURITY, 0)
+// createHostWithRetry instructs the provider to create the Host, retrying with exponential backoff on transient error
+// (as classified by IsTransientHostCreationError); if 'cycleTemplates' is true, the next try uses the next template satisfying 'hostDef'
+// Non-transient errors are returned immediately.
+func (instance *Host) createHostWithRetry(ctx context.Context, hostReq *abstract.HostRequest, hostDef abstract.HostSizingRequirements, cycleTemplates bool) (*abstract.HostFull, *userdata.Content, fail.Error) {
+	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return xerr
+		return nil, nil, xerr
 	}
 
-	// if Host is not a gateway, executes userdata.PHASE4/5 scripts
-	// to fix possible system issues and finalize Host creation.
-	// For a gateway, userdata.PHASE3 to 5 have to be run explicitly (cf. operations/subnet.go)
-	if !userdataContent.IsGateway {
-		// execute userdata.PHASE4_SYSTEM_FIXES script to fix possible misconfiguration in system
-		xerr = instance.runInstallPhase(ctx, userdata.PHASE4_SYSTEM_FIXES, userdataContent)
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			return xerr
-		}
-
-		// Reboot Host
-		logrus.Infof("finalizing Host provisioning of '%s' (not-gateway): rebooting", instance.GetName())
-		command = "sudo systemctl reboot"
-		_, _, _, _ = instance.UnsafeRun(ctx, command, outputs.COLLECT, 10*time.Second, 30*time.Second)
-
-		_, xerr = instance.waitInstallPhase(ctx, userdata.PHASE4_SYSTEM_FIXES, 0)
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			return xerr
-		}
+	var (
+		ahf             *abstract.HostFull
+		userdataContent *userdata.Content
+		lastXErr        fail.Error
+		templates       []*abstract.HostTemplate
+		attempt         uint
+	)
+	svc := instance.GetService()
+	attempts := getHostCreationAttempts()
+	xerr = retry.Action(
+		func() error {
+			if task.Aborted() {
+				lastXErr = fail.AbortedError(nil, "aborted")
+				return retry.StopRetryError(lastXErr)
+			}
 
-		// execute userdata.PHASE5_FINAL script to final install/configure of the Host (no need to reboot)
-		xerr = instance.runInstallPhase(ctx, userdata.PHASE5_FINAL, userdataContent)
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			return xerr
-		}
+			attempt++
+			var innerXErr fail.Error
+			ahf, userdataContent, innerXErr = svc.CreateHost(*hostReq)
+			if innerXErr == nil {
+				return nil
+			}
 
-		_, xerr = instance.waitInstallPhase(ctx, userdata.PHASE5_FINAL, temporal.GetHostTimeout())
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			switch xerr.(type) { //nolint
-			case *fail.ErrTimeout:
-				return fail.Wrap(xerr, "timeout creating a Host")
+			lastXErr = innerXErr
+			if !IsTransientHostCreationError(innerXErr) || attempt >= attempts {
+				return retry.StopRetryError(innerXErr)
 			}
-			if abstract.IsProvisioningError(xerr) {
-				logrus.Errorf("%+v", xerr)
-				return fail.Wrap(xerr, "error provisioning the new Host, please check safescaled logs", instance.GetName())
+
+			logrus.Warnf("transient failure creating Host '%s' (attempt %d/%d), retrying: %v", hostReq.ResourceName, attempt, attempts, innerXErr)
+			if cycleTemplates {
+				if templates == nil {
+					var listXErr fail.Error
+					templates, listXErr = svc.ListTemplatesBySizing(hostDef, false)
+					if listXErr != nil {
+						logrus.Warnf("failed to list alternative templates for Host '%s': %v", hostReq.ResourceName, listXErr)
+						templates = []*abstract.HostTemplate{}
+					}
+				}
+				if len(templates) > 1 {
+					next := templates[attempt%uint(len(templates))]
+					logrus.Debugf("next try to create Host '%s' will use template '%s'", hostReq.ResourceName, next.Name)
+					hostReq.TemplateID = next.ID
+				}
 			}
-			return xerr
+			return innerXErr
+		},
+		retry.PrevailDone(retry.Unsuccessful(), retry.Max(attempts)),
+		retry.Exponential(hostCreationRetryDelay),
+		nil,
+		nil,
+		nil,
+	)
+	if xerr != nil {
+		if lastXErr != nil {
+			return nil, nil, lastXErr
 		}
+		return nil, nil, xerr
 	}
-	return nil
+	return ahf, userdataContent, nil
 }
 
-// WaitSSHReady waits until SSH responds successfully
-func (instance *Host) WaitSSHReady(ctx context.Context, timeout time.Duration) (_ string, xerr fail.Error) {
+// CheckCreation resolves the template, image, Subnets and Security Groups a creation of Host with 'hostReq' would use,
+// without provisioning anything, and reports the problems that would prevent the creation
+// A Subnet dedicated to a single Host is not resolved, as it would be created with the Host
+func (instance *Host) CheckCreation(ctx context.Context, hostReq abstract.HostRequest, hostDef abstract.HostSizingRequirements) (_ *abstract.HostCreationReport, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
 	if instance == nil || instance.IsNull() {
-		return "", fail.InvalidInstanceError()
+		return nil, fail.InvalidInstanceError()
 	}
 	if ctx == nil {
-		return "", fail.InvalidParameterCannotBeNilError("ctx")
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
 	}
 
 	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return "", xerr
+		return nil, xerr
 	}
 
 	if task.Aborted() {
-		return "", fail.AbortedError(nil, "aborted")
+		return nil, fail.AbortedError(nil, "aborted")
 	}
 
-	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host")).Entering()
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(%s)", hostReq.ResourceName).WithStopwatch().Entering()
 	defer tracer.Exiting()
 
 	instance.lock.RLock()
 	defer instance.lock.RUnlock()
 
-	return instance.waitInstallPhase(ctx, userdata.PHASE5_FINAL, timeout)
+	return instance.unsafeCheckCreation(ctx, hostReq, hostDef)
 }
 
-// createSingleHostNetwork creates Single-Host Network and Subnet
-func createSingleHostNetworking(ctx context.Context, svc iaas.Service, singleHostRequest abstract.HostRequest) (_ resources.Subnet, _ func() fail.Error, xerr fail.Error) {
-	// Build network name
-	cfg, xerr := svc.GetConfigurationOptions()
-	if xerr != nil {
-		return nil, nil, xerr
+// checkDefaultSubnetGateway validates that a Host without public IP will be reachable through a gateway of its default
+// Subnet; without gateway, the Host would be created but never reachable by SSH
+func checkDefaultSubnetGateway(hostReq abstract.HostRequest) fail.Error {
+	if hostReq.Single || hostReq.IsGateway || hostReq.PublicIP || len(hostReq.Subnets) == 0 {
+		return nil
 	}
 
-	bucketName := cfg.GetString("MetadataBucketName")
-	if bucketName == "" {
-		return nil, nil, fail.InconsistentError("missing service configuration option 'MetadataBucketName'")
+	as := hostReq.Subnets[0]
+	if len(as.GatewayIDs) == 0 {
+		return fail.InvalidRequestError("cannot create Host '%s' without public IP in Subnet '%s', that has no gateway: request a public IP for the Host or use a Subnet with gateway", hostReq.ResourceName, as.Name)
 	}
+	return nil
+}
 
-	networkName := fmt.Sprintf("sfnet-%s", strings.Trim(bucketName, objectstorage.BucketNamePrefix+"-"))
+// checkRequestedSecurityGroups validates the Security Groups requested for a Host
+// A Host skipping the default Security Groups must be given at least one Security Group, else it would not be reachable
+func checkRequestedSecurityGroups(hostReq abstract.HostRequest) fail.Error {
+	if !hostReq.SkipDefaultSecurityGroups {
+		return nil
+	}
+	for k := range hostReq.SecurityGroupIDs {
+		if k != "" {
+			return nil
+		}
+	}
+	return fail.InvalidRequestError("Host '%s' skips the default Security Groups but no Security Group has been provided", hostReq.ResourceName)
+}
 
-	// Create network if needed
-	networkInstance, xerr := LoadNetwork(svc, networkName)
-	if xerr != nil {
-		switch xerr.(type) {
-		case *fail.ErrNotFound:
-			networkInstance, xerr = NewNetwork(svc)
-			if xerr != nil {
-				return nil, nil, xerr
-			}
+// checkAdditionalInterfaces validates the additional network interfaces requested for a Host
+// As hostproperty.NetworkV2 records one address per Subnet, an additional interface cannot be connected to a Subnet the
+// Host is already connected to
+func checkAdditionalInterfaces(hostReq abstract.HostRequest) fail.Error {
+	connected := make(map[string]bool, len(hostReq.Subnets)+len(hostReq.AdditionalInterfaces))
+	for _, v := range hostReq.Subnets {
+		connected[v.ID] = true
+	}
+	for _, v := range hostReq.AdditionalInterfaces {
+		if v.Subnet == nil {
+			return fail.InvalidRequestError("an additional interface of Host '%s' has no Subnet", hostReq.ResourceName)
+		}
+		if connected[v.Subnet.ID] {
+			return fail.InvalidRequestError("Host '%s' cannot have several interfaces on Subnet '%s'", hostReq.ResourceName, v.Subnet.Name)
+		}
+		connected[v.Subnet.ID] = true
 
-			request := abstract.NetworkRequest{
-				Name:          networkName,
-				CIDR:          abstract.SingleHostNetworkCIDR,
-				KeepOnFailure: true,
+		if v.IPAddress != "" {
+			ip := net.ParseIP(v.IPAddress)
+			if ip == nil {
+				return fail.InvalidRequestError("invalid IP address '%s' requested on Subnet '%s'", v.IPAddress, v.Subnet.Name)
 			}
-			xerr = networkInstance.Create(ctx, request)
-			if xerr != nil {
-				return nil, nil, xerr
+			_, ipNet, err := net.ParseCIDR(v.Subnet.CIDR)
+			if err != nil {
+				return fail.Wrap(err, "failed to parse CIDR of Subnet '%s'", v.Subnet.Name)
+			}
+			if !ipNet.Contains(ip) {
+				return fail.InvalidRequestError("IP address '%s' is not in the CIDR '%s' of Subnet '%s'", v.IPAddress, v.Subnet.CIDR, v.Subnet.Name)
 			}
-		default:
-			return nil, nil, xerr
 		}
 	}
-	defer networkInstance.Released()
+	return nil
+}
 
-	// Check if Subnet exists
-	var (
-		subnetRequest abstract.SubnetRequest
-		cidrIndex     uint
-	)
-	subnetInstance, xerr := LoadSubnet(svc, networkInstance.GetID(), singleHostRequest.ResourceName)
-	if xerr != nil {
-		switch xerr.(type) {
-		case *fail.ErrNotFound:
-			subnetInstance, xerr = NewSubnet(svc)
-			if xerr != nil {
-				return nil, nil, xerr
-			}
+// unsafeCheckCreation is the non goroutine-safe version of CheckCreation, that does the real work
+// Errors of resolution are reported as problems; only errors preventing the check itself are returned
+func (instance *Host) unsafeCheckCreation(ctx context.Context, hostReq abstract.HostRequest, hostDef abstract.HostSizingRequirements) (*abstract.HostCreationReport, fail.Error) {
+	svc := instance.GetService()
+	report := &abstract.HostCreationReport{}
+	addProblem := func(format string, args ...interface{}) {
+		report.Problems = append(report.Problems, fmt.Sprintf(format, args...))
+	}
 
-			var (
-				subnetCIDR string
-			)
+	if hostReq.ResourceName == "" {
+		addProblem("missing Host name")
+	}
+	if hostReq.Tenancy != hosttenancy.Default && !svc.GetCapabilities().DedicatedTenancy {
+		addProblem("tenancy '%s' is not supported by the provider of tenant '%s'", hostReq.Tenancy.String(), svc.GetName())
+	}
+	if hostReq.DedicatedHostID != "" && hostReq.Tenancy != hosttenancy.Host {
+		addProblem("a dedicated host can only be requested with tenancy '%s'", hosttenancy.Host.String())
+	}
+	if len(hostReq.AdditionalInterfaces) > 0 && !svc.GetCapabilities().MultipleInterfaces {
+		addProblem("additional network interfaces are not supported by the provider of tenant '%s'", svc.GetName())
+	}
 
-			subnetCIDR, cidrIndex, xerr = ReserveCIDRForSingleHost(networkInstance)
-			xerr = debug.InjectPlannedFail(xerr)
-			if xerr != nil {
-				return nil, nil, xerr
+	// Check if Host name is already used
+	if hostReq.ResourceName != "" {
+		hostInstance, xerr := LoadHost(svc, hostReq.ResourceName)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrNotFound:
+				_, xerr = svc.InspectHost(abstract.NewHostCore().SetName(hostReq.ResourceName))
+				if xerr != nil {
+					switch xerr.(type) {
+					case *fail.ErrNotFound:
+						// continue
+					default:
+						addProblem("failed to check if Host resource name '%s' is already used: %v", hostReq.ResourceName, xerr)
+					}
+				} else {
+					addProblem("found an existing Host named '%s' (but not managed by SafeScale)", hostReq.ResourceName)
+				}
+			default:
+				addProblem("failed to check if Host '%s' already exists: %v", hostReq.ResourceName, xerr)
 			}
+		} else {
+			hostInstance.Released()
+			addProblem("Host '%s' already exists", hostReq.ResourceName)
+		}
+	}
 
-			var dnsServers []string
-			opts, xerr := svc.GetConfigurationOptions()
-			xerr = debug.InjectPlannedFail(xerr)
-			if xerr != nil {
-				switch xerr.(type) {
-				case *fail.ErrNotFound:
-				default:
-					return nil, nil, xerr
-				}
-			} else {
-				if servers := strings.TrimSpace(opts.GetString("DNSServers")); servers != "" {
-					dnsServers = strings.Split(servers, ",")
-				}
+	// Resolve template
+	switch {
+	case hostReq.TemplateID != "":
+		templates, xerr := svc.ListTemplates(true)
+		if xerr != nil {
+			addProblem("failed to list templates: %v", xerr)
+			break
+		}
+		for _, v := range templates {
+			if v.ID == hostReq.TemplateID {
+				report.TemplateID = v.ID
+				break
 			}
+		}
+		if report.TemplateID == "" {
+			addProblem("failed to find template with ID '%s'", hostReq.TemplateID)
+		}
+	case hostDef.Template != "":
+		tmpl, xerr := svc.FindTemplateByName(hostDef.Template)
+		if xerr != nil {
+			addProblem("failed to find template '%s': %v", hostDef.Template, xerr)
+			break
+		}
+		report.TemplateID = tmpl.ID
+	default:
+		templateID, xerr := instance.findTemplateID(hostDef)
+		if xerr != nil {
+			addProblem("failed to find a template matching sizing: %v", xerr)
+			break
+		}
+		report.TemplateID = templateID
+	}
 
-			subnetRequest.Name = singleHostRequest.ResourceName
-			subnetRequest.NetworkID = networkInstance.GetID()
-			subnetRequest.IPVersion = ipversion.IPv4
-			subnetRequest.CIDR = subnetCIDR
-			subnetRequest.DNSServers = dnsServers
-			subnetRequest.HA = false
+	// Resolve image
+	if hostReq.ImageID != "" {
+		report.ImageID = hostReq.ImageID
+	} else {
+		imageID, xerr := instance.findImageID(&hostDef)
+		if xerr != nil {
+			addProblem("failed to find image '%s': %v", hostDef.Image, xerr)
+		} else {
+			report.ImageID = imageID
+		}
+	}
 
-			xerr = subnetInstance.(*Subnet).CreateSubnetWithoutGateway(ctx, subnetRequest)
-			xerr = debug.InjectPlannedFail(xerr)
+	if hostReq.Single {
+		return report, nil
+	}
+
+	// Resolve Subnets
+	subnets := hostReq.Subnets
+	if len(subnets) == 0 {
+		if hostReq.NetworkID == "" {
+			addProblem("no Subnet nor Network provided to create Host '%s'", hostReq.ResourceName)
+		} else {
+			networkInstance, xerr := LoadNetwork(svc, hostReq.NetworkID)
 			if xerr != nil {
-				return nil, nil, xerr
-			}
+				addProblem("failed to load Network '%s': %v", hostReq.NetworkID, xerr)
+			} else {
+				subnetInstance, xerr := networkInstance.SelectSubnetForHost(ctx, hostReq.SubnetSelection)
+				networkInstance.Released()
+				if xerr != nil {
+					addProblem("failed to select a Subnet in Network '%s': %v", hostReq.NetworkID, xerr)
+				} else {
+					xerr = subnetInstance.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+						as, ok := clonable.(*abstract.Subnet)
+						if !ok {
+							return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
+						}
 
-			defer func() {
-				if xerr != nil && !singleHostRequest.KeepOnFailure {
-					derr := subnetInstance.Delete(ctx)
-					if derr != nil {
-						_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on failure, failed to delete Subnet '%s'", singleHostRequest.ResourceName))
+						subnets = append(subnets, as)
+						return nil
+					})
+					subnetInstance.Released()
+					if xerr != nil {
+						return nil, xerr
 					}
 				}
-			}()
-
-			// Sets the CIDR index in instance metadata
-			xerr = subnetInstance.Alter(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
-				as, ok := clonable.(*abstract.Subnet)
-				if !ok {
-					return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
-				}
-
-				as.SingleHostCIDRIndex = cidrIndex
-				return nil
-			})
-			if xerr != nil {
-				return nil, nil, xerr
 			}
-		default:
-			return nil, nil, xerr
 		}
-	} else {
+	}
+	for _, v := range subnets {
+		subnetInstance, xerr := LoadSubnet(svc, "", v.ID)
+		if xerr != nil {
+			addProblem("failed to load Subnet '%s': %v", v.Name, xerr)
+			continue
+		}
 		subnetInstance.Released()
-		return nil, nil, fail.DuplicateError("there is already a Subnet named '%s'", singleHostRequest.ResourceName)
+		report.SubnetIDs = append(report.SubnetIDs, v.ID)
+	}
+	checkReq := hostReq
+	checkReq.Subnets = subnets
+	if xerr := checkDefaultSubnetGateway(checkReq); xerr != nil {
+		addProblem(xerr.Error())
+	}
+	if xerr := checkAdditionalInterfaces(checkReq); xerr != nil {
+		addProblem(xerr.Error())
+	}
+	if xerr := checkRequestedSecurityGroups(checkReq); xerr != nil {
+		addProblem(xerr.Error())
 	}
 
-	undoFunc := func() fail.Error {
-		var errors []error
-		if !singleHostRequest.KeepOnFailure {
-			derr := subnetInstance.Delete(ctx)
-			if derr != nil {
-				errors = append(errors, fail.Wrap(derr, "cleaning up on failure, failed to delete Subnet '%s'", singleHostRequest.ResourceName))
-			}
-			derr = FreeCIDRForSingleHost(networkInstance, cidrIndex)
-			if derr != nil {
-				errors = append(errors, fail.Wrap(derr, "cleaning up on failure, failed to free CIDR slot in Network '%s'", networkInstance.GetName()))
+	// Resolve Security Groups
+	sgIDs := hostReq.SecurityGroupIDs
+	if len(sgIDs) == 0 && !hostReq.SkipDefaultSecurityGroups {
+		sgIDs = make(map[string]struct{}, len(subnets)+1)
+		for _, v := range subnets {
+			if v.InternalSecurityGroupID != "" {
+				sgIDs[v.InternalSecurityGroupID] = struct{}{}
 			}
 		}
-		if len(errors) > 0 {
-			return fail.NewErrorList(errors)
+
+		opts, xerr := svc.GetConfigurationOptions()
+		if xerr != nil {
+			return nil, xerr
+		}
+
+		anon, ok := opts.Get("UseNATService")
+		useNATService := ok && anon.(bool)
+		if (hostReq.PublicIP || useNATService) && len(subnets) > 0 && subnets[0].PublicIPSecurityGroupID != "" {
+			sgIDs[subnets[0].PublicIPSecurityGroupID] = struct{}{}
 		}
-		return nil
 	}
+	for k := range sgIDs {
+		sgInstance, xerr := LoadSecurityGroup(svc, k)
+		if xerr != nil {
+			addProblem("failed to load Security Group '%s': %v", k, xerr)
+			continue
+		}
+		sgInstance.Released()
+		report.SecurityGroupIDs = append(report.SecurityGroupIDs, k)
+	}
+	sort.Strings(report.SecurityGroupIDs)
 
-	return subnetInstance, undoFunc, nil
+	return report, nil
 }
 
-// Delete deletes a Host with its metadata and updates subnet links
-func (instance *Host) Delete(ctx context.Context) (xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
+func (instance *Host) findTemplateID(hostDef abstract.HostSizingRequirements) (string, fail.Error) {
+	svc := instance.GetService()
+	if hostDef.Template != "" {
+		if tpl, xerr := svc.FindTemplateByName(hostDef.Template); xerr == nil {
+			return tpl.ID, nil
+		}
+		logrus.Warning(fail.NotFoundError("failed to find template '%s', trying to guess from sizing...", hostDef.Template))
+	}
 
-	if instance == nil || instance.IsNull() {
-		return fail.InvalidInstanceError()
+	template, xerr := svc.FindTemplateBySizing(hostDef)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return "", xerr
 	}
-	if ctx == nil {
-		return fail.InvalidParameterCannotBeNilError("ctx")
+
+	return template.ID, nil
+}
+
+func (instance *Host) findImageID(hostDef *abstract.HostSizingRequirements) (string, fail.Error) {
+	svc := instance.GetService()
+	if hostDef.Image == "" {
+		cfg, xerr := svc.GetConfigurationOptions()
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return "", xerr
+		}
+		hostDef.Image = cfg.GetString("DefaultImage")
 	}
 
-	task, xerr := concurrency.TaskFromContext(ctx)
+	var img *abstract.Image
+	xerr := retry.WhileUnsuccessfulDelay1Second(
+		func() error {
+			var innerXErr fail.Error
+			img, innerXErr = svc.SearchImage(hostDef.Image)
+			return innerXErr
+		},
+		30*time.Second,
+	)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return xerr
+		return "", xerr
 	}
+	return img.ID, nil
+}
 
-	if task.Aborted() {
-		return fail.AbortedError(nil, "aborted")
+// runInstallPhase uploads then starts script corresponding to phase 'phase'
+// The script is removed from the Host after successful execution; on failure, it is kept for debugging only if the Host
+// has been requested with KeepOnFailure (the script may contain sensitive material, like private key)
+func (instance *Host) runInstallPhase(ctx context.Context, phase userdata.Phase, userdataContent *userdata.Content) fail.Error {
+	content, xerr := userdataContent.Generate(phase)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
 	}
 
-	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host")).Entering()
-	defer tracer.Exiting()
-
-	instance.lock.Lock()
-	defer instance.lock.Unlock()
+	folder := instance.getTempFolder()
+	if folder != utils.TempFolder {
+		// custom folder is not created by userdata, do it now with the same permissions than utils.TempFolder
+		command, xerr := instance.buildPrivilegedCommand(fmt.Sprintf("-c 'mkdir -p %s && chmod 1777 %s'", folder, folder))
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return fail.Wrap(xerr, "cannot create folder '%s' on Host '%s'", folder, instance.GetName())
+		}
 
-	xerr = instance.Inspect(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
-		// Do not remove a Host that is a gateway
-		return props.Inspect(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
-			hostNetworkV2, ok := clonable.(*propertiesv2.HostNetworking)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
+		retcode, _, stderr, xerr := instance.UnsafeRun(ctx, command, outputs.COLLECT, temporal.GetConnectionTimeout(), temporal.GetExecutionTimeout())
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return fail.Wrap(xerr, "failed to create folder '%s' on Host '%s'", folder, instance.GetName())
+		}
+		if retcode != 0 {
+			return fail.NewError("failed to create folder '%s' on Host '%s': %s", folder, instance.GetName(), stderr)
+		}
+	}
 
-			if hostNetworkV2.IsGateway {
-				return fail.NotAvailableError("cannot delete Host, it's a gateway that can only be deleted through its Subnet")
-			}
-			return nil
-		})
-	})
+	// Only root can read the script
+	file := fmt.Sprintf("%s/user_data.%s.sh", folder, phase)
+	xerr = instance.unsafePushStringToFileWithOwnership(ctx, string(content), file, "root:root", "0600")
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return xerr
 	}
 
-	return instance.RelaxedDeleteHost(ctx)
-}
-
-// RelaxedDeleteHost is the method that really deletes a host, being a gateway or not
-func (instance *Host) RelaxedDeleteHost(ctx context.Context) (xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
+	command, xerr := instance.buildPrivilegedCommand(file)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return fail.Wrap(xerr, "cannot execute install phase '%s' on Host '%s'", phase, instance.GetName())
+	}
+	removal, xerr := instance.buildPrivilegedCommand(fmt.Sprintf("-c 'rm -f %s'", file))
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return fail.Wrap(xerr, "cannot execute install phase '%s' on Host '%s'", phase, instance.GetName())
+	}
+	// The removal is done in the same session than the execution, because the script may change the SSH credentials
+	if instance.keepScriptsOnFailure {
+		command = fmt.Sprintf("%s; rc=$?; [ $rc -eq 0 ] && %s; exit $rc", command, removal)
+	} else {
+		command = fmt.Sprintf("%s; rc=$?; %s; exit $rc", command, removal)
+	}
 
-	task, xerr := concurrency.TaskFromContext(ctx)
+	// Executes the script on the remote Host
+	result, xerr := instance.unsafeExecute(ctx, command, outputs.COLLECT, 0, 0)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return xerr
+		return fail.Wrap(xerr, "failed to apply configuration phase '%s'", phase)
+	}
+	logrus.Debugf("install phase '%s' on Host '%s' ended with code %d in %s", phase, instance.GetName(), result.Retcode, temporal.FormatDuration(result.Duration))
+	if result.Retcode != 0 {
+		if result.Retcode == 255 {
+			return fail.NewError("failed to execute install phase '%s' on Host '%s': SSH connection failed", phase, instance.GetName())
+		}
+		if instance.keepScriptsOnFailure {
+			logrus.Warnf("keeping script '%s' of failed install phase '%s' on Host '%s' for debugging", file, phase, instance.GetName())
+		}
+		return fail.NewError("failed to execute install phase '%s' on Host '%s': %s", phase, instance.GetName(), result.Stderr)
 	}
+	return nil
+}
 
-	if task.Aborted() {
-		return fail.AbortedError(nil, "aborted")
+// persistUserdata records in metadata a copy of the userdata of the Host without its secrets (cf. RunPhase)
+func (instance *Host) persistUserdata(userdataContent *userdata.Content) fail.Error {
+	if userdataContent == nil {
+		return fail.InvalidParameterCannotBeNilError("userdataContent")
 	}
 
-	svc := instance.GetService()
-	var shares map[string]*propertiesv1.HostShare
-	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		// Do not remove a Host having shares that are currently remotely mounted
-		innerXErr := props.Inspect(hostproperty.SharesV1, func(clonable data.Clonable) fail.Error {
-			sharesV1, ok := clonable.(*propertiesv1.HostShares)
+	jsoned, err := json.Marshal(userdataContent.Sanitized())
+	if err != nil {
+		return fail.ConvertError(err)
+	}
+
+	return instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(hostproperty.UserdataV1, func(clonable data.Clonable) fail.Error {
+			hostUserdataV1, ok := clonable.(*propertiesv1.HostUserdata)
 			if !ok {
-				return fail.InconsistentError("'*propertiesv1.HostShares' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				return fail.InconsistentError("'*propertiesv1.HostUserdata' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			shares = sharesV1.ByID
-			shareCount := len(shares)
-			for _, hostShare := range shares {
-				count := len(hostShare.ClientsByID)
-				if count > 0 {
-					// clients found, checks if these clients already exists...
-					for _, hostID := range hostShare.ClientsByID {
-						instance, inErr := LoadHost(svc, hostID)
-						if inErr == nil {
-							instance.Released()
-							return fail.NotAvailableError("Host '%s' exports %d share%s and at least one share is mounted", instance.GetName(), shareCount, strprocess.Plural(uint(shareCount)))
-						}
+			hostUserdataV1.Content = string(jsoned)
+			return nil
+		})
+	})
+}
+
+// getTempFolder returns the folder where scripts are uploaded on the Host
+func (instance *Host) getTempFolder() string {
+	if instance.tempFolder != "" {
+		return instance.tempFolder
+	}
+	return utils.TempFolder
+}
+
+// getShell returns the shell to use to run scripts on the Host
+func (instance *Host) getShell() string {
+	if instance.defaultShell != "" {
+		return instance.defaultShell
+	}
+	return "bash"
+}
+
+// buildPrivilegedCommand returns the command to execute 'script' with administrative privileges on the Host, using the
+// privilege escalation and the shell configured for the Host
+// returns:
+// - *fail.ErrNotAvailable if the Host does not allow privilege escalation
+func (instance *Host) buildPrivilegedCommand(script string) (string, fail.Error) {
+	switch instance.privilegeEscalation {
+	case privilegeescalation.Sudo:
+		return fmt.Sprintf("sudo %s %s", instance.getShell(), script), nil
+	case privilegeescalation.Doas:
+		return fmt.Sprintf("doas %s %s", instance.getShell(), script), nil
+	case privilegeescalation.None:
+		return "", fail.NotAvailableError("privileges are required, but Host '%s' has been configured without privilege escalation", instance.GetName())
+	default:
+		return "", fail.InconsistentError("unknown privilege escalation '%d' for Host '%s'", instance.privilegeEscalation, instance.GetName())
+	}
+}
+
+// waitInstallPhase waits for the install phase to be done on the Host
+// Connection to SSH server is bounded by temporal.GetConnectSSHTimeout(), the whole wait by 'timeout' (if 0, uses temporal.GetHostTimeout());
+// environment variable SSH_TIMEOUT (in minutes) overrides the latter
+func (instance *Host) waitInstallPhase(ctx context.Context, phase userdata.Phase, timeout time.Duration) (string, fail.Error) {
+	readyTimeout := timeout
+	if readyTimeout <= 0 {
+		readyTimeout = temporal.GetHostTimeout()
+	}
+	if readyTimeoutCandidate := os.Getenv("SSH_TIMEOUT"); readyTimeoutCandidate != "" {
+		if num, err := strconv.Atoi(readyTimeoutCandidate); err == nil {
+			logrus.Debugf("Using custom timeout of %d minutes", num)
+			readyTimeout = time.Duration(num) * time.Minute
+		}
+	}
+	connectTimeout := temporal.GetConnectSSHTimeout()
+
+	status, xerr := instance.sshProfile.WaitServerReady(ctx, string(phase), connectTimeout, readyTimeout)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrTimeout:
+			return status, fail.Wrap(xerr, "failed to wait for SSH on Host '%s' to be ready (phase %s): %s", instance.GetName(), phase, status)
+		default:
+		}
+		if abstract.IsProvisioningError(xerr) {
+			logrus.Errorf("%+v", xerr)
+			return status, fail.Wrap(xerr, "error provisioning Host '%s', please check safescaled logs", instance.GetName())
+		}
+	}
+	return status, xerr
+}
+
+// syncClockCommand forces a synchronization of the clock of the Host with NTP, whatever the NTP client installed
+const syncClockCommand = "sudo chronyc -a makestep || sudo ntpdate -u pool.ntp.org || (sudo timedatectl set-ntp true && sudo systemctl restart systemd-timesyncd)"
+
+// checkClockSkew measures the difference between the clock of the Host and the one of the daemon, records it in
+// property DescriptionV1 and warns if it exceeds the threshold; if sync is true, forces a NTP synchronization in this case
+func (instance *Host) checkClockSkew(ctx context.Context, sync bool) fail.Error {
+	hostName := instance.GetName()
+	skew, xerr := instance.measureClockSkew(ctx)
+	if xerr != nil {
+		// Not being able to measure the skew does not prevent the Host to work
+		logrus.Warnf("failed to measure clock skew of Host '%s': %v", hostName, xerr)
+		return nil
+	}
+
+	threshold := temporal.GetClockSkewThreshold()
+	if skew > threshold || skew < -threshold {
+		logrus.Warnf("clock of Host '%s' is skewed by %s compared to the daemon (threshold: %s)", hostName, skew, threshold)
+		if sync {
+			retcode, _, stderr, xerr := instance.UnsafeRun(ctx, syncClockCommand, outputs.COLLECT, temporal.GetConnectSSHTimeout(), temporal.GetExecutionTimeout())
+			if xerr != nil {
+				return fail.Wrap(xerr, "failed to synchronize clock of Host '%s'", hostName)
+			}
+			if retcode != 0 {
+				return fail.ExecutionError(nil, "failed to synchronize clock of Host '%s' (retcode=%d): %s", hostName, retcode, stderr)
+			}
+
+			if newSkew, xerr := instance.measureClockSkew(ctx); xerr == nil {
+				logrus.Infof("clock of Host '%s' synchronized, skew is now %s", hostName, newSkew)
+			}
+		}
+	} else {
+		logrus.Debugf("clock of Host '%s' is skewed by %s compared to the daemon", hostName, skew)
+	}
+
+	return instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(hostproperty.DescriptionV1, func(clonable data.Clonable) fail.Error {
+			hostDescriptionV1, ok := clonable.(*propertiesv1.HostDescription)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostDescription' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			// Records the skew measured before any synchronization, it's the one that explains failures
+			hostDescriptionV1.ClockSkew = skew
+			return nil
+		})
+	})
+}
+
+// measureClockSkew returns the difference between the clock of the Host and the one of the daemon
+// Note: the clock of the daemon is taken at the middle of the SSH exchange, so the precision is half its duration
+func (instance *Host) measureClockSkew(ctx context.Context) (time.Duration, fail.Error) {
+	before := time.Now()
+	retcode, stdout, stderr, xerr := instance.UnsafeRun(ctx, "date -u +%s%N", outputs.COLLECT, temporal.GetConnectSSHTimeout(), 30*time.Second)
+	after := time.Now()
+	if xerr != nil {
+		return 0, xerr
+	}
+	if retcode != 0 {
+		return 0, fail.ExecutionError(nil, "failed to read clock (retcode=%d): %s", retcode, stderr)
+	}
+
+	nanos, err := strconv.ParseInt(strings.TrimSpace(stdout), 10, 64)
+	if err != nil {
+		return 0, fail.SyntaxError("failed to parse clock '%s': %v", strings.TrimSpace(stdout), err)
+	}
+
+	reference := before.Add(after.Sub(before) / 2)
+	return time.Unix(0, nanos).Sub(reference), nil
+}
+
+// rebootAndWaitInstallPhase reboots the Host, confirms the reboot started (the Host becomes unreachable or has a new
+// boot id), then waits for the Host to come back with the install phase done
+// Returns *fail.ErrTimeout with distinct messages when the reboot never started and when the Host did not come back
+func (instance *Host) rebootAndWaitInstallPhase(ctx context.Context, phase userdata.Phase) fail.Error {
+	hostName := instance.GetName()
+	bootID := instance.readBootID(ctx)
+
+	command := "sudo systemctl reboot"
+	_, _, _, _ = instance.UnsafeRun(ctx, command, outputs.COLLECT, 10*time.Second, 30*time.Second)
+
+	startTimeout := temporal.GetHostRebootStartTimeout(ctx)
+	xerr := retry.WhileUnsuccessful(
+		func() error {
+			retcode, stdout, _, innerXErr := instance.UnsafeRun(ctx, readBootIDCommand, outputs.COLLECT, 10*time.Second, 10*time.Second)
+			if innerXErr != nil {
+				// Host unreachable, the reboot has started
+				return nil
+			}
+			if retcode == 0 && bootID != "" && strings.TrimSpace(stdout) != bootID {
+				// Host already came back from reboot
+				return nil
+			}
+			return fail.NewError("Host '%s' still reachable", hostName)
+		},
+		temporal.GetMinDelay(),
+		startTimeout,
+	)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *retry.ErrTimeout, *fail.ErrTimeout:
+			return fail.TimeoutError(xerr, startTimeout, fmt.Sprintf("reboot of Host '%s' never started", hostName))
+		default:
+			return xerr
+		}
+	}
+
+	returnTimeout := temporal.GetHostRebootReturnTimeout(ctx)
+	_, xerr = instance.waitInstallPhase(ctx, phase, returnTimeout)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrTimeout:
+			return fail.TimeoutError(xerr, returnTimeout, fmt.Sprintf("Host '%s' did not come back after reboot", hostName))
+		default:
+			return xerr
+		}
+	}
+	return nil
+}
+
+// readBootIDCommand is the command returning the identifier of the current boot of a Linux Host
+const readBootIDCommand = "cat /proc/sys/kernel/random/boot_id"
+
+// readBootID returns the identifier of the current boot of the Host, empty string if it cannot be read
+func (instance *Host) readBootID(ctx context.Context) string {
+	retcode, stdout, _, xerr := instance.UnsafeRun(ctx, readBootIDCommand, outputs.COLLECT, 10*time.Second, 10*time.Second)
+	if xerr != nil || retcode != 0 {
+		return ""
+	}
+	return strings.TrimSpace(stdout)
+}
+
+// updateSubnets updates subnets on which host is attached and host property HostNetworkV2
+func (instance *Host) updateSubnets(task concurrency.Task, req abstract.HostRequest) fail.Error {
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	// If Host is a gateway or is single, do not add it as Host attached to the Subnet, it's considered as part of the subnet
+	if !req.IsGateway && !req.Single {
+		return instance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+			return props.Alter(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+				hnV2, ok := clonable.(*propertiesv2.HostNetworking)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				hostID := instance.GetID()
+				hostName := instance.GetName()
+
+				for _, as := range hostRequestSubnets(req) {
+					rs, innerXErr := LoadSubnet(instance.MetadataCore.GetService(), "", as.ID)
+					if innerXErr != nil {
+						return innerXErr
+					}
+
+					innerXErr = rs.Alter(func(clonable data.Clonable, properties *serialize.JSONProperties) fail.Error {
+						return properties.Alter(subnetproperty.HostsV1, func(clonable data.Clonable) fail.Error {
+							subnetHostsV1, ok := clonable.(*propertiesv1.SubnetHosts)
+							if !ok {
+								return fail.InconsistentError("'*propertiesv1.SubnetHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+							}
+
+							subnetHostsV1.ByName[hostName] = hostID
+							subnetHostsV1.ByID[hostID] = hostName
+							return nil
+						})
+					})
+					if innerXErr != nil {
+						return innerXErr
+					}
+
+					hnV2.SubnetsByID[as.ID] = as.Name
+					hnV2.SubnetsByName[as.Name] = as.ID
+				}
+				return nil
+			})
+		})
+	}
+	return nil
+}
+
+// hostRequestSubnets returns the Subnets the Host is connected to, including the ones of its additional interfaces
+func hostRequestSubnets(req abstract.HostRequest) []*abstract.Subnet {
+	if len(req.AdditionalInterfaces) == 0 {
+		return req.Subnets
+	}
+
+	out := make([]*abstract.Subnet, 0, len(req.Subnets)+len(req.AdditionalInterfaces))
+	out = append(out, req.Subnets...)
+	for _, v := range req.AdditionalInterfaces {
+		out = append(out, v.Subnet)
+	}
+	return out
+}
+
+// undoUpdateSubnets removes what updateSubnets have done
+func (instance *Host) undoUpdateSubnets(req abstract.HostRequest, errorPtr *fail.Error) {
+	if errorPtr != nil && *errorPtr != nil && !req.IsGateway && !req.Single && !req.KeepOnFailure {
+		// // Without this,the undo will not be able to complete in case it's called on an abort...
+		// defer task.DisarmAbortSignal()()
+
+		xerr := instance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+			return props.Alter(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+				hsV1, ok := clonable.(*propertiesv2.HostNetworking)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				hostID := instance.GetID()
+				hostName := instance.GetName()
+
+				for _, as := range hostRequestSubnets(req) {
+					rs, innerXErr := LoadSubnet(instance.MetadataCore.GetService(), "", as.ID)
+					if innerXErr != nil {
+						return innerXErr
+					}
+
+					innerXErr = rs.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+						return props.Alter(subnetproperty.HostsV1, func(clonable data.Clonable) fail.Error {
+							subnetHostsV1, ok := clonable.(*propertiesv1.SubnetHosts)
+							if !ok {
+								return fail.InconsistentError("'*propertiesv1.SubnetHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+							}
+
+							delete(subnetHostsV1.ByID, hostID)
+							delete(subnetHostsV1.ByName, hostName)
+							return nil
+						})
+					})
+					if innerXErr != nil {
+						return innerXErr
 					}
+
+					delete(hsV1.SubnetsByID, as.ID)
+					delete(hsV1.SubnetsByName, as.ID)
 				}
+				return nil
+			})
+		})
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			_ = (*errorPtr).AddConsequence(fail.Wrap(xerr, "cleaning up on %s, failed to remove Host relationships with Subnets", ActionFromError(xerr)))
+		}
+	}
+}
+
+func (instance *Host) finalizeProvisioning(ctx context.Context, hostReq abstract.HostRequest, userdataContent *userdata.Content) (xerr fail.Error) {
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	// measures the duration and the outcome of each phase
+	phases := metrics.NewSequence(metrics.KindProvisioningPhase, instance.GetService().GetProviderName())
+	defer phases.End(&xerr)
+
+	// PHASE1 done, the clock of the Host is now checked; a large skew breaks TLS and SSH in the following phases
+	phases.Begin("clock_check")
+	xerr = instance.checkClockSkew(ctx, hostReq.SyncClockOnSkew)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// Reset userdata script for Host from Cloud Provider metadata service (if stack is able to do so)
+	xerr = instance.GetService().ClearHostStartupScript(instance.GetID())
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// Executes userdata.PHASE2_NETWORK_AND_SECURITY script to configure networking and security
+	phases.Begin(string(userdata.PHASE2_NETWORK_AND_SECURITY))
+	xerr = instance.runInstallPhase(ctx, userdata.PHASE2_NETWORK_AND_SECURITY, userdataContent)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// Update Keypair of the Host with the final one
+	xerr = instance.Alter(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+		ah, ok := clonable.(*abstract.HostCore)
+		if !ok {
+			return fail.InconsistentError("'*abstract.HostCore' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		ah.PrivateKey = userdataContent.FinalPrivateKey
+		return nil
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return fail.Wrap(xerr, "failed to update Keypair")
+	}
+	xerr = instance.updateCachedInformation()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	logrus.Infof("finalizing Host provisioning of '%s': rebooting", instance.GetName())
+	xerr = instance.rebootAndWaitInstallPhase(ctx, userdata.PHASE2_NETWORK_AND_SECURITY)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// if Host is not a gateway, executes userdata.PHASE4/5 scripts
+	// to fix possible system issues and finalize Host creation.
+	// For a gateway, userdata.PHASE3 to 5 have to be run explicitly (cf. operations/subnet.go)
+	if !userdataContent.IsGateway {
+		// execute userdata.PHASE4_SYSTEM_FIXES script to fix possible misconfiguration in system
+		phases.Begin(string(userdata.PHASE4_SYSTEM_FIXES))
+		xerr = instance.runInstallPhase(ctx, userdata.PHASE4_SYSTEM_FIXES, userdataContent)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+
+		// Reboot Host
+		logrus.Infof("finalizing Host provisioning of '%s' (not-gateway): rebooting", instance.GetName())
+		xerr = instance.rebootAndWaitInstallPhase(ctx, userdata.PHASE4_SYSTEM_FIXES)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+
+		// execute userdata.PHASE5_FINAL script to final install/configure of the Host (no need to reboot)
+		phases.Begin(string(userdata.PHASE5_FINAL))
+		xerr = instance.runInstallPhase(ctx, userdata.PHASE5_FINAL, userdataContent)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+
+		_, xerr = instance.waitInstallPhase(ctx, userdata.PHASE5_FINAL, temporal.GetHostTimeout())
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) { //nolint
+			case *fail.ErrTimeout:
+				return fail.Wrap(xerr, "timeout creating a Host")
+			}
+			if abstract.IsProvisioningError(xerr) {
+				logrus.Errorf("%+v", xerr)
+				return fail.Wrap(xerr, "error provisioning the new Host, please check safescaled logs", instance.GetName())
+			}
+			return xerr
+		}
+	}
+	return nil
+}
+
+// WaitSSHReady waits until SSH responds successfully
+func (instance *Host) WaitSSHReady(ctx context.Context, timeout time.Duration) (_ string, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return "", fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return "", fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return "", xerr
+	}
+
+	if task.Aborted() {
+		return "", fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host")).Entering()
+	defer tracer.Exiting()
+
+	instance.lock.RLock()
+	defer instance.lock.RUnlock()
+
+	return instance.waitInstallPhase(ctx, userdata.PHASE5_FINAL, timeout)
+}
+
+// createSingleHostNetwork creates Single-Host Network and Subnet
+func createSingleHostNetworking(ctx context.Context, svc iaas.Service, singleHostRequest abstract.HostRequest) (_ resources.Subnet, _ func() fail.Error, xerr fail.Error) {
+	// Build network name from the metadata bucket used by svc, which may not be the one of the tenant
+	bucketName := svc.GetMetadataBucket().Name
+	if bucketName == "" {
+		return nil, nil, fail.InconsistentError("missing metadata bucket name")
+	}
+
+	networkName := fmt.Sprintf("sfnet-%s", strings.Trim(bucketName, objectstorage.BucketNamePrefix+"-"))
+
+	// Create network if needed
+	networkInstance, xerr := LoadNetwork(svc, networkName)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotFound:
+			networkInstance, xerr = NewNetwork(svc)
+			if xerr != nil {
+				return nil, nil, xerr
+			}
+
+			request := abstract.NetworkRequest{
+				Name:          networkName,
+				CIDR:          abstract.SingleHostNetworkCIDR,
+				KeepOnFailure: true,
+			}
+			xerr = networkInstance.Create(ctx, request)
+			if xerr != nil {
+				return nil, nil, xerr
+			}
+		default:
+			return nil, nil, xerr
+		}
+	}
+	defer networkInstance.Released()
+
+	// Check if Subnet exists
+	var (
+		subnetRequest abstract.SubnetRequest
+		cidrIndex     uint
+	)
+	subnetInstance, xerr := LoadSubnet(svc, networkInstance.GetID(), singleHostRequest.ResourceName)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotFound:
+			subnetInstance, xerr = NewSubnet(svc)
+			if xerr != nil {
+				return nil, nil, xerr
+			}
+
+			var (
+				subnetCIDR string
+			)
+
+			subnetCIDR, cidrIndex, xerr = ReserveCIDRForSingleHost(networkInstance)
+			xerr = debug.InjectPlannedFail(xerr)
+			if xerr != nil {
+				return nil, nil, xerr
+			}
+
+			// Once recorded in Subnet metadata, the CIDR slot is freed by the deletion of the Subnet
+			recorded := false
+			defer func() {
+				if xerr != nil && !recorded && !singleHostRequest.KeepOnFailure {
+					derr := FreeCIDRForSingleHost(networkInstance, cidrIndex)
+					if derr != nil {
+						_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on failure, failed to free CIDR slot '%d' in Network '%s'", cidrIndex, networkInstance.GetName()))
+					}
+				}
+			}()
+
+			var dnsServers []string
+			opts, xerr := svc.GetConfigurationOptions()
+			xerr = debug.InjectPlannedFail(xerr)
+			if xerr != nil {
+				switch xerr.(type) {
+				case *fail.ErrNotFound:
+				default:
+					return nil, nil, xerr
+				}
+			} else {
+				if servers := strings.TrimSpace(opts.GetString("DNSServers")); servers != "" {
+					dnsServers = strings.Split(servers, ",")
+				}
+			}
+
+			subnetRequest.Name = singleHostRequest.ResourceName
+			subnetRequest.NetworkID = networkInstance.GetID()
+			subnetRequest.IPVersion = ipversion.IPv4
+			subnetRequest.CIDR = subnetCIDR
+			subnetRequest.DNSServers = dnsServers
+			subnetRequest.HA = false
+
+			xerr = subnetInstance.(*Subnet).CreateSubnetWithoutGateway(ctx, subnetRequest)
+			xerr = debug.InjectPlannedFail(xerr)
+			if xerr != nil {
+				return nil, nil, xerr
+			}
+
+			defer func() {
+				if xerr != nil && !singleHostRequest.KeepOnFailure {
+					derr := subnetInstance.Delete(ctx)
+					if derr != nil {
+						_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on failure, failed to delete Subnet '%s'", singleHostRequest.ResourceName))
+					}
+				}
+			}()
+
+			// Sets the CIDR index in instance metadata
+			xerr = subnetInstance.Alter(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+				as, ok := clonable.(*abstract.Subnet)
+				if !ok {
+					return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				as.SingleHostCIDRIndex = cidrIndex
+				return nil
+			})
+			if xerr != nil {
+				return nil, nil, xerr
+			}
+			recorded = true
+
+			xerr = CommitCIDRForSingleHost(networkInstance, cidrIndex)
+			xerr = debug.InjectPlannedFail(xerr)
+			if xerr != nil {
+				return nil, nil, xerr
+			}
+		default:
+			return nil, nil, xerr
+		}
+	} else {
+		subnetInstance.Released()
+		return nil, nil, fail.DuplicateError("there is already a Subnet named '%s'", singleHostRequest.ResourceName)
+	}
+
+	undoFunc := func() fail.Error {
+		var errors []error
+		if !singleHostRequest.KeepOnFailure {
+			derr := subnetInstance.Delete(ctx)
+			if derr != nil {
+				errors = append(errors, fail.Wrap(derr, "cleaning up on failure, failed to delete Subnet '%s'", singleHostRequest.ResourceName))
+			}
+			derr = FreeCIDRForSingleHost(networkInstance, cidrIndex)
+			if derr != nil {
+				errors = append(errors, fail.Wrap(derr, "cleaning up on failure, failed to free CIDR slot in Network '%s'", networkInstance.GetName()))
+			}
+		}
+		if len(errors) > 0 {
+			return fail.NewErrorList(errors)
+		}
+		return nil
+	}
+
+	return subnetInstance, undoFunc, nil
+}
+
+// Delete deletes a Host with its metadata and updates subnet links
+// Option "CleanupFeatures" (bool, default false) removes the Features installed on the Host before deleting it; the
+// failures to remove Features are logged and do not prevent the deletion, unless option "StrictFeatureCleanup" (bool)
+// is set to true
+// Returns *fail.ErrResourceInUse listing the blockers if the Host is a gateway, has Volumes attached or exports Shares
+// mounted by other Hosts, and *fail.ErrProviderFailure if the provider refused to delete the Host
+// A Host already gone on provider side is considered as successfully deleted
+func (instance *Host) Delete(ctx context.Context, options ...data.ImmutableKeyValue) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	var cleanupFeatures, strictFeatureCleanup bool
+	for _, v := range options {
+		switch v.Key() {
+		case "CleanupFeatures":
+			cleanupFeatures = v.Value().(bool)
+		case "StrictFeatureCleanup":
+			strictFeatureCleanup = v.Value().(bool)
+		}
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(cleanupFeatures=%v)", cleanupFeatures).Entering()
+	defer tracer.Exiting()
+	defer metrics.Measure(metrics.KindOperation, "host.delete", instance.GetService().GetProviderName())(&xerr)
+
+	// make sure no other operation on the same Host runs in parallel in the daemon
+	unlockHost, xerr := lockHost(ctx, instance)
+	if xerr != nil {
+		return xerr
+	}
+	defer unlockHost()
+
+	xerr = instance.Inspect(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+		// Do not remove a Host that is a gateway
+		return props.Inspect(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+			hostNetworkV2, ok := clonable.(*propertiesv2.HostNetworking)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			if hostNetworkV2.IsGateway {
+				blockers := []string{fmt.Sprintf("Subnet '%s'", hostNetworkV2.SubnetsByID[hostNetworkV2.DefaultSubnetID])}
+				return fail.ResourceInUseError(blockers, "cannot delete Host, it's a gateway that can only be deleted through its Subnet")
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// Features are removed before locking the instance, the removal needing to read the Host
+	if cleanupFeatures {
+		xerr = instance.removeInstalledFeatures(ctx)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			if strictFeatureCleanup {
+				return fail.Wrap(xerr, "failed to remove the Features installed on Host '%s'", instance.GetName())
+			}
+			logrus.Warnf("failed to remove the Features installed on Host '%s', deleting it anyway: %v", instance.GetName(), xerr)
+		}
+	}
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	return instance.RelaxedDeleteHost(ctx)
+}
+
+// removeInstalledFeatures removes the Features installed on the Host, the Features required by others being removed after
+// them; every Feature removal is attempted, the failures being returned as a list of errors
+func (instance *Host) removeInstalledFeatures(ctx context.Context) fail.Error {
+	var installed map[string]map[string]struct{} // Features installed, with the Features requiring them
+	xerr := instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(hostproperty.FeaturesV1, func(clonable data.Clonable) fail.Error {
+			featuresV1, ok := clonable.(*propertiesv1.HostFeatures)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostFeatures' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			installed = make(map[string]map[string]struct{}, len(featuresV1.Installed))
+			for k, v := range featuresV1.Installed {
+				installed[k] = make(map[string]struct{}, len(v.RequiredBy))
+				for r := range v.RequiredBy {
+					installed[k][r] = struct{}{}
+				}
+			}
+			return nil
+		})
+	})
+	if xerr != nil {
+		return xerr
+	}
+
+	var errors []error
+	for len(installed) > 0 {
+		// Selects the Features no remaining Feature requires; if there is none (dependency cycle), removes all the remaining ones
+		var selected []string
+		for name, requiredBy := range installed {
+			required := false
+			for k := range requiredBy {
+				if _, ok := installed[k]; ok {
+					required = true
+					break
+				}
+			}
+			if !required {
+				selected = append(selected, name)
+			}
+		}
+		if len(selected) == 0 {
+			for name := range installed {
+				selected = append(selected, name)
+			}
+		}
+		sort.Strings(selected)
+
+		for _, name := range selected {
+			delete(installed, name)
+			if _, xerr := instance.DeleteFeature(ctx, name, data.Map{}, resources.FeatureSettings{}); xerr != nil {
+				switch xerr.(type) {
+				case *fail.ErrAborted:
+					return xerr
+				default:
+					errors = append(errors, fail.Wrap(xerr, "failed to remove Feature '%s'", name))
+				}
+			}
+		}
+	}
+	if len(errors) > 0 {
+		return fail.NewErrorList(errors)
+	}
+	return nil
+}
+
+// RelaxedDeleteHost is the method that really deletes a host, being a gateway or not
+func (instance *Host) RelaxedDeleteHost(ctx context.Context) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	svc := instance.GetService()
+	var shares map[string]*propertiesv1.HostShare
+	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		// Do not remove a Host having shares that are currently remotely mounted
+		innerXErr := props.Inspect(hostproperty.SharesV1, func(clonable data.Clonable) fail.Error {
+			sharesV1, ok := clonable.(*propertiesv1.HostShares)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostShares' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			shares = sharesV1.ByID
+			var blockers []string
+			for _, hostShare := range shares {
+				// clients found, checks if these clients still exist...
+				for clientID, clientName := range hostShare.ClientsByID {
+					clientInstance, inErr := LoadHost(svc, clientID)
+					if inErr == nil {
+						clientInstance.Released()
+						blockers = append(blockers, fmt.Sprintf("Share '%s' mounted on Host '%s'", hostShare.Name, clientName))
+					}
+				}
+			}
+			if len(blockers) > 0 {
+				sort.Strings(blockers)
+				return fail.ResourceInUseError(blockers, "Host '%s' exports %d share%s mounted by other Hosts", instance.GetName(), len(blockers), strprocess.Plural(uint(len(blockers))))
+			}
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		// Do not delete a Host with Volumes attached
+		return props.Inspect(hostproperty.VolumesV1, func(clonable data.Clonable) fail.Error {
+			hostVolumesV1, ok := clonable.(*propertiesv1.HostVolumes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostVolumes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			nAttached := len(hostVolumesV1.VolumesByID)
+			if nAttached > 0 {
+				blockers := make([]string, 0, nAttached)
+				for name := range hostVolumesV1.VolumesByName {
+					blockers = append(blockers, fmt.Sprintf("Volume '%s'", name))
+				}
+				sort.Strings(blockers)
+				return fail.ResourceInUseError(blockers, "Host '%s' has %d volume%s attached", instance.GetName(), nAttached, strprocess.Plural(uint(nAttached)))
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	var (
+		single         bool
+		singleSubnetID string
+	)
+	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		// If Host has mounted shares, unmounts them before anything else
+		// Note: only the IDs of the Shares are needed, so there is no need to load the Hosts serving them
+		var mounts []string
+		innerXErr := props.Inspect(hostproperty.MountsV1, func(clonable data.Clonable) fail.Error {
+			hostMountsV1, ok := clonable.(*propertiesv1.HostMounts)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostMounts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			for _, i := range hostMountsV1.RemoteMountsByPath {
+				mounts = append(mounts, i.ShareID)
+			}
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		// Unmounts tier shares mounted on Host (done outside the previous Host.properties.Reading() section, because
+		// Unmount() have to lock for write, and won't succeed while Host.properties.Reading() is running,
+		// leading to a deadlock)
+		// Every unmount is attempted; the Host is not deleted if a Share remains mounted
+		var unmountErrors []error
+		for _, v := range mounts {
+			if task.Aborted() {
+				return fail.AbortedError(nil, "aborted")
+			}
+
+			shareInstance, loopErr := LoadShare(svc, v)
+			if loopErr != nil {
+				logrus.Errorf(loopErr.Error())
+				unmountErrors = append(unmountErrors, loopErr)
+				continue
+			}
+
+			//goland:noinspection ALL
+			defer func(item resources.Share) {
+				item.Released()
+			}(shareInstance)
+
+			loopErr = shareInstance.Unmount(ctx, instance)
+			if loopErr != nil {
+				logrus.Warnf("failed to unmount Share '%s' from Host '%s', trying forced unmount: %v", shareInstance.GetName(), instance.GetName(), loopErr)
+				loopErr = shareInstance.ForceUnmount(ctx, instance)
+			}
+			if loopErr != nil {
+				logrus.Errorf(loopErr.Error())
+				unmountErrors = append(unmountErrors, loopErr)
+				continue
+			}
+		}
+		if len(unmountErrors) > 0 {
+			return fail.Wrap(fail.NewErrorList(unmountErrors), "failed to unmount Shares from Host '%s'", instance.GetName())
+		}
+
+		// if Host exports shares, delete them
+		for _, v := range shares {
+			if task.Aborted() {
+				return fail.AbortedError(nil, "aborted")
+			}
+
+			shareInstance, loopErr := LoadShare(svc, v.Name)
+			if loopErr != nil {
+				return loopErr
+			}
+
+			loopErr = shareInstance.Delete(ctx)
+			if loopErr != nil {
+				return loopErr
+			}
+		}
+
+		// Walk through property propertiesv1.HostNetworking to remove the reference to the Host in Subnets
+		innerXErr = props.Inspect(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+			hostNetworkV2, ok := clonable.(*propertiesv2.HostNetworking)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+			hostID := instance.GetID()
+			// hostName := instance.GetName()
+
+			single = hostNetworkV2.Single
+			if single {
+				singleSubnetID = hostNetworkV2.DefaultSubnetID
+			}
+
+			if !single {
+				var errors []error
+				for k := range hostNetworkV2.SubnetsByID {
+					if !hostNetworkV2.IsGateway && k != hostNetworkV2.DefaultSubnetID {
+						subnetInstance, loopErr := LoadSubnet(svc, "", k)
+						if loopErr == nil {
+							//goland:noinspection ALL
+							defer func(item resources.Subnet) {
+								item.Released()
+							}(subnetInstance)
+
+							loopErr = subnetInstance.AbandonHost(ctx, hostID)
+						}
+						if loopErr != nil {
+							logrus.Errorf(loopErr.Error())
+							errors = append(errors, loopErr)
+							continue
+						}
+					}
+				}
+				if len(errors) > 0 {
+					return fail.Wrap(fail.NewErrorList(errors), "failed to update metadata for Subnets of Host")
+				}
+			}
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		// Unbind Security Groups from Host
+		innerXErr = props.Alter(hostproperty.SecurityGroupsV1, func(clonable data.Clonable) fail.Error {
+			hsgV1, ok := clonable.(*propertiesv1.HostSecurityGroups)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostSecurityGroups' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			// Unbind Security Groups from Host
+			var errors []error
+			for _, v := range hsgV1.ByID {
+				rsg, derr := LoadSecurityGroup(svc, v.ID)
+				if derr == nil {
+					//goland:noinspection ALL
+					defer func(sgInstance resources.SecurityGroup) {
+						sgInstance.Released()
+					}(rsg)
+
+					// Host is deleted, so all the references on the bond are removed
+					derr = rsg.UnbindFromHost(ctx, instance, data.NewImmutableKeyValue("Force", true))
+				}
+				if derr != nil {
+					switch derr.(type) {
+					case *fail.ErrNotFound:
+						// Consider that a Security Group that cannot be loaded or is not bound as a success
+					default:
+						errors = append(errors, derr)
+					}
+				}
+			}
+			if len(errors) > 0 {
+				return fail.Wrap(fail.NewErrorList(errors), "failed to unbind some Security Groups")
+			}
+
+			return nil
+		})
+		if innerXErr != nil {
+			return fail.Wrap(innerXErr, "failed to unbind Security Groups from Host")
+		}
+
+		// Delete Host
+		waitForDeletion := true
+		innerXErr = retry.WhileUnsuccessfulDelay1Second(
+			func() error {
+				if derr := svc.DeleteHost(instance.GetID()); derr != nil {
+					switch derr.(type) {
+					case *fail.ErrNotFound:
+						// A Host not found is considered as a successful deletion
+						logrus.Tracef("Host not found, deletion considered as a success")
+					default:
+						return fail.ProviderFailureError(surfaceProviderError(derr, "cannot delete Host"), "provider failed to delete Host '%s'", instance.GetName())
+					}
+					waitForDeletion = false
+				}
+				return nil
+			},
+			temporal.GetHostDeletionTimeout(ctx),
+		)
+		if innerXErr != nil {
+			switch innerXErr.(type) {
+			case *retry.ErrTimeout, *retry.ErrStopRetry:
+				// surfaces the last refusal of the provider, to keep it distinct from the refusals of SafeScale
+				if cause, ok := fail.ConvertError(innerXErr.Cause()).(*fail.ErrProviderFailure); ok {
+					return cause
+				}
+			default:
+			}
+			return innerXErr
+		}
+
+		// wait for effective Host deletion
+		if waitForDeletion {
+			innerXErr = retry.WhileUnsuccessfulDelay5SecondsTimeout(
+				func() error {
+					state, stateErr := svc.GetHostState(instance.GetID())
+					if stateErr != nil {
+						switch stateErr.(type) {
+						case *fail.ErrNotFound:
+							// If Host is not found anymore, consider this as a success
+							return nil
+						default:
+							return stateErr
+						}
+					}
+					if state == hoststate.Error {
+						return fail.NotAvailableError("Host is in state Error")
+					}
+					return nil
+				},
+				temporal.GetHostDeletionConfirmationTimeout(ctx),
+			)
+			if innerXErr != nil {
+				switch innerXErr.(type) {
+				case *retry.ErrStopRetry:
+					innerXErr = fail.ConvertError(innerXErr.Cause())
+				default:
+				}
+			}
+			if innerXErr != nil {
+				switch innerXErr.(type) {
+				case *fail.ErrNotFound:
+					// continue
+				default:
+					return innerXErr
+				}
+			}
+		}
+
+		return nil
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if single {
+		// delete its dedicated Subnet
+		singleSubnetInstance, xerr := LoadSubnet(svc, "", singleSubnetID)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+
+		xerr = singleSubnetInstance.Delete(ctx)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+	}
+
+	// Deletes metadata from Object Storage
+	hostID := instance.GetID()
+	xerr = instance.MetadataCore.Delete()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		// If entry not found, considered as a success
+		if _, ok := xerr.(*fail.ErrNotFound); !ok {
+			return xerr
+		}
+		logrus.Tracef("core instance not found, deletion considered as a success")
+	}
+
+	// Evicts the Host from cache, so a Host with the same name can be created right away
+	hostCache, xerr := svc.GetCache(hostKind)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	return hostCache.DeleteEntry(hostID)
+}
+
+// GetSSHConfig loads SSH configuration for Host from metadata
+//
+// FIXME: verify that system.SSHConfig carries data about secondary getGateway
+func (instance *Host) GetSSHConfig() (_ *system.SSHConfig, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return nil, xerr
+	}
+	defer instance.lock.RUnlock()
+
+	return instance.sshProfile, nil
+}
+
+// Execute tries to execute command 'cmd' on the Host, returning the outcome of the execution
+// By default, 'cmd' is executed as is, with the rights of the operator user (without privilege escalation), collecting
+// its outputs.
+// Valid keyvalues for options are :
+// - "Privileged": bool = if set to true, executes 'cmd' with the shell and the privilege escalation configured for the Host
+// - "Outputs": outputs.Enum = tells what to do with the outputs of 'cmd' (default: outputs.COLLECT)
+// - "ConnectionTimeout": time.Duration = the time allowed to connect to the Host (default: temporal.GetConnectionTimeout())
+// - "ExecutionTimeout": time.Duration = the time allowed to execute 'cmd' (default: temporal.GetExecutionTimeout())
+// If "Privileged" is requested on a Host configured without privilege escalation, returns *fail.ErrNotAvailable
+// If the command has been started, the result is returned even if an error occurred, filled with what has been collected
+func (instance *Host) Execute(ctx context.Context, cmd string, options ...data.ImmutableKeyValue) (_ *resources.ExecutionResult, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if cmd == "" {
+		return nil, fail.InvalidParameterError("cmd", "cannot be empty string")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	var (
+		privileged        bool
+		outs              = outputs.COLLECT
+		connectionTimeout = temporal.GetConnectionTimeout()
+		executionTimeout  = temporal.GetExecutionTimeout()
+	)
+	for _, v := range options {
+		switch v.Key() {
+		case "Privileged":
+			privileged = v.Value().(bool)
+		case "Outputs":
+			outs = v.Value().(outputs.Enum)
+		case "ConnectionTimeout":
+			connectionTimeout = v.Value().(time.Duration)
+		case "ExecutionTimeout":
+			executionTimeout = v.Value().(time.Duration)
+		default:
+		}
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(cmd='%s', outs=%s)", cmd, outs.String()).Entering()
+	defer tracer.Exiting()
+
+	instance.lock.RLock()
+	defer instance.lock.RUnlock()
+
+	if privileged {
+		cmd, xerr = instance.buildPrivilegedCommand("-c " + strprocess.ShellQuote(cmd))
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return nil, xerr
+		}
+	}
+
+	return instance.unsafeExecute(ctx, cmd, outs, connectionTimeout, executionTimeout)
+}
+
+// Run tries to execute command 'cmd' on the Host
+// Run is an adapter of Execute, kept for the callers expecting retcode, stdout and stderr separately; the options
+// "Outputs", "ConnectionTimeout" and "ExecutionTimeout" are overridden by the corresponding parameters
+func (instance *Host) Run(ctx context.Context, cmd string, outs outputs.Enum, connectionTimeout, executionTimeout time.Duration, options ...data.ImmutableKeyValue) (_ int, _ string, _ string, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return 0, "", "", fail.InvalidInstanceError()
+	}
+
+	options = append(options,
+		data.NewImmutableKeyValue("Outputs", outs),
+		data.NewImmutableKeyValue("ConnectionTimeout", connectionTimeout),
+		data.NewImmutableKeyValue("ExecutionTimeout", executionTimeout),
+	)
+	result, xerr := instance.Execute(ctx, cmd, options...)
+	if result == nil {
+		return -1, "", "", xerr
+	}
+	return result.Retcode, result.Stdout, result.Stderr, xerr
+}
+
+// RunWithStdin tries to execute command 'cmd' on the Host, streaming the content of 'stdin' to the standard input of the
+// remote process (for example to feed 'kubectl apply -f -' without pushing a file first)
+// The standard input of the remote process is closed when 'stdin' reaches EOF; on timeout or abort, the SSH session is torn down.
+// Contrary to Run, the command is not retried if the SSH connection fails, 'stdin' being consumed by the first attempt.
+func (instance *Host) RunWithStdin(ctx context.Context, cmd string, stdin io.Reader, outs outputs.Enum, connectionTimeout, executionTimeout time.Duration, options ...data.ImmutableKeyValue) (_ int, _ string, _ string, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return 0, "", "", fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return -1, "", "", fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if cmd == "" {
+		return -1, "", "", fail.InvalidParameterError("cmd", "cannot be empty string")
+	}
+	if stdin == nil {
+		return -1, "", "", fail.InvalidParameterCannotBeNilError("stdin")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return -1, "", "", xerr
+	}
+
+	if task.Aborted() {
+		return 0, "", "", fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(cmd='%s', outs=%s)", cmd, outs.String()).Entering()
+	defer tracer.Exiting()
+
+	instance.lock.RLock()
+	defer instance.lock.RUnlock()
+
+	privileged := false
+	for _, v := range options {
+		switch v.Key() {
+		case "Privileged":
+			privileged = v.Value().(bool)
+		default:
+		}
+	}
+	if privileged {
+		cmd, xerr = instance.buildPrivilegedCommand("-c " + strprocess.ShellQuote(cmd))
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return -1, "", "", xerr
+		}
+	}
+
+	return instance.UnsafeRunWithStdin(ctx, cmd, stdin, outs, connectionTimeout, executionTimeout)
+}
+
+// StreamLog follows the file 'path' on the Host like 'tail -F', calling 'handler' with each line added to the file,
+// starting with its last 'lines' lines
+// The following ends when the task is aborted (or the context cancelled): the remote tail is then terminated. Returns
+// nil in this case.
+func (instance *Host) StreamLog(ctx context.Context, path string, lines uint, handler func(string)) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if path == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("path")
+	}
+	if handler == nil {
+		return fail.InvalidParameterCannotBeNilError("handler")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(%s, %d)", path, lines).Entering()
+	defer tracer.Exiting()
+
+	// tail is killed when the standard input of the remote command is closed, either when the task is aborted or
+	// when the SSH connection is lost
+	cmd := fmt.Sprintf("tail -n %d -F %s & pid=$!; cat >/dev/null; kill $pid", lines, strprocess.ShellQuote(path))
+
+	// the lock is not kept while following the file, which may last a long time
+	instance.lock.RLock()
+	sshProfile := instance.sshProfile
+	cmd, xerr = instance.buildPrivilegedCommand("-c " + strprocess.ShellQuote(cmd))
+	instance.lock.RUnlock()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	taskCtx := task.GetContext()
+	stdinReader, stdinWriter := io.Pipe()
+	go func() {
+		<-taskCtx.Done()
+		_ = stdinWriter.Close()
+	}()
+	defer func() { _ = stdinWriter.Close() }()
+
+	xerr = runWithOutputHandler(taskCtx, sshProfile, cmd, stdinReader, handler, 0)
+	if xerr != nil {
+		if task.Aborted() || taskCtx.Err() != nil {
+			return nil
+		}
+		return fail.Wrap(xerr, "failed to follow '%s' on Host '%s'", path, instance.GetName())
+	}
+	return nil
+}
+
+// Pull downloads a file from Host
+func (instance *Host) Pull(ctx context.Context, target, source string, timeout time.Duration) (_ int, _ string, _ string, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return 0, "", "", fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return 0, "", "", fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if target == "" {
+		return 0, "", "", fail.InvalidParameterCannotBeEmptyStringError("target")
+	}
+	if source == "" {
+		return 0, "", "", fail.InvalidParameterCannotBeEmptyStringError("source")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return -1, "", "", xerr
+	}
+
+	if task.Aborted() {
+		return 0, "", "", fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(target=%s,source=%s)", target, source).Entering()
+	defer tracer.Exiting()
+
+	instance.lock.RLock()
+	defer instance.lock.RUnlock()
+
+	// FIXME: reintroduce timeout on ssh.
+	// if timeout < temporal.GetHostTimeout() {
+	// 	timeout = temporal.GetHostTimeout()
+	// }
+	var (
+		retcode        int
+		stdout, stderr string
+	)
+	xerr = retry.WhileUnsuccessfulDelay5Seconds(
+		func() error {
+			var innerXErr fail.Error
+			if retcode, stdout, stderr, innerXErr = instance.sshProfile.Copy(ctx, target, source, false); innerXErr != nil {
+				return innerXErr
+			}
+			switch retcode { //nolint
+			case 1: // FIXME: Check errorcodes
+				if strings.Contains(stdout, "lost connection") {
+					return fail.NewError("lost connection, retrying...")
+				}
+			}
+			return nil
+		},
+		2*timeout,
+	)
+	return retcode, stdout, stderr, xerr
+}
+
+// Push uploads a file to Host
+func (instance *Host) Push(ctx context.Context, source, target, owner, mode string, timeout time.Duration) (_ int, _ string, _ string, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return 0, "", "", fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return 0, "", "", fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return -1, "", "", xerr
+	}
+
+	if task.Aborted() {
+		return 0, "", "", fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(source=%s, target=%s, owner=%s, mode=%s)", source, target, owner, mode).Entering()
+	defer tracer.Exiting()
+
+	instance.lock.RLock()
+	defer instance.lock.RUnlock()
+
+	return instance.UnsafePush(ctx, source, target, owner, mode, timeout)
+}
+
+// GetShare returns a clone of the propertiesv1.HostShare corresponding to share 'shareRef'
+func (instance *Host) GetShare(shareRef string) (_ *propertiesv1.HostShare, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return nil, xerr
+	}
+	defer instance.lock.RUnlock()
+
+	var (
+		hostShare *propertiesv1.HostShare
+		// ok        bool
+	)
+	err := instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(hostproperty.SharesV1, func(clonable data.Clonable) fail.Error {
+			sharesV1, ok := clonable.(*propertiesv1.HostShares)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostShares' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			if item, ok := sharesV1.ByID[shareRef]; ok {
+				hostShare = item.Clone().(*propertiesv1.HostShare)
+				return nil
+			}
+			if item, ok := sharesV1.ByName[shareRef]; ok {
+				hostShare = sharesV1.ByID[item].Clone().(*propertiesv1.HostShare)
+				return nil
+			}
+			return fail.NotFoundError("share '%s' not found in server '%s' metadata", shareRef, instance.GetName())
+		})
+	})
+	err = debug.InjectPlannedFail(err)
+	if err != nil {
+		return nil, err
+	}
+
+	return hostShare, nil
+}
+
+// GetVolumes returns information about volumes attached to the Host
+func (instance *Host) GetVolumes() (_ *propertiesv1.HostVolumes, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return nil, xerr
+	}
+	defer instance.lock.RUnlock()
+
+	return instance.UnsafeGetVolumes()
+}
+
+// Start starts the Host
+func (instance *Host) Start(ctx context.Context) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host")).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	// make sure no other operation on the same Host runs in parallel in the daemon
+	unlockHost, xerr := lockHost(ctx, instance)
+	if xerr != nil {
+		return xerr
+	}
+	defer unlockHost()
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	defer recordOperation(instance, hostproperty.LastOperationV1, "start")(&xerr)
+
+	return instance.unsafeStart(ctx)
+}
+
+// Stop stops the Host
+func (instance *Host) Stop(ctx context.Context) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host")).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	// make sure no other operation on the same Host runs in parallel in the daemon
+	unlockHost, xerr := lockHost(ctx, instance)
+	if xerr != nil {
+		return xerr
+	}
+	defer unlockHost()
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	defer recordOperation(instance, hostproperty.LastOperationV1, "stop")(&xerr)
+
+	_, xerr = instance.unsafeStop(ctx, 0)
+	return xerr
+}
+
+// StopGracefully stops the Host by a shutdown of its operating system, waiting at most 'gracePeriod' for the Host to be
+// stopped before stopping it with the provider; returns the way the Host has been stopped, also recorded in metadata
+// If gracePeriod <= 0, the Host is directly stopped by the provider
+func (instance *Host) StopGracefully(ctx context.Context, gracePeriod time.Duration) (_ resources.HostStopMethod, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return "", fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return "", fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return "", xerr
+	}
+
+	if task.Aborted() {
+		return "", fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(%s)", gracePeriod).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	// make sure no other operation on the same Host runs in parallel in the daemon
+	unlockHost, xerr := lockHost(ctx, instance)
+	if xerr != nil {
+		return "", xerr
+	}
+	defer unlockHost()
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	return instance.unsafeStop(ctx, gracePeriod)
+}
+
+// CreateImage captures the disk of the Host in a new image named 'imageName', and returns the ID of the image once available
+// A started Host is stopped gracefully before the capture, to get a consistent disk, then restarted; if the restart fails,
+// the ID of the image is returned with the error
+// The ID of the image is recorded in metadata of the Host; returns *fail.ErrNotAvailable if the provider cannot capture images
+func (instance *Host) CreateImage(ctx context.Context, imageName string) (imageID string, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return "", fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return "", fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if imageName = strings.TrimSpace(imageName); imageName == "" {
+		return "", fail.InvalidParameterCannotBeEmptyStringError("imageName")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return "", xerr
+	}
+
+	if task.Aborted() {
+		return "", fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(%s)", imageName).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	svc := instance.GetService()
+	if !svc.GetCapabilities().HostImageCapture {
+		return "", fail.NotAvailableError("the provider cannot create an image from a Host")
+	}
+
+	// make sure no other operation on the same Host runs in parallel in the daemon
+	unlockHost, xerr := lockHost(ctx, instance)
+	if xerr != nil {
+		return "", xerr
+	}
+	defer unlockHost()
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	defer recordOperation(instance, hostproperty.LastOperationV1, "create-image")(&xerr)
+
+	hostName := instance.GetName()
+	hostID := instance.GetID()
+
+	state, xerr := svc.GetHostState(hostID)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return "", xerr
+	}
+
+	switch state {
+	case hoststate.Stopped:
+	case hoststate.Started:
+		if _, xerr = instance.unsafeStop(ctx, hostImageShutdownGracePeriod); xerr != nil {
+			return "", fail.Wrap(xerr, "failed to stop Host '%s' before the capture of its disk", hostName)
+		}
+
+		defer func() {
+			if derr := instance.unsafeStart(ctx); derr != nil {
+				derr = fail.Wrap(derr, "failed to restart Host '%s' after the capture of its disk", hostName)
+				if xerr != nil {
+					_ = xerr.AddConsequence(derr)
+				} else {
+					xerr = derr
+				}
+			}
+		}()
+	default:
+		return "", fail.NotAvailableError("Host '%s' has to be started or stopped to capture its disk (current state: %s)", hostName, state.String())
+	}
+
+	image, xerr := svc.CreateImageFromHost(hostID, imageName)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return "", xerr
+	}
+
+	// Records the image produced; the image being available, a failure is not an error of the capture
+	innerXErr := instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(hostproperty.DescriptionV1, func(clonable data.Clonable) fail.Error {
+			hostDescriptionV1, ok := clonable.(*propertiesv1.HostDescription)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostDescription' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			hostDescriptionV1.LastImageID = image.ID
+			hostDescriptionV1.LastImageName = imageName
+			hostDescriptionV1.LastImageCreated = time.Now()
+			return nil
+		})
+	})
+	if innerXErr != nil {
+		logrus.Warnf("Image '%s' of Host '%s' created, but failed to record it: %v", image.ID, hostName, innerXErr)
+	}
+
+	logrus.Infof("Image '%s' (%s) created from Host '%s'", imageName, image.ID, hostName)
+	return image.ID, nil
+}
+
+// WaitForState waits for the Host to reach 'state' on provider side
+// Returns *fail.ErrTimeout, annotated with the last observed state, if the state is not reached after 'timeout'
+func (instance *Host) WaitForState(ctx context.Context, state hoststate.Enum, timeout time.Duration) (xerr fail.Error) {
+	_, xerr = instance.WaitForAnyState(ctx, []hoststate.Enum{state}, timeout)
+	return xerr
+}
+
+// WaitForAnyState waits for the Host to reach one of 'states' on provider side, and returns the state reached
+// Returns *fail.ErrTimeout, annotated with the last observed state, if none of the states is reached after 'timeout';
+// returns *fail.ErrNotAvailable if the Host goes in state Error (and Error is not a wanted state)
+func (instance *Host) WaitForAnyState(ctx context.Context, states []hoststate.Enum, timeout time.Duration) (_ hoststate.Enum, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return hoststate.Unknown, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return hoststate.Unknown, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if len(states) == 0 {
+		return hoststate.Unknown, fail.InvalidParameterError("states", "cannot be empty")
+	}
+	if timeout <= 0 {
+		timeout = temporal.GetHostStateChangeTimeout(ctx)
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return hoststate.Unknown, xerr
+	}
+
+	if task.Aborted() {
+		return hoststate.Unknown, fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(%v, %s)", states, timeout).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	wanted := make(map[hoststate.Enum]bool, len(states))
+	for _, v := range states {
+		wanted[v] = true
+	}
+
+	hostName := instance.GetName()
+	hostID := instance.GetID()
+	svc := instance.GetService()
+	lastState := hoststate.Unknown
+	xerr = retry.WhileUnsuccessful(
+		func() error {
+			if task.Aborted() {
+				return retry.StopRetryError(fail.AbortedError(nil, "aborted"))
+			}
+
+			state, innerXErr := svc.GetHostState(hostID)
+			if innerXErr != nil {
+				return innerXErr
+			}
+
+			lastState = state
+			if wanted[state] {
+				return nil
+			}
+			if state == hoststate.Error {
+				return retry.StopRetryError(fail.NotAvailableError("Host '%s' is in state Error", hostName))
+			}
+			return fail.NewError("Host '%s' is in state '%s'", hostName, state.String())
+		},
+		temporal.GetMinDelay(),
+		timeout,
+	)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *retry.ErrStopRetry:
+			if cerr := fail.ConvertError(xerr.Cause()); cerr != nil {
+				return lastState, cerr
+			}
+			return lastState, xerr
+		case *retry.ErrTimeout:
+			terr := fail.TimeoutError(xerr, timeout, fmt.Sprintf("timeout waiting Host '%s' to reach state %v; last observed state is '%s'", hostName, states, lastState.String()))
+			_ = terr.Annotate("last_state", lastState)
+			return lastState, terr
+		default:
+			return lastState, xerr
+		}
+	}
+
+	return lastState, nil
+}
+
+// Reboot reboots the Host
+func (instance *Host) Reboot(ctx context.Context) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host")).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	defer recordOperation(instance, hostproperty.LastOperationV1, "reboot")(&xerr)
+
+	xerr = instance.Stop(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+	return instance.Start(ctx)
+}
+
+// Resize ...
+// not yet implemented
+func (instance *Host) Resize(ctx context.Context, hostSize abstract.HostSizingRequirements) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(%v)", hostSize).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	// make sure no other operation on the same Host runs in parallel in the daemon
+	unlockHost, xerr := lockHost(ctx, instance)
+	if xerr != nil {
+		return xerr
+	}
+	defer unlockHost()
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	defer recordOperation(instance, hostproperty.LastOperationV1, "resize")(&xerr)
+
+	ahf, xerr := instance.GetService().ResizeHost(instance.GetID(), hostSize)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotImplemented:
+			return xerr
+		default:
+			return surfaceProviderError(xerr, "failed to resize Host '%s'", instance.GetName())
+		}
+	}
+
+	return instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(hostproperty.SizingV2, func(clonable data.Clonable) fail.Error {
+			hostSizingV2, ok := clonable.(*propertiesv2.HostSizing)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.HostSizing' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			if ahf != nil && ahf.Sizing != nil {
+				hostSizingV2.AllocatedSize = converters.HostEffectiveSizingFromAbstractToPropertyV2(ahf.Sizing)
+			}
+			hostSizingV2.RequestedSize = converters.HostSizingRequirementsFromAbstractToPropertyV2(hostSize)
+			return nil
+		})
+	})
+}
+
+// RunPhase runs again an install phase on the Host (typically after a failure of the provisioning of a Host created
+// with KeepOnFailure), generating its script from the userdata persisted at creation; PHASE1_INIT cannot be run again,
+// being executed by the provider at Host startup
+// As during creation, the Host is rebooted after phases PHASE2_NETWORK_AND_SECURITY and PHASE4_SYSTEM_FIXES
+func (instance *Host) RunPhase(ctx context.Context, phase userdata.Phase) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	switch phase {
+	case userdata.PHASE1_INIT:
+		return fail.InvalidRequestError("install phase '%s' cannot be run again, it is executed by the provider at Host startup", phase)
+	case userdata.PHASE2_NETWORK_AND_SECURITY, userdata.PHASE3_GATEWAY_HIGH_AVAILABILITY, userdata.PHASE4_SYSTEM_FIXES, userdata.PHASE5_FINAL:
+	default:
+		return fail.InvalidParameterError("phase", "unknown install phase '%s'", phase)
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(%s)", phase).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	// make sure no other operation on the same Host runs in parallel in the daemon
+	unlockHost, xerr := lockHost(ctx, instance)
+	if xerr != nil {
+		return xerr
+	}
+	defer unlockHost()
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	defer recordOperation(instance, hostproperty.LastOperationV1, "run-phase")(&xerr)
+
+	hostName := instance.GetName()
+	userdataContent := userdata.NewContent()
+	var privateKey, password string
+	xerr = instance.Inspect(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+		ahc, ok := clonable.(*abstract.HostCore)
+		if !ok {
+			return fail.InconsistentError("'*abstract.HostCore' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		privateKey, password = ahc.PrivateKey, ahc.Password
+		return props.Inspect(hostproperty.UserdataV1, func(clonable data.Clonable) fail.Error {
+			hostUserdataV1, ok := clonable.(*propertiesv1.HostUserdata)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostUserdata' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			if hostUserdataV1.Content == "" {
+				return fail.NotFoundError("no userdata recorded for Host '%s' (created by a previous release or imported)", hostName)
+			}
+			if err := json.Unmarshal([]byte(hostUserdataV1.Content), userdataContent); err != nil {
+				return fail.SyntaxError("failed to decode userdata of Host '%s': %v", hostName, err)
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if phase == userdata.PHASE3_GATEWAY_HIGH_AVAILABILITY {
+		if !userdataContent.IsGateway {
+			return fail.InvalidRequestError("install phase '%s' is reserved to gateways", phase)
+		}
+		if userdataContent.SecondaryGatewayPrivateIP != "" {
+			return fail.NotAvailableError("install phase '%s' cannot be run again on a gateway with high availability, the password of keepalived not being recorded", phase)
+		}
+	}
+
+	xerr = userdataContent.Restore(privateKey, password)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	logrus.Infof("running again install phase '%s' on Host '%s'", phase, hostName)
+	xerr = instance.runInstallPhase(ctx, phase, userdataContent)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	switch phase {
+	case userdata.PHASE2_NETWORK_AND_SECURITY, userdata.PHASE4_SYSTEM_FIXES:
+		xerr = instance.rebootAndWaitInstallPhase(ctx, phase)
+	case userdata.PHASE5_FINAL:
+		_, xerr = instance.waitInstallPhase(ctx, phase, temporal.GetHostTimeout())
+	default:
+	}
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	logrus.Infof("install phase '%s' successfully run again on Host '%s'", phase, hostName)
+	return nil
+}
+
+// authorizedKeysFile is the file listing the public keys allowed to connect as the operator user
+const authorizedKeysFile = "${HOME}/.ssh/authorized_keys"
+
+// buildAddAuthorizedKeyScript returns the script allowing 'publicKey' to connect as the user running it, if not already
+// allowed
+func buildAddAuthorizedKeyScript(publicKey string) string {
+	return fmt.Sprintf(`set -e
+mkdir -p "${HOME}/.ssh" && chmod 700 "${HOME}/.ssh"
+touch %[2]s && chmod 600 %[2]s
+grep -qxF '%[1]s' %[2]s || echo '%[1]s' >>%[2]s
+`, publicKey, authorizedKeysFile)
+}
+
+// buildRemoveAuthorizedKeyScript returns the script removing 'publicKey' from the keys allowed to connect as the user
+// running it; nothing is changed if 'keptPublicKey' would not remain allowed
+func buildRemoveAuthorizedKeyScript(publicKey, keptPublicKey string) string {
+	return fmt.Sprintf(`set -e
+grep -vxF '%[1]s' %[3]s >%[3]s.new || true
+grep -qxF '%[2]s' %[3]s.new || { rm -f %[3]s.new; echo "kept key would not be allowed anymore" >&2; exit 1; }
+chmod 600 %[3]s.new
+mv -f %[3]s.new %[3]s
+`, publicKey, keptPublicKey, authorizedKeysFile)
+}
+
+// runAuthorizedKeysScript runs a script built by buildAddAuthorizedKeyScript or buildRemoveAuthorizedKeyScript on the
+// Host reached with 'ssh'
+func runAuthorizedKeysScript(ctx context.Context, ssh *system.SSHConfig, script string) fail.Error {
+	retcode, _, stderr, xerr := runWithStdin(ctx, ssh, "bash -s", strings.NewReader(script), outputs.COLLECT, temporal.GetExecutionTimeout())
+	if xerr != nil {
+		return xerr
+	}
+	if retcode != 0 {
+		return fail.ExecutionError(nil, "failed to update authorized keys (retcode=%d): %s", retcode, stderr)
+	}
+	return nil
+}
+
+// RotateKeypair replaces the SSH keypair used to connect to the Host as the operator user
+// The new public key is allowed and checked before the new private key is recorded in metadata; until then, any failure
+// leaves the Host reachable with the previous keypair. The previous public key is removed last.
+func (instance *Host) RotateKeypair(ctx context.Context) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "").WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	// make sure no other operation on the same Host runs in parallel in the daemon
+	unlockHost, xerr := lockHost(ctx, instance)
+	if xerr != nil {
+		return xerr
+	}
+	defer unlockHost()
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	defer recordOperation(instance, hostproperty.LastOperationV1, "rotate-keypair")(&xerr)
+
+	hostName := instance.GetName()
+	if instance.sshProfile == nil {
+		return fail.NotAvailableError("no SSH configuration available for Host '%s'", hostName)
+	}
+	oldProfile := instance.sshProfile
+
+	oldPublicKey, xerr := crypt.PublicKeyFromPrivateKey(oldProfile.PrivateKey)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return fail.Wrap(xerr, "failed to compute current public key of Host '%s'", hostName)
+	}
+	oldPublicKey = strings.TrimSpace(oldPublicKey)
+
+	kp, xerr := abstract.NewKeyPair("")
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return fail.Wrap(xerr, "failed to generate new keypair for Host '%s'", hostName)
+	}
+	newPublicKey := strings.TrimSpace(kp.PublicKey)
+
+	// Allows the new public key besides the current one
+	xerr = runAuthorizedKeysScript(ctx, oldProfile, buildAddAuthorizedKeyScript(newPublicKey))
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return fail.Wrap(xerr, "failed to install new public key on Host '%s'", hostName)
+	}
+
+	// Until the new private key is recorded, any failure removes the new public key from the Host
+	recorded := false
+	defer func() {
+		if xerr != nil && !recorded {
+			derr := runAuthorizedKeysScript(context.Background(), oldProfile, buildRemoveAuthorizedKeyScript(newPublicKey, oldPublicKey))
+			if derr != nil {
+				_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on failure, failed to remove new public key from Host '%s'", hostName))
+			}
+		}
+	}()
+
+	// Makes sure the new keypair gives access to the Host before recording it
+	probe := copySSHConfig(oldProfile)
+	probe.PrivateKey = kp.PrivateKey
+	retcode, _, stderr, xerr := run(ctx, probe, "true", outputs.COLLECT, temporal.GetConnectSSHTimeout())
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return fail.Wrap(xerr, "failed to connect to Host '%s' with new keypair", hostName)
+	}
+	if retcode != 0 {
+		return fail.ExecutionError(nil, "failed to connect to Host '%s' with new keypair (retcode=%d): %s", hostName, retcode, stderr)
+	}
+
+	// Records the new keypair; the userdata is updated too, for the phase setting authorized keys to be run again safely
+	xerr = instance.AlterMany([]resources.Callback{
+		func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+			ahc, ok := clonable.(*abstract.HostCore)
+			if !ok {
+				return fail.InconsistentError("'*abstract.HostCore' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			ahc.PrivateKey = kp.PrivateKey
+			return nil
+		},
+		func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+			return props.Alter(hostproperty.UserdataV1, func(clonable data.Clonable) fail.Error {
+				hostUserdataV1, ok := clonable.(*propertiesv1.HostUserdata)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv1.HostUserdata' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				if hostUserdataV1.Content == "" {
+					return fail.AlteredNothingError()
+				}
+				userdataContent := userdata.NewContent()
+				if err := json.Unmarshal([]byte(hostUserdataV1.Content), userdataContent); err != nil {
+					return fail.SyntaxError("failed to decode userdata of Host '%s': %v", hostName, err)
+				}
+				userdataContent.FinalPublicKey = newPublicKey
+				jsoned, err := json.Marshal(userdataContent)
+				if err != nil {
+					return fail.ConvertError(err)
+				}
+				hostUserdataV1.Content = string(jsoned)
+				return nil
+			})
+		},
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return fail.Wrap(xerr, "failed to record new keypair of Host '%s'", hostName)
+	}
+	recorded = true
+
+	xerr = instance.updateCachedInformation()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// The SSH configuration of a gateway is cached with the Subnet
+	var isGateway bool
+	var defaultSubnetID string
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+			hnV2, ok := clonable.(*propertiesv2.HostNetworking)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			isGateway, defaultSubnetID = hnV2.IsGateway, hnV2.DefaultSubnetID
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+	if isGateway && defaultSubnetID != "" {
+		refreshSubnetHostsGateways(instance.GetService(), defaultSubnetID)
+	}
+
+	// Finally removes the previous public key, using the new keypair
+	xerr = runAuthorizedKeysScript(ctx, instance.sshProfile, buildRemoveAuthorizedKeyScript(oldPublicKey, newPublicKey))
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return fail.Wrap(xerr, "new keypair of Host '%s' is in use, but failed to remove previous public key", hostName)
+	}
+
+	logrus.Infof("SSH keypair of Host '%s' successfully rotated", hostName)
+	return nil
+}
+
+// AttachToSubnet connects the Host to an additional Subnet, adding a network interface on provider side
+// If option "Default" is set to true, the Subnet becomes the default Subnet of the Host
+// Note: the configuration of the new interface inside the Host (address, default route) is left to the provider (DHCP)
+func (instance *Host) AttachToSubnet(ctx context.Context, subnetID string, options ...data.ImmutableKeyValue) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if subnetID == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("subnetID")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(%s)", subnetID).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	asDefault := false
+	for _, v := range options {
+		switch v.Key() {
+		case "Default":
+			asDefault, _ = v.Value().(bool)
+		default:
+		}
+	}
+
+	// make sure no other operation on the same Host runs in parallel in the daemon
+	unlockHost, xerr := lockHost(ctx, instance)
+	if xerr != nil {
+		return xerr
+	}
+	defer unlockHost()
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	hostName := instance.GetName()
+	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+			hnV2, ok := clonable.(*propertiesv2.HostNetworking)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			if hnV2.IsGateway || hnV2.Single {
+				return fail.InvalidRequestError("cannot attach gateway or single Host '%s' to another Subnet", hostName)
+			}
+			if _, ok := hnV2.SubnetsByID[subnetID]; ok {
+				return fail.DuplicateError("Host '%s' is already attached to Subnet '%s'", hostName, subnetID)
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	svc := instance.GetService()
+	rs, xerr := LoadSubnet(svc, "", subnetID)
+	if xerr != nil {
+		return xerr
+	}
+
+	var as *abstract.Subnet
+	xerr = rs.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+		var ok bool
+		as, ok = clonable.(*abstract.Subnet)
+		if !ok {
+			return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+		return nil
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	ip, xerr := svc.AttachHostToSubnet(instance.GetID(), as)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotImplemented:
+			return xerr
+		default:
+			return surfaceProviderError(xerr, "failed to attach Host '%s' to Subnet '%s'", hostName, as.Name)
+		}
+	}
+
+	defer func() {
+		if xerr != nil {
+			if derr := svc.DetachHostFromSubnet(instance.GetID(), as); derr != nil {
+				_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on %s, failed to detach Host '%s' from Subnet '%s'", ActionFromError(xerr), hostName, as.Name))
 			}
-			return nil
-		})
-		if innerXErr != nil {
-			return innerXErr
 		}
+	}()
 
-		// Do not delete a Host with Volumes attached
-		return props.Inspect(hostproperty.VolumesV1, func(clonable data.Clonable) fail.Error {
-			hostVolumesV1, ok := clonable.(*propertiesv1.HostVolumes)
+	hostID := instance.GetID()
+	xerr = rs.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(subnetproperty.HostsV1, func(clonable data.Clonable) fail.Error {
+			subnetHostsV1, ok := clonable.(*propertiesv1.SubnetHosts)
 			if !ok {
-				return fail.InconsistentError("'*propertiesv1.HostVolumes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				return fail.InconsistentError("'*propertiesv1.SubnetHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			nAttached := len(hostVolumesV1.VolumesByID)
-			if nAttached > 0 {
-				return fail.NotAvailableError("Host '%s' has %d volume%s attached", instance.GetName(), nAttached, strprocess.Plural(uint(nAttached)))
-			}
+			subnetHostsV1.ByName[hostName] = hostID
+			subnetHostsV1.ByID[hostID] = hostName
 			return nil
 		})
 	})
@@ -1908,599 +5032,690 @@ func (instance *Host) RelaxedDeleteHost(ctx context.Context) (xerr fail.Error) {
 		return xerr
 	}
 
-	var (
-		single         bool
-		singleSubnetID string
-	)
+	defer func() {
+		if xerr != nil {
+			derr := rs.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+				return props.Alter(subnetproperty.HostsV1, func(clonable data.Clonable) fail.Error {
+					subnetHostsV1, ok := clonable.(*propertiesv1.SubnetHosts)
+					if !ok {
+						return fail.InconsistentError("'*propertiesv1.SubnetHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+					}
+
+					delete(subnetHostsV1.ByID, hostID)
+					delete(subnetHostsV1.ByName, hostName)
+					return nil
+				})
+			})
+			if derr != nil {
+				_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on %s, failed to remove Host '%s' from Subnet '%s' metadata", ActionFromError(xerr), hostName, as.Name))
+			}
+		}
+	}()
+
 	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		// If Host has mounted shares, unmounts them before anything else
-		var mounts []*propertiesv1.HostShare
-		innerXErr := props.Inspect(hostproperty.MountsV1, func(clonable data.Clonable) fail.Error {
-			hostMountsV1, ok := clonable.(*propertiesv1.HostMounts)
+		innerXErr := props.Alter(hostproperty.SecurityGroupsV1, func(clonable data.Clonable) (innerXErr fail.Error) {
+			hsgV1, ok := clonable.(*propertiesv1.HostSecurityGroups)
 			if !ok {
-				return fail.InconsistentError("'*propertiesv1.HostMounts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				return fail.InconsistentError("'*propertiesv1.HostSecurityGroups' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			for _, i := range hostMountsV1.RemoteMountsByPath {
-				if task.Aborted() {
-					return fail.AbortedError(nil, "aborted")
-				}
-
-				// Retrieve v data
-				shareInstance, loopErr := LoadShare(svc, i.ShareID)
-				if loopErr != nil {
-					return loopErr
-				}
-
-				//goland:noinspection ALL
-				defer func(item resources.Share) {
-					item.Released()
-				}(shareInstance)
+			if as.InternalSecurityGroupID == "" {
+				return nil
+			}
+			if _, ok := hsgV1.ByID[as.InternalSecurityGroupID]; ok {
+				return nil
+			}
 
-				// Retrieve data about the server serving the v
-				rhServer, loopErr := shareInstance.GetServer()
-				if loopErr != nil {
-					return loopErr
-				}
+			lansg, innerXErr := LoadSecurityGroup(svc, as.InternalSecurityGroupID)
+			if innerXErr != nil {
+				return fail.Wrap(innerXErr, "failed to query Subnet '%s' Security Group '%s'", as.Name, as.InternalSecurityGroupID)
+			}
+			defer lansg.Released()
 
-				// Retrieve data about v from its server
-				item, loopErr := rhServer.GetShare(i.ShareID)
-				if loopErr != nil {
-					return loopErr
-				}
+			if innerXErr = lansg.BindToHost(ctx, instance, resources.SecurityGroupEnable, resources.MarkSecurityGroupAsSupplemental); innerXErr != nil {
+				return fail.Wrap(innerXErr, "failed to apply Subnet '%s' internal Security Group '%s' on Host '%s'", as.Name, lansg.GetName(), hostName)
+			}
 
-				mounts = append(mounts, item)
+			item := &propertiesv1.SecurityGroupBond{
+				ID:         lansg.GetID(),
+				Name:       lansg.GetName(),
+				Disabled:   false,
+				FromSubnet: true,
 			}
+			hsgV1.ByID[item.ID] = item
+			hsgV1.ByName[item.Name] = item.ID
 			return nil
 		})
 		if innerXErr != nil {
 			return innerXErr
 		}
 
-		// Unmounts tier shares mounted on Host (done outside the previous Host.properties.Reading() section, because
-		// Unmount() have to lock for write, and won't succeed while Host.properties.Reading() is running,
-		// leading to a deadlock)
-		for _, v := range mounts {
-			if task.Aborted() {
-				return fail.AbortedError(nil, "aborted")
+		return props.Alter(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+			hnV2, ok := clonable.(*propertiesv2.HostNetworking)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			shareInstance, loopErr := LoadShare(svc, v.ID)
-			if loopErr != nil {
-				return loopErr
+			hnV2.SubnetsByID[as.ID] = as.Name
+			hnV2.SubnetsByName[as.Name] = as.ID
+			hnV2.IPv4Addresses[as.ID] = ip
+			if asDefault {
+				hnV2.DefaultSubnetID = as.ID
 			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
 
-			//goland:noinspection ALL
-			defer func(item resources.Share) {
-				item.Released()
-			}(shareInstance)
-
-			loopErr = shareInstance.Unmount(ctx, instance)
-			if loopErr != nil {
-				return loopErr
-			}
-		}
+	return instance.updateCachedInformation()
+}
 
-		// if Host exports shares, delete them
-		for _, v := range shares {
-			if task.Aborted() {
-				return fail.AbortedError(nil, "aborted")
-			}
+// DetachFromSubnet disconnects the Host from a Subnet, removing the corresponding network interface on provider side
+// The default Subnet of the Host cannot be detached; another Subnet has to be attached as default first
+func (instance *Host) DetachFromSubnet(ctx context.Context, subnetID string) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
 
-			shareInstance, loopErr := LoadShare(svc, v.Name)
-			if loopErr != nil {
-				return loopErr
-			}
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if subnetID == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("subnetID")
+	}
 
-			loopErr = shareInstance.Delete(ctx)
-			if loopErr != nil {
-				return loopErr
-			}
-		}
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
 
-		// Walk through property propertiesv1.HostNetworking to remove the reference to the Host in Subnets
-		innerXErr = props.Inspect(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
-			hostNetworkV2, ok := clonable.(*propertiesv2.HostNetworking)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
-			hostID := instance.GetID()
-			// hostName := instance.GetName()
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
 
-			single = hostNetworkV2.Single
-			if single {
-				singleSubnetID = hostNetworkV2.DefaultSubnetID
-			}
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(%s)", subnetID).WithStopwatch().Entering()
+	defer tracer.Exiting()
 
-			if !single {
-				var errors []error
-				for k := range hostNetworkV2.SubnetsByID {
-					if !hostNetworkV2.IsGateway && k != hostNetworkV2.DefaultSubnetID {
-						subnetInstance, loopErr := LoadSubnet(svc, "", k)
-						if loopErr == nil {
-							//goland:noinspection ALL
-							defer func(item resources.Subnet) {
-								item.Released()
-							}(subnetInstance)
+	// make sure no other operation on the same Host runs in parallel in the daemon
+	unlockHost, xerr := lockHost(ctx, instance)
+	if xerr != nil {
+		return xerr
+	}
+	defer unlockHost()
 
-							loopErr = subnetInstance.AbandonHost(ctx, hostID)
-						}
-						if loopErr != nil {
-							logrus.Errorf(loopErr.Error())
-							errors = append(errors, loopErr)
-							continue
-						}
-					}
-				}
-				if len(errors) > 0 {
-					return fail.Wrap(fail.NewErrorList(errors), "failed to update metadata for Subnets of Host")
-				}
-			}
-			return nil
-		})
-		if innerXErr != nil {
-			return innerXErr
-		}
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
 
-		// Unbind Security Groups from Host
-		innerXErr = props.Alter(hostproperty.SecurityGroupsV1, func(clonable data.Clonable) fail.Error {
-			hsgV1, ok := clonable.(*propertiesv1.HostSecurityGroups)
+	hostName := instance.GetName()
+	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+			hnV2, ok := clonable.(*propertiesv2.HostNetworking)
 			if !ok {
-				return fail.InconsistentError("'*propertiesv1.HostSecurityGroups' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			// Unbind Security Groups from Host
-			var errors []error
-			for _, v := range hsgV1.ByID {
-				rsg, derr := LoadSecurityGroup(svc, v.ID)
-				if derr == nil {
-					//goland:noinspection ALL
-					defer func(sgInstance resources.SecurityGroup) {
-						sgInstance.Released()
-					}(rsg)
-
-					derr = rsg.UnbindFromHost(ctx, instance)
-				}
-				if derr != nil {
-					switch derr.(type) {
-					case *fail.ErrNotFound:
-						// Consider that a Security Group that cannot be loaded or is not bound as a success
-					default:
-						errors = append(errors, derr)
-					}
-				}
+			if _, ok := hnV2.SubnetsByID[subnetID]; !ok {
+				return fail.NotFoundError("Host '%s' is not attached to Subnet '%s'", hostName, subnetID)
 			}
-			if len(errors) > 0 {
-				return fail.Wrap(fail.NewErrorList(errors), "failed to unbind some Security Groups")
+			if hnV2.DefaultSubnetID == subnetID {
+				return fail.InvalidRequestError("cannot detach Host '%s' from its default Subnet '%s'; attach it to another Subnet as default first", hostName, subnetID)
 			}
-
 			return nil
 		})
-		if innerXErr != nil {
-			return fail.Wrap(innerXErr, "failed to unbind Security Groups from Host")
-		}
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
 
-		// Delete Host
-		waitForDeletion := true
-		innerXErr = retry.WhileUnsuccessfulDelay1Second(
-			func() error {
-				if derr := svc.DeleteHost(instance.GetID()); derr != nil {
-					switch derr.(type) {
-					case *fail.ErrNotFound:
-						// A Host not found is considered as a successful deletion
-						logrus.Tracef("Host not found, deletion considered as a success")
-					default:
-						return fail.Wrap(derr, "cannot delete Host")
-					}
-					waitForDeletion = false
-				}
-				return nil
-			},
-			time.Minute*5, // FIXME: hardcoded timeout
-		)
-		if innerXErr != nil {
-			return innerXErr
-		}
+	svc := instance.GetService()
+	rs, xerr := LoadSubnet(svc, "", subnetID)
+	if xerr != nil {
+		return xerr
+	}
 
-		// wait for effective Host deletion
-		if waitForDeletion {
-			innerXErr = retry.WhileUnsuccessfulDelay5SecondsTimeout(
-				func() error {
-					state, stateErr := svc.GetHostState(instance.GetID())
-					if stateErr != nil {
-						switch stateErr.(type) {
-						case *fail.ErrNotFound:
-							// If Host is not found anymore, consider this as a success
-							return nil
-						default:
-							return stateErr
-						}
-					}
-					if state == hoststate.Error {
-						return fail.NotAvailableError("Host is in state Error")
-					}
-					return nil
-				},
-				time.Minute*2, // FIXME: hardcoded duration
-			)
-			if innerXErr != nil {
-				switch innerXErr.(type) {
-				case *retry.ErrStopRetry:
-					innerXErr = fail.ConvertError(innerXErr.Cause())
-				default:
-				}
+	var as *abstract.Subnet
+	xerr = rs.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+		var ok bool
+		as, ok = clonable.(*abstract.Subnet)
+		if !ok {
+			return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+		return nil
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// unbind the Security Group inherited from the Subnet, then remove the network interface
+	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(hostproperty.SecurityGroupsV1, func(clonable data.Clonable) fail.Error {
+			hsgV1, ok := clonable.(*propertiesv1.HostSecurityGroups)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostSecurityGroups' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			if as.InternalSecurityGroupID == "" {
+				return nil
+			}
+			item, ok := hsgV1.ByID[as.InternalSecurityGroupID]
+			if !ok || !item.FromSubnet {
+				return nil
 			}
+
+			lansg, innerXErr := LoadSecurityGroup(svc, as.InternalSecurityGroupID)
 			if innerXErr != nil {
 				switch innerXErr.(type) {
 				case *fail.ErrNotFound:
-					// continue
+					// Security Group already gone, consider it unbound
 				default:
 					return innerXErr
 				}
+			} else {
+				defer lansg.Released()
+
+				if innerXErr = lansg.UnbindFromHost(ctx, instance, data.NewImmutableKeyValue("Force", true)); innerXErr != nil {
+					return fail.Wrap(innerXErr, "failed to unbind Subnet '%s' internal Security Group '%s' from Host '%s'", as.Name, item.Name, hostName)
+				}
 			}
-		}
 
-		return nil
+			delete(hsgV1.ByID, item.ID)
+			delete(hsgV1.ByName, item.Name)
+			return nil
+		})
 	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return xerr
 	}
 
-	if single {
-		// delete its dedicated Subnet
-		singleSubnetInstance, xerr := LoadSubnet(svc, "", singleSubnetID)
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
+	xerr = svc.DetachHostFromSubnet(instance.GetID(), as)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotFound:
+			// No more interface on provider side, consider the Host detached
+		case *fail.ErrNotImplemented:
 			return xerr
+		default:
+			return surfaceProviderError(xerr, "failed to detach Host '%s' from Subnet '%s'", hostName, as.Name)
 		}
+	}
 
-		xerr = singleSubnetInstance.Delete(ctx)
-		xerr = debug.InjectPlannedFail(xerr)
-		if xerr != nil {
-			return xerr
-		}
+	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+			hnV2, ok := clonable.(*propertiesv2.HostNetworking)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			delete(hnV2.SubnetsByName, hnV2.SubnetsByID[as.ID])
+			delete(hnV2.SubnetsByID, as.ID)
+			delete(hnV2.IPv4Addresses, as.ID)
+			delete(hnV2.IPv6Addresses, as.ID)
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
 	}
 
-	// Deletes metadata from Object Storage
-	xerr = instance.MetadataCore.Delete()
+	hostID := instance.GetID()
+	xerr = rs.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(subnetproperty.HostsV1, func(clonable data.Clonable) fail.Error {
+			subnetHostsV1, ok := clonable.(*propertiesv1.SubnetHosts)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.SubnetHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			delete(subnetHostsV1.ByID, hostID)
+			delete(subnetHostsV1.ByName, hostName)
+			return nil
+		})
+	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		// If entry not found, considered as a success
-		if _, ok := xerr.(*fail.ErrNotFound); !ok {
-			return xerr
-		}
-		logrus.Tracef("core instance not found, deletion considered as a success")
+		return xerr
 	}
 
-	return nil
+	return instance.updateCachedInformation()
 }
 
-// GetSSHConfig loads SSH configuration for Host from metadata
-//
-// FIXME: verify that system.SSHConfig carries data about secondary getGateway
-func (instance *Host) GetSSHConfig() (_ *system.SSHConfig, xerr fail.Error) {
+// GetPublicIP returns the preferred public IP address of the Host: the IPv4 one if any, the IPv6 one otherwise
+func (instance *Host) GetPublicIP() (ip string, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
+	ip = ""
 	if instance == nil || instance.IsNull() {
-		return nil, fail.InvalidInstanceError()
+		return ip, fail.InvalidInstanceError()
 	}
 
-	instance.lock.RLock()
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return ip, xerr
+	}
 	defer instance.lock.RUnlock()
 
-	return instance.sshProfile, nil
+	if ip = instance.publicIP; ip == "" {
+		return ip, fail.NotFoundError("no public IP associated with Host '%s'", instance.GetName())
+	}
+	return ip, nil
 }
 
-// Run tries to execute command 'cmd' on the Host
-func (instance *Host) Run(ctx context.Context, cmd string, outs outputs.Enum, connectionTimeout, executionTimeout time.Duration) (_ int, _ string, _ string, xerr fail.Error) {
+// GetPublicIPv4 returns the public IPv4 address of the Host
+// Returns *fail.ErrNotFound if the Host has no public IPv4 address
+func (instance *Host) GetPublicIPv4() (ip string, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
 	if instance == nil || instance.IsNull() {
-		return 0, "", "", fail.InvalidInstanceError()
-	}
-	if ctx == nil {
-		return -1, "", "", fail.InvalidParameterCannotBeNilError("ctx")
-	}
-	if cmd == "" {
-		return -1, "", "", fail.InvalidParameterError("cmd", "cannot be empty string")
+		return "", fail.InvalidInstanceError()
 	}
 
-	task, xerr := concurrency.TaskFromContext(ctx)
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return -1, "", "", xerr
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return "", xerr
 	}
+	defer instance.lock.RUnlock()
 
-	if task.Aborted() {
-		return 0, "", "", fail.AbortedError(nil, "aborted")
+	if ip = instance.publicIPv4; ip == "" {
+		return "", fail.NotFoundError("no public IPv4 address associated with Host '%s'", instance.GetName())
 	}
+	return ip, nil
+}
 
-	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(cmd='%s', outs=%s)", outs.String()).Entering()
-	defer tracer.Exiting()
+// GetPublicIPv6 returns the public IPv6 address of the Host
+// Returns *fail.ErrNotFound if the Host has no public IPv6 address
+func (instance *Host) GetPublicIPv6() (ip string, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
 
-	instance.lock.RLock()
+	if instance == nil || instance.IsNull() {
+		return "", fail.InvalidInstanceError()
+	}
+
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return "", xerr
+	}
 	defer instance.lock.RUnlock()
 
-	return instance.UnsafeRun(ctx, cmd, outs, connectionTimeout, executionTimeout)
+	if ip = instance.publicIPv6; ip == "" {
+		return "", fail.NotFoundError("no public IPv6 address associated with Host '%s'", instance.GetName())
+	}
+	return ip, nil
 }
 
-// Pull downloads a file from Host
-func (instance *Host) Pull(ctx context.Context, target, source string, timeout time.Duration) (_ int, _ string, _ string, xerr fail.Error) {
+// GetPrivateIP returns the private IP of the Host on its default Networking
+func (instance *Host) GetPrivateIP() (_ string, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
 	if instance == nil || instance.IsNull() {
-		return 0, "", "", fail.InvalidInstanceError()
-	}
-	if ctx == nil {
-		return 0, "", "", fail.InvalidParameterCannotBeNilError("ctx")
+		return "", fail.InvalidInstanceError()
 	}
-	if target == "" {
-		return 0, "", "", fail.InvalidParameterCannotBeEmptyStringError("target")
+
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return "", xerr
 	}
-	if source == "" {
-		return 0, "", "", fail.InvalidParameterCannotBeEmptyStringError("source")
+	defer instance.lock.RUnlock()
+
+	return instance.privateIP, nil
+}
+
+// GetPrivateIPs returns the private IP addresses of the Host on all its Subnets (including the ones of additional
+// interfaces), indexed by Subnet ID
+func (instance *Host) GetPrivateIPs() (_ map[string]string, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
 	}
 
-	task, xerr := concurrency.TaskFromContext(ctx)
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return -1, "", "", xerr
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return nil, xerr
 	}
+	defer instance.lock.RUnlock()
 
-	if task.Aborted() {
-		return 0, "", "", fail.AbortedError(nil, "aborted")
+	out := make(map[string]string, len(instance.privateIPs))
+	for k, v := range instance.privateIPs {
+		out[k] = v
 	}
+	return out, nil
+}
 
-	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(target=%s,source=%s)", target, source).Entering()
-	defer tracer.Exiting()
+// GetPrivateIPOnSubnet returns the private IP of the Host on its default Subnet
+func (instance *Host) GetPrivateIPOnSubnet(subnetID string) (ip string, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
 
-	instance.lock.RLock()
+	ip = ""
+	if instance == nil || instance.IsNull() {
+		return ip, fail.InvalidInstanceError()
+	}
+	if subnetID = strings.TrimSpace(subnetID); subnetID == "" {
+		return ip, fail.InvalidParameterError("subnetID", "cannot be empty string")
+	}
+
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return ip, xerr
+	}
 	defer instance.lock.RUnlock()
 
-	// FIXME: reintroduce timeout on ssh.
-	// if timeout < temporal.GetHostTimeout() {
-	// 	timeout = temporal.GetHostTimeout()
-	// }
-	var (
-		retcode        int
-		stdout, stderr string
-	)
-	xerr = retry.WhileUnsuccessfulDelay5Seconds(
-		func() error {
-			var innerXErr fail.Error
-			if retcode, stdout, stderr, innerXErr = instance.sshProfile.Copy(ctx, target, source, false); innerXErr != nil {
-				return innerXErr
+	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+			hostNetworkV2, ok := clonable.(*propertiesv2.HostNetworking)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
-			switch retcode { //nolint
-			case 1: // FIXME: Check errorcodes
-				if strings.Contains(stdout, "lost connection") {
-					return fail.NewError("lost connection, retrying...")
-				}
+			if ip, ok = hostNetworkV2.IPv4Addresses[subnetID]; !ok {
+				return fail.InvalidRequestError("Host '%s' does not have an IP address on subnet '%s'", instance.GetName(), subnetID)
 			}
 			return nil
-		},
-		2*timeout,
-	)
-	return retcode, stdout, stderr, xerr
+		})
+	})
+	return ip, xerr
 }
 
-// Push uploads a file to Host
-func (instance *Host) Push(ctx context.Context, source, target, owner, mode string, timeout time.Duration) (_ int, _ string, _ string, xerr fail.Error) {
+// GetAccessIP returns the IP to reach the Host
+func (instance *Host) GetAccessIP() (ip string, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
+	ip = ""
 	if instance == nil || instance.IsNull() {
-		return 0, "", "", fail.InvalidInstanceError()
-	}
-	if ctx == nil {
-		return 0, "", "", fail.InvalidParameterCannotBeNilError("ctx")
-	}
-
-	task, xerr := concurrency.TaskFromContext(ctx)
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return -1, "", "", xerr
+		return ip, fail.InvalidInstanceError()
 	}
 
-	if task.Aborted() {
-		return 0, "", "", fail.AbortedError(nil, "aborted")
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return ip, xerr
 	}
-
-	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(source=%s, target=%s, owner=%s, mode=%s)", source, target, owner, mode).Entering()
-	defer tracer.Exiting()
-
-	instance.lock.RLock()
 	defer instance.lock.RUnlock()
 
-	return instance.UnsafePush(ctx, source, target, owner, mode, timeout)
+	return instance.accessIP, nil
 }
 
-// GetShare returns a clone of the propertiesv1.HostShare corresponding to share 'shareRef'
-func (instance *Host) GetShare(shareRef string) (_ *propertiesv1.HostShare, xerr fail.Error) {
+// GetShares returns the information about the shares hosted by the Host
+func (instance *Host) GetShares() (shares *propertiesv1.HostShares, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
+	shares = &propertiesv1.HostShares{}
 	if instance == nil || instance.IsNull() {
-		return nil, fail.InvalidInstanceError()
+		return shares, fail.InvalidInstanceError()
 	}
 
-	instance.lock.RLock()
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return shares, xerr
+	}
 	defer instance.lock.RUnlock()
 
-	var (
-		hostShare *propertiesv1.HostShare
-		// ok        bool
-	)
-	err := instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
 		return props.Inspect(hostproperty.SharesV1, func(clonable data.Clonable) fail.Error {
-			sharesV1, ok := clonable.(*propertiesv1.HostShares)
+			hostSharesV1, ok := clonable.(*propertiesv1.HostShares)
 			if !ok {
 				return fail.InconsistentError("'*propertiesv1.HostShares' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			if item, ok := sharesV1.ByID[shareRef]; ok {
-				hostShare = item.Clone().(*propertiesv1.HostShare)
-				return nil
-			}
-			if item, ok := sharesV1.ByName[shareRef]; ok {
-				hostShare = sharesV1.ByID[item].Clone().(*propertiesv1.HostShare)
-				return nil
-			}
-			return fail.NotFoundError("share '%s' not found in server '%s' metadata", shareRef, instance.GetName())
-		})
-	})
-	err = debug.InjectPlannedFail(err)
-	if err != nil {
-		return nil, err
-	}
-
-	return hostShare, nil
+			shares = hostSharesV1
+			return nil
+		})
+	})
+	return shares, xerr
 }
 
-// GetVolumes returns information about volumes attached to the Host
-func (instance *Host) GetVolumes() (_ *propertiesv1.HostVolumes, xerr fail.Error) {
+// GetMounts returns the information abouts the mounts of the Host
+func (instance *Host) GetMounts() (mounts *propertiesv1.HostMounts, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
+	mounts = nil
 	if instance == nil || instance.IsNull() {
-		return nil, fail.InvalidInstanceError()
+		return mounts, fail.InvalidInstanceError()
 	}
 
-	instance.lock.RLock()
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return mounts, xerr
+	}
 	defer instance.lock.RUnlock()
 
-	return instance.UnsafeGetVolumes()
+	return instance.UnsafeGetMounts()
 }
 
-// Start starts the Host
-func (instance *Host) Start(ctx context.Context) (xerr fail.Error) {
+const (
+	inventoryKernelMarker   = "--- safescale:inventory:kernel ---"
+	inventoryPackagesMarker = "--- safescale:inventory:packages ---"
+)
+
+// inventoryPackageCommand returns the name of the package manager and the command listing the installed packages (one
+// "<name>\t<version>" per line), chosen from the install methods of the Host in order of preference
+// Returns empty strings if no install method corresponds to a supported package manager
+func inventoryPackageCommand(methods map[uint8]installmethod.Enum) (string, string) {
+	for i := uint8(1); i <= uint8(len(methods)); i++ {
+		switch methods[i] {
+		case installmethod.Apt:
+			return "dpkg", `dpkg-query -W -f='${Package}\t${Version}\n'`
+		case installmethod.Yum, installmethod.Dnf:
+			return "rpm", `rpm -qa --queryformat '%{NAME}\t%{VERSION}-%{RELEASE}\n'`
+		}
+	}
+	return "", ""
+}
+
+// inventoryCommand returns the command printing the OS release, the kernel release and, if 'packageCommand' is not
+// empty, the installed packages, in sections separated by markers
+func inventoryCommand(packageCommand string) string {
+	cmd := "cat /etc/os-release && echo '" + inventoryKernelMarker + "' && uname -r"
+	if packageCommand != "" {
+		cmd += " && echo '" + inventoryPackagesMarker + "' && " + packageCommand
+	}
+	return cmd
+}
+
+// parseHostInventory builds the inventory from the output of the command returned by inventoryCommand()
+// Several versions of the same package (kernels for example) are separated by a comma
+func parseHostInventory(output, packageManager string) (*propertiesv1.HostInventory, fail.Error) {
+	inventory := propertiesv1.NewHostInventory()
+	section := "os-release"
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch line {
+		case inventoryKernelMarker:
+			section = "kernel"
+			continue
+		case inventoryPackagesMarker:
+			section = "packages"
+			inventory.PackageManager = packageManager
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		switch section {
+		case "os-release":
+			parts := strings.SplitN(line, "=", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			value := strings.TrimSpace(parts[1])
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				value = unquoted
+			} else {
+				value = strings.Trim(value, "'")
+			}
+			switch strings.TrimSpace(parts[0]) {
+			case "ID":
+				inventory.OSID = value
+			case "VERSION_ID":
+				inventory.OSVersion = value
+			case "PRETTY_NAME":
+				inventory.OSName = value
+			}
+		case "kernel":
+			inventory.Kernel = strings.TrimSpace(line)
+		case "packages":
+			parts := strings.SplitN(line, "\t", 2)
+			if len(parts) != 2 || parts[0] == "" {
+				continue
+			}
+			if current, ok := inventory.Packages[parts[0]]; ok && current != parts[1] {
+				inventory.Packages[parts[0]] = current + "," + parts[1]
+			} else {
+				inventory.Packages[parts[0]] = parts[1]
+			}
+		}
+	}
+
+	if inventory.OSID == "" || inventory.Kernel == "" {
+		return nil, fail.SyntaxError("failed to find OS release and kernel in inventory output")
+	}
+	return inventory, nil
+}
+
+// CollectInventory collects the release of the operating system, the release of the kernel and the versions of the
+// installed packages of the Host, with the package manager corresponding to its install methods, and records them
+// in property InventoryV1
+func (instance *Host) CollectInventory(ctx context.Context) (_ *propertiesv1.HostInventory, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
 	if instance == nil || instance.IsNull() {
-		return fail.InvalidInstanceError()
+		return nil, fail.InvalidInstanceError()
 	}
 	if ctx == nil {
-		return fail.InvalidParameterCannotBeNilError("ctx")
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
 	}
 
 	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return xerr
+		return nil, xerr
 	}
 
 	if task.Aborted() {
-		return fail.AbortedError(nil, "aborted")
+		return nil, fail.AbortedError(nil, "aborted")
 	}
 
-	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host")).WithStopwatch().Entering()
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "").WithStopwatch().Entering()
 	defer tracer.Exiting()
 
+	// make sure no other operation on the same Host runs in parallel in the daemon
+	unlockHost, xerr := lockHost(ctx, instance)
+	if xerr != nil {
+		return nil, xerr
+	}
+	defer unlockHost()
+
 	instance.lock.Lock()
 	defer instance.lock.Unlock()
 
+	defer recordOperation(instance, hostproperty.LastOperationV1, "collect-inventory")(&xerr)
+
 	hostName := instance.GetName()
-	hostID := instance.GetID()
+	packageManager, packageCommand := inventoryPackageCommand(instance.installMethods)
+	if packageManager == "" {
+		logrus.Warnf("no supported package manager found for Host '%s', inventory will not contain packages", hostName)
+	}
 
-	svc := instance.GetService()
-	xerr = svc.StartHost(hostID)
+	retcode, stdout, stderr, xerr := instance.UnsafeRun(ctx, inventoryCommand(packageCommand), outputs.COLLECT, temporal.GetConnectSSHTimeout(), temporal.GetExecutionTimeout())
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return xerr
+		return nil, fail.Wrap(xerr, "failed to collect inventory of Host '%s'", hostName)
+	}
+	if retcode != 0 {
+		return nil, fail.ExecutionError(nil, "failed to collect inventory of Host '%s' (retcode=%d): %s", hostName, retcode, stderr)
 	}
 
-	xerr = retry.WhileUnsuccessfulDelay5Seconds(
-		func() error {
-			if task.Aborted() {
-				return fail.AbortedError(nil, "aborted")
+	inventory, xerr := parseHostInventory(stdout, packageManager)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, fail.Wrap(xerr, "failed to parse inventory of Host '%s'", hostName)
+	}
+	inventory.CollectedAt = time.Now()
+
+	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(hostproperty.InventoryV1, func(clonable data.Clonable) fail.Error {
+			hostInventoryV1, ok := clonable.(*propertiesv1.HostInventory)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostInventory' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			return svc.WaitHostState(hostID, hoststate.Started, temporal.GetHostTimeout())
-		},
-		5*time.Minute,
-	)
+			_ = hostInventoryV1.Replace(inventory)
+			return nil
+		})
+	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		switch xerr.(type) {
-		case *fail.ErrAborted:
-			if cerr := fail.ConvertError(xerr.Cause()); cerr != nil {
-				return cerr
-			}
-			return xerr
-		case *retry.ErrTimeout:
-			return fail.Wrap(xerr, "timeout waiting Host '%s' to be started", hostName)
-		default:
-			return xerr
-		}
+		return nil, xerr
 	}
-	return nil
+
+	logrus.Debugf("inventory of Host '%s' collected: %s %s, kernel %s, %d packages", hostName, inventory.OSID, inventory.OSVersion, inventory.Kernel, len(inventory.Packages))
+	return inventory, nil
 }
 
-// Stop stops the Host
-func (instance *Host) Stop(ctx context.Context) (xerr fail.Error) {
+// GetInventory returns the inventory of the Host recorded by the last call to CollectInventory()
+// Returns *fail.ErrNotFound if the inventory has never been collected
+func (instance *Host) GetInventory() (inventory *propertiesv1.HostInventory, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
 	if instance == nil || instance.IsNull() {
-		return fail.InvalidInstanceError()
+		return nil, fail.InvalidInstanceError()
 	}
-	if ctx == nil {
-		return fail.InvalidParameterCannotBeNilError("ctx")
+
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return nil, xerr
 	}
+	defer instance.lock.RUnlock()
 
-	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(hostproperty.InventoryV1, func(clonable data.Clonable) fail.Error {
+			hostInventoryV1, ok := clonable.(*propertiesv1.HostInventory)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostInventory' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			if hostInventoryV1.CollectedAt.IsZero() {
+				return fail.NotFoundError("inventory of Host '%s' has never been collected", instance.GetName())
+			}
+			inventory = hostInventoryV1.Clone().(*propertiesv1.HostInventory)
+			return nil
+		})
+	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return xerr
-	}
-
-	if task.Aborted() {
-		return fail.AbortedError(nil, "aborted")
+		return nil, xerr
 	}
+	return inventory, nil
+}
 
-	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host")).WithStopwatch().Entering()
-	defer tracer.Exiting()
-
-	instance.lock.Lock()
-	defer instance.lock.Unlock()
+// IsClusterMember returns true if the Host is member of a cluster
+func (instance *Host) IsClusterMember() (yes bool, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
 
-	hostName := instance.GetName()
-	hostID := instance.GetID()
+	yes = false
+	if instance == nil || instance.IsNull() {
+		return yes, fail.InvalidInstanceError()
+	}
 
-	svc := instance.GetService()
-	xerr = svc.StopHost(hostID)
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		return xerr
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return yes, xerr
 	}
+	defer instance.lock.RUnlock()
 
-	xerr = retry.WhileUnsuccessfulDelay5Seconds(
-		func() error {
-			if task.Aborted() {
-				return fail.AbortedError(nil, "aborted")
+	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(hostproperty.ClusterMembershipV1, func(clonable data.Clonable) fail.Error {
+			hostClusterMembershipV1, ok := clonable.(*propertiesv1.HostClusterMembership)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostClusterMembership' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			return svc.WaitHostState(hostID, hoststate.Stopped, temporal.GetHostTimeout())
-		},
-		// FIXME: hardcoded value
-		5*time.Minute,
-	)
-	xerr = debug.InjectPlannedFail(xerr)
-	if xerr != nil {
-		switch xerr.(type) {
-		case *fail.ErrAborted:
-			if cerr := fail.ConvertError(xerr.Cause()); cerr != nil {
-				return cerr
-			}
-			return xerr
-		case *retry.ErrTimeout:
-			return fail.Wrap(xerr, "timeout waiting Host '%s' to be stopped", hostName)
-		default:
-			return xerr
-		}
-	}
-	return nil
+			yes = hostClusterMembershipV1.Cluster != ""
+			return nil
+		})
+	})
+	return yes, xerr
 }
 
-// Reboot reboots the Host
-func (instance *Host) Reboot(ctx context.Context) (xerr fail.Error) {
+// JoinCluster makes the Host join the Cluster 'clusterName' with the role 'role' (clusternodetype.Master or clusternodetype.Node)
+// The Host must be attached to the Subnet of the Cluster.
+func (instance *Host) JoinCluster(ctx context.Context, clusterName string, role clusternodetype.Enum) (xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
 	if instance == nil || instance.IsNull() {
@@ -2509,6 +5724,19 @@ func (instance *Host) Reboot(ctx context.Context) (xerr fail.Error) {
 	if ctx == nil {
 		return fail.InvalidParameterCannotBeNilError("ctx")
 	}
+	if clusterName == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("clusterName")
+	}
+
+	var nodeType string
+	switch role {
+	case clusternodetype.Master:
+		nodeType = "master"
+	case clusternodetype.Node:
+		nodeType = "node"
+	default:
+		return fail.InvalidParameterError("role", "must be Master or Node")
+	}
 
 	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
@@ -2520,183 +5748,203 @@ func (instance *Host) Reboot(ctx context.Context) (xerr fail.Error) {
 		return fail.AbortedError(nil, "aborted")
 	}
 
-	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host")).WithStopwatch().Entering()
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(%s, %d)", clusterName, role).Entering()
 	defer tracer.Exiting()
 
-	xerr = instance.Stop(ctx)
+	isMember, xerr := instance.IsClusterMember()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return xerr
 	}
-	return instance.Start(ctx)
-}
-
-// Resize ...
-// not yet implemented
-func (instance *Host) Resize(ctx context.Context, hostSize abstract.HostSizingRequirements) (xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
-
-	if instance == nil || instance.IsNull() {
-		return fail.InvalidInstanceError()
-	}
-	if ctx == nil {
-		return fail.InvalidParameterCannotBeNilError("ctx")
+	if isMember {
+		return fail.NotAvailableError("Host '%s' is already member of a Cluster", instance.GetName())
 	}
 
-	task, xerr := concurrency.TaskFromContext(ctx)
+	svc := instance.GetService()
+	clusterInstance, xerr := LoadCluster(svc, clusterName)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return xerr
 	}
+	defer clusterInstance.Released()
 
-	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host")).WithStopwatch().Entering()
-	defer tracer.Exiting()
-
-	return fail.NotImplementedError("Host.Resize() not yet implemented")
-}
-
-// GetPublicIP returns the public IP address of the Host
-func (instance *Host) GetPublicIP() (ip string, xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
-
-	ip = ""
-	if instance == nil || instance.IsNull() {
-		return ip, fail.InvalidInstanceError()
+	// Host must be attached to the Subnet of the Cluster
+	netCfg, xerr := clusterInstance.GetNetworkConfig()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
 	}
 
-	instance.lock.RLock()
-	defer instance.lock.RUnlock()
+	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+			hnV2, ok := clonable.(*propertiesv2.HostNetworking)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
 
-	if ip = instance.publicIP; ip == "" {
-		return ip, fail.NotFoundError("no public IP associated with Host '%s'", instance.GetName())
+			if hnV2.IsGateway {
+				return fail.InvalidRequestError("Host '%s' is a gateway and cannot join a Cluster", instance.GetName())
+			}
+			if _, ok := hnV2.SubnetsByID[netCfg.SubnetID]; !ok {
+				return fail.InvalidRequestError("Host '%s' is not attached to the Subnet of Cluster '%s'", instance.GetName(), clusterName)
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
 	}
-	return ip, nil
-}
-
-// GetPrivateIP returns the private IP of the Host on its default Networking
-func (instance *Host) GetPrivateIP() (_ string, xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
 
-	if instance == nil || instance.IsNull() {
-		return "", fail.InvalidInstanceError()
+	// Registers Host in Cluster nodes
+	castedCluster := clusterInstance.(*Cluster)
+	_, xerr = castedCluster.registerHost(instance, role)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
 	}
+	defer func() {
+		if xerr != nil {
+			if derr := castedCluster.unregisterHost(instance); derr != nil {
+				_ = xerr.AddConsequence(fail.Wrap(derr, "cleaning up on %s, failed to unregister Host '%s' from Cluster '%s'", ActionFromError(xerr), instance.GetName(), clusterName))
+			}
+		}
+	}()
 
-	instance.lock.RLock()
-	defer instance.lock.RUnlock()
-
-	return instance.privateIP, nil
-}
-
-// GetPrivateIPOnSubnet returns the private IP of the Host on its default Subnet
-func (instance *Host) GetPrivateIPOnSubnet(subnetID string) (ip string, xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
-
-	ip = ""
-	if instance == nil || instance.IsNull() {
-		return ip, fail.InvalidInstanceError()
+	// Executes the join specific to the flavor of the Cluster
+	switch role {
+	case clusternodetype.Master:
+		if castedCluster.makers.JoinMasterToCluster != nil {
+			xerr = castedCluster.makers.JoinMasterToCluster(clusterInstance, instance)
+		}
+	case clusternodetype.Node:
+		if castedCluster.makers.JoinNodeToCluster != nil {
+			xerr = castedCluster.makers.JoinNodeToCluster(clusterInstance, instance)
+		}
 	}
-	if subnetID = strings.TrimSpace(subnetID); subnetID == "" {
-		return ip, fail.InvalidParameterError("subnetID", "cannot be empty string")
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return fail.Wrap(xerr, "failed to join Host '%s' to Cluster '%s'", instance.GetName(), clusterName)
 	}
 
-	instance.lock.RLock()
-	defer instance.lock.RUnlock()
-
-	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Inspect(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
-			hostNetworkV2, ok := clonable.(*propertiesv2.HostNetworking)
+	// Records the membership in Host metadata
+	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(hostproperty.ClusterMembershipV1, func(clonable data.Clonable) fail.Error {
+			hostClusterMembershipV1, ok := clonable.(*propertiesv1.HostClusterMembership)
 			if !ok {
-				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
-			if ip, ok = hostNetworkV2.IPv4Addresses[subnetID]; !ok {
-				return fail.InvalidRequestError("Host '%s' does not have an IP address on subnet '%s'", instance.GetName(), subnetID)
+				return fail.InconsistentError("'*propertiesv1.HostClusterMembership' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
+
+			hostClusterMembershipV1.Cluster = clusterName
+			hostClusterMembershipV1.Type = nodeType
 			return nil
 		})
 	})
-	return ip, xerr
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	return nil
 }
 
-// GetAccessIP returns the IP to reach the Host
-func (instance *Host) GetAccessIP() (ip string, xerr fail.Error) {
+// LeaveCluster makes the Host leave the Cluster it is member of, without deleting the Host
+func (instance *Host) LeaveCluster(ctx context.Context) (xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
-	ip = ""
 	if instance == nil || instance.IsNull() {
-		return ip, fail.InvalidInstanceError()
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
 	}
 
-	instance.lock.RLock()
-	defer instance.lock.RUnlock()
-
-	return instance.accessIP, nil
-}
-
-// GetShares returns the information about the shares hosted by the Host
-func (instance *Host) GetShares() (shares *propertiesv1.HostShares, xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
 
-	shares = &propertiesv1.HostShares{}
-	if instance == nil || instance.IsNull() {
-		return shares, fail.InvalidInstanceError()
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
 	}
 
-	instance.lock.RLock()
-	defer instance.lock.RUnlock()
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host")).Entering()
+	defer tracer.Exiting()
 
+	var clusterName, nodeType string
 	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Inspect(hostproperty.SharesV1, func(clonable data.Clonable) fail.Error {
-			hostSharesV1, ok := clonable.(*propertiesv1.HostShares)
+		return props.Inspect(hostproperty.ClusterMembershipV1, func(clonable data.Clonable) fail.Error {
+			hostClusterMembershipV1, ok := clonable.(*propertiesv1.HostClusterMembership)
 			if !ok {
-				return fail.InconsistentError("'*propertiesv1.HostShares' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				return fail.InconsistentError("'*propertiesv1.HostClusterMembership' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			shares = hostSharesV1
+			clusterName = hostClusterMembershipV1.Cluster
+			nodeType = hostClusterMembershipV1.Type
 			return nil
 		})
 	})
-	return shares, xerr
-}
-
-// GetMounts returns the information abouts the mounts of the Host
-func (instance *Host) GetMounts() (mounts *propertiesv1.HostMounts, xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
-
-	mounts = nil
-	if instance == nil || instance.IsNull() {
-		return mounts, fail.InvalidInstanceError()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+	if clusterName == "" {
+		return fail.InvalidRequestError("Host '%s' is not member of a Cluster", instance.GetName())
 	}
 
-	instance.lock.RLock()
-	defer instance.lock.RUnlock()
-
-	return instance.UnsafeGetMounts()
-}
-
-// IsClusterMember returns true if the Host is member of a cluster
-func (instance *Host) IsClusterMember() (yes bool, xerr fail.Error) {
-	defer fail.OnPanic(&xerr)
+	clusterInstance, xerr := LoadCluster(instance.GetService(), clusterName)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+	defer clusterInstance.Released()
 
-	yes = false
-	if instance == nil || instance.IsNull() {
-		return yes, fail.InvalidInstanceError()
+	// Executes the leave specific to the flavor of the Cluster
+	castedCluster := clusterInstance.(*Cluster)
+	switch nodeType {
+	case "master":
+		if castedCluster.makers.LeaveMasterFromCluster != nil {
+			xerr = castedCluster.makers.LeaveMasterFromCluster(clusterInstance, instance)
+		}
+	case "node":
+		if castedCluster.makers.LeaveNodeFromCluster != nil {
+			var master resources.Host
+			master, xerr = clusterInstance.FindAvailableMaster(ctx)
+			if xerr == nil {
+				xerr = castedCluster.makers.LeaveNodeFromCluster(clusterInstance, instance, master)
+			}
+		}
+	default:
+		return fail.InconsistentError("Host '%s' has an unexpected role '%s' in Cluster '%s'", instance.GetName(), nodeType, clusterName)
+	}
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return fail.Wrap(xerr, "failed to make Host '%s' leave Cluster '%s'", instance.GetName(), clusterName)
 	}
 
-	instance.lock.RLock()
-	defer instance.lock.RUnlock()
+	xerr = castedCluster.unregisterHost(instance)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotFound:
+			// Host not registered in Cluster, consider it as a success
+		default:
+			return xerr
+		}
+	}
 
-	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Inspect(hostproperty.ClusterMembershipV1, func(clonable data.Clonable) fail.Error {
+	return instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(hostproperty.ClusterMembershipV1, func(clonable data.Clonable) fail.Error {
 			hostClusterMembershipV1, ok := clonable.(*propertiesv1.HostClusterMembership)
 			if !ok {
 				return fail.InconsistentError("'*propertiesv1.HostClusterMembership' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			yes = hostClusterMembershipV1.Cluster != ""
+			hostClusterMembershipV1.Reset()
 			return nil
 		})
 	})
-	return yes, xerr
 }
 
 // IsGateway tells if the Host acts as a gateway for a Subnet
@@ -2707,7 +5955,9 @@ func (instance *Host) IsGateway() (_ bool, xerr fail.Error) {
 		return false, fail.InvalidInstanceError()
 	}
 
-	instance.lock.RLock()
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return false, xerr
+	}
 	defer instance.lock.RUnlock()
 
 	var state bool
@@ -2737,7 +5987,9 @@ func (instance *Host) IsSingle() (_ bool, xerr fail.Error) {
 		return false, fail.InvalidInstanceError()
 	}
 
-	instance.lock.RLock()
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return false, xerr
+	}
 	defer instance.lock.RUnlock()
 
 	var state bool
@@ -2822,17 +6074,27 @@ func (instance *Host) ToProtocol() (ph *protocol.Host, xerr fail.Error) {
 		return nil, fail.InvalidInstanceError()
 	}
 
-	instance.lock.RLock()
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return nil, xerr
+	}
 	defer instance.lock.RUnlock()
 
 	var (
-		ahc           *abstract.HostCore
-		hostSizingV1  *propertiesv1.HostSizing
-		hostVolumesV1 *propertiesv1.HostVolumes
-		volumes       []string
+		ahc              *abstract.HostCore
+		hostSizingV1     *propertiesv1.HostSizing
+		hostVolumesV1    *propertiesv1.HostVolumes
+		volumes          []string
+		created          time.Time
+		lastStateChanged time.Time
+		tenancy          hosttenancy.Enum
+		dedicatedHostID  string
+		clockSkew        time.Duration
+		lastOperation    *protocol.OperationStatus
+		ipPreserved      bool
 	)
 
 	publicIP := instance.publicIP
+	publicIPv4, publicIPv6 := instance.publicIPv4, instance.publicIPv6
 	privateIP := instance.privateIP
 
 	xerr = instance.Inspect(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
@@ -2857,7 +6119,46 @@ func (instance *Host) ToProtocol() (ph *protocol.Host, xerr fail.Error) {
 				for _, v := range hostVolumesV1.VolumesByName {
 					volumes = append(volumes, v)
 				}
-				return nil
+
+				// Older metadata may not have last operation, it's not an error
+				if props.Lookup(hostproperty.LastOperationV1) {
+					innerXErr := props.Inspect(hostproperty.LastOperationV1, func(clonable data.Clonable) fail.Error {
+						lastOperationV1, ok := clonable.(*propertiesv1.LastOperation)
+						if !ok {
+							return fail.InconsistentError("'*propertiesv1.LastOperation' expected, '%s' provided", reflect.TypeOf(clonable).String())
+						}
+
+						lastOperation = converters.LastOperationFromPropertyToProtocol(*lastOperationV1)
+						return nil
+					})
+					if innerXErr != nil {
+						return innerXErr
+					}
+				}
+
+				// Older metadata may not have description, it's not an error
+				if !props.Lookup(hostproperty.DescriptionV1) {
+					return nil
+				}
+
+				return props.Inspect(hostproperty.DescriptionV1, func(clonable data.Clonable) fail.Error {
+					hostDescriptionV1, ok := clonable.(*propertiesv1.HostDescription)
+					if !ok {
+						return fail.InconsistentError("'*propertiesv1.HostDescription' expected, '%s' provided", reflect.TypeOf(clonable).String())
+					}
+
+					created = hostDescriptionV1.Created
+					lastStateChanged = hostDescriptionV1.LastStateChanged
+					if lastStateChanged.IsZero() {
+						// State never seen changing since creation, backfill with creation time
+						lastStateChanged = created
+					}
+					tenancy = hostDescriptionV1.Tenancy
+					dedicatedHostID = hostDescriptionV1.DedicatedHostID
+					clockSkew = hostDescriptionV1.ClockSkew
+					ipPreserved = hostDescriptionV1.PublicIPPreserved
+					return nil
+				})
 			})
 		})
 	})
@@ -2878,6 +6179,15 @@ func (instance *Host) ToProtocol() (ph *protocol.Host, xerr fail.Error) {
 		Ram:                 hostSizingV1.AllocatedSize.RAMSize,
 		State:               protocol.HostState(ahc.LastState),
 		AttachedVolumeNames: volumes,
+		CreatedAt:           converters.TimeToProtocol(created),
+		LastStateChangedAt:  converters.TimeToProtocol(lastStateChanged),
+		Tenancy:             tenancy.String(),
+		DedicatedHostId:     dedicatedHostID,
+		ClockSkewMs:         clockSkew.Milliseconds(),
+		LastOperation:       lastOperation,
+		PublicIpPreserved:   ipPreserved,
+		PublicIpv4:          publicIPv4,
+		PublicIpv6:          publicIPv6,
 	}
 	return ph, nil
 }
@@ -3008,7 +6318,7 @@ func (instance *Host) UnbindSecurityGroup(ctx context.Context, sg resources.Secu
 			}
 
 			// unbind security group from Host on remote service side
-			if innerXErr := sg.UnbindFromHost(ctx, instance); innerXErr != nil {
+			if innerXErr := sg.UnbindFromHost(ctx, instance, data.NewImmutableKeyValue("Force", true)); innerXErr != nil {
 				return innerXErr
 			}
 
@@ -3029,7 +6339,9 @@ func (instance *Host) ListSecurityGroups(state securitygroupstate.Enum) (list []
 		return emptySlice, fail.InvalidInstanceError()
 	}
 
-	instance.lock.RLock()
+	if xerr = instance.rLockWithTimeout(); xerr != nil {
+		return emptySlice, xerr
+	}
 	defer instance.lock.RUnlock()
 
 	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
@@ -3051,6 +6363,161 @@ func (instance *Host) ListSecurityGroups(state securitygroupstate.Enum) (list []
 	return list, nil
 }
 
+// GetEffectiveRules returns the rules applied to the Host by the enabled Security Groups bound to it, de-duplicated and
+// annotated with the Security Groups defining them
+func (instance *Host) GetEffectiveRules(ctx context.Context) (_ abstract.EffectiveSecurityGroupRules, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host")).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	bonds, xerr := instance.ListSecurityGroups(securitygroupstate.Enabled)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	// sorts bonds to make the origins order deterministic
+	sort.Slice(bonds, func(i, j int) bool {
+		return bonds[i].Name < bonds[j].Name
+	})
+
+	var out abstract.EffectiveSecurityGroupRules
+	svc := instance.GetService()
+	for _, v := range bonds {
+		if task.Aborted() {
+			return nil, fail.AbortedError(nil, "aborted")
+		}
+
+		sgInstance, xerr := LoadSecurityGroup(svc, v.ID)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrNotFound:
+				// Security Group vanished in the meantime, it does not apply anymore
+				logrus.Warnf("Security Group '%s' bound to Host '%s' not found, ignored", v.Name, instance.GetName())
+				continue
+			default:
+				return nil, xerr
+			}
+		}
+
+		origin := abstract.SecurityGroupRuleOrigin{GroupID: v.ID, GroupName: v.Name, FromSubnet: v.FromSubnet}
+		xerr = sgInstance.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+			asg, ok := clonable.(*abstract.SecurityGroup)
+			if !ok {
+				return fail.InconsistentError("'*abstract.SecurityGroup' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			out = out.Merge(asg.Rules, origin)
+			return nil
+		})
+		sgInstance.Released()
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return nil, xerr
+		}
+	}
+
+	return out, nil
+}
+
+// Verify cross-checks the metadata of the Host against the Subnets, Security Groups, Volumes and Shares it references,
+// and reports the discrepancies found; nothing is modified
+func (instance *Host) Verify(ctx context.Context) (_ resources.ConsistencyReport, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	emptyReport := resources.ConsistencyReport{}
+	if instance == nil || instance.IsNull() {
+		return emptyReport, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return emptyReport, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return emptyReport, xerr
+	}
+
+	if task.Aborted() {
+		return emptyReport, fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host")).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	instance.lock.RLock()
+	defer instance.lock.RUnlock()
+
+	return instance.unsafeVerify(ctx)
+}
+
+// Repair fixes the discrepancies of the report whose kind is listed in 'kinds'; the other ones are left untouched
+// Only the discrepancies that can be fixed in metadata without side effect are repairable (see resources.ConsistencyIssueKind)
+func (instance *Host) Repair(ctx context.Context, report resources.ConsistencyReport, kinds ...resources.ConsistencyIssueKind) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if report.HostID != instance.GetID() {
+		return fail.InvalidParameterError("report", "is not a report of Host '%s'", instance.GetName())
+	}
+	for _, v := range kinds {
+		switch v {
+		case resources.MissingSubnetLink, resources.DeadSecurityGroupBond, resources.DeadVolumeAttachment, resources.DeadRemoteMount:
+		default:
+			return fail.InvalidParameterError("kinds", "discrepancies of kind '%s' are not repairable", v)
+		}
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.host"), "(%v)", kinds).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	unlock, xerr := lockHost(ctx, instance)
+	if xerr != nil {
+		return xerr
+	}
+	defer unlock()
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	return instance.unsafeRepair(ctx, report, kinds...)
+}
+
 // EnableSecurityGroup enables a bound security group to Host by applying its rules
 func (instance *Host) EnableSecurityGroup(ctx context.Context, sg resources.SecurityGroup) (xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
@@ -3244,17 +6711,33 @@ func (instance *Host) DisableSecurityGroup(ctx context.Context, rsg resources.Se
 }
 
 // ReserveCIDRForSingleHost returns the first available CIDR and its index inside the Network 'network'
+// The reservation stays uncommitted until CommitCIDRForSingleHost is called; the uncommitted reservations older than
+// temporal.GetSingleHostCIDRReservationTimeout() are considered as leaked and are reclaimed here
 func ReserveCIDRForSingleHost(networkInstance resources.Network) (string, uint, fail.Error) {
 	var index uint
 	xerr := networkInstance.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Alter(networkproperty.SingleHostsV1, func(clonable data.Clonable) fail.Error {
-			nshV1, ok := clonable.(*propertiesv1.NetworkSingleHosts)
+		return props.Alter(networkproperty.SingleHostReservationsV1, func(clonable data.Clonable) fail.Error {
+			nshrV1, ok := clonable.(*propertiesv1.NetworkSingleHostReservations)
 			if !ok {
-				return fail.InconsistentError("'*propertiesv1.NetworkSingleHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				return fail.InconsistentError("'*propertiesv1.NetworkSingleHostReservations' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			index = nshV1.ReserveSlot()
-			return nil
+			return props.Alter(networkproperty.SingleHostsV1, func(clonable data.Clonable) fail.Error {
+				nshV1, ok := clonable.(*propertiesv1.NetworkSingleHosts)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv1.NetworkSingleHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				for _, v := range nshrV1.Expired(temporal.GetSingleHostCIDRReservationTimeout()) {
+					logrus.Warnf("reclaiming CIDR slot '%d' of Network '%s', reserved for a single Host but never committed", v, networkInstance.GetName())
+					nshV1.FreeSlot(v)
+					nshrV1.Commit(v)
+				}
+
+				index = nshV1.ReserveSlot()
+				nshrV1.Reserve(index)
+				return nil
+			})
 		})
 	})
 	if xerr != nil {
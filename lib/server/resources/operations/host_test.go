@@ -17,9 +17,31 @@
 package operations
 
 import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/CS-SI/SafeScale/lib/protocol"
+	"github.com/CS-SI/SafeScale/lib/server/resources"
+	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusternodetype"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/installmethod"
+	propertiesv1 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v1"
+	propertiesv2 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v2"
+	propertiesv3 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v3"
+	"github.com/CS-SI/SafeScale/lib/system"
+	"github.com/CS-SI/SafeScale/lib/utils/data"
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
 )
 
 func Test_host_IsNull_Empty(t *testing.T) {
@@ -34,3 +56,751 @@ func Test_host_IsNull_Nil(t *testing.T) {
 	itis := rh.IsNull()
 	require.True(t, itis)
 }
+
+func Test_host_GetPublicIPs_Null(t *testing.T) {
+	var rh *Host
+	_, xerr := rh.GetPublicIPv4()
+	assert.IsType(t, &fail.ErrInvalidInstance{}, xerr)
+	_, xerr = rh.GetPublicIPv6()
+	assert.IsType(t, &fail.ErrInvalidInstance{}, xerr)
+	_, xerr = (&Host{}).GetPublicIPv6()
+	assert.IsType(t, &fail.ErrInvalidInstance{}, xerr)
+}
+
+func Test_IsTransientHostCreationError(t *testing.T) {
+	require.True(t, IsTransientHostCreationError(fail.OverloadError("too many requests")))
+	require.True(t, IsTransientHostCreationError(fail.NewError("InsufficientInstanceCapacity: insufficient capacity in zone")))
+	require.False(t, IsTransientHostCreationError(fail.OverflowError(nil, 10, "quota exceeded")))
+	require.False(t, IsTransientHostCreationError(fail.InvalidRequestError("invalid image")))
+	require.False(t, IsTransientHostCreationError(fail.NewError("unexpected failure")))
+}
+
+func Test_IsTransientPackageInstallError(t *testing.T) {
+	require.True(t, IsTransientPackageInstallError(fail.NewError("E: Could not get lock /var/lib/dpkg/lock-frontend")))
+	xerr := fail.ExecutionError(nil, "failed to install common node requirements")
+	_ = xerr.Annotate("stderr", "Err:1 http://archive.ubuntu.com focal InRelease\n  Temporary failure resolving 'archive.ubuntu.com'")
+	require.True(t, IsTransientPackageInstallError(xerr))
+	require.False(t, IsTransientPackageInstallError(fail.NewError("E: Unable to locate package dockr-ce")))
+	require.False(t, IsTransientPackageInstallError(fail.AbortedError(nil, "aborted")))
+	require.False(t, IsTransientPackageInstallError(fail.NewError("unexpected failure")))
+}
+
+func Test_checkMinimumNodes(t *testing.T) {
+	require.Nil(t, checkMinimumNodes("cluster", 5, 2, 3))
+	require.Nil(t, checkMinimumNodes("cluster", 1, 1, 0))
+	xerr := checkMinimumNodes("cluster", 3, 1, 3)
+	require.NotNil(t, xerr)
+	require.IsType(t, &fail.ErrInvalidRequest{}, xerr)
+	require.Contains(t, xerr.Error(), "2 nodes would remain out of 3, below the minimum of 3")
+}
+
+func Test_checkGatewaySizing(t *testing.T) {
+	minimum := abstract.HostSizingRequirements{MinCores: 2, MinRAMSize: 7.0, MinDiskSize: 50, MinGPU: -1}
+	require.Nil(t, checkGatewaySizing(abstract.HostSizingRequirements{MinCores: 4, MinRAMSize: 16.0, MinDiskSize: 50}, minimum))
+
+	xerr := checkGatewaySizing(abstract.HostSizingRequirements{MinCores: 1, MinRAMSize: 16.0, MinDiskSize: 20}, minimum)
+	require.NotNil(t, xerr)
+	require.IsType(t, &fail.ErrInvalidRequest{}, xerr)
+	require.Contains(t, xerr.Error(), "1 cores requested, 2 required")
+	require.Contains(t, xerr.Error(), "20 GB of disk requested, 50 GB required")
+}
+
+func Test_checkRequestedSecurityGroups(t *testing.T) {
+	require.Nil(t, checkRequestedSecurityGroups(abstract.HostRequest{ResourceName: "host"}))
+
+	req := abstract.HostRequest{ResourceName: "host", SkipDefaultSecurityGroups: true}
+	xerr := checkRequestedSecurityGroups(req)
+	require.NotNil(t, xerr)
+	require.IsType(t, &fail.ErrInvalidRequest{}, xerr)
+
+	req.SecurityGroupIDs = map[string]struct{}{"sg-id": {}}
+	require.Nil(t, checkRequestedSecurityGroups(req))
+}
+
+func Test_checkSchemaVersion(t *testing.T) {
+	current := currentSchemaVersion(hostKind)
+	require.NoError(t, checkSchemaVersion(hostKind, 0))
+	require.NoError(t, checkSchemaVersion(hostKind, current))
+
+	xerr := checkSchemaVersion(hostKind, current+1)
+	require.Error(t, xerr)
+	_, ok := xerr.(*fail.ErrForbidden)
+	require.True(t, ok)
+}
+
+func Test_surfaceProviderError(t *testing.T) {
+	providerMsg := "image not found in region"
+
+	xerr := fail.Wrap(fail.NewErrorWithCause(errors.New(providerMsg), "failed to create host"), "stack failure")
+	out := surfaceProviderError(xerr, "failed to create Host '%s'", "myhost")
+	require.True(t, strings.HasPrefix(out.Error(), "failed to create Host 'myhost' (provider error: "+providerMsg+")"))
+	anno, ok := out.Annotation(abstract.ProviderErrorMessageAnnotation)
+	require.True(t, ok)
+	require.EqualValues(t, providerMsg, anno)
+
+	xerr = fail.InvalidRequestError("invalid request")
+	_ = xerr.Annotate(abstract.ProviderErrorCodeAnnotation, "4047")
+	_ = xerr.Annotate(abstract.ProviderErrorMessageAnnotation, providerMsg)
+	out = surfaceProviderError(xerr, "failed to create Host '%s'", "myhost")
+	require.True(t, strings.HasPrefix(out.Error(), "failed to create Host 'myhost' (provider error 4047: "+providerMsg+")"))
+	_, ok = out.(*fail.ErrInvalidRequest)
+	require.True(t, ok)
+}
+
+func Test_subnetGatewaysCache(t *testing.T) {
+	cache := &gatewaysSSHConfigCache{byService: map[string]map[string]*gatewaysSSHConfig{}}
+
+	var calls int32
+	loader := func() (*system.SSHConfig, *system.SSHConfig, fail.Error) {
+		atomic.AddInt32(&calls, 1)
+		return &system.SSHConfig{Hostname: "gw-subnet", IPAddress: "10.0.0.1"}, nil, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			primary, secondary, xerr := cache.get("tenant", "subnet-id", loader)
+			require.Nil(t, xerr)
+			require.Equal(t, "gw-subnet", primary.Hostname)
+			require.Nil(t, secondary)
+		}()
+	}
+	wg.Wait()
+	require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+
+	// Returned configurations are copies
+	primary, _, _ := cache.get("tenant", "subnet-id", loader)
+	primary.Hostname = "altered"
+	primary, _, _ = cache.get("tenant", "subnet-id", loader)
+	require.Equal(t, "gw-subnet", primary.Hostname)
+
+	// Invalidation forces a new load; entries of other services are distinct
+	cache.invalidate("tenant", "subnet-id")
+	_, _, _ = cache.get("tenant", "subnet-id", loader)
+	_, _, _ = cache.get("other-tenant", "subnet-id", loader)
+	require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+
+	// Failure of loader is not cached
+	_, _, xerr := cache.get("tenant", "failing", func() (*system.SSHConfig, *system.SSHConfig, fail.Error) {
+		return nil, nil, fail.NotFoundError("no gateway")
+	})
+	require.NotNil(t, xerr)
+	_, _, xerr = cache.get("tenant", "failing", loader)
+	require.Nil(t, xerr)
+}
+
+func Test_resourceLockManager(t *testing.T) {
+	m := &resourceLockManager{locks: map[string]*resourceLock{}}
+
+	// operations locking the same keys in different orders are serialized without deadlock
+	var (
+		wg      sync.WaitGroup
+		counter int
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			keys := []string{"cluster:svc:c1", "host:svc:h1"}
+			if i%2 == 0 {
+				keys = []string{"host:svc:h1", "cluster:svc:c1"}
+			}
+			unlock, xerr := m.Lock(context.Background(), keys...)
+			if xerr != nil {
+				t.Error(xerr)
+				return
+			}
+			value := counter
+			time.Sleep(time.Millisecond)
+			counter = value + 1
+			unlock()
+		}(i)
+	}
+	wg.Wait()
+	require.EqualValues(t, 20, counter)
+	require.Empty(t, m.locks)
+
+	// waiting for a lock stops when the context is done, releasing the keys already locked
+	unlock, xerr := m.Lock(context.Background(), "host:svc:h1")
+	require.Nil(t, xerr)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, xerr = m.Lock(ctx, "cluster:svc:c1", "host:svc:h1")
+	require.NotNil(t, xerr)
+	unlock()
+	require.Empty(t, m.locks)
+}
+
+func Test_normalizeClusterDomain(t *testing.T) {
+	domain, xerr := normalizeClusterDomain(" .Cluster.Local. ")
+	require.Nil(t, xerr)
+	require.EqualValues(t, "cluster.local", domain)
+
+	for _, v := range []string{"", ".", "my_cluster.local", "-cluster.local", "cluster..local", strings.Repeat("a", 64) + ".local"} {
+		_, xerr = normalizeClusterDomain(v)
+		require.NotNil(t, xerr, v)
+		require.IsType(t, &fail.ErrInvalidParameter{}, xerr)
+	}
+}
+
+func Test_buildClusterDomainScript(t *testing.T) {
+	script := buildClusterDomainScript("cluster.local", []clusterDomainEntry{
+		{name: "gw-mycluster", ip: "192.168.0.1"},
+		{name: "mycluster-node-1", ip: "192.168.0.10"},
+	})
+	require.Contains(t, script, "Domains=cluster.local")
+	require.Contains(t, script, "search cluster.local")
+	require.Contains(t, script, "# BEGIN "+clusterDomainMarker+"\n192.168.0.1\tgw-mycluster.cluster.local gw-mycluster\n192.168.0.10\tmycluster-node-1.cluster.local mycluster-node-1\n# END "+clusterDomainMarker+"\n")
+}
+
+func Test_buildAuthorizedKeyScripts(t *testing.T) {
+	oldKey, newKey := "ssh-rsa AAAAold", "ssh-rsa AAAAnew"
+
+	script := buildAddAuthorizedKeyScript(newKey)
+	require.Contains(t, script, "grep -qxF '"+newKey+"' "+authorizedKeysFile+" || echo '"+newKey+"' >>"+authorizedKeysFile)
+
+	script = buildRemoveAuthorizedKeyScript(oldKey, newKey)
+	require.Contains(t, script, "grep -vxF '"+oldKey+"' "+authorizedKeysFile+" >"+authorizedKeysFile+".new")
+	require.Contains(t, script, "grep -qxF '"+newKey+"' "+authorizedKeysFile+".new || {")
+}
+
+func Test_classifyHostSettleFailure(t *testing.T) {
+	// never found: genuine not found
+	xerr := classifyHostSettleFailure("h1", false, fail.NotFoundError("not found"))
+	require.IsType(t, &fail.ErrNotFound{}, xerr)
+
+	// found then lost: transient, worth a retry of creation
+	xerr = classifyHostSettleFailure("h1", true, fail.NotFoundError("not found"))
+	require.IsType(t, &fail.ErrNotAvailable{}, xerr)
+	require.True(t, IsTransientHostCreationError(xerr))
+
+	xerr = classifyHostSettleFailure("h1", true, nil)
+	require.IsType(t, &fail.ErrNotAvailable{}, xerr)
+
+	xerr = classifyHostSettleFailure("h1", false, fail.ForbiddenError("denied"))
+	require.Contains(t, xerr.Error(), "denied")
+	require.False(t, IsTransientHostCreationError(xerr))
+}
+
+func Test_buildClusterTags(t *testing.T) {
+	tags, xerr := buildClusterTags("mycluster", map[string]string{"cost-center": "42", clusterNameTagKey: "other"})
+	require.Nil(t, xerr)
+	require.Equal(t, map[string]string{"cost-center": "42", clusterNameTagKey: "mycluster"}, tags)
+
+	tags, xerr = buildClusterTags("mycluster", nil)
+	require.Nil(t, xerr)
+	require.Equal(t, map[string]string{clusterNameTagKey: "mycluster"}, tags)
+
+	_, xerr = buildClusterTags("mycluster", map[string]string{" ": "42"})
+	require.IsType(t, &fail.ErrInvalidParameter{}, xerr)
+}
+
+func Test_buildInstallMethods(t *testing.T) {
+	// auto-detection
+	methods := buildInstallMethods([]installmethod.Enum{installmethod.Dnf}, nil)
+	require.Equal(t, map[uint8]installmethod.Enum{1: installmethod.Dnf, 2: installmethod.Bash, 3: installmethod.None}, methods)
+
+	// override replaces detected methods, None stays last
+	methods = buildInstallMethods([]installmethod.Enum{installmethod.Dnf}, []installmethod.Enum{installmethod.None, installmethod.Yum, installmethod.Bash})
+	require.Equal(t, map[uint8]installmethod.Enum{1: installmethod.Yum, 2: installmethod.Bash, 3: installmethod.None}, methods)
+
+	list, xerr := parseInstallMethods([]string{" yum", "", "Bash"})
+	require.Nil(t, xerr)
+	require.Equal(t, []installmethod.Enum{installmethod.Yum, installmethod.Bash}, list)
+
+	_, xerr = parseInstallMethods([]string{"rpm"})
+	require.IsType(t, &fail.ErrInvalidRequest{}, xerr)
+}
+
+func Test_selectInstallMethod(t *testing.T) {
+	methods := buildInstallMethods([]installmethod.Enum{installmethod.Dnf}, nil)
+	defined := map[string]interface{}{"yum": nil, "bash": nil}
+
+	meth, xerr := selectInstallMethod(methods, defined, "")
+	require.Nil(t, xerr)
+	require.Equal(t, installmethod.Bash, meth)
+
+	// required method not available on the target
+	_, xerr = selectInstallMethod(methods, defined, "yum")
+	require.IsType(t, &fail.ErrNotAvailable{}, xerr)
+
+	methods = buildInstallMethods(nil, []installmethod.Enum{installmethod.Yum, installmethod.Bash})
+	meth, xerr = selectInstallMethod(methods, defined, "yum")
+	require.Nil(t, xerr)
+	require.Equal(t, installmethod.Yum, meth)
+
+	// required method not defined by the Feature
+	_, xerr = selectInstallMethod(methods, defined, "apt")
+	require.IsType(t, &fail.ErrSyntax{}, xerr)
+}
+
+func Test_summarizeClusterHealth(t *testing.T) {
+	gateways := []*protocol.ClusterHostHealth{{Id: "gw", Reachable: true}}
+	masters := []*protocol.ClusterHostHealth{{Id: "m1", Reachable: true}, {Id: "m2", Error: "timeout"}}
+	nodes := []*protocol.ClusterHostHealth{{Id: "n1", Reachable: true}, {Id: "n2", Reachable: true}, {Id: "n3", Error: "timeout"}}
+
+	health := summarizeClusterHealth(gateways, masters, nodes)
+	require.Equal(t, gateways, health.Gateways)
+	require.EqualValues(t, 1, health.ReachableMasters)
+	require.EqualValues(t, 1, health.UnreachableMasters)
+	require.EqualValues(t, 2, health.ReachableNodes)
+	require.EqualValues(t, 1, health.UnreachableNodes)
+	require.Len(t, health.UnreachableHosts, 2)
+	require.Equal(t, "m2", health.UnreachableHosts[0].Id)
+	require.Equal(t, "n3", health.UnreachableHosts[1].Id)
+}
+
+func Test_summarizeFeaturesVerification(t *testing.T) {
+	docker := &results{}
+	_ = docker.AddOne("check", "node-1", stepResult{completed: true, success: true})
+	_ = docker.AddOne("check", "node-2", stepResult{completed: true, retcode: 1})
+	kong := &results{}
+	_ = kong.AddOne("check", "node-1", stepResult{completed: true, success: true})
+
+	out := summarizeFeaturesVerification(map[string]featureCheckOutcome{
+		"docker":  {results: docker},
+		"kong":    {results: kong},
+		"missing": {err: errors.New("feature not found")},
+	})
+	require.False(t, out.Successful)
+	require.Len(t, out.Features, 3)
+	require.False(t, out.Features[0].Successful) // docker, failed on node-2
+	require.True(t, out.Features[1].Successful)  // kong
+	require.Equal(t, "feature not found", out.Features[2].Error)
+
+	require.Len(t, out.Hosts, 2)
+	require.Equal(t, "node-1", out.Hosts[0].Host)
+	require.True(t, out.Hosts[0].Successful)
+	require.Len(t, out.Hosts[0].Features, 2)
+	require.False(t, out.Hosts[1].Successful)
+	require.Contains(t, out.Hosts[1].Features[0].Error, "check: ")
+
+	require.True(t, summarizeFeaturesVerification(map[string]featureCheckOutcome{"kong": {results: kong}}).Successful)
+}
+
+func Test_browseContextError(t *testing.T) {
+	require.Nil(t, browseContextError(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.IsType(t, &fail.ErrAborted{}, browseContextError(ctx))
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	xerr := browseContextError(ctx)
+	require.IsType(t, &fail.ErrTimeout{}, xerr)
+
+	// timeout of the bounded browse is reported with its duration, deadline of the caller is reported as is
+	xerr = boundedBrowseError(context.Background(), xerr, time.Minute, "Hosts")
+	require.IsType(t, &fail.ErrTimeout{}, xerr)
+	require.Contains(t, xerr.Error(), "partial")
+	require.NotContains(t, boundedBrowseError(ctx, browseContextError(ctx), time.Minute, "Hosts").Error(), "partial")
+}
+
+// fakeHost is a Host only able to give its identity
+type fakeHost struct {
+	resources.Host
+}
+
+func (fakeHost) GetID() string   { return "host-id" }
+func (fakeHost) GetName() string { return "host" }
+
+// fakeBindingSecurityGroup is a Security Group recording its bindings in 'provider', the bindings on provider side
+type fakeBindingSecurityGroup struct {
+	resources.SecurityGroup
+	id         string
+	failBind   bool
+	failUnbind bool
+	provider   map[string]bool
+}
+
+func (sg *fakeBindingSecurityGroup) GetID() string   { return sg.id }
+func (sg *fakeBindingSecurityGroup) GetName() string { return sg.id }
+func (sg *fakeBindingSecurityGroup) Released()       {}
+
+func (sg *fakeBindingSecurityGroup) BindToHost(context.Context, resources.Host, resources.SecurityGroupActivation, resources.SecurityGroupMark) fail.Error {
+	if sg.failBind {
+		return fail.NewError("injected failure of bind")
+	}
+	sg.provider[sg.id] = true
+	return nil
+}
+
+func (sg *fakeBindingSecurityGroup) UnbindFromHost(context.Context, resources.Host, ...data.ImmutableKeyValue) fail.Error {
+	if sg.failUnbind {
+		return fail.NewError("injected failure of unbind")
+	}
+	delete(sg.provider, sg.id)
+	return nil
+}
+
+func Test_hostSecurityGroupBindings(t *testing.T) {
+	// builds 3 Security Groups, the bind of the third one failing
+	newBindings := func(provider map[string]bool) hostSecurityGroupBindings {
+		var bindings hostSecurityGroupBindings
+		bindings.add(&fakeBindingSecurityGroup{id: "sg1", provider: provider}, true)
+		bindings.add(&fakeBindingSecurityGroup{id: "sg2", provider: provider}, false)
+		bindings.add(&fakeBindingSecurityGroup{id: "sg3", provider: provider, failBind: true}, false)
+		return bindings
+	}
+	// records the bonds like applySecurityGroups and checks metadata match provider side
+	requireConsistent := func(provider map[string]bool, bonds []*propertiesv1.SecurityGroupBond) {
+		hsgV1 := propertiesv1.NewHostSecurityGroups()
+		recordSecurityGroupBonds(hsgV1, bonds)
+		require.Len(t, hsgV1.ByID, len(provider))
+		require.Len(t, hsgV1.ByName, len(provider))
+		for k := range provider {
+			require.Contains(t, hsgV1.ByID, k)
+		}
+	}
+
+	// failure in the middle: the Security Groups already bound are unbound, nothing to record
+	provider := map[string]bool{}
+	bindings := newBindings(provider)
+	bonds, xerr := bindings.bind(context.Background(), fakeHost{}, false)
+	require.NotNil(t, xerr)
+	require.Empty(t, provider)
+	requireConsistent(provider, bonds)
+
+	// failure in the middle, Host kept on failure: the Security Groups bound are recorded
+	provider = map[string]bool{}
+	bindings = newBindings(provider)
+	bonds, xerr = bindings.bind(context.Background(), fakeHost{}, true)
+	require.NotNil(t, xerr)
+	require.Len(t, provider, 2)
+	requireConsistent(provider, bonds)
+	require.True(t, bonds[0].FromSubnet)
+	require.False(t, bonds[1].FromSubnet)
+
+	// failure in the middle, and failure of an unbind: the Security Group still bound is recorded
+	provider = map[string]bool{}
+	bindings = newBindings(provider)
+	bindings[0].sg.(*fakeBindingSecurityGroup).failUnbind = true
+	bonds, xerr = bindings.bind(context.Background(), fakeHost{}, false)
+	require.NotNil(t, xerr)
+	require.Equal(t, map[string]bool{"sg1": true}, provider)
+	requireConsistent(provider, bonds)
+
+	// success: everything is recorded
+	provider = map[string]bool{}
+	bindings = newBindings(provider)
+	bindings[2].sg.(*fakeBindingSecurityGroup).failBind = false
+	bindings.add(&fakeBindingSecurityGroup{id: "sg1", provider: provider}, false) // duplicate, ignored
+	require.Len(t, bindings, 3)
+	bonds, xerr = bindings.bind(context.Background(), fakeHost{}, false)
+	require.Nil(t, xerr)
+	require.Len(t, provider, 3)
+	requireConsistent(provider, bonds)
+}
+
+func Test_drainOptionsFrom(t *testing.T) {
+	skip, timeout := drainOptionsFrom()
+	require.False(t, skip)
+	require.Equal(t, time.Duration(0), timeout)
+
+	skip, timeout = drainOptionsFrom(
+		data.NewImmutableKeyValue("AllowBelowMinimum", true),
+		data.NewImmutableKeyValue("SkipDrain", true),
+		data.NewImmutableKeyValue("DrainTimeout", 90*time.Second),
+	)
+	require.True(t, skip)
+	require.Equal(t, 90*time.Second, timeout)
+}
+
+func Test_allocateNodeSlots(t *testing.T) {
+	nodesV3 := &propertiesv3.ClusterNodes{GlobalLastIndex: 10, MasterLastIndex: 2}
+
+	slots, xerr := allocateNodeSlots(nodesV3, "c1", clusternodetype.Master, 2)
+	require.Nil(t, xerr)
+	require.Equal(t, []clusterNodeSlot{{numericalID: 11, hostName: "c1-master-3"}, {numericalID: 12, hostName: "c1-master-4"}}, slots)
+
+	slots, xerr = allocateNodeSlots(nodesV3, "c1", clusternodetype.Node, 1)
+	require.Nil(t, xerr)
+	require.Equal(t, []clusterNodeSlot{{numericalID: 13, hostName: "c1-node-1"}}, slots)
+	require.EqualValues(t, 13, nodesV3.GlobalLastIndex)
+
+	_, xerr = allocateNodeSlots(nodesV3, "c1", clusternodetype.Gateway, 1)
+	require.NotNil(t, xerr)
+}
+
+// Test_allocateNodeSlots_Concurrent creates many nodes concurrently, reserving their slots like AddNodes and
+// taskCreateMasters do (the lock playing the role of Alter), and checks that no NumericalID nor name is given twice
+func Test_allocateNodeSlots_Concurrent(t *testing.T) {
+	const (
+		creators     = 50
+		nodesPerCall = 20
+	)
+
+	var (
+		lock    sync.Mutex
+		created sync.Map
+		dupes   int32
+		wg      sync.WaitGroup
+	)
+	nodesV3 := &propertiesv3.ClusterNodes{GlobalLastIndex: 10}
+	wg.Add(creators)
+	for i := 0; i < creators; i++ {
+		nodeType := clusternodetype.Node
+		if i%5 == 0 {
+			nodeType = clusternodetype.Master
+		}
+		go func(nodeType clusternodetype.Enum) {
+			defer wg.Done()
+
+			lock.Lock()
+			slots, xerr := allocateNodeSlots(nodesV3, "c1", nodeType, nodesPerCall)
+			lock.Unlock()
+			if !assert.Nil(t, xerr) {
+				return
+			}
+
+			// the nodes of a reservation are created in parallel, each with its own slot
+			var subtasks sync.WaitGroup
+			subtasks.Add(len(slots))
+			for j, slot := range slots {
+				if j > 0 {
+					assert.Equal(t, slots[j-1].numericalID+1, slot.numericalID, "slots of a reservation must be contiguous")
+				}
+				go func(slot clusterNodeSlot) {
+					defer subtasks.Done()
+					if _, loaded := created.LoadOrStore(slot.numericalID, slot.hostName); loaded {
+						atomic.AddInt32(&dupes, 1)
+					}
+					if _, loaded := created.LoadOrStore(slot.hostName, slot.numericalID); loaded {
+						atomic.AddInt32(&dupes, 1)
+					}
+				}(slot)
+			}
+			subtasks.Wait()
+		}(nodeType)
+	}
+	wg.Wait()
+
+	require.EqualValues(t, 0, dupes)
+	require.EqualValues(t, 10+creators*nodesPerCall, nodesV3.GlobalLastIndex)
+	count := 0
+	created.Range(func(_, _ interface{}) bool {
+		count++
+		return true
+	})
+	require.Equal(t, 2*creators*nodesPerCall, count)
+}
+
+func Test_inventoryPackageCommand(t *testing.T) {
+	manager, _ := inventoryPackageCommand(buildInstallMethods([]installmethod.Enum{installmethod.Apt}, nil))
+	assert.Equal(t, "dpkg", manager)
+
+	manager, _ = inventoryPackageCommand(buildInstallMethods(nil, []installmethod.Enum{installmethod.Bash, installmethod.Dnf}))
+	assert.Equal(t, "rpm", manager)
+
+	manager, cmd := inventoryPackageCommand(buildInstallMethods(nil, nil))
+	assert.Empty(t, manager)
+	assert.Empty(t, cmd)
+	assert.NotContains(t, inventoryCommand(cmd), inventoryPackagesMarker)
+}
+
+func Test_parseHostInventory(t *testing.T) {
+	output := strings.Join([]string{
+		`NAME="Ubuntu"`,
+		`VERSION_ID="20.04"`,
+		`ID=ubuntu`,
+		`PRETTY_NAME="Ubuntu 20.04.2 LTS"`,
+		inventoryKernelMarker,
+		"5.4.0-42-generic",
+		inventoryPackagesMarker,
+		"openssl\t1.1.1f-1ubuntu2",
+		"linux-image\t5.4.0-42",
+		"linux-image\t5.4.0-65",
+		"",
+	}, "\n")
+
+	inventory, xerr := parseHostInventory(output, "dpkg")
+	require.Nil(t, xerr)
+	assert.Equal(t, "ubuntu", inventory.OSID)
+	assert.Equal(t, "20.04", inventory.OSVersion)
+	assert.Equal(t, "Ubuntu 20.04.2 LTS", inventory.OSName)
+	assert.Equal(t, "5.4.0-42-generic", inventory.Kernel)
+	assert.Equal(t, "dpkg", inventory.PackageManager)
+	assert.Equal(t, map[string]string{"openssl": "1.1.1f-1ubuntu2", "linux-image": "5.4.0-42,5.4.0-65"}, inventory.Packages)
+
+	// without packages section, no package manager is recorded
+	inventory, xerr = parseHostInventory("ID='centos'\n"+inventoryKernelMarker+"\n3.10.0\n", "rpm")
+	require.Nil(t, xerr)
+	assert.Equal(t, "centos", inventory.OSID)
+	assert.Empty(t, inventory.PackageManager)
+	assert.Empty(t, inventory.Packages)
+
+	_, xerr = parseHostInventory("garbage", "dpkg")
+	require.NotNil(t, xerr)
+}
+
+func Test_checkClusterNodeNameAvailable(t *testing.T) {
+	nodesV3 := &propertiesv3.ClusterNodes{
+		Masters:      []uint{11},
+		PrivateNodes: []uint{12},
+		ByNumericalID: map[uint]*propertiesv3.ClusterNode{
+			11: {ID: "id-master", NumericalID: 11, Name: "c1-master-1"},
+			12: {ID: "id-node", NumericalID: 12, Name: "imported"},
+		},
+		GlobalLastIndex: 12,
+	}
+
+	require.Nil(t, checkClusterNodeNameAvailable(nodesV3, "c1", "other", "id-other"))
+	require.Nil(t, checkClusterNodeNameAvailable(nodesV3, "c1", "imported", "id-node"))
+
+	// a node cannot take the name of a master, and conversely
+	xerr := checkClusterNodeNameAvailable(nodesV3, "c1", "c1-master-1", "id-other")
+	require.IsType(t, &fail.ErrDuplicate{}, xerr)
+	assert.Contains(t, xerr.Error(), "id-master")
+	assert.Contains(t, xerr.Error(), "id-other")
+
+	// the name generated for a new node collides with the one of an imported node
+	nodesV3.ByNumericalID[12].Name = "c1-node-1"
+	_, xerr = allocateNodeSlots(nodesV3, "c1", clusternodetype.Node, 1)
+	require.IsType(t, &fail.ErrDuplicate{}, xerr)
+}
+
+func Test_findDuplicateClusterNodeNames(t *testing.T) {
+	nodesV3 := &propertiesv3.ClusterNodes{
+		Masters:      []uint{11},
+		PrivateNodes: []uint{13, 12, 14},
+		ByNumericalID: map[uint]*propertiesv3.ClusterNode{
+			11: {ID: "id-11", NumericalID: 11, Name: "c1-master-1"},
+			12: {ID: "id-12", NumericalID: 12, Name: "c1-node-1"},
+			13: {ID: "id-13", NumericalID: 13, Name: "c1-master-1"},
+			14: {ID: "id-14", NumericalID: 14, Name: "c1-node-2"},
+		},
+	}
+
+	duplicates := findDuplicateClusterNodeNames(nodesV3, "c1")
+	require.Len(t, duplicates, 1)
+	require.IsType(t, &fail.ErrDuplicate{}, duplicates[0])
+	assert.Contains(t, duplicates[0].Error(), "'id-11' and 'id-13'")
+
+	delete(nodesV3.ByNumericalID, 13)
+	assert.Empty(t, findDuplicateClusterNodeNames(nodesV3, "c1"))
+}
+
+func Test_resolveGatewaysSSHConfig_Direct(t *testing.T) {
+	// gateways, single Hosts and Hosts not migrated yet are reached directly, without looking for gateways
+	for _, hnV2 := range []*propertiesv2.HostNetworking{
+		{IsGateway: true, DefaultSubnetID: "subnet"},
+		{Single: true, DefaultSubnetID: "subnet"},
+		{},
+	} {
+		primary, secondary, xerr := resolveGatewaysSSHConfig(nil, hnV2, "safescale")
+		require.Nil(t, xerr)
+		assert.Nil(t, primary)
+		assert.Nil(t, secondary)
+	}
+}
+
+func Test_validateDiagnosticsRequest(t *testing.T) {
+	assert.Nil(t, validateDiagnosticsRequest(defaultDiagnosticsPaths, defaultDiagnosticsCommands))
+	assert.Nil(t, validateDiagnosticsRequest([]string{"/opt/safescale/var/log/feature.*.log"}, nil))
+
+	for _, v := range []string{"var/log", "/var/log; rm -rf /", "/var/log/$(id)", "/var/log syslog"} {
+		xerr := validateDiagnosticsRequest([]string{v}, nil)
+		require.NotNil(t, xerr, v)
+		assert.IsType(t, &fail.ErrInvalidParameter{}, xerr)
+	}
+	assert.NotNil(t, validateDiagnosticsRequest(nil, []string{"df -h\nrm -rf /"}))
+	assert.NotNil(t, validateDiagnosticsRequest(nil, []string{" "}))
+}
+
+func Test_diagnosticsBundle(t *testing.T) {
+	bundle := newDiagnosticsBundle(10)
+	assert.True(t, bundle.reserve(6))
+	assert.False(t, bundle.reserve(5))
+	bundle.release(6)
+	assert.True(t, bundle.reserve(10))
+	bundle.addArchive("mycluster-node-2", []byte("22"))
+	bundle.addArchive("mycluster-master-1", []byte("1"))
+	bundle.addProblem("mycluster-node-3", "failed to collect diagnostics: %s", "timeout")
+
+	content, err := bundle.write("mycluster", []string{"/var/log/syslog"}, []string{"df -h"})
+	require.Nil(t, err)
+
+	var names []string
+	reader := tar.NewReader(bytes.NewReader(content))
+	for {
+		header, err := reader.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, header.Name)
+		if header.Name == diagnosticsManifest {
+			manifest, err := ioutil.ReadAll(reader)
+			require.Nil(t, err)
+			assert.Contains(t, string(manifest), "Cluster: mycluster\n")
+			assert.Contains(t, string(manifest), "  01: df -h\n")
+			assert.Contains(t, string(manifest), "  mycluster-node-2.tar.gz: 2 bytes\n")
+			assert.Contains(t, string(manifest), "  mycluster-node-3: failed to collect diagnostics: timeout\n")
+		}
+	}
+	assert.Equal(t, []string{diagnosticsManifest, "mycluster-master-1.tar.gz", "mycluster-node-2.tar.gz"}, names)
+}
+
+func Test_removeClusterNode(t *testing.T) {
+	nodesV3 := &propertiesv3.ClusterNodes{
+		Masters:           []uint{11},
+		MasterByID:        map[string]uint{"id-11": 11},
+		MasterByName:      map[string]uint{"c1-master-1": 11},
+		PrivateNodes:      []uint{12, 13},
+		PrivateNodeByID:   map[string]uint{"id-12": 12, "id-13": 13},
+		PrivateNodeByName: map[string]uint{"c1-node-1": 12, "c1-node-2": 13},
+		ByNumericalID: map[uint]*propertiesv3.ClusterNode{
+			11: {ID: "id-11", NumericalID: 11, Name: "c1-master-1"},
+			12: {ID: "id-12", NumericalID: 12, Name: "c1-node-1"},
+			13: {ID: "id-13", NumericalID: 13, Name: "c1-node-2"},
+		},
+		Cordoned: map[uint]bool{12: true},
+		Labels:   map[uint]map[string]string{12: {"zone": "a"}},
+	}
+
+	assert.False(t, removeClusterNode(nodesV3, "id-unknown"))
+
+	require.True(t, removeClusterNode(nodesV3, "id-12"))
+	assert.Equal(t, []uint{13}, nodesV3.PrivateNodes)
+	assert.NotContains(t, nodesV3.PrivateNodeByID, "id-12")
+	assert.NotContains(t, nodesV3.PrivateNodeByName, "c1-node-1")
+	assert.NotContains(t, nodesV3.ByNumericalID, uint(12))
+	assert.Empty(t, nodesV3.Cordoned)
+	assert.Empty(t, nodesV3.Labels)
+	assert.False(t, removeClusterNode(nodesV3, "id-12"))
+
+	require.True(t, removeClusterNode(nodesV3, "id-11"))
+	assert.Empty(t, nodesV3.Masters)
+	assert.Empty(t, nodesV3.MasterByName)
+	assert.Len(t, nodesV3.ByNumericalID, 1)
+}
+
+func Test_compareHostSizing(t *testing.T) {
+	recorded := &propertiesv2.HostEffectiveSizing{Cores: 2, RAMSize: 4, DiskSize: 50}
+
+	assert.Empty(t, compareHostSizing(nil, &abstract.HostEffectiveSizing{Cores: 4}))
+	assert.Empty(t, compareHostSizing(recorded, nil))
+	assert.Empty(t, compareHostSizing(recorded, &abstract.HostEffectiveSizing{Cores: 2, RAMSize: 4, DiskSize: 50}))
+	// values not reported by the provider are not compared
+	assert.Empty(t, compareHostSizing(recorded, &abstract.HostEffectiveSizing{Cores: 2}))
+
+	diffs := compareHostSizing(recorded, &abstract.HostEffectiveSizing{Cores: 4, RAMSize: 8, DiskSize: 50})
+	assert.Equal(t, []string{"4 cores instead of 2", "8.0 GB of RAM instead of 4.0"}, diffs)
+}
+
+func Test_ClusterReconciliationReport(t *testing.T) {
+	report := resources.ClusterReconciliationReport{ClusterName: "c1"}
+	assert.True(t, report.IsConsistent())
+
+	report.Drifts = []resources.ClusterDrift{
+		{Kind: resources.DeadClusterNode, Role: "node", HostID: "id-12"},
+		{Kind: resources.ClusterSizingDrift, Role: "gateway", HostID: "id-gw"},
+		{Kind: resources.DeadClusterNode, Role: "master", HostID: "id-11"},
+	}
+	assert.False(t, report.IsConsistent())
+	assert.Len(t, report.DriftsOfKind(resources.DeadClusterNode), 2)
+	assert.Empty(t, report.DriftsOfKind(resources.DeadClusterGateway))
+}
@@ -18,24 +18,32 @@ package operations
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
 
+	"github.com/CS-SI/SafeScale/lib/server/iaas"
 	"github.com/CS-SI/SafeScale/lib/server/resources"
+	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/subnetproperty"
 	propertiesv2 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v2"
 
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hostproperty"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hoststate"
 	propertiesv1 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v1"
 	"github.com/CS-SI/SafeScale/lib/system"
 	"github.com/CS-SI/SafeScale/lib/utils/cli/enums/outputs"
 	"github.com/CS-SI/SafeScale/lib/utils/concurrency"
 	"github.com/CS-SI/SafeScale/lib/utils/data"
+	"github.com/CS-SI/SafeScale/lib/utils/data/cache"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
 	"github.com/CS-SI/SafeScale/lib/utils/retry"
 	"github.com/CS-SI/SafeScale/lib/utils/serialize"
@@ -44,6 +52,35 @@ import (
 
 // UnsafeRun is the non goroutine-safe version of Run, with less parameter validation, that does the real work
 func (instance *Host) UnsafeRun(ctx context.Context, cmd string, outs outputs.Enum, connectionTimeout, executionTimeout time.Duration) (_ int, _ string, _ string, xerr fail.Error) {
+	return instance.unsafeRun(ctx, cmd, nil, outs, connectionTimeout, executionTimeout)
+}
+
+// UnsafeRunWithStdin is the non goroutine-safe version of RunWithStdin, with less parameter validation, that does the real work
+// Note: must be used with wisdom
+func (instance *Host) UnsafeRunWithStdin(ctx context.Context, cmd string, stdin io.Reader, outs outputs.Enum, connectionTimeout, executionTimeout time.Duration) (_ int, _ string, _ string, xerr fail.Error) {
+	if stdin == nil {
+		return 0, "", "", fail.InvalidParameterCannotBeNilError("stdin")
+	}
+
+	return instance.unsafeRun(ctx, cmd, stdin, outs, connectionTimeout, executionTimeout)
+}
+
+// unsafeExecute is the non goroutine-safe version of Execute, with less parameter validation, that does the real work
+// The result is returned even on failure, filled with what has been collected
+func (instance *Host) unsafeExecute(ctx context.Context, cmd string, outs outputs.Enum, connectionTimeout, executionTimeout time.Duration) (*resources.ExecutionResult, fail.Error) {
+	start := time.Now()
+	retcode, stdout, stderr, xerr := instance.unsafeRun(ctx, cmd, nil, outs, connectionTimeout, executionTimeout)
+	return &resources.ExecutionResult{
+		Command:  cmd,
+		Retcode:  retcode,
+		Stdout:   stdout,
+		Stderr:   stderr,
+		Duration: time.Since(start),
+	}, xerr
+}
+
+// unsafeRun executes the command, feeding its standard input with 'stdin' if not nil
+func (instance *Host) unsafeRun(ctx context.Context, cmd string, stdin io.Reader, outs outputs.Enum, connectionTimeout, executionTimeout time.Duration) (_ int, _ string, _ string, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
 	if cmd == "" {
@@ -70,7 +107,11 @@ func (instance *Host) UnsafeRun(ctx context.Context, cmd string, outs outputs.En
 	)
 
 	hostName := instance.GetName()
-	retCode, stdOut, stdErr, xerr = run(ctx, instance.sshProfile, cmd, outs, executionTimeout)
+	if stdin != nil {
+		retCode, stdOut, stdErr, xerr = runWithStdin(ctx, instance.sshProfile, cmd, stdin, outs, executionTimeout)
+	} else {
+		retCode, stdOut, stdErr, xerr = run(ctx, instance.sshProfile, cmd, outs, executionTimeout)
+	}
 	if xerr != nil {
 		switch xerr.(type) {
 		case *retry.ErrStopRetry: // == *fail.ErrAborted
@@ -159,6 +200,99 @@ func run(ctx context.Context, ssh *system.SSHConfig, cmd string, outs outputs.En
 	return retcode, stdout, stderr, xerr
 }
 
+// runWithStdin executes command on the host, streaming 'stdin' to its standard input
+// Contrary to run, the command is not retried on failure, the content of 'stdin' being consumed by the first attempt
+// In case of error, can return:
+// - *fail.ErrExecution: command failed to execute
+// - *fail.ErrNotAvailable: failed to connect to remote host
+// - *fail.ErrTimeout: execution has timed out
+// - *fail.ErrAborted: execution has been aborted by context
+func runWithStdin(ctx context.Context, ssh *system.SSHConfig, cmd string, stdin io.Reader, outs outputs.Enum, timeout time.Duration) (_ int, _ string, _ string, xerr fail.Error) {
+	// no timeout is unsafe, we set an upper limit
+	if timeout == 0 {
+		timeout = temporal.GetLongOperationTimeout()
+	}
+
+	sshCmd, xerr := ssh.NewCommandWithStdin(ctx, cmd)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return -1, "", "", xerr
+	}
+
+	defer func() {
+		if derr := sshCmd.Close(); derr != nil {
+			if xerr == nil {
+				xerr = derr
+			} else {
+				_ = xerr.AddConsequence(fail.Wrap(derr, "failed to close SSHCommand"))
+			}
+		}
+	}()
+
+	retcode, stdout, stderr, xerr := sshCmd.RunWithStdin(ctx, outs, stdin, timeout)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrExecution:
+			// Adds stdout and stderr as annotations to xerr
+			_ = xerr.Annotate("stdout", stdout)
+			_ = xerr.Annotate("stderr", stderr)
+		default:
+		}
+		return retcode, stdout, stderr, xerr
+	}
+	// If retcode == 255, ssh connection failed
+	if retcode == 255 {
+		return retcode, stdout, stderr, fail.NotAvailableError("failed to connect")
+	}
+	return retcode, stdout, stderr, nil
+}
+
+// runWithOutputHandler executes command on the host, calling 'handler' with each line of its standard output as soon as
+// it is received; the command is not retried on failure
+// In case of error, can return:
+// - *fail.ErrExecution: command failed to execute
+// - *fail.ErrNotAvailable: failed to connect to remote host
+// - *fail.ErrAborted: execution has been aborted by context
+func runWithOutputHandler(ctx context.Context, ssh *system.SSHConfig, cmd string, stdin io.Reader, handler func(string), timeout time.Duration) (xerr fail.Error) {
+	sshCmd, xerr := ssh.NewCommandWithStdin(ctx, cmd)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	defer func() {
+		if derr := sshCmd.Close(); derr != nil {
+			if xerr == nil {
+				xerr = derr
+			} else {
+				_ = xerr.AddConsequence(fail.Wrap(derr, "failed to close SSHCommand"))
+			}
+		}
+	}()
+
+	retcode, stderr, xerr := sshCmd.RunWithOutputHandler(ctx, stdin, handler, timeout)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrExecution:
+			_ = xerr.Annotate("stderr", stderr)
+		default:
+		}
+		return xerr
+	}
+	// If retcode == 255, ssh connection failed
+	if retcode == 255 {
+		return fail.NotAvailableError("failed to connect")
+	}
+	if retcode != 0 {
+		xerr = fail.ExecutionError(nil, "remote command failed with retcode %d", retcode)
+		_ = xerr.Annotate("retcode", retcode).Annotate("stderr", stderr)
+		return xerr
+	}
+	return nil
+}
+
 // UnsafePush is the non goroutine-safe version of Push, with less parameter validation, that do the real work
 // Note: must be used with wisdom
 func (instance *Host) UnsafePush(ctx context.Context, source, target, owner, mode string, timeout time.Duration) (_ int, _ string, _ string, xerr fail.Error) {
@@ -437,3 +571,656 @@ func (instance *Host) unsafeGetDefaultSubnet() (rs resources.Subnet, xerr fail.E
 
 	return rs, nil
 }
+
+// hostReferenceExists tells if the resource referenced by a Host, checked for discrepancies of kind 'kind', still exists
+func hostReferenceExists(svc iaas.Service, kind resources.ConsistencyIssueKind, id string) (bool, fail.Error) {
+	var (
+		rsc  cache.Cacheable
+		xerr fail.Error
+	)
+	switch kind {
+	case resources.MissingSubnet:
+		rsc, xerr = LoadSubnet(svc, "", id)
+	case resources.DeadSecurityGroupBond:
+		rsc, xerr = LoadSecurityGroup(svc, id)
+	case resources.DeadVolumeAttachment:
+		rsc, xerr = LoadVolume(svc, id)
+	case resources.DeadRemoteMount:
+		rsc, xerr = LoadShare(svc, id)
+	default:
+		return false, fail.InvalidParameterError("kind", "cannot check the existence of resources for discrepancies of kind '%s'", kind)
+	}
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotFound:
+			return false, nil
+		default:
+			return false, xerr
+		}
+	}
+
+	rsc.Released()
+	return true, nil
+}
+
+// unsafeVerify is the non goroutine-safe version of Verify, that does the real work
+func (instance *Host) unsafeVerify(ctx context.Context) (_ resources.ConsistencyReport, xerr fail.Error) {
+	report := resources.ConsistencyReport{HostID: instance.GetID(), HostName: instance.GetName()}
+
+	var (
+		hnV2    *propertiesv2.HostNetworking
+		bonds   map[string]string // Security Group names, indexed by ID
+		volumes map[string]string // Volume names, indexed by ID
+		shares  map[string]string // mount points, indexed by Share ID
+	)
+	xerr = instance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		innerXErr := props.Inspect(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+			var ok bool
+			hnV2, ok = clonable.Clone().(*propertiesv2.HostNetworking)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		innerXErr = props.Inspect(hostproperty.SecurityGroupsV1, func(clonable data.Clonable) fail.Error {
+			hsgV1, ok := clonable.(*propertiesv1.HostSecurityGroups)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostSecurityGroups' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			bonds = make(map[string]string, len(hsgV1.ByID))
+			for k, v := range hsgV1.ByID {
+				bonds[k] = v.Name
+			}
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		innerXErr = props.Inspect(hostproperty.VolumesV1, func(clonable data.Clonable) fail.Error {
+			hvV1, ok := clonable.(*propertiesv1.HostVolumes)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostVolumes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			volumes = make(map[string]string, len(hvV1.VolumesByID))
+			for k := range hvV1.VolumesByID {
+				volumes[k] = ""
+			}
+			for k, v := range hvV1.VolumesByName {
+				if _, ok := volumes[v]; ok {
+					volumes[v] = k
+				}
+			}
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		return props.Inspect(hostproperty.MountsV1, func(clonable data.Clonable) fail.Error {
+			hmV1, ok := clonable.(*propertiesv1.HostMounts)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostMounts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			shares = make(map[string]string, len(hmV1.RemoteMountsByShareID))
+			for k, v := range hmV1.RemoteMountsByShareID {
+				shares[k] = v
+			}
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return report, xerr
+	}
+
+	svc := instance.GetService()
+
+	// checks a set of references, in a deterministic order
+	check := func(kind resources.ConsistencyIssueKind, refs map[string]string, describe func(id, name string) string) fail.Error {
+		ids := make([]string, 0, len(refs))
+		for k := range refs {
+			ids = append(ids, k)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			exists, xerr := hostReferenceExists(svc, kind, id)
+			if xerr != nil {
+				return xerr
+			}
+			if !exists {
+				report.Issues = append(report.Issues, resources.ConsistencyIssue{
+					Kind:         kind,
+					ResourceID:   id,
+					ResourceName: refs[id],
+					Description:  describe(id, refs[id]),
+				})
+			}
+		}
+		return nil
+	}
+
+	subnets := make(map[string]string, len(hnV2.SubnetsByID)+1)
+	for k, v := range hnV2.SubnetsByID {
+		subnets[k] = v
+	}
+	if hnV2.DefaultSubnetID != "" {
+		if _, ok := subnets[hnV2.DefaultSubnetID]; !ok {
+			subnets[hnV2.DefaultSubnetID] = ""
+		}
+	}
+	xerr = check(resources.MissingSubnet, subnets, func(id, name string) string {
+		return fmt.Sprintf("Subnet '%s' of Host does not exist", id)
+	})
+	if xerr != nil {
+		return report, xerr
+	}
+
+	// Gateways and single Hosts are not registered in the Hosts of their Subnets
+	if !hnV2.IsGateway && !hnV2.Single {
+		ids := make([]string, 0, len(subnets))
+		for k := range subnets {
+			ids = append(ids, k)
+		}
+		sort.Strings(ids)
+
+		hostID := instance.GetID()
+		for _, id := range ids {
+			subnetInstance, xerr := LoadSubnet(svc, "", id)
+			if xerr != nil {
+				switch xerr.(type) {
+				case *fail.ErrNotFound:
+					// already reported as missing
+					continue
+				default:
+					return report, xerr
+				}
+			}
+
+			linked := false
+			xerr = subnetInstance.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+				return props.Inspect(subnetproperty.HostsV1, func(clonable data.Clonable) fail.Error {
+					shV1, ok := clonable.(*propertiesv1.SubnetHosts)
+					if !ok {
+						return fail.InconsistentError("'*propertiesv1.SubnetHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+					}
+
+					_, linked = shV1.ByID[hostID]
+					return nil
+				})
+			})
+			subnetName := subnetInstance.GetName()
+			subnetInstance.Released()
+			if xerr != nil {
+				return report, xerr
+			}
+
+			if !linked {
+				report.Issues = append(report.Issues, resources.ConsistencyIssue{
+					Kind:         resources.MissingSubnetLink,
+					ResourceID:   id,
+					ResourceName: subnetName,
+					Description:  fmt.Sprintf("Subnet '%s' does not reference the Host", subnetName),
+				})
+			}
+		}
+	}
+
+	xerr = check(resources.DeadSecurityGroupBond, bonds, func(id, name string) string {
+		return fmt.Sprintf("Security Group '%s' bound to Host does not exist", name)
+	})
+	if xerr != nil {
+		return report, xerr
+	}
+
+	xerr = check(resources.DeadVolumeAttachment, volumes, func(id, name string) string {
+		return fmt.Sprintf("Volume '%s' attached to Host does not exist", id)
+	})
+	if xerr != nil {
+		return report, xerr
+	}
+
+	xerr = check(resources.DeadRemoteMount, shares, func(id, path string) string {
+		return fmt.Sprintf("Share '%s' mounted on '%s' does not exist", id, path)
+	})
+	if xerr != nil {
+		return report, xerr
+	}
+
+	return report, nil
+}
+
+// unsafeRepair is the non goroutine-safe version of Repair, that does the real work
+// The discrepancies reporting a vanished resource are checked again before being fixed, in case the report is outdated
+func (instance *Host) unsafeRepair(ctx context.Context, report resources.ConsistencyReport, kinds ...resources.ConsistencyIssueKind) (xerr fail.Error) {
+	selected := map[resources.ConsistencyIssueKind]bool{}
+	for _, v := range kinds {
+		selected[v] = true
+	}
+
+	svc := instance.GetService()
+	var errors []error
+	for _, v := range report.Issues {
+		if !selected[v.Kind] {
+			continue
+		}
+
+		switch v.Kind {
+		case resources.MissingSubnetLink:
+			subnetInstance, xerr := LoadSubnet(svc, "", v.ResourceID)
+			if xerr != nil {
+				errors = append(errors, xerr)
+				continue
+			}
+
+			xerr = subnetInstance.AdoptHost(ctx, instance)
+			subnetInstance.Released()
+			if xerr != nil {
+				errors = append(errors, fail.Wrap(xerr, "failed to link Host to Subnet '%s'", v.ResourceName))
+				continue
+			}
+		default:
+			exists, xerr := hostReferenceExists(svc, v.Kind, v.ResourceID)
+			if xerr != nil {
+				errors = append(errors, xerr)
+				continue
+			}
+			if exists {
+				logrus.Warnf("resource '%s' referenced by Host '%s' exists, discrepancy '%s' ignored", v.ResourceID, instance.GetName(), v.Kind)
+				continue
+			}
+
+			if xerr = instance.dropDeadReference(v.Kind, v.ResourceID); xerr != nil {
+				errors = append(errors, xerr)
+				continue
+			}
+		}
+		logrus.Infof("Host '%s': repaired '%s' discrepancy on '%s'", instance.GetName(), v.Kind, v.ResourceID)
+	}
+	if len(errors) > 0 {
+		return fail.Wrap(fail.NewErrorList(errors), "failed to repair Host '%s'", instance.GetName())
+	}
+	return nil
+}
+
+// dropDeadReference removes from metadata the reference to a resource that does not exist anymore
+func (instance *Host) dropDeadReference(kind resources.ConsistencyIssueKind, id string) fail.Error {
+	return instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		switch kind {
+		case resources.DeadSecurityGroupBond:
+			return props.Alter(hostproperty.SecurityGroupsV1, func(clonable data.Clonable) fail.Error {
+				hsgV1, ok := clonable.(*propertiesv1.HostSecurityGroups)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv1.HostSecurityGroups' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				delete(hsgV1.ByID, id)
+				for k, v := range hsgV1.ByName {
+					if v == id {
+						delete(hsgV1.ByName, k)
+					}
+				}
+				return nil
+			})
+
+		case resources.DeadVolumeAttachment:
+			var device string
+			innerXErr := props.Alter(hostproperty.VolumesV1, func(clonable data.Clonable) fail.Error {
+				hvV1, ok := clonable.(*propertiesv1.HostVolumes)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv1.HostVolumes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				device = hvV1.DevicesByID[id]
+				delete(hvV1.VolumesByID, id)
+				delete(hvV1.DevicesByID, id)
+				if device != "" {
+					delete(hvV1.VolumesByDevice, device)
+				}
+				for k, v := range hvV1.VolumesByName {
+					if v == id {
+						delete(hvV1.VolumesByName, k)
+					}
+				}
+				return nil
+			})
+			if innerXErr != nil || device == "" {
+				return innerXErr
+			}
+
+			// Drops also the local mount of the device of the Volume
+			return props.Alter(hostproperty.MountsV1, func(clonable data.Clonable) fail.Error {
+				hmV1, ok := clonable.(*propertiesv1.HostMounts)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv1.HostMounts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				if path, ok := hmV1.LocalMountsByDevice[device]; ok {
+					delete(hmV1.LocalMountsByDevice, device)
+					delete(hmV1.LocalMountsByPath, path)
+				}
+				return nil
+			})
+
+		case resources.DeadRemoteMount:
+			return props.Alter(hostproperty.MountsV1, func(clonable data.Clonable) fail.Error {
+				hmV1, ok := clonable.(*propertiesv1.HostMounts)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv1.HostMounts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				path, ok := hmV1.RemoteMountsByShareID[id]
+				if !ok {
+					return nil
+				}
+
+				delete(hmV1.RemoteMountsByShareID, id)
+				if mount, ok := hmV1.RemoteMountsByPath[path]; ok {
+					delete(hmV1.RemoteMountsByExport, mount.Export)
+					delete(hmV1.RemoteMountsByPath, path)
+				}
+				return nil
+			})
+
+		default:
+			return fail.InvalidParameterError("kind", "discrepancies of kind '%s' are not repairable", kind)
+		}
+	})
+}
+
+// unsafeStart is the non goroutine-safe version of Start, that does the real work
+func (instance *Host) unsafeStart(ctx context.Context) (xerr fail.Error) {
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	hostName := instance.GetName()
+	hostID := instance.GetID()
+
+	svc := instance.GetService()
+	xerr = svc.StartHost(hostID)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	xerr = retry.WhileUnsuccessfulDelay5Seconds(
+		func() error {
+			if task.Aborted() {
+				return fail.AbortedError(nil, "aborted")
+			}
+
+			return svc.WaitHostState(hostID, hoststate.Started, temporal.GetHostTimeout())
+		},
+		temporal.GetHostStateChangeTimeout(ctx),
+	)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrAborted:
+			if cerr := fail.ConvertError(xerr.Cause()); cerr != nil {
+				return cerr
+			}
+			return xerr
+		case *retry.ErrTimeout:
+			return fail.Wrap(xerr, "timeout waiting Host '%s' to be started", hostName)
+		default:
+			return xerr
+		}
+	}
+
+	instance.unsafeRefreshPublicIP()
+	return nil
+}
+
+// unsafeStop is the non goroutine-safe version of Stop and StopGracefully, that does the real work
+// If gracePeriod > 0, a shutdown of the operating system is tried first
+func (instance *Host) unsafeStop(ctx context.Context, gracePeriod time.Duration) (_ resources.HostStopMethod, xerr fail.Error) {
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return "", xerr
+	}
+
+	hostName := instance.GetName()
+	hostID := instance.GetID()
+	svc := instance.GetService()
+
+	// The public IP would be lost by the stop if its preservation requested at creation failed; tries again
+	instance.unsafePreservePublicIP()
+
+	method := resources.HostStoppedByProvider
+	if gracePeriod > 0 && instance.unsafeShutdownFromGuest(ctx, gracePeriod) {
+		method = resources.HostStoppedFromGuest
+	}
+
+	if method == resources.HostStoppedByProvider {
+		xerr = svc.StopHost(hostID)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return "", xerr
+		}
+
+		xerr = retry.WhileUnsuccessfulDelay5Seconds(
+			func() error {
+				if task.Aborted() {
+					return fail.AbortedError(nil, "aborted")
+				}
+
+				return svc.WaitHostState(hostID, hoststate.Stopped, temporal.GetHostTimeout())
+			},
+			temporal.GetHostStateChangeTimeout(ctx),
+		)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrAborted:
+				if cerr := fail.ConvertError(xerr.Cause()); cerr != nil {
+					return "", cerr
+				}
+				return "", xerr
+			case *retry.ErrTimeout:
+				return "", fail.Wrap(xerr, "timeout waiting Host '%s' to be stopped", hostName)
+			default:
+				return "", xerr
+			}
+		}
+	}
+
+	// Records the way the Host has been stopped; the Host being stopped, a failure is not an error of the stop
+	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(hostproperty.DescriptionV1, func(clonable data.Clonable) fail.Error {
+			hostDescriptionV1, ok := clonable.(*propertiesv1.HostDescription)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostDescription' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			hostDescriptionV1.LastStopMethod = string(method)
+			return nil
+		})
+	})
+	if xerr != nil {
+		logrus.Warnf("Host '%s' stopped, but failed to record the way it has been stopped: %v", hostName, xerr)
+	}
+
+	logrus.Debugf("Host '%s' stopped by %s", hostName, method)
+	return method, nil
+}
+
+// unsafeInspectPublicIP returns the public IP recorded for the Host, and tells if it has to be preserved across stop and
+// start and if it is
+func (instance *Host) unsafeInspectPublicIP() (publicIP string, requested, preserved bool, xerr fail.Error) {
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		innerXErr := props.Inspect(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+			hnV2, ok := clonable.(*propertiesv2.HostNetworking)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			publicIP = hnV2.PublicIPv4
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		// Older metadata may not have description, it's not an error
+		if !props.Lookup(hostproperty.DescriptionV1) {
+			return nil
+		}
+
+		return props.Inspect(hostproperty.DescriptionV1, func(clonable data.Clonable) fail.Error {
+			hostDescriptionV1, ok := clonable.(*propertiesv1.HostDescription)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostDescription' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			requested, preserved = hostDescriptionV1.PreservePublicIP, hostDescriptionV1.PublicIPPreserved
+			return nil
+		})
+	})
+	return publicIP, requested, preserved, xerr
+}
+
+// unsafeSetPublicIP records 'publicIP' as the public IP of the Host and updates the cached information depending on it
+func (instance *Host) unsafeSetPublicIP(publicIP string) fail.Error {
+	var isGateway bool
+	var defaultSubnetID string
+	xerr := instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+			hnV2, ok := clonable.(*propertiesv2.HostNetworking)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			if hnV2.PublicIPv4 == publicIP {
+				return fail.AlteredNothingError()
+			}
+			hnV2.PublicIPv4 = publicIP
+			isGateway, defaultSubnetID = hnV2.IsGateway, hnV2.DefaultSubnetID
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	xerr = instance.updateCachedInformation()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// The access IP of a gateway is cached with the Subnet
+	if isGateway && defaultSubnetID != "" {
+		refreshSubnetHostsGateways(instance.GetService(), defaultSubnetID)
+	}
+	return nil
+}
+
+// unsafePreservePublicIP makes static the public IP of the Host if its preservation has been requested at creation but
+// is not done yet, recording the outcome in metadata
+// A failure is logged and recorded, but is not an error: the Host is usable anyway
+func (instance *Host) unsafePreservePublicIP() {
+	hostName := instance.GetName()
+	publicIP, requested, preserved, xerr := instance.unsafeInspectPublicIP()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		logrus.Warnf("failed to read public IP of Host '%s': %v", hostName, xerr)
+		return
+	}
+	if !requested || preserved {
+		return
+	}
+
+	ahf := abstract.NewHostFull()
+	ahf.Core.ID, ahf.Core.Name = instance.GetID(), hostName
+	ahf.Networking.PublicIPv4 = publicIP
+	reason := preserveHostPublicIP(instance.GetService(), ahf)
+
+	xerr = instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(hostproperty.DescriptionV1, func(clonable data.Clonable) fail.Error {
+			hostDescriptionV1, ok := clonable.(*propertiesv1.HostDescription)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostDescription' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			hostDescriptionV1.PublicIPPreserved = reason == ""
+			hostDescriptionV1.PublicIPPreservationError = reason
+			return nil
+		})
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr == nil && reason == "" {
+		xerr = instance.unsafeSetPublicIP(ahf.Networking.PublicIPv4)
+	}
+	if xerr != nil {
+		logrus.Warnf("failed to record preservation of public IP of Host '%s': %v", hostName, xerr)
+	}
+}
+
+// unsafeRefreshPublicIP records the public IP of the Host just started, if the provider may have allocated a new one
+// (public IP not preserved, on a provider releasing the public IP of a stopped Host)
+// A failure is logged, but is not an error: the Host is started anyway
+func (instance *Host) unsafeRefreshPublicIP() {
+	svc := instance.GetService()
+	if !svc.GetCapabilities().EphemeralPublicIP {
+		return
+	}
+
+	hostName := instance.GetName()
+	publicIP, _, preserved, xerr := instance.unsafeInspectPublicIP()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		logrus.Warnf("failed to read public IP of Host '%s': %v", hostName, xerr)
+		return
+	}
+	if publicIP == "" || preserved {
+		return
+	}
+
+	ahf, xerr := svc.InspectHost(instance.GetID())
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr == nil && ahf.Networking.PublicIPv4 != publicIP {
+		logrus.Infof("public IP of Host '%s' changed from %s to %s while stopped", hostName, publicIP, ahf.Networking.PublicIPv4)
+		xerr = instance.unsafeSetPublicIP(ahf.Networking.PublicIPv4)
+	}
+	if xerr != nil {
+		logrus.Warnf("failed to update public IP of Host '%s' after start: %v", hostName, xerr)
+	}
+}
+
+// unsafeShutdownFromGuest asks the operating system of the Host to shut down, then waits at most gracePeriod for the
+// Host to be stopped; returns true if the Host has been stopped this way
+func (instance *Host) unsafeShutdownFromGuest(ctx context.Context, gracePeriod time.Duration) bool {
+	hostName := instance.GetName()
+
+	// The shutdown may close the connection before the command returns, so an error of execution is not significant;
+	// only a command having run to completion with failure prevents to wait for the stop
+	retcode, _, stderr, xerr := instance.unsafeRun(ctx, "sudo shutdown -h now", nil, outputs.COLLECT, temporal.GetConnectSSHTimeout(), temporal.GetExecutionTimeout())
+	if xerr != nil {
+		logrus.Debugf("execution of shutdown on Host '%s' ended with error, ignored: %v", hostName, xerr)
+	} else if retcode != 0 {
+		logrus.Warnf("failed to shut down Host '%s' from inside (retcode=%d): %s", hostName, retcode, stderr)
+		return false
+	}
+
+	xerr = instance.GetService().WaitHostState(instance.GetID(), hoststate.Stopped, gracePeriod)
+	if xerr != nil {
+		logrus.Warnf("Host '%s' not stopped after a grace period of %s, stopping it with the provider", hostName, temporal.FormatDuration(gracePeriod))
+		return false
+	}
+	return true
+}
@@ -28,12 +28,12 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/CS-SI/SafeScale/lib/server/resources"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/featuretarget"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hostproperty"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/installaction"
 	"github.com/CS-SI/SafeScale/lib/server/resources/operations/remotefile"
 	propertiesv1 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v1"
 	"github.com/CS-SI/SafeScale/lib/utils"
-	"github.com/CS-SI/SafeScale/lib/utils/cli/enums/outputs"
 	"github.com/CS-SI/SafeScale/lib/utils/concurrency"
 	"github.com/CS-SI/SafeScale/lib/utils/data"
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
@@ -48,6 +48,9 @@ const (
 	targetMasters  = "masters"
 	targetNodes    = "nodes"
 	targetGateways = "gateways"
+
+	// stepMaxParallelism is the maximum number of hosts on which a step not serialized runs simultaneously
+	stepMaxParallelism = 8
 )
 
 type stepResult struct {
@@ -199,6 +202,24 @@ func (st stepTargets) parse() (string, string, string, string, fail.Error) {
 	return hostT, masterT, nodeT, gwT, nil
 }
 
+// stepTargetsOf returns the step targets selecting the Hosts of a Cluster corresponding to 'target'
+func stepTargetsOf(target featuretarget.Enum) (stepTargets, fail.Error) {
+	switch target {
+	case featuretarget.Gateways:
+		return stepTargets{targetGateways: "all"}, nil
+	case featuretarget.Masters:
+		return stepTargets{targetMasters: "all"}, nil
+	case featuretarget.Nodes:
+		return stepTargets{targetNodes: "all"}, nil
+	case featuretarget.AllHosts:
+		return stepTargets{targetGateways: "all", targetMasters: "all", targetNodes: "all"}, nil
+	case featuretarget.SingleMaster:
+		return stepTargets{targetMasters: "one"}, nil
+	default:
+		return nil, fail.InvalidParameterError("target", "no step targets correspond to '%s'", target.String())
+	}
+}
+
 // step is a struct containing the needed information to apply the installation
 // step on all selected host targets
 type step struct {
@@ -310,7 +331,22 @@ func (is *step) Run(ctx context.Context, hosts []resources.Host, v data.Map, s r
 			}
 		}
 	} else {
-		subtasks := map[string]concurrency.Task{}
+		tg, xerr := concurrency.NewTaskGroupWithParent(task)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return nil, xerr
+		}
+
+		// Bounds the number of hosts on which the step runs simultaneously
+		semaphore := make(chan struct{}, stepMaxParallelism)
+		boundedRunOnHost := func(t concurrency.Task, p concurrency.TaskParameters) (concurrency.TaskResult, fail.Error) {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			return is.taskRunOnHost(t, p)
+		}
+
+		subtasks := map[string]string{}
 		for _, h := range hosts {
 			tracer.Trace("%s(%s):step(%s)@%s: starting", is.Worker.action.String(), is.Worker.feature.GetName(), is.Name, h.GetName())
 			is.Worker.startTime = time.Now()
@@ -350,29 +386,35 @@ func (is *step) Run(ctx context.Context, hosts []resources.Host, v data.Map, s r
 				return nil, xerr
 			}
 
-			subtask, xerr := concurrency.NewTaskWithParent(task)
+			subtask, xerr := tg.Start(boundedRunOnHost, runOnHostParameters{Host: h, Variables: cloneV})
 			xerr = debug.InjectPlannedFail(xerr)
 			if xerr != nil {
 				return nil, xerr
 			}
 
-			subtask, xerr = subtask.Start(is.taskRunOnHost, runOnHostParameters{Host: h, Variables: cloneV})
+			sid, xerr := subtask.GetID()
 			xerr = debug.InjectPlannedFail(xerr)
 			if xerr != nil {
 				return nil, xerr
 			}
 
-			subtasks[h.GetName()] = subtask
+			subtasks[sid] = h.GetName()
 		}
-		for k, s := range subtasks {
-			outcome, xerr := s.Wait()
-			xerr = debug.InjectPlannedFail(xerr)
-			if xerr != nil {
+
+		results, xerr := tg.WaitGroup()
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			logrus.Warn(tracer.TraceMessage(": %s(%s):step(%s) finished after %s, but failed to recover some results: %s",
+				is.Worker.action.String(), is.Worker.feature.GetName(), is.Name, temporal.FormatDuration(time.Since(is.Worker.startTime)), xerr.Error()))
+		}
+		for sid, k := range subtasks {
+			outcome, ok := results[sid].(resources.UnitResult)
+			if !ok {
 				logrus.Warn(tracer.TraceMessage(": %s(%s):step(%s)@%s finished after %s, but failed to recover result",
 					is.Worker.action.String(), is.Worker.feature.GetName(), is.Name, k, temporal.FormatDuration(time.Since(is.Worker.startTime))))
 				continue
 			}
-			outcomes.AddOne(k, outcome.(resources.UnitResult))
+			outcomes.AddOne(k, outcome)
 
 			if !outcomes.Successful() {
 				if is.Worker.action == installaction.Check { // Checks can fail and it's ok
@@ -470,14 +512,21 @@ func (is *step) taskRunOnHost(task concurrency.Task, params concurrency.TaskPara
 	}
 
 	// Executes the script on the remote host
-	retcode, outrun, _, xerr := p.Host.Run(task.GetContext(), command, outputs.COLLECT, temporal.GetConnectionTimeout(), is.WallTime)
+	result, xerr := p.Host.Execute(task.GetContext(), command, data.NewImmutableKeyValue("ExecutionTimeout", is.WallTime))
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		_ = xerr.Annotate("stdout", outrun)
-		return stepResult{err: xerr, retcode: retcode, output: outrun}, nil
+		if _, ok := xerr.(*fail.ErrTimeout); ok {
+			xerr = fail.Wrap(xerr, "%s of Feature '%s' timed out at step '%s'", strings.ToLower(is.Action.String()), is.Worker.feature.GetName(), is.Name)
+		}
+		if result == nil {
+			return stepResult{err: xerr, retcode: -1}, nil
+		}
+		_ = xerr.Annotate("stdout", result.Stdout)
+		return stepResult{err: xerr, retcode: result.Retcode, output: result.Stdout}, nil
 	}
 
-	return stepResult{success: retcode == 0, completed: true, err: nil, retcode: retcode, output: outrun}, nil
+	logrus.Debugf("step '%s' of feature '%s' on Host '%s' ended with code %d in %s", is.Name, is.Worker.feature.GetName(), p.Host.GetName(), result.Retcode, temporal.FormatDuration(result.Duration))
+	return stepResult{success: result.Retcode == 0, completed: true, err: nil, retcode: result.Retcode, output: result.Stdout}, nil
 }
 
 // realizeVariables replaces any template occuring in every variable
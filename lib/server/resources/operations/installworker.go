@@ -35,6 +35,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clustercomplexity"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterflavor"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/featuretarget"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/featuretargettype"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hostproperty"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/installaction"
@@ -104,6 +105,7 @@ type worker struct {
 	variables data.Map
 	settings  resources.FeatureSettings
 	startTime time.Time
+	deadline  time.Time // the action on the Feature must end before this time
 
 	host *Host
 	// node    bool
@@ -293,15 +295,17 @@ func (w *worker) identifyAllMasters(ctx context.Context) ([]resources.Host, fail
 		if xerr != nil {
 			return nil, xerr
 		}
+		refs := make([]string, 0, len(masters))
 		for _, i := range masters {
-			hostInstance, xerr := LoadHost(w.cluster.GetService(), i)
-			xerr = debug.InjectPlannedFail(xerr)
-			if xerr != nil {
-				return nil, xerr
-			}
-
-			w.allMasters = append(w.allMasters, hostInstance)
+			refs = append(refs, i)
+		}
+		hosts, xerr := LoadHosts(w.cluster.GetService(), refs)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return nil, xerr
 		}
+
+		w.allMasters = append(w.allMasters, hosts...)
 	}
 	return w.allMasters, nil
 }
@@ -339,21 +343,21 @@ func (w *worker) identifyAllNodes(ctx context.Context) ([]resources.Host, fail.E
 	}
 
 	if w.allNodes == nil {
-		var allHosts []resources.Host
 		list, xerr := w.cluster.UnsafeListNodeIDs(ctx)
 		xerr = debug.InjectPlannedFail(xerr)
 		if xerr != nil {
 			return nil, xerr
 		}
+		refs := make([]string, 0, len(list))
 		for _, i := range list {
-			hostInstance, xerr := LoadHost(w.cluster.GetService(), i)
-			xerr = debug.InjectPlannedFail(xerr)
-			if xerr != nil {
-				return nil, xerr
-			}
-
-			allHosts = append(allHosts, hostInstance)
+			refs = append(refs, i)
+		}
+		allHosts, xerr := LoadHosts(w.cluster.GetService(), refs)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return nil, xerr
 		}
+
 		w.allNodes = allHosts
 	}
 	return w.allNodes, nil
@@ -520,6 +524,9 @@ func (w *worker) Proceed(ctx context.Context, v data.Map, s resources.FeatureSet
 		order = strings.Split(pace, ",")
 	}
 
+	timeout := w.timeout()
+	w.deadline = time.Now().Add(timeout)
+
 	// Applies reverseproxy rules and security to make Feature functional (Feature may need it during the install)
 	switch w.action {
 	case installaction.Add:
@@ -557,7 +564,7 @@ func (w *worker) Proceed(ctx context.Context, v data.Map, s resources.FeatureSet
 	}
 
 	// Now enumerate steps and execute each of them
-	for _, k := range order {
+	for i, k := range order {
 		stepKey := stepsKey + "." + k
 		stepMap, ok := steps[strings.ToLower(k)].(map[string]interface{})
 		if !ok {
@@ -565,6 +572,12 @@ func (w *worker) Proceed(ctx context.Context, v data.Map, s resources.FeatureSet
 			return outcomes, fail.SyntaxError(msg, w.feature.GetName(), w.feature.GetDisplayFilename(), stepKey)
 		}
 
+		if time.Now().After(w.deadline) {
+			return outcomes, fail.TimeoutError(nil, timeout, fmt.Sprintf("failed to %s Feature '%s' on '%s' in %s: step '%s' not started", strings.ToLower(w.action.String()), w.feature.GetName(), w.target.GetName(), temporal.FormatDuration(timeout), k))
+		}
+
+		logrus.Infof("%s Feature '%s' on '%s': starting step '%s' (%d/%d)", w.action.String(), w.feature.GetName(), w.target.GetName(), k, i+1, len(order))
+		stepStart := time.Now()
 		subtask, xerr := task.StartInSubtask(w.taskLaunchStep, taskLaunchStepParameters{
 			stepName:  k,
 			stepKey:   stepKey,
@@ -579,9 +592,11 @@ func (w *worker) Proceed(ctx context.Context, v data.Map, s resources.FeatureSet
 		tr, xerr := subtask.Wait()
 		xerr = debug.InjectPlannedFail(xerr)
 		if xerr != nil {
+			logrus.Infof("%s Feature '%s' on '%s': step '%s' (%d/%d) failed after %s", w.action.String(), w.feature.GetName(), w.target.GetName(), k, i+1, len(order), temporal.FormatDuration(time.Since(stepStart)))
 			return outcomes, xerr
 		}
 
+		logrus.Infof("%s Feature '%s' on '%s': step '%s' (%d/%d) done in %s", w.action.String(), w.feature.GetName(), w.target.GetName(), k, i+1, len(order), temporal.FormatDuration(time.Since(stepStart)))
 		if tr != nil {
 			outcome := tr.(*resources.UnitResults)
 			_ = outcomes.Add(k, *outcome)
@@ -655,6 +670,14 @@ func (w *worker) taskLaunchStep(task concurrency.Task, params concurrency.TaskPa
 	var hostsList []resources.Host
 	if w.target.TargetType() == featuretargettype.Host {
 		hostsList, xerr = w.identifyHosts(task.GetContext(), map[string]string{"hosts": "1"})
+	} else if w.settings.Target != featuretarget.Default {
+		// Targets requested in settings override the ones of the step
+		stepT, xerr = stepTargetsOf(w.settings.Target)
+		if xerr != nil {
+			return nil, xerr
+		}
+
+		hostsList, xerr = w.identifyHosts(task.GetContext(), stepT)
 	} else {
 		anon, ok = p.stepMap[yamlTargetsKeyword]
 		if ok {
@@ -765,6 +788,11 @@ func (w *worker) taskLaunchStep(task concurrency.Task, params concurrency.TaskPa
 		}
 	}
 
+	// the step cannot run beyond the time left to the Feature
+	if left := time.Until(w.deadline); left < wallTime {
+		wallTime = left
+	}
+
 	templateCommand, xerr := normalizeScript(data.Map{
 		"reserved_Name":    w.feature.GetName(),
 		"reserved_Content": runContent,
@@ -808,6 +836,14 @@ func (w *worker) taskLaunchStep(task concurrency.Task, params concurrency.TaskPa
 	if !r.Successful() {
 		// If there are some not completed steps, reports them and break
 		if !r.Completed() {
+			// a timeout is reported as is, to be recognizable by the caller
+			for _, k := range r.Keys() {
+				if terr, ok := r.ResultOfKey(k).Error().(*fail.ErrTimeout); ok {
+					logrus.Errorf(strprocess.Capitalize(terr.Error()))
+					return &r, terr
+				}
+			}
+
 			msg := fmt.Sprintf("execution of step '%s::%s' failed on: %v", w.action.String(), p.stepName, r.Uncompleted())
 			logrus.Errorf(strprocess.Capitalize(msg))
 			return &r, fail.NewError(msg)
@@ -826,6 +862,26 @@ func (w *worker) taskLaunchStep(task concurrency.Task, params concurrency.TaskPa
 	return &r, nil
 }
 
+// timeout returns the maximum duration of the action on the Feature: the one requested in settings if any, else the
+// one set in specification file by key 'timeout' of the action (in minutes) if any, else temporal.GetFeatureTimeout()
+func (w *worker) timeout() time.Duration {
+	if w.settings.Timeout > 0 {
+		return w.settings.Timeout
+	}
+
+	if w.rootKey != "" {
+		key := w.rootKey + "." + yamlTimeoutKeyword
+		if w.feature.specs.IsSet(key) {
+			if minutes := w.feature.specs.GetInt(key); minutes > 0 {
+				return time.Duration(minutes) * time.Minute
+			}
+			logrus.Warningf("Invalid value '%s' for '%s', ignored.", w.feature.specs.GetString(key), key)
+		}
+	}
+
+	return temporal.GetFeatureTimeout()
+}
+
 // validateContextForCluster checks if the flavor of the cluster is listed in Feature specification
 // 'feature.suitableFor.cluster'.
 // If no flavors is listed, no flavors are authorized (but using 'cluster: no' is strongly recommended)
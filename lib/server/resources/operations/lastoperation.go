@@ -0,0 +1,77 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operations
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CS-SI/SafeScale/lib/server/resources"
+	propertiesv1 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v1"
+	"github.com/CS-SI/SafeScale/lib/utils/data"
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+	"github.com/CS-SI/SafeScale/lib/utils/serialize"
+)
+
+// recordOperation records in property 'property' of the metadata of 'instance' that the operation 'operationType' is
+// running, and returns the function recording its outcome from the error pointed by its parameter, to be deferred as
+// in 'defer recordOperation(instance, hostproperty.LastOperationV1, "start")(&xerr)'
+// Failures to record are only logged: they must not change the outcome of the operation
+func recordOperation(instance resources.Metadata, property, operationType string) func(*fail.Error) {
+	started := time.Now()
+	xerr := alterLastOperation(instance, property, func(lo *propertiesv1.LastOperation) {
+		lo.Begin(operationType)
+		lo.Started = started
+	})
+	if xerr != nil {
+		logrus.Warnf("failed to record start of operation '%s': %v", operationType, xerr)
+	}
+
+	return func(xerr *fail.Error) {
+		var err error
+		if xerr != nil && *xerr != nil {
+			err = *xerr
+		}
+		// Begins again, so the record is coherent even if the recording of the start failed
+		derr := alterLastOperation(instance, property, func(lo *propertiesv1.LastOperation) {
+			lo.Begin(operationType)
+			lo.Started = started
+			lo.End(err)
+		})
+		if derr != nil {
+			logrus.Warnf("failed to record end of operation '%s': %v", operationType, derr)
+		}
+	}
+}
+
+// alterLastOperation applies 'update' to the record of the last operation stored in property 'property' of the
+// metadata of 'instance'
+func alterLastOperation(instance resources.Metadata, property string, update func(*propertiesv1.LastOperation)) fail.Error {
+	return instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(property, func(clonable data.Clonable) fail.Error {
+			lastOperationV1, ok := clonable.(*propertiesv1.LastOperation)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.LastOperation' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			update(lastOperationV1)
+			return nil
+		})
+	})
+}
@@ -17,6 +17,7 @@
 package operations
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 	"strings"
@@ -42,6 +43,9 @@ const (
 	byIDFolderName = "byID"
 	// byNameFolderName tells in what MetadataFolder to store 'byName' information
 	byNameFolderName = "byName"
+
+	// readBatchParallelism tells how many metadata reads ReadBatch runs at the same time
+	readBatchParallelism = 10
 )
 
 // MetadataCore contains the core functions of a persistent object
@@ -61,6 +65,7 @@ type MetadataCore struct {
 	loaded            bool
 	committed         bool
 	kindSplittedStore bool // tells if data read/write is done directly from/to folder (when false) or from/to subfolders (when true)
+	schemaVersion     uint // schema version of the metadata as read from Object Storage (see metadataschema.go)
 }
 
 func NullCore() *MetadataCore {
@@ -123,6 +128,38 @@ func (c *MetadataCore) GetService() iaas.Service {
 	return c.folder.GetService()
 }
 
+// useMetadataBucket makes the instance read and write its metadata in the bucket named 'bucketName' instead of the
+// metadata bucket of the tenant; the Service returned by GetService() is then bound to this bucket, so the resources
+// created or loaded with it use the same bucket
+// Must be called before the instance carries or reads data
+func (c *MetadataCore) useMetadataBucket(bucketName string) fail.Error {
+	if c == nil || c.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.loaded {
+		return fail.NotAvailableError("cannot change metadata bucket of %s already carrying a value", c.kind)
+	}
+
+	svc, xerr := iaas.WithMetadataBucket(c.folder.GetService(), bucketName)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	fld, xerr := NewMetadataFolder(svc, c.folder.Path())
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	c.folder = fld
+	return nil
+}
+
 // GetID returns the id of the data protected
 // satisfies interface data.Identifiable
 func (c *MetadataCore) GetID() string {
@@ -287,6 +324,98 @@ func (c *MetadataCore) Alter(callback resources.Callback, options ...data.Immuta
 	return fail.ConvertError(c.notifyObservers())
 }
 
+// AlterMany protects the data for exclusive write like Alter, but runs all the callbacks before writing metadata only
+// once, as a transaction: if a callback or the write fails, the data in memory are rolled back to their content before
+// the call
+// Valid keyvalues for options are the ones of Alter
+func (c *MetadataCore) AlterMany(callbacks []resources.Callback, options ...data.ImmutableKeyValue) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if c == nil || (c != nil && c.IsNull()) {
+		return fail.InvalidInstanceError()
+	}
+	if len(callbacks) == 0 {
+		return fail.InvalidParameterError("callbacks", "cannot be empty slice")
+	}
+	for k, v := range callbacks {
+		if v == nil {
+			return fail.InvalidParameterError("callbacks", "entry #%d cannot be nil", k)
+		}
+	}
+	if c.shielded == nil {
+		return fail.InvalidInstanceContentError("c.shielded", "cannot be nil")
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Make sure c.properties is populated
+	if c.properties == nil {
+		c.properties, xerr = serialize.NewJSONProperties("resources." + c.kind)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+	}
+
+	doReload := true
+	for _, v := range options {
+		switch v.Key() {
+		case "Reload":
+			doReload = v.Value().(bool)
+		default:
+		}
+	}
+	// Reload reloads data from objectstorage to be sure to have the last revision
+	if doReload {
+		xerr = c.reload()
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return fail.Wrap(xerr, "failed to reload metadata")
+		}
+	}
+
+	// keeps a copy of the data to be able to roll back
+	shieldedBackup, propertiesBackup, committedBackup := c.shielded.Clone(), c.properties.Clone(), c.committed
+	rollback := func() {
+		c.shielded, c.properties, c.committed = shieldedBackup, propertiesBackup, committedBackup
+	}
+
+	altered := false
+	for _, v := range callbacks {
+		callback := v
+		xerr = c.shielded.Alter(func(clonable data.Clonable) fail.Error {
+			return callback(clonable, c.properties)
+		})
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrAlteredNothing:
+				continue
+			default:
+				rollback()
+				return xerr
+			}
+		}
+		altered = true
+	}
+	if !altered {
+		return nil
+	}
+
+	c.committed = false
+
+	xerr = c.write()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		rollback()
+		return xerr
+	}
+
+	// notify observers there has been changed in the instance
+	return fail.ConvertError(c.notifyObservers())
+}
+
 // Carry links metadata with real data
 // If c is already carrying a shielded data, returns fail.NotAvailableError
 //
@@ -392,7 +521,13 @@ func (c *MetadataCore) Read(ref string) (xerr fail.Error) {
 	c.loaded = true
 	c.committed = true
 
-	return c.updateIdentity()
+	xerr = c.updateIdentity()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	return c.upgradeSchema(true)
 }
 
 // ReadByID reads a metadata identified by ID from Object Storage
@@ -417,7 +552,7 @@ func (c *MetadataCore) ReadByID(id string) (xerr fail.Error) {
 			func() error {
 				if innerXErr := c.readByID(id); innerXErr != nil {
 					switch innerXErr.(type) {
-					case *fail.ErrNotFound: // If not found, stop immediately
+					case *fail.ErrNotFound, *fail.ErrForbidden: // If not found or forbidden, stop immediately
 						return retry.StopRetryError(innerXErr)
 					default:
 						return innerXErr
@@ -432,7 +567,7 @@ func (c *MetadataCore) ReadByID(id string) (xerr fail.Error) {
 			func() error {
 				if innerXErr := c.readByName(id); innerXErr != nil {
 					switch innerXErr.(type) {
-					case *fail.ErrNotFound: // If not found, stop immediately
+					case *fail.ErrNotFound, *fail.ErrForbidden: // If not found or forbidden, stop immediately
 						return retry.StopRetryError(innerXErr)
 					default:
 						return innerXErr
@@ -458,7 +593,13 @@ func (c *MetadataCore) ReadByID(id string) (xerr fail.Error) {
 	c.loaded = true
 	c.committed = true
 
-	return c.updateIdentity()
+	xerr = c.updateIdentity()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	return c.upgradeSchema(true)
 }
 
 // readByID reads a metadata identified by ID from Object Storage
@@ -490,6 +631,8 @@ func (c *MetadataCore) readByReference(ref string) (xerr fail.Error) {
 			if innerXErr := c.readByID(ref); innerXErr != nil {
 				innerXErr = debug.InjectPlannedFail(innerXErr)
 				switch innerXErr.(type) {
+				case *fail.ErrForbidden:
+					return retry.StopRetryError(innerXErr)
 				case *fail.ErrNotFound:
 					if c.kindSplittedStore {
 						// Try to read by name
@@ -498,7 +641,7 @@ func (c *MetadataCore) readByReference(ref string) (xerr fail.Error) {
 					}
 					if innerXErr != nil {
 						switch innerXErr.(type) {
-						case *fail.ErrNotFound:
+						case *fail.ErrNotFound, *fail.ErrForbidden:
 							return retry.StopRetryError(innerXErr)
 						default:
 							return innerXErr
@@ -618,7 +761,7 @@ func (c *MetadataCore) reload() (xerr fail.Error) {
 			func() error {
 				if innerXErr := c.readByID(id); innerXErr != nil {
 					switch innerXErr.(type) {
-					case *fail.ErrNotFound: // If not found, stop immediately
+					case *fail.ErrNotFound, *fail.ErrForbidden: // If not found or forbidden, stop immediately
 						return retry.StopRetryError(innerXErr)
 					default:
 						return innerXErr
@@ -647,7 +790,7 @@ func (c *MetadataCore) reload() (xerr fail.Error) {
 			func() error {
 				if innerXErr := c.readByName(name); innerXErr != nil {
 					switch innerXErr.(type) {
-					case *fail.ErrNotFound: // If not found, stop immediately
+					case *fail.ErrNotFound, *fail.ErrForbidden: // If not found or forbidden, stop immediately
 						return retry.StopRetryError(innerXErr)
 					default:
 						return innerXErr
@@ -671,16 +814,32 @@ func (c *MetadataCore) reload() (xerr fail.Error) {
 	c.loaded = true
 	c.committed = true
 
+	// Upgrades in memory only; metadata will be written back on next alteration
+	xerr = c.upgradeSchema(false)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
 	return fail.ConvertError(c.notifyObservers())
 }
 
 // BrowseFolder walks through MetadataFolder and executes a callback for each entries
 func (c *MetadataCore) BrowseFolder(callback func(buf []byte) fail.Error) (xerr fail.Error) {
+	return c.BrowseFolderWithContext(context.Background(), callback)
+}
+
+// BrowseFolderWithContext walks through MetadataFolder and executes a callback for each entries, stopping as soon as
+// 'ctx' is done (see MetadataFolder.BrowseWithContext)
+func (c *MetadataCore) BrowseFolderWithContext(ctx context.Context, callback func(buf []byte) fail.Error) (xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
 	if c == nil || (c != nil && c.IsNull()) {
 		return fail.InvalidInstanceError()
 	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
 	if callback == nil {
 		return fail.InvalidParameterError("callback", "cannot be nil")
 	}
@@ -689,11 +848,11 @@ func (c *MetadataCore) BrowseFolder(callback func(buf []byte) fail.Error) (xerr
 	defer c.lock.RUnlock()
 
 	if c.kindSplittedStore {
-		return c.folder.Browse(byIDFolderName, func(buf []byte) fail.Error {
+		return c.folder.BrowseWithContext(ctx, byIDFolderName, func(buf []byte) fail.Error {
 			return callback(buf)
 		})
 	}
-	return c.folder.Browse("", func(buf []byte) fail.Error {
+	return c.folder.BrowseWithContext(ctx, "", func(buf []byte) fail.Error {
 		return callback(buf)
 	})
 }
@@ -859,6 +1018,7 @@ func (c *MetadataCore) serialize() (_ []byte, xerr fail.Error) {
 	}
 
 	shieldedMapped["properties"] = propsMapped
+	shieldedMapped[schemaVersionFieldName] = currentSchemaVersion(c.kind)
 	// logrus.Tracef("everything mapped:\n%s\n", spew.Sdump(shieldedMapped))
 
 	r, err := json.Marshal(shieldedMapped)
@@ -912,6 +1072,18 @@ func (c *MetadataCore) deserialize(buf []byte) (xerr fail.Error) {
 		}
 	}
 
+	// metadata without schema version are legacy ones, with schema version 0
+	var schemaVersion uint
+	if version, ok := mapped[schemaVersionFieldName].(float64); ok {
+		schemaVersion = uint(version)
+		delete(mapped, schemaVersionFieldName)
+	}
+	xerr = checkSchemaVersion(c.kind, schemaVersion)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
 	jsoned, err := json.Marshal(mapped)
 	err = debug.InjectPlannedError(err)
 	if err != nil {
@@ -937,6 +1109,8 @@ func (c *MetadataCore) deserialize(buf []byte) (xerr fail.Error) {
 			return fail.Wrap(xerr, "failed to deserialize properties")
 		}
 	}
+
+	c.schemaVersion = schemaVersion
 	return nil
 }
 
@@ -1064,3 +1238,56 @@ func (c *MetadataCore) RemoveObserver(name string) error {
 	delete(c.observers, name)
 	return nil
 }
+
+// ReadBatch reads in parallel the metadata of several instances of the same kind, with at most readBatchParallelism
+// reads running at the same time.
+// 'loader' is called once per ref and is in charge of reading the metadata (usually going through the cache of the kind,
+// like LoadHost does, so the cache gets populated).
+// Returns the instances loaded, in the same order as 'refs' (entry is nil for refs that failed to load); errors are
+// aggregated in a fail.ErrorList, one error per ref that failed.
+func ReadBatch(refs []string, loader func(ref string) (data.Identifiable, fail.Error)) (_ []data.Identifiable, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if loader == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("loader")
+	}
+
+	var (
+		wg       sync.WaitGroup
+		errLock  sync.Mutex
+		errors   []error
+		results  = make([]data.Identifiable, len(refs))
+		throttle = make(chan struct{}, readBatchParallelism)
+	)
+	for i, ref := range refs {
+		if ref = strings.TrimSpace(ref); ref == "" {
+			continue
+		}
+
+		wg.Add(1)
+		throttle <- struct{}{}
+		go func(index int, ref string) {
+			defer func() {
+				<-throttle
+				wg.Done()
+			}()
+
+			instance, innerXErr := loader(ref)
+			innerXErr = debug.InjectPlannedFail(innerXErr)
+			if innerXErr != nil {
+				errLock.Lock()
+				errors = append(errors, fail.Wrap(innerXErr, "failed to read metadata of '%s'", ref))
+				errLock.Unlock()
+				return
+			}
+
+			results[index] = instance
+		}(i, ref)
+	}
+	wg.Wait()
+
+	if len(errors) > 0 {
+		return results, fail.NewErrorList(errors)
+	}
+	return results, nil
+}
@@ -18,6 +18,7 @@ package operations
 
 import (
 	"bytes"
+	"context"
 	"strings"
 	"time"
 
@@ -338,9 +339,28 @@ func (f MetadataFolder) Write(path string, name string, content []byte, options
 
 // Browse browses the content of a specific path in Metadata and executes 'callback' on each entry
 func (f MetadataFolder) Browse(path string, callback folderDecoderCallback) fail.Error {
+	return f.BrowseWithContext(context.Background(), path, callback)
+}
+
+// BrowseWithContext browses the content of a specific path in Metadata like Browse, but stops as soon as 'ctx' is done,
+// including while an entry is being read
+// Returns *fail.ErrTimeout if the deadline of 'ctx' is exceeded, *fail.ErrAborted if 'ctx' is cancelled; in both cases
+// 'callback' has already been executed on the entries read before
+func (f MetadataFolder) BrowseWithContext(ctx context.Context, path string, callback folderDecoderCallback) fail.Error {
 	if f.IsNull() {
 		return fail.InvalidInstanceError()
 	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if callback == nil {
+		return fail.InvalidParameterCannotBeNilError("callback")
+	}
+
+	xerr := browseContextError(ctx)
+	if xerr != nil {
+		return xerr
+	}
 
 	absPath := f.absolutePath(path)
 	metadataBucket := f.getBucket()
@@ -358,15 +378,22 @@ func (f MetadataFolder) Browse(path string, callback folderDecoderCallback) fail
 
 	var err error
 	for _, i := range list {
-		var buffer bytes.Buffer
-		xerr = f.service.ReadObject(metadataBucket.Name, i, &buffer, 0, 0)
+		xerr = browseContextError(ctx)
+		if xerr != nil {
+			return xerr
+		}
+
+		data, xerr := f.readObjectWithContext(ctx, metadataBucket.Name, i)
 		xerr = debug.InjectPlannedFail(xerr)
 		if xerr != nil {
-			logrus.Errorf("Error browsing metadata: reading from buffer: %+v", xerr)
+			switch xerr.(type) {
+			case *fail.ErrTimeout, *fail.ErrAborted:
+			default:
+				logrus.Errorf("Error browsing metadata: reading from buffer: %+v", xerr)
+			}
 			return xerr
 		}
 
-		data := buffer.Bytes()
 		if f.crypt {
 			data, err = crypt.Decrypt(data, f.cryptKey)
 			err = debug.InjectPlannedError(err)
@@ -383,3 +410,50 @@ func (f MetadataFolder) Browse(path string, callback folderDecoderCallback) fail
 	}
 	return nil
 }
+
+// readObjectWithContext reads the object 'name' in bucket 'bucketName', giving up as soon as 'ctx' is done
+// The read in progress cannot be interrupted: it ends in background and its content is dropped
+func (f MetadataFolder) readObjectWithContext(ctx context.Context, bucketName, name string) ([]byte, fail.Error) {
+	read := func() (_ []byte, xerr fail.Error) {
+		defer fail.OnPanic(&xerr)
+
+		var buffer bytes.Buffer
+		xerr = f.service.ReadObject(bucketName, name, &buffer, 0, 0)
+		return buffer.Bytes(), xerr
+	}
+
+	// context that cannot be done, no need to watch it
+	if ctx.Done() == nil {
+		return read()
+	}
+
+	type readResult struct {
+		data []byte
+		xerr fail.Error
+	}
+	done := make(chan readResult, 1) // buffered, so the read in background does not block if nobody waits for it anymore
+	go func() {
+		data, xerr := read()
+		done <- readResult{data: data, xerr: xerr}
+	}()
+
+	select {
+	case r := <-done:
+		return r.data, r.xerr
+	case <-ctx.Done():
+		return nil, browseContextError(ctx)
+	}
+}
+
+// browseContextError returns the error to report when 'ctx' is done during a browse of metadata: *fail.ErrTimeout if
+// its deadline is exceeded, *fail.ErrAborted if it is cancelled, nil if it is not done
+func browseContextError(ctx context.Context) fail.Error {
+	switch err := ctx.Err(); err {
+	case nil:
+		return nil
+	case context.DeadlineExceeded:
+		return fail.TimeoutError(err, 0, "browse of metadata interrupted by deadline")
+	default:
+		return fail.AbortedError(err, "browse of metadata aborted")
+	}
+}
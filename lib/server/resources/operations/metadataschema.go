@@ -0,0 +1,104 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operations
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/CS-SI/SafeScale/lib/utils/data"
+	"github.com/CS-SI/SafeScale/lib/utils/debug"
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+	"github.com/CS-SI/SafeScale/lib/utils/serialize"
+)
+
+const (
+	// schemaVersionFieldName is the name of the field containing the schema version in serialized metadata
+	schemaVersionFieldName = "schema_version"
+)
+
+// metadataMigration describes how to upgrade the metadata of a resource from one schema version to the next one
+type metadataMigration struct {
+	description string
+	apply       func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error
+}
+
+// metadataMigrations contains, by kind of resource, the ordered list of migrations to apply on load
+// The migration at index i upgrades metadata from schema version i to schema version i+1; schema version 0 designates
+// legacy metadata written before the stamping of schema version.
+// Migrations must only be appended, never removed or reordered.
+var metadataMigrations = map[string][]metadataMigration{
+	hostKind: {
+		{description: "convert property NetworkV1 to NetworkV2", apply: migrateHostNetworkV1ToV2},
+	},
+	clusterKind: {
+		{description: "convert property NodesV1 or NodesV2 to NodesV3", apply: migrateClusterNodesToV3},
+		{description: "convert property NetworkV1 or NetworkV2 to NetworkV3", apply: migrateClusterNetworkToV3},
+		{description: "convert property DefaultsV1 to DefaultsV2", apply: migrateClusterDefaultsToV2},
+	},
+}
+
+// currentSchemaVersion returns the schema version of the metadata of resource of kind 'kind' handled by the running binary
+func currentSchemaVersion(kind string) uint {
+	return uint(len(metadataMigrations[kind]))
+}
+
+// checkSchemaVersion refuses metadata written with a schema more recent than the one handled by the running binary
+func checkSchemaVersion(kind string, version uint) fail.Error {
+	if current := currentSchemaVersion(kind); version > current {
+		return fail.ForbiddenError("metadata of %s has been written with schema version %d, newer than the schema version %d supported by this binary; upgrade SafeScale to handle it", kind, version, current)
+	}
+	return nil
+}
+
+// upgradeSchema applies in sequence the migrations needed to bring the metadata to the current schema version
+// If 'persist' is true and at least one migration has been applied, the metadata is written back
+// Note: must be called after locking the instance
+func (c *MetadataCore) upgradeSchema(persist bool) (xerr fail.Error) {
+	migrations := metadataMigrations[c.kind]
+	current := uint(len(migrations))
+	if c.schemaVersion >= current {
+		return nil
+	}
+
+	for i := c.schemaVersion; i < current; i++ {
+		m := migrations[i]
+		logrus.Debugf("upgrading metadata of %s '%s' from schema version %d to %d: %s", c.kind, c.GetName(), i, i+1, m.description)
+		xerr = c.shielded.Alter(func(clonable data.Clonable) fail.Error {
+			return m.apply(clonable, c.properties)
+		})
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrAlteredNothing:
+				// continue
+			default:
+				return fail.Wrap(xerr, "failed to upgrade metadata of %s '%s' to schema version %d", c.kind, c.GetName(), i+1)
+			}
+		}
+	}
+	c.schemaVersion = current
+
+	if persist {
+		c.committed = false
+		xerr = c.write()
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return fail.Wrap(xerr, "failed to write upgraded metadata of %s '%s'", c.kind, c.GetName())
+		}
+	}
+	return nil
+}
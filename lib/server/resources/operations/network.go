@@ -19,6 +19,7 @@ package operations
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 
@@ -30,15 +31,18 @@ import (
 	"github.com/CS-SI/SafeScale/lib/server/resources"
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/networkproperty"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/subnetproperty"
 	propertiesv1 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v1"
 	"github.com/CS-SI/SafeScale/lib/utils/concurrency"
 	"github.com/CS-SI/SafeScale/lib/utils/data"
 	"github.com/CS-SI/SafeScale/lib/utils/data/cache"
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
+	"github.com/CS-SI/SafeScale/lib/utils/debug/tracing"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
 	netretry "github.com/CS-SI/SafeScale/lib/utils/net"
 	"github.com/CS-SI/SafeScale/lib/utils/retry"
 	"github.com/CS-SI/SafeScale/lib/utils/serialize"
+	"github.com/CS-SI/SafeScale/lib/utils/strprocess"
 	"github.com/CS-SI/SafeScale/lib/utils/temporal"
 )
 
@@ -236,6 +240,13 @@ func (instance *Network) Create(ctx context.Context, req abstract.NetworkRequest
 		return fail.AbortedError(nil, "aborted")
 	}
 
+	if req.CIDR != "" {
+		req.CIDR, xerr = abstract.NormalizeCIDR(req.CIDR)
+		if xerr != nil {
+			return xerr
+		}
+	}
+
 	tracer := debug.NewTracer(task, true, "('%s', '%s')", req.Name, req.CIDR).WithStopwatch().Entering()
 	defer tracer.Exiting()
 
@@ -707,9 +718,298 @@ func (instance *Network) AbandonSubnet(ctx context.Context, subnetID string) (xe
 	})
 }
 
+// SelectSubnetForHost returns the Subnet of the Network having the most free IP addresses, respecting the exclusions
+// and the required tags in criteria
+// Returns *fail.ErrNotFound if no Subnet respects criteria, *fail.ErrNotAvailable if no eligible Subnet has free IP address
+func (instance *Network) SelectSubnetForHost(ctx context.Context, criteria abstract.SubnetSelectionCriteria) (_ resources.Subnet, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	defer debug.NewTracer(task, tracing.ShouldTrace("resources.network")).Entering().Exiting()
+
+	excluded := make(map[string]struct{}, len(criteria.Excluded))
+	for _, v := range criteria.Excluded {
+		excluded[v] = struct{}{}
+	}
+
+	var candidates []string
+	instance.lock.RLock()
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Inspect(networkproperty.SubnetsV1, func(clonable data.Clonable) fail.Error {
+			nsV1, ok := clonable.(*propertiesv1.NetworkSubnets)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.NetworkSubnets' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			for id, name := range nsV1.ByID {
+				if _, ok := excluded[id]; ok {
+					continue
+				}
+				if _, ok := excluded[name]; ok {
+					continue
+				}
+				candidates = append(candidates, id)
+			}
+			return nil
+		})
+	})
+	instance.lock.RUnlock()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	// sorts candidates to make selection deterministic when several Subnets have the same number of free IPs
+	sort.Strings(candidates)
+
+	var (
+		selected      resources.Subnet
+		selectedFree  uint
+		eligibleCount int
+	)
+	svc := instance.GetService()
+	for _, v := range candidates {
+		if task.Aborted() {
+			if selected != nil {
+				selected.Released()
+			}
+			return nil, fail.AbortedError(nil, "aborted")
+		}
+
+		subnetInstance, xerr := LoadSubnet(svc, instance.GetID(), v)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrNotFound:
+				// Subnet vanished in the meantime, ignore it
+				continue
+			default:
+				if selected != nil {
+					selected.Released()
+				}
+				return nil, xerr
+			}
+		}
+
+		var (
+			eligible bool
+			free     uint
+		)
+		xerr = subnetInstance.Review(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+			as, ok := clonable.(*abstract.Subnet)
+			if !ok {
+				return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			for k, v := range criteria.RequiredTags {
+				if value, ok := as.Tags[k]; !ok || value != v {
+					return nil
+				}
+			}
+
+			eligible = true
+			var innerXErr fail.Error
+			free, innerXErr = subnetInstance.(*Subnet).unsafeCountFreeIPs(as, props)
+			return innerXErr
+		})
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			subnetInstance.Released()
+			if selected != nil {
+				selected.Released()
+			}
+			return nil, xerr
+		}
+
+		if !eligible {
+			subnetInstance.Released()
+			continue
+		}
+
+		eligibleCount++
+		if free > selectedFree {
+			if selected != nil {
+				selected.Released()
+			}
+			selected = subnetInstance
+			selectedFree = free
+		} else {
+			subnetInstance.Released()
+		}
+	}
+
+	if eligibleCount == 0 {
+		return nil, fail.NotFoundError("failed to find a Subnet in Network '%s' respecting selection criteria", instance.GetName())
+	}
+	if selected == nil {
+		return nil, fail.NotAvailableError("no Subnet in Network '%s' respecting selection criteria has free IP address", instance.GetName())
+	}
+
+	logrus.Debugf("Selected Subnet '%s' in Network '%s' (%d free IP%s)", selected.GetName(), instance.GetName(), selectedFree, strprocess.Plural(selectedFree))
+	return selected, nil
+}
+
+// InspectUsage returns the Subnets of the Network with their occupation, and the number of CIDR still available for single Hosts
+func (instance *Network) InspectUsage(ctx context.Context) (_ *abstract.NetworkUsage, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	defer debug.NewTracer(task, tracing.ShouldTrace("resources.network")).Entering().Exiting()
+
+	var subnetIDs []string
+	out := &abstract.NetworkUsage{}
+	instance.lock.RLock()
+	xerr = instance.Review(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		innerXErr := props.Inspect(networkproperty.SubnetsV1, func(clonable data.Clonable) fail.Error {
+			nsV1, ok := clonable.(*propertiesv1.NetworkSubnets)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.NetworkSubnets' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			for id := range nsV1.ByID {
+				subnetIDs = append(subnetIDs, id)
+			}
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		return props.Inspect(networkproperty.SingleHostsV1, func(clonable data.Clonable) fail.Error {
+			nshV1, ok := clonable.(*propertiesv1.NetworkSingleHosts)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.NetworkSingleHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			out.FreeSingleHostSlots = nshV1.CountFreeSlots()
+			return nil
+		})
+	})
+	instance.lock.RUnlock()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	sort.Strings(subnetIDs)
+	svc := instance.GetService()
+	for _, v := range subnetIDs {
+		if task.Aborted() {
+			return nil, fail.AbortedError(nil, "aborted")
+		}
+
+		subnetInstance, xerr := LoadSubnet(svc, instance.GetID(), v)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrNotFound:
+				// Subnet vanished in the meantime, ignore it
+				continue
+			default:
+				return nil, xerr
+			}
+		}
+
+		xerr = subnetInstance.Review(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+			as, ok := clonable.(*abstract.Subnet)
+			if !ok {
+				return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			usage := abstract.SubnetUsage{
+				ID:         as.ID,
+				Name:       as.Name,
+				CIDR:       as.CIDR,
+				GatewayIDs: append([]string{}, as.GatewayIDs...),
+			}
+
+			gateways := make(map[string]struct{}, len(as.GatewayIDs))
+			for _, v := range as.GatewayIDs {
+				gateways[v] = struct{}{}
+			}
+			innerXErr := props.Inspect(subnetproperty.HostsV1, func(clonable data.Clonable) fail.Error {
+				shV1, ok := clonable.(*propertiesv1.SubnetHosts)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv1.SubnetHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				for k := range shV1.ByID {
+					if _, ok := gateways[k]; !ok {
+						usage.HostCount++
+					}
+				}
+				return nil
+			})
+			if innerXErr != nil {
+				return innerXErr
+			}
+
+			usage.FreeIPCount, innerXErr = subnetInstance.(*Subnet).unsafeCountFreeIPs(as, props)
+			if innerXErr != nil {
+				return innerXErr
+			}
+
+			out.Subnets = append(out.Subnets, usage)
+			return nil
+		})
+		subnetInstance.Released()
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return nil, xerr
+		}
+	}
+
+	return out, nil
+}
+
 // FreeCIDRForSingleHost frees the CIDR index inside the Network 'Network'
 func FreeCIDRForSingleHost(network resources.Network, index uint) fail.Error {
 	return network.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+		innerXErr := props.Alter(networkproperty.SingleHostReservationsV1, func(clonable data.Clonable) fail.Error {
+			nshrV1, ok := clonable.(*propertiesv1.NetworkSingleHostReservations)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.NetworkSingleHostReservations' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			nshrV1.Commit(index)
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
 		return props.Alter(networkproperty.SingleHostsV1, func(clonable data.Clonable) fail.Error {
 			nshV1, ok := clonable.(*propertiesv1.NetworkSingleHosts)
 			if !ok {
@@ -721,3 +1021,19 @@ func FreeCIDRForSingleHost(network resources.Network, index uint) fail.Error {
 		})
 	})
 }
+
+// CommitCIDRForSingleHost marks the CIDR index reserved inside the Network 'network' as committed, once recorded in
+// the metadata of the Subnet using it; a committed index is not reclaimed anymore by ReserveCIDRForSingleHost
+func CommitCIDRForSingleHost(network resources.Network, index uint) fail.Error {
+	return network.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+		return props.Alter(networkproperty.SingleHostReservationsV1, func(clonable data.Clonable) fail.Error {
+			nshrV1, ok := clonable.(*propertiesv1.NetworkSingleHostReservations)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.NetworkSingleHostReservations' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			nshrV1.Commit(index)
+			return nil
+		})
+	})
+}
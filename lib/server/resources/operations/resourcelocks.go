@@ -0,0 +1,144 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operations
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/CS-SI/SafeScale/lib/server/iaas"
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+)
+
+// Kinds of resources serialized by resourceLocks
+// Note: locks are acquired in the lexical order of their keys, so the kind of a resource must sort before the kinds of
+// the resources it contains (a Cluster operation may act on its Hosts, not the reverse)
+const (
+	clusterLockKind = "cluster"
+	hostLockKind    = "host"
+)
+
+// resourceLock is a named lock, usable with a context
+type resourceLock struct {
+	sem   chan struct{}
+	users uint
+}
+
+// resourceLockManager serializes the mutating operations on a same resource, even if they use distinct instances
+// loaded from metadata; the in-process instance.lock only protects one instance
+type resourceLockManager struct {
+	lock  sync.Mutex
+	locks map[string]*resourceLock
+}
+
+var resourceLocks = &resourceLockManager{locks: map[string]*resourceLock{}}
+
+// resourceLockKey builds the key of the lock of a resource of a service
+func resourceLockKey(svc iaas.Service, kind, id string) string {
+	return kind + ":" + svc.GetName() + ":" + id
+}
+
+// acquire returns the lock corresponding to key, registering the caller as user
+func (m *resourceLockManager) acquire(key string) *resourceLock {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	item, ok := m.locks[key]
+	if !ok {
+		item = &resourceLock{sem: make(chan struct{}, 1)}
+		m.locks[key] = item
+	}
+	item.users++
+	return item
+}
+
+// release unregisters the caller as user of the lock corresponding to key, forgetting the lock when unused
+func (m *resourceLockManager) release(key string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if item, ok := m.locks[key]; ok {
+		item.users--
+		if item.users == 0 {
+			delete(m.locks, key)
+		}
+	}
+}
+
+// lockKey locks the key, waiting until the lock is available or the context is done
+func (m *resourceLockManager) lockKey(ctx context.Context, key string) fail.Error {
+	item := m.acquire(key)
+	select {
+	case item.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		m.release(key)
+		return fail.AbortedError(ctx.Err(), "aborted while waiting for lock on '%s'", key)
+	}
+}
+
+// unlockKey unlocks the key
+func (m *resourceLockManager) unlockKey(key string) {
+	m.lock.Lock()
+	item, ok := m.locks[key]
+	m.lock.Unlock()
+	if !ok {
+		return
+	}
+
+	<-item.sem
+	m.release(key)
+}
+
+// Lock locks the keys in lexical order, to prevent deadlocks between operations locking several resources
+// Returns a function to call to unlock all the keys
+// Note: the lock is not reentrant; an operation must not lock a key it already holds
+func (m *resourceLockManager) Lock(ctx context.Context, keys ...string) (func(), fail.Error) {
+	sorted := make([]string, 0, len(keys))
+	seen := map[string]bool{}
+	for _, v := range keys {
+		if !seen[v] {
+			seen[v] = true
+			sorted = append(sorted, v)
+		}
+	}
+	sort.Strings(sorted)
+
+	unlock := func(locked []string) {
+		for i := len(locked) - 1; i >= 0; i-- {
+			m.unlockKey(locked[i])
+		}
+	}
+	for k, v := range sorted {
+		if xerr := m.lockKey(ctx, v); xerr != nil {
+			unlock(sorted[:k])
+			return nil, xerr
+		}
+	}
+	return func() { unlock(sorted) }, nil
+}
+
+// lockCluster locks the Cluster against the mutating operations running on it in the daemon, whatever the instance used
+func lockCluster(ctx context.Context, instance *Cluster) (func(), fail.Error) {
+	return resourceLocks.Lock(ctx, resourceLockKey(instance.GetService(), clusterLockKind, instance.GetID()))
+}
+
+// lockHost locks the Host against the mutating operations running on it in the daemon, whatever the instance used
+func lockHost(ctx context.Context, instance *Host) (func(), fail.Error) {
+	return resourceLocks.Lock(ctx, resourceLockKey(instance.GetService(), hostLockKind, instance.GetID()))
+}
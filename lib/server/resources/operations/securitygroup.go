@@ -41,6 +41,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
 	"github.com/CS-SI/SafeScale/lib/utils/retry"
 	"github.com/CS-SI/SafeScale/lib/utils/serialize"
+	"github.com/CS-SI/SafeScale/lib/utils/strprocess"
 )
 
 const (
@@ -168,6 +169,107 @@ func LoadSecurityGroup(svc iaas.Service, ref string) (rsg resources.SecurityGrou
 	return rsg, nil
 }
 
+// EnsureSecurityGroup makes sure a Security Group named 'name' exists in the Network 'networkID' with the rules 'rules':
+// the Security Group is created with these rules if it does not exist, otherwise its rules are reconciled with them
+// (cf. SecurityGroup.Reconcile)
+// Returns fail.ErrInvalidRequest if the Security Group exists in another Network
+// The caller has to call Released() on the returned instance
+func EnsureSecurityGroup(ctx context.Context, svc iaas.Service, networkID, name string, rules abstract.SecurityGroupRules) (_ resources.SecurityGroup, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if svc == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("svc")
+	}
+	if networkID == "" {
+		return nil, fail.InvalidParameterError("networkID", "cannot be empty string")
+	}
+	if name == "" {
+		return nil, fail.InvalidParameterError("name", "cannot be empty string")
+	}
+	for k, v := range rules {
+		if v.IsNull() {
+			return nil, fail.InvalidParameterError("rules", "entry #%d cannot be null value of 'abstract.SecurityGroupRule'", k)
+		}
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.security-group"), "('%s')", name).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	rsg, xerr := LoadSecurityGroup(svc, name)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		switch xerr.(type) {
+		case *fail.ErrNotFound:
+			rsg, xerr = NewSecurityGroup(svc)
+			xerr = debug.InjectPlannedFail(xerr)
+			if xerr != nil {
+				return nil, xerr
+			}
+
+			xerr = rsg.Create(ctx, networkID, name, "", rules)
+			xerr = debug.InjectPlannedFail(xerr)
+			if xerr == nil {
+				return rsg, nil
+			}
+			if _, ok := xerr.(*fail.ErrDuplicate); !ok {
+				return nil, xerr
+			}
+
+			// Security Group created meanwhile, reconciles its rules
+			rsg, xerr = LoadSecurityGroup(svc, name)
+			xerr = debug.InjectPlannedFail(xerr)
+			if xerr != nil {
+				return nil, xerr
+			}
+		default:
+			return nil, xerr
+		}
+	}
+
+	defer func() {
+		if xerr != nil {
+			rsg.Released()
+		}
+	}()
+
+	xerr = rsg.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+		asg, ok := clonable.(*abstract.SecurityGroup)
+		if !ok {
+			return fail.InconsistentError("'*abstract.SecurityGroup' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		if asg.Network != "" && asg.Network != networkID {
+			return fail.InvalidRequestError("Security Group '%s' already exists in another Network", name)
+		}
+		return nil
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	xerr = rsg.Reconcile(ctx, rules)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	return rsg, nil
+}
+
 // IsNull tests if instance is nil or empty
 func (instance *SecurityGroup) IsNull() bool {
 	if instance.MetadataCore == nil {
@@ -338,6 +440,18 @@ func (instance *SecurityGroup) Create(ctx context.Context, networkID, name, desc
 		return fail.DuplicateError("a Security Group named '%s' already exists (but not managed by SafeScale)", name)
 	}
 
+	for k, v := range rules {
+		if v.IsNull() {
+			return fail.InvalidParameterError("rules", "entry #%d cannot be null value of 'abstract.SecurityGroupRule'", k)
+		}
+
+		xerr = instance.unsafeResolveRuleReferences(v)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+	}
+
 	asg, xerr = svc.CreateSecurityGroup(networkID, name, description, rules)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
@@ -649,6 +763,18 @@ func (instance *SecurityGroup) AddRules(ctx context.Context, rules abstract.Secu
 	instance.lock.Lock()
 	defer instance.lock.Unlock()
 
+	for k, v := range rules {
+		if v.IsNull() {
+			return fail.InvalidParameterError("rules", "entry #%d cannot be null value of 'abstract.SecurityGroupRule'", k)
+		}
+
+		xerr = instance.unsafeResolveRuleReferences(v)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+	}
+
 	return instance.Alter(func(clonable data.Clonable, _ *serialize.JSONProperties) (innerXErr fail.Error) {
 		asg, ok := clonable.(*abstract.SecurityGroup)
 		if !ok {
@@ -698,6 +824,14 @@ func (instance *SecurityGroup) DeleteRule(ctx context.Context, rule *abstract.Se
 	instance.lock.Lock()
 	defer instance.lock.Unlock()
 
+	// The rule to delete may reference Security Groups by name or IP sets, as when it has been added
+	rule = rule.Clone().(*abstract.SecurityGroupRule)
+	xerr = instance.unsafeResolveRuleReferences(rule)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
 	return instance.Alter(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
 		asg, ok := clonable.(*abstract.SecurityGroup)
 		if !ok {
@@ -714,6 +848,78 @@ func (instance *SecurityGroup) DeleteRule(ctx context.Context, rule *abstract.Se
 	})
 }
 
+// Reconcile makes the rules of the Security Group match 'rules': the rules concerning a traffic absent from 'rules' are
+// deleted, then the missing ones are added; the rules already present are kept as is
+func (instance *SecurityGroup) Reconcile(ctx context.Context, rules abstract.SecurityGroupRules) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.security-group"), "(%d rules)", len(rules)).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	// rules are compared once their references resolved, as they are recorded
+	desired := make(abstract.SecurityGroupRules, 0, len(rules))
+	for k, v := range rules {
+		if v.IsNull() {
+			return fail.InvalidParameterError("rules", "entry #%d cannot be null value of 'abstract.SecurityGroupRule'", k)
+		}
+
+		rule := v.Clone().(*abstract.SecurityGroupRule)
+		xerr = instance.unsafeResolveRuleReferences(rule)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+		desired = append(desired, rule)
+	}
+
+	return instance.Alter(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+		asg, ok := clonable.(*abstract.SecurityGroup)
+		if !ok {
+			return fail.InconsistentError("'*abstract.SecurityGroup' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		svc := instance.GetService()
+		toDelete, toAdd := asg.Rules.Diff(desired)
+		for _, v := range toDelete {
+			newAsg, innerXErr := svc.DeleteRuleFromSecurityGroup(asg, v)
+			if innerXErr != nil {
+				return fail.Wrap(innerXErr, "failed to delete obsolete rule from Security Group '%s'", asg.Name)
+			}
+			asg.Replace(newAsg)
+		}
+		for _, v := range toAdd {
+			newAsg, innerXErr := svc.AddRuleToSecurityGroup(asg, v)
+			if innerXErr != nil {
+				return fail.Wrap(innerXErr, "failed to add rule to Security Group '%s'", asg.Name)
+			}
+			asg.Replace(newAsg)
+		}
+
+		logrus.Debugf("Security Group '%s' reconciled: %d rule%s deleted, %d rule%s added", asg.Name, len(toDelete), strprocess.Plural(uint(len(toDelete))), len(toAdd), strprocess.Plural(uint(len(toAdd))))
+		return nil
+	})
+}
+
 // GetBoundHosts returns the list of ID of hosts bound to the security group
 func (instance *SecurityGroup) GetBoundHosts(ctx context.Context) (_ []*propertiesv1.SecurityGroupBond, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
@@ -805,6 +1011,95 @@ func (instance *SecurityGroup) GetBoundSubnets(ctx context.Context) (list []*pro
 	return list, xerr
 }
 
+// RepairBindings unbinds the security group from the hosts it references but whose metadata does not exist anymore
+// Such bonds are left behind by failed host creations and cannot be reached by the unbind done during host deletion.
+// Unbinding on provider side is done with the recorded host ID, a host not found on provider side being considered as unbound.
+func (instance *SecurityGroup) RepairBindings(ctx context.Context) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.security-group"), "").WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	bonds, xerr := instance.GetBoundHosts(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
+	// Looks for bonds referencing hosts without metadata; any other error than not found stops the repair, to avoid unbinding live hosts
+	svc := instance.GetService()
+	var orphans []string
+	for _, v := range bonds {
+		if task.Aborted() {
+			return fail.AbortedError(nil, "aborted")
+		}
+
+		rh, xerr := NewHost(svc)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+
+		xerr = rh.ReadByID(v.ID)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrNotFound:
+				orphans = append(orphans, v.ID)
+				continue
+			default:
+				return fail.Wrap(xerr, "failed to check metadata of Host '%s' bound to Security Group '%s'", v.Name, instance.GetName())
+			}
+		}
+	}
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	return instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+		for _, hostID := range orphans {
+			if innerXErr := instance.unsafeUnbindFromHost(props, hostID, data.NewImmutableKeyValue("Force", true)); innerXErr != nil {
+				return fail.Wrap(innerXErr, "failed to unbind Security Group '%s' from orphaned Host '%s'", instance.GetName(), hostID)
+			}
+			logrus.Infof("Security Group '%s' unbound from Host '%s' that does not exist anymore", instance.GetName(), hostID)
+		}
+
+		return props.Alter(securitygroupproperty.HostsV1, func(clonable data.Clonable) fail.Error {
+			sghV1, ok := clonable.(*propertiesv1.SecurityGroupHosts)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.SecurityGroupHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			if sghV1.DefaultFor != "" {
+				if _, ok := sghV1.ByID[sghV1.DefaultFor]; !ok {
+					sghV1.DefaultFor = ""
+				}
+			}
+			return nil
+		})
+	})
+}
+
 // CheckConsistency checks the rules in the security group on provider side are identical to the ones registered in metadata
 func (instance *SecurityGroup) CheckConsistency(_ context.Context) fail.Error {
 	return fail.NotImplementedError()
@@ -883,21 +1178,29 @@ func (instance *SecurityGroup) BindToHost(ctx context.Context, rh resources.Host
 				return fail.InconsistentError("'*propertiesv1.SecurityGroupHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			// First check if host is present; if not present or state is different, replace the entry
+			// First check if host is present; if not present, creates the entry; if present with the same state, counts one more reference
+			// (changing the state of the bond does not add a reference)
 			hostID := rh.GetID()
 			hostName := rh.GetName()
 			disable := !bool(enable)
-			if item, ok := sghV1.ByID[hostID]; !ok || item.Disabled == disable {
+			item, ok := sghV1.ByID[hostID]
+			if !ok {
 				item = &propertiesv1.SecurityGroupBond{
 					ID:   hostID,
 					Name: hostName,
 				}
 				sghV1.ByID[hostID] = item
 				sghV1.ByName[hostName] = hostID
+			} else if item.RefCount == 0 {
+				// metadata written before reference counting
+				item.RefCount = 1
+			}
+			if !ok || item.Disabled == disable {
+				item.RefCount++
 			}
 
 			// update the state
-			sghV1.ByID[hostID].Disabled = disable
+			item.Disabled = disable
 
 			switch enable {
 			case resources.SecurityGroupEnable:
@@ -931,7 +1234,11 @@ func (instance *SecurityGroup) BindToHost(ctx context.Context, rh resources.Host
 }
 
 // UnbindFromHost unbinds the security group from an host
-func (instance *SecurityGroup) UnbindFromHost(ctx context.Context, rh resources.Host) (xerr fail.Error) {
+// The bond is reference counted: the Security Group is effectively unbound from the Host on provider side only when
+// the last reference is removed. Bonds inherited from Subnet are not affected (they are tracked in Subnet bonds).
+// options:
+// - "Force": bool = removes all the references at once (used when the Host is deleted; default is false)
+func (instance *SecurityGroup) UnbindFromHost(ctx context.Context, rh resources.Host, options ...data.ImmutableKeyValue) (xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
 	if instance == nil || instance.IsNull() {
@@ -958,33 +1265,13 @@ func (instance *SecurityGroup) UnbindFromHost(ctx context.Context, rh resources.
 	defer instance.lock.Unlock()
 
 	return instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Alter(securitygroupproperty.HostsV1, func(clonable data.Clonable) fail.Error {
-			sgphV1, ok := clonable.(*propertiesv1.SecurityGroupHosts)
-			if !ok {
-				return fail.InconsistentError("'*securitygroupproperty.HostsV1' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
-
-			// Unbind security group on provider side; if not found, consider as a success
-			hostID := rh.GetID()
-			if innerXErr := instance.GetService().UnbindSecurityGroupFromHost(instance.GetID(), hostID); innerXErr != nil {
-				switch innerXErr.(type) {
-				case *fail.ErrNotFound:
-					return nil
-				default:
-					return innerXErr
-				}
-			}
-
-			// updates security group properties
-			delete(sgphV1.ByID, hostID)
-			delete(sgphV1.ByName, rh.GetName())
-			return nil
-		})
+		return instance.unsafeUnbindFromHost(props, rh.GetID(), options...)
 	})
 }
 
 // UnbindFromHostByReference unbinds the security group from an host identified by reference (id or name)
-func (instance *SecurityGroup) UnbindFromHostByReference(ctx context.Context, hostRef string) (xerr fail.Error) {
+// Reference counting and options are the same than UnbindFromHost
+func (instance *SecurityGroup) UnbindFromHostByReference(ctx context.Context, hostRef string, options ...data.ImmutableKeyValue) (xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
 	if instance == nil || instance.IsNull() {
@@ -1011,39 +1298,23 @@ func (instance *SecurityGroup) UnbindFromHostByReference(ctx context.Context, ho
 	defer instance.lock.Unlock()
 
 	return instance.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Alter(securitygroupproperty.HostsV1, func(clonable data.Clonable) fail.Error {
+		hostID := hostRef
+		innerXErr := props.Inspect(securitygroupproperty.HostsV1, func(clonable data.Clonable) fail.Error {
 			sgphV1, ok := clonable.(*propertiesv1.SecurityGroupHosts)
 			if !ok {
 				return fail.InconsistentError("'*securitygroupproperty.HostsV1' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			var (
-				b                *propertiesv1.SecurityGroupBond
-				hostID, hostName string
-			)
-			if b, ok = sgphV1.ByID[hostRef]; ok {
-				hostID = hostRef
-				hostName = b.Name
-			} else if hostID, ok = sgphV1.ByName[hostRef]; ok {
-				hostName = hostRef
-			}
-			if hostID != "" {
-				// Unbind security group on provider side; if not found, consider as a success
-				if innerXErr := instance.GetService().UnbindSecurityGroupFromHost(instance.GetID(), hostID); innerXErr != nil {
-					switch innerXErr.(type) {
-					case *fail.ErrNotFound:
-						return nil
-					default:
-						return innerXErr
-					}
-				}
+			if id, ok := sgphV1.ByName[hostRef]; ok {
+				hostID = id
 			}
-
-			// updates security group properties
-			delete(sgphV1.ByID, hostID)
-			delete(sgphV1.ByName, hostName)
 			return nil
 		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		return instance.unsafeUnbindFromHost(props, hostID, options...)
 	})
 }
 
@@ -17,6 +17,7 @@
 package operations
 
 import (
+	"net"
 	"reflect"
 	"strings"
 
@@ -222,6 +223,86 @@ func (instance *SecurityGroup) unsafeClear(task concurrency.Task) fail.Error {
 	})
 }
 
+// ipSetReferencePrefix is the prefix of the sources/targets of rules referencing a named IP set of the tenant
+const ipSetReferencePrefix = "ipset:"
+
+// unsafeResolveRuleReferences replaces the references to Security Groups (by name or ID) and to named IP sets in the
+// sources and targets of the rule by Security Group IDs and CIDRs; the references as requested are kept in
+// rule.References when a resolution has been done
+// Returns fail.ErrInvalidRequest if a reference cannot be resolved
+func (instance *SecurityGroup) unsafeResolveRuleReferences(rule *abstract.SecurityGroupRule) fail.Error {
+	svc := instance.GetService()
+	selfID, selfName := instance.GetID(), instance.GetName()
+
+	resolve := func(in []string) ([]string, bool, fail.Error) {
+		out := make([]string, 0, len(in))
+		changed := false
+		for _, v := range in {
+			if _, _, err := net.ParseCIDR(v); err == nil {
+				out = append(out, v)
+				continue
+			}
+
+			if strings.HasPrefix(v, ipSetReferencePrefix) {
+				name := strings.TrimPrefix(v, ipSetReferencePrefix)
+				cidrs, xerr := svc.GetIPSet(name)
+				if xerr != nil {
+					switch xerr.(type) {
+					case *fail.ErrNotFound, *fail.ErrInvalidParameter:
+						return nil, false, fail.InvalidRequestError("rule references unknown IP set '%s'", name)
+					default:
+						return nil, false, xerr
+					}
+				}
+				out = append(out, cidrs...)
+				changed = true
+				continue
+			}
+
+			// The Security Group may reference itself, and cannot be loaded while locked
+			if selfID != "" && (v == selfID || v == selfName) {
+				changed = changed || v != selfID
+				out = append(out, selfID)
+				continue
+			}
+
+			sgInstance, xerr := LoadSecurityGroup(svc, v)
+			if xerr != nil {
+				switch xerr.(type) {
+				case *fail.ErrNotFound:
+					return nil, false, fail.InvalidRequestError("rule references unknown Security Group '%s'", v)
+				default:
+					return nil, false, xerr
+				}
+			}
+			id := sgInstance.GetID()
+			sgInstance.Released()
+			changed = changed || v != id
+			out = append(out, id)
+		}
+		return out, changed, nil
+	}
+
+	sources, sourcesChanged, xerr := resolve(rule.Sources)
+	if xerr != nil {
+		return xerr
+	}
+	targets, targetsChanged, xerr := resolve(rule.Targets)
+	if xerr != nil {
+		return xerr
+	}
+
+	if sourcesChanged {
+		rule.References = append(rule.References, rule.Sources...)
+		rule.Sources = sources
+	}
+	if targetsChanged {
+		rule.References = append(rule.References, rule.Targets...)
+		rule.Targets = targets
+	}
+	return nil
+}
+
 // unsafeAddRule adds a rule to a security group
 func (instance *SecurityGroup) unsafeAddRule(task concurrency.Task, rule *abstract.SecurityGroupRule) (xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
@@ -230,6 +311,12 @@ func (instance *SecurityGroup) unsafeAddRule(task concurrency.Task, rule *abstra
 		return fail.InvalidParameterError("rule", "cannot be null value of 'abstract.SecurityGroupRule'")
 	}
 
+	xerr = instance.unsafeResolveRuleReferences(rule)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return xerr
+	}
+
 	return instance.Alter(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
 		asg, ok := clonable.(*abstract.SecurityGroup)
 		if !ok {
@@ -245,3 +332,51 @@ func (instance *SecurityGroup) unsafeAddRule(task concurrency.Task, rule *abstra
 		return nil
 	})
 }
+
+// unsafeUnbindFromHost removes a reference on the bond between the Security Group and the Host, and unbinds the
+// Security Group from the Host on provider side when there is no more reference
+// options:
+// - "Force": bool = removes all the references at once (default is false)
+// Note: a write lock of the instance (instance.lock.Lock()) must have been called before calling this method
+func (instance *SecurityGroup) unsafeUnbindFromHost(props *serialize.JSONProperties, hostID string, options ...data.ImmutableKeyValue) fail.Error {
+	force := false
+	for _, v := range options {
+		switch v.Key() {
+		case "Force":
+			force = v.Value().(bool)
+		default:
+		}
+	}
+
+	return props.Alter(securitygroupproperty.HostsV1, func(clonable data.Clonable) fail.Error {
+		sgphV1, ok := clonable.(*propertiesv1.SecurityGroupHosts)
+		if !ok {
+			return fail.InconsistentError("'*securitygroupproperty.HostsV1' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		item, found := sgphV1.ByID[hostID]
+		if found && !force && item.RefCount > 1 {
+			// Other references remain, keep the bond
+			item.RefCount--
+			logrus.Debugf("Security Group '%s' still referenced %d time%s by Host '%s', keeping bond", instance.GetName(), item.RefCount, strprocess.Plural(item.RefCount), item.Name)
+			return nil
+		}
+
+		// Unbind security group on provider side; if not found, consider as a success
+		if innerXErr := instance.GetService().UnbindSecurityGroupFromHost(instance.GetID(), hostID); innerXErr != nil {
+			switch innerXErr.(type) {
+			case *fail.ErrNotFound:
+				// consider a Security Group not bound as a success
+			default:
+				return innerXErr
+			}
+		}
+
+		// updates security group properties
+		if found {
+			delete(sgphV1.ByName, item.Name)
+			delete(sgphV1.ByID, hostID)
+		}
+		return nil
+	})
+}
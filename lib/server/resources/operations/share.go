@@ -135,9 +135,10 @@ func NewShare(svc iaas.Service) (resources.Share, fail.Error) {
 
 // LoadShare returns the name of the host owing the Share 'ref', read from Object Storage
 // logic: try to read until success.
-//        If error is fail.ErrNotFound return this error
-//        In case of any other error, abort the retry to propagate the error
-//        If retry times out, return fail.ErrTimeout
+//
+//	If error is fail.ErrNotFound return this error
+//	In case of any other error, abort the retry to propagate the error
+//	If retry times out, return fail.ErrTimeout
 func LoadShare(svc iaas.Service, ref string) (rs resources.Share, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
@@ -530,39 +531,65 @@ func (instance *Share) Create(
 	return instance.carry(&si)
 }
 
-// GetServer returns the Host acting as Share server, with error handling
-// Note: do not forget to call .Released() on returned host when you do not use it anymore
-func (instance *Share) GetServer() (_ resources.Host, xerr fail.Error) {
+// GetServerID returns the ID of the Host acting as Share server, read from Share metadata
+// Use it instead of GetServer() when the Host instance itself is not needed, to avoid loading Host metadata
+func (instance *Share) GetServerID() (_ string, xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
 	if instance == nil || instance.IsNull() {
-		return nil, fail.InvalidInstanceError()
+		return "", fail.InvalidInstanceError()
 	}
 
 	instance.lock.RLock()
 	defer instance.lock.RUnlock()
 
-	var hostID, hostName string
+	identity, xerr := instance.unsafeGetIdentity()
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return "", xerr
+	}
+
+	return identity.HostID, nil
+}
+
+// unsafeGetIdentity returns a copy of the ShareIdentity stored in metadata
+// Note: a lock of the instance (instance.lock.Lock() or instance.lock.RLock()) must have been called before calling this method
+func (instance *Share) unsafeGetIdentity() (identity ShareIdentity, xerr fail.Error) {
 	xerr = instance.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
 		share, ok := clonable.(*ShareIdentity)
 		if !ok {
 			return fail.InconsistentError("'*shareItem' expected, '%s' provided", reflect.TypeOf(clonable).String())
 		}
 
-		hostID = share.HostID
-		hostName = share.HostName
+		identity = *share
 		return nil
 	})
+	return identity, xerr
+}
+
+// GetServer returns the Host acting as Share server, with error handling
+// Note: do not forget to call .Released() on returned host when you do not use it anymore
+func (instance *Share) GetServer() (_ resources.Host, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+
+	instance.lock.RLock()
+	defer instance.lock.RUnlock()
+
+	identity, xerr := instance.unsafeGetIdentity()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return nil, xerr
 	}
 
 	svc := instance.GetService()
-	server, xerr := LoadHost(svc, hostID)
+	server, xerr := LoadHost(svc, identity.HostID)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		server, xerr = LoadHost(svc, hostName)
+		server, xerr = LoadHost(svc, identity.HostName)
 	}
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
@@ -862,60 +889,113 @@ func (instance *Share) Unmount(ctx context.Context, target resources.Host) (xerr
 	instance.lock.Lock()
 	defer instance.lock.Unlock()
 
-	var (
-		shareName, shareID string
-		// /*serverID,*/ serverName string
-		// serverPrivateIP          string
-		hostShare *propertiesv1.HostShare
-	)
+	return instance.unsafeUnmount(ctx, target, false)
+}
 
-	// Retrieve info about the Share
-	xerr = instance.Review(func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
-		si, ok := clonable.(*ShareIdentity)
-		if !ok {
-			return fail.InconsistentError("'*shareItem' expected, '%s' provided", reflect.TypeOf(clonable).String())
-		}
+// ForceUnmount unmounts a Share from local directory of an host, even if the Share server is unreachable
+// The unmount is forced and lazy on the host, and failing to update the metadata of the Share server does not prevent
+// the removal of the mount from the host metadata
+func (instance *Share) ForceUnmount(ctx context.Context, target resources.Host) (xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
 
-		shareName = si.ShareName
-		shareID = si.ShareID
-		return nil
-	})
+	if instance == nil || instance.IsNull() {
+		return fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return fail.InvalidParameterCannotBeNilError("ctx")
+	}
+	if target == nil {
+		return fail.InvalidParameterCannotBeNilError("target")
+	}
 
-	rhServer, xerr := instance.GetServer()
+	task, xerr := concurrency.TaskFromContext(ctx)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return xerr
 	}
 
-	serverName := rhServer.GetName()
-	serverPrivateIP, xerr := rhServer.GetPrivateIP()
+	if task.Aborted() {
+		return fail.AbortedError(nil, "aborted")
+	}
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	return instance.unsafeUnmount(ctx, target, true)
+}
+
+// unsafeUnmount unmounts a Share from local directory of an host
+// If force is true, the Share server may be unreachable
+// Note: a lock of the instance (instance.lock.Lock()) must have been called before calling this method
+func (instance *Share) unsafeUnmount(ctx context.Context, target resources.Host, force bool) (xerr fail.Error) {
+	identity, xerr := instance.unsafeGetIdentity()
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
 		return xerr
 	}
 
-	xerr = rhServer.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
-		return props.Inspect(hostproperty.SharesV1, func(clonable data.Clonable) fail.Error {
-			rhServer, ok := clonable.(*propertiesv1.HostShares)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv1.HotShares' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
+	shareName := identity.ShareName
+	shareID := identity.ShareID
 
-			var found bool
-			if hostShare, found = rhServer.ByID[shareID]; !found {
-				return fail.NotFoundError("failed to find Share '%s' in Host '%s' metadata", shareName, serverName)
-			}
+	// Retrieve info about the Share on server
+	var (
+		rhServer   resources.Host
+		hostShare  *propertiesv1.HostShare
+		remotePath string
+	)
+	xerr = func() fail.Error {
+		svc := instance.GetService()
+		var innerXErr fail.Error
+		rhServer, innerXErr = LoadHost(svc, identity.HostID)
+		if innerXErr != nil {
+			rhServer, innerXErr = LoadHost(svc, identity.HostName)
+		}
+		innerXErr = debug.InjectPlannedFail(innerXErr)
+		if innerXErr != nil {
+			return innerXErr
+		}
 
-			return nil
+		serverName := rhServer.GetName()
+		serverPrivateIP, innerXErr := rhServer.GetPrivateIP()
+		innerXErr = debug.InjectPlannedFail(innerXErr)
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		innerXErr = rhServer.Inspect(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
+			return props.Inspect(hostproperty.SharesV1, func(clonable data.Clonable) fail.Error {
+				hostSharesV1, ok := clonable.(*propertiesv1.HostShares)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv1.HotShares' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				var found bool
+				if hostShare, found = hostSharesV1.ByID[shareID]; !found {
+					return fail.NotFoundError("failed to find Share '%s' in Host '%s' metadata", shareName, serverName)
+				}
+
+				return nil
+			})
 		})
-	})
-	xerr = debug.InjectPlannedFail(xerr)
+		innerXErr = debug.InjectPlannedFail(innerXErr)
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		remotePath = serverPrivateIP + ":" + hostShare.Path
+		return nil
+	}()
 	if xerr != nil {
-		return xerr
+		if !force {
+			return xerr
+		}
+		logrus.Warnf("failed to reach server of Share '%s', forcing unmount: %v", shareName, xerr)
+		rhServer = nil
+	}
+	if rhServer != nil {
+		defer rhServer.Released()
 	}
 
-	var mountPath string
-	remotePath := serverPrivateIP + ":" + hostShare.Path
 	targetName := target.GetName()
 	targetID := target.GetID()
 	xerr = target.Alter(func(_ data.Clonable, props *serialize.JSONProperties) fail.Error {
@@ -930,6 +1010,12 @@ func (instance *Share) Unmount(ctx context.Context, target resources.Host) (xerr
 				return fail.NotFoundError("not mounted on host '%s'", targetName)
 			}
 
+			// Without the Share server, use the export recorded on mount
+			export := remotePath
+			if export == "" {
+				export = mount.Export
+			}
+
 			// Unmount Share from client
 			sshConfig, inErr := target.GetSSHConfig()
 			if inErr != nil {
@@ -941,16 +1027,19 @@ func (instance *Share) Unmount(ctx context.Context, target resources.Host) (xerr
 				return inErr
 			}
 
-			inErr = nfsClient.Unmount(ctx, serverPrivateIP+":"+hostShare.Path)
+			if force {
+				inErr = nfsClient.ForceUnmount(ctx, export)
+			} else {
+				inErr = nfsClient.Unmount(ctx, export)
+			}
 			if inErr != nil {
 				return inErr
 			}
 
 			// Remove mount from mount list
-			mountPath = mount.Path
 			delete(targetMountsV1.RemoteMountsByShareID, mount.ShareID)
-			delete(targetMountsV1.RemoteMountsByPath, mountPath)
-			delete(targetMountsV1.RemoteMountsByExport, remotePath)
+			delete(targetMountsV1.RemoteMountsByPath, mount.Path)
+			delete(targetMountsV1.RemoteMountsByExport, mount.Export)
 			return nil
 		})
 	})
@@ -959,6 +1048,10 @@ func (instance *Share) Unmount(ctx context.Context, target resources.Host) (xerr
 		return xerr
 	}
 
+	if rhServer == nil {
+		return nil
+	}
+
 	// Remove host from client lists of the Share
 	xerr = rhServer.Alter(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
 		return props.Alter(hostproperty.SharesV1, func(clonable data.Clonable) fail.Error {
@@ -967,16 +1060,20 @@ func (instance *Share) Unmount(ctx context.Context, target resources.Host) (xerr
 				return fail.InconsistentError("'*propertiesv1.HostShares' expected, '%s' provided", reflect.TypeOf(clonable).String())
 			}
 
-			delete(hostSharesV1.ByID[shareID].ClientsByName, targetName)
-			delete(hostSharesV1.ByID[shareID].ClientsByID, targetID)
+			if item, ok := hostSharesV1.ByID[shareID]; ok {
+				delete(item.ClientsByName, targetName)
+				delete(item.ClientsByID, targetID)
+			}
 			return nil
 		})
 	})
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
-		return xerr
+		if !force {
+			return xerr
+		}
+		logrus.Warnf("Share '%s' unmounted from Host '%s', but failed to update metadata of Share server: %v", shareName, targetName, xerr)
 	}
-
 	return nil
 }
 
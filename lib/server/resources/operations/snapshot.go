@@ -0,0 +1,395 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operations
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/CS-SI/SafeScale/lib/protocol"
+	"github.com/CS-SI/SafeScale/lib/server/iaas"
+	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterproperty"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hostproperty"
+	propertiesv1 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v1"
+	propertiesv2 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v2"
+	propertiesv3 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v3"
+	"github.com/CS-SI/SafeScale/lib/utils/concurrency"
+	"github.com/CS-SI/SafeScale/lib/utils/data"
+	"github.com/CS-SI/SafeScale/lib/utils/debug"
+	"github.com/CS-SI/SafeScale/lib/utils/debug/tracing"
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+	"github.com/CS-SI/SafeScale/lib/utils/serialize"
+	"github.com/CS-SI/SafeScale/lib/utils/temporal"
+)
+
+// snapshotParallelism is the maximum number of metadata folders browsed simultaneously by Snapshot
+const snapshotParallelism = 3
+
+// Snapshot returns a view of the Networks, Subnets, Hosts, Clusters and Security Groups of the tenant of svc, browsing
+// the metadata folder of each kind of resource once
+// The resources are linked together (Hosts to Subnets to Networks, Cluster membership); the references to resources not
+// found in metadata, and the metadata that cannot be read, are reported in the problems of the snapshot
+func Snapshot(task concurrency.Task, svc iaas.Service) (_ *protocol.TenantSnapshot, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if task == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("task")
+	}
+	if svc == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("svc")
+	}
+
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.tenant"), "(%s)", svc.GetName()).WithStopwatch().Entering()
+	defer tracer.Exiting()
+
+	builder := &snapshotBuilder{svc: svc}
+	browsers := []func(concurrency.Task) fail.Error{
+		builder.browseNetworks,
+		builder.browseSubnets,
+		builder.browseHosts,
+		builder.browseClusters,
+		builder.browseSecurityGroups,
+	}
+
+	tg, xerr := concurrency.NewTaskGroupWithParent(task)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	semaphore := make(chan struct{}, snapshotParallelism)
+	for _, v := range browsers {
+		browse := v
+		_, xerr = tg.Start(func(t concurrency.Task, _ concurrency.TaskParameters) (concurrency.TaskResult, fail.Error) {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			return nil, browse(t)
+		}, nil)
+		if xerr != nil {
+			_ = tg.Abort()
+			return nil, xerr
+		}
+	}
+
+	_, _, xerr = tg.WaitGroupFor(temporal.GetTenantSnapshotTimeout(task.GetContext()))
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, fail.Wrap(xerr, "failed to take snapshot of tenant '%s'", svc.GetName())
+	}
+
+	return builder.assemble(), nil
+}
+
+// snapshotBuilder collects the metadata of the resources of a tenant and assembles the snapshot
+type snapshotBuilder struct {
+	svc iaas.Service
+
+	lock           sync.Mutex
+	networks       []*protocol.TenantSnapshotNetwork
+	subnets        []*protocol.TenantSnapshotSubnet
+	hosts          []*protocol.TenantSnapshotHost
+	clusters       []*protocol.TenantSnapshotCluster
+	securityGroups []*protocol.TenantSnapshotSecurityGroup
+	problems       []string
+}
+
+func (b *snapshotBuilder) addProblem(format string, args ...interface{}) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.problems = append(b.problems, fmt.Sprintf(format, args...))
+}
+
+// browseKind calls callback with the content of each entry of the metadata folder of a kind of resource
+// An entry that cannot be read is reported as problem and skipped
+func (b *snapshotBuilder) browseKind(task concurrency.Task, kind, path string, factory func() data.Clonable, callback func(data.Clonable, *serialize.JSONProperties) fail.Error) fail.Error {
+	browser, xerr := NewCore(b.svc, kind, path, factory())
+	if xerr != nil {
+		return xerr
+	}
+
+	return browser.BrowseFolder(func(buf []byte) fail.Error {
+		if task.Aborted() {
+			return fail.AbortedError(nil, "aborted")
+		}
+
+		// a new instance for each entry, deserialize keeping the properties absent from buf
+		entry, innerXErr := NewCore(b.svc, kind, path, factory())
+		if innerXErr != nil {
+			return innerXErr
+		}
+		if innerXErr = entry.Deserialize(buf); innerXErr != nil {
+			b.addProblem("failed to read metadata of %s: %v", kind, innerXErr)
+			return nil
+		}
+		if innerXErr = entry.Review(callback); innerXErr != nil {
+			b.addProblem("failed to read metadata of %s '%s': %v", kind, entry.GetName(), innerXErr)
+		}
+		return nil
+	})
+}
+
+func (b *snapshotBuilder) browseNetworks(task concurrency.Task) fail.Error {
+	return b.browseKind(task, networkKind, networksFolderName, func() data.Clonable { return abstract.NewNetwork() }, func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+		an, ok := clonable.(*abstract.Network)
+		if !ok {
+			return fail.InconsistentError("'*abstract.Network' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		b.lock.Lock()
+		defer b.lock.Unlock()
+
+		b.networks = append(b.networks, &protocol.TenantSnapshotNetwork{Id: an.ID, Name: an.Name, Cidr: an.CIDR})
+		return nil
+	})
+}
+
+func (b *snapshotBuilder) browseSubnets(task concurrency.Task) fail.Error {
+	return b.browseKind(task, subnetKind, subnetsFolderName, func() data.Clonable { return abstract.NewSubnet() }, func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+		as, ok := clonable.(*abstract.Subnet)
+		if !ok {
+			return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		b.lock.Lock()
+		defer b.lock.Unlock()
+
+		b.subnets = append(b.subnets, &protocol.TenantSnapshotSubnet{
+			Id:         as.ID,
+			Name:       as.Name,
+			Cidr:       as.CIDR,
+			NetworkId:  as.Network,
+			State:      as.State.String(),
+			GatewayIds: append([]string{}, as.GatewayIDs...),
+		})
+		return nil
+	})
+}
+
+func (b *snapshotBuilder) browseHosts(task concurrency.Task) fail.Error {
+	return b.browseKind(task, hostKind, hostsFolderName, func() data.Clonable { return abstract.NewHostCore() }, func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+		ahc, ok := clonable.(*abstract.HostCore)
+		if !ok {
+			return fail.InconsistentError("'*abstract.HostCore' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		item := &protocol.TenantSnapshotHost{Id: ahc.ID, Name: ahc.Name, State: ahc.LastState.String()}
+		if props.Lookup(hostproperty.NetworkV2) {
+			innerXErr := props.Inspect(hostproperty.NetworkV2, func(clonable data.Clonable) fail.Error {
+				hnV2, ok := clonable.(*propertiesv2.HostNetworking)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv2.HostNetworking' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				item.IsGateway = hnV2.IsGateway
+				item.DefaultSubnetId = hnV2.DefaultSubnetID
+				for k := range hnV2.SubnetsByID {
+					item.SubnetIds = append(item.SubnetIds, k)
+				}
+				sort.Strings(item.SubnetIds)
+				item.PrivateIp = hnV2.IPv4Addresses[hnV2.DefaultSubnetID]
+				item.PublicIp = hnV2.PublicIPv4
+				return nil
+			})
+			if innerXErr != nil {
+				return innerXErr
+			}
+		}
+		innerXErr := props.Inspect(hostproperty.SecurityGroupsV1, func(clonable data.Clonable) fail.Error {
+			hsgV1, ok := clonable.(*propertiesv1.HostSecurityGroups)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.HostSecurityGroups' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			for k := range hsgV1.ByID {
+				item.SecurityGroupIds = append(item.SecurityGroupIds, k)
+			}
+			sort.Strings(item.SecurityGroupIds)
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		b.lock.Lock()
+		defer b.lock.Unlock()
+
+		b.hosts = append(b.hosts, item)
+		return nil
+	})
+}
+
+func (b *snapshotBuilder) browseClusters(task concurrency.Task) fail.Error {
+	return b.browseKind(task, clusterKind, clustersFolderName, func() data.Clonable { return abstract.NewClusterIdentity() }, func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+		aci, ok := clonable.(*abstract.ClusterIdentity)
+		if !ok {
+			return fail.InconsistentError("'*abstract.ClusterIdentity' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		item := &protocol.TenantSnapshotCluster{
+			Name:       aci.Name,
+			Flavor:     aci.Flavor.String(),
+			Complexity: aci.Complexity.String(),
+		}
+		innerXErr := props.Inspect(clusterproperty.StateV1, func(clonable data.Clonable) fail.Error {
+			stateV1, ok := clonable.(*propertiesv1.ClusterState)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.ClusterState' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			item.State = stateV1.State.String()
+			return nil
+		})
+		if innerXErr != nil {
+			return innerXErr
+		}
+
+		// Clusters not yet migrated to clusterproperty.NodesV3 are reported without members
+		if props.Lookup(clusterproperty.NodesV3) {
+			innerXErr = props.Inspect(clusterproperty.NodesV3, func(clonable data.Clonable) fail.Error {
+				nodesV3, ok := clonable.(*propertiesv3.ClusterNodes)
+				if !ok {
+					return fail.InconsistentError("'*propertiesv3.ClusterNodes' expected, '%s' provided", reflect.TypeOf(clonable).String())
+				}
+
+				for _, v := range nodesV3.Masters {
+					if node, ok := nodesV3.ByNumericalID[v]; ok {
+						item.MasterIds = append(item.MasterIds, node.ID)
+					}
+				}
+				for _, v := range nodesV3.PrivateNodes {
+					if node, ok := nodesV3.ByNumericalID[v]; ok {
+						item.NodeIds = append(item.NodeIds, node.ID)
+					}
+				}
+				return nil
+			})
+			if innerXErr != nil {
+				return innerXErr
+			}
+		} else {
+			b.addProblem("members of Cluster '%s' unknown, its metadata needs an upgrade", aci.Name)
+		}
+
+		b.lock.Lock()
+		defer b.lock.Unlock()
+
+		b.clusters = append(b.clusters, item)
+		return nil
+	})
+}
+
+func (b *snapshotBuilder) browseSecurityGroups(task concurrency.Task) fail.Error {
+	return b.browseKind(task, securityGroupKind, securityGroupsFolderName, func() data.Clonable { return abstract.NewSecurityGroup() }, func(clonable data.Clonable, _ *serialize.JSONProperties) fail.Error {
+		asg, ok := clonable.(*abstract.SecurityGroup)
+		if !ok {
+			return fail.InconsistentError("'*abstract.SecurityGroup' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		b.lock.Lock()
+		defer b.lock.Unlock()
+
+		b.securityGroups = append(b.securityGroups, &protocol.TenantSnapshotSecurityGroup{Id: asg.ID, Name: asg.Name, NetworkId: asg.Network})
+		return nil
+	})
+}
+
+// assemble links the collected resources together and builds the snapshot
+// Note: must be called once all the browsing is done
+func (b *snapshotBuilder) assemble() *protocol.TenantSnapshot {
+	networks := make(map[string]*protocol.TenantSnapshotNetwork, len(b.networks))
+	for _, v := range b.networks {
+		networks[v.Id] = v
+	}
+	subnets := make(map[string]*protocol.TenantSnapshotSubnet, len(b.subnets))
+	for _, v := range b.subnets {
+		subnets[v.Id] = v
+	}
+	hosts := make(map[string]*protocol.TenantSnapshotHost, len(b.hosts))
+	for _, v := range b.hosts {
+		hosts[v.Id] = v
+	}
+	securityGroups := make(map[string]*protocol.TenantSnapshotSecurityGroup, len(b.securityGroups))
+	for _, v := range b.securityGroups {
+		securityGroups[v.Id] = v
+	}
+
+	sort.Slice(b.subnets, func(i, j int) bool { return b.subnets[i].Name < b.subnets[j].Name })
+	for _, v := range b.subnets {
+		if network, ok := networks[v.NetworkId]; ok {
+			network.SubnetIds = append(network.SubnetIds, v.Id)
+		} else {
+			b.problems = append(b.problems, fmt.Sprintf("Subnet '%s' references unknown Network '%s'", v.Name, v.NetworkId))
+		}
+	}
+
+	sort.Slice(b.hosts, func(i, j int) bool { return b.hosts[i].Name < b.hosts[j].Name })
+	for _, v := range b.hosts {
+		for _, id := range v.SubnetIds {
+			subnet, ok := subnets[id]
+			if !ok {
+				b.problems = append(b.problems, fmt.Sprintf("Host '%s' references unknown Subnet '%s'", v.Name, id))
+				continue
+			}
+			if !v.IsGateway {
+				subnet.HostIds = append(subnet.HostIds, v.Id)
+			}
+		}
+		for _, id := range v.SecurityGroupIds {
+			if sg, ok := securityGroups[id]; ok {
+				sg.HostIds = append(sg.HostIds, v.Id)
+			} else {
+				b.problems = append(b.problems, fmt.Sprintf("Host '%s' references unknown Security Group '%s'", v.Name, id))
+			}
+		}
+	}
+
+	sort.Slice(b.clusters, func(i, j int) bool { return b.clusters[i].Name < b.clusters[j].Name })
+	for _, v := range b.clusters {
+		for _, id := range append(append([]string{}, v.MasterIds...), v.NodeIds...) {
+			if host, ok := hosts[id]; ok {
+				host.Cluster = v.Name
+			} else {
+				b.problems = append(b.problems, fmt.Sprintf("Cluster '%s' references unknown Host '%s'", v.Name, id))
+			}
+		}
+	}
+
+	sort.Slice(b.networks, func(i, j int) bool { return b.networks[i].Name < b.networks[j].Name })
+	sort.Slice(b.securityGroups, func(i, j int) bool { return b.securityGroups[i].Name < b.securityGroups[j].Name })
+	sort.Strings(b.problems)
+
+	return &protocol.TenantSnapshot{
+		Tenant:         b.svc.GetName(),
+		TakenAt:        time.Now().Unix(),
+		Networks:       b.networks,
+		Subnets:        b.subnets,
+		Hosts:          b.hosts,
+		Clusters:       b.clusters,
+		SecurityGroups: b.securityGroups,
+		Problems:       b.problems,
+	}
+}
@@ -417,6 +417,11 @@ func (instance *Subnet) Create(ctx context.Context, req abstract.SubnetRequest,
 		return xerr
 	}
 
+	req.CIDR, xerr = abstract.NormalizeCIDR(req.CIDR)
+	if xerr != nil {
+		return xerr
+	}
+
 	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.subnet"),
 		"('%s', '%s', %s, <sizing>, '%s', %v)", req.Name, req.CIDR, req.IPVersion.String(), req.Image, req.HA,
 	).WithStopwatch().Entering()
@@ -1555,7 +1560,11 @@ func (instance *Subnet) GetGatewayPublicIPs() (_ []string, xerr fail.Error) {
 }
 
 // Delete deletes a Subnet
-func (instance *Subnet) Delete(ctx context.Context) (xerr fail.Error) {
+// If Hosts (other than gateways) are still attached to the Subnet, the deletion is refused with a fail.ErrNotAvailable,
+// unless option "Force" is set to true, in which case these Hosts are deleted first.
+// options:
+// - "Force": bool = delete Hosts still attached to the Subnet before deleting it (default is false)
+func (instance *Subnet) Delete(ctx context.Context, options ...data.ImmutableKeyValue) (xerr fail.Error) {
 	defer fail.OnPanic(&xerr)
 
 	if instance == nil || instance.IsNull() {
@@ -1575,9 +1584,27 @@ func (instance *Subnet) Delete(ctx context.Context) (xerr fail.Error) {
 		return fail.AbortedError(nil, "aborted")
 	}
 
-	tracer := debug.NewTracer(nil, true /*tracing.ShouldTrace("operations.Subnet")*/).WithStopwatch().Entering()
+	force := false
+	for _, v := range options {
+		switch v.Key() {
+		case "Force":
+			force = v.Value().(bool)
+		default:
+		}
+	}
+
+	tracer := debug.NewTracer(nil, true /*tracing.ShouldTrace("operations.Subnet")*/, "(force=%v)", force).WithStopwatch().Entering()
 	defer tracer.Exiting()
 
+	// Hosts deletion alters Subnet metadata to unlink themselves, so it has to be done before locking the instance
+	if force {
+		xerr = instance.deleteAttachedHosts(ctx)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			return xerr
+		}
+	}
+
 	instance.lock.Lock()
 	defer instance.lock.Unlock()
 
@@ -1590,37 +1617,17 @@ func (instance *Subnet) Delete(ctx context.Context) (xerr fail.Error) {
 		svc := instance.GetService()
 
 		// Check if hosts are still attached to Subnet according to metadata
-		var errorMsg string
-		innerErr := props.Inspect(subnetproperty.HostsV1, func(clonable data.Clonable) fail.Error {
-			shV1, ok := clonable.(*propertiesv1.SubnetHosts)
-			if !ok {
-				return fail.InconsistentError("'*propertiesv1.SubnetHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
-			}
-
-			hostsLen := uint(len(shV1.ByName))
-			hostList := make([]string, 0, hostsLen)
-			if hostsLen > 0 {
-				for k := range shV1.ByName {
-					// Check if Host still has metadata and count it if yes
-					if hostInstance, innerXErr := LoadHost(svc, k); innerXErr == nil {
-						hostInstance.Released()
-						hostList = append(hostList, k)
-					}
-				}
-			}
-			if len(hostList) > 0 {
-				verb := "are"
-				if hostsLen == 1 {
-					verb = "is"
-				}
-				errorMsg = fmt.Sprintf("cannot delete Subnet '%s': %d host%s %s still attached to it: %s",
-					as.Name, hostsLen, strprocess.Plural(hostsLen), verb, strings.Join(hostList, ", "))
-				return fail.NotAvailableError(errorMsg)
+		hostList, innerXErr := instance.unsafeListAttachedHosts(as, props)
+		if innerXErr != nil {
+			return innerXErr
+		}
+		if hostsLen := uint(len(hostList)); hostsLen > 0 {
+			verb := "are"
+			if hostsLen == 1 {
+				verb = "is"
 			}
-			return nil
-		})
-		if innerErr != nil {
-			return innerErr
+			return fail.NotAvailableError("cannot delete Subnet '%s': %d host%s %s still attached to it: %s",
+				as.Name, hostsLen, strprocess.Plural(hostsLen), verb, strings.Join(hostList, ", "))
 		}
 
 		// Leave a chance to abort
@@ -1722,9 +1729,93 @@ func (instance *Subnet) Delete(ctx context.Context) (xerr fail.Error) {
 	}
 
 	// Remove metadata
+	invalidateSubnetGateways(instance.GetService(), instance.GetID())
 	return instance.MetadataCore.Delete()
 }
 
+// ListDeletionBlockers returns the names of the Hosts (excluding gateways) preventing the deletion of the Subnet,
+// without deleting anything; an empty list means Delete can proceed without option "Force"
+func (instance *Subnet) ListDeletionBlockers(ctx context.Context) (_ []string, xerr fail.Error) {
+	defer fail.OnPanic(&xerr)
+
+	if instance == nil || instance.IsNull() {
+		return nil, fail.InvalidInstanceError()
+	}
+	if ctx == nil {
+		return nil, fail.InvalidParameterCannotBeNilError("ctx")
+	}
+
+	task, xerr := concurrency.TaskFromContext(ctx)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	if task.Aborted() {
+		return nil, fail.AbortedError(nil, "aborted")
+	}
+
+	defer debug.NewTracer(task, tracing.ShouldTrace("resources.subnet")).Entering().Exiting()
+
+	instance.lock.RLock()
+	defer instance.lock.RUnlock()
+
+	var list []string
+	xerr = instance.Review(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+		as, ok := clonable.(*abstract.Subnet)
+		if !ok {
+			return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		var innerXErr fail.Error
+		list, innerXErr = instance.unsafeListAttachedHosts(as, props)
+		return innerXErr
+	})
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	return list, nil
+}
+
+// deleteAttachedHosts deletes the Hosts (excluding gateways) still attached to the Subnet
+func (instance *Subnet) deleteAttachedHosts(ctx context.Context) fail.Error {
+	hostList, xerr := instance.ListDeletionBlockers(ctx)
+	if xerr != nil {
+		return xerr
+	}
+
+	svc := instance.GetService()
+	for _, v := range hostList {
+		hostInstance, xerr := LoadHost(svc, v)
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrNotFound:
+				// Host vanished in the meantime, consider this as a success
+				continue
+			default:
+				return xerr
+			}
+		}
+
+		logrus.Debugf("Deleting Host '%s' attached to Subnet '%s'...", v, instance.GetName())
+		xerr = hostInstance.Delete(ctx)
+		hostInstance.Released()
+		xerr = debug.InjectPlannedFail(xerr)
+		if xerr != nil {
+			switch xerr.(type) {
+			case *fail.ErrNotFound:
+				// Host not found, consider this as a success
+			default:
+				return fail.Wrap(xerr, "failed to delete Host '%s' attached to Subnet '%s'", v, instance.GetName())
+			}
+		}
+	}
+	return nil
+}
+
 // Released overloads core.Released() to release the parent Network instance
 func (instance *Subnet) Released() {
 	if instance == nil || instance.IsNull() {
@@ -1800,6 +1891,7 @@ func (instance *Subnet) deleteGateways(subnet *abstract.Subnet) (ids []string, x
 
 			// Remove current entry from gateways to delete
 			subnet.GatewayIDs = subnet.GatewayIDs[1:]
+			invalidateSubnetGateways(svc, subnet.ID)
 		}
 	}
 	return ids, nil
@@ -2433,6 +2525,11 @@ func (instance *Subnet) CreateSubnetWithoutGateway(ctx context.Context, req abst
 		return xerr
 	}
 
+	req.CIDR, xerr = abstract.NormalizeCIDR(req.CIDR)
+	if xerr != nil {
+		return xerr
+	}
+
 	tracer := debug.NewTracer(task, tracing.ShouldTrace("resources.subnet"),
 		"('%s', '%s', %s, <sizing>, '%s', %v)", req.Name, req.CIDR, req.IPVersion.String(), req.Image, req.HA,
 	).WithStopwatch().Entering()
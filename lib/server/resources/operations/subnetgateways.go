@@ -0,0 +1,196 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package operations
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CS-SI/SafeScale/lib/server/iaas"
+	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/subnetproperty"
+	propertiesv1 "github.com/CS-SI/SafeScale/lib/server/resources/properties/v1"
+	"github.com/CS-SI/SafeScale/lib/system"
+	"github.com/CS-SI/SafeScale/lib/utils/data"
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+	"github.com/CS-SI/SafeScale/lib/utils/serialize"
+	"github.com/CS-SI/SafeScale/lib/utils/temporal"
+)
+
+// gatewaysSSHConfig contains the SSH configurations of the gateways of a Subnet
+type gatewaysSSHConfig struct {
+	lock      sync.Mutex
+	loaded    bool
+	primary   *system.SSHConfig
+	secondary *system.SSHConfig
+}
+
+// gatewaysSSHConfigCache keeps the SSH configurations of the gateways of Subnets, indexed by service then by Subnet ID,
+// to share them between the Hosts of a same Subnet
+type gatewaysSSHConfigCache struct {
+	lock      sync.Mutex
+	byService map[string]map[string]*gatewaysSSHConfig
+}
+
+var subnetGatewaysCache = &gatewaysSSHConfigCache{byService: map[string]map[string]*gatewaysSSHConfig{}}
+
+// entry returns the cache entry of the Subnet, creating it if needed
+func (c *gatewaysSSHConfigCache) entry(service, subnetID string) *gatewaysSSHConfig {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	bySubnet, ok := c.byService[service]
+	if !ok {
+		bySubnet = map[string]*gatewaysSSHConfig{}
+		c.byService[service] = bySubnet
+	}
+	item, ok := bySubnet[subnetID]
+	if !ok {
+		item = &gatewaysSSHConfig{}
+		bySubnet[subnetID] = item
+	}
+	return item
+}
+
+// get returns copies of the SSH configurations of the gateways of the Subnet, calling 'loader' to compute them if not in cache
+// Concurrent calls for the same Subnet wait for the first one to compute the configurations; on loader failure, nothing is cached
+func (c *gatewaysSSHConfigCache) get(service, subnetID string, loader func() (*system.SSHConfig, *system.SSHConfig, fail.Error)) (*system.SSHConfig, *system.SSHConfig, fail.Error) {
+	item := c.entry(service, subnetID)
+
+	item.lock.Lock()
+	defer item.lock.Unlock()
+
+	if !item.loaded {
+		primary, secondary, xerr := loader()
+		if xerr != nil {
+			return nil, nil, xerr
+		}
+
+		item.primary, item.secondary = primary, secondary
+		item.loaded = true
+	}
+	return copySSHConfig(item.primary), copySSHConfig(item.secondary), nil
+}
+
+// invalidate removes from cache the SSH configurations of the gateways of the Subnet
+func (c *gatewaysSSHConfigCache) invalidate(service, subnetID string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if bySubnet, ok := c.byService[service]; ok {
+		delete(bySubnet, subnetID)
+	}
+}
+
+// copySSHConfig returns a copy of 'in', so the configurations in cache cannot be altered by their users
+func copySSHConfig(in *system.SSHConfig) *system.SSHConfig {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	return &out
+}
+
+// invalidateSubnetGateways removes from cache the SSH configurations of the gateways of the Subnet, to be called when
+// the gateways of the Subnet change
+func invalidateSubnetGateways(svc iaas.Service, subnetID string) {
+	if svc == nil || subnetID == "" {
+		return
+	}
+	subnetGatewaysCache.invalidate(svc.GetName(), subnetID)
+}
+
+// refreshSubnetHostsGateways removes from cache the SSH configurations of the gateways of the Subnet, then makes the
+// Hosts of the Subnet currently in cache use the new gateways, to be called when the gateways of an existing Subnet
+// change; Hosts not in cache will use the new gateways when loaded
+// Must not be called while holding the lock of the Subnet. Failures are only logged: a Host not refreshed can still be
+// refreshed with Host.RefreshConnectivity()
+func refreshSubnetHostsGateways(svc iaas.Service, subnetID string) {
+	invalidateSubnetGateways(svc, subnetID)
+	if svc == nil || subnetID == "" {
+		return
+	}
+
+	subnetInstance, xerr := LoadSubnet(svc, "", subnetID)
+	if xerr != nil {
+		logrus.Warnf("failed to load Subnet '%s' to refresh the gateways of its Hosts: %v", subnetID, xerr)
+		return
+	}
+	defer subnetInstance.Released()
+
+	var hostIDs []string
+	xerr = subnetInstance.Review(func(clonable data.Clonable, props *serialize.JSONProperties) fail.Error {
+		as, ok := clonable.(*abstract.Subnet)
+		if !ok {
+			return fail.InconsistentError("'*abstract.Subnet' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		// Gateways are reached directly, and the caller may be one of them, holding its lock
+		gateways := make(map[string]struct{}, len(as.GatewayIDs))
+		for _, v := range as.GatewayIDs {
+			gateways[v] = struct{}{}
+		}
+		return props.Inspect(subnetproperty.HostsV1, func(clonable data.Clonable) fail.Error {
+			subnetHostsV1, ok := clonable.(*propertiesv1.SubnetHosts)
+			if !ok {
+				return fail.InconsistentError("'*propertiesv1.SubnetHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+			}
+
+			for k := range subnetHostsV1.ByID {
+				if _, ok := gateways[k]; !ok {
+					hostIDs = append(hostIDs, k)
+				}
+			}
+			return nil
+		})
+	})
+	if xerr != nil {
+		logrus.Warnf("failed to list the Hosts of Subnet '%s' to refresh their gateways: %v", subnetInstance.GetName(), xerr)
+		return
+	}
+
+	hostCache, xerr := svc.GetCache(hostKind)
+	if xerr != nil {
+		logrus.Warnf("failed to get cache of Hosts to refresh their gateways: %v", xerr)
+		return
+	}
+
+	for _, id := range hostIDs {
+		// No loader: only the Hosts in cache may use the previous gateways
+		ce, xerr := hostCache.Get(id)
+		if xerr != nil {
+			continue
+		}
+		hostInstance, ok := ce.Content().(*Host)
+		if !ok || hostInstance.IsNull() {
+			continue
+		}
+
+		// Do not wait for a Host busy with another operation
+		if !hostInstance.lock.LockWithTimeout(temporal.GetHostReadLockTimeout()) {
+			logrus.Warnf("Host '%s' is busy with another operation, its gateways have not been refreshed", hostInstance.GetName())
+			continue
+		}
+		xerr = hostInstance.unsafeRefreshGateways()
+		hostInstance.lock.Unlock()
+		if xerr != nil {
+			logrus.Warnf("failed to refresh the gateways of Host '%s': %v", hostInstance.GetName(), xerr)
+		}
+	}
+}
@@ -90,6 +90,7 @@ func (instance *Subnet) taskCreateGateway(task concurrency.Task, params concurre
 	if xerr != nil {
 		return nil, xerr
 	}
+	invalidateSubnetGateways(svc, instance.GetID())
 
 	// Starting from here, deletes the gateway if exiting with error
 	defer func() {
@@ -185,6 +186,13 @@ func (instance *Subnet) taskFinalizeGatewayConfiguration(task concurrency.Task,
 		fmt.Sprintf("Ending final configuration phases on the gateway '%s'", gwname),
 	)()
 
+	// userdata of gateways is completed after their creation, records it again for Host.RunPhase
+	xerr = objgw.persistUserdata(userData)
+	xerr = debug.InjectPlannedFail(xerr)
+	if xerr != nil {
+		return nil, xerr
+	}
+
 	xerr = objgw.runInstallPhase(task.GetContext(), userdata.PHASE3_GATEWAY_HIGH_AVAILABILITY, userData)
 	xerr = debug.InjectPlannedFail(xerr)
 	if xerr != nil {
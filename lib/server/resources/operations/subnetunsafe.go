@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/CS-SI/SafeScale/lib/server/resources"
 	"github.com/CS-SI/SafeScale/lib/server/resources/abstract"
@@ -32,6 +33,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/utils/data"
 	"github.com/CS-SI/SafeScale/lib/utils/debug"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
+	netutils "github.com/CS-SI/SafeScale/lib/utils/net"
 	"github.com/CS-SI/SafeScale/lib/utils/serialize"
 	"github.com/sirupsen/logrus"
 )
@@ -444,3 +446,90 @@ func (instance *Subnet) createInternalSecurityGroup(ctx context.Context, network
 
 	return sg, nil
 }
+
+// unsafeListAttachedHosts returns the names of the Hosts (excluding gateways) still attached to the Subnet and still owning metadata
+// Note: a lock of the instance (instance.lock.Lock() or instance.lock.RLock()) must have been called before calling this method
+func (instance *Subnet) unsafeListAttachedHosts(as *abstract.Subnet, props *serialize.JSONProperties) ([]string, fail.Error) {
+	gateways := make(map[string]struct{}, len(as.GatewayIDs))
+	for _, v := range as.GatewayIDs {
+		gateways[v] = struct{}{}
+	}
+
+	var list []string
+	xerr := props.Inspect(subnetproperty.HostsV1, func(clonable data.Clonable) fail.Error {
+		shV1, ok := clonable.(*propertiesv1.SubnetHosts)
+		if !ok {
+			return fail.InconsistentError("'*propertiesv1.SubnetHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		svc := instance.GetService()
+		list = make([]string, 0, len(shV1.ByID))
+		for id, name := range shV1.ByID {
+			if _, ok := gateways[id]; ok {
+				continue
+			}
+
+			// Check if Host still has metadata and count it if yes
+			hostInstance, innerXErr := LoadHost(svc, id)
+			if innerXErr != nil {
+				switch innerXErr.(type) {
+				case *fail.ErrNotFound:
+					continue
+				default:
+					return innerXErr
+				}
+			}
+			hostInstance.Released()
+			list = append(list, name)
+		}
+		return nil
+	})
+	if xerr != nil {
+		return nil, xerr
+	}
+
+	sort.Strings(list)
+	return list, nil
+}
+
+// unsafeCountFreeIPs returns the number of IP addresses still available in the Subnet for new Hosts
+// Note: a lock of the instance (instance.lock.Lock() or instance.lock.RLock()) must have been called before calling this method
+func (instance *Subnet) unsafeCountFreeIPs(as *abstract.Subnet, props *serialize.JSONProperties) (uint, fail.Error) {
+	start, end, xerr := netutils.CIDRToUInt32Range(as.CIDR)
+	if xerr != nil {
+		return 0, xerr
+	}
+
+	// network and broadcast addresses are not usable
+	used := uint64(2) + uint64(len(as.GatewayIDs))
+	if as.VIP != nil {
+		used++
+	}
+
+	gateways := make(map[string]struct{}, len(as.GatewayIDs))
+	for _, v := range as.GatewayIDs {
+		gateways[v] = struct{}{}
+	}
+	xerr = props.Inspect(subnetproperty.HostsV1, func(clonable data.Clonable) fail.Error {
+		shV1, ok := clonable.(*propertiesv1.SubnetHosts)
+		if !ok {
+			return fail.InconsistentError("'*propertiesv1.SubnetHosts' expected, '%s' provided", reflect.TypeOf(clonable).String())
+		}
+
+		for k := range shV1.ByID {
+			if _, ok := gateways[k]; !ok {
+				used++
+			}
+		}
+		return nil
+	})
+	if xerr != nil {
+		return 0, xerr
+	}
+
+	size := uint64(end-start) + 1
+	if used >= size {
+		return 0, nil
+	}
+	return uint(size - used), nil
+}
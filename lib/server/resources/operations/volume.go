@@ -21,7 +21,6 @@ import (
 	"reflect"
 	"strings"
 	"sync"
-	"time"
 
 	mapset "github.com/deckarep/golang-set"
 	"github.com/sirupsen/logrus"
@@ -684,13 +683,13 @@ func (instance *volume) Attach(ctx context.Context, host resources.Host, path, f
 			}
 			return nil
 		},
-		2*time.Minute,
+		temporal.GetVolumeAttachmentTimeout(ctx),
 	)
 	if retryErr != nil {
 		if _, ok := retryErr.(*retry.ErrTimeout); ok {
 			return retryErr
 		}
-		return fail.Wrap(retryErr, fmt.Sprintf("failed to confirm the disk attachment after %s", 2*time.Minute))
+		return fail.Wrap(retryErr, fmt.Sprintf("failed to confirm the disk attachment after %s", temporal.GetVolumeAttachmentTimeout(ctx)))
 	}
 
 	if task.Aborted() {
@@ -890,10 +889,10 @@ func listAttachedDevices(ctx context.Context, host resources.Host) (_ mapset.Set
 			}
 			return nil
 		},
-		2*time.Minute,
+		temporal.GetVolumeAttachmentTimeout(ctx),
 	)
 	if retryErr != nil {
-		return nil, fail.Wrap(retryErr, "failed to get list of connected disks after %s", 2*time.Minute)
+		return nil, fail.Wrap(retryErr, "failed to get list of connected disks after %s", temporal.GetVolumeAttachmentTimeout(ctx))
 	}
 
 	disks := strings.Split(stdout, "\n")
@@ -73,12 +73,19 @@ type ClusterFeatures struct {
 	// Disabled keeps track of features normally automatically added with cluster creation,
 	// but explicitely disabled; if a disabled feature is added, must be removed from this property
 	Disabled map[string]struct{} `json:"disabled"`
+	// BestEffort keeps track of features normally automatically added with cluster creation, whose installation failure
+	// must not fail the configuration of the cluster
+	BestEffort map[string]struct{} `json:"best_effort,omitempty"`
+	// Failed keeps track of the features added on a best-effort basis whose installation failed, with the reason
+	Failed map[string]string `json:"failed,omitempty"`
 }
 
 func newClusterFeatures() *ClusterFeatures {
 	return &ClusterFeatures{
-		Installed: map[string]*ClusterInstalledFeature{},
-		Disabled:  map[string]struct{}{},
+		Installed:  map[string]*ClusterInstalledFeature{},
+		Disabled:   map[string]struct{}{},
+		BestEffort: map[string]struct{}{},
+		Failed:     map[string]string{},
 	}
 }
 
@@ -105,6 +112,14 @@ func (f *ClusterFeatures) Replace(p data.Clonable) data.Clonable {
 	for k, v := range src.Disabled {
 		f.Disabled[k] = v
 	}
+	f.BestEffort = make(map[string]struct{}, len(src.BestEffort))
+	for k, v := range src.BestEffort {
+		f.BestEffort[k] = v
+	}
+	f.Failed = make(map[string]string, len(src.Failed))
+	for k, v := range src.Failed {
+		f.Failed[k] = v
+	}
 	return f
 }
 
@@ -48,6 +48,8 @@ func TestFeatures_Clone(t *testing.T) {
 	ct.Installed["fair"] = NewClusterInstalledFeature()
 	ct.Installed["fair"].Requires["something"] = struct{}{}
 	ct.Disabled["kind"] = struct{}{}
+	ct.BestEffort["remotedesktop"] = struct{}{}
+	ct.Failed["remotedesktop"] = "failed"
 
 	clonedCt, ok := ct.Clone().(*ClusterFeatures)
 	if !ok {
@@ -63,3 +65,21 @@ func TestFeatures_Clone(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestFeatures_CloneBestEffort(t *testing.T) {
+	ct := newClusterFeatures()
+	ct.BestEffort["remotedesktop"] = struct{}{}
+	ct.Failed["remotedesktop"] = "failed"
+
+	clonedCt, ok := ct.Clone().(*ClusterFeatures)
+	if !ok {
+		t.Fail()
+	}
+
+	assert.Equal(t, ct, clonedCt)
+	delete(clonedCt.Failed, "remotedesktop")
+	clonedCt.BestEffort["reverseproxy"] = struct{}{}
+
+	assert.Contains(t, ct.Failed, "remotedesktop")
+	assert.NotContains(t, ct.BestEffort, "reverseproxy")
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package propertiesv1
+
+import (
+	"time"
+
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterproperty"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterstate"
+	"github.com/CS-SI/SafeScale/lib/utils/data"
+	"github.com/CS-SI/SafeScale/lib/utils/serialize"
+)
+
+// ClusterStateHistoryMaxLength is the maximum number of state transitions kept in ClusterStateHistory
+const ClusterStateHistoryMaxLength = 50
+
+// ClusterStateTransition describes a change of state of a cluster
+type ClusterStateTransition struct {
+	From      clusterstate.Enum `json:"from"`             // state of the cluster before the transition
+	To        clusterstate.Enum `json:"to"`               // state of the cluster after the transition
+	Timestamp time.Time         `json:"timestamp"`        // tells when the transition occurred
+	Reason    string            `json:"reason,omitempty"` // contains a description of what triggered the transition
+}
+
+// ClusterStateHistory contains the last state transitions of a cluster
+// not FROZEN yet
+// Note: if tagged as FROZEN, must not be changed ever.
+//       Create a new version instead with needed supplemental fields
+type ClusterStateHistory struct {
+	Transitions []ClusterStateTransition `json:"transitions,omitempty"` // ordered from the oldest to the newest
+}
+
+func newClusterStateHistory() *ClusterStateHistory {
+	return &ClusterStateHistory{}
+}
+
+// Clone ...
+// satisfies interface data.Clonable
+func (h ClusterStateHistory) Clone() data.Clonable {
+	return newClusterStateHistory().Replace(&h)
+}
+
+// Replace ...
+// satisfies interface data.Clonable
+func (h *ClusterStateHistory) Replace(p data.Clonable) data.Clonable {
+	// Do not test with isNull(), it's allowed to clone a null value...
+	if h == nil || p == nil {
+		return h
+	}
+
+	src := p.(*ClusterStateHistory)
+	h.Transitions = make([]ClusterStateTransition, len(src.Transitions))
+	copy(h.Transitions, src.Transitions)
+	return h
+}
+
+// Append records a new transition, discarding the oldest ones to keep at most ClusterStateHistoryMaxLength entries
+func (h *ClusterStateHistory) Append(from, to clusterstate.Enum, reason string) {
+	h.Transitions = append(h.Transitions, ClusterStateTransition{
+		From:      from,
+		To:        to,
+		Timestamp: time.Now(),
+		Reason:    reason,
+	})
+	if count := len(h.Transitions); count > ClusterStateHistoryMaxLength {
+		h.Transitions = append([]ClusterStateTransition{}, h.Transitions[count-ClusterStateHistoryMaxLength:]...)
+	}
+}
+
+func init() {
+	serialize.PropertyTypeRegistry.Register("resources.cluster", clusterproperty.StateHistoryV1, newClusterStateHistory())
+}
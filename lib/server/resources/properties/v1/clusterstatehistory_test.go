@@ -0,0 +1,58 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package propertiesv1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterstate"
+)
+
+func TestStateHistory_Clone(t *testing.T) {
+	ct := newClusterStateHistory()
+	ct.Append(clusterstate.Creating, clusterstate.Nominal, "created")
+
+	clonedCt, ok := ct.Clone().(*ClusterStateHistory)
+	if !ok {
+		t.Fail()
+	}
+
+	assert.Equal(t, ct, clonedCt)
+	clonedCt.Transitions[0].To = clusterstate.Degraded
+
+	areEqual := reflect.DeepEqual(ct, clonedCt)
+	if areEqual {
+		t.Error("It's a shallow clone !")
+		t.Fail()
+	}
+}
+
+func TestStateHistory_Append(t *testing.T) {
+	ct := newClusterStateHistory()
+	for i := 0; i < ClusterStateHistoryMaxLength+10; i++ {
+		ct.Append(clusterstate.Nominal, clusterstate.Degraded, "")
+	}
+	ct.Append(clusterstate.Degraded, clusterstate.Nominal, "repaired")
+
+	assert.Equal(t, ClusterStateHistoryMaxLength, len(ct.Transitions))
+	last := ct.Transitions[len(ct.Transitions)-1]
+	assert.Equal(t, clusterstate.Nominal, last.To)
+	assert.Equal(t, "repaired", last.Reason)
+}
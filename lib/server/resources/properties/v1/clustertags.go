@@ -0,0 +1,64 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package propertiesv1
+
+import (
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterproperty"
+	"github.com/CS-SI/SafeScale/lib/utils/data"
+	"github.com/CS-SI/SafeScale/lib/utils/serialize"
+)
+
+// ClusterTags contains the tags set on the resources of a cluster (Hosts, Subnets and Network) in the provider, for
+// cost allocation for example
+// not FROZEN yet
+// Note: if tagged as FROZEN, must not be changed ever.
+//       Create a new version instead with needed supplemental fields
+type ClusterTags struct {
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+func newClusterTags() *ClusterTags {
+	return &ClusterTags{
+		Tags: map[string]string{},
+	}
+}
+
+// Clone ...
+// satisfies interface data.Clonable
+func (t ClusterTags) Clone() data.Clonable {
+	return newClusterTags().Replace(&t)
+}
+
+// Replace ...
+// satisfies interface data.Clonable
+func (t *ClusterTags) Replace(p data.Clonable) data.Clonable {
+	// Do not test with isNull(), it's allowed to clone a null value...
+	if t == nil || p == nil {
+		return t
+	}
+
+	src := p.(*ClusterTags)
+	t.Tags = make(map[string]string, len(src.Tags))
+	for k, v := range src.Tags {
+		t.Tags[k] = v
+	}
+	return t
+}
+
+func init() {
+	serialize.PropertyTypeRegistry.Register("resources.cluster", clusterproperty.TagsV1, newClusterTags())
+}
@@ -19,7 +19,9 @@ package propertiesv1
 import (
 	"time"
 
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/accesspreference"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hostproperty"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hosttenancy"
 	"github.com/CS-SI/SafeScale/lib/utils/data"
 	"github.com/CS-SI/SafeScale/lib/utils/serialize"
 )
@@ -35,6 +37,32 @@ type HostDescription struct {
 	Purpose string    `json:"purpose,omitempty"`  // contains a description of the use of a host (not set for now)
 	Tenant  string    `json:"tenant,omitempty"`   // contains the tenant name used to create the host
 	Domain  string    `json:"domain,omitempty"`   // Contains the domain used to define the FQDN of the host at creation (taken from first network attached to the host)
+	// LastStateChanged tells the last time the state of the host has been seen changing (zero if never observed)
+	LastStateChanged time.Time `json:"last_state_changed,omitempty"`
+	// Tenancy tells on which kind of physical host the Host has been placed
+	Tenancy hosttenancy.Enum `json:"tenancy,omitempty"`
+	// DedicatedHostID contains the ID of the dedicated host the Host has been placed on (if any)
+	DedicatedHostID string `json:"dedicated_host_id,omitempty"`
+	// LastStopMethod tells how the Host has been stopped the last time ("guest" or "provider"; empty if never stopped by SafeScale)
+	LastStopMethod string `json:"last_stop_method,omitempty"`
+	// ClockSkew contains the difference between the clock of the host and the one of the daemon, measured at creation
+	ClockSkew time.Duration `json:"clock_skew,omitempty"`
+	// AccessPreference tells which IP address to use to access the Host (preference of the tenant by default)
+	AccessPreference accesspreference.Enum `json:"access_preference,omitempty"`
+	// Imported tells if the host has been created outside SafeScale, then brought under management
+	Imported bool `json:"imported,omitempty"`
+	// PreservePublicIP tells if the public IP of the host has to be kept across stop and start (requested at creation)
+	PreservePublicIP bool `json:"preserve_public_ip,omitempty"`
+	// PublicIPPreserved tells if the public IP of the host is kept when the host is stopped
+	PublicIPPreserved bool `json:"public_ip_preserved,omitempty"`
+	// PublicIPPreservationError contains the reason why the public IP of the host could not be preserved
+	PublicIPPreservationError string `json:"public_ip_preservation_error,omitempty"`
+	// LastImageID contains the ID of the last image created from the disk of the host
+	LastImageID string `json:"last_image_id,omitempty"`
+	// LastImageName contains the name of the last image created from the disk of the host
+	LastImageName string `json:"last_image_name,omitempty"`
+	// LastImageCreated tells when the last image has been created from the disk of the host
+	LastImageCreated time.Time `json:"last_image_created,omitempty"`
 }
 
 // NewHostDescription ...
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package propertiesv1
+
+import (
+	"time"
+
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hostproperty"
+	"github.com/CS-SI/SafeScale/lib/utils/data"
+	"github.com/CS-SI/SafeScale/lib/utils/serialize"
+)
+
+// HostInventory contains the inventory of the operating system and of the packages installed on the host, as
+// collected by Host.CollectInventory()
+// not FROZEN yet
+// Note: if tagged as FROZEN, must not be changed ever.
+//       Create a new version instead with needed supplemental fields
+type HostInventory struct {
+	OSID           string            `json:"os_id,omitempty"`           // ID of the distribution, from /etc/os-release ("ubuntu", "centos", ...)
+	OSVersion      string            `json:"os_version,omitempty"`      // version of the distribution, from /etc/os-release ("20.04", "7", ...)
+	OSName         string            `json:"os_name,omitempty"`         // full name of the distribution, from /etc/os-release
+	Kernel         string            `json:"kernel,omitempty"`          // release of the running kernel
+	PackageManager string            `json:"package_manager,omitempty"` // package manager used to list the packages ("dpkg", "rpm"); empty if none is supported
+	Packages       map[string]string `json:"packages,omitempty"`        // versions of the installed packages, indexed by package name
+	CollectedAt    time.Time         `json:"collected_at,omitempty"`    // tells when the inventory has been collected
+}
+
+// NewHostInventory ...
+func NewHostInventory() *HostInventory {
+	return &HostInventory{
+		Packages: map[string]string{},
+	}
+}
+
+// Clone ...
+// satisfies interface data.Clonable
+func (hi HostInventory) Clone() data.Clonable {
+	return NewHostInventory().Replace(&hi)
+}
+
+// Replace ...
+// satisfies interface data.Clonable
+func (hi *HostInventory) Replace(p data.Clonable) data.Clonable {
+	// Do not test with isNull(), it's allowed to clone a null value...
+	if hi == nil || p == nil {
+		return hi
+	}
+
+	src := p.(*HostInventory)
+	*hi = *src
+	hi.Packages = make(map[string]string, len(src.Packages))
+	for k, v := range src.Packages {
+		hi.Packages[k] = v
+	}
+	return hi
+}
+
+func init() {
+	serialize.PropertyTypeRegistry.Register("resources.host", hostproperty.InventoryV1, NewHostInventory())
+}
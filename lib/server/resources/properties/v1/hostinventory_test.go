@@ -0,0 +1,48 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package propertiesv1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostInventory_Clone(t *testing.T) {
+	hi := NewHostInventory()
+	hi.OSID = "ubuntu"
+	hi.Kernel = "5.4.0-42-generic"
+	hi.PackageManager = "dpkg"
+	hi.Packages["openssl"] = "1.1.1f-1ubuntu2"
+	hi.CollectedAt = time.Now()
+
+	clonedHi, ok := hi.Clone().(*HostInventory)
+	if !ok {
+		t.Fail()
+	}
+
+	assert.Equal(t, hi, clonedHi)
+	clonedHi.Packages["openssl"] = "1.1.1f-1ubuntu2.1"
+
+	areEqual := reflect.DeepEqual(hi, clonedHi)
+	if areEqual {
+		t.Error("It's a shallow clone !")
+		t.Fail()
+	}
+}
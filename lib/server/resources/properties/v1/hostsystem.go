@@ -18,6 +18,8 @@ package propertiesv1
 
 import (
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hostproperty"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/installmethod"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/privilegeescalation"
 	"github.com/CS-SI/SafeScale/lib/utils/data"
 	"github.com/CS-SI/SafeScale/lib/utils/serialize"
 )
@@ -31,6 +33,13 @@ type HostSystem struct {
 	Flavor   string `json:"flavor,omitempty"`   // Flavor of operating system (ie 'ubuntu server', 'windows server 2016', ... Not normalized yet...)
 	Image    string `json:"image,omitempty"`    // name of the provider's image used
 	HostName string `json:"hostname,omitempty"` // Hostname on the system
+	// PrivilegeEscalation tells how to gain administrative privileges on the host
+	PrivilegeEscalation privilegeescalation.Enum `json:"privilege_escalation,omitempty"`
+	DefaultShell        string                   `json:"default_shell,omitempty"` // shell used to run scripts on the host (bash if empty)
+	TempFolder          string                   `json:"temp_folder,omitempty"`   // folder where scripts are uploaded on the host (utils.TempFolder if empty)
+	// InstallMethods overrides the methods used to install Features on the host, by order of preference (detected from the
+	// operating system if empty)
+	InstallMethods []installmethod.Enum `json:"install_methods,omitempty"`
 }
 
 // NewHostSystem ...
@@ -57,6 +66,10 @@ func (hs *HostSystem) Replace(p data.Clonable) data.Clonable {
 
 	src := p.(*HostSystem)
 	*hs = *src
+	if len(src.InstallMethods) > 0 {
+		hs.InstallMethods = make([]installmethod.Enum, len(src.InstallMethods))
+		copy(hs.InstallMethods, src.InstallMethods)
+	}
 	return hs
 }
 
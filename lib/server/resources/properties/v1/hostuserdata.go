@@ -0,0 +1,62 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package propertiesv1
+
+import (
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hostproperty"
+	"github.com/CS-SI/SafeScale/lib/utils/data"
+	"github.com/CS-SI/SafeScale/lib/utils/serialize"
+)
+
+// HostUserdata contains the userdata used to provision the host, without its secrets
+// not FROZEN yet
+// Note: if tagged as FROZEN, must not be changed ever.
+//       Create a new version instead with needed supplemental fields
+type HostUserdata struct {
+	Content string `json:"content,omitempty"` // JSON of the sanitized userdata.Content
+}
+
+// NewHostUserdata ...
+func NewHostUserdata() *HostUserdata {
+	return &HostUserdata{}
+}
+
+// Reset ...
+func (hu *HostUserdata) Reset() {
+	*hu = HostUserdata{}
+}
+
+// Clone ...
+func (hu HostUserdata) Clone() data.Clonable {
+	return NewHostUserdata().Replace(&hu)
+}
+
+// Replace ...
+func (hu *HostUserdata) Replace(p data.Clonable) data.Clonable {
+	// Do not test with isNull(), it's allowed to clone a null value...
+	if hu == nil || p == nil {
+		return hu
+	}
+
+	src := p.(*HostUserdata)
+	*hu = *src
+	return hu
+}
+
+func init() {
+	serialize.PropertyTypeRegistry.Register("resources.host", hostproperty.UserdataV1, NewHostUserdata())
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package propertiesv1
+
+import (
+	"time"
+
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterproperty"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/hostproperty"
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/operationstatus"
+	"github.com/CS-SI/SafeScale/lib/utils/data"
+	"github.com/CS-SI/SafeScale/lib/utils/serialize"
+)
+
+// LastOperation describes the last operation run on a resource (host or cluster), to be able to tell what happened to
+// it without digging into the logs
+// not FROZEN yet
+// Note: if tagged as FROZEN, must not be changed ever.
+//       Create a new version instead with needed supplemental fields
+type LastOperation struct {
+	Type    string               `json:"type,omitempty"`    // name of the operation ("create", "start", ...)
+	Status  operationstatus.Enum `json:"status,omitempty"`  // status of the operation
+	Started time.Time            `json:"started,omitempty"` // tells when the operation started
+	Ended   time.Time            `json:"ended,omitempty"`   // tells when the operation ended (zero while running)
+	Error   string               `json:"error,omitempty"`   // contains the error message if the operation failed
+}
+
+// NewLastOperation ...
+func NewLastOperation() *LastOperation {
+	return &LastOperation{}
+}
+
+// Clone ...
+// satisfies interface data.Clonable
+func (lo LastOperation) Clone() data.Clonable {
+	return NewLastOperation().Replace(&lo)
+}
+
+// Replace ...
+// satisfies interface data.Clonable
+func (lo *LastOperation) Replace(p data.Clonable) data.Clonable {
+	// Do not test with isNull(), it's allowed to clone a null value...
+	if lo == nil || p == nil {
+		return lo
+	}
+
+	src := p.(*LastOperation)
+	*lo = *src
+	return lo
+}
+
+// Begin records the start of the operation 'operationType', replacing the previous one
+func (lo *LastOperation) Begin(operationType string) {
+	*lo = LastOperation{
+		Type:    operationType,
+		Status:  operationstatus.Running,
+		Started: time.Now(),
+	}
+}
+
+// End records the outcome of the current operation: failed with the message of 'err' if not nil, succeeded otherwise
+func (lo *LastOperation) End(err error) {
+	lo.Ended = time.Now()
+	if err != nil {
+		lo.Status = operationstatus.Failed
+		lo.Error = err.Error()
+		return
+	}
+	lo.Status = operationstatus.Succeeded
+	lo.Error = ""
+}
+
+func init() {
+	serialize.PropertyTypeRegistry.Register("resources.host", hostproperty.LastOperationV1, NewLastOperation())
+	serialize.PropertyTypeRegistry.Register("resources.cluster", clusterproperty.LastOperationV1, NewLastOperation())
+}
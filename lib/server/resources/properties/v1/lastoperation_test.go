@@ -0,0 +1,66 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package propertiesv1
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/operationstatus"
+)
+
+func TestLastOperation_Clone(t *testing.T) {
+	lo := NewLastOperation()
+	lo.Begin("create")
+
+	clonedLo, ok := lo.Clone().(*LastOperation)
+	if !ok {
+		t.Fail()
+	}
+
+	assert.Equal(t, lo, clonedLo)
+	clonedLo.End(errors.New("failure"))
+
+	areEqual := reflect.DeepEqual(lo, clonedLo)
+	if areEqual {
+		t.Error("It's a shallow clone !")
+		t.Fail()
+	}
+}
+
+func TestLastOperation_BeginEnd(t *testing.T) {
+	lo := NewLastOperation()
+	lo.Begin("start")
+	assert.Equal(t, "start", lo.Type)
+	assert.Equal(t, operationstatus.Running, lo.Status)
+	assert.False(t, lo.Started.IsZero())
+	assert.True(t, lo.Ended.IsZero())
+
+	lo.End(errors.New("provider unavailable"))
+	assert.Equal(t, operationstatus.Failed, lo.Status)
+	assert.Equal(t, "provider unavailable", lo.Error)
+	assert.False(t, lo.Ended.Before(lo.Started))
+
+	lo.Begin("stop")
+	assert.Equal(t, "", lo.Error)
+	assert.True(t, lo.Ended.IsZero())
+	lo.End(nil)
+	assert.Equal(t, operationstatus.Succeeded, lo.Status)
+}
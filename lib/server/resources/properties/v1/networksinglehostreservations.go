@@ -0,0 +1,92 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package propertiesv1
+
+import (
+	"sort"
+	"time"
+
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/networkproperty"
+	"github.com/CS-SI/SafeScale/lib/utils/data"
+	"github.com/CS-SI/SafeScale/lib/utils/serialize"
+)
+
+// NetworkSingleHostReservations contains the CIDR slots for single Hosts reserved in NetworkSingleHosts but not yet
+// committed in the metadata of the corresponding Subnet; a slot staying uncommitted too long has been leaked (by a crash
+// of the daemon during the creation of the Subnet, for example) and can be reclaimed
+// not FROZEN yet
+// Note: if tagged as FROZEN, must not be changed ever.
+//       Create a new version instead with needed supplemental fields
+type NetworkSingleHostReservations struct {
+	ByIndex map[uint]time.Time `json:"by_index,omitempty"` // contains the time of reservation of uncommitted slots, indexed by slot
+}
+
+// NewNetworkSingleHostReservations ...
+func NewNetworkSingleHostReservations() *NetworkSingleHostReservations {
+	return &NetworkSingleHostReservations{
+		ByIndex: map[uint]time.Time{},
+	}
+}
+
+// Clone ... (data.Clonable interface)
+func (nshr NetworkSingleHostReservations) Clone() data.Clonable {
+	return NewNetworkSingleHostReservations().Replace(&nshr)
+}
+
+// Replace ... (data.Clonable interface)
+func (nshr *NetworkSingleHostReservations) Replace(p data.Clonable) data.Clonable {
+	// Do not test with isNull(), it's allowed to clone a null value...
+	if nshr == nil || p == nil {
+		return nshr
+	}
+
+	src := p.(*NetworkSingleHostReservations)
+	nshr.ByIndex = make(map[uint]time.Time, len(src.ByIndex))
+	for k, v := range src.ByIndex {
+		nshr.ByIndex[k] = v
+	}
+	return nshr
+}
+
+// Reserve records the slot 'index' as reserved but not committed
+func (nshr *NetworkSingleHostReservations) Reserve(index uint) {
+	if nshr.ByIndex == nil {
+		nshr.ByIndex = map[uint]time.Time{}
+	}
+	nshr.ByIndex[index] = time.Now()
+}
+
+// Commit forgets the reservation of slot 'index', now recorded in the metadata of its Subnet
+func (nshr *NetworkSingleHostReservations) Commit(index uint) {
+	delete(nshr.ByIndex, index)
+}
+
+// Expired returns the slots reserved for longer than 'timeout' without being committed, sorted by index
+func (nshr *NetworkSingleHostReservations) Expired(timeout time.Duration) []uint {
+	var out []uint
+	for k, v := range nshr.ByIndex {
+		if time.Since(v) > timeout {
+			out = append(out, k)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+func init() {
+	serialize.PropertyTypeRegistry.Register("resources.network", string(networkproperty.SingleHostReservationsV1), NewNetworkSingleHostReservations())
+}
@@ -0,0 +1,43 @@
+package propertiesv1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkSingleHostReservations_Clone(t *testing.T) {
+	ct := NewNetworkSingleHostReservations()
+	ct.Reserve(1)
+
+	clonedCt, ok := ct.Clone().(*NetworkSingleHostReservations)
+	if !ok {
+		t.Fail()
+	}
+
+	assert.Equal(t, ct, clonedCt)
+	clonedCt.Reserve(2)
+
+	areEqual := reflect.DeepEqual(ct, clonedCt)
+	if areEqual {
+		t.Error("It's a shallow clone !")
+		t.Fail()
+	}
+}
+
+func TestNetworkSingleHostReservations_Expired(t *testing.T) {
+	ct := NewNetworkSingleHostReservations()
+	ct.Reserve(3)
+	ct.Reserve(1)
+	ct.Reserve(2)
+	assert.Empty(t, ct.Expired(time.Minute))
+
+	ct.ByIndex[3] = time.Now().Add(-2 * time.Minute)
+	ct.ByIndex[1] = time.Now().Add(-2 * time.Minute)
+	assert.Equal(t, []uint{1, 3}, ct.Expired(time.Minute))
+
+	ct.Commit(1)
+	assert.Equal(t, []uint{3}, ct.Expired(time.Minute))
+}
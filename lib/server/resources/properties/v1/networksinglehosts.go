@@ -94,6 +94,20 @@ func (nsh *NetworkSingleHosts) ReserveSlot() (index uint) {
 	return index
 }
 
+// CountFreeSlots returns the number of CIDR slots still available for single Hosts
+func (nsh *NetworkSingleHosts) CountFreeSlots() uint {
+	// no free slot recorded means no slot has been reserved yet
+	if len(nsh.FreeSlots) == 0 {
+		return SingleHostsMaxCIDRSlotValue
+	}
+
+	var count uint
+	for _, v := range nsh.FreeSlots {
+		count += v.Last - v.First + 1
+	}
+	return count
+}
+
 // FreeSlot frees a slot
 func (nsh *NetworkSingleHosts) FreeSlot(index uint) {
 	var inserted bool
@@ -60,3 +60,16 @@ func TestNetworkSingleHosts_ReserveSlot(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestNetworkSingleHosts_CountFreeSlots(t *testing.T) {
+	ct := NewNetworkSingleHosts()
+	assert.Equal(t, SingleHostsMaxCIDRSlotValue, ct.CountFreeSlots())
+
+	_ = ct.ReserveSlot()
+	_ = ct.ReserveSlot()
+	_ = ct.ReserveSlot()
+	assert.Equal(t, SingleHostsMaxCIDRSlotValue-3, ct.CountFreeSlots())
+
+	ct.FreeSlot(2)
+	assert.Equal(t, SingleHostsMaxCIDRSlotValue-2, ct.CountFreeSlots())
+}
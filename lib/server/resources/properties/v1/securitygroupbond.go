@@ -26,6 +26,7 @@ type SecurityGroupBond struct {
 	ID         string `json:"id"`
 	Disabled   bool   `json:"disabled"`
 	FromSubnet bool   `json:"from_subnet"`
+	RefCount   uint   `json:"ref_count,omitempty"` // number of binds done on the bond; 0 (metadata written before reference counting) means 1
 }
 
 // NewSecurityGroupBond ...
@@ -40,6 +41,7 @@ func (sgb *SecurityGroupBond) Reset() *SecurityGroupBond {
 		sgb.ID = ""
 		sgb.Disabled = false
 		sgb.FromSubnet = false
+		sgb.RefCount = 0
 		return sgb
 	}
 	return NewSecurityGroupBond()
@@ -26,25 +26,28 @@ import (
 // ClusterNetwork contains network information relative to cluster
 // not FROZEN yet
 type ClusterNetwork struct {
-	NetworkID          string           `json:"network_id,omitempty"`           // contains the ID of the network
-	CreatedNetwork     bool             `json:"created_network,omitempty"`      // tells if the network had been created with the cluster
-	SubnetID           string           `json:"subnet_id,omitempty"`            // contains the ID of the subnet
-	CIDR               string           `json:"cidr,omitempty"`                 // the network CIDR
-	GatewayID          string           `json:"gateway_id,omitempty"`           // contains the ID of the primary gateway
-	GatewayIP          string           `json:"gateway_ip,omitempty"`           // contains the private IP address of the primary gateway
-	SecondaryGatewayID string           `json:"secondary_gateway_id,omitempty"` // contains the ID of the secondary gateway
-	SecondaryGatewayIP string           `json:"secondary_gateway_ip,omitempty"` // contains the private IP of the secondary gateway
-	DefaultRouteIP     string           `json:"default_route_ip,omitempty"`     // contains the IP of the default route
-	PrimaryPublicIP    string           `json:"primary_public_ip,omitempty"`    // contains the public IP of the primary gateway
-	SecondaryPublicIP  string           `json:"secondary_public_ip,omitempty"`  // contains the public IP of the secondary gateway
-	EndpointIP         string           `json:"endpoint_ip,omitempty"`          // contains the IP of the external Endpoint
-	SubnetState        subnetstate.Enum `json:"status,omitempty"`               // contains the network state
-	Domain             string           `json:"domain,omitempty"`               // contains the domain used to define the FQDN of hosts created (taken from network)
+	NetworkID          string            `json:"network_id,omitempty"`           // contains the ID of the network
+	CreatedNetwork     bool              `json:"created_network,omitempty"`      // tells if the network had been created with the cluster
+	SubnetID           string            `json:"subnet_id,omitempty"`            // contains the ID of the subnet
+	CIDR               string            `json:"cidr,omitempty"`                 // the network CIDR
+	GatewayID          string            `json:"gateway_id,omitempty"`           // contains the ID of the primary gateway
+	GatewayIP          string            `json:"gateway_ip,omitempty"`           // contains the private IP address of the primary gateway
+	SecondaryGatewayID string            `json:"secondary_gateway_id,omitempty"` // contains the ID of the secondary gateway
+	SecondaryGatewayIP string            `json:"secondary_gateway_ip,omitempty"` // contains the private IP of the secondary gateway
+	DefaultRouteIP     string            `json:"default_route_ip,omitempty"`     // contains the IP of the default route
+	PrimaryPublicIP    string            `json:"primary_public_ip,omitempty"`    // contains the public IP of the primary gateway
+	SecondaryPublicIP  string            `json:"secondary_public_ip,omitempty"`  // contains the public IP of the secondary gateway
+	EndpointIP         string            `json:"endpoint_ip,omitempty"`          // contains the IP of the external Endpoint
+	SubnetState        subnetstate.Enum  `json:"status,omitempty"`               // contains the network state
+	Domain             string            `json:"domain,omitempty"`               // contains the domain used to define the FQDN of hosts created (taken from network)
+	NodePoolSubnets    map[string]string `json:"node_pool_subnets,omitempty"`    // maps the name of a node pool to the ID of the Subnet dedicated to it
+	ExternalGateway    bool              `json:"external_gateway,omitempty"`     // tells if the gateway (and its Subnet) existed before the cluster, and must not be deleted with it
 }
 
 func newClusterNetwork() *ClusterNetwork {
 	return &ClusterNetwork{
-		SubnetState: subnetstate.Unknown,
+		SubnetState:     subnetstate.Unknown,
+		NodePoolSubnets: map[string]string{},
 	}
 }
 
@@ -62,7 +65,12 @@ func (n *ClusterNetwork) Replace(p data.Clonable) data.Clonable {
 		return n
 	}
 
-	*n = *p.(*ClusterNetwork)
+	src := p.(*ClusterNetwork)
+	*n = *src
+	n.NodePoolSubnets = make(map[string]string, len(src.NodePoolSubnets))
+	for k, v := range src.NodePoolSubnets {
+		n.NodePoolSubnets[k] = v
+	}
 	return n
 }
 
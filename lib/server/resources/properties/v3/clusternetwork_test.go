@@ -41,3 +41,18 @@ func TestNetwork_Clone(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestNetwork_CloneNodePoolSubnets(t *testing.T) {
+	ct := newClusterNetwork()
+	ct.NodePoolSubnets["gpu"] = "subnet-gpu"
+
+	clonedCt, ok := ct.Clone().(*ClusterNetwork)
+	if !ok {
+		t.Fail()
+	}
+
+	assert.Equal(t, ct, clonedCt)
+	clonedCt.NodePoolSubnets["cpu"] = "subnet-cpu"
+	assert.Equal(t, 1, len(ct.NodePoolSubnets))
+	assert.Equal(t, 2, len(clonedCt.NodePoolSubnets))
+}
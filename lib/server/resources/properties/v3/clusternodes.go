@@ -17,6 +17,7 @@
 package propertiesv3
 
 import (
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusternodestate"
 	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusterproperty"
 	"github.com/CS-SI/SafeScale/lib/utils/data"
 	"github.com/CS-SI/SafeScale/lib/utils/serialize"
@@ -35,20 +36,23 @@ type ClusterNode struct {
 // ClusterNodes contains all the nodes created in the cluster
 // Not frozen yet
 type ClusterNodes struct {
-	Masters           []uint                `json:"masters,omitempty"`
-	MasterByName      map[string]uint       `json:"master_by_name,omitempty"`
-	MasterByID        map[string]uint       `json:"master_by_id,omitempty"`
-	PrivateNodes      []uint                `json:"private_nodes,omitempty"`
-	PrivateNodeByName map[string]uint       `json:"private_node_by_name,omitempty"`
-	PrivateNodeByID   map[string]uint       `json:"private_node_by_id,omitempty"`
-	PublicNodes       []uint                `json:"public_nodes,omitempty"`
-	PublicNodeByName  map[string]uint       `json:"public_node_by_name,omitempty"`
-	PublicNodeByID    map[string]uint       `json:"public_node_by_id,omitempty"`
-	ByNumericalID     map[uint]*ClusterNode `json:"host_by_numeric_id,omitempty"` // maps *ClusterNode with NumericalID
-	MasterLastIndex   int                   `json:"master_last_index,omitempty"`  // is used to keep the index associated to the name of the last created master
-	PrivateLastIndex  int                   `json:"private_last_index,omitempty"` // is used to keep the index associated to the name of the last created private node
-	PublicLastIndex   int                   `json:"public_last_index,omitempty"`  // is used to keep the index associated to the name of the last created public node
-	GlobalLastIndex   uint                  `json:"global_last_index,omitempty"`  // is used to keep the index associated to the last created ClusterNode (being master or node)
+	Masters           []uint                         `json:"masters,omitempty"`
+	MasterByName      map[string]uint                `json:"master_by_name,omitempty"`
+	MasterByID        map[string]uint                `json:"master_by_id,omitempty"`
+	PrivateNodes      []uint                         `json:"private_nodes,omitempty"`
+	PrivateNodeByName map[string]uint                `json:"private_node_by_name,omitempty"`
+	PrivateNodeByID   map[string]uint                `json:"private_node_by_id,omitempty"`
+	PublicNodes       []uint                         `json:"public_nodes,omitempty"`
+	PublicNodeByName  map[string]uint                `json:"public_node_by_name,omitempty"`
+	PublicNodeByID    map[string]uint                `json:"public_node_by_id,omitempty"`
+	ByNumericalID     map[uint]*ClusterNode          `json:"host_by_numeric_id,omitempty"` // maps *ClusterNode with NumericalID
+	MasterLastIndex   int                            `json:"master_last_index,omitempty"`  // is used to keep the index associated to the name of the last created master
+	PrivateLastIndex  int                            `json:"private_last_index,omitempty"` // is used to keep the index associated to the name of the last created private node
+	PublicLastIndex   int                            `json:"public_last_index,omitempty"`  // is used to keep the index associated to the name of the last created public node
+	GlobalLastIndex   uint                           `json:"global_last_index,omitempty"`  // is used to keep the index associated to the last created ClusterNode (being master or node)
+	Cordoned          map[uint]bool                  `json:"cordoned,omitempty"`           // contains the NumericalID of the nodes marked unschedulable
+	Labels            map[uint]map[string]string     `json:"labels,omitempty"`             // contains the labels of the nodes, by NumericalID
+	States            map[uint]clusternodestate.Enum `json:"states,omitempty"`             // contains the state of the nodes not started, by NumericalID
 }
 
 func newClusterNodes() *ClusterNodes {
@@ -64,6 +68,9 @@ func newClusterNodes() *ClusterNodes {
 		PrivateNodeByID:   map[string]uint{},
 		ByNumericalID:     map[uint]*ClusterNode{},
 		GlobalLastIndex:   10, // Keep some places for special cases, like gateways NumericalID
+		Cordoned:          map[uint]bool{},
+		Labels:            map[uint]map[string]string{},
+		States:            map[uint]clusternodestate.Enum{},
 	}
 }
 
@@ -116,6 +123,25 @@ func (n *ClusterNodes) Replace(p data.Clonable) data.Clonable {
 		n.ByNumericalID[k] = &node
 	}
 
+	n.Cordoned = make(map[uint]bool, len(src.Cordoned))
+	for k, v := range src.Cordoned {
+		n.Cordoned[k] = v
+	}
+
+	n.Labels = make(map[uint]map[string]string, len(src.Labels))
+	for k, v := range src.Labels {
+		labels := make(map[string]string, len(v))
+		for lk, lv := range v {
+			labels[lk] = lv
+		}
+		n.Labels[k] = labels
+	}
+
+	n.States = make(map[uint]clusternodestate.Enum, len(src.States))
+	for k, v := range src.States {
+		n.States[k] = v
+	}
+
 	return n
 }
 
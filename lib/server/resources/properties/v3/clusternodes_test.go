@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/CS-SI/SafeScale/lib/server/resources/enums/clusternodestate"
 )
 
 func TestNodes_Clone(t *testing.T) {
@@ -72,3 +74,38 @@ func TestNodes_Clone2(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestNodes_Clone3(t *testing.T) {
+	ct := newClusterNodes()
+	ct.Cordoned[11] = true
+	clonedCt, ok := ct.Clone().(*ClusterNodes)
+	if !ok {
+		t.Fail()
+	}
+
+	assert.Equal(t, ct, clonedCt)
+	clonedCt.Cordoned[12] = true
+
+	areEqual := reflect.DeepEqual(ct, clonedCt)
+	if areEqual {
+		t.Error("It's a shallow clone !")
+		t.FailNow()
+	}
+}
+
+func TestNodes_CloneLabelsAndStates(t *testing.T) {
+	ct := newClusterNodes()
+	ct.Labels[11] = map[string]string{"env": "dev"}
+	ct.States[11] = clusternodestate.Stopped
+	clonedCt, ok := ct.Clone().(*ClusterNodes)
+	if !ok {
+		t.Fail()
+	}
+
+	assert.Equal(t, ct, clonedCt)
+	clonedCt.Labels[11]["env"] = "prod"
+	clonedCt.States[12] = clusternodestate.Stopped
+
+	assert.Equal(t, "dev", ct.Labels[11]["env"])
+	assert.Equal(t, 1, len(ct.States))
+}
@@ -64,10 +64,12 @@ type SecurityGroup interface {
 	DeleteRule(ctx context.Context, rule *abstract.SecurityGroupRule) fail.Error                                   // deletes a rule from a Security Group
 	GetBoundHosts(ctx context.Context) ([]*propertiesv1.SecurityGroupBond, fail.Error)                             // returns a slice of bonds corresponding to hosts bound to the security group
 	GetBoundSubnets(ctx context.Context) ([]*propertiesv1.SecurityGroupBond, fail.Error)                           // returns a slice of bonds corresponding to networks bound to the security group
+	RepairBindings(ctx context.Context) fail.Error                                                                 // unbinds the security group from hosts that do not exist anymore
+	Reconcile(ctx context.Context, rules abstract.SecurityGroupRules) fail.Error                                   // makes the rules of the security group match the ones provided
 	Reset(ctx context.Context) fail.Error                                                                          // resets the rules of the security group from the ones registered in metadata
 	ToProtocol() (*protocol.SecurityGroupResponse, fail.Error)                                                     // converts a SecurityGroup to equivalent gRPC message
-	UnbindFromHost(ctx context.Context, _ Host) fail.Error                                                         // unbinds a Security Group from Host
-	UnbindFromHostByReference(ctx context.Context, _ string) fail.Error                                            // unbinds a Security Group from Host
+	UnbindFromHost(ctx context.Context, _ Host, options ...data.ImmutableKeyValue) fail.Error                      // unbinds a Security Group from Host
+	UnbindFromHostByReference(ctx context.Context, _ string, options ...data.ImmutableKeyValue) fail.Error         // unbinds a Security Group from Host
 	UnbindFromSubnet(ctx context.Context, _ Subnet) fail.Error                                                     // unbinds a Security Group from Subnet
 	UnbindFromSubnetByReference(ctx context.Context, _ string) fail.Error                                          // unbinds a Security group from a Subnet identified by reference (ID or name)
 }
@@ -37,8 +37,10 @@ type Share interface {
 	Browse(ctx context.Context, callback func(hostName string, shareID string) fail.Error) fail.Error
 	Create(ctx context.Context, shareName string, host Host, path string, options string /*securityModes []string, readOnly, rootSquash, secure, async, noHide, crossMount, subtreeCheck bool*/) fail.Error // creates a share on host
 	Delete(ctx context.Context) fail.Error
+	GetServerID() (string, fail.Error)                                                                             // returns the ID of the Host acting as share server, without loading it
 	GetServer() (Host, fail.Error)                                                                                 // returns the *Host acting as share server, with error handling
 	Mount(ctx context.Context, host Host, path string, withCache bool) (*propertiesv1.HostRemoteMount, fail.Error) // mounts a share on a local directory of an host
 	Unmount(ctx context.Context, host Host) fail.Error                                                             // unmounts a share from local directory of an host
+	ForceUnmount(ctx context.Context, host Host) fail.Error                                                        // unmounts a share from local directory of an host, even if the share server is unreachable
 	ToProtocol() (*protocol.ShareMountList, fail.Error)
 }
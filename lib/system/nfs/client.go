@@ -65,7 +65,7 @@ func (c *Client) Mount(ctx context.Context, export string, mountPoint string, wi
 
 // Unmount a nfs share from NFS server
 func (c *Client) Unmount(ctx context.Context, export string) fail.Error {
-	data := map[string]interface{}{"Export": export}
+	data := map[string]interface{}{"Export": export, "Force": false}
 	stdout, xerr := executeScript(ctx, *c.SSHConfig, "nfs_client_share_unmount.sh", data)
 	if xerr != nil {
 		_ = xerr.Annotate("stdout", stdout)
@@ -73,3 +73,14 @@ func (c *Client) Unmount(ctx context.Context, export string) fail.Error {
 	}
 	return nil
 }
+
+// ForceUnmount forces a lazy unmount of a nfs share, usable when the NFS server is unreachable
+func (c *Client) ForceUnmount(ctx context.Context, export string) fail.Error {
+	data := map[string]interface{}{"Export": export, "Force": true}
+	stdout, xerr := executeScript(ctx, *c.SSHConfig, "nfs_client_share_unmount.sh", data)
+	if xerr != nil {
+		_ = xerr.Annotate("stdout", stdout)
+		return fail.Wrap(xerr, "error executing script to force unmount of remote NFS share")
+	}
+	return nil
+}
@@ -27,5 +27,9 @@ function print_error() {
 }
 trap print_error ERR
 
+{{ if .Force }}
 umount -fl {{.Export}}
+{{ else }}
+umount {{.Export}}
+{{ end }}
 sed -i '\#^{{.Export}}#d' /etc/fstab
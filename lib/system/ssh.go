@@ -17,6 +17,7 @@
 package system
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
 	"io"
@@ -40,6 +41,7 @@ import (
 	"github.com/CS-SI/SafeScale/lib/utils/debug/tracing"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
 	"github.com/CS-SI/SafeScale/lib/utils/retry"
+	"github.com/CS-SI/SafeScale/lib/utils/strprocess"
 	"github.com/CS-SI/SafeScale/lib/utils/temporal"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
@@ -505,6 +507,34 @@ func (scmd *SSHCommand) Run(ctx context.Context, outs outputs.Enum) (int, string
 //       you risk to call twice os/exec.Wait, which may panic
 // FIXME: maybe we should move this method inside sshconfig directly with systematically created scmd...
 func (scmd *SSHCommand) RunWithTimeout(ctx context.Context, outs outputs.Enum, timeout time.Duration) (int, string, string, fail.Error) {
+	return scmd.runWithTimeout(ctx, outs, taskExecuteParameters{collectOutputs: outs != outputs.DISPLAY}, timeout)
+}
+
+// RunWithStdin runs the command like RunWithTimeout, streaming the content of 'stdin' to the standard input of the remote process
+// The standard input is closed when 'stdin' reaches EOF, to signal the end of the input to the remote process.
+// Note: the SSHCommand must have been created with SSHConfig.NewCommandWithStdin, otherwise the remote command does not receive the stream
+func (scmd *SSHCommand) RunWithStdin(ctx context.Context, outs outputs.Enum, stdin io.Reader, timeout time.Duration) (int, string, string, fail.Error) {
+	if stdin == nil {
+		return -1, "", "", fail.InvalidParameterCannotBeNilError("stdin")
+	}
+
+	return scmd.runWithTimeout(ctx, outs, taskExecuteParameters{collectOutputs: outs != outputs.DISPLAY, stdin: stdin}, timeout)
+}
+
+// RunWithOutputHandler runs the command like RunWithStdin, calling 'handler' with each line of the standard output of the
+// remote process as soon as it is received, instead of collecting it (for example to follow a log file)
+// 'stdin' may be nil; only the standard error is returned.
+func (scmd *SSHCommand) RunWithOutputHandler(ctx context.Context, stdin io.Reader, handler func(string), timeout time.Duration) (int, string, fail.Error) {
+	if handler == nil {
+		return -1, "", fail.InvalidParameterCannotBeNilError("handler")
+	}
+
+	retcode, _, stderr, xerr := scmd.runWithTimeout(ctx, outputs.COLLECT, taskExecuteParameters{collectOutputs: true, stdin: stdin, stdoutHandler: handler}, timeout)
+	return retcode, stderr, xerr
+}
+
+// runWithTimeout does the real work of RunWithTimeout, RunWithStdin and RunWithOutputHandler
+func (scmd *SSHCommand) runWithTimeout(ctx context.Context, outs outputs.Enum, params taskExecuteParameters, timeout time.Duration) (int, string, string, fail.Error) {
 	if scmd == nil {
 		return -1, "", "", fail.InvalidInstanceError()
 	}
@@ -530,7 +560,7 @@ func (scmd *SSHCommand) RunWithTimeout(ctx context.Context, outs outputs.Enum, t
 		return -1, "", "", xerr
 	}
 
-	if _, xerr = subtask.StartWithTimeout(scmd.taskExecute, taskExecuteParameters{collectOutputs: outs != outputs.DISPLAY}, timeout); xerr != nil {
+	if _, xerr = subtask.StartWithTimeout(scmd.taskExecute, params, timeout); xerr != nil {
 		return -1, "", "", xerr
 	}
 
@@ -555,6 +585,9 @@ func (scmd *SSHCommand) RunWithTimeout(ctx context.Context, outs outputs.Enum, t
 type taskExecuteParameters struct {
 	// stdout, stderr io.ReadCloser
 	collectOutputs bool
+	stdin          io.Reader // if not nil, content streamed to the standard input of the command
+	// if not nil, called with each line of the standard output of the command instead of collecting it
+	stdoutHandler func(string)
 }
 
 func (scmd *SSHCommand) taskExecute(task concurrency.Task, p concurrency.TaskParameters) (concurrency.TaskResult, fail.Error) {
@@ -603,6 +636,13 @@ func (scmd *SSHCommand) taskExecute(task concurrency.Task, p concurrency.TaskPar
 		return result, xerr
 	}
 
+	var stdinPipe io.WriteCloser
+	if params.stdin != nil {
+		if stdinPipe, xerr = scmd.getStdinPipe(); xerr != nil {
+			return result, xerr
+		}
+	}
+
 	if !params.collectOutputs {
 		if stdoutBridge, xerr = cli.NewStdoutBridge(stdoutPipe /*params.stdout*/); xerr != nil {
 			return result, xerr
@@ -629,8 +669,28 @@ func (scmd *SSHCommand) taskExecute(task concurrency.Task, p concurrency.TaskPar
 		return result, xerr
 	}
 
+	// Streams stdin to the command; closing the pipe signals EOF to the remote process.
+	// Wait closes the pipe when the command exits (normally, on timeout or on abort), which ends the copy
+	if stdinPipe != nil {
+		go func() {
+			if _, err := io.Copy(stdinPipe, params.stdin); err != nil {
+				logrus.Debugf("failed to stream stdin to remote command on '%s': %v", scmd.hostname, err)
+			}
+			_ = stdinPipe.Close()
+		}()
+	}
+
 	if params.collectOutputs {
-		if msgOut, err = ioutil.ReadAll(stdoutPipe /*params.stdout*/); err != nil {
+		if params.stdoutHandler != nil {
+			scanner := bufio.NewScanner(stdoutPipe)
+			for scanner.Scan() {
+				params.stdoutHandler(strings.TrimRight(scanner.Text(), "\r"))
+			}
+			if err = scanner.Err(); err != nil {
+				// the pipe is closed when the command is killed on abort, the error of the command prevails
+				logrus.Debugf("stopped reading output of remote command on '%s': %v", scmd.hostname, err)
+			}
+		} else if msgOut, err = ioutil.ReadAll(stdoutPipe /*params.stdout*/); err != nil {
 			return result, fail.ConvertError(err)
 		}
 
@@ -766,7 +826,8 @@ func (sconf *SSHConfig) CreateTunneling() ([]*SSHTunnel, *SSHConfig, fail.Error)
 	return tunnels, &sshConfig, nil
 }
 
-func createSSHCommand(sconf *SSHConfig, cmdString, username, shell string, withTty, withSudo bool) (string, *os.File, fail.Error) {
+// If 'withStdin' is true, the command is passed as argument of ssh instead of through ssh stdin, which is then left available for the caller
+func createSSHCommand(sconf *SSHConfig, cmdString, username, shell string, withTty, withSudo, withStdin bool) (string, *os.File, fail.Error) {
 	f, err := CreateTempFileFromString(sconf.PrivateKey, 0400)
 	if err != nil {
 		return "", nil, fail.Wrap(err, "unable to create temporary key file")
@@ -802,6 +863,15 @@ func createSSHCommand(sconf *SSHConfig, cmdString, username, shell string, withT
 		}
 	}
 
+	if withStdin && cmdString != "" {
+		// command is quoted twice: once for the local shell running ssh, once for the remote shell
+		remote := shell + " -c " + strprocess.ShellQuote(cmdString)
+		if cmd != "" {
+			remote = cmd + " " + remote
+		}
+		return sshCmdString + " " + strprocess.ShellQuote(remote), f, nil
+	}
+
 	if cmd != "" {
 		sshCmdString += " " + cmd + " " + shell
 	}
@@ -816,15 +886,21 @@ func createSSHCommand(sconf *SSHConfig, cmdString, username, shell string, withT
 
 // NewCommand returns the cmd struct to execute runCmdString remotely
 func (sconf *SSHConfig) NewCommand(ctx context.Context, cmdString string) (*SSHCommand, fail.Error) {
-	return sconf.newCommand(ctx, cmdString, false, false)
+	return sconf.newCommand(ctx, cmdString, false, false, false)
+}
+
+// NewCommandWithStdin returns the cmd struct to execute runCmdString remotely, with its standard input available
+// to be fed by SSHCommand.RunWithStdin
+func (sconf *SSHConfig) NewCommandWithStdin(ctx context.Context, cmdString string) (*SSHCommand, fail.Error) {
+	return sconf.newCommand(ctx, cmdString, false, false, true)
 }
 
 // NewSudoCommand returns the cmd struct to execute runCmdString remotely. NewCommand is executed with sudo
 func (sconf *SSHConfig) NewSudoCommand(ctx context.Context, cmdString string) (*SSHCommand, fail.Error) {
-	return sconf.newCommand(ctx, cmdString, false, true)
+	return sconf.newCommand(ctx, cmdString, false, true, false)
 }
 
-func (sconf *SSHConfig) newCommand(ctx context.Context, cmdString string, withTty, withSudo bool) (*SSHCommand, fail.Error) {
+func (sconf *SSHConfig) newCommand(ctx context.Context, cmdString string, withTty, withSudo, withStdin bool) (*SSHCommand, fail.Error) {
 	if sconf == nil {
 		return nil, fail.InvalidInstanceError()
 	}
@@ -853,7 +929,7 @@ func (sconf *SSHConfig) newCommand(ctx context.Context, cmdString string, withTt
 		return nil, fail.AbortedError(nil, "aborted")
 	}
 
-	sshCmdString, keyFile, err := createSSHCommand(sshConfig, cmdString, "", "", withTty, withSudo)
+	sshCmdString, keyFile, err := createSSHCommand(sshConfig, cmdString, "", "", withTty, withSudo, withStdin)
 	if err != nil {
 		return nil, fail.Wrap(err, "unable to create command")
 	}
@@ -868,8 +944,10 @@ func (sconf *SSHConfig) newCommand(ctx context.Context, cmdString string, withTt
 	return &sshCommand, nil
 }
 
-// WaitServerReady waits until the SSH server is ready
-func (sconf *SSHConfig) WaitServerReady(ctx context.Context, phase string, timeout time.Duration) (out string, xerr fail.Error) {
+// WaitServerReady waits until the SSH server is ready and the phase is done on remote host
+// 'connectTimeout' bounds each attempt to connect to the remote SSH server, 'readyTimeout' bounds the whole wait for the phase to be done.
+// On timeout, the error returned tells if the remote SSH server has never been reached or if the phase did not complete.
+func (sconf *SSHConfig) WaitServerReady(ctx context.Context, phase string, connectTimeout, readyTimeout time.Duration) (out string, xerr fail.Error) {
 	if sconf == nil {
 		return "", fail.InvalidInstanceError()
 	}
@@ -892,8 +970,8 @@ func (sconf *SSHConfig) WaitServerReady(ctx context.Context, phase string, timeo
 		return "", fail.AbortedError(nil, "aborted")
 	}
 
-	defer debug.NewTracer(task, tracing.ShouldTrace("sconf"), "('%s',%s)", phase, temporal.FormatDuration(timeout)).Entering().Exiting()
-	defer fail.OnExitTraceError(&xerr, "timeout waiting remote SSH phase '%s' of host '%s' for %s", phase, sconf.Hostname, temporal.FormatDuration(timeout))
+	defer debug.NewTracer(task, tracing.ShouldTrace("sconf"), "('%s',%s,%s)", phase, temporal.FormatDuration(connectTimeout), temporal.FormatDuration(readyTimeout)).Entering().Exiting()
+	defer fail.OnExitTraceError(&xerr, "timeout waiting remote SSH phase '%s' of host '%s' for %s", phase, sconf.Hostname, temporal.FormatDuration(readyTimeout))
 
 	originalPhase := phase
 	if phase == "ready" {
@@ -903,6 +981,7 @@ func (sconf *SSHConfig) WaitServerReady(ctx context.Context, phase string, timeo
 	var (
 		retcode        int
 		stdout, stderr string
+		connected      bool
 	)
 
 	begins := time.Now()
@@ -917,7 +996,7 @@ func (sconf *SSHConfig) WaitServerReady(ctx context.Context, phase string, timeo
 			defer func() { _ = sshCmd.Close() }()
 
 			var innerXErr fail.Error
-			retcode, stdout, stderr, innerXErr = sshCmd.RunWithTimeout(ctx, outputs.COLLECT, timeout)
+			retcode, stdout, stderr, innerXErr = sshCmd.RunWithTimeout(ctx, outputs.COLLECT, connectTimeout)
 			if innerXErr != nil {
 				return innerXErr
 			}
@@ -925,13 +1004,22 @@ func (sconf *SSHConfig) WaitServerReady(ctx context.Context, phase string, timeo
 				if retcode == 255 {
 					return fail.NewError("remote SSH not ready: error code: 255; Output [%s]; Error [%s]", stdout, stderr)
 				}
+				connected = true
 				return fail.NewError("remote SSH NOT ready: error code: %d; Output [%s]; Error [%s]", retcode, stdout, stderr)
 			}
 			return nil
 		},
-		timeout+time.Minute,
+		readyTimeout,
 	)
 	if retryErr != nil {
+		switch retryErr.(type) {
+		case *retry.ErrTimeout:
+			if !connected {
+				return stdout, fail.TimeoutError(retryErr.Cause(), readyTimeout, "failed to connect to remote SSH server of host '%s' after %s", sconf.Hostname, temporal.FormatDuration(readyTimeout))
+			}
+			return stdout, fail.TimeoutError(retryErr.Cause(), readyTimeout, "phase '%s' did not complete on host '%s' after %s", originalPhase, sconf.Hostname, temporal.FormatDuration(readyTimeout))
+		default:
+		}
 		return stdout, retryErr
 	}
 
@@ -1034,7 +1122,7 @@ func (sconf *SSHConfig) Enter(username, shell string) (xerr fail.Error) {
 		return fail.Wrap(xerr, "unable to create command")
 	}
 
-	sshCmdString, keyFile, xerr := createSSHCommand(sshConfig, "", username, shell, true, false)
+	sshCmdString, keyFile, xerr := createSSHCommand(sshConfig, "", username, shell, true, false, false)
 	if xerr != nil {
 		for _, t := range tunnels {
 			if nerr := t.Close(); nerr != nil {
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package concurrency
+
+import (
+	"context"
+)
+
+const keyForCorrelationInContext taskContextKey = "correlation"
+
+// Correlation identifies the request on behalf of which a task runs
+// It is carried by the context of the task, so every subtask (and every task of a task group) started from this task
+// inherits it, allowing to correlate the traces of all the goroutines working for a same request
+type Correlation struct {
+	ID     string // id of the request (for a daemon job, the uuid of the gRPC message)
+	Tenant string // name of the tenant the request works on
+}
+
+// IsNull tells if the correlation is empty
+func (c Correlation) IsNull() bool {
+	return c.ID == ""
+}
+
+// String returns the correlation ID used in logs, in the format "<tenant>:<id>" (or "<id>" without tenant)
+func (c Correlation) String() string {
+	if c.IsNull() {
+		return ""
+	}
+	if c.Tenant == "" {
+		return c.ID
+	}
+	return c.Tenant + ":" + c.ID
+}
+
+// ContextWithCorrelation returns a copy of ctx carrying the correlation
+func ContextWithCorrelation(ctx context.Context, correlation Correlation) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, keyForCorrelationInContext, correlation)
+}
+
+// CorrelationFromContext returns the correlation carried by ctx, if any
+func CorrelationFromContext(ctx context.Context) (Correlation, bool) {
+	if ctx != nil {
+		if c, ok := ctx.Value(keyForCorrelationInContext).(Correlation); ok && !c.IsNull() {
+			return c, true
+		}
+	}
+	return Correlation{}, false
+}
+
+// DetachedContext returns a context that is never canceled but keeps the correlation carried by ctx
+// It is meant for cleanups that must run even if the operation has been aborted, without losing the correlation of their traces
+func DetachedContext(ctx context.Context) context.Context {
+	if correlation, ok := CorrelationFromContext(ctx); ok {
+		return ContextWithCorrelation(context.Background(), correlation)
+	}
+	return context.Background()
+}
@@ -82,6 +82,7 @@ type TaskCore interface {
 	SetID(string) fail.Error
 	GetID() (string, fail.Error)
 	GetSignature() string
+	GetCorrelation() Correlation
 	GetStatus() (TaskStatus, fail.Error)
 	GetContext() context.Context
 	GetLastError() (error, fail.Error)
@@ -102,8 +103,9 @@ type Task interface {
 
 // task is a structure allowing to identify (indirectly) goroutines
 type task struct {
-	mu sync.Mutex
-	id string
+	mu          sync.Mutex
+	id          string
+	correlation Correlation // correlation of the request the task works for, inherited from the context
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -232,6 +234,7 @@ func newTask(ctx context.Context, parentTask Task) (*task, fail.Error) {
 	}
 
 	t.id = u.String()
+	t.correlation, _ = CorrelationFromContext(childContext)
 	t.ctx = context.WithValue(childContext, keyForTaskInContext, &t)
 
 	return &t, nil
@@ -294,7 +297,8 @@ func (t *task) GetID() (string, fail.Error) {
 // }
 
 // GetSignature builds the "signature" of the task passed as parameter,
-// ie a string representation of the task ID in the format "{task <id>}".
+// ie a string representation of the task ID in the format "{task <id>}", or "{task <id>, correlation <correlation>}"
+// when the task works on behalf of a request.
 func (t *task) GetSignature() string {
 	if t.IsNull() {
 		return ""
@@ -308,11 +312,26 @@ func (t *task) GetSignature() string {
 
 func (t *task) getSignature() string {
 	if t.id != "" {
+		if !t.correlation.IsNull() {
+			return `{task ` + t.id + `, correlation ` + t.correlation.String() + `}`
+		}
 		return `{task ` + t.id + `}`
 	}
 	return ""
 }
 
+// GetCorrelation returns the correlation of the request the task works for
+func (t *task) GetCorrelation() Correlation {
+	if t.IsNull() {
+		return Correlation{}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.correlation
+}
+
 // GetStatus returns the current task status
 func (t *task) GetStatus() (TaskStatus, fail.Error) {
 	if t.IsNull() {
@@ -691,3 +691,34 @@ func TestAbortButThisTimeUsingTrueAbortChannel(t *testing.T) {
 		fmt.Println(outString)
 	}
 }
+
+func TestCorrelationPropagation(t *testing.T) {
+	ctx := ContextWithCorrelation(context.Background(), Correlation{ID: "job-id", Tenant: "tenant"})
+	parent, xerr := NewTaskWithContext(ctx)
+	require.Nil(t, xerr)
+	require.Equal(t, "tenant:job-id", parent.GetCorrelation().String())
+	require.Contains(t, parent.GetSignature(), "correlation tenant:job-id")
+
+	subtask, xerr := parent.StartInSubtask(func(t Task, _ TaskParameters) (TaskResult, fail.Error) {
+		return t.GetCorrelation(), nil
+	}, nil)
+	require.Nil(t, xerr)
+	res, xerr := subtask.Wait()
+	require.Nil(t, xerr)
+	require.Equal(t, parent.GetCorrelation(), res)
+
+	tg, xerr := NewTaskGroupWithParent(parent)
+	require.Nil(t, xerr)
+	require.Contains(t, tg.GetSignature(), "correlation tenant:job-id")
+
+	detached := DetachedContext(parent.GetContext())
+	correlation, ok := CorrelationFromContext(detached)
+	require.True(t, ok)
+	require.Equal(t, parent.GetCorrelation(), correlation)
+	require.Nil(t, detached.Done())
+
+	untracked, xerr := NewTask()
+	require.Nil(t, xerr)
+	require.True(t, untracked.GetCorrelation().IsNull())
+	require.NotContains(t, untracked.GetSignature(), "correlation")
+}
@@ -149,9 +149,21 @@ func (instance *taskGroup) GetSignature() string {
 		return ""
 	}
 
+	if correlation := instance.task.GetCorrelation(); !correlation.IsNull() {
+		return `{taskGroup ` + tid + `, correlation ` + correlation.String() + `}`
+	}
 	return `{taskGroup ` + tid + `}`
 }
 
+// GetCorrelation returns the correlation of the request the task group works for
+func (instance *taskGroup) GetCorrelation() Correlation {
+	if instance.isNull() {
+		return Correlation{}
+	}
+
+	return instance.task.GetCorrelation()
+}
+
 // GetStatus returns the current task status
 func (instance *taskGroup) GetStatus() (TaskStatus, fail.Error) {
 	if instance.isNull() {
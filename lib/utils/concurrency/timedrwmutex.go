@@ -0,0 +1,82 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package concurrency
+
+import (
+	"sync"
+	"time"
+)
+
+// TimedRWMutex is a sync.RWMutex allowing to give up a lock for read that cannot be acquired in time
+// The zero value is an unlocked mutex, usable in place of a sync.RWMutex
+type TimedRWMutex struct {
+	sync.RWMutex
+}
+
+// RLockWithTimeout locks for read, giving up if the lock cannot be acquired before 'timeout'
+// Returns true if the lock has been acquired (the caller then has to call RUnlock()), false otherwise
+func (m *TimedRWMutex) RLockWithTimeout(timeout time.Duration) bool {
+	acquired := make(chan struct{})
+	abandoned := make(chan struct{})
+	go func() {
+		m.RLock()
+		select {
+		case acquired <- struct{}{}:
+		case <-abandoned:
+			// nobody waits for the lock anymore, releases it as soon as acquired
+			m.RUnlock()
+		}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-acquired:
+		return true
+	case <-timer.C:
+		close(abandoned)
+		return false
+	}
+}
+
+// LockWithTimeout locks for write, giving up if the lock cannot be acquired before 'timeout'
+// Returns true if the lock has been acquired (the caller then has to call Unlock()), false otherwise
+func (m *TimedRWMutex) LockWithTimeout(timeout time.Duration) bool {
+	acquired := make(chan struct{})
+	abandoned := make(chan struct{})
+	go func() {
+		m.Lock()
+		select {
+		case acquired <- struct{}{}:
+		case <-abandoned:
+			// nobody waits for the lock anymore, releases it as soon as acquired
+			m.Unlock()
+		}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-acquired:
+		return true
+	case <-timer.C:
+		close(abandoned)
+		return false
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package concurrency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimedRWMutex_RLockWithTimeout(t *testing.T) {
+	var m TimedRWMutex
+
+	// unlocked: acquired at once, and shared with other readers
+	assert.True(t, m.RLockWithTimeout(100*time.Millisecond))
+	assert.True(t, m.RLockWithTimeout(100*time.Millisecond))
+	m.RUnlock()
+	m.RUnlock()
+
+	// locked for write: gives up after timeout
+	m.Lock()
+	begin := time.Now()
+	assert.False(t, m.RLockWithTimeout(100*time.Millisecond))
+	assert.True(t, time.Since(begin) >= 100*time.Millisecond)
+	m.Unlock()
+
+	// the abandoned lock for read must not be kept
+	done := make(chan struct{})
+	go func() {
+		m.Lock()
+		m.Unlock()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("lock for write blocked by an abandoned lock for read")
+	}
+
+	// released for write during the wait: acquired
+	m.Lock()
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		m.Unlock()
+	}()
+	assert.True(t, m.RLockWithTimeout(time.Second))
+	m.RUnlock()
+}
+
+func TestTimedRWMutex_LockWithTimeout(t *testing.T) {
+	var m TimedRWMutex
+
+	// unlocked: acquired at once
+	assert.True(t, m.LockWithTimeout(100*time.Millisecond))
+
+	// locked for write: gives up after timeout
+	begin := time.Now()
+	assert.False(t, m.LockWithTimeout(100*time.Millisecond))
+	assert.True(t, time.Since(begin) >= 100*time.Millisecond)
+	m.Unlock()
+
+	// the abandoned lock for write must not be kept
+	done := make(chan struct{})
+	go func() {
+		m.RLock()
+		m.RUnlock()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("lock for read blocked by an abandoned lock for write")
+	}
+
+	// locked for read: gives up, then acquired once released
+	m.RLock()
+	assert.False(t, m.LockWithTimeout(50*time.Millisecond))
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		m.RUnlock()
+	}()
+	assert.True(t, m.LockWithTimeout(time.Second))
+	m.Unlock()
+}
@@ -53,3 +53,16 @@ func GenerateRSAKeyPair(name string) (privKey string, pubKey string, xerr fail.E
 	)
 	return string(priKeyPem), string(pubBytes), nil
 }
+
+// PublicKeyFromPrivateKey returns the public key, in authorized_keys format, corresponding to the PEM encoded private key
+func PublicKeyFromPrivateKey(privKey string) (string, fail.Error) {
+	if privKey == "" {
+		return "", fail.InvalidParameterCannotBeEmptyStringError("privKey")
+	}
+
+	signer, err := ssh.ParsePrivateKey([]byte(privKey))
+	if err != nil {
+		return "", fail.ConvertError(err)
+	}
+	return string(ssh.MarshalAuthorizedKey(signer.PublicKey())), nil
+}
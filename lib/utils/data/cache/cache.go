@@ -25,10 +25,13 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/CS-SI/SafeScale/lib/utils/data"
 	"github.com/CS-SI/SafeScale/lib/utils/data/observer"
 	"github.com/CS-SI/SafeScale/lib/utils/debug/callstack"
 	"github.com/CS-SI/SafeScale/lib/utils/fail"
+	"github.com/CS-SI/SafeScale/lib/utils/temporal"
 )
 
 // Cache interface describing what a struct must implement to be considered as a Cache
@@ -39,8 +42,11 @@ type Cache interface {
 
 	// ReserveEntry locks an entry identified by key for update
 	// if entry does not exist, create an empty one
+	// The reservation is a lease: if not committed, freed or refreshed in time, it is considered as stale and the key as free
 	ReserveEntry(key string) fail.Error
 
+	RefreshEntry(key string) fail.Error // extends the lease of the reservation of a cache entry
+
 	// CommitEntry fills a previously reserved entry by 'key' with 'content'
 	// The key retained at the end in the cache may be different to the one passed in parameter (and used previously in ReserveEntry),
 	// because content.GetID() has to be the final key.
@@ -53,9 +59,10 @@ type Cache interface {
 type cache struct {
 	name atomic.Value
 
-	lock     sync.RWMutex
-	cache    map[string]*Entry
-	reserved map[string]struct{}
+	lock               sync.RWMutex
+	cache              map[string]*Entry
+	reserved           map[string]time.Time // expiration date of the reservation
+	reservationTimeout time.Duration
 }
 
 // NewCache creates a new cache
@@ -65,8 +72,9 @@ func NewCache(name string) (Cache, fail.Error) {
 	}
 
 	cacheInstance := &cache{
-		cache:    map[string]*Entry{},
-		reserved: map[string]struct{}{},
+		cache:              map[string]*Entry{},
+		reserved:           map[string]time.Time{},
+		reservationTimeout: temporal.GetReservationTimeout(),
 	}
 	cacheInstance.name.Store(name)
 	return cacheInstance, nil
@@ -98,8 +106,12 @@ func (instance *cache) GetEntry(key string) (*Entry, fail.Error) {
 	instance.lock.RLock()
 	defer instance.lock.RUnlock()
 
-	if _, ok := instance.reserved[key]; ok {
-		return nil, fail.NotAvailableError("cache entry '%s' is reserved and cannot be use until freed or committed", key)
+	if expiration, ok := instance.reserved[key]; ok {
+		if time.Now().Before(expiration) {
+			return nil, fail.NotAvailableError("cache entry '%s' is reserved and cannot be use until freed or committed", key)
+		}
+		// a stale reservation does not hold the key
+		return nil, fail.NotFoundError("failed to find cache entry with key '%s'", key)
 	}
 	if ce, ok := instance.cache[key]; ok {
 		return ce, nil
@@ -126,8 +138,14 @@ func (instance *cache) ReserveEntry(key string) (xerr fail.Error) {
 
 // unsafeReserveEntry is the workforce of ReserveEntry, without locking
 func (instance *cache) unsafeReserveEntry(key string) (xerr fail.Error) {
-	if _, ok := instance.reserved[key]; ok {
-		return fail.NotAvailableError("the cache entry '%s' is already reserved", key)
+	if expiration, ok := instance.reserved[key]; ok {
+		if time.Now().Before(expiration) {
+			return fail.NotAvailableError("the cache entry '%s' is already reserved", key)
+		}
+
+		// the reservation has not been committed, freed or refreshed in time (its owner probably died), drops it
+		logrus.Warnf("reservation of cache entry '%s' expired at %s, considered as stale", key, expiration.Format(time.RFC3339))
+		_ = instance.unsafeFreeEntry(key)
 	}
 	if _, ok := instance.cache[key]; ok {
 		return fail.DuplicateError(callstack.DecorateWith("", "", fmt.Sprintf("there is already an entry in the cache with key '%s'", key), 0))
@@ -136,7 +154,27 @@ func (instance *cache) unsafeReserveEntry(key string) (xerr fail.Error) {
 	ce := newEntry(&reservation{key: key})
 	ce.lock()
 	instance.cache[key] = &ce
-	instance.reserved[key] = struct{}{}
+	instance.reserved[key] = time.Now().Add(instance.reservationTimeout)
+	return nil
+}
+
+// RefreshEntry extends the lease of the reservation of the cache entry identified by key, for an owner needing more time
+// to commit it
+func (instance *cache) RefreshEntry(key string) fail.Error {
+	if instance.isNull() {
+		return fail.InvalidInstanceError()
+	}
+	if key = strings.TrimSpace(key); key == "" {
+		return fail.InvalidParameterCannotBeEmptyStringError("key")
+	}
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
+
+	if _, ok := instance.reserved[key]; !ok {
+		return fail.NotAvailableError("the cache entry '%s' is not reserved", key)
+	}
+	instance.reserved[key] = time.Now().Add(instance.reservationTimeout)
 	return nil
 }
 
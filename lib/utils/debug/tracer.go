@@ -27,6 +27,7 @@ import (
 
 	"github.com/CS-SI/SafeScale/lib/utils/concurrency"
 	"github.com/CS-SI/SafeScale/lib/utils/debug/callstack"
+	"github.com/CS-SI/SafeScale/lib/utils/debug/tracing"
 	"github.com/CS-SI/SafeScale/lib/utils/strprocess"
 	"github.com/CS-SI/SafeScale/lib/utils/temporal"
 )
@@ -47,6 +48,8 @@ type Tracer interface {
 // tracer ...
 type tracer struct {
 	taskSig      string
+	taskID       string
+	correlation  concurrency.Correlation
 	fileName     string
 	funcName     string
 	callerParams string
@@ -54,6 +57,7 @@ type tracer struct {
 	inDone       bool
 	outDone      bool
 	sw           temporal.Stopwatch
+	endSpan      func() // ends the span exported between Entering and Exiting (see tracing.SetSpanExporter)
 }
 
 const (
@@ -70,6 +74,8 @@ func NewTracer(task concurrency.Task, enable bool, msg ...interface{}) Tracer {
 	}
 	if task != nil {
 		t.taskSig = task.GetSignature()
+		t.taskID, _ = task.GetID()
+		t.correlation = task.GetCorrelation()
 	}
 
 	message := strprocess.FormatStrings(msg...)
@@ -127,11 +133,22 @@ func (t *tracer) WithStopwatch() Tracer {
 }
 
 // Entering logs the input message (signifying we are going in) using TRACE level
+// If an exporter of spans is set, starts also a span in the trace of the request the task works for, whatever the log
+// tracing is enabled or not
 func (t *tracer) Entering() Tracer {
 	if !t.IsNull() && !t.inDone {
 		if t.sw != nil {
 			t.sw.Start()
 		}
+		if t.endSpan == nil {
+			t.endSpan = tracing.StartSpan(t.correlation.ID, t.taskID, t.funcName, map[string]string{
+				"code.filepath":    t.fileName,
+				"code.function":    t.funcName,
+				"safescale.params": t.callerParams,
+				"safescale.task":   t.taskID,
+				"safescale.tenant": t.correlation.Tenant,
+			})
+		}
 		if t.enabled {
 			t.inDone = true
 			msg := goingInPrefix + t.buildMessage()
@@ -157,6 +174,10 @@ func (t *tracer) Exiting() Tracer {
 		if t.sw != nil {
 			t.sw.Stop()
 		}
+		if t.endSpan != nil {
+			t.endSpan()
+			t.endSpan = func() {}
+		}
 		if t.enabled {
 			t.outDone = true
 			msg := goingOutPrefix + t.buildMessage()
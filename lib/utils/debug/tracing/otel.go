@@ -0,0 +1,141 @@
+// +build otel
+
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+// Export of spans with OpenTelemetry, enabled by the build tag 'otel' (BUILD_TAGS=otel make all); this needs the modules
+// go.opentelemetry.io/otel, go.opentelemetry.io/otel/sdk and go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceIDContextKey is the key in context of the trace ID wanted for a root span
+type traceIDContextKey struct{}
+
+// correlationIDGenerator generates random span IDs, and uses the trace ID carried by the context for root spans
+type correlationIDGenerator struct{}
+
+// NewIDs returns the IDs of a root span
+func (correlationIDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	traceID, ok := ctx.Value(traceIDContextKey{}).(trace.TraceID)
+	for !ok || !traceID.IsValid() {
+		_, _ = rand.Read(traceID[:])
+		ok = true
+	}
+	return traceID, newSpanID()
+}
+
+// NewSpanID returns the ID of a child span
+func (correlationIDGenerator) NewSpanID(_ context.Context, _ trace.TraceID) trace.SpanID {
+	return newSpanID()
+}
+
+func newSpanID() (spanID trace.SpanID) {
+	for !spanID.IsValid() {
+		_, _ = rand.Read(spanID[:])
+	}
+	return spanID
+}
+
+// traceIDFromCorrelation converts the correlation ID of a request to a trace ID: the correlation ID of a daemon job being
+// an UUID, it is used as is; any other ID is hashed
+func traceIDFromCorrelation(id string) (traceID trace.TraceID) {
+	if b, err := hex.DecodeString(strings.ReplaceAll(id, "-", "")); err == nil && len(b) == len(traceID) {
+		copy(traceID[:], b)
+		return traceID
+	}
+	sum := sha256.Sum256([]byte(id))
+	copy(traceID[:], sum[:len(traceID)])
+	return traceID
+}
+
+// otelSpan is a Span exported with OpenTelemetry
+type otelSpan struct {
+	span trace.Span
+}
+
+// End ends the span
+func (s otelSpan) End() {
+	s.span.End()
+}
+
+// otelExporter is a SpanExporter using OpenTelemetry
+type otelExporter struct {
+	tracer trace.Tracer
+}
+
+// StartSpan starts an OpenTelemetry span
+func (e otelExporter) StartSpan(traceID string, parent Span, name string, attributes map[string]string) Span {
+	ctx := context.Background()
+	if p, ok := parent.(otelSpan); ok {
+		ctx = trace.ContextWithSpan(ctx, p.span)
+	} else {
+		ctx = context.WithValue(ctx, traceIDContextKey{}, traceIDFromCorrelation(traceID))
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(attributes))
+	for k, v := range attributes {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	_, span := e.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+	return otelSpan{span: span}
+}
+
+// SetupOpenTelemetry exports the spans of debug.Tracer with OpenTelemetry if the standard environment variables ask for
+// it (OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT, completed by OTEL_SERVICE_NAME,
+// OTEL_RESOURCE_ATTRIBUTES, OTEL_TRACES_SAMPLER, ...)
+// Returns the function to call before exiting, to flush the spans not exported yet
+func SetupOpenTelemetry(serviceName string) (shutdown func(), err error) {
+	shutdown = func() {}
+	if !openTelemetryConfigured() {
+		return shutdown, nil
+	}
+
+	ctx := context.Background()
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return shutdown, err
+	}
+	res, err := resource.New(ctx, resource.WithAttributes(semconv.ServiceNameKey.String(serviceName)), resource.WithFromEnv())
+	if err != nil {
+		return shutdown, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithIDGenerator(correlationIDGenerator{}),
+	)
+	SetSpanExporter(otelExporter{tracer: provider.Tracer("github.com/CS-SI/SafeScale")})
+	return func() {
+		SetSpanExporter(nil)
+		_ = provider.Shutdown(context.Background())
+	}, nil
+}
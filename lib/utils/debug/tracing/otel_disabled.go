@@ -0,0 +1,33 @@
+// +build !otel
+
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import (
+	"fmt"
+)
+
+// SetupOpenTelemetry does nothing, the export of spans with OpenTelemetry needing the build tag 'otel'
+// Returns an error if the standard environment variables ask for this export, for the user to know it will not happen
+func SetupOpenTelemetry(_ string) (shutdown func(), err error) {
+	shutdown = func() {}
+	if openTelemetryConfigured() {
+		return shutdown, fmt.Errorf("export of spans with OpenTelemetry is configured, but not available in this build (needs build tag 'otel')")
+	}
+	return shutdown, nil
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// Span is an operation exported to a tracing backend
+type Span interface {
+	End()
+}
+
+// SpanExporter exports the operations traced by debug.Tracer to a tracing backend
+type SpanExporter interface {
+	// StartSpan starts the span 'name' in the trace identified by 'traceID' (the correlation ID of the request), as a
+	// child of 'parent' (nil for a root span)
+	StartSpan(traceID string, parent Span, name string, attributes map[string]string) Span
+}
+
+// openSpan is a span not ended yet, with the task running it
+type openSpan struct {
+	taskID string
+	span   Span
+}
+
+// spans keeps the exporter in use and, by trace, the spans not ended yet, to find the parents of new spans
+var spans = struct {
+	sync.Mutex
+	exporter SpanExporter
+	open     map[string][]*openSpan
+}{
+	open: map[string][]*openSpan{},
+}
+
+// SetSpanExporter sets the exporter of spans; nil disables the export
+func SetSpanExporter(exporter SpanExporter) {
+	spans.Lock()
+	defer spans.Unlock()
+
+	spans.exporter = exporter
+	spans.open = map[string][]*openSpan{}
+}
+
+// StartSpan starts a span for the operation 'name' run by the task 'taskID' for the request 'traceID', as a child of
+// the last span still running in the same task, or else in the same request
+// Returns the function ending the span; nothing is exported if no exporter is set or if 'traceID' is empty
+func StartSpan(traceID, taskID, name string, attributes map[string]string) (end func()) {
+	spans.Lock()
+	defer spans.Unlock()
+
+	if spans.exporter == nil || traceID == "" {
+		return func() {}
+	}
+
+	var parent Span
+	open := spans.open[traceID]
+	if len(open) > 0 {
+		parent = open[len(open)-1].span
+		for i := len(open) - 1; i >= 0; i-- {
+			if open[i].taskID == taskID {
+				parent = open[i].span
+				break
+			}
+		}
+	}
+
+	current := &openSpan{taskID: taskID, span: spans.exporter.StartSpan(traceID, parent, name, attributes)}
+	spans.open[traceID] = append(open, current)
+	exporter := spans.exporter
+	return func() {
+		spans.Lock()
+		defer spans.Unlock()
+
+		// the exporter may have changed meanwhile, the span has already been forgotten in this case
+		if spans.exporter == exporter {
+			open := spans.open[traceID]
+			for i, v := range open {
+				if v == current {
+					open = append(open[:i], open[i+1:]...)
+					break
+				}
+			}
+			if len(open) == 0 {
+				delete(spans.open, traceID)
+			} else {
+				spans.open[traceID] = open
+			}
+		}
+		current.span.End()
+	}
+}
+
+// openTelemetryConfigured tells if the standard OpenTelemetry environment variables ask for the export of spans
+func openTelemetryConfigured() bool {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") || os.Getenv("OTEL_TRACES_EXPORTER") == "none" {
+		return false
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSpan struct {
+	name   string
+	parent *fakeSpan
+	ended  bool
+}
+
+func (s *fakeSpan) End() {
+	s.ended = true
+}
+
+type fakeExporter struct {
+	started map[string]*fakeSpan
+}
+
+func (e *fakeExporter) StartSpan(_ string, parent Span, name string, _ map[string]string) Span {
+	s := &fakeSpan{name: name}
+	if parent != nil {
+		s.parent = parent.(*fakeSpan)
+	}
+	e.started[name] = s
+	return s
+}
+
+func TestStartSpan(t *testing.T) {
+	// no exporter: nothing started
+	StartSpan("job1", "task1", "create", nil)()
+
+	exporter := &fakeExporter{started: map[string]*fakeSpan{}}
+	SetSpanExporter(exporter)
+	defer SetSpanExporter(nil)
+
+	// no correlation: nothing started
+	StartSpan("", "task1", "nocorrelation", nil)()
+	assert.Empty(t, exporter.started)
+
+	endCreate := StartSpan("job1", "task1", "create", nil)
+	endPhase := StartSpan("job1", "task1", "phase", nil)
+	// subtask: child of the last span of the request
+	endSubtask := StartSpan("job1", "task2", "subtask", nil)
+	// back in task1: child of the last span of task1
+	endCheck := StartSpan("job1", "task1", "check", nil)
+	// other request: root span
+	endOther := StartSpan("job2", "task3", "other", nil)
+
+	assert.Nil(t, exporter.started["create"].parent)
+	assert.Equal(t, "create", exporter.started["phase"].parent.name)
+	assert.Equal(t, "phase", exporter.started["subtask"].parent.name)
+	assert.Equal(t, "phase", exporter.started["check"].parent.name)
+	assert.Nil(t, exporter.started["other"].parent)
+
+	endCheck()
+	endSubtask()
+	endPhase()
+	assert.True(t, exporter.started["phase"].ended)
+	assert.False(t, exporter.started["create"].ended)
+
+	// span ended: no longer a parent
+	endNext := StartSpan("job1", "task1", "next", nil)
+	assert.Equal(t, "create", exporter.started["next"].parent.name)
+	endNext()
+	endCreate()
+	endOther()
+
+	spans.Lock()
+	assert.Empty(t, spans.open)
+	spans.Unlock()
+}
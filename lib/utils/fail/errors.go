@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os/exec"
+	"strings"
 	"syscall"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 // consequencer is the interface exposing the methods manipulating consequences
@@ -318,8 +320,21 @@ func (e errorCore) GRPCCode() codes.Code {
 }
 
 // ToGRPCStatus returns a grpcstatus struct from error
+// Annotations, if any, are attached to the status as details
 func (e errorCore) ToGRPCStatus() error {
-	return grpcstatus.Errorf(e.GRPCCode(), e.Error())
+	st := grpcstatus.New(e.GRPCCode(), e.Error())
+	if len(e.annotations) > 0 {
+		fields := make(map[string]interface{}, len(e.annotations))
+		for k, v := range e.annotations {
+			fields[k] = fmt.Sprintf("%v", v)
+		}
+		if details, err := structpb.NewStruct(fields); err == nil {
+			if withDetails, err := st.WithDetails(details); err == nil {
+				st = withDetails
+			}
+		}
+	}
+	return st.Err()
 }
 
 func (e *errorCore) prependToMessage(msg string) {
@@ -512,6 +527,102 @@ func (e *ErrNotAvailable) UnformattedError() string {
 	return e.Error()
 }
 
+// ErrResourceInUse resource cannot be changed or deleted while other resources depend on it
+type ErrResourceInUse struct {
+	*errorCore
+	blockers []string
+}
+
+// ResourceInUseError creates a ErrResourceInUse error, listing in 'blockers' the resources preventing the operation
+// (ex: "volume 'data'"); the blockers are annotated on the error with key "blockers", so they reach gRPC clients
+func ResourceInUseError(blockers []string, msg ...interface{}) *ErrResourceInUse {
+	r := newError(nil, nil, msg...)
+	r.grpcCode = codes.FailedPrecondition
+	out := &ErrResourceInUse{errorCore: r, blockers: blockers}
+	if len(blockers) > 0 {
+		_ = out.Annotate("blockers", strings.Join(blockers, ", "))
+	}
+	return out
+}
+
+// IsNull tells if the instance is null
+func (e *ErrResourceInUse) IsNull() bool {
+	return e == nil || e.errorCore.IsNull()
+}
+
+// Blockers returns the resources preventing the operation
+func (e *ErrResourceInUse) Blockers() []string {
+	if e.IsNull() {
+		return nil
+	}
+	return e.blockers
+}
+
+// AddConsequence ...
+func (e *ErrResourceInUse) AddConsequence(err error) Error {
+	if e.IsNull() {
+		logrus.Errorf(callstack.DecorateWith("invalid call:", "ErrResourceInUse.AddConsequence()", "from null instance", 0))
+		return e
+	}
+	_ = e.errorCore.AddConsequence(err)
+	return e
+}
+
+// Annotate ...
+func (e *ErrResourceInUse) Annotate(key string, value data.Annotation) data.Annotatable {
+	if e.IsNull() {
+		logrus.Errorf(callstack.DecorateWith("invalid call:", "ErrResourceInUse.Annotate()", "from null instance", 0))
+		return e
+	}
+	_ = e.errorCore.Annotate(key, value)
+	return e
+}
+
+func (e *ErrResourceInUse) UnformattedError() string {
+	return e.Error()
+}
+
+// ErrProviderFailure the provider failed to execute a request that was valid from SafeScale point of view
+type ErrProviderFailure struct {
+	*errorCore
+}
+
+// ProviderFailureError creates a ErrProviderFailure error
+func ProviderFailureError(cause error, msg ...interface{}) *ErrProviderFailure {
+	r := newError(cause, nil, msg...)
+	r.grpcCode = codes.Internal
+	return &ErrProviderFailure{r}
+}
+
+// IsNull tells if the instance is null
+func (e *ErrProviderFailure) IsNull() bool {
+	return e == nil || e.errorCore.IsNull()
+}
+
+// AddConsequence ...
+func (e *ErrProviderFailure) AddConsequence(err error) Error {
+	if e.IsNull() {
+		logrus.Errorf(callstack.DecorateWith("invalid call:", "ErrProviderFailure.AddConsequence()", "from null instance", 0))
+		return e
+	}
+	_ = e.errorCore.AddConsequence(err)
+	return e
+}
+
+// Annotate ...
+func (e *ErrProviderFailure) Annotate(key string, value data.Annotation) data.Annotatable {
+	if e.IsNull() {
+		logrus.Errorf(callstack.DecorateWith("invalid call:", "ErrProviderFailure.Annotate()", "from null instance", 0))
+		return e
+	}
+	_ = e.errorCore.Annotate(key, value)
+	return e
+}
+
+func (e *ErrProviderFailure) UnformattedError() string {
+	return e.Error()
+}
+
 // ErrDuplicate already exists error
 type ErrDuplicate struct {
 	*errorCore
@@ -28,6 +28,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
 )
 
 func generateErrTimeout() *ErrTimeout {
@@ -373,6 +374,44 @@ func TestInterfaceMatching(t *testing.T) {
 			logrus.Fatal("*ImplTimeout doesn't satisfy interface error")
 		}
 	}
+
+	{
+		val := ResourceInUseError(nil)
+		if _, ok := interface{}(val).(Error); !ok {
+			logrus.Fatal("*ErrResourceInUse doesn't satisfy interface Error")
+		}
+		if _, ok := interface{}(val).(error); !ok {
+			logrus.Fatal("*ErrResourceInUse doesn't satisfy interface error")
+		}
+	}
+
+	{
+		val := ProviderFailureError(nil)
+		if _, ok := interface{}(val).(Error); !ok {
+			logrus.Fatal("*ErrProviderFailure doesn't satisfy interface Error")
+		}
+		if _, ok := interface{}(val).(error); !ok {
+			logrus.Fatal("*ErrProviderFailure doesn't satisfy interface error")
+		}
+	}
+}
+
+func TestResourceInUseBlockers(t *testing.T) {
+	xerr := ResourceInUseError([]string{"volume 'data'", "volume 'logs'"}, "Host 'gw' has 2 volumes attached")
+	assert.EqualValues(t, []string{"volume 'data'", "volume 'logs'"}, xerr.Blockers())
+	assert.Equal(t, codes.FailedPrecondition, xerr.GRPCCode())
+	assert.Contains(t, xerr.Error(), "volume 'data', volume 'logs'")
+
+	// the type, and so the blockers and the gRPC code, survive a Wrap
+	wrapped := Wrap(xerr, "cannot delete host")
+	casted, ok := wrapped.(*ErrResourceInUse)
+	if !ok {
+		t.Fatalf("expected '*ErrResourceInUse', got '%s'", reflect.TypeOf(wrapped).String())
+	}
+	assert.Len(t, casted.Blockers(), 2)
+	assert.Equal(t, codes.FailedPrecondition, casted.GRPCCode())
+
+	assert.Equal(t, codes.Internal, ProviderFailureError(fmt.Errorf("quota exceeded"), "provider rejected the deletion").GRPCCode())
 }
 
 func lazyDevs() error {
@@ -590,3 +629,17 @@ func TestNotUncategorizedError(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestResourceInUseFromGRPCStatus(t *testing.T) {
+	st := ResourceInUseError([]string{"Volume 'data'", "Volume 'logs'"}, "Host 'gw' has 2 volumes attached").ToGRPCStatus()
+	xerr := FromGRPCStatus(st)
+	casted, ok := xerr.(*ErrResourceInUse)
+	if !ok {
+		t.Fatalf("expected '*ErrResourceInUse', got '%s'", reflect.TypeOf(xerr).String())
+	}
+	assert.EqualValues(t, []string{"Volume 'data'", "Volume 'logs'"}, casted.Blockers())
+
+	// FailedPrecondition without blockers is still an invalid parameter
+	_, ok = FromGRPCStatus(InvalidParameterError("in", "cannot be nil").ToGRPCStatus()).(*ErrInvalidParameter)
+	assert.True(t, ok)
+}
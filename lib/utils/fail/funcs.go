@@ -17,9 +17,12 @@
 package fail
 
 import (
+	"strings"
+
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/CS-SI/SafeScale/lib/utils/strprocess"
 )
@@ -97,6 +100,9 @@ func FromGRPCStatus(err error) Error {
 	case codes.Aborted:
 		return &ErrAborted{common}
 	case codes.FailedPrecondition:
+		if blockers := blockersFromGRPCStatus(err); len(blockers) > 0 {
+			return &ErrResourceInUse{errorCore: common, blockers: blockers}
+		}
 		return &ErrInvalidParameter{common}
 	case codes.AlreadyExists:
 		return &ErrDuplicate{common}
@@ -122,6 +128,20 @@ func FromGRPCStatus(err error) Error {
 	return common
 }
 
+// blockersFromGRPCStatus returns the blockers of an ErrResourceInUse attached as details to the GRPC status
+func blockersFromGRPCStatus(err error) []string {
+	for _, v := range grpcstatus.Convert(err).Details() {
+		details, ok := v.(*structpb.Struct)
+		if !ok {
+			continue
+		}
+		if field, ok := details.GetFields()["blockers"]; ok && field.GetStringValue() != "" {
+			return strings.Split(field.GetStringValue(), ", ")
+		}
+	}
+	return nil
+}
+
 // ToGRPCStatus translates an error to a GRPC status
 func ToGRPCStatus(err error) error {
 	if casted, ok := err.(Error); ok {
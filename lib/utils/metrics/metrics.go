@@ -0,0 +1,166 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+)
+
+// Kinds of measured operations
+const (
+	KindOperation         = "operation"          // operation on a resource, like the creation of a Host
+	KindProvisioningPhase = "provisioning_phase" // phase of the provisioning of a Host
+	KindProviderCall      = "provider_call"      // call to the API of the provider
+)
+
+// Observation is the timing and the outcome of a measured operation
+type Observation struct {
+	Kind      string // kind of the operation (see KindOperation, KindProvisioningPhase and KindProviderCall)
+	Name      string // name of the operation, like "host.create", "netsec" or "CreateHost"
+	Provider  string // name of the provider of the tenant
+	Success   bool
+	ErrorKind string // kind of the error on failure, like "timeout" or "not_found" (see ErrorKind)
+	Duration  time.Duration
+}
+
+// Recorder receives the observations, to export them to a monitoring backend
+type Recorder interface {
+	Observe(Observation)
+}
+
+// recorder is the Recorder in use
+var recorder = struct {
+	sync.RWMutex
+	instance Recorder
+}{}
+
+// SetRecorder sets the recorder of observations; nil disables the measures
+func SetRecorder(r Recorder) {
+	recorder.Lock()
+	defer recorder.Unlock()
+
+	recorder.instance = r
+}
+
+func currentRecorder() Recorder {
+	recorder.RLock()
+	defer recorder.RUnlock()
+
+	return recorder.instance
+}
+
+// Measure starts measuring the operation 'name' of kind 'kind' on the provider 'provider'
+// Returns the function to call with the outcome of the operation, meant to be deferred with the address of the named
+// error returned by the caller.
+// Does nothing if no recorder is set.
+func Measure(kind, name, provider string) func(xerr *fail.Error) {
+	r := currentRecorder()
+	if r == nil {
+		return func(*fail.Error) {}
+	}
+
+	start := time.Now()
+	return func(xerr *fail.Error) {
+		observation := Observation{
+			Kind:     kind,
+			Name:     name,
+			Provider: provider,
+			Success:  true,
+			Duration: time.Since(start),
+		}
+		if xerr != nil && *xerr != nil {
+			observation.Success = false
+			observation.ErrorKind = ErrorKind(*xerr)
+		}
+		r.Observe(observation)
+	}
+}
+
+// Sequence measures consecutive phases of an operation, each phase ending when the next one begins
+type Sequence struct {
+	kind     string
+	provider string
+	current  func(*fail.Error)
+}
+
+// NewSequence returns a Sequence of phases of kind 'kind' on the provider 'provider'
+func NewSequence(kind, provider string) *Sequence {
+	return &Sequence{kind: kind, provider: provider}
+}
+
+// Begin ends successfully the current phase, if any, and starts measuring the phase 'name'
+func (s *Sequence) Begin(name string) {
+	s.End(nil)
+	s.current = Measure(s.kind, name, s.provider)
+}
+
+// End ends the current phase, if any, with the outcome 'xerr' (nil meaning success), meant to be deferred
+func (s *Sequence) End(xerr *fail.Error) {
+	if s.current != nil {
+		s.current(xerr)
+		s.current = nil
+	}
+}
+
+// failPkgPath is the import path of package fail, whose exported error types give the kinds of errors
+var failPkgPath = reflect.TypeOf(fail.ErrNotFound{}).PkgPath()
+
+// ErrorKind returns the kind of 'err' in snake case, derived from its type (for example "not_found" for a
+// *fail.ErrNotFound, "error_list" for a *fail.ErrorList); returns "other" for errors not typed by package fail, and an
+// empty string for nil
+func ErrorKind(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	t := reflect.TypeOf(err)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	name := t.Name()
+	if t.PkgPath() != failPkgPath || name == "" || !unicode.IsUpper(rune(name[0])) {
+		return "other"
+	}
+	if len(name) > len("Err") && strings.HasPrefix(name, "Err") && unicode.IsUpper(rune(name[len("Err")])) {
+		name = name[len("Err"):]
+	}
+
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// prometheusListen returns the address where to serve the metrics for Prometheus, given by the environment variable
+// SAFESCALE_METRICS_LISTEN; empty if the export to Prometheus is not wanted
+func prometheusListen() string {
+	return strings.TrimSpace(os.Getenv("SAFESCALE_METRICS_LISTEN"))
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CS-SI/SafeScale/lib/utils/fail"
+)
+
+type fakeRecorder struct {
+	observations []Observation
+}
+
+func (r *fakeRecorder) Observe(o Observation) {
+	r.observations = append(r.observations, o)
+}
+
+func TestErrorKind(t *testing.T) {
+	assert.Equal(t, "", ErrorKind(nil))
+	assert.Equal(t, "not_found", ErrorKind(fail.NotFoundError("not found")))
+	assert.Equal(t, "timeout", ErrorKind(fail.TimeoutError(nil, 0, "too long")))
+	assert.Equal(t, "invalid_instance_content", ErrorKind(fail.InvalidInstanceContentError("field", "value")))
+	assert.Equal(t, "error_list", ErrorKind(fail.NewErrorList([]error{errors.New("boom")})))
+	assert.Equal(t, "other", ErrorKind(errors.New("boom")))
+}
+
+func TestMeasure(t *testing.T) {
+	// without recorder, nothing is observed
+	SetRecorder(nil)
+	var xerr fail.Error = fail.NotFoundError("not found")
+	Measure(KindOperation, "host.create", "ovh")(&xerr)
+
+	r := &fakeRecorder{}
+	SetRecorder(r)
+	defer SetRecorder(nil)
+
+	var noErr fail.Error
+	Measure(KindOperation, "host.create", "ovh")(&noErr)
+	Measure(KindProviderCall, "DeleteHost", "aws")(&xerr)
+	require.Len(t, r.observations, 2)
+	assert.Equal(t, Observation{Kind: KindOperation, Name: "host.create", Provider: "ovh", Success: true, Duration: r.observations[0].Duration}, r.observations[0])
+	assert.False(t, r.observations[1].Success)
+	assert.Equal(t, "not_found", r.observations[1].ErrorKind)
+	assert.Equal(t, "aws", r.observations[1].Provider)
+}
+
+func TestSequence(t *testing.T) {
+	r := &fakeRecorder{}
+	SetRecorder(r)
+	defer SetRecorder(nil)
+
+	var xerr fail.Error = fail.TimeoutError(nil, 0, "too long")
+	s := NewSequence(KindProvisioningPhase, "gcp")
+	s.Begin("netsec")
+	s.Begin("sysfix")
+	s.End(&xerr)
+	s.End(nil) // no phase running anymore, does nothing
+
+	require.Len(t, r.observations, 2)
+	assert.Equal(t, "netsec", r.observations[0].Name)
+	assert.True(t, r.observations[0].Success)
+	assert.Equal(t, "sysfix", r.observations[1].Name)
+	assert.False(t, r.observations[1].Success)
+	assert.Equal(t, "timeout", r.observations[1].ErrorKind)
+}
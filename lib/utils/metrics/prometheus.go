@@ -0,0 +1,109 @@
+// +build prometheus
+
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+// Adapter exporting the observations to Prometheus, enabled by the build tag 'prometheus' (BUILD_TAGS=prometheus make all);
+// this needs the module github.com/prometheus/client_golang
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+// prometheusRecorder is a Recorder exporting the observations as Prometheus metrics:
+//   - safescale_operation_duration_seconds (histogram), by kind, name, provider and outcome
+//   - safescale_operations_total (counter), by kind, name, provider, outcome and error kind, giving the failure rates
+type prometheusRecorder struct {
+	durations *prometheus.HistogramVec
+	total     *prometheus.CounterVec
+}
+
+// NewPrometheusRecorder returns a Recorder exporting the observations as Prometheus metrics registered in 'registerer'
+func NewPrometheusRecorder(registerer prometheus.Registerer) (Recorder, error) {
+	r := &prometheusRecorder{
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "safescale",
+			Name:      "operation_duration_seconds",
+			Help:      "Duration of the operations on resources, of the provisioning phases of Hosts and of the provider calls",
+			Buckets:   []float64{0.1, 0.5, 1, 5, 15, 30, 60, 120, 300, 600, 1200, 1800, 3600},
+		}, []string{"kind", "name", "provider", "outcome"}),
+		total: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "safescale",
+			Name:      "operations_total",
+			Help:      "Number of operations on resources, of provisioning phases of Hosts and of provider calls, by outcome",
+		}, []string{"kind", "name", "provider", "outcome", "error_kind"}),
+	}
+	if err := registerer.Register(r.durations); err != nil {
+		return nil, err
+	}
+	if err := registerer.Register(r.total); err != nil {
+		registerer.Unregister(r.durations)
+		return nil, err
+	}
+	return r, nil
+}
+
+// Observe updates the metrics with the observation
+func (r *prometheusRecorder) Observe(o Observation) {
+	outcome := "success"
+	if !o.Success {
+		outcome = "failure"
+	}
+	r.durations.WithLabelValues(o.Kind, o.Name, o.Provider, outcome).Observe(o.Duration.Seconds())
+	r.total.WithLabelValues(o.Kind, o.Name, o.Provider, outcome, o.ErrorKind).Inc()
+}
+
+// SetupPrometheus exports the observations to Prometheus if the environment variable SAFESCALE_METRICS_LISTEN gives the
+// address (like ":9101") where to serve the metrics, on path /metrics
+// Returns the function to call before exiting, to stop serving the metrics
+func SetupPrometheus() (shutdown func(), err error) {
+	shutdown = func() {}
+	listen := prometheusListen()
+	if listen == "" {
+		return shutdown, nil
+	}
+
+	registry := prometheus.NewRegistry()
+	recorder, err := NewPrometheusRecorder(registry)
+	if err != nil {
+		return shutdown, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	server := &http.Server{Addr: listen, Handler: mux}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logrus.Errorf("failed to serve Prometheus metrics on '%s': %v", listen, err)
+		}
+	}()
+
+	SetRecorder(recorder)
+	return func() {
+		SetRecorder(nil)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(ctx)
+	}, nil
+}
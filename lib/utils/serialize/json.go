@@ -93,10 +93,13 @@ func (x *JSONProperties) Clone() *JSONProperties {
 	defer x.RUnlock()
 
 	newP := &JSONProperties{
-		module: x.module,
+		Properties: make(data.Map, len(x.Properties)),
+		module:     x.module,
 	}
 	for k, v := range x.Properties {
-		newP.Properties[k] = v
+		if p, ok := v.(*jsonProperty); ok {
+			newP.Properties[k] = p.Clone()
+		}
 	}
 	return newP
 }
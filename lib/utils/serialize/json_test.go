@@ -515,3 +515,31 @@ func TestNestedLocks(t *testing.T) {
 
 	assert.Nil(t, xerr)
 }
+
+func TestJSONProperties_Clone(t *testing.T) {
+	PropertyTypeRegistry.Register("clusters", "first", &LikeFeatures{})
+
+	clusters, _ := NewJSONProperties("clusters")
+	err := clusters.Alter("first", func(clonable data.Clonable) fail.Error {
+		clonable.(*LikeFeatures).Installed["Loren"] = "Ipsum"
+		return nil
+	})
+	assert.Nil(t, err)
+
+	cloned := clusters.Clone()
+	assert.NotNil(t, cloned)
+	assert.EqualValues(t, 1, cloned.Count())
+
+	// altering the clone must not change the original
+	err = cloned.Alter("first", func(clonable data.Clonable) fail.Error {
+		clonable.(*LikeFeatures).Installed["Loren"] = "Dolor"
+		return nil
+	})
+	assert.Nil(t, err)
+
+	err = clusters.Inspect("first", func(clonable data.Clonable) fail.Error {
+		assert.Equal(t, "Ipsum", clonable.(*LikeFeatures).Installed["Loren"])
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -74,3 +74,9 @@ func FormatStrings(msg ...interface{}) string {
 	// return fmt.Sprint(msg[0].(string))
 	return msg[0].(string)
 }
+
+// ShellQuote returns value enclosed in single quotes, escaping the single quotes it contains, to be used safely
+// as a single argument in a shell command line
+func ShellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
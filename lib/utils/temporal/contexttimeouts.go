@@ -0,0 +1,168 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package temporal
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// DefaultClusterStateChangeTimeout is the default time to wait for a Cluster to finish a start or a stop already running
+	DefaultClusterStateChangeTimeout = 5 * time.Minute
+
+	// DefaultHostStateChangeTimeout is the default time to wait for a Host to reach the requested state on Start/Stop
+	DefaultHostStateChangeTimeout = 5 * time.Minute
+
+	// DefaultHostDeletionTimeout is the default time allowed to the provider to accept the deletion of a Host
+	DefaultHostDeletionTimeout = 5 * time.Minute
+
+	// DefaultHostDeletionConfirmationTimeout is the default time to wait for the effective deletion of a Host by the provider
+	DefaultHostDeletionConfirmationTimeout = 2 * time.Minute
+
+	// DefaultVolumeAttachmentTimeout is the default time to wait for an attached Volume to be seen by the Host
+	DefaultVolumeAttachmentTimeout = 2 * time.Minute
+
+	// DefaultClusterHostsDeletionTimeout is the default time to wait for the deletion of the Hosts of a Cluster
+	DefaultClusterHostsDeletionTimeout = 15 * time.Minute
+
+	// DefaultProviderCallTimeout is the default time allowed to a single inspection call to the provider API
+	DefaultProviderCallTimeout = 2 * time.Minute
+
+	// DefaultHostRebootStartTimeout is the default time to wait for a Host to become unreachable after a reboot request
+	DefaultHostRebootStartTimeout = 2 * time.Minute
+
+	// DefaultHostRebootReturnTimeout is the default time to wait for a rebooting Host to be reachable again
+	DefaultHostRebootReturnTimeout = HostTimeout
+
+	// DefaultTenantSnapshotTimeout is the default time allowed to browse the metadata of a tenant to take its snapshot
+	DefaultTenantSnapshotTimeout = 5 * time.Minute
+
+	// DefaultClusterDiagnosticsTimeout is the default time allowed to collect the diagnostics of the Hosts of a Cluster
+	DefaultClusterDiagnosticsTimeout = 15 * time.Minute
+)
+
+// Timeouts contains overrides of the timeouts used by operations; a zero value means the default timeout is used
+//
+// The operations honoring these timeouts are:
+// - ClusterStateChange: Cluster.Start() and Cluster.Stop(), when waiting for a start or a stop already running
+// - HostStateChange: Host.Start() and Host.Stop(), when waiting for the Host to reach the requested state
+// - HostDeletion: Host.Delete(), when requesting the deletion of the Host to the provider
+// - HostDeletionConfirmation: Host.Delete(), when waiting for the effective deletion of the Host
+// - VolumeAttachment: Volume.Attach(), when waiting for the new device to be seen by the Host
+// - ClusterHostsDeletion: Cluster.Delete(), when waiting for the deletion of the Hosts of the Cluster
+// - ProviderCall: single calls to the provider API inspecting a Host (InspectHost, GetHostState)
+// - HostRebootStart: Host creation, when waiting for the Host to become unreachable after a reboot request
+// - HostRebootReturn: Host creation, when waiting for a rebooting Host to be reachable again
+// - TenantSnapshot: Snapshot(), when browsing the metadata of the resources of the tenant
+// - ClusterDiagnostics: Cluster.CollectDiagnostics(), when collecting the diagnostics of the masters and nodes
+type Timeouts struct {
+	ClusterStateChange       time.Duration
+	HostStateChange          time.Duration
+	HostDeletion             time.Duration
+	HostDeletionConfirmation time.Duration
+	VolumeAttachment         time.Duration
+	ClusterHostsDeletion     time.Duration
+	ProviderCall             time.Duration
+	HostRebootStart          time.Duration
+	HostRebootReturn         time.Duration
+	TenantSnapshot           time.Duration
+	ClusterDiagnostics       time.Duration
+}
+
+type timeoutsContextKey struct{}
+
+// WithTimeouts returns a copy of ctx carrying the timeout overrides 'timeouts'
+func WithTimeouts(ctx context.Context, timeouts Timeouts) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, timeoutsContextKey{}, timeouts)
+}
+
+// TimeoutsFromContext returns the timeout overrides stored in ctx (zero value if there is none)
+func TimeoutsFromContext(ctx context.Context) Timeouts {
+	if ctx != nil {
+		if timeouts, ok := ctx.Value(timeoutsContextKey{}).(Timeouts); ok {
+			return timeouts
+		}
+	}
+	return Timeouts{}
+}
+
+// overrideOrDefault returns override if set, defaultValue otherwise
+func overrideOrDefault(override, defaultValue time.Duration) time.Duration {
+	if override > 0 {
+		return override
+	}
+	return defaultValue
+}
+
+// GetClusterStateChangeTimeout ...
+func GetClusterStateChangeTimeout(ctx context.Context) time.Duration {
+	return overrideOrDefault(TimeoutsFromContext(ctx).ClusterStateChange, GetTimeoutFromEnv("SAFESCALE_CLUSTER_STATE_CHANGE_TIMEOUT", DefaultClusterStateChangeTimeout))
+}
+
+// GetHostStateChangeTimeout ...
+func GetHostStateChangeTimeout(ctx context.Context) time.Duration {
+	return overrideOrDefault(TimeoutsFromContext(ctx).HostStateChange, GetTimeoutFromEnv("SAFESCALE_HOST_STATE_CHANGE_TIMEOUT", DefaultHostStateChangeTimeout))
+}
+
+// GetHostDeletionTimeout ...
+func GetHostDeletionTimeout(ctx context.Context) time.Duration {
+	return overrideOrDefault(TimeoutsFromContext(ctx).HostDeletion, GetTimeoutFromEnv("SAFESCALE_HOST_DELETION_TIMEOUT", DefaultHostDeletionTimeout))
+}
+
+// GetHostDeletionConfirmationTimeout ...
+func GetHostDeletionConfirmationTimeout(ctx context.Context) time.Duration {
+	return overrideOrDefault(TimeoutsFromContext(ctx).HostDeletionConfirmation, GetTimeoutFromEnv("SAFESCALE_HOST_DELETION_CONFIRMATION_TIMEOUT", DefaultHostDeletionConfirmationTimeout))
+}
+
+// GetVolumeAttachmentTimeout ...
+func GetVolumeAttachmentTimeout(ctx context.Context) time.Duration {
+	return overrideOrDefault(TimeoutsFromContext(ctx).VolumeAttachment, GetTimeoutFromEnv("SAFESCALE_VOLUME_ATTACHMENT_TIMEOUT", DefaultVolumeAttachmentTimeout))
+}
+
+// GetClusterHostsDeletionTimeout ...
+func GetClusterHostsDeletionTimeout(ctx context.Context) time.Duration {
+	return overrideOrDefault(TimeoutsFromContext(ctx).ClusterHostsDeletion, GetTimeoutFromEnv("SAFESCALE_CLUSTER_HOSTS_DELETION_TIMEOUT", DefaultClusterHostsDeletionTimeout))
+}
+
+// GetProviderCallTimeout returns the time allowed to a single inspection call to the provider API
+func GetProviderCallTimeout(ctx context.Context) time.Duration {
+	return overrideOrDefault(TimeoutsFromContext(ctx).ProviderCall, GetTimeoutFromEnv("SAFESCALE_PROVIDER_CALL_TIMEOUT", DefaultProviderCallTimeout))
+}
+
+// GetHostRebootStartTimeout returns the time to wait for a Host to become unreachable after a reboot request
+func GetHostRebootStartTimeout(ctx context.Context) time.Duration {
+	return overrideOrDefault(TimeoutsFromContext(ctx).HostRebootStart, GetTimeoutFromEnv("SAFESCALE_HOST_REBOOT_START_TIMEOUT", DefaultHostRebootStartTimeout))
+}
+
+// GetHostRebootReturnTimeout returns the time to wait for a rebooting Host to be reachable again
+func GetHostRebootReturnTimeout(ctx context.Context) time.Duration {
+	return overrideOrDefault(TimeoutsFromContext(ctx).HostRebootReturn, GetTimeoutFromEnv("SAFESCALE_HOST_REBOOT_RETURN_TIMEOUT", DefaultHostRebootReturnTimeout))
+}
+
+// GetTenantSnapshotTimeout returns the time allowed to browse the metadata of a tenant to take its snapshot
+func GetTenantSnapshotTimeout(ctx context.Context) time.Duration {
+	return overrideOrDefault(TimeoutsFromContext(ctx).TenantSnapshot, GetTimeoutFromEnv("SAFESCALE_TENANT_SNAPSHOT_TIMEOUT", DefaultTenantSnapshotTimeout))
+}
+
+// GetClusterDiagnosticsTimeout returns the time allowed to collect the diagnostics of the Hosts of a Cluster
+func GetClusterDiagnosticsTimeout(ctx context.Context) time.Duration {
+	return overrideOrDefault(TimeoutsFromContext(ctx).ClusterDiagnostics, GetTimeoutFromEnv("SAFESCALE_CLUSTER_DIAGNOSTICS_TIMEOUT", DefaultClusterDiagnosticsTimeout))
+}
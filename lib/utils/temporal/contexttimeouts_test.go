@@ -0,0 +1,58 @@
+/*
+ * Copyright 2018-2021, CS Systemes d'Information, http://csgroup.eu
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package temporal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTimeouts(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, DefaultHostStateChangeTimeout, GetHostStateChangeTimeout(ctx))
+	assert.Equal(t, DefaultHostDeletionTimeout, GetHostDeletionTimeout(nil)) // nolint
+
+	ctx = WithTimeouts(ctx, Timeouts{HostStateChange: 10 * time.Second})
+	assert.Equal(t, 10*time.Second, GetHostStateChangeTimeout(ctx))
+	assert.Equal(t, DefaultClusterStateChangeTimeout, GetClusterStateChangeTimeout(ctx))
+	assert.Equal(t, DefaultHostDeletionConfirmationTimeout, GetHostDeletionConfirmationTimeout(ctx))
+	assert.Equal(t, DefaultVolumeAttachmentTimeout, GetVolumeAttachmentTimeout(ctx))
+	assert.Equal(t, DefaultClusterHostsDeletionTimeout, GetClusterHostsDeletionTimeout(ctx))
+
+	ctx = WithTimeouts(ctx, Timeouts{ClusterHostsDeletion: time.Minute})
+	assert.Equal(t, time.Minute, GetClusterHostsDeletionTimeout(ctx))
+	assert.Equal(t, DefaultProviderCallTimeout, GetProviderCallTimeout(ctx))
+
+	ctx = WithTimeouts(ctx, Timeouts{ProviderCall: 30 * time.Second})
+	assert.Equal(t, 30*time.Second, GetProviderCallTimeout(ctx))
+	assert.Equal(t, DefaultHostRebootStartTimeout, GetHostRebootStartTimeout(ctx))
+
+	ctx = WithTimeouts(ctx, Timeouts{HostRebootStart: time.Minute, HostRebootReturn: 3 * time.Minute})
+	assert.Equal(t, time.Minute, GetHostRebootStartTimeout(ctx))
+	assert.Equal(t, 3*time.Minute, GetHostRebootReturnTimeout(ctx))
+	assert.Equal(t, DefaultTenantSnapshotTimeout, GetTenantSnapshotTimeout(ctx))
+
+	ctx = WithTimeouts(ctx, Timeouts{TenantSnapshot: time.Minute})
+	assert.Equal(t, time.Minute, GetTenantSnapshotTimeout(ctx))
+	assert.Equal(t, DefaultClusterDiagnosticsTimeout, GetClusterDiagnosticsTimeout(ctx))
+
+	ctx = WithTimeouts(ctx, Timeouts{ClusterDiagnostics: 30 * time.Minute})
+	assert.Equal(t, 30*time.Minute, GetClusterDiagnosticsTimeout(ctx))
+}
@@ -64,6 +64,24 @@ const (
 
 	// BigDelay is a big delay
 	BigDelay = 30 * time.Second
+
+	// DefaultClockSkewThreshold is the default maximum difference tolerated between the clock of a Host and the one of the daemon
+	DefaultClockSkewThreshold = 30 * time.Second
+
+	// DefaultReservationTimeout is the default lease of a reservation of cache entry; a reservation not refreshed
+	// during this time is considered as stale
+	DefaultReservationTimeout = 5 * time.Minute
+
+	// DefaultSingleHostCIDRReservationTimeout is the default delay after which a CIDR reserved for a single Host and not
+	// committed is considered as leaked (the daemon probably crashed during the creation of the Subnet)
+	DefaultSingleHostCIDRReservationTimeout = 30 * time.Minute
+
+	// DefaultFeatureTimeout is the default maximum duration of an action (check, add, remove) on a Feature, all steps included
+	DefaultFeatureTimeout = 1 * time.Hour
+
+	// DefaultHostReadLockTimeout is the default maximum wait of a read-only operation on a Host for the lock held by
+	// another operation
+	DefaultHostReadLockTimeout = 10 * time.Second
 )
 
 // GetTimeoutFromEnv reads a environment variable 'string', interprets the variable as a time.Duration if possible and returns the time to the caller
@@ -159,7 +177,32 @@ func GetMetadataReadAfterWriteTimeout() time.Duration {
 	return GetTimeoutFromEnv("SAFESCALE_METADATA_READ_AFTER_WRITE_TIMEOUT", DefaultMetadataReadAfterWriteTimeout)
 }
 
+// GetClockSkewThreshold returns the maximum difference tolerated between the clock of a Host and the one of the daemon
+func GetClockSkewThreshold() time.Duration {
+	return GetTimeoutFromEnv("SAFESCALE_CLOCK_SKEW_THRESHOLD", DefaultClockSkewThreshold)
+}
+
 // GetLongOperationTimeout ...
 func GetLongOperationTimeout() time.Duration {
 	return GetTimeoutFromEnv("SAFESCALE_HOST_LONG_OPERATION_TIMEOUT", LongHostOperationTimeout)
 }
+
+// GetReservationTimeout returns the lease of a reservation of cache entry
+func GetReservationTimeout() time.Duration {
+	return GetTimeoutFromEnv("SAFESCALE_RESERVATION_TIMEOUT", DefaultReservationTimeout)
+}
+
+// GetSingleHostCIDRReservationTimeout returns the delay after which a CIDR reserved for a single Host and not committed is reclaimed
+func GetSingleHostCIDRReservationTimeout() time.Duration {
+	return GetTimeoutFromEnv("SAFESCALE_SINGLE_HOST_CIDR_RESERVATION_TIMEOUT", DefaultSingleHostCIDRReservationTimeout)
+}
+
+// GetFeatureTimeout returns the default maximum duration of an action on a Feature
+func GetFeatureTimeout() time.Duration {
+	return GetTimeoutFromEnv("SAFESCALE_FEATURE_TIMEOUT", DefaultFeatureTimeout)
+}
+
+// GetHostReadLockTimeout returns the maximum wait of a read-only operation on a Host for the lock held by another operation
+func GetHostReadLockTimeout() time.Duration {
+	return GetTimeoutFromEnv("SAFESCALE_HOST_READ_LOCK_TIMEOUT", DefaultHostReadLockTimeout)
+}